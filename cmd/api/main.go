@@ -12,6 +12,7 @@ import (
 
 	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
 	"github.com/camelhr/camelhr-api/internal/web"
 	"github.com/camelhr/log"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -33,6 +34,12 @@ func main() {
 	log.Debug("debug logging enabled") // printed only if log level is set to debug
 	log.Info("config loaded successfully")
 
+	// load the keys used to sign and verify the access tokens
+	jwtKeys, err := auth.NewKeySet(configs)
+	if err != nil {
+		log.Fatal("failed to load jwt keys: %v", err)
+	}
+
 	// connect to the database, set configurations and check if the connection is successful
 	db, err := connectToDatabase(configs)
 	if err != nil {
@@ -52,7 +59,7 @@ func main() {
 	defer redisClient.Close()
 
 	// setup routes and start the server
-	handler := web.SetupRoutes(pgDB, redisClient, configs)
+	handler := web.SetupRoutes(pgDB, redisClient, configs, jwtKeys)
 	server := &http.Server{
 		Addr:              configs.HTTPAddress,
 		Handler:           handler,
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/log"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// orgpurger hard deletes the organizations whose restore grace period has ended.
+// It purges up to the configured batch size of organizations per run and is meant to be run periodically
+// e.g. as a cron job. The organizations left over are purged in the next runs.
+func main() {
+	configs := config.LoadConfig()
+	log.InitGlobalLogger("orgpurger", configs.LogLevel)
+
+	if err := purge(configs); err != nil {
+		log.Fatal("%v", err)
+	}
+}
+
+func purge(c config.Config) error {
+	db, err := connectToDatabase(c)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	// the sessions and the cached status of an organization are cleared once it is deleted
+	// so the purge does not need the session manager and the status cache
+	orgService := organization.NewService(c, organization.NewRepository(database.NewPostgresDatabase(db)), nil, nil)
+
+	deletedBefore := time.Now().UTC().Add(-organization.RestoreGracePeriod(c))
+
+	purged, err := orgService.PurgeOrganizations(context.Background(), deletedBefore, c.OrgPurgeBatchSize)
+	log.Info("purged %d organizations deleted before %s", purged, deletedBefore.Format(time.RFC3339))
+
+	return err
+}
+
+func connectToDatabase(c config.Config) (*sqlx.DB, error) {
+	db, err := sqlx.Open("pgx", c.DBConn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(c.DBMaxOpen)
+	db.SetMaxIdleConns(c.DBMaxIdle)
+	db.SetConnMaxIdleTime(time.Duration(c.DBMaxIdleConnTime) * time.Minute)
+	db.SetConnMaxLifetime(0)
+
+	if err = db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,28 @@
+package base
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const randomTokenLength = 32
+
+// GenerateRandomToken generates a cryptographically secure random token.
+// The token is url safe and can be sent in emails as part of a link.
+func GenerateRandomToken() (string, error) {
+	bytes := make([]byte, randomTokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken returns the hex encoded sha256 hash of the given token.
+// Use it to store the tokens in the database instead of the plain text values.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -3,17 +3,27 @@ package config
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"log" //nolint:depguard // since functions defined here are called before the structured logger is initialized
+	"math"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	AppSecret string `mapstructure:"app_secret"`
+	AppURL    string `mapstructure:"app_url"`
+
+	BaseDomain string `mapstructure:"base_domain"`
+
+	JWTSigningKey       string `mapstructure:"jwt_signing_key"`
+	JWTVerificationKeys string `mapstructure:"jwt_verification_keys"`
 
 	LogLevel string `mapstructure:"log_level"`
 
-	HTTPAddress string `mapstructure:"http_address"`
+	HTTPAddress    string `mapstructure:"http_address"`
+	TrustedProxies string `mapstructure:"trusted_proxies"`
 
 	DBConn            string `mapstructure:"db_conn"`
 	DBMaxOpen         int    `mapstructure:"db_max_open"`
@@ -21,12 +31,52 @@ type Config struct {
 	DBMaxIdleConnTime int    `mapstructure:"db_max_idle_conn_time"`
 
 	RedisConn string `mapstructure:"redis_conn"`
+
+	SMTPAddress  string `mapstructure:"smtp_address"`
+	SMTPUsername string `mapstructure:"smtp_username"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	MailFrom     string `mapstructure:"mail_from"`
+
+	LoginMaxFailedAttempts    int `mapstructure:"login_max_failed_attempts"`
+	LoginIPMaxFailedAttempts  int `mapstructure:"login_ip_max_failed_attempts"`
+	LoginFailedAttemptsWindow int `mapstructure:"login_failed_attempts_window"`
+	LoginLockoutDuration      int `mapstructure:"login_lockout_duration"`
+	LoginMaxLockoutDuration   int `mapstructure:"login_max_lockout_duration"`
+
+	PasswordArgon2Memory      int `mapstructure:"password_argon2_memory"`
+	PasswordArgon2Iterations  int `mapstructure:"password_argon2_iterations"`
+	PasswordArgon2Parallelism int `mapstructure:"password_argon2_parallelism"`
+
+	AdminAPIKeys string `mapstructure:"admin_api_keys"`
+
+	SSOAllowInsecureIssuers bool `mapstructure:"sso_allow_insecure_issuers"`
+
+	OrgRestoreGracePeriod int `mapstructure:"org_restore_grace_period"`
+	OrgPurgeBatchSize     int `mapstructure:"org_purge_batch_size"`
+
+	OrgSubdomainAliasTTL  int    `mapstructure:"org_subdomain_alias_ttl"`
+	OrgReservedSubdomains string `mapstructure:"org_reserved_subdomains"`
 }
 
 const (
 	defaultDBMaxOpen         = 4
 	defaultDBMaxIdle         = 4
 	defaultDBMaxIdleConnTime = 4
+
+	defaultLoginMaxFailedAttempts    = 5
+	defaultLoginIPMaxFailedAttempts  = 50
+	defaultLoginFailedAttemptsWindow = 900  // 15 minutes
+	defaultLoginLockoutDuration      = 60   // 1 minute
+	defaultLoginMaxLockoutDuration   = 3600 // 1 hour
+
+	defaultPasswordArgon2Memory      = 19456 // 19 MiB
+	defaultPasswordArgon2Iterations  = 2
+	defaultPasswordArgon2Parallelism = 1
+
+	defaultOrgRestoreGracePeriod = 30 // 30 days
+	defaultOrgPurgeBatchSize     = 100
+
+	defaultOrgSubdomainAliasTTL = 90 // 90 days
 )
 
 func init() {
@@ -40,6 +90,21 @@ func init() {
 	// it is set to a random value by default. it must be set in the environment variable for production.
 	viper.SetDefault("app_secret", generateDefaultRandomAppSecret())
 
+	// jwt signing key is the pem encoded pkcs8 rsa or ed25519 private key used to sign the access tokens.
+	// jwt verification keys are the pem encoded pkix public keys that are accepted in addition to the signing key.
+	// to rotate the signing key, add the public key of the new key to the verification keys first, then swap the
+	// signing key and keep the public key of the old key in the verification keys until its tokens have expired.
+	// when the signing key is not set, the access tokens are signed using the app secret.
+	viper.SetDefault("jwt_signing_key", "")       // secret value. must be set in the environment.
+	viper.SetDefault("jwt_verification_keys", "") // public keys separated by newline
+
+	// app url is the base url of the web application. it is used to build the links sent in emails.
+	viper.SetDefault("app_url", "https://camelhr.com")
+
+	// base domain is the domain the organizations are served under as {subdomain}.{base_domain}.
+	// the requests to a host other than its subdomains are resolved using the verified custom domains.
+	viper.SetDefault("base_domain", "camelhr.com")
+
 	// logger configs
 	viper.SetDefault("log_level", "info")
 
@@ -48,6 +113,11 @@ func init() {
 	// so it can be accessed via any IP address that the machine has.
 	viper.SetDefault("http_address", "0.0.0.0:8080")
 
+	// trusted proxies are the load balancers and the reverse proxies in front of the server.
+	// the client ip is read from the X-Forwarded-For and the X-Real-IP headers only when the request is made
+	// by a trusted proxy. otherwise, the headers are ignored since any client can set them.
+	viper.SetDefault("trusted_proxies", "") // ips or cidrs separated by comma
+
 	// database configs
 	viper.SetDefault("db_conn", "") // secret value. must be set in the environment.
 	viper.SetDefault("db_max_open", defaultDBMaxOpen)
@@ -57,6 +127,55 @@ func init() {
 	// redis configs
 	viper.SetDefault("redis_conn", "") // secret value. must be set in the environment.
 
+	// mail configs
+	// when smtp address is not set, the emails are written to the log instead of being sent.
+	viper.SetDefault("smtp_address", "") // host:port of the smtp server
+	viper.SetDefault("smtp_username", "")
+	viper.SetDefault("smtp_password", "") // secret value. must be set in the environment.
+	viper.SetDefault("mail_from", "no-reply@camelhr.com")
+
+	// login lockout configs
+	// the account (subdomain + email) and the client ip are locked temporarily after too many failed logins.
+	// the lockout duration is doubled for every further failed attempt up to the max lockout duration.
+	viper.SetDefault("login_max_failed_attempts", defaultLoginMaxFailedAttempts)
+	viper.SetDefault("login_ip_max_failed_attempts", defaultLoginIPMaxFailedAttempts)
+	viper.SetDefault("login_failed_attempts_window", defaultLoginFailedAttemptsWindow) // in seconds
+	viper.SetDefault("login_lockout_duration", defaultLoginLockoutDuration)            // in seconds
+	viper.SetDefault("login_max_lockout_duration", defaultLoginMaxLockoutDuration)     // in seconds
+
+	// password hashing configs
+	// the passwords are hashed using argon2id. the defaults follow the owasp recommendation.
+	// changing the parameters re-hashes the password of each user on their next successful login.
+	viper.SetDefault("password_argon2_memory", defaultPasswordArgon2Memory) // in KiB
+	viper.SetDefault("password_argon2_iterations", defaultPasswordArgon2Iterations)
+	viper.SetDefault("password_argon2_parallelism", defaultPasswordArgon2Parallelism)
+
+	// platform admin configs
+	// admin api keys are the credentials of the platform operators to access the admin api.
+	// each entry is the name of the operator and the sha256 hex digest of the key separated by colon.
+	// the admin api is disabled when no key is set.
+	viper.SetDefault("admin_api_keys", "") // operator:sha256-hex entries separated by comma
+
+	// sso configs
+	// the identity providers are reached only through the https urls of public hosts since they are set by the
+	// organization admins. allowing the insecure issuers lifts the checks. use it for local development only.
+	viper.SetDefault("sso_allow_insecure_issuers", false)
+
+	// organization lifecycle configs
+	// a deleted organization can be restored by its owner or an operator within the restore grace period.
+	// once the grace period has ended, the organization is purged along with its tenant data by the orgpurger
+	// and its subdomain is released. the purge batch size limits the number of organizations purged per run.
+	viper.SetDefault("org_restore_grace_period", defaultOrgRestoreGracePeriod) // in days
+	viper.SetDefault("org_purge_batch_size", defaultOrgPurgeBatchSize)
+
+	// the former subdomain of an organization is kept as an alias once the subdomain is changed.
+	// the requests to the alias are redirected and it can not be used by another organization until it expires.
+	viper.SetDefault("org_subdomain_alias_ttl", defaultOrgSubdomainAliasTTL) // in days
+
+	// the reserved subdomains can not be used by an organization. they are added to the bundled list
+	// of the names used by the platform and the offensive words. the existing organizations are not affected.
+	viper.SetDefault("org_reserved_subdomains", "") // subdomains separated by comma
+
 	// override default values with environment variables.
 	viper.AutomaticEnv()
 }
@@ -70,9 +189,37 @@ func LoadConfig() Config {
 		log.Fatalf("failed to read configs: %v", err)
 	}
 
+	if err := config.Validate(); err != nil {
+		log.Fatalf("invalid configs: %v", err)
+	}
+
 	return config
 }
 
+// Validate returns error when a config is out of the range accepted by the application.
+// The application must not start with such configs since every request using them would fail.
+func (c Config) Validate() error {
+	// argon2 requires at least 8 KiB of memory per lane
+	const minArgon2MemoryPerLane = 8
+
+	var errs []error
+
+	if c.PasswordArgon2Parallelism < 1 || c.PasswordArgon2Parallelism > math.MaxUint8 {
+		errs = append(errs, fmt.Errorf("password_argon2_parallelism must be between 1 and %d", math.MaxUint8))
+	}
+
+	if c.PasswordArgon2Iterations < 1 || int64(c.PasswordArgon2Iterations) > math.MaxUint32 {
+		errs = append(errs, errors.New("password_argon2_iterations must be a positive 32-bit integer"))
+	}
+
+	minMemory := minArgon2MemoryPerLane * max(c.PasswordArgon2Parallelism, 1)
+	if c.PasswordArgon2Memory < minMemory || int64(c.PasswordArgon2Memory) > math.MaxUint32 {
+		errs = append(errs, fmt.Errorf("password_argon2_memory must be a 32-bit integer of at least %d", minMemory))
+	}
+
+	return errors.Join(errs...)
+}
+
 // generateDefaultRandomAppSecret generates a random app secret.
 func generateDefaultRandomAppSecret() string {
 	const length = 32
@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := config.Config{
+		PasswordArgon2Memory:      19456,
+		PasswordArgon2Iterations:  2,
+		PasswordArgon2Parallelism: 1,
+	}
+
+	t.Run("should not return error for the valid configs", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, valid.Validate())
+	})
+
+	for _, tc := range []struct {
+		name   string
+		modify func(c *config.Config)
+		errMsg string
+	}{
+		{
+			name:   "should return error when the argon2 parallelism is zero",
+			modify: func(c *config.Config) { c.PasswordArgon2Parallelism = 0 },
+			errMsg: "password_argon2_parallelism must be between 1 and 255",
+		},
+		{
+			name:   "should return error when the argon2 parallelism does not fit in a byte",
+			modify: func(c *config.Config) { c.PasswordArgon2Parallelism = 256 },
+			errMsg: "password_argon2_parallelism must be between 1 and 255",
+		},
+		{
+			name:   "should return error when the argon2 iterations is zero",
+			modify: func(c *config.Config) { c.PasswordArgon2Iterations = 0 },
+			errMsg: "password_argon2_iterations must be a positive 32-bit integer",
+		},
+		{
+			name:   "should return error when the argon2 memory is zero",
+			modify: func(c *config.Config) { c.PasswordArgon2Memory = 0 },
+			errMsg: "password_argon2_memory must be a 32-bit integer of at least 8",
+		},
+		{
+			name: "should return error when the argon2 memory is less than 8 KiB per lane",
+			modify: func(c *config.Config) {
+				c.PasswordArgon2Memory = 31
+				c.PasswordArgon2Parallelism = 4
+			},
+			errMsg: "password_argon2_memory must be a 32-bit integer of at least 32",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := valid
+			tc.modify(&c)
+
+			err := c.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
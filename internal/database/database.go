@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueViolationCode is the postgres error code of a duplicate key value.
+const uniqueViolationCode = "23505"
+
 // Database is an interface that defines the methods that a database should implement.
 type Database interface {
 	// Exec executes a query. Should be used for write operations.
@@ -36,3 +41,10 @@ func SuppressNoRowsError(err error) error {
 
 	return err
 }
+
+// IsUniqueViolation reports whether the error is caused by a duplicate value of the given unique constraint or index.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == constraint
+}
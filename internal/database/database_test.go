@@ -0,0 +1,37 @@
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Parallel()
+
+	uniqueViolation := &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"}
+
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"should return true for the unique violation of the constraint", uniqueViolation, true},
+		{"should return true for the wrapped unique violation", fmt.Errorf("insert: %w", uniqueViolation), true},
+		{"should return false for the unique violation of another constraint",
+			&pgconn.PgError{Code: "23505", ConstraintName: "idx_users_name"}, false},
+		{"should return false for another postgres error",
+			&pgconn.PgError{Code: "23503", ConstraintName: "idx_users_email"}, false},
+		{"should return false for a non postgres error", assert.AnError, false},
+		{"should return false for nil", nil, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, database.IsUniqueViolation(tc.err, "idx_users_email"))
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// ListOrganizations lists the organizations matching the q and the status query parameters.
+// The limit and the offset query parameters paginate the result.
+func (h *handler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	orgs, total, err := h.service.ListOrganizations(r.Context(), filter)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := ListResponse{
+		Organizations: make([]OrganizationResponse, 0, len(orgs)),
+		Total:         total,
+		Limit:         filter.Limit,
+		Offset:        filter.Offset,
+	}
+
+	if result.Limit == 0 {
+		result.Limit = DefaultLimit
+	}
+
+	for _, org := range orgs {
+		result.Organizations = append(result.Organizations, toOrganizationResponse(org))
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// GetOrganization returns an organization along with the number of its users.
+func (h *handler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.URLParamID(r, "orgID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	org, err := h.service.GetOrganization(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toOrganizationResponse(org))
+}
+
+// SuspendOrganization suspends an organization. All the sessions of the organization are revoked.
+func (h *handler) SuspendOrganization(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, h.service.SuspendOrganization)
+}
+
+// UnsuspendOrganization unsuspends an organization.
+func (h *handler) UnsuspendOrganization(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, h.service.UnsuspendOrganization)
+}
+
+// RestoreOrganization restores a soft deleted organization along with its users.
+func (h *handler) RestoreOrganization(w http.ResponseWriter, r *http.Request) {
+	h.takeAction(w, r, h.service.RestoreOrganization)
+}
+
+// ListAuditLogs lists the actions taken by the operators on an organization.
+func (h *handler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.URLParamID(r, "orgID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	logs, err := h.service.ListAuditLogs(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := make([]AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, AuditLogResponse{
+			ID:        l.ID,
+			Operator:  l.Operator,
+			Action:    l.Action,
+			Comment:   l.Comment,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// Impersonate starts a session of the user of the userID path parameter on behalf of the operator.
+// The access token is returned in the response instead of a cookie and can not be renewed.
+func (h *handler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	operator, err := request.Operator(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+
+		return
+	}
+
+	orgID, err := request.URLParamID(r, "orgID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	userID, err := request.URLParamID(r, "userID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ActionRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result, err := h.service.Impersonate(r.Context(), operator, orgID, userID, reqPayload.Comment,
+		session.NewDevice(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImpersonationNotAllowed):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+		case errors.Is(err, ErrOrgDeleted), errors.Is(err, auth.ErrOrgSuspended), errors.Is(err, auth.ErrUserDisabled):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+		default:
+			response.ErrorResponse(w, err)
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ImpersonationResponse{
+		AccessToken:  result.JWT,
+		ExpiresIn:    int(result.TTL.Seconds()),
+		Impersonated: true,
+		Operator:     operator,
+		UserID:       userID,
+	})
+}
+
+// ListImpersonationLogs lists the requests made by the operators while impersonating the users of an organization.
+func (h *handler) ListImpersonationLogs(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.URLParamID(r, "orgID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	logs, err := h.service.ListImpersonationLogs(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := make([]ImpersonationLogResponse, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, ImpersonationLogResponse{
+			ID:        l.ID,
+			UserID:    l.UserID,
+			Operator:  l.Operator,
+			SessionID: l.SessionID,
+			Method:    l.Method,
+			Path:      l.Path,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// takeAction takes the action on the organization of the orgID path parameter on behalf of the operator.
+func (h *handler) takeAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	actionFn func(ctx context.Context, operator string, orgID int64, comment string) error,
+) {
+	operator, err := request.Operator(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+
+		return
+	}
+
+	orgID, err := request.URLParamID(r, "orgID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ActionRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := actionFn(r.Context(), operator, orgID, reqPayload.Comment); err != nil {
+		if errors.Is(err, ErrOrgDeleted) || errors.Is(err, ErrOrgNotDeleted) ||
+			errors.Is(err, ErrOrgAlreadySuspended) || errors.Is(err, ErrOrgNotSuspended) ||
+			errors.Is(err, ErrRestoreWindowExpired) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// parseListFilter parses the filter and the pagination from the query parameters.
+func parseListFilter(r *http.Request) (ListFilter, error) {
+	query := r.URL.Query()
+	filter := ListFilter{Query: query.Get("q"), Status: query.Get("status")}
+
+	var err error
+
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return ListFilter{}, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil {
+			return ListFilter{}, fmt.Errorf("invalid offset: %w", err)
+		}
+	}
+
+	return filter, nil
+}
+
+func toOrganizationResponse(org Organization) OrganizationResponse {
+	return OrganizationResponse{
+		Response: organization.Response{
+			ID:                   org.ID,
+			Subdomain:            org.Subdomain,
+			Name:                 org.Name,
+			SuspendedAt:          org.SuspendedAt,
+			MFARequired:          org.MFARequired,
+			MagicLinkEnabled:     org.MagicLinkEnabled,
+			ImpersonationAllowed: org.ImpersonationAllowed,
+			Timestamps:           org.Timestamps,
+		},
+		DeletedAt: org.DeletedAt,
+		Comment:   org.Comment,
+		UserCount: org.UserCount,
+	}
+}
@@ -0,0 +1,370 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const organizationsPath = "/api/v1/admin/organizations"
+
+// withOperator sets the operator in the request context as done by the admin auth middleware.
+func withOperator(req *http.Request, operator string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), request.CtxOperatorKey, operator))
+}
+
+func TestHandler_ListOrganizations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the limit is not a number", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, organizationsPath+"?limit=ten", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(admin.NewMockService(t))
+
+		handler.ListOrganizations(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should list the organizations matching the query parameters", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, organizationsPath+"?q=camel&status=suspended&limit=10&offset=20", nil)
+		require.NoError(t, err)
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+		filter := admin.ListFilter{Query: "camel", Status: admin.StatusSuspended, Limit: 10, Offset: 20}
+		org := admin.Organization{
+			Organization: organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", Name: "Camel"},
+			UserCount:    7,
+		}
+
+		mockService.On("ListOrganizations", fake.MockContext, filter).Return([]admin.Organization{org}, int64(21), nil)
+
+		handler.ListOrganizations(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result admin.ListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, int64(21), result.Total)
+		assert.Equal(t, 10, result.Limit)
+		assert.Equal(t, 20, result.Offset)
+		require.Len(t, result.Organizations, 1)
+		assert.Equal(t, org.ID, result.Organizations[0].ID)
+		assert.Equal(t, int64(7), result.Organizations[0].UserCount)
+	})
+}
+
+func TestHandler_GetOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the org id is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, organizationsPath+"/{orgID}", nil)
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "orgID", "invalid")
+
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(admin.NewMockService(t))
+
+		handler.GetOrganization(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestHandler_SuspendOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the operator is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/suspend",
+			strings.NewReader(`{"comment":"violation of terms"}`))
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "orgID", "1")
+
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(admin.NewMockService(t))
+
+		handler.SuspendOrganization(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return bad request when the comment is missing", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/suspend", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		req = withOperator(tests.WithURLParam(req, "orgID", "1"), "john")
+
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(admin.NewMockService(t))
+
+		handler.SuspendOrganization(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return conflict when the organization is already suspended", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/suspend",
+			strings.NewReader(`{"comment":"violation of terms"}`))
+		require.NoError(t, err)
+		req = withOperator(tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10)), "john")
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("SuspendOrganization", fake.MockContext, "john", orgID, "violation of terms").
+			Return(admin.ErrOrgAlreadySuspended)
+
+		handler.SuspendOrganization(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should suspend the organization on behalf of the operator", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/suspend",
+			strings.NewReader(`{"comment":"violation of terms"}`))
+		require.NoError(t, err)
+		req = withOperator(tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10)), "john")
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("SuspendOrganization", fake.MockContext, "john", orgID, "violation of terms").Return(nil)
+
+		handler.SuspendOrganization(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestHandler_RestoreOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return conflict when the organization is not deleted", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/restore",
+			strings.NewReader(`{"comment":"requested by the owner"}`))
+		require.NoError(t, err)
+		req = withOperator(tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10)), "john")
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("RestoreOrganization", fake.MockContext, "john", orgID, "requested by the owner").
+			Return(admin.ErrOrgNotDeleted)
+
+		handler.RestoreOrganization(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return conflict when the restore window of the organization has ended", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/restore",
+			strings.NewReader(`{"comment":"requested by the owner"}`))
+		require.NoError(t, err)
+		req = withOperator(tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10)), "john")
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("RestoreOrganization", fake.MockContext, "john", orgID, "requested by the owner").
+			Return(admin.ErrRestoreWindowExpired)
+
+		handler.RestoreOrganization(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), admin.ErrRestoreWindowExpired.Error())
+	})
+}
+
+func TestHandler_ListAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the audit logs of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, organizationsPath+"/{orgID}/audit-logs", nil)
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10))
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+		logs := []admin.AuditLog{
+			{ID: 2, OrganizationID: orgID, Operator: "john", Action: admin.ActionUnsuspend, Comment: "resolved"},
+			{ID: 1, OrganizationID: orgID, Operator: "jane", Action: admin.ActionSuspend, Comment: "unpaid"},
+		}
+
+		mockService.On("ListAuditLogs", fake.MockContext, orgID).Return(logs, nil)
+
+		handler.ListAuditLogs(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result []admin.AuditLogResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, "john", result[0].Operator)
+		assert.Equal(t, admin.ActionSuspend, result[1].Action)
+	})
+}
+
+func TestHandler_Impersonate(t *testing.T) {
+	t.Parallel()
+
+	// newImpersonateRequest returns a request to impersonate the user of the organization on behalf of john.
+	newImpersonateRequest := func(t *testing.T, orgID, userID int64) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, organizationsPath+"/{orgID}/users/{userID}/impersonate",
+			strings.NewReader(`{"comment":"ticket 1234"}`))
+		require.NoError(t, err)
+
+		req = tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10))
+		req = tests.WithURLParam(req, "userID", strconv.FormatInt(userID, 10))
+
+		return withOperator(req, "john")
+	}
+
+	t.Run("should return forbidden when the organization does not allow the impersonation", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		req := newImpersonateRequest(t, orgID, userID)
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("Impersonate", fake.MockContext, "john", orgID, userID, "ticket 1234", session.Device{}).
+			Return(auth.LoginResult{}, admin.ErrImpersonationNotAllowed)
+
+		handler.Impersonate(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return conflict when the user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		req := newImpersonateRequest(t, orgID, userID)
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("Impersonate", fake.MockContext, "john", orgID, userID, "ticket 1234", session.Device{}).
+			Return(auth.LoginResult{}, auth.ErrUserDisabled)
+
+		handler.Impersonate(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return the access token marked as impersonated", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		req := newImpersonateRequest(t, orgID, userID)
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+
+		mockService.On("Impersonate", fake.MockContext, "john", orgID, userID, "ticket 1234", session.Device{}).
+			Return(auth.LoginResult{JWT: "jwt", TTL: auth.ImpersonationSessionTTL}, nil)
+
+		handler.Impersonate(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Result().Cookies())
+
+		var result admin.ImpersonationResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, "jwt", result.AccessToken)
+		assert.Equal(t, int(auth.ImpersonationSessionTTL.Seconds()), result.ExpiresIn)
+		assert.True(t, result.Impersonated)
+		assert.Equal(t, "john", result.Operator)
+		assert.Equal(t, userID, result.UserID)
+	})
+}
+
+func TestHandler_ListImpersonationLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the impersonation logs of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, organizationsPath+"/{orgID}/impersonation-logs", nil)
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "orgID", strconv.FormatInt(orgID, 10))
+
+		mockService := admin.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := admin.NewHandler(mockService)
+		logs := []admin.ImpersonationLog{
+			{ID: 2, OrganizationID: orgID, UserID: 5, Operator: "john", Method: http.MethodPut, Path: "/me"},
+			{ID: 1, OrganizationID: orgID, UserID: 5, Operator: "john", Method: http.MethodGet, Path: "/me"},
+		}
+
+		mockService.On("ListImpersonationLogs", fake.MockContext, orgID).Return(logs, nil)
+
+		handler.ListImpersonationLogs(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result []admin.ImpersonationLogResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, http.MethodPut, result[0].Method)
+		assert.Equal(t, int64(5), result[1].UserID)
+	})
+}
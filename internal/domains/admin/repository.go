@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// ListOrganizations returns a page of the organizations matching the filter ordered by their id.
+	ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, error)
+
+	// CountOrganizations returns the number of the organizations matching the filter.
+	CountOrganizations(ctx context.Context, filter ListFilter) (int64, error)
+
+	// GetOrganizationByID returns an organization by its id including a soft deleted one.
+	GetOrganizationByID(ctx context.Context, orgID int64) (Organization, error)
+
+	// CreateAuditLog records an action taken by a platform operator on an organization.
+	CreateAuditLog(ctx context.Context, orgID int64, operator, action, comment string) error
+
+	// ListAuditLogs returns the audit logs of an organization starting with the latest.
+	ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error)
+
+	// CreateImpersonationLog records a request made by a platform operator while impersonating a user.
+	// It returns sql.ErrNoRows without recording the request when the organization does not allow the impersonation.
+	CreateImpersonationLog(ctx context.Context, log ImpersonationLog) error
+
+	// ListImpersonationLogs returns the impersonation logs of an organization starting with the latest.
+	ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, error) {
+	var orgs []Organization
+	err := r.db.List(ctx, &orgs, listOrganizationsQuery, filter.Query, filter.Status, filter.Limit, filter.Offset)
+
+	return orgs, err
+}
+
+func (r *repository) CountOrganizations(ctx context.Context, filter ListFilter) (int64, error) {
+	var count int64
+	err := r.db.Get(ctx, &count, countOrganizationsQuery, filter.Query, filter.Status)
+
+	return count, err
+}
+
+func (r *repository) GetOrganizationByID(ctx context.Context, orgID int64) (Organization, error) {
+	var org Organization
+	err := r.db.Get(ctx, &org, getOrganizationByIDQuery, orgID)
+
+	return org, err
+}
+
+func (r *repository) CreateAuditLog(ctx context.Context, orgID int64, operator, action, comment string) error {
+	return r.db.Exec(ctx, nil, createAuditLogQuery, orgID, operator, action, comment)
+}
+
+func (r *repository) ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error) {
+	var logs []AuditLog
+	err := r.db.List(ctx, &logs, listAuditLogsQuery, orgID)
+
+	return logs, err
+}
+
+func (r *repository) CreateImpersonationLog(ctx context.Context, log ImpersonationLog) error {
+	var id int64
+
+	return r.db.Get(ctx, &id, createImpersonationLogQuery,
+		log.OrganizationID, log.UserID, log.Operator, log.SessionID, log.Method, log.Path)
+}
+
+func (r *repository) ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error) {
+	var logs []ImpersonationLog
+	err := r.db.List(ctx, &logs, listImpersonationLogsQuery, orgID)
+
+	return logs, err
+}
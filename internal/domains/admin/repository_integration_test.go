@@ -0,0 +1,165 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *AdminTestSuite) TestRepositoryIntegration_ListOrganizations() {
+	s.Run("should list the organizations matching the query and the status", func() {
+		s.T().Parallel()
+
+		repo := admin.NewRepository(s.DB)
+		name := gofakeit.UUID()
+		active := fake.NewOrganization(s.DB, fake.OrganizationName(name+" active"))
+		suspended := fake.NewOrganization(s.DB, fake.OrganizationName(name+" suspended"), fake.OrganizationSuspended())
+		deleted := fake.NewOrganization(s.DB, fake.OrganizationName(name+" deleted"), fake.OrganizationDeleted())
+		active.AddUser(s.DB)
+		active.AddUser(s.DB)
+		active.AddUser(s.DB, fake.UserDeleted())
+
+		result, err := repo.ListOrganizations(context.Background(), admin.ListFilter{Query: name, Limit: 10})
+		s.Require().NoError(err)
+		s.Require().Len(result, 3)
+		s.Equal(active.ID, result[0].ID)
+		s.Equal(int64(2), result[0].UserCount)
+		s.Equal(suspended.ID, result[1].ID)
+		s.Equal(deleted.ID, result[2].ID)
+
+		for status, orgID := range map[string]int64{
+			admin.StatusActive:    active.ID,
+			admin.StatusSuspended: suspended.ID,
+			admin.StatusDeleted:   deleted.ID,
+		} {
+			filter := admin.ListFilter{Query: name, Status: status, Limit: 10}
+
+			result, err := repo.ListOrganizations(context.Background(), filter)
+			s.Require().NoError(err)
+			s.Require().Len(result, 1)
+			s.Equal(orgID, result[0].ID)
+
+			total, err := repo.CountOrganizations(context.Background(), filter)
+			s.Require().NoError(err)
+			s.Equal(int64(1), total)
+		}
+	})
+
+	s.Run("should paginate the organizations", func() {
+		s.T().Parallel()
+
+		repo := admin.NewRepository(s.DB)
+		name := gofakeit.UUID()
+		fake.NewOrganization(s.DB, fake.OrganizationName(name+" 1"))
+		second := fake.NewOrganization(s.DB, fake.OrganizationName(name+" 2"))
+		fake.NewOrganization(s.DB, fake.OrganizationName(name+" 3"))
+
+		result, err := repo.ListOrganizations(context.Background(), admin.ListFilter{Query: name, Limit: 1, Offset: 1})
+		s.Require().NoError(err)
+		s.Require().Len(result, 1)
+		s.Equal(second.ID, result[0].ID)
+
+		total, err := repo.CountOrganizations(context.Background(), admin.ListFilter{Query: name, Limit: 1, Offset: 1})
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+	})
+}
+
+func (s *AdminTestSuite) TestRepositoryIntegration_GetOrganizationByID() {
+	s.Run("should return a deleted organization", func() {
+		s.T().Parallel()
+
+		repo := admin.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB, fake.OrganizationDeleted())
+
+		result, err := repo.GetOrganizationByID(context.Background(), o.ID)
+		s.Require().NoError(err)
+		s.Equal(o.ID, result.ID)
+		s.NotNil(result.DeletedAt)
+	})
+
+	s.Run("should return error when the organization does not exist", func() {
+		s.T().Parallel()
+
+		repo := admin.NewRepository(s.DB)
+
+		_, err := repo.GetOrganizationByID(context.Background(), gofakeit.Int64())
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *AdminTestSuite) TestRepositoryIntegration_AuditLogs() {
+	s.Run("should list the audit logs of the organization starting with the latest", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := admin.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		other := fake.NewOrganization(s.DB)
+
+		s.Require().NoError(repo.CreateAuditLog(ctx, o.ID, "jane", admin.ActionSuspend, "unpaid"))
+		s.Require().NoError(repo.CreateAuditLog(ctx, o.ID, "john", admin.ActionUnsuspend, "resolved"))
+		s.Require().NoError(repo.CreateAuditLog(ctx, other.ID, "john", admin.ActionSuspend, "unpaid"))
+
+		result, err := repo.ListAuditLogs(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal("john", result[0].Operator)
+		s.Equal(admin.ActionUnsuspend, result[0].Action)
+		s.Equal("resolved", result[0].Comment)
+		s.Equal("jane", result[1].Operator)
+		s.Equal(admin.ActionSuspend, result[1].Action)
+	})
+}
+
+func (s *AdminTestSuite) TestRepositoryIntegration_ImpersonationLogs() {
+	s.Run("should not record the request when the organization does not allow the impersonation", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := admin.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := o.AddUser(s.DB)
+
+		err := repo.CreateImpersonationLog(ctx, admin.ImpersonationLog{
+			OrganizationID: o.ID, UserID: u.ID, Operator: "john", SessionID: gofakeit.UUID(),
+			Method: "GET", Path: "/api/v1/subdomains/" + o.Subdomain + "/me",
+		})
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		result, err := repo.ListImpersonationLogs(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Empty(result)
+	})
+
+	s.Run("should list the impersonation logs of the organization starting with the latest", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := admin.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := o.AddUser(s.DB)
+		sessionID := gofakeit.UUID()
+		s.Require().NoError(organization.NewRepository(s.DB).SetImpersonationAllowed(ctx, o.ID, true))
+
+		for _, method := range []string{"GET", "PUT"} {
+			s.Require().NoError(repo.CreateImpersonationLog(ctx, admin.ImpersonationLog{
+				OrganizationID: o.ID, UserID: u.ID, Operator: "john", SessionID: sessionID,
+				Method: method, Path: "/api/v1/subdomains/" + o.Subdomain + "/me",
+			}))
+		}
+
+		result, err := repo.ListImpersonationLogs(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal("PUT", result[0].Method)
+		s.Equal("GET", result[1].Method)
+		s.Equal(u.ID, result[0].UserID)
+		s.Equal("john", result[0].Operator)
+		s.Equal(sessionID, result[0].SessionID)
+	})
+}
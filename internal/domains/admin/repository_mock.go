@@ -0,0 +1,424 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package admin
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountOrganizations provides a mock function with given fields: ctx, filter
+func (_m *MockRepository) CountOrganizations(ctx context.Context, filter ListFilter) (int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrganizations")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CountOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrganizations'
+type MockRepository_CountOrganizations_Call struct {
+	*mock.Call
+}
+
+// CountOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter ListFilter
+func (_e *MockRepository_Expecter) CountOrganizations(ctx interface{}, filter interface{}) *MockRepository_CountOrganizations_Call {
+	return &MockRepository_CountOrganizations_Call{Call: _e.mock.On("CountOrganizations", ctx, filter)}
+}
+
+func (_c *MockRepository_CountOrganizations_Call) Run(run func(ctx context.Context, filter ListFilter)) *MockRepository_CountOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ListFilter))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountOrganizations_Call) Return(_a0 int64, _a1 error) *MockRepository_CountOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CountOrganizations_Call) RunAndReturn(run func(context.Context, ListFilter) (int64, error)) *MockRepository_CountOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuditLog provides a mock function with given fields: ctx, orgID, operator, action, comment
+func (_m *MockRepository) CreateAuditLog(ctx context.Context, orgID int64, operator string, action string, comment string) error {
+	ret := _m.Called(ctx, orgID, operator, action, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, string) error); ok {
+		r0 = rf(ctx, orgID, operator, action, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditLog'
+type MockRepository_CreateAuditLog_Call struct {
+	*mock.Call
+}
+
+// CreateAuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - operator string
+//   - action string
+//   - comment string
+func (_e *MockRepository_Expecter) CreateAuditLog(ctx interface{}, orgID interface{}, operator interface{}, action interface{}, comment interface{}) *MockRepository_CreateAuditLog_Call {
+	return &MockRepository_CreateAuditLog_Call{Call: _e.mock.On("CreateAuditLog", ctx, orgID, operator, action, comment)}
+}
+
+func (_c *MockRepository_CreateAuditLog_Call) Run(run func(ctx context.Context, orgID int64, operator string, action string, comment string)) *MockRepository_CreateAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateAuditLog_Call) Return(_a0 error) *MockRepository_CreateAuditLog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateAuditLog_Call) RunAndReturn(run func(context.Context, int64, string, string, string) error) *MockRepository_CreateAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateImpersonationLog provides a mock function with given fields: ctx, log
+func (_m *MockRepository) CreateImpersonationLog(ctx context.Context, log ImpersonationLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImpersonationLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ImpersonationLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateImpersonationLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImpersonationLog'
+type MockRepository_CreateImpersonationLog_Call struct {
+	*mock.Call
+}
+
+// CreateImpersonationLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - log ImpersonationLog
+func (_e *MockRepository_Expecter) CreateImpersonationLog(ctx interface{}, log interface{}) *MockRepository_CreateImpersonationLog_Call {
+	return &MockRepository_CreateImpersonationLog_Call{Call: _e.mock.On("CreateImpersonationLog", ctx, log)}
+}
+
+func (_c *MockRepository_CreateImpersonationLog_Call) Run(run func(ctx context.Context, log ImpersonationLog)) *MockRepository_CreateImpersonationLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ImpersonationLog))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateImpersonationLog_Call) Return(_a0 error) *MockRepository_CreateImpersonationLog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateImpersonationLog_Call) RunAndReturn(run func(context.Context, ImpersonationLog) error) *MockRepository_CreateImpersonationLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganizationByID provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetOrganizationByID(ctx context.Context, orgID int64) (Organization, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizationByID")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Organization, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Organization); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetOrganizationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationByID'
+type MockRepository_GetOrganizationByID_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetOrganizationByID(ctx interface{}, orgID interface{}) *MockRepository_GetOrganizationByID_Call {
+	return &MockRepository_GetOrganizationByID_Call{Call: _e.mock.On("GetOrganizationByID", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetOrganizationByID_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetOrganizationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetOrganizationByID_Call) Return(_a0 Organization, _a1 error) *MockRepository_GetOrganizationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetOrganizationByID_Call) RunAndReturn(run func(context.Context, int64) (Organization, error)) *MockRepository_GetOrganizationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditLogs")
+	}
+
+	var r0 []AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]AuditLog, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []AuditLog); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditLogs'
+type MockRepository_ListAuditLogs_Call struct {
+	*mock.Call
+}
+
+// ListAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) ListAuditLogs(ctx interface{}, orgID interface{}) *MockRepository_ListAuditLogs_Call {
+	return &MockRepository_ListAuditLogs_Call{Call: _e.mock.On("ListAuditLogs", ctx, orgID)}
+}
+
+func (_c *MockRepository_ListAuditLogs_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_ListAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAuditLogs_Call) Return(_a0 []AuditLog, _a1 error) *MockRepository_ListAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAuditLogs_Call) RunAndReturn(run func(context.Context, int64) ([]AuditLog, error)) *MockRepository_ListAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListImpersonationLogs provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListImpersonationLogs")
+	}
+
+	var r0 []ImpersonationLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]ImpersonationLog, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []ImpersonationLog); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ImpersonationLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListImpersonationLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListImpersonationLogs'
+type MockRepository_ListImpersonationLogs_Call struct {
+	*mock.Call
+}
+
+// ListImpersonationLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) ListImpersonationLogs(ctx interface{}, orgID interface{}) *MockRepository_ListImpersonationLogs_Call {
+	return &MockRepository_ListImpersonationLogs_Call{Call: _e.mock.On("ListImpersonationLogs", ctx, orgID)}
+}
+
+func (_c *MockRepository_ListImpersonationLogs_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_ListImpersonationLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListImpersonationLogs_Call) Return(_a0 []ImpersonationLog, _a1 error) *MockRepository_ListImpersonationLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListImpersonationLogs_Call) RunAndReturn(run func(context.Context, int64) ([]ImpersonationLog, error)) *MockRepository_ListImpersonationLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizations provides a mock function with given fields: ctx, filter
+func (_m *MockRepository) ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrganizations")
+	}
+
+	var r0 []Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) ([]Organization, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) []Organization); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizations'
+type MockRepository_ListOrganizations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter ListFilter
+func (_e *MockRepository_Expecter) ListOrganizations(ctx interface{}, filter interface{}) *MockRepository_ListOrganizations_Call {
+	return &MockRepository_ListOrganizations_Call{Call: _e.mock.On("ListOrganizations", ctx, filter)}
+}
+
+func (_c *MockRepository_ListOrganizations_Call) Run(run func(ctx context.Context, filter ListFilter)) *MockRepository_ListOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ListFilter))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListOrganizations_Call) Return(_a0 []Organization, _a1 error) *MockRepository_ListOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListOrganizations_Call) RunAndReturn(run func(context.Context, ListFilter) ([]Organization, error)) *MockRepository_ListOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,274 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+)
+
+type Service interface {
+	// ListOrganizations returns a page of the organizations matching the filter along with their total count.
+	// The limit defaults to the DefaultLimit.
+	ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, int64, error)
+
+	// GetOrganization returns an organization by its id including a soft deleted one.
+	GetOrganization(ctx context.Context, orgID int64) (Organization, error)
+
+	// SuspendOrganization suspends an organization and records the action of the operator.
+	SuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error
+
+	// UnsuspendOrganization unsuspends an organization and records the action of the operator.
+	UnsuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error
+
+	// RestoreOrganization restores a soft deleted organization and records the action of the operator.
+	// It returns ErrRestoreWindowExpired when the organization was deleted before the restore grace period.
+	RestoreOrganization(ctx context.Context, operator string, orgID int64, comment string) error
+
+	// ListAuditLogs returns the actions taken by the operators on an organization starting with the latest.
+	ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error)
+
+	// Impersonate starts a short-lived session of the user of the organization for the operator
+	// and records the action. It returns ErrImpersonationNotAllowed unless the organization allows the impersonation.
+	Impersonate(
+		ctx context.Context, operator string, orgID, userID int64, comment string, device session.Device,
+	) (auth.LoginResult, error)
+
+	// LogImpersonatedRequest records a request made with an impersonation session.
+	// It returns ErrImpersonationNotAllowed when the organization no longer allows the impersonation
+	// so that the request can be rejected.
+	LogImpersonatedRequest(ctx context.Context, log ImpersonationLog) error
+
+	// ListImpersonationLogs returns the requests made while impersonating the users of an organization
+	// starting with the latest.
+	ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error)
+}
+
+type service struct {
+	restoreGracePeriod time.Duration
+	repo               Repository
+	transactor         database.Transactor
+	orgService         organization.Service
+	authService        auth.Service
+}
+
+func NewService(
+	conf config.Config, repo Repository, transactor database.Transactor, orgService organization.Service,
+	authService auth.Service,
+) Service {
+	return &service{
+		restoreGracePeriod: organization.RestoreGracePeriod(conf),
+		repo:               repo,
+		transactor:         transactor,
+		orgService:         orgService,
+		authService:        authService,
+	}
+}
+
+var (
+	ErrOrgDeleted          = errors.New("organization is deleted")
+	ErrOrgNotDeleted       = errors.New("organization is not deleted")
+	ErrOrgAlreadySuspended = errors.New("organization is already suspended")
+	ErrOrgNotSuspended     = errors.New("organization is not suspended")
+
+	ErrRestoreWindowExpired = errors.New("organization can no longer be restored")
+
+	ErrImpersonationNotAllowed = errors.New("impersonation is not allowed by the organization")
+)
+
+func (s *service) ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, int64, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultLimit
+	}
+
+	if err := validateFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	orgs, err := s.repo.ListOrganizations(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountOrganizations(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orgs, total, nil
+}
+
+func (s *service) GetOrganization(ctx context.Context, orgID int64) (Organization, error) {
+	org, err := s.repo.GetOrganizationByID(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Organization{}, base.NewNotFoundError("organization not found for the given id")
+	}
+
+	return org, err
+}
+
+func (s *service) SuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	return s.takeAction(ctx, operator, orgID, ActionSuspend, comment, func(org Organization) error {
+		if org.DeletedAt != nil {
+			return ErrOrgDeleted
+		}
+
+		if org.IsSuspended() {
+			return ErrOrgAlreadySuspended
+		}
+
+		return nil
+	}, s.orgService.SuspendOrganization)
+}
+
+func (s *service) UnsuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	return s.takeAction(ctx, operator, orgID, ActionUnsuspend, comment, func(org Organization) error {
+		if org.DeletedAt != nil {
+			return ErrOrgDeleted
+		}
+
+		if !org.IsSuspended() {
+			return ErrOrgNotSuspended
+		}
+
+		return nil
+	}, s.orgService.UnsuspendOrganization)
+}
+
+func (s *service) RestoreOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	return s.takeAction(ctx, operator, orgID, ActionRestore, comment, func(org Organization) error {
+		if org.DeletedAt == nil {
+			return ErrOrgNotDeleted
+		}
+
+		if !org.IsRestorable(s.restoreGracePeriod) {
+			return ErrRestoreWindowExpired
+		}
+
+		return nil
+	}, s.orgService.RestoreOrganization)
+}
+
+func (s *service) ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error) {
+	if _, err := s.GetOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAuditLogs(ctx, orgID)
+}
+
+func (s *service) Impersonate(
+	ctx context.Context, operator string, orgID, userID int64, comment string, device session.Device,
+) (auth.LoginResult, error) {
+	if err := organization.ValidateComment(comment); err != nil {
+		return auth.LoginResult{}, err
+	}
+
+	org, err := s.GetOrganization(ctx, orgID)
+	if err != nil {
+		return auth.LoginResult{}, err
+	}
+
+	if org.DeletedAt != nil {
+		return auth.LoginResult{}, ErrOrgDeleted
+	}
+
+	if !org.ImpersonationAllowed {
+		return auth.LoginResult{}, ErrImpersonationNotAllowed
+	}
+
+	var result auth.LoginResult
+
+	// the session is created only along with its audit log
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateAuditLog(ctx, orgID, operator, ActionImpersonate, comment); err != nil {
+			return err
+		}
+
+		result, err = s.authService.Impersonate(ctx, operator, orgID, userID, device)
+
+		return err
+	})
+	if err != nil {
+		return auth.LoginResult{}, err
+	}
+
+	return result, nil
+}
+
+func (s *service) LogImpersonatedRequest(ctx context.Context, log ImpersonationLog) error {
+	err := s.repo.CreateImpersonationLog(ctx, log)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrImpersonationNotAllowed
+	}
+
+	return err
+}
+
+func (s *service) ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error) {
+	if _, err := s.GetOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListImpersonationLogs(ctx, orgID)
+}
+
+// takeAction checks the state of the organization and then takes the action along with recording it.
+// The audit log is recorded first within the same transaction so that no action is taken without its record.
+func (s *service) takeAction(
+	ctx context.Context,
+	operator string,
+	orgID int64,
+	action, comment string,
+	check func(org Organization) error,
+	actionFn func(ctx context.Context, id int64, comment string) error,
+) error {
+	if err := organization.ValidateComment(comment); err != nil {
+		return err
+	}
+
+	org, err := s.GetOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if err := check(org); err != nil {
+		return err
+	}
+
+	return s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateAuditLog(ctx, orgID, operator, action, comment); err != nil {
+			return err
+		}
+
+		return actionFn(ctx, orgID, comment)
+	})
+}
+
+// validateFilter validates the status and the pagination of the filter.
+func validateFilter(filter ListFilter) error {
+	switch filter.Status {
+	case "", StatusActive, StatusSuspended, StatusDeleted:
+	default:
+		return base.NewInputValidationError(fmt.Sprintf("status must be one of %s, %s or %s",
+			StatusActive, StatusSuspended, StatusDeleted))
+	}
+
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return base.NewInputValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxLimit))
+	}
+
+	if filter.Offset < 0 {
+		return base.NewInputValidationError("offset must not be negative")
+	}
+
+	return nil
+}
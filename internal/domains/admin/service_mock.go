@@ -0,0 +1,534 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package admin
+
+import (
+	context "context"
+
+	auth "github.com/camelhr/camelhr-api/internal/domains/auth"
+	session "github.com/camelhr/camelhr-api/internal/domains/session"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// GetOrganization provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetOrganization(ctx context.Context, orgID int64) (Organization, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganization")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Organization, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Organization); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganization'
+type MockService_GetOrganization_Call struct {
+	*mock.Call
+}
+
+// GetOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) GetOrganization(ctx interface{}, orgID interface{}) *MockService_GetOrganization_Call {
+	return &MockService_GetOrganization_Call{Call: _e.mock.On("GetOrganization", ctx, orgID)}
+}
+
+func (_c *MockService_GetOrganization_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetOrganization_Call) Return(_a0 Organization, _a1 error) *MockService_GetOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetOrganization_Call) RunAndReturn(run func(context.Context, int64) (Organization, error)) *MockService_GetOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Impersonate provides a mock function with given fields: ctx, operator, orgID, userID, comment, device
+func (_m *MockService) Impersonate(ctx context.Context, operator string, orgID int64, userID int64, comment string, device session.Device) (auth.LoginResult, error) {
+	ret := _m.Called(ctx, operator, orgID, userID, comment, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Impersonate")
+	}
+
+	var r0 auth.LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, string, session.Device) (auth.LoginResult, error)); ok {
+		return rf(ctx, operator, orgID, userID, comment, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, string, session.Device) auth.LoginResult); ok {
+		r0 = rf(ctx, operator, orgID, userID, comment, device)
+	} else {
+		r0 = ret.Get(0).(auth.LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, string, session.Device) error); ok {
+		r1 = rf(ctx, operator, orgID, userID, comment, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Impersonate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Impersonate'
+type MockService_Impersonate_Call struct {
+	*mock.Call
+}
+
+// Impersonate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator string
+//   - orgID int64
+//   - userID int64
+//   - comment string
+//   - device session.Device
+func (_e *MockService_Expecter) Impersonate(ctx interface{}, operator interface{}, orgID interface{}, userID interface{}, comment interface{}, device interface{}) *MockService_Impersonate_Call {
+	return &MockService_Impersonate_Call{Call: _e.mock.On("Impersonate", ctx, operator, orgID, userID, comment, device)}
+}
+
+func (_c *MockService_Impersonate_Call) Run(run func(ctx context.Context, operator string, orgID int64, userID int64, comment string, device session.Device)) *MockService_Impersonate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64), args[4].(string), args[5].(session.Device))
+	})
+	return _c
+}
+
+func (_c *MockService_Impersonate_Call) Return(_a0 auth.LoginResult, _a1 error) *MockService_Impersonate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Impersonate_Call) RunAndReturn(run func(context.Context, string, int64, int64, string, session.Device) (auth.LoginResult, error)) *MockService_Impersonate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, orgID
+func (_m *MockService) ListAuditLogs(ctx context.Context, orgID int64) ([]AuditLog, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditLogs")
+	}
+
+	var r0 []AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]AuditLog, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []AuditLog); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ListAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAuditLogs'
+type MockService_ListAuditLogs_Call struct {
+	*mock.Call
+}
+
+// ListAuditLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) ListAuditLogs(ctx interface{}, orgID interface{}) *MockService_ListAuditLogs_Call {
+	return &MockService_ListAuditLogs_Call{Call: _e.mock.On("ListAuditLogs", ctx, orgID)}
+}
+
+func (_c *MockService_ListAuditLogs_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_ListAuditLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ListAuditLogs_Call) Return(_a0 []AuditLog, _a1 error) *MockService_ListAuditLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ListAuditLogs_Call) RunAndReturn(run func(context.Context, int64) ([]AuditLog, error)) *MockService_ListAuditLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListImpersonationLogs provides a mock function with given fields: ctx, orgID
+func (_m *MockService) ListImpersonationLogs(ctx context.Context, orgID int64) ([]ImpersonationLog, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListImpersonationLogs")
+	}
+
+	var r0 []ImpersonationLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]ImpersonationLog, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []ImpersonationLog); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ImpersonationLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ListImpersonationLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListImpersonationLogs'
+type MockService_ListImpersonationLogs_Call struct {
+	*mock.Call
+}
+
+// ListImpersonationLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) ListImpersonationLogs(ctx interface{}, orgID interface{}) *MockService_ListImpersonationLogs_Call {
+	return &MockService_ListImpersonationLogs_Call{Call: _e.mock.On("ListImpersonationLogs", ctx, orgID)}
+}
+
+func (_c *MockService_ListImpersonationLogs_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_ListImpersonationLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ListImpersonationLogs_Call) Return(_a0 []ImpersonationLog, _a1 error) *MockService_ListImpersonationLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ListImpersonationLogs_Call) RunAndReturn(run func(context.Context, int64) ([]ImpersonationLog, error)) *MockService_ListImpersonationLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrganizations provides a mock function with given fields: ctx, filter
+func (_m *MockService) ListOrganizations(ctx context.Context, filter ListFilter) ([]Organization, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrganizations")
+	}
+
+	var r0 []Organization
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) ([]Organization, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListFilter) []Organization); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ListFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockService_ListOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrganizations'
+type MockService_ListOrganizations_Call struct {
+	*mock.Call
+}
+
+// ListOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter ListFilter
+func (_e *MockService_Expecter) ListOrganizations(ctx interface{}, filter interface{}) *MockService_ListOrganizations_Call {
+	return &MockService_ListOrganizations_Call{Call: _e.mock.On("ListOrganizations", ctx, filter)}
+}
+
+func (_c *MockService_ListOrganizations_Call) Run(run func(ctx context.Context, filter ListFilter)) *MockService_ListOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ListFilter))
+	})
+	return _c
+}
+
+func (_c *MockService_ListOrganizations_Call) Return(_a0 []Organization, _a1 int64, _a2 error) *MockService_ListOrganizations_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockService_ListOrganizations_Call) RunAndReturn(run func(context.Context, ListFilter) ([]Organization, int64, error)) *MockService_ListOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogImpersonatedRequest provides a mock function with given fields: ctx, log
+func (_m *MockService) LogImpersonatedRequest(ctx context.Context, log ImpersonationLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogImpersonatedRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ImpersonationLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_LogImpersonatedRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogImpersonatedRequest'
+type MockService_LogImpersonatedRequest_Call struct {
+	*mock.Call
+}
+
+// LogImpersonatedRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - log ImpersonationLog
+func (_e *MockService_Expecter) LogImpersonatedRequest(ctx interface{}, log interface{}) *MockService_LogImpersonatedRequest_Call {
+	return &MockService_LogImpersonatedRequest_Call{Call: _e.mock.On("LogImpersonatedRequest", ctx, log)}
+}
+
+func (_c *MockService_LogImpersonatedRequest_Call) Run(run func(ctx context.Context, log ImpersonationLog)) *MockService_LogImpersonatedRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ImpersonationLog))
+	})
+	return _c
+}
+
+func (_c *MockService_LogImpersonatedRequest_Call) Return(_a0 error) *MockService_LogImpersonatedRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_LogImpersonatedRequest_Call) RunAndReturn(run func(context.Context, ImpersonationLog) error) *MockService_LogImpersonatedRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreOrganization provides a mock function with given fields: ctx, operator, orgID, comment
+func (_m *MockService) RestoreOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	ret := _m.Called(ctx, operator, orgID, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) error); ok {
+		r0 = rf(ctx, operator, orgID, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RestoreOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreOrganization'
+type MockService_RestoreOrganization_Call struct {
+	*mock.Call
+}
+
+// RestoreOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator string
+//   - orgID int64
+//   - comment string
+func (_e *MockService_Expecter) RestoreOrganization(ctx interface{}, operator interface{}, orgID interface{}, comment interface{}) *MockService_RestoreOrganization_Call {
+	return &MockService_RestoreOrganization_Call{Call: _e.mock.On("RestoreOrganization", ctx, operator, orgID, comment)}
+}
+
+func (_c *MockService_RestoreOrganization_Call) Run(run func(ctx context.Context, operator string, orgID int64, comment string)) *MockService_RestoreOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RestoreOrganization_Call) Return(_a0 error) *MockService_RestoreOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RestoreOrganization_Call) RunAndReturn(run func(context.Context, string, int64, string) error) *MockService_RestoreOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuspendOrganization provides a mock function with given fields: ctx, operator, orgID, comment
+func (_m *MockService) SuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	ret := _m.Called(ctx, operator, orgID, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) error); ok {
+		r0 = rf(ctx, operator, orgID, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SuspendOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuspendOrganization'
+type MockService_SuspendOrganization_Call struct {
+	*mock.Call
+}
+
+// SuspendOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator string
+//   - orgID int64
+//   - comment string
+func (_e *MockService_Expecter) SuspendOrganization(ctx interface{}, operator interface{}, orgID interface{}, comment interface{}) *MockService_SuspendOrganization_Call {
+	return &MockService_SuspendOrganization_Call{Call: _e.mock.On("SuspendOrganization", ctx, operator, orgID, comment)}
+}
+
+func (_c *MockService_SuspendOrganization_Call) Run(run func(ctx context.Context, operator string, orgID int64, comment string)) *MockService_SuspendOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_SuspendOrganization_Call) Return(_a0 error) *MockService_SuspendOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SuspendOrganization_Call) RunAndReturn(run func(context.Context, string, int64, string) error) *MockService_SuspendOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnsuspendOrganization provides a mock function with given fields: ctx, operator, orgID, comment
+func (_m *MockService) UnsuspendOrganization(ctx context.Context, operator string, orgID int64, comment string) error {
+	ret := _m.Called(ctx, operator, orgID, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsuspendOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string) error); ok {
+		r0 = rf(ctx, operator, orgID, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_UnsuspendOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsuspendOrganization'
+type MockService_UnsuspendOrganization_Call struct {
+	*mock.Call
+}
+
+// UnsuspendOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator string
+//   - orgID int64
+//   - comment string
+func (_e *MockService_Expecter) UnsuspendOrganization(ctx interface{}, operator interface{}, orgID interface{}, comment interface{}) *MockService_UnsuspendOrganization_Call {
+	return &MockService_UnsuspendOrganization_Call{Call: _e.mock.On("UnsuspendOrganization", ctx, operator, orgID, comment)}
+}
+
+func (_c *MockService_UnsuspendOrganization_Call) Run(run func(ctx context.Context, operator string, orgID int64, comment string)) *MockService_UnsuspendOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_UnsuspendOrganization_Call) Return(_a0 error) *MockService_UnsuspendOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_UnsuspendOrganization_Call) RunAndReturn(run func(context.Context, string, int64, string) error) *MockService_UnsuspendOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
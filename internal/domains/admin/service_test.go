@@ -0,0 +1,382 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ListOrganizations(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		filter admin.ListFilter
+	}{
+		{name: "should return error when the status is invalid", filter: admin.ListFilter{Status: "unknown"}},
+		{name: "should return error when the limit is too large", filter: admin.ListFilter{Limit: admin.MaxLimit + 1}},
+		{name: "should return error when the limit is negative", filter: admin.ListFilter{Limit: -1}},
+		{name: "should return error when the offset is negative", filter: admin.ListFilter{Offset: -1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := admin.NewService(config.Config{}, admin.NewMockRepository(t), nil, nil, nil)
+
+			_, _, err := service.ListOrganizations(context.Background(), tc.filter)
+			require.Error(t, err)
+			assert.True(t, base.IsInputValidationError(err))
+		})
+	}
+
+	t.Run("should return the organizations and the total count using the default limit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		filter := admin.ListFilter{Query: "camel", Status: admin.StatusActive}
+		expectedFilter := filter
+		expectedFilter.Limit = admin.DefaultLimit
+		orgs := []admin.Organization{
+			{Organization: organization.Organization{ID: gofakeit.Int64()}, UserCount: 3},
+		}
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, nil)
+
+		repo.On("ListOrganizations", ctx, expectedFilter).Return(orgs, nil)
+		repo.On("CountOrganizations", ctx, expectedFilter).Return(int64(21), nil)
+
+		result, total, err := service.ListOrganizations(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, orgs, result)
+		assert.Equal(t, int64(21), total)
+	})
+}
+
+func TestService_GetOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the organization does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{}, sql.ErrNoRows)
+
+		_, err := service.GetOrganization(ctx, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+}
+
+func TestService_SuspendOrganization(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+
+	for _, tc := range []struct {
+		name string
+		org  organization.Organization
+		err  error
+	}{
+		{
+			name: "should return error when the organization is deleted",
+			org:  organization.Organization{Timestamps: base.Timestamps{DeletedAt: &now}},
+			err:  admin.ErrOrgDeleted,
+		},
+		{
+			name: "should return error when the organization is already suspended",
+			org:  organization.Organization{SuspendedAt: &now},
+			err:  admin.ErrOrgAlreadySuspended,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			tc.org.ID = gofakeit.Int64()
+			repo := admin.NewMockRepository(t)
+			service := admin.NewService(config.Config{}, repo, nil, organization.NewMockService(t), nil)
+
+			repo.On("GetOrganizationByID", ctx, tc.org.ID).Return(admin.Organization{Organization: tc.org}, nil)
+
+			err := service.SuspendOrganization(ctx, "john", tc.org.ID, "violation of terms")
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	t.Run("should return error when the comment is missing", func(t *testing.T) {
+		t.Parallel()
+
+		service := admin.NewService(config.Config{}, admin.NewMockRepository(t), nil, organization.NewMockService(t), nil)
+
+		err := service.SuspendOrganization(context.Background(), "john", gofakeit.Int64(), "")
+		require.Error(t, err)
+	})
+
+	t.Run("should record the audit log before suspending the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		comment := "violation of terms"
+		repo := admin.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := admin.NewService(config.Config{}, repo, transactor, orgService, nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID}}, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		auditLogCall := repo.On("CreateAuditLog", ctx, orgID, "john", admin.ActionSuspend, comment).Return(nil)
+		orgService.On("SuspendOrganization", ctx, orgID, comment).Return(nil).NotBefore(auditLogCall)
+
+		err := service.SuspendOrganization(ctx, "john", orgID, comment)
+		require.NoError(t, err)
+	})
+
+	t.Run("should not suspend the organization when the audit log can not be recorded", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		service := admin.NewService(config.Config{}, repo, transactor, organization.NewMockService(t), nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID}}, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("CreateAuditLog", ctx, orgID, "john", admin.ActionSuspend, "comment").Return(assert.AnError)
+
+		err := service.SuspendOrganization(ctx, "john", orgID, "comment")
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestService_UnsuspendOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization is not suspended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, organization.NewMockService(t), nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID}}, nil)
+
+		err := service.UnsuspendOrganization(ctx, "john", orgID, "comment")
+		require.ErrorIs(t, err, admin.ErrOrgNotSuspended)
+	})
+
+	t.Run("should record the audit log and unsuspend the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := admin.NewService(config.Config{}, repo, transactor, orgService, nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID, SuspendedAt: &now}}, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("CreateAuditLog", ctx, orgID, "john", admin.ActionUnsuspend, "comment").Return(nil)
+		orgService.On("UnsuspendOrganization", ctx, orgID, "comment").Return(nil)
+
+		err := service.UnsuspendOrganization(ctx, "john", orgID, "comment")
+		require.NoError(t, err)
+	})
+}
+
+func TestService_RestoreOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization is not deleted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, organization.NewMockService(t), nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID}}, nil)
+
+		err := service.RestoreOrganization(ctx, "john", orgID, "comment")
+		require.ErrorIs(t, err, admin.ErrOrgNotDeleted)
+	})
+
+	t.Run("should return error when the restore window of the organization has ended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		deletedAt := time.Now().UTC().Add(-31 * 24 * time.Hour)
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{OrgRestoreGracePeriod: 30}, repo, nil,
+			organization.NewMockService(t), nil)
+
+		org := organization.Organization{ID: orgID, Timestamps: base.Timestamps{DeletedAt: &deletedAt}}
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{Organization: org}, nil)
+
+		err := service.RestoreOrganization(ctx, "john", orgID, "comment")
+		require.ErrorIs(t, err, admin.ErrRestoreWindowExpired)
+	})
+
+	t.Run("should record the audit log and restore the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := admin.NewService(config.Config{OrgRestoreGracePeriod: 30}, repo, transactor, orgService, nil)
+
+		org := organization.Organization{ID: orgID, Timestamps: base.Timestamps{DeletedAt: &now}}
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{Organization: org}, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("CreateAuditLog", ctx, orgID, "john", admin.ActionRestore, "comment").Return(nil)
+		orgService.On("RestoreOrganization", ctx, orgID, "comment").Return(nil)
+
+		err := service.RestoreOrganization(ctx, "john", orgID, "comment")
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ListAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the organization does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, nil)
+
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{}, sql.ErrNoRows)
+
+		_, err := service.ListAuditLogs(ctx, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+}
+
+func TestService_Impersonate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization does not allow the impersonation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, auth.NewMockService(t))
+
+		repo.On("GetOrganizationByID", ctx, orgID).
+			Return(admin.Organization{Organization: organization.Organization{ID: orgID}}, nil)
+
+		_, err := service.Impersonate(ctx, "john", orgID, gofakeit.Int64(), "ticket 1234", session.Device{})
+		require.ErrorIs(t, err, admin.ErrImpersonationNotAllowed)
+	})
+
+	t.Run("should return error when the organization is deleted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		orgID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, auth.NewMockService(t))
+
+		org := organization.Organization{
+			ID:                   orgID,
+			ImpersonationAllowed: true,
+			Timestamps:           base.Timestamps{DeletedAt: &now},
+		}
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{Organization: org}, nil)
+
+		_, err := service.Impersonate(ctx, "john", orgID, gofakeit.Int64(), "ticket 1234", session.Device{})
+		require.ErrorIs(t, err, admin.ErrOrgDeleted)
+	})
+
+	t.Run("should record the audit log and start the impersonation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		repo := admin.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		authService := auth.NewMockService(t)
+		service := admin.NewService(config.Config{}, repo, transactor, nil, authService)
+		expected := auth.LoginResult{JWT: "jwt", TTL: auth.ImpersonationSessionTTL}
+
+		org := organization.Organization{ID: orgID, ImpersonationAllowed: true}
+		repo.On("GetOrganizationByID", ctx, orgID).Return(admin.Organization{Organization: org}, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("CreateAuditLog", ctx, orgID, "john", admin.ActionImpersonate, "ticket 1234").Return(nil)
+		authService.On("Impersonate", ctx, "john", orgID, userID, session.Device{}).Return(expected, nil)
+
+		result, err := service.Impersonate(ctx, "john", orgID, userID, "ticket 1234", session.Device{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestService_LogImpersonatedRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization no longer allows the impersonation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, nil)
+		log := admin.ImpersonationLog{OrganizationID: gofakeit.Int64(), UserID: gofakeit.Int64(), Operator: "john"}
+
+		repo.On("CreateImpersonationLog", ctx, log).Return(sql.ErrNoRows)
+
+		err := service.LogImpersonatedRequest(ctx, log)
+		require.ErrorIs(t, err, admin.ErrImpersonationNotAllowed)
+	})
+
+	t.Run("should record the request", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := admin.NewMockRepository(t)
+		service := admin.NewService(config.Config{}, repo, nil, nil, nil)
+		log := admin.ImpersonationLog{OrganizationID: gofakeit.Int64(), UserID: gofakeit.Int64(), Operator: "john"}
+
+		repo.On("CreateImpersonationLog", ctx, log).Return(nil)
+
+		err := service.LogImpersonatedRequest(ctx, log)
+		require.NoError(t, err)
+	})
+}
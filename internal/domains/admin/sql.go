@@ -0,0 +1,24 @@
+package admin
+
+import _ "embed"
+
+//go:embed sql/list_organizations.sql
+var listOrganizationsQuery string
+
+//go:embed sql/count_organizations.sql
+var countOrganizationsQuery string
+
+//go:embed sql/get_organization_by_id.sql
+var getOrganizationByIDQuery string
+
+//go:embed sql/create_audit_log.sql
+var createAuditLogQuery string
+
+//go:embed sql/list_audit_logs.sql
+var listAuditLogsQuery string
+
+//go:embed sql/create_impersonation_log.sql
+var createImpersonationLogQuery string
+
+//go:embed sql/list_impersonation_logs.sql
+var listImpersonationLogsQuery string
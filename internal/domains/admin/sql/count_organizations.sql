@@ -0,0 +1,19 @@
+-- countOrganizationsQuery
+-- $1: query
+-- $2: status
+SELECT
+    COUNT(*)
+FROM
+    organizations o
+WHERE
+    (
+        $1 = ''
+        OR STRPOS(LOWER(o.name), LOWER($1)) > 0
+        OR STRPOS(LOWER(o.subdomain), LOWER($1)) > 0
+    )
+    AND (
+        $2 = ''
+        OR ($2 = 'active' AND o.suspended_at IS NULL AND o.deleted_at IS NULL)
+        OR ($2 = 'suspended' AND o.suspended_at IS NOT NULL AND o.deleted_at IS NULL)
+        OR ($2 = 'deleted' AND o.deleted_at IS NOT NULL)
+    );
@@ -0,0 +1,9 @@
+-- createAuditLogQuery
+-- $1: organization_id
+-- $2: operator
+-- $3: action
+-- $4: comment
+INSERT INTO
+    admin_audit_logs(organization_id, operator, action, comment)
+VALUES
+    ($1, $2, $3, $4);
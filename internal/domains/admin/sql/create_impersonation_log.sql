@@ -0,0 +1,23 @@
+-- createImpersonationLogQuery
+-- the request is recorded only when the organization still allows the impersonation
+-- $1: organization_id
+-- $2: user_id
+-- $3: operator
+-- $4: session_id
+-- $5: method
+-- $6: path
+INSERT INTO
+    impersonation_logs(organization_id, user_id, operator, session_id, method, path)
+SELECT
+    o.organization_id,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+FROM
+    organizations o
+WHERE
+    o.organization_id = $1
+    AND o.impersonation_allowed = TRUE
+    AND o.deleted_at IS NULL RETURNING impersonation_log_id;
@@ -0,0 +1,28 @@
+-- getOrganizationByIDQuery
+-- $1: organization_id
+-- the soft deleted organizations are included
+SELECT
+    o.organization_id,
+    o.subdomain,
+    o.name,
+    o.suspended_at,
+    o.mfa_required,
+    o.magic_link_enabled,
+    o.impersonation_allowed,
+    o.created_at,
+    o.updated_at,
+    o.deleted_at,
+    o.comment,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            users u
+        WHERE
+            u.organization_id = o.organization_id
+            AND u.deleted_at IS NULL
+    ) AS user_count
+FROM
+    organizations o
+WHERE
+    o.organization_id = $1;
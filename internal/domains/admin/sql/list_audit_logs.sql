@@ -0,0 +1,15 @@
+-- listAuditLogsQuery
+-- $1: organization_id
+SELECT
+    admin_audit_log_id,
+    organization_id,
+    operator,
+    action,
+    comment,
+    created_at
+FROM
+    admin_audit_logs
+WHERE
+    organization_id = $1
+ORDER BY
+    admin_audit_log_id DESC;
@@ -0,0 +1,17 @@
+-- listImpersonationLogsQuery
+-- $1: organization_id
+SELECT
+    impersonation_log_id,
+    organization_id,
+    user_id,
+    operator,
+    session_id,
+    method,
+    path,
+    created_at
+FROM
+    impersonation_logs
+WHERE
+    organization_id = $1
+ORDER BY
+    impersonation_log_id DESC;
@@ -0,0 +1,44 @@
+-- listOrganizationsQuery
+-- $1: query
+-- $2: status
+-- $3: limit
+-- $4: offset
+SELECT
+    o.organization_id,
+    o.subdomain,
+    o.name,
+    o.suspended_at,
+    o.mfa_required,
+    o.magic_link_enabled,
+    o.impersonation_allowed,
+    o.created_at,
+    o.updated_at,
+    o.deleted_at,
+    o.comment,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            users u
+        WHERE
+            u.organization_id = o.organization_id
+            AND u.deleted_at IS NULL
+    ) AS user_count
+FROM
+    organizations o
+WHERE
+    (
+        $1 = ''
+        OR STRPOS(LOWER(o.name), LOWER($1)) > 0
+        OR STRPOS(LOWER(o.subdomain), LOWER($1)) > 0
+    )
+    AND (
+        $2 = ''
+        OR ($2 = 'active' AND o.suspended_at IS NULL AND o.deleted_at IS NULL)
+        OR ($2 = 'suspended' AND o.suspended_at IS NOT NULL AND o.deleted_at IS NULL)
+        OR ($2 = 'deleted' AND o.deleted_at IS NOT NULL)
+    )
+ORDER BY
+    o.organization_id
+LIMIT
+    $3 OFFSET $4;
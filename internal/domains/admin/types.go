@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+)
+
+// The statuses to filter the organizations by.
+const (
+	StatusActive    = "active"
+	StatusSuspended = "suspended"
+	StatusDeleted   = "deleted"
+)
+
+// The actions of the platform operators recorded in the audit log.
+const (
+	ActionSuspend   = "suspend"
+	ActionUnsuspend = "unsuspend"
+	ActionRestore   = "restore"
+
+	// ActionImpersonate is recorded when an operator starts impersonating a user of the organization.
+	// The requests made while impersonating are recorded in the impersonation log.
+	ActionImpersonate = "impersonate"
+)
+
+const (
+	// DefaultLimit is the number of organizations listed when the limit is not given.
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of organizations listed at once.
+	MaxLimit = 100
+)
+
+// Organization represents an organization along with the number of its users.
+// Unlike the tenant api, it includes the soft deleted organizations.
+type Organization struct {
+	organization.Organization
+
+	// UserCount is the number of the users of the organization which are not deleted.
+	UserCount int64 `db:"user_count"`
+}
+
+// AuditLog represents an action taken by a platform operator on an organization.
+type AuditLog struct {
+	// ID is the unique identifier of the audit log.
+	ID int64 `db:"admin_audit_log_id"`
+
+	// OrganizationID is the reference to the organization the action was taken on.
+	OrganizationID int64 `db:"organization_id"`
+
+	// Operator is the name of the platform operator who took the action.
+	Operator string `db:"operator"`
+
+	// Action is the action taken on the organization.
+	Action string `db:"action"`
+
+	// Comment is the reason for the action given by the operator.
+	Comment string `db:"comment"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ImpersonationLog represents a request made by a platform operator while impersonating a user.
+type ImpersonationLog struct {
+	// ID is the unique identifier of the impersonation log.
+	ID int64 `db:"impersonation_log_id"`
+
+	// OrganizationID is the reference to the organization of the impersonated user.
+	OrganizationID int64 `db:"organization_id"`
+
+	// UserID is the reference to the impersonated user.
+	UserID int64 `db:"user_id"`
+
+	// Operator is the name of the platform operator who made the request.
+	Operator string `db:"operator"`
+
+	// SessionID is the identifier of the impersonation session the request was made with.
+	SessionID string `db:"session_id"`
+
+	// Method is the http method of the request.
+	Method string `db:"method"`
+
+	// Path is the url path of the request.
+	Path string `db:"path"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ListFilter represents the filter and the pagination of the organizations to list.
+type ListFilter struct {
+	// Query matches the name or the subdomain of the organizations. Empty query matches all.
+	Query string
+
+	// Status is one of StatusActive, StatusSuspended or StatusDeleted. Empty status matches all.
+	Status string
+
+	Limit  int
+	Offset int
+}
+
+// ActionRequest represents the request payload of an action taken on an organization.
+type ActionRequest struct {
+	Comment string `json:"comment" validate:"required,max=255"`
+}
+
+// OrganizationResponse represents the response payload of an organization.
+type OrganizationResponse struct {
+	organization.Response
+
+	DeletedAt *time.Time `json:"deleted_at"`
+	Comment   *string    `json:"comment"`
+	UserCount int64      `json:"user_count"`
+}
+
+// ListResponse represents the response payload of a page of the organizations.
+type ListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Total         int64                  `json:"total"`
+	Limit         int                    `json:"limit"`
+	Offset        int                    `json:"offset"`
+}
+
+// ImpersonationResponse represents the response payload of a started impersonation.
+// The access token can not be renewed and expires along with the session.
+type ImpersonationResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Impersonated bool   `json:"impersonated"`
+	Operator     string `json:"operator"`
+	UserID       int64  `json:"user_id"`
+}
+
+// ImpersonationLogResponse represents the response payload of an impersonation log.
+type ImpersonationLogResponse struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Operator  string    `json:"operator"`
+	SessionID string    `json:"session_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditLogResponse represents the response payload of an audit log.
+type AuditLogResponse struct {
+	ID        int64     `json:"id"`
+	Operator  string    `json:"operator"`
+	Action    string    `json:"action"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
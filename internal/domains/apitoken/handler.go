@@ -0,0 +1,133 @@
+package apitoken
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// ListAPITokens lists the api tokens of the authenticated user. The plaintext tokens are not included.
+func (h *handler) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	userID, _, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	tokens, err := h.service.ListAPITokens(r.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := make([]Response, 0, len(tokens))
+	for _, t := range tokens {
+		result = append(result, toResponse(t))
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// CreateAPIToken creates a new api token for the authenticated user.
+// The plaintext token is shown only once in the response.
+func (h *handler) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	userID, _, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload CreateRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	t, token, err := h.service.CreateAPIToken(r.Context(), userID, reqPayload)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateName) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, CreateResponse{Response: toResponse(t), Token: token})
+}
+
+// RegenerateAPIToken replaces the plaintext token of the given api token of the authenticated user.
+// The new plaintext token is shown only once in the response.
+func (h *handler) RegenerateAPIToken(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	tokenID, err := request.URLParamID(r, "apiTokenID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	t, token, err := h.service.RegenerateAPIToken(r.Context(), userID, orgID, tokenID)
+	if err != nil {
+		if errors.Is(err, ErrTokenExpired) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CreateResponse{Response: toResponse(t), Token: token})
+}
+
+// DeleteAPIToken revokes the given api token of the authenticated user.
+func (h *handler) DeleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	tokenID, err := request.URLParamID(r, "apiTokenID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.DeleteAPIToken(r.Context(), userID, orgID, tokenID); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+func toResponse(t APIToken) Response {
+	return Response{
+		ID:         t.ID,
+		Name:       t.Name,
+		Scopes:     t.ScopeList(),
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
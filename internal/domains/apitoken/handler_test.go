@@ -0,0 +1,284 @@
+package apitoken_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiTokensPath = "/api/v1/subdomains/{subdomain}/me/api-tokens"
+
+func TestHandler_ListAPITokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the user is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, apiTokensPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(apitoken.NewMockService(t))
+
+		handler.ListAPITokens(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should list the api tokens without the token hash", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, apiTokensPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+		tokens := []apitoken.APIToken{{
+			ID:        gofakeit.Int64(),
+			Name:      "ci",
+			TokenHash: gofakeit.UUID(),
+			Scopes:    "users:read,users:write",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}}
+
+		// mock the service calls
+		mockService.On("ListAPITokens", fake.MockContext, userID).Return(tokens, nil)
+
+		// call the handler
+		handler.ListAPITokens(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), tokens[0].TokenHash)
+
+		var result []apitoken.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, tokens[0].ID, result[0].ID)
+		assert.Equal(t, []string{"users:read", "users:write"}, result[0].Scopes)
+	})
+}
+
+func TestHandler_CreateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the payload is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		payload := `{"name":"ci","scopes":[],"expires_in_days":30}`
+		req, err := http.NewRequest(http.MethodPost, apiTokensPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(apitoken.NewMockService(t))
+
+		handler.CreateAPIToken(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return conflict when a token with the same name exists", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		payload := `{"name":"ci","scopes":["users:read"],"expires_in_days":30}`
+		req, err := http.NewRequest(http.MethodPost, apiTokensPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+		reqPayload := apitoken.CreateRequest{Name: "ci", Scopes: []string{"users:read"}, ExpiresInDays: 30}
+
+		// mock the service calls
+		mockService.On("CreateAPIToken", fake.MockContext, userID, reqPayload).
+			Return(apitoken.APIToken{}, "", apitoken.ErrDuplicateName)
+
+		// call the handler
+		handler.CreateAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should create the api token and return the plaintext token", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		payload := `{"name":"ci","scopes":["users:read"],"expires_in_days":30}`
+		req, err := http.NewRequest(http.MethodPost, apiTokensPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+		reqPayload := apitoken.CreateRequest{Name: "ci", Scopes: []string{"users:read"}, ExpiresInDays: 30}
+		token := gofakeit.UUID()
+		created := apitoken.APIToken{ID: gofakeit.Int64(), Name: "ci", Scopes: "users:read"}
+
+		// mock the service calls
+		mockService.On("CreateAPIToken", fake.MockContext, userID, reqPayload).Return(created, token, nil)
+
+		// call the handler
+		handler.CreateAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var result apitoken.CreateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, created.ID, result.ID)
+		assert.Equal(t, token, result.Token)
+	})
+}
+
+func TestHandler_RegenerateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return conflict when the token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, apiTokensPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "apiTokenID", strconv.FormatInt(tokenID, 10))
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("RegenerateAPIToken", fake.MockContext, userID, orgID, tokenID).
+			Return(apitoken.APIToken{}, "", apitoken.ErrTokenExpired)
+
+		// call the handler
+		handler.RegenerateAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should return the new plaintext token", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, apiTokensPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "apiTokenID", strconv.FormatInt(tokenID, 10))
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+		token := gofakeit.UUID()
+
+		// mock the service calls
+		mockService.On("RegenerateAPIToken", fake.MockContext, userID, orgID, tokenID).
+			Return(apitoken.APIToken{ID: tokenID, Name: "ci", Scopes: apitoken.ScopeUsersRead}, token, nil)
+
+		// call the handler
+		handler.RegenerateAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result apitoken.CreateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, tokenID, result.ID)
+		assert.Equal(t, token, result.Token)
+	})
+}
+
+func TestHandler_DeleteAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the token id is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodDelete, apiTokensPath+"/invalid", nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithURLParam(req, "apiTokenID", "invalid")
+
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(apitoken.NewMockService(t))
+
+		handler.DeleteAPIToken(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found when the token does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, apiTokensPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "apiTokenID", strconv.FormatInt(tokenID, 10))
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteAPIToken", fake.MockContext, userID, orgID, tokenID).
+			Return(base.NewNotFoundError("api token not found for the given id"))
+
+		// call the handler
+		handler.DeleteAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should delete the api token", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, apiTokensPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "apiTokenID", strconv.FormatInt(tokenID, 10))
+
+		mockService := apitoken.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := apitoken.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteAPIToken", fake.MockContext, userID, orgID, tokenID).Return(nil)
+
+		// call the handler
+		handler.DeleteAPIToken(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
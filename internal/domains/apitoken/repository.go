@@ -0,0 +1,81 @@
+package apitoken
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// ListAPITokens returns the api tokens of the user ordered by the creation time in descending order.
+	ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error)
+
+	// GetAPITokenByOrgSubdomainTokenHash returns the api token of an active user of the organization by its hash.
+	GetAPITokenByOrgSubdomainTokenHash(ctx context.Context, orgSubdomain, tokenHash string) (APIToken, error)
+
+	// CreateAPIToken stores a new api token of the user.
+	// It returns ErrDuplicateName if the user has another token with the same name.
+	CreateAPIToken(ctx context.Context, t APIToken) (APIToken, error)
+
+	// DeleteAPIToken deletes the api token of the user.
+	// It returns sql.ErrNoRows if the token is not found.
+	DeleteAPIToken(ctx context.Context, userID, tokenID int64) error
+
+	// UpdateTokenHash replaces the hash of the api token of the user.
+	// It returns sql.ErrNoRows if the token is not found.
+	UpdateTokenHash(ctx context.Context, userID, tokenID int64, tokenHash string) (APIToken, error)
+
+	// UpdateLastUsedAt sets the last used time of the api token to the current time.
+	UpdateLastUsedAt(ctx context.Context, tokenID int64) error
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error) {
+	var tokens []APIToken
+	err := r.db.List(ctx, &tokens, listAPITokensQuery, userID)
+
+	return tokens, err
+}
+
+func (r *repository) GetAPITokenByOrgSubdomainTokenHash(
+	ctx context.Context,
+	orgSubdomain, tokenHash string,
+) (APIToken, error) {
+	var t APIToken
+	err := r.db.Get(ctx, &t, getAPITokenByOrgSubdomainTokenHashQuery, orgSubdomain, tokenHash)
+
+	return t, err
+}
+
+func (r *repository) CreateAPIToken(ctx context.Context, t APIToken) (APIToken, error) {
+	var result APIToken
+	err := r.db.Exec(ctx, &result, createAPITokenQuery, t.UserID, t.Name, t.TokenHash, t.Scopes, t.ExpiresAt)
+	if database.IsUniqueViolation(err, "idx_api_tokens_user_id_name") {
+		return APIToken{}, ErrDuplicateName
+	}
+
+	return result, err
+}
+
+func (r *repository) DeleteAPIToken(ctx context.Context, userID, tokenID int64) error {
+	var id int64
+	return r.db.Exec(ctx, &id, deleteAPITokenQuery, userID, tokenID)
+}
+
+func (r *repository) UpdateLastUsedAt(ctx context.Context, tokenID int64) error {
+	return r.db.Exec(ctx, nil, updateAPITokenLastUsedAtQuery, tokenID)
+}
+
+func (r *repository) UpdateTokenHash(ctx context.Context, userID, tokenID int64, tokenHash string) (APIToken, error) {
+	var t APIToken
+	err := r.db.Exec(ctx, &t, updateAPITokenHashQuery, userID, tokenID, tokenHash)
+
+	return t, err
+}
@@ -0,0 +1,164 @@
+package apitoken_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_CreateAPIToken() {
+	s.Run("should create the api token of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := apitoken.APIToken{
+			UserID:    u.ID,
+			Name:      "ci",
+			TokenHash: base.HashToken("token"),
+			Scopes:    "users:read,users:write",
+			ExpiresAt: time.Now().UTC().Add(time.Hour),
+		}
+
+		result, err := repo.CreateAPIToken(ctx, t)
+		s.Require().NoError(err)
+		s.NotZero(result.ID)
+		s.Equal(u.ID, result.UserID)
+		s.Equal(o.ID, result.OrganizationID)
+		s.Equal(t.Name, result.Name)
+		s.Equal(t.TokenHash, result.TokenHash)
+		s.Equal([]string{"users:read", "users:write"}, result.ScopeList())
+		s.WithinDuration(t.ExpiresAt, result.ExpiresAt, time.Second)
+		s.Nil(result.LastUsedAt)
+		s.NotZero(result.CreatedAt)
+
+		// the name is unique for the user
+		t.TokenHash = base.HashToken("another-token")
+		_, err = repo.CreateAPIToken(ctx, t)
+		s.Require().ErrorIs(err, apitoken.ErrDuplicateName)
+	})
+}
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_ListAPITokens() {
+	s.Run("should list only the api tokens of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t1 := fake.NewAPIToken(s.DB, u.ID)
+		t2 := fake.NewAPIToken(s.DB, u.ID)
+		fake.NewAPIToken(s.DB, fake.NewUser(s.DB, o.ID).ID)
+
+		result, err := repo.ListAPITokens(ctx, u.ID)
+		s.Require().NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal(t2.ID, result[0].ID)
+		s.Equal(t1.ID, result[1].ID)
+		s.Equal(o.ID, result[0].OrganizationID)
+	})
+}
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_GetAPITokenByOrgSubdomainTokenHash() {
+	s.Run("should return the api token of the organization", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		t := fake.NewAPIToken(s.DB, fake.NewUser(s.DB, o.ID).ID)
+
+		result, err := repo.GetAPITokenByOrgSubdomainTokenHash(ctx, o.Subdomain, base.HashToken(t.Token))
+		s.Require().NoError(err)
+		s.Equal(t.ID, result.ID)
+		s.Equal(o.ID, result.OrganizationID)
+
+		// the token is not returned for another organization
+		_, err = repo.GetAPITokenByOrgSubdomainTokenHash(ctx, fake.NewOrganization(s.DB).Subdomain,
+			base.HashToken(t.Token))
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not return the api token of a disabled user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		t := fake.NewAPIToken(s.DB, fake.NewUser(s.DB, o.ID, fake.UserDisabled()).ID)
+
+		_, err := repo.GetAPITokenByOrgSubdomainTokenHash(ctx, o.Subdomain, base.HashToken(t.Token))
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_DeleteAPIToken() {
+	s.Run("should delete only the api token of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := fake.NewAPIToken(s.DB, u.ID)
+
+		err := repo.DeleteAPIToken(ctx, fake.NewUser(s.DB, o.ID).ID, t.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		err = repo.DeleteAPIToken(ctx, u.ID, t.ID)
+		s.Require().NoError(err)
+
+		result, err := repo.ListAPITokens(ctx, u.ID)
+		s.Require().NoError(err)
+		s.Empty(result)
+	})
+}
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_UpdateTokenHash() {
+	s.Run("should replace the hash of only the api token of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := fake.NewAPIToken(s.DB, u.ID)
+		tokenHash := base.HashToken(gofakeit.UUID())
+
+		_, err := repo.UpdateTokenHash(ctx, fake.NewUser(s.DB, o.ID).ID, t.ID, tokenHash)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		result, err := repo.UpdateTokenHash(ctx, u.ID, t.ID, tokenHash)
+		s.Require().NoError(err)
+		s.Equal(t.ID, result.ID)
+		s.Equal(o.ID, result.OrganizationID)
+		s.Equal(tokenHash, result.TokenHash)
+		s.Equal(t.Name, result.Name)
+		s.Equal(t.Scopes, result.Scopes)
+	})
+}
+
+func (s *APITokenTestSuite) TestRepositoryIntegration_UpdateLastUsedAt() {
+	s.Run("should set the last used time of the api token", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := apitoken.NewRepository(s.DB)
+		t := fake.NewAPIToken(s.DB, fake.NewUser(s.DB, fake.NewOrganization(s.DB).ID).ID)
+
+		err := repo.UpdateLastUsedAt(ctx, t.ID)
+		s.Require().NoError(err)
+
+		result := t.FetchLatest(s.DB)
+		s.Require().NotNil(result.LastUsedAt)
+		s.WithinDuration(time.Now().UTC(), *result.LastUsedAt, time.Minute)
+	})
+}
@@ -0,0 +1,364 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package apitoken
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAPIToken provides a mock function with given fields: ctx, t
+func (_m *MockRepository) CreateAPIToken(ctx context.Context, t APIToken) (APIToken, error) {
+	ret := _m.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIToken")
+	}
+
+	var r0 APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, APIToken) (APIToken, error)); ok {
+		return rf(ctx, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, APIToken) APIToken); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, APIToken) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type MockRepository_CreateAPIToken_Call struct {
+	*mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t APIToken
+func (_e *MockRepository_Expecter) CreateAPIToken(ctx interface{}, t interface{}) *MockRepository_CreateAPIToken_Call {
+	return &MockRepository_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", ctx, t)}
+}
+
+func (_c *MockRepository_CreateAPIToken_Call) Run(run func(ctx context.Context, t APIToken)) *MockRepository_CreateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(APIToken))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateAPIToken_Call) Return(_a0 APIToken, _a1 error) *MockRepository_CreateAPIToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CreateAPIToken_Call) RunAndReturn(run func(context.Context, APIToken) (APIToken, error)) *MockRepository_CreateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAPIToken provides a mock function with given fields: ctx, userID, tokenID
+func (_m *MockRepository) DeleteAPIToken(ctx context.Context, userID int64, tokenID int64) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIToken'
+type MockRepository_DeleteAPIToken_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - tokenID int64
+func (_e *MockRepository_Expecter) DeleteAPIToken(ctx interface{}, userID interface{}, tokenID interface{}) *MockRepository_DeleteAPIToken_Call {
+	return &MockRepository_DeleteAPIToken_Call{Call: _e.mock.On("DeleteAPIToken", ctx, userID, tokenID)}
+}
+
+func (_c *MockRepository_DeleteAPIToken_Call) Run(run func(ctx context.Context, userID int64, tokenID int64)) *MockRepository_DeleteAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteAPIToken_Call) Return(_a0 error) *MockRepository_DeleteAPIToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteAPIToken_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_DeleteAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPITokenByOrgSubdomainTokenHash provides a mock function with given fields: ctx, orgSubdomain, tokenHash
+func (_m *MockRepository) GetAPITokenByOrgSubdomainTokenHash(ctx context.Context, orgSubdomain string, tokenHash string) (APIToken, error) {
+	ret := _m.Called(ctx, orgSubdomain, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPITokenByOrgSubdomainTokenHash")
+	}
+
+	var r0 APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (APIToken, error)); ok {
+		return rf(ctx, orgSubdomain, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) APIToken); ok {
+		r0 = rf(ctx, orgSubdomain, tokenHash)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, orgSubdomain, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPITokenByOrgSubdomainTokenHash'
+type MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetAPITokenByOrgSubdomainTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgSubdomain string
+//   - tokenHash string
+func (_e *MockRepository_Expecter) GetAPITokenByOrgSubdomainTokenHash(ctx interface{}, orgSubdomain interface{}, tokenHash interface{}) *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call {
+	return &MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call{Call: _e.mock.On("GetAPITokenByOrgSubdomainTokenHash", ctx, orgSubdomain, tokenHash)}
+}
+
+func (_c *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call) Run(run func(ctx context.Context, orgSubdomain string, tokenHash string)) *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call) Return(_a0 APIToken, _a1 error) *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call) RunAndReturn(run func(context.Context, string, string) (APIToken, error)) *MockRepository_GetAPITokenByOrgSubdomainTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPITokens provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPITokens")
+	}
+
+	var r0 []APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]APIToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []APIToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAPITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPITokens'
+type MockRepository_ListAPITokens_Call struct {
+	*mock.Call
+}
+
+// ListAPITokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) ListAPITokens(ctx interface{}, userID interface{}) *MockRepository_ListAPITokens_Call {
+	return &MockRepository_ListAPITokens_Call{Call: _e.mock.On("ListAPITokens", ctx, userID)}
+}
+
+func (_c *MockRepository_ListAPITokens_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_ListAPITokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAPITokens_Call) Return(_a0 []APIToken, _a1 error) *MockRepository_ListAPITokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAPITokens_Call) RunAndReturn(run func(context.Context, int64) ([]APIToken, error)) *MockRepository_ListAPITokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: ctx, tokenID
+func (_m *MockRepository) UpdateLastUsedAt(ctx context.Context, tokenID int64) error {
+	ret := _m.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateLastUsedAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLastUsedAt'
+type MockRepository_UpdateLastUsedAt_Call struct {
+	*mock.Call
+}
+
+// UpdateLastUsedAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID int64
+func (_e *MockRepository_Expecter) UpdateLastUsedAt(ctx interface{}, tokenID interface{}) *MockRepository_UpdateLastUsedAt_Call {
+	return &MockRepository_UpdateLastUsedAt_Call{Call: _e.mock.On("UpdateLastUsedAt", ctx, tokenID)}
+}
+
+func (_c *MockRepository_UpdateLastUsedAt_Call) Run(run func(ctx context.Context, tokenID int64)) *MockRepository_UpdateLastUsedAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateLastUsedAt_Call) Return(_a0 error) *MockRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateLastUsedAt_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_UpdateLastUsedAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTokenHash provides a mock function with given fields: ctx, userID, tokenID, tokenHash
+func (_m *MockRepository) UpdateTokenHash(ctx context.Context, userID int64, tokenID int64, tokenHash string) (APIToken, error) {
+	ret := _m.Called(ctx, userID, tokenID, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTokenHash")
+	}
+
+	var r0 APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) (APIToken, error)); ok {
+		return rf(ctx, userID, tokenID, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) APIToken); ok {
+		r0 = rf(ctx, userID, tokenID, tokenHash)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string) error); ok {
+		r1 = rf(ctx, userID, tokenID, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpdateTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTokenHash'
+type MockRepository_UpdateTokenHash_Call struct {
+	*mock.Call
+}
+
+// UpdateTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - tokenID int64
+//   - tokenHash string
+func (_e *MockRepository_Expecter) UpdateTokenHash(ctx interface{}, userID interface{}, tokenID interface{}, tokenHash interface{}) *MockRepository_UpdateTokenHash_Call {
+	return &MockRepository_UpdateTokenHash_Call{Call: _e.mock.On("UpdateTokenHash", ctx, userID, tokenID, tokenHash)}
+}
+
+func (_c *MockRepository_UpdateTokenHash_Call) Run(run func(ctx context.Context, userID int64, tokenID int64, tokenHash string)) *MockRepository_UpdateTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateTokenHash_Call) Return(_a0 APIToken, _a1 error) *MockRepository_UpdateTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpdateTokenHash_Call) RunAndReturn(run func(context.Context, int64, int64, string) (APIToken, error)) *MockRepository_UpdateTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
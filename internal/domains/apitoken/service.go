@@ -0,0 +1,156 @@
+package apitoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+)
+
+type Service interface {
+	// ListAPITokens returns the api tokens of the user.
+	ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error)
+
+	// CreateAPIToken creates a new named api token for the user.
+	// It returns the stored token along with the plaintext token.
+	// The plaintext token is not stored and can not be retrieved afterwards.
+	// It returns ErrDuplicateName if the user has another token with the same name.
+	CreateAPIToken(ctx context.Context, userID int64, req CreateRequest) (APIToken, string, error)
+
+	// RegenerateAPIToken replaces the plaintext token of the api token of the user keeping its name, scopes and expiry.
+	// The previous plaintext token stops working and its cached authentication is deleted.
+	// It returns ErrTokenExpired if the token is expired.
+	RegenerateAPIToken(ctx context.Context, userID, orgID, tokenID int64) (APIToken, string, error)
+
+	// DeleteAPIToken revokes the api token of the user.
+	// The cached authentication of the token is deleted as well.
+	DeleteAPIToken(ctx context.Context, userID, orgID, tokenID int64) error
+
+	// Authenticate returns the api token of an active user of the organization for the given plaintext token.
+	// It returns ErrTokenExpired if the token is expired. Otherwise, it records the usage of the token.
+	Authenticate(ctx context.Context, orgSubdomain, token string) (APIToken, error)
+}
+
+type service struct {
+	repo           Repository
+	sessionManager session.SessionManager
+}
+
+func NewService(repo Repository, sessionManager session.SessionManager) Service {
+	return &service{repo, sessionManager}
+}
+
+var (
+	ErrTokenExpired  = errors.New("api token expired")
+	ErrDuplicateName = errors.New("api token with the same name already exists")
+)
+
+func (s *service) ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error) {
+	return s.repo.ListAPITokens(ctx, userID)
+}
+
+func (s *service) CreateAPIToken(ctx context.Context, userID int64, req CreateRequest) (APIToken, string, error) {
+	for _, scope := range req.Scopes {
+		if !IsValidScope(scope) {
+			return APIToken{}, "", base.NewInputValidationError(fmt.Sprintf("invalid scope: %s", scope))
+		}
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	t, err := s.repo.CreateAPIToken(ctx, APIToken{
+		UserID:    userID,
+		Name:      req.Name,
+		TokenHash: base.HashToken(token),
+		Scopes:    strings.Join(req.Scopes, scopesSeparator),
+		ExpiresAt: time.Now().UTC().AddDate(0, 0, req.ExpiresInDays),
+	})
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	return t, token, nil
+}
+
+func (s *service) RegenerateAPIToken(ctx context.Context, userID, orgID, tokenID int64) (APIToken, string, error) {
+	tokens, err := s.repo.ListAPITokens(ctx, userID)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	idx := slices.IndexFunc(tokens, func(t APIToken) bool { return t.ID == tokenID })
+	if idx < 0 {
+		return APIToken{}, "", base.NewNotFoundError("api token not found for the given id")
+	}
+
+	if tokens[idx].IsExpired() {
+		return APIToken{}, "", ErrTokenExpired
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	t, err := s.repo.UpdateTokenHash(ctx, userID, tokenID, base.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return APIToken{}, "", base.NewNotFoundError("api token not found for the given id")
+		}
+
+		return APIToken{}, "", err
+	}
+
+	if err := s.sessionManager.DeleteAPITokenSession(ctx, userID, orgID, tokenID); err != nil {
+		return APIToken{}, "", err
+	}
+
+	return t, token, nil
+}
+
+func (s *service) DeleteAPIToken(ctx context.Context, userID, orgID, tokenID int64) error {
+	if err := s.repo.DeleteAPIToken(ctx, userID, tokenID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return base.NewNotFoundError("api token not found for the given id")
+		}
+
+		return err
+	}
+
+	return s.sessionManager.DeleteAPITokenSession(ctx, userID, orgID, tokenID)
+}
+
+func (s *service) Authenticate(ctx context.Context, orgSubdomain, token string) (APIToken, error) {
+	if err := organization.ValidateSubdomain(orgSubdomain); err != nil {
+		return APIToken{}, err
+	}
+
+	t, err := s.repo.GetAPITokenByOrgSubdomainTokenHash(ctx, orgSubdomain, base.HashToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return APIToken{}, base.NewNotFoundError("api token not found for the given org-subdomain")
+		}
+
+		return APIToken{}, err
+	}
+
+	if t.IsExpired() {
+		return APIToken{}, ErrTokenExpired
+	}
+
+	if err := s.repo.UpdateLastUsedAt(ctx, t.ID); err != nil {
+		return APIToken{}, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,333 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package apitoken
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, orgSubdomain, token
+func (_m *MockService) Authenticate(ctx context.Context, orgSubdomain string, token string) (APIToken, error) {
+	ret := _m.Called(ctx, orgSubdomain, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (APIToken, error)); ok {
+		return rf(ctx, orgSubdomain, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) APIToken); ok {
+		r0 = rf(ctx, orgSubdomain, token)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, orgSubdomain, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgSubdomain string
+//   - token string
+func (_e *MockService_Expecter) Authenticate(ctx interface{}, orgSubdomain interface{}, token interface{}) *MockService_Authenticate_Call {
+	return &MockService_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, orgSubdomain, token)}
+}
+
+func (_c *MockService_Authenticate_Call) Run(run func(ctx context.Context, orgSubdomain string, token string)) *MockService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Authenticate_Call) Return(_a0 APIToken, _a1 error) *MockService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Authenticate_Call) RunAndReturn(run func(context.Context, string, string) (APIToken, error)) *MockService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPIToken provides a mock function with given fields: ctx, userID, req
+func (_m *MockService) CreateAPIToken(ctx context.Context, userID int64, req CreateRequest) (APIToken, string, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIToken")
+	}
+
+	var r0 APIToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, CreateRequest) (APIToken, string, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, CreateRequest) APIToken); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, CreateRequest) string); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, CreateRequest) error); ok {
+		r2 = rf(ctx, userID, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockService_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type MockService_CreateAPIToken_Call struct {
+	*mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - req CreateRequest
+func (_e *MockService_Expecter) CreateAPIToken(ctx interface{}, userID interface{}, req interface{}) *MockService_CreateAPIToken_Call {
+	return &MockService_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", ctx, userID, req)}
+}
+
+func (_c *MockService_CreateAPIToken_Call) Run(run func(ctx context.Context, userID int64, req CreateRequest)) *MockService_CreateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(CreateRequest))
+	})
+	return _c
+}
+
+func (_c *MockService_CreateAPIToken_Call) Return(_a0 APIToken, _a1 string, _a2 error) *MockService_CreateAPIToken_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockService_CreateAPIToken_Call) RunAndReturn(run func(context.Context, int64, CreateRequest) (APIToken, string, error)) *MockService_CreateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAPIToken provides a mock function with given fields: ctx, userID, orgID, tokenID
+func (_m *MockService) DeleteAPIToken(ctx context.Context, userID int64, orgID int64, tokenID int64) error {
+	ret := _m.Called(ctx, userID, orgID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, userID, orgID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_DeleteAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPIToken'
+type MockService_DeleteAPIToken_Call struct {
+	*mock.Call
+}
+
+// DeleteAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - tokenID int64
+func (_e *MockService_Expecter) DeleteAPIToken(ctx interface{}, userID interface{}, orgID interface{}, tokenID interface{}) *MockService_DeleteAPIToken_Call {
+	return &MockService_DeleteAPIToken_Call{Call: _e.mock.On("DeleteAPIToken", ctx, userID, orgID, tokenID)}
+}
+
+func (_c *MockService_DeleteAPIToken_Call) Run(run func(ctx context.Context, userID int64, orgID int64, tokenID int64)) *MockService_DeleteAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteAPIToken_Call) Return(_a0 error) *MockService_DeleteAPIToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_DeleteAPIToken_Call) RunAndReturn(run func(context.Context, int64, int64, int64) error) *MockService_DeleteAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAPITokens provides a mock function with given fields: ctx, userID
+func (_m *MockService) ListAPITokens(ctx context.Context, userID int64) ([]APIToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPITokens")
+	}
+
+	var r0 []APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]APIToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []APIToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ListAPITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAPITokens'
+type MockService_ListAPITokens_Call struct {
+	*mock.Call
+}
+
+// ListAPITokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockService_Expecter) ListAPITokens(ctx interface{}, userID interface{}) *MockService_ListAPITokens_Call {
+	return &MockService_ListAPITokens_Call{Call: _e.mock.On("ListAPITokens", ctx, userID)}
+}
+
+func (_c *MockService_ListAPITokens_Call) Run(run func(ctx context.Context, userID int64)) *MockService_ListAPITokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ListAPITokens_Call) Return(_a0 []APIToken, _a1 error) *MockService_ListAPITokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ListAPITokens_Call) RunAndReturn(run func(context.Context, int64) ([]APIToken, error)) *MockService_ListAPITokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateAPIToken provides a mock function with given fields: ctx, userID, orgID, tokenID
+func (_m *MockService) RegenerateAPIToken(ctx context.Context, userID int64, orgID int64, tokenID int64) (APIToken, string, error) {
+	ret := _m.Called(ctx, userID, orgID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateAPIToken")
+	}
+
+	var r0 APIToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (APIToken, string, error)); ok {
+		return rf(ctx, userID, orgID, tokenID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) APIToken); ok {
+		r0 = rf(ctx, userID, orgID, tokenID)
+	} else {
+		r0 = ret.Get(0).(APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) string); ok {
+		r1 = rf(ctx, userID, orgID, tokenID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, int64, int64) error); ok {
+		r2 = rf(ctx, userID, orgID, tokenID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockService_RegenerateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateAPIToken'
+type MockService_RegenerateAPIToken_Call struct {
+	*mock.Call
+}
+
+// RegenerateAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - tokenID int64
+func (_e *MockService_Expecter) RegenerateAPIToken(ctx interface{}, userID interface{}, orgID interface{}, tokenID interface{}) *MockService_RegenerateAPIToken_Call {
+	return &MockService_RegenerateAPIToken_Call{Call: _e.mock.On("RegenerateAPIToken", ctx, userID, orgID, tokenID)}
+}
+
+func (_c *MockService_RegenerateAPIToken_Call) Run(run func(ctx context.Context, userID int64, orgID int64, tokenID int64)) *MockService_RegenerateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_RegenerateAPIToken_Call) Return(_a0 APIToken, _a1 string, _a2 error) *MockService_RegenerateAPIToken_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockService_RegenerateAPIToken_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (APIToken, string, error)) *MockService_RegenerateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,261 @@
+package apitoken_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIToken_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return whether the token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, apitoken.APIToken{ExpiresAt: time.Now().Add(-time.Minute)}.IsExpired())
+		assert.False(t, apitoken.APIToken{ExpiresAt: time.Now().Add(time.Minute)}.IsExpired())
+	})
+}
+
+func TestService_CreateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the scope is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		service := apitoken.NewService(nil, nil)
+		req := apitoken.CreateRequest{
+			Name:          gofakeit.Word(),
+			Scopes:        []string{apitoken.ScopeUsersRead, "users:delete"},
+			ExpiresInDays: 30,
+		}
+
+		_, _, err := service.CreateAPIToken(ctx, gofakeit.Int64(), req)
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+		assert.ErrorContains(t, err, "invalid scope: users:delete")
+	})
+
+	t.Run("should return error when a token with the same name exists", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+		req := apitoken.CreateRequest{Name: "ci", Scopes: []string{apitoken.ScopeUsersRead}, ExpiresInDays: 30}
+
+		repo.On("CreateAPIToken", ctx, mock.AnythingOfType("apitoken.APIToken")).
+			Return(apitoken.APIToken{}, apitoken.ErrDuplicateName)
+
+		_, _, err := service.CreateAPIToken(ctx, userID, req)
+		require.ErrorIs(t, err, apitoken.ErrDuplicateName)
+	})
+
+	t.Run("should store the hash of the token and return the plaintext token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+		req := apitoken.CreateRequest{
+			Name:          "ci",
+			Scopes:        []string{apitoken.ScopeUsersRead, apitoken.ScopeUsersWrite},
+			ExpiresInDays: 30,
+		}
+
+		var stored apitoken.APIToken
+
+		repo.On("CreateAPIToken", ctx, mock.AnythingOfType("apitoken.APIToken")).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(apitoken.APIToken) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(func(_ context.Context, t apitoken.APIToken) (apitoken.APIToken, error) {
+				t.ID = gofakeit.Int64()
+				return t, nil
+			})
+
+		result, token, err := service.CreateAPIToken(ctx, userID, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.NotZero(t, result.ID)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "ci", stored.Name)
+		assert.Equal(t, base.HashToken(token), stored.TokenHash)
+		assert.Equal(t, "users:read,users:write", stored.Scopes)
+		assert.WithinDuration(t, time.Now().UTC().AddDate(0, 0, 30), stored.ExpiresAt, time.Minute)
+	})
+}
+
+func TestService_RegenerateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the token does not belong to the user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("ListAPITokens", ctx, userID).Return([]apitoken.APIToken{{ID: 1, UserID: userID}}, nil)
+
+		_, _, err := service.RegenerateAPIToken(ctx, userID, gofakeit.Int64(), 2)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("ListAPITokens", ctx, userID).
+			Return([]apitoken.APIToken{{ID: 1, UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)}}, nil)
+
+		_, _, err := service.RegenerateAPIToken(ctx, userID, gofakeit.Int64(), 1)
+		require.ErrorIs(t, err, apitoken.ErrTokenExpired)
+	})
+
+	t.Run("should replace the token hash and delete the cached session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		existing := apitoken.APIToken{ID: 1, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+		repo := apitoken.NewMockRepository(t)
+		sessionManager := session.NewMockSessionManager(t)
+		service := apitoken.NewService(repo, sessionManager)
+
+		var tokenHash string
+
+		repo.On("ListAPITokens", ctx, userID).Return([]apitoken.APIToken{existing}, nil)
+		repo.On("UpdateTokenHash", ctx, userID, existing.ID, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { tokenHash = args.String(3) }).
+			Return(existing, nil)
+		sessionManager.On("DeleteAPITokenSession", ctx, userID, orgID, existing.ID).Return(nil)
+
+		result, token, err := service.RegenerateAPIToken(ctx, userID, orgID, existing.ID)
+		require.NoError(t, err)
+		assert.Equal(t, existing, result)
+		assert.Equal(t, base.HashToken(token), tokenHash)
+	})
+}
+
+func TestService_DeleteAPIToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the token does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("DeleteAPIToken", ctx, userID, tokenID).Return(sql.ErrNoRows)
+
+		err := service.DeleteAPIToken(ctx, userID, gofakeit.Int64(), tokenID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should delete the token and its cached session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		tokenID := gofakeit.Int64()
+		repo := apitoken.NewMockRepository(t)
+		sessionManager := session.NewMockSessionManager(t)
+		service := apitoken.NewService(repo, sessionManager)
+
+		repo.On("DeleteAPIToken", ctx, userID, tokenID).Return(nil)
+		sessionManager.On("DeleteAPITokenSession", ctx, userID, orgID, tokenID).Return(nil)
+
+		err := service.DeleteAPIToken(ctx, userID, orgID, tokenID)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the subdomain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		service := apitoken.NewService(nil, nil)
+
+		_, err := service.Authenticate(context.Background(), "", gofakeit.UUID())
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return not found error when the token does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(10)
+		token := gofakeit.UUID()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("GetAPITokenByOrgSubdomainTokenHash", ctx, subdomain, base.HashToken(token)).
+			Return(apitoken.APIToken{}, sql.ErrNoRows)
+
+		_, err := service.Authenticate(ctx, subdomain, token)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(10)
+		token := gofakeit.UUID()
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("GetAPITokenByOrgSubdomainTokenHash", ctx, subdomain, base.HashToken(token)).
+			Return(apitoken.APIToken{ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		_, err := service.Authenticate(ctx, subdomain, token)
+		require.ErrorIs(t, err, apitoken.ErrTokenExpired)
+	})
+
+	t.Run("should record the usage and return the token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(10)
+		token := gofakeit.UUID()
+		apiToken := apitoken.APIToken{ID: gofakeit.Int64(), ExpiresAt: time.Now().Add(time.Hour)}
+		repo := apitoken.NewMockRepository(t)
+		service := apitoken.NewService(repo, nil)
+
+		repo.On("GetAPITokenByOrgSubdomainTokenHash", ctx, subdomain, base.HashToken(token)).Return(apiToken, nil)
+		repo.On("UpdateLastUsedAt", ctx, apiToken.ID).Return(nil)
+
+		result, err := service.Authenticate(ctx, subdomain, token)
+		require.NoError(t, err)
+		assert.Equal(t, apiToken, result)
+	})
+}
@@ -0,0 +1,21 @@
+package apitoken
+
+import _ "embed"
+
+//go:embed sql/list_api_tokens.sql
+var listAPITokensQuery string
+
+//go:embed sql/get_api_token_by_org_subdomain_token_hash.sql
+var getAPITokenByOrgSubdomainTokenHashQuery string
+
+//go:embed sql/create_api_token.sql
+var createAPITokenQuery string
+
+//go:embed sql/delete_api_token.sql
+var deleteAPITokenQuery string
+
+//go:embed sql/update_api_token_last_used_at.sql
+var updateAPITokenLastUsedAtQuery string
+
+//go:embed sql/update_api_token_hash.sql
+var updateAPITokenHashQuery string
@@ -0,0 +1,26 @@
+-- createAPITokenQuery
+-- $1: user_id
+-- $2: name
+-- $3: token_hash
+-- $4: scopes
+-- $5: expires_at
+INSERT INTO
+    api_tokens(user_id, name, token_hash, scopes, expires_at)
+VALUES
+    ($1, $2, $3, $4, $5) RETURNING
+    api_token_id,
+    user_id,
+    (
+        SELECT
+            organization_id
+        FROM
+            users
+        WHERE
+            user_id = $1
+    ) AS organization_id,
+    name,
+    token_hash,
+    scopes,
+    expires_at,
+    last_used_at,
+    created_at;
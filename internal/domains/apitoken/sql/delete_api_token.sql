@@ -0,0 +1,8 @@
+-- deleteAPITokenQuery
+-- $1: user_id
+-- $2: api_token_id
+DELETE FROM
+    api_tokens
+WHERE
+    user_id = $1
+    AND api_token_id = $2 RETURNING api_token_id;
@@ -0,0 +1,23 @@
+-- getAPITokenByOrgSubdomainTokenHashQuery
+-- $1: subdomain
+-- $2: token_hash
+SELECT
+    t.api_token_id,
+    t.user_id,
+    u.organization_id,
+    t.name,
+    t.token_hash,
+    t.scopes,
+    t.expires_at,
+    t.last_used_at,
+    t.created_at
+FROM
+    api_tokens t
+    INNER JOIN users u ON t.user_id = u.user_id
+    INNER JOIN organizations o ON u.organization_id = o.organization_id
+WHERE
+    o.subdomain = $1
+    AND t.token_hash = $2
+    AND u.disabled_at IS NULL
+    AND u.deleted_at IS NULL
+    AND o.deleted_at IS NULL;
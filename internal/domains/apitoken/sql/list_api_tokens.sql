@@ -0,0 +1,20 @@
+-- listAPITokensQuery
+-- $1: user_id
+SELECT
+    t.api_token_id,
+    t.user_id,
+    u.organization_id,
+    t.name,
+    t.token_hash,
+    t.scopes,
+    t.expires_at,
+    t.last_used_at,
+    t.created_at
+FROM
+    api_tokens t
+    INNER JOIN users u ON t.user_id = u.user_id
+WHERE
+    t.user_id = $1
+ORDER BY
+    t.created_at DESC,
+    t.api_token_id DESC;
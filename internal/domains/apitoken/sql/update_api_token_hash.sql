@@ -0,0 +1,27 @@
+-- updateAPITokenHashQuery
+-- $1: user_id
+-- $2: api_token_id
+-- $3: token_hash
+UPDATE
+    api_tokens
+SET
+    token_hash = $3
+WHERE
+    user_id = $1
+    AND api_token_id = $2 RETURNING
+    api_token_id,
+    user_id,
+    (
+        SELECT
+            organization_id
+        FROM
+            users
+        WHERE
+            user_id = $1
+    ) AS organization_id,
+    name,
+    token_hash,
+    scopes,
+    expires_at,
+    last_used_at,
+    created_at;
@@ -0,0 +1,8 @@
+-- updateAPITokenLastUsedAtQuery
+-- $1: api_token_id
+UPDATE
+    api_tokens
+SET
+    last_used_at = NOW()
+WHERE
+    api_token_id = $1;
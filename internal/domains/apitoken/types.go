@@ -0,0 +1,102 @@
+package apitoken
+
+import (
+	"strings"
+	"time"
+)
+
+// The scopes grant an api token access to the endpoints of a resource.
+// The endpoints authenticated using a session are not restricted by the scopes.
+const (
+	ScopeOrganizationRead  = "organization:read"
+	ScopeOrganizationWrite = "organization:write"
+	ScopeUsersRead         = "users:read"
+	ScopeUsersWrite        = "users:write"
+	ScopeEmployeesRead     = "employees:read"
+	ScopeEmployeesWrite    = "employees:write"
+)
+
+const (
+	// CacheTTL is the duration for which an authenticated api token is cached.
+	// The last used time of the token is updated at most once within this duration.
+	CacheTTL = 5 * time.Minute
+
+	scopesSeparator = ","
+)
+
+// APIToken represents a named api token of a user.
+type APIToken struct {
+	// ID is the unique identifier of the api token.
+	ID int64 `db:"api_token_id"`
+
+	// UserID is the reference to the user the token belongs to.
+	UserID int64 `db:"user_id"`
+
+	// OrganizationID is the reference to the organization of the user.
+	OrganizationID int64 `db:"organization_id"`
+
+	// Name is the unique name of the token given by the user.
+	Name string `db:"name"`
+
+	// TokenHash is the sha256 hash of the token. The plaintext token is never stored.
+	TokenHash string `db:"token_hash"`
+
+	// Scopes is the comma separated list of the scopes granted to the token.
+	Scopes string `db:"scopes"`
+
+	// ExpiresAt is the timestamp after which the token is rejected.
+	ExpiresAt time.Time `db:"expires_at"`
+
+	// LastUsedAt is the timestamp when the token was last used to authenticate a request.
+	LastUsedAt *time.Time `db:"last_used_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ScopeList returns the scopes granted to the token.
+func (t APIToken) ScopeList() []string {
+	return strings.Split(t.Scopes, scopesSeparator)
+}
+
+// IsExpired returns true if the token is expired.
+func (t APIToken) IsExpired() bool {
+	return !time.Now().Before(t.ExpiresAt)
+}
+
+// IsValidScope returns true if the given scope is one of the known scopes.
+func IsValidScope(scope string) bool {
+	switch scope {
+	case ScopeOrganizationRead, ScopeOrganizationWrite, ScopeUsersRead, ScopeUsersWrite,
+		ScopeEmployeesRead, ScopeEmployeesWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateRequest represents the request payload to create an api token.
+type CreateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+
+	Scopes []string `json:"scopes" validate:"required,min=1,unique"`
+
+	ExpiresInDays int `json:"expires_in_days" validate:"required,min=1,max=365"`
+}
+
+// Response represents the response payload of an api token.
+type Response struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateResponse represents the response payload of a created api token.
+// The plaintext token is returned only once upon creation.
+type CreateResponse struct {
+	Response
+
+	Token string `json:"token"`
+}
@@ -3,17 +3,21 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/web/request"
 	"github.com/camelhr/camelhr-api/internal/web/response"
 )
 
-var ErrInvalidContext = errors.New("invalid context")
-
 type handler struct {
 	service Service
 }
@@ -42,13 +46,201 @@ func (h *handler) Register(w http.ResponseWriter, r *http.Request) {
 	response.Empty(w, http.StatusCreated)
 }
 
+// VerifyEmail verifies the email of a user using the verification token.
+func (h *handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var reqPayload VerifyEmailRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.VerifyEmail(r.Context(), reqPayload.Token); err != nil {
+		if errors.Is(err, ErrInvalidVerificationToken) || errors.Is(err, ErrVerificationTokenUsed) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// ForgotPassword sends a password reset link to the user.
+// The response is the same whether or not the user exists.
+func (h *handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ForgotPasswordRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.ForgotPassword(r.Context(), org.Subdomain, reqPayload.Email); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusAccepted)
+}
+
+// ResetPassword resets the password of a user using the password reset token.
+func (h *handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ResetPasswordRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	err = h.service.ResetPassword(r.Context(), org.Subdomain, reqPayload.Token, reqPayload.Password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidResetToken) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// RequestOrganizationRestore sends a link to restore the deleted organization to its owner.
+// The response is the same whether or not the organization or the owner exists.
+func (h *handler) RequestOrganizationRestore(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload OrganizationRestoreRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.RequestOrganizationRestore(r.Context(), org.Subdomain, reqPayload.Email); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusAccepted)
+}
+
+// ConfirmOrganizationRestore restores the deleted organization using the organization restore token.
+func (h *handler) ConfirmOrganizationRestore(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ConfirmOrganizationRestoreRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.ConfirmOrganizationRestore(r.Context(), org.Subdomain, reqPayload.Token); err != nil {
+		if errors.Is(err, ErrInvalidRestoreToken) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+			return
+		}
+
+		if errors.Is(err, ErrRestoreWindowExpired) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// RequestEmailChange mails a link to confirm the new email of the authenticated user.
+func (h *handler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ChangeEmailRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.RequestEmailChange(r.Context(), userID, orgID, reqPayload.Email); err != nil {
+		if errors.Is(err, ErrEmailAlreadyExists) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusAccepted)
+}
+
+// ConfirmEmailChange changes the email of a user using the email change token.
+func (h *handler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ConfirmEmailChangeRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.ConfirmEmailChange(r.Context(), org.Subdomain, reqPayload.Token); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidEmailChangeToken):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		case errors.Is(err, ErrEmailAlreadyExists):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+		default:
+			response.ErrorResponse(w, err)
+		}
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
 // Login logs in a user.
 func (h *handler) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	subdomain := request.URLParam(r, "subdomain")
-	if err := organization.ValidateSubdomain(subdomain); err != nil {
-		response.ErrorResponse(w, err)
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
 		return
 	}
 
@@ -63,41 +255,327 @@ func (h *handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the password policy is applied when the password is set. the login only requires a password
 	password := r.Form.Get("password")
-	if err := user.ValidatePassword(password); err != nil {
-		response.ErrorResponse(w, err)
+	if password == "" {
+		response.ErrorResponse(w, base.NewInputValidationError("password is required"))
 		return
 	}
 
 	rememberMe := r.Form.Get("remember_me") == "true"
 
-	jwt, ttl, err := h.service.Login(ctx, subdomain, email, password, rememberMe)
+	result, err := h.service.Login(ctx, org.Subdomain, email, password, rememberMe, session.NewDevice(r))
 	if err != nil {
+		if writeLockedError(w, err) {
+			return
+		}
+
 		if errors.Is(err, ErrInvalidCredentials) || errors.Is(err, ErrUserDisabled) {
 			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized)))
 			return
 		}
 
+		if errors.Is(err, ErrOrgSuspended) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+			return
+		}
+
 		response.ErrorResponse(w, err)
 
 		return
 	}
 
-	response.SetCookie(w, JWTCookieName, jwt, int(ttl.Seconds()))
+	writeLoginResult(w, result)
+}
+
+// ChangeExpiredPassword completes the login once the expired password of the user is changed.
+func (h *handler) ChangeExpiredPassword(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ExpiredPasswordRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result, err := h.service.ChangeExpiredPassword(r.Context(), org.Subdomain, reqPayload.Token, reqPayload.Password,
+		reqPayload.RememberMe, session.NewDevice(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPasswordChange), errors.Is(err, ErrUserDisabled):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized)))
+		case errors.Is(err, ErrOrgSuspended):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+		default:
+			response.ErrorResponse(w, err)
+		}
+
+		return
+	}
+
+	writeLoginResult(w, result)
+}
+
+// RequestMagicLink mails a one-time login link to the user.
+// The response is the same whether or not the user exists.
+func (h *handler) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload MagicLinkRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	browserBinding, err := h.service.RequestMagicLink(r.Context(), org.Subdomain, reqPayload.Email, reqPayload.RememberMe)
+	if err != nil {
+		if errors.Is(err, ErrMagicLinkDisabled) || errors.Is(err, ErrOrgSuspended) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	// the link works only in this browser
+	response.SetCookie(w, MagicLinkCookieName, browserBinding, int(MagicLinkTokenTTL.Seconds()))
+	response.Empty(w, http.StatusAccepted)
+}
+
+// MagicLinkCallback logs in the user using the magic link token and the browser binding cookie.
+func (h *handler) MagicLinkCallback(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload MagicLinkCallbackRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	// a missing cookie is rejected by the service as the link is bound to the browser
+	var browserBinding string
+	if cookie, err := r.Cookie(MagicLinkCookieName); err == nil {
+		browserBinding = cookie.Value
+	}
+
+	result, err := h.service.MagicLinkLogin(r.Context(), org.Subdomain, reqPayload.Token, browserBinding,
+		session.NewDevice(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidMagicLink), errors.Is(err, ErrUserDisabled):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized)))
+		case errors.Is(err, ErrMagicLinkDisabled), errors.Is(err, ErrOrgSuspended):
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+		default:
+			response.ErrorResponse(w, err)
+		}
+
+		return
+	}
+
+	response.RemoveCookie(w, MagicLinkCookieName)
+	writeLoginResult(w, result)
+}
+
+// SetupMFA starts the mfa enrollment during login when the organization requires mfa.
+func (h *handler) SetupMFA(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload MFASetupRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	enrollment, err := h.service.SetupMFA(r.Context(), org.Subdomain, reqPayload.MFAToken)
+	if err != nil {
+		response.ErrorResponse(w, mapMFAError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, enrollment)
+}
+
+// VerifyMFA completes the login by verifying the mfa code.
+func (h *handler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload MFAVerifyRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result, err := h.service.VerifyMFA(r.Context(), org.Subdomain, reqPayload.MFAToken, reqPayload.Code,
+		reqPayload.RememberMe, session.NewDevice(r))
+	if err != nil {
+		if writeLockedError(w, err) {
+			return
+		}
+
+		response.ErrorResponse(w, mapMFAError(err))
+
+		return
+	}
+
+	// the session cookie is set only after the expired password is changed
+	if result.PasswordChangeToken != "" {
+		response.JSON(w, http.StatusOK, MFAVerifyResponse{
+			RecoveryCodes:          result.RecoveryCodes,
+			PasswordChangeRequired: true,
+			PasswordChangeToken:    result.PasswordChangeToken,
+		})
+
+		return
+	}
+
+	setSessionCookies(w, result)
+
+	// the recovery codes are shown only once when the enrollment is completed during login
+	if len(result.RecoveryCodes) > 0 {
+		response.JSON(w, http.StatusOK, MFAVerifyResponse{RecoveryCodes: result.RecoveryCodes})
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// SSOAuthorize returns the url of the identity provider to start the sso login with.
+func (h *handler) SSOAuthorize(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	rememberMe := r.URL.Query().Get("remember_me") == "true"
+
+	authorizationURL, browserBinding, err := h.service.SSOAuthorize(r.Context(), org.Subdomain, rememberMe)
+	if err != nil {
+		response.ErrorResponse(w, mapSSOError(err))
+		return
+	}
+
+	// the login can be completed only in this browser
+	response.SetCookie(w, SSOCookieName, browserBinding, int(sso.StateTTL.Seconds()))
+	response.JSON(w, http.StatusOK, SSOAuthorizeResponse{AuthorizationURL: authorizationURL})
+}
+
+// SSOCallback completes the sso login using the code and the state returned by the identity provider
+// along with the browser binding cookie.
+func (h *handler) SSOCallback(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload SSOCallbackRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	// a missing cookie is rejected by the service as the login is bound to the browser
+	var browserBinding string
+	if cookie, err := r.Cookie(SSOCookieName); err == nil {
+		browserBinding = cookie.Value
+	}
+
+	result, err := h.service.SSOLogin(r.Context(), org.Subdomain, reqPayload.Code, reqPayload.State,
+		browserBinding, session.NewDevice(r))
+	if err != nil {
+		response.ErrorResponse(w, mapSSOError(err))
+		return
+	}
+
+	response.RemoveCookie(w, SSOCookieName)
+	setSessionCookies(w, result)
+	response.Empty(w, http.StatusOK)
+}
+
+// Refresh renews the session using the refresh token cookie.
+// It issues a new jwt and rotates the refresh token.
+func (h *handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	cookie, err := r.Cookie(RefreshTokenCookieName)
+	if err != nil || cookie.Value == "" {
+		response.ErrorResponse(w, base.WrapError(ErrInvalidRefreshToken,
+			base.ErrorHTTPStatus(http.StatusUnauthorized)))
+
+		return
+	}
+
+	result, err := h.service.Refresh(r.Context(), org.Subdomain, cookie.Value)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrUserDisabled) {
+			// the client must login again
+			response.RemoveCookie(w, JWTCookieName)
+			response.RemoveCookie(w, RefreshTokenCookieName)
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized)))
+
+			return
+		}
+
+		if errors.Is(err, ErrOrgSuspended) {
+			response.RemoveCookie(w, JWTCookieName)
+			response.RemoveCookie(w, RefreshTokenCookieName)
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden)))
+
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	setSessionCookies(w, result)
 	response.Empty(w, http.StatusOK)
 }
 
 // Logout logs out a user.
 func (h *handler) Logout(w http.ResponseWriter, r *http.Request) {
 	response.RemoveCookie(w, JWTCookieName)
+	response.RemoveCookie(w, RefreshTokenCookieName)
 
-	userID, orgID, err := h.extractUserIDOrgIDSubdomain(r)
+	userID, orgID, err := request.UserIDOrgID(r)
 	if err != nil {
 		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
 		return
 	}
 
-	if err := h.service.Logout(r.Context(), userID, orgID); err != nil {
+	// the session id is absent in the context when authenticated using api token
+	sessionID, _ := r.Context().Value(request.CtxSessionIDKey).(string)
+
+	if err := h.service.Logout(r.Context(), userID, orgID, sessionID); err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
@@ -105,18 +583,117 @@ func (h *handler) Logout(w http.ResponseWriter, r *http.Request) {
 	response.Empty(w, http.StatusOK)
 }
 
-// extractUserIDOrgIDSubdomain extracts the user id, org id and subdomain from the request context.
-func (h *handler) extractUserIDOrgIDSubdomain(r *http.Request) (int64, int64, error) {
-	// return userID, orgID, subdomain from the request context
-	userID, ok := r.Context().Value(request.CtxUserIDKey).(int64)
-	if !ok {
-		return 0, 0, fmt.Errorf("user id not found in the request context: %w", ErrInvalidContext)
+// UnlockUser clears the login lockout of a user of the organization.
+func (h *handler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	userID, err := request.URLParamID(r, "userID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
 	}
 
-	orgID, ok := r.Context().Value(request.CtxOrgIDKey).(int64)
-	if !ok {
-		return 0, 0, fmt.Errorf("org id not found in the request context: %w", ErrInvalidContext)
+	if err := h.service.UnlockUser(r.Context(), orgID, userID); err != nil {
+		response.ErrorResponse(w, err)
+		return
 	}
 
-	return userID, orgID, nil
+	response.Empty(w, http.StatusOK)
+}
+
+// JWKS publishes the public keys to verify the access tokens.
+// It allows other services to validate the access tokens without sharing a secret.
+func (h *handler) JWKS(w http.ResponseWriter, _ *http.Request) {
+	const maxAgeInSeconds = 300 // 5 minutes
+
+	// the key set changes only on key rotation. let the clients cache it for a while
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAgeInSeconds))
+	response.JSON(w, http.StatusOK, h.service.JWKS())
+}
+
+// writeLoginResult sets the session cookies or responds with the mfa challenge when the mfa step is required.
+// It responds with the password change token instead when the expired password must be changed.
+func writeLoginResult(w http.ResponseWriter, result LoginResult) {
+	if result.PasswordChangeToken != "" {
+		response.JSON(w, http.StatusOK, PasswordChangeRequiredResponse{
+			PasswordChangeRequired: true,
+			PasswordChangeToken:    result.PasswordChangeToken,
+		})
+
+		return
+	}
+
+	// the session cookie is set only after the mfa step is completed
+	if result.MFAToken != "" {
+		response.JSON(w, http.StatusOK, MFAChallengeResponse{
+			MFARequired:           true,
+			MFAToken:              result.MFAToken,
+			MFAEnrollmentRequired: result.MFAEnrollmentRequired,
+		})
+
+		return
+	}
+
+	setSessionCookies(w, result)
+	response.Empty(w, http.StatusOK)
+}
+
+// setSessionCookies sets the jwt and the refresh token cookies.
+// Both the cookies live as long as the session so that an expired jwt can be renewed.
+func setSessionCookies(w http.ResponseWriter, result LoginResult) {
+	response.SetCookie(w, JWTCookieName, result.JWT, int(result.TTL.Seconds()))
+	response.SetCookie(w, RefreshTokenCookieName, result.RefreshToken, int(result.TTL.Seconds()))
+}
+
+// writeLockedError responds with too many requests when the login is locked.
+// It returns false without writing the response for any other error.
+func writeLockedError(w http.ResponseWriter, err error) bool {
+	var lockedErr *lockout.LockedError
+	if !errors.As(err, &lockedErr) {
+		return false
+	}
+
+	// let the client know when the login can be attempted again
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+	response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusTooManyRequests)))
+
+	return true
+}
+
+// mapMFAError sets the http status of the errors returned by the mfa step of the login.
+func mapMFAError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidMFAToken), errors.Is(err, ErrUserDisabled), errors.Is(err, mfa.ErrInvalidCode):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized))
+	case errors.Is(err, mfa.ErrAlreadyEnabled), errors.Is(err, mfa.ErrNotEnrolled):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest))
+	case errors.Is(err, ErrOrgSuspended):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	default:
+		return err
+	}
+}
+
+// mapSSOError sets the http status of the known sso login errors.
+// The details of the identity provider errors are logged but not sent in the response.
+func mapSSOError(err error) error {
+	switch {
+	case errors.Is(err, sso.ErrIdentityProvider):
+		return base.NewAPIError(sso.ErrIdentityProvider.Error(), base.ErrorCause(err),
+			base.ErrorHTTPStatus(http.StatusBadGateway))
+	case errors.Is(err, sso.ErrInvalidIDToken):
+		return base.NewAPIError(sso.ErrInvalidIDToken.Error(), base.ErrorCause(err),
+			base.ErrorHTTPStatus(http.StatusUnauthorized))
+	case errors.Is(err, sso.ErrInvalidState), errors.Is(err, sso.ErrEmailNotVerified),
+		errors.Is(err, sso.ErrEmailNotAllowed), errors.Is(err, ErrSSOUserNotFound), errors.Is(err, ErrUserDisabled):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized))
+	case errors.Is(err, ErrOrgSuspended):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	default:
+		return err
+	}
 }
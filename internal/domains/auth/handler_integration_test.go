@@ -1,6 +1,8 @@
 package auth_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -8,14 +10,21 @@ import (
 	"strings"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/tests"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/camelhr/camelhr-api/internal/web"
 )
 
 const (
-	loginPathFormat  = "/api/v1/subdomains/%s/auth/login"
-	logoutPathFormat = "/api/v1/subdomains/%s/auth/logout"
+	loginPathFormat        = "/api/v1/subdomains/%s/auth/login"
+	logoutPathFormat       = "/api/v1/subdomains/%s/auth/logout"
+	ssoAuthorizePathFormat = "/api/v1/subdomains/%s/auth/sso/authorize"
+	ssoCallbackPathFormat  = "/api/v1/subdomains/%s/auth/sso/callback"
 )
 
 func (s *AuthTestSuite) TestHandlerIntegration_Register() {
@@ -33,7 +42,7 @@ func (s *AuthTestSuite) TestHandlerIntegration_Register() {
 		s.Require().NoError(err)
 
 		rr := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(rr, req)
 
 		// assert the response
@@ -60,7 +69,7 @@ func (s *AuthTestSuite) TestHandlerIntegration_Login() {
 		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 		rr := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(rr, req)
 
 		// assert the response
@@ -68,6 +77,70 @@ func (s *AuthTestSuite) TestHandlerIntegration_Login() {
 		s.Empty(rr.Body.String())
 		s.Contains(rr.Header().Get("Set-Cookie"), auth.JWTCookieName)
 	})
+
+	s.Run("should reject the login of a suspended organization", func() {
+		s.T().Parallel()
+
+		// create a suspended organization and a user
+		o := fake.NewOrganization(s.DB, fake.OrganizationSuspended())
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", u.Email)
+		form.Add("password", validPassword)
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(loginPathFormat, o.Subdomain),
+			strings.NewReader(form.Encode()))
+		s.Require().NoError(err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		rr := httptest.NewRecorder()
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
+		h.ServeHTTP(rr, req)
+
+		// assert the response
+		s.Require().Equal(http.StatusForbidden, rr.Code)
+		s.JSONEq(`{"error":"organization is suspended"}`, rr.Body.String())
+	})
+
+	s.Run("should lock the login after too many failed attempts", func() {
+		s.T().Parallel()
+
+		// create an organization and a user
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		conf := s.Config
+		conf.LoginMaxFailedAttempts = 2
+		conf.LoginFailedAttemptsWindow = 60
+		conf.LoginLockoutDuration = 60
+		conf.LoginMaxLockoutDuration = 60
+		h := web.SetupRoutes(s.DB, s.RedisClient, conf, s.JWTKeys)
+
+		login := func(password string) *httptest.ResponseRecorder {
+			form := url.Values{}
+			form.Add("email", u.Email)
+			form.Add("password", password)
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(loginPathFormat, o.Subdomain),
+				strings.NewReader(form.Encode()))
+			s.Require().NoError(err)
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			return rr
+		}
+
+		s.Require().Equal(http.StatusUnauthorized, login(validPassword+"1").Code)
+		s.Require().Equal(http.StatusUnauthorized, login(validPassword+"2").Code)
+
+		// the correct password is rejected as well while locked
+		rr := login(validPassword)
+		s.Require().Equal(http.StatusTooManyRequests, rr.Code)
+		s.NotEmpty(rr.Header().Get("Retry-After"))
+		s.JSONEq(`{"error":"too many failed login attempts. try again later"}`, rr.Body.String())
+	})
 }
 
 func (s *AuthTestSuite) TestHandlerIntegration_Logout() {
@@ -89,7 +162,7 @@ func (s *AuthTestSuite) TestHandlerIntegration_Logout() {
 
 		// login
 		loginRR := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(loginRR, loginReq)
 
 		// assert the login response
@@ -113,3 +186,199 @@ func (s *AuthTestSuite) TestHandlerIntegration_Logout() {
 			"jwt_session_id=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict")
 	})
 }
+
+func (s *AuthTestSuite) TestHandlerIntegration_SSOLogin() {
+	// login runs the whole sso flow against a local identity provider and returns the callback response.
+	// the callback is sent by another browser without the browser binding cookie unless sameBrowser is set
+	login := func(provider *tests.OIDCProvider, subdomain, email string, sameBrowser bool) *httptest.ResponseRecorder {
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
+
+		authorizeReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf(ssoAuthorizePathFormat, subdomain), nil)
+		s.Require().NoError(err)
+
+		authorizeRR := httptest.NewRecorder()
+		h.ServeHTTP(authorizeRR, authorizeReq)
+		s.Require().Equal(http.StatusOK, authorizeRR.Code)
+
+		var authorizeResp auth.SSOAuthorizeResponse
+		s.Require().NoError(json.Unmarshal(authorizeRR.Body.Bytes(), &authorizeResp))
+
+		authorizationURL, err := url.Parse(authorizeResp.AuthorizationURL)
+		s.Require().NoError(err)
+
+		// the user logs in at the identity provider which redirects back with the code and the state
+		code, err := provider.Authorize(authorizeResp.AuthorizationURL, email)
+		s.Require().NoError(err)
+
+		callbackReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf(ssoCallbackPathFormat, subdomain),
+			strings.NewReader(fmt.Sprintf(`{"code":"%s","state":"%s"}`, code,
+				authorizationURL.Query().Get("state"))))
+		s.Require().NoError(err)
+
+		if sameBrowser {
+			for _, cookie := range authorizeRR.Result().Cookies() {
+				callbackReq.AddCookie(cookie)
+			}
+		}
+
+		callbackRR := httptest.NewRecorder()
+		h.ServeHTTP(callbackRR, callbackReq)
+
+		return callbackRR
+	}
+
+	// configure creates the sso configuration of the organization using the identity provider
+	configure := func(orgID int64, provider *tests.OIDCProvider, domain string, jit bool) {
+		_, err := sso.NewRepository(s.DB).UpsertConfig(context.Background(), sso.Config{
+			OrganizationID:      orgID,
+			Issuer:              provider.Issuer,
+			ClientID:            provider.ClientID,
+			ClientSecret:        provider.ClientSecret,
+			AllowedEmailDomains: domain,
+			JITProvisioning:     jit,
+		})
+		s.Require().NoError(err)
+	}
+
+	s.Run("should login an existing user", func() {
+		s.T().Parallel()
+
+		provider, err := tests.NewOIDCProvider()
+		s.Require().NoError(err)
+		defer provider.Close()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		configure(o.ID, provider, strings.ToLower(strings.Split(u.Email, "@")[1]), false)
+
+		rr := login(provider, o.Subdomain, u.Email, true)
+
+		s.Require().Equal(http.StatusOK, rr.Code)
+		s.Empty(rr.Body.String())
+		s.Contains(rr.Header().Get("Set-Cookie"), auth.JWTCookieName)
+	})
+
+	s.Run("should reject the login completed by another browser", func() {
+		s.T().Parallel()
+
+		provider, err := tests.NewOIDCProvider()
+		s.Require().NoError(err)
+		defer provider.Close()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		configure(o.ID, provider, strings.ToLower(strings.Split(u.Email, "@")[1]), false)
+
+		rr := login(provider, o.Subdomain, u.Email, false)
+
+		s.Require().Equal(http.StatusUnauthorized, rr.Code)
+		s.JSONEq(`{"error":"sso state is invalid or expired"}`, rr.Body.String())
+		s.Empty(rr.Header().Get("Set-Cookie"))
+	})
+
+	s.Run("should reject an unknown user when jit provisioning is disabled", func() {
+		s.T().Parallel()
+
+		provider, err := tests.NewOIDCProvider()
+		s.Require().NoError(err)
+		defer provider.Close()
+
+		o := fake.NewOrganization(s.DB)
+		domain := gofakeit.DomainName()
+		configure(o.ID, provider, domain, false)
+
+		rr := login(provider, o.Subdomain, gofakeit.Username()+"@"+domain, true)
+
+		s.Require().Equal(http.StatusUnauthorized, rr.Code)
+		s.JSONEq(`{"error":"user is not a member of the organization"}`, rr.Body.String())
+	})
+
+	s.Run("should provision an unknown user when jit provisioning is enabled", func() {
+		s.T().Parallel()
+
+		provider, err := tests.NewOIDCProvider()
+		s.Require().NoError(err)
+		defer provider.Close()
+
+		o := fake.NewOrganization(s.DB)
+		domain := gofakeit.DomainName()
+		email := gofakeit.Username() + "@" + domain
+		configure(o.ID, provider, domain, true)
+
+		rr := login(provider, o.Subdomain, email, true)
+
+		s.Require().Equal(http.StatusOK, rr.Code)
+		s.Contains(rr.Header().Get("Set-Cookie"), auth.JWTCookieName)
+
+		// the provisioned user can not login using a password
+		u, err := user.NewRepository(s.DB).GetUserByOrgIDEmail(context.Background(), o.ID, email)
+		s.Require().NoError(err)
+		s.True(u.IsEmailVerified)
+		s.Empty(u.PasswordHash)
+	})
+
+	s.Run("should reject an email of a domain that is not allowed", func() {
+		s.T().Parallel()
+
+		provider, err := tests.NewOIDCProvider()
+		s.Require().NoError(err)
+		defer provider.Close()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		configure(o.ID, provider, gofakeit.DomainName(), true)
+
+		rr := login(provider, o.Subdomain, u.Email, true)
+
+		s.Require().Equal(http.StatusUnauthorized, rr.Code)
+		s.JSONEq(`{"error":"email domain is not allowed to login using sso"}`, rr.Body.String())
+	})
+}
+
+func (s *AuthTestSuite) TestHandlerIntegration_Impersonation() {
+	s.Run("should reject the deletion of the organization by an impersonating operator", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserIsOwner())
+		s.Require().NoError(organization.NewRepository(s.DB).SetImpersonationAllowed(ctx, o.ID, true))
+
+		adminAPIKey := gofakeit.UUID()
+		conf := s.Config
+		conf.AdminAPIKeys = "john:" + base.HashToken(adminAPIKey)
+		h := web.SetupRoutes(s.DB, s.RedisClient, conf, s.JWTKeys)
+
+		// impersonate the owner of the organization
+		req, err := http.NewRequest(http.MethodPost,
+			fmt.Sprintf("/api/v1/admin/organizations/%d/users/%d/impersonate", o.ID, u.ID),
+			strings.NewReader(`{"comment":"investigate the support ticket"}`))
+		s.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer "+adminAPIKey)
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		s.Require().Equal(http.StatusOK, rr.Code)
+
+		var impersonation struct {
+			AccessToken string `json:"access_token"`
+		}
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &impersonation))
+
+		// delete the organization using the impersonation session
+		req, err = http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/subdomains/%s/organizations", o.Subdomain),
+			strings.NewReader(`{"comment":"delete the organization"}`))
+		s.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer "+impersonation.AccessToken)
+
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+
+		s.Require().Equal(http.StatusForbidden, rr.Code)
+		s.JSONEq(`{"error":"impersonation is not allowed to modify the data"}`, rr.Body.String())
+
+		result, err := organization.NewRepository(s.DB).GetOrganizationByID(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Nil(result.DeletedAt)
+	})
+}
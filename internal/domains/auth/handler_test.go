@@ -6,23 +6,46 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/tests"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/camelhr/camelhr-api/internal/web/request"
-	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	registerPath = "/api/v1/auth/register"
-	loginPath    = "/api/v1/subdomains/{subdomain}/auth/login"
-	logoutPath   = "/api/v1/subdomains/{subdomain}/auth/logout"
+	registerPath          = "/api/v1/auth/register"
+	verifyEmailPath       = "/api/v1/auth/verify-email"
+	loginPath             = "/api/v1/subdomains/{subdomain}/auth/login"
+	forgotPasswordPath    = "/api/v1/subdomains/{subdomain}/auth/forgot-password"
+	resetPasswordPath     = "/api/v1/subdomains/{subdomain}/auth/reset-password"
+	confirmEmailPath      = "/api/v1/subdomains/{subdomain}/auth/confirm-email-change"
+	restoreOrgPath        = "/api/v1/subdomains/{subdomain}/auth/restore-organization"
+	confirmRestorePath    = "/api/v1/subdomains/{subdomain}/auth/restore-organization/confirm"
+	changeEmailPath       = "/api/v1/subdomains/{subdomain}/me/email"
+	refreshPath           = "/api/v1/subdomains/{subdomain}/auth/refresh"
+	logoutPath            = "/api/v1/subdomains/{subdomain}/auth/logout"
+	unlockUserPath        = "/api/v1/subdomains/{subdomain}/users/{userID}/unlock"
+	mfaSetupPath          = "/api/v1/subdomains/{subdomain}/auth/mfa/setup"
+	mfaVerifyPath         = "/api/v1/subdomains/{subdomain}/auth/mfa/verify"
+	ssoAuthorizePath      = "/api/v1/subdomains/{subdomain}/auth/sso/authorize"
+	ssoCallbackPath       = "/api/v1/subdomains/{subdomain}/auth/sso/callback"
+	magicLinkPath         = "/api/v1/subdomains/{subdomain}/auth/magic-link"
+	magicLinkCallbackPath = "/api/v1/subdomains/{subdomain}/auth/magic-link/callback"
+	expiredPasswordPath   = "/api/v1/subdomains/{subdomain}/auth/expired-password"
 )
 
 func TestHandler_Register(t *testing.T) {
@@ -70,6 +93,10 @@ func TestHandler_Register(t *testing.T) {
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
+		// the password policy is applied by the service
+		mockService.On("Register", req.Context(), email, password, subdomain, orgName).
+			Return(base.NewInputValidationError("password must be at least 8 characters in length"))
+
 		// call the handler
 		handler.Register(rr, req)
 
@@ -185,421 +212,1888 @@ func TestHandler_Register(t *testing.T) {
 	})
 }
 
-func TestHandler_Login(t *testing.T) {
+func TestHandler_VerifyEmail(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should return error when subdomain is invalid", func(t *testing.T) {
+	t.Run("should return error when token is missing", func(t *testing.T) {
 		t.Parallel()
 
-		subdomain := "invalid subdomain"
-		req, err := http.NewRequest(http.MethodPost, loginPath, nil)
+		req, err := http.NewRequest(http.MethodPost, verifyEmailPath, strings.NewReader(`{"token":""}`))
 		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
-
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.VerifyEmail(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.JSONEq(t, `{"error":"subdomain can only contain alphanumeric characters"}`, rr.Body.String())
+		assert.JSONEq(t, `{"error":"token is a required field"}`, rr.Body.String())
 	})
 
-	t.Run("should return error when email is invalid", func(t *testing.T) {
+	t.Run("should return bad request when token is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		email := "invalid email"
-		password := validPassword
-		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		token := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, verifyEmailPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s"}`, token)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyEmail", fake.MockContext, token).Return(auth.ErrInvalidVerificationToken)
+
+		// call the handler
+		handler.VerifyEmail(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"verification token is invalid or expired"}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request when token is already used", func(t *testing.T) {
+		t.Parallel()
+
+		token := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, verifyEmailPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s"}`, token)))
+		require.NoError(t, err)
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
+		// mock the service calls
+		mockService.On("VerifyEmail", fake.MockContext, token).Return(auth.ErrVerificationTokenUsed)
+
 		// call the handler
-		handler.Login(rr, req)
+		handler.VerifyEmail(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.JSONEq(t, `{"error":"email must be a valid email address"}`, rr.Body.String())
+		assert.JSONEq(t, `{"error":"verification token has already been used"}`, rr.Body.String())
 	})
 
-	t.Run("should return error when password is invalid", func(t *testing.T) {
+	t.Run("should verify email", func(t *testing.T) {
 		t.Parallel()
 
-		email := gofakeit.Email()
-		password := "@2nR"
-		subdomain := gofakeit.LetterN(30)
+		token := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, verifyEmailPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s"}`, token)))
+		require.NoError(t, err)
 
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyEmail", fake.MockContext, token).Return(nil)
+
+		// call the handler
+		handler.VerifyEmail(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, forgotPasswordPath, strings.NewReader(`{"email":"invalid email"}`))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{Subdomain: gofakeit.LetterN(30)}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ForgotPassword(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.JSONEq(t, `{"error":"password must be at least 8 characters in length"}`, rr.Body.String())
+		assert.JSONEq(t, `{"error":"email must be a valid email address"}`, rr.Body.String())
 	})
 
 	t.Run("should return error when service call fails", func(t *testing.T) {
 		t.Parallel()
 
 		email := gofakeit.Email()
-		password := validPassword
 		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		req, err := http.NewRequest(http.MethodPost, forgotPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("Login", fake.MockContext, subdomain, email, password, false).
-			Return("", time.Duration(0), assert.AnError)
+		mockService.On("ForgotPassword", fake.MockContext, subdomain, email).Return(assert.AnError)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ForgotPassword(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusInternalServerError, rr.Code)
 		assert.JSONEq(t, `{"error":""}`, rr.Body.String())
 	})
 
-	t.Run("should return unauthorized when invalid credentials", func(t *testing.T) {
+	t.Run("should accept the request", func(t *testing.T) {
 		t.Parallel()
 
 		email := gofakeit.Email()
-		password := validPassword
 		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		req, err := http.NewRequest(http.MethodPost, forgotPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("Login", fake.MockContext, subdomain, email, password, false).
-			Return("", time.Duration(0), auth.ErrInvalidCredentials)
+		mockService.On("ForgotPassword", fake.MockContext, subdomain, email).Return(nil)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ForgotPassword(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusUnauthorized, rr.Code)
-		assert.JSONEq(t, `{"error":"email or password is invalid"}`, rr.Body.String())
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Empty(t, rr.Body.String())
 	})
+}
 
-	t.Run("should return unauthorized when user is disabled", func(t *testing.T) {
+func TestHandler_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when password is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		email := gofakeit.Email()
-		password := validPassword
+		token := gofakeit.UUID()
 		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		req, err := http.NewRequest(http.MethodPost, resetPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"@2nR"}`, token)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
-		// mock the service calls
-		mockService.On("Login", fake.MockContext, subdomain, email, password, false).
-			Return("", time.Duration(0), auth.ErrUserDisabled)
+		// the password policy is applied by the service
+		mockService.On("ResetPassword", req.Context(), subdomain, token, "@2nR").
+			Return(base.NewInputValidationError("password must be at least 8 characters in length"))
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ResetPassword(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusUnauthorized, rr.Code)
-		assert.JSONEq(t, `{"error":"user is disabled"}`, rr.Body.String())
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"password must be at least 8 characters in length"}`, rr.Body.String())
 	})
 
-	t.Run("should login", func(t *testing.T) {
+	t.Run("should return bad request when token is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		jwt := gofakeit.UUID()
-		email := gofakeit.Email()
-		password := validPassword
+		token := gofakeit.UUID()
 		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		req, err := http.NewRequest(http.MethodPost, resetPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"%s"}`, token, validPassword)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("Login", fake.MockContext, subdomain, email, password, false).
-			Return(jwt, auth.DefaultSessionTTL, nil)
+		mockService.On("ResetPassword", fake.MockContext, subdomain, token, validPassword).
+			Return(auth.ErrInvalidResetToken)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ResetPassword(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusOK, rr.Code)
-		assert.Empty(t, rr.Body.String())
-		assert.Equal(
-			t,
-			fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
-				jwt, int(auth.DefaultSessionTTL.Seconds())),
-			rr.Header().Get("Set-Cookie"),
-		)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"password reset token is invalid or expired"}`, rr.Body.String())
 	})
 
-	t.Run("should login with remember-me", func(t *testing.T) {
+	t.Run("should reset password", func(t *testing.T) {
 		t.Parallel()
 
-		jwt := gofakeit.UUID()
-		email := gofakeit.Email()
-		password := validPassword
+		token := gofakeit.UUID()
 		subdomain := gofakeit.LetterN(30)
-
-		// create url-encoded form data
-		form := url.Values{}
-		form.Add("email", email)
-		form.Add("password", password)
-		form.Add("remember_me", "true")
-		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		req, err := http.NewRequest(http.MethodPost, resetPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"%s"}`, token, validPassword)))
 		require.NoError(t, err)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("Login", fake.MockContext, subdomain, email, password, true).
-			Return(jwt, auth.RememberMeSessionTTL, nil)
+		mockService.On("ResetPassword", fake.MockContext, subdomain, token, validPassword).Return(nil)
 
 		// call the handler
-		handler.Login(rr, req)
+		handler.ResetPassword(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusOK, rr.Code)
 		assert.Empty(t, rr.Body.String())
-		assert.Equal(
-			t,
-			fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
-				jwt, int(auth.RememberMeSessionTTL.Seconds())),
-			rr.Header().Get("Set-Cookie"),
-		)
 	})
 }
 
-func TestHandler_Logout(t *testing.T) {
+func TestHandler_RequestOrganizationRestore(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should logout successfully", func(t *testing.T) {
+	t.Run("should return error when email is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		req, err := http.NewRequest(http.MethodPost, restoreOrgPath,
+			strings.NewReader(`{"email":"invalid email"}`))
 		require.NoError(t, err)
 
-		// set required values in request context
-		subdomain := gofakeit.LetterN(30)
-		userID := gofakeit.Int64()
-		orgID := gofakeit.Int64()
-		ctx := req.Context()
-		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
-		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
-		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
-
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		ctx = context.WithValue(ctx, chi.RouteCtxKey, routeContext)
-		req = req.WithContext(ctx)
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{Subdomain: gofakeit.LetterN(30)}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
-		// mock the service calls
-		mockService.On("Logout", fake.MockContext, userID, orgID).Return(nil)
-
 		// call the handler
-		handler.Logout(rr, req)
+		handler.RequestOrganizationRestore(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusOK, rr.Code)
-		assert.Equal(
-			t,
-			"jwt_session_id=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
-			rr.Header().Get("Set-Cookie"),
-		)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"email must be a valid email address"}`, rr.Body.String())
 	})
 
-	t.Run("should return error when service call fails", func(t *testing.T) {
+	t.Run("should accept the request", func(t *testing.T) {
 		t.Parallel()
 
-		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
-		require.NoError(t, err)
-
-		// set required values in request context
+		email := gofakeit.Email()
 		subdomain := gofakeit.LetterN(30)
-		userID := gofakeit.Int64()
-		orgID := gofakeit.Int64()
-		ctx := req.Context()
-		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
-		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
-		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
+		req, err := http.NewRequest(http.MethodPost, restoreOrgPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
+		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		ctx = context.WithValue(ctx, chi.RouteCtxKey, routeContext)
-		req = req.WithContext(ctx)
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("Logout", fake.MockContext, userID, orgID).Return(assert.AnError)
+		mockService.On("RequestOrganizationRestore", fake.MockContext, subdomain, email).Return(nil)
 
 		// call the handler
-		handler.Logout(rr, req)
+		handler.RequestOrganizationRestore(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.JSONEq(t, `{"error":""}`, rr.Body.String())
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Empty(t, rr.Body.String())
 	})
+}
 
-	t.Run("should return bad request when user-id is not found in the request context", func(t *testing.T) {
+func TestHandler_ConfirmOrganizationRestore(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "should return bad request when token is invalid", serviceErr: auth.ErrInvalidRestoreToken,
+			expectedCode: http.StatusBadRequest},
+		{name: "should return conflict when restore window has ended", serviceErr: auth.ErrRestoreWindowExpired,
+			expectedCode: http.StatusConflict},
+		{name: "should restore the organization", expectedCode: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token := gofakeit.UUID()
+			subdomain := gofakeit.LetterN(30)
+			req, err := http.NewRequest(http.MethodPost, confirmRestorePath,
+				strings.NewReader(fmt.Sprintf(`{"token":"%s"}`, token)))
+			require.NoError(t, err)
+
+			// set the organization resolved by the tenant middleware
+			req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+			mockService := auth.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := auth.NewHandler(mockService)
+
+			// mock the service calls
+			mockService.On("ConfirmOrganizationRestore", fake.MockContext, subdomain, token).Return(tc.serviceErr)
+
+			// call the handler
+			handler.ConfirmOrganizationRestore(rr, req)
+
+			// check the result
+			require.Equal(t, tc.expectedCode, rr.Code)
+
+			if tc.serviceErr != nil {
+				assert.JSONEq(t, fmt.Sprintf(`{"error":"%s"}`, tc.serviceErr.Error()), rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_RequestEmailChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the email is invalid", func(t *testing.T) {
 		t.Parallel()
 
-		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		req, err := http.NewRequest(http.MethodPut, changeEmailPath, strings.NewReader(`{"email":"invalid"}`))
 		require.NoError(t, err)
-
-		// set required values in request context
-		subdomain := gofakeit.LetterN(30)
-		orgID := gofakeit.Int64()
-		ctx := req.Context()
-		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
-		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
-		req = req.WithContext(ctx)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
 		// call the handler
-		handler.Logout(rr, req)
+		handler.RequestEmailChange(rr, req)
 
 		// check the result
 		require.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.JSONEq(t, `{"error":"user id not found in the request context: invalid context"}`, rr.Body.String())
 	})
 
-	t.Run("should return bad request when org-id is not found in the request context", func(t *testing.T) {
+	t.Run("should return conflict when the email is used by another user", func(t *testing.T) {
 		t.Parallel()
 
-		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		email := gofakeit.Email()
+		req, err := http.NewRequest(http.MethodPut, changeEmailPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
 		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("RequestEmailChange", fake.MockContext, userID, orgID, email).
+			Return(auth.ErrEmailAlreadyExists)
+
+		// call the handler
+		handler.RequestEmailChange(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusConflict, rr.Code)
+		assert.JSONEq(t, `{"error":"email is already used by another user of the organization"}`, rr.Body.String())
+	})
+
+	t.Run("should accept the email change request", func(t *testing.T) {
+		t.Parallel()
 
-		// set required values in request context
-		subdomain := gofakeit.LetterN(30)
 		userID := gofakeit.Int64()
-		ctx := req.Context()
-		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
-		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
-		req = req.WithContext(ctx)
+		orgID := gofakeit.Int64()
+		email := gofakeit.Email()
+		req, err := http.NewRequest(http.MethodPut, changeEmailPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
 
 		mockService := auth.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := auth.NewHandler(mockService)
 
+		// mock the service calls
+		mockService.On("RequestEmailChange", fake.MockContext, userID, orgID, email).Return(nil)
+
 		// call the handler
-		handler.Logout(rr, req)
+		handler.RequestEmailChange(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusBadRequest, rr.Code)
-		assert.JSONEq(t, `{"error":"org id not found in the request context: invalid context"}`, rr.Body.String())
+		require.Equal(t, http.StatusAccepted, rr.Code)
+	})
+}
+
+func TestHandler_ConfirmEmailChange(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "should return bad request when token is invalid", err: auth.ErrInvalidEmailChangeToken,
+			code: http.StatusBadRequest},
+		{name: "should return conflict when the email was taken", err: auth.ErrEmailAlreadyExists,
+			code: http.StatusConflict},
+		{name: "should confirm the email change", err: nil, code: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token := gofakeit.UUID()
+			subdomain := gofakeit.LetterN(30)
+			req, err := http.NewRequest(http.MethodPost, confirmEmailPath,
+				strings.NewReader(fmt.Sprintf(`{"token":"%s"}`, token)))
+			require.NoError(t, err)
+
+			// set the organization resolved by the tenant middleware
+			req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+			mockService := auth.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := auth.NewHandler(mockService)
+
+			// mock the service calls
+			mockService.On("ConfirmEmailChange", fake.MockContext, subdomain, token).Return(tc.err)
+
+			// call the handler
+			handler.ConfirmEmailChange(rr, req)
+
+			// check the result
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Login(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization is not in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, loginPath, nil)
+		require.NoError(t, err)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"organization not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return error when email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		email := "invalid email"
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"email must be a valid email address"}`, rr.Body.String())
+	})
+
+	t.Run("should return error when password is empty", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		password := ""
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"password is required"}`, rr.Body.String())
+	})
+
+	t.Run("should return error when service call fails", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{}, assert.AnError)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.JSONEq(t, `{"error":""}`, rr.Body.String())
+	})
+
+	t.Run("should return too many requests with retry-after when login is locked", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{}, &lockout.LockedError{RetryAfter: 90*time.Second + time.Millisecond})
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.JSONEq(t, `{"error":"too many failed login attempts. try again later"}`, rr.Body.String())
+		assert.Equal(t, "91", rr.Header().Get("Retry-After"))
+	})
+
+	t.Run("should return unauthorized when invalid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{}, auth.ErrInvalidCredentials)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"email or password is invalid"}`, rr.Body.String())
+	})
+
+	t.Run("should return unauthorized when user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{}, auth.ErrUserDisabled)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"user is disabled"}`, rr.Body.String())
+	})
+
+	t.Run("should return forbidden when the organization is suspended", func(t *testing.T) {
+		t.Parallel()
+
+		email := gofakeit.Email()
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", validPassword)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, validPassword, false, session.Device{}).
+			Return(auth.LoginResult{}, auth.ErrOrgSuspended)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		assert.JSONEq(t, `{"error":"organization is suspended"}`, rr.Body.String())
+	})
+
+	t.Run("should login", func(t *testing.T) {
+		t.Parallel()
+
+		jwt := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the client device metadata
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+		req.Header.Set("User-Agent", device.UserAgent)
+		req.RemoteAddr = device.IP + ":12345"
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, device).
+			Return(auth.LoginResult{JWT: jwt, RefreshToken: refreshToken, TTL: auth.DefaultSessionTTL}, nil)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(
+			t,
+			[]string{
+				fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+					jwt, int(auth.DefaultSessionTTL.Seconds())),
+				fmt.Sprintf("refresh_token=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+					refreshToken, int(auth.DefaultSessionTTL.Seconds())),
+			},
+			rr.Header().Values("Set-Cookie"),
+		)
+	})
+
+	t.Run("should login with remember-me", func(t *testing.T) {
+		t.Parallel()
+
+		jwt := gofakeit.UUID()
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		form.Add("remember_me", "true")
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, true, session.Device{}).
+			Return(auth.LoginResult{JWT: jwt, TTL: auth.RememberMeSessionTTL}, nil)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(
+			t,
+			fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+				jwt, int(auth.RememberMeSessionTTL.Seconds())),
+			rr.Header().Get("Set-Cookie"),
+		)
+	})
+
+	t.Run("should return the mfa challenge without session cookie", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{MFAToken: mfaToken, MFAEnrollmentRequired: true}, nil)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"mfa_required":true,"mfa_token":"%s","mfa_enrollment_required":true}`,
+			mfaToken), rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+	t.Run("should return the password change token when the password has expired", func(t *testing.T) {
+		t.Parallel()
+
+		token := gofakeit.UUID()
+		email := gofakeit.Email()
+		password := validPassword
+		subdomain := gofakeit.LetterN(30)
+
+		// create url-encoded form data
+		form := url.Values{}
+		form.Add("email", email)
+		form.Add("password", password)
+		req, err := http.NewRequest(http.MethodPost, loginPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Login", fake.MockContext, subdomain, email, password, false, session.Device{}).
+			Return(auth.LoginResult{PasswordChangeToken: token}, nil)
+
+		// call the handler
+		handler.Login(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"password_change_required":true,"password_change_token":"%s"}`, token),
+			rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+}
+
+func TestHandler_RequestMagicLink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return forbidden when magic link login is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		req, err := http.NewRequest(http.MethodPost, magicLinkPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s"}`, email)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("RequestMagicLink", fake.MockContext, subdomain, email, false).
+			Return("", auth.ErrMagicLinkDisabled)
+
+		// call the handler
+		handler.RequestMagicLink(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("should set the browser binding cookie", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		browserBinding := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, magicLinkPath,
+			strings.NewReader(fmt.Sprintf(`{"email":"%s","remember_me":true}`, email)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("RequestMagicLink", fake.MockContext, subdomain, email, true).Return(browserBinding, nil)
+
+		// call the handler
+		handler.RequestMagicLink(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Contains(t, rr.Header().Get("Set-Cookie"), auth.MagicLinkCookieName+"="+browserBinding)
+	})
+}
+
+func TestHandler_MagicLinkCallback(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "should return unauthorized when the link is invalid", err: auth.ErrInvalidMagicLink,
+			code: http.StatusUnauthorized},
+		{name: "should return unauthorized when the user is disabled", err: auth.ErrUserDisabled,
+			code: http.StatusUnauthorized},
+		{name: "should return forbidden when magic link login is disabled", err: auth.ErrMagicLinkDisabled,
+			code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			subdomain := gofakeit.LetterN(30)
+			req, err := http.NewRequest(http.MethodPost, magicLinkCallbackPath, strings.NewReader(`{"token":"token"}`))
+			require.NoError(t, err)
+
+			// set the organization resolved by the tenant middleware
+			req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+			mockService := auth.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := auth.NewHandler(mockService)
+
+			// mock the service calls
+			mockService.On("MagicLinkLogin", fake.MockContext, subdomain, "token", "", session.Device{}).
+				Return(auth.LoginResult{}, tc.err)
+
+			// call the handler
+			handler.MagicLinkCallback(rr, req)
+
+			// check the result
+			require.Equal(t, tc.code, rr.Code)
+			assert.Empty(t, rr.Header().Get("Set-Cookie"))
+		})
+	}
+
+	t.Run("should set the session cookies and remove the browser binding cookie", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		jwt := gofakeit.UUID()
+		browserBinding := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, magicLinkCallbackPath, strings.NewReader(`{"token":"token"}`))
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: auth.MagicLinkCookieName, Value: browserBinding})
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("MagicLinkLogin", fake.MockContext, subdomain, "token", browserBinding, session.Device{}).
+			Return(auth.LoginResult{JWT: jwt, RefreshToken: gofakeit.UUID(), TTL: auth.DefaultSessionTTL}, nil)
+
+		// call the handler
+		handler.MagicLinkCallback(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Values("Set-Cookie")[0], auth.MagicLinkCookieName+"=;")
+		assert.Contains(t, rr.Header().Values("Set-Cookie")[1], auth.JWTCookieName+"="+jwt)
+		assert.Len(t, rr.Header().Values("Set-Cookie"), 3)
+	})
+}
+
+func TestHandler_SetupMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when mfa token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaSetupPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetupMFA", fake.MockContext, subdomain, mfaToken).
+			Return(mfa.Enrollment{}, auth.ErrInvalidMFAToken)
+
+		// call the handler
+		handler.SetupMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"mfa token is invalid or expired"}`, rr.Body.String())
+	})
+
+	t.Run("should return the enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaSetupPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetupMFA", fake.MockContext, subdomain, mfaToken).
+			Return(mfa.Enrollment{Secret: "SECRET", URI: "otpauth://totp/CamelHR:user?secret=SECRET"}, nil)
+
+		// call the handler
+		handler.SetupMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"secret":"SECRET","uri":"otpauth://totp/CamelHR:user?secret=SECRET"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return unauthorized when code is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaVerifyPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s","code":"123456"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyMFA", fake.MockContext, subdomain, mfaToken, "123456", false, session.Device{}).
+			Return(auth.LoginResult{}, mfa.ErrInvalidCode)
+
+		// call the handler
+		handler.VerifyMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"mfa code is invalid"}`, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("should return too many requests when the login is locked", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaVerifyPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s","code":"123456"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyMFA", fake.MockContext, subdomain, mfaToken, "123456", false, session.Device{}).
+			Return(auth.LoginResult{}, &lockout.LockedError{RetryAfter: time.Minute})
+
+		// call the handler
+		handler.VerifyMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.JSONEq(t, `{"error":"too many failed login attempts. try again later"}`, rr.Body.String())
+		assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+	})
+
+	t.Run("should set the session cookie", func(t *testing.T) {
+		t.Parallel()
+
+		jwt := gofakeit.UUID()
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaVerifyPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s","code":"123456","remember_me":true}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyMFA", fake.MockContext, subdomain, mfaToken, "123456", true, session.Device{}).
+			Return(auth.LoginResult{JWT: jwt, TTL: auth.RememberMeSessionTTL}, nil)
+
+		// call the handler
+		handler.VerifyMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(
+			t,
+			fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+				jwt, int(auth.RememberMeSessionTTL.Seconds())),
+			rr.Header().Get("Set-Cookie"),
+		)
+	})
+
+	t.Run("should return the password change token without the session cookie", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken := gofakeit.UUID()
+		passwordChangeToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaVerifyPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s","code":"123456"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyMFA", fake.MockContext, subdomain, mfaToken, "123456", false, session.Device{}).
+			Return(auth.LoginResult{PasswordChangeToken: passwordChangeToken}, nil)
+
+		// call the handler
+		handler.VerifyMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"password_change_required":true,"password_change_token":"%s"}`,
+			passwordChangeToken), rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("should return the recovery codes when enrollment is completed", func(t *testing.T) {
+		t.Parallel()
+
+		jwt := gofakeit.UUID()
+		mfaToken := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, mfaVerifyPath,
+			strings.NewReader(fmt.Sprintf(`{"mfa_token":"%s","code":"123456"}`, mfaToken)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("VerifyMFA", fake.MockContext, subdomain, mfaToken, "123456", false, session.Device{}).
+			Return(auth.LoginResult{JWT: jwt, TTL: auth.DefaultSessionTTL, RecoveryCodes: []string{"abcde-fghij"}}, nil)
+
+		// call the handler
+		handler.VerifyMFA(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"recovery_codes":["abcde-fghij"]}`, rr.Body.String())
+		assert.NotEmpty(t, rr.Header().Get("Set-Cookie"))
+	})
+}
+
+func TestHandler_ChangeExpiredPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return unauthorized when token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		token := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, expiredPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"%s"}`, token, validPassword)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("ChangeExpiredPassword", fake.MockContext, subdomain, token, validPassword, false,
+			session.Device{}).Return(auth.LoginResult{}, auth.ErrInvalidPasswordChange)
+
+		// call the handler
+		handler.ChangeExpiredPassword(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"password change token is invalid or expired"}`, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("should return bad request when password does not satisfy the policy", func(t *testing.T) {
+		t.Parallel()
+
+		token := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, expiredPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"@2nR"}`, token)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("ChangeExpiredPassword", fake.MockContext, subdomain, token, "@2nR", false, session.Device{}).
+			Return(auth.LoginResult{}, base.NewInputValidationError("password must be at least 8 characters in length"))
+
+		// call the handler
+		handler.ChangeExpiredPassword(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"password must be at least 8 characters in length"}`, rr.Body.String())
+	})
+
+	t.Run("should set the session cookie once the password is changed", func(t *testing.T) {
+		t.Parallel()
+
+		jwt := gofakeit.UUID()
+		token := gofakeit.UUID()
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, expiredPasswordPath,
+			strings.NewReader(fmt.Sprintf(`{"token":"%s","password":"%s"}`, token, validPassword)))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("ChangeExpiredPassword", fake.MockContext, subdomain, token, validPassword, false,
+			session.Device{}).Return(auth.LoginResult{JWT: jwt, TTL: auth.DefaultSessionTTL}, nil)
+
+		// call the handler
+		handler.ChangeExpiredPassword(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("Set-Cookie"))
+	})
+}
+
+func TestHandler_SSOAuthorize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when sso is not configured", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodGet, ssoAuthorizePath, nil)
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SSOAuthorize", fake.MockContext, subdomain, false).
+			Return("", "", base.NewNotFoundError("sso configuration not found for the given organization"))
+
+		// call the handler
+		handler.SSOAuthorize(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return bad gateway when the identity provider fails", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodGet, ssoAuthorizePath, nil)
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SSOAuthorize", fake.MockContext, subdomain, false).
+			Return("", "", fmt.Errorf("connection refused: %w", sso.ErrIdentityProvider))
+
+		// call the handler
+		handler.SSOAuthorize(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadGateway, rr.Code)
+		assert.JSONEq(t, `{"error":"identity provider request failed"}`, rr.Body.String())
+	})
+
+	t.Run("should return the authorization url and bind the login to the browser", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		authorizationURL := gofakeit.URL()
+		browserBinding := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodGet, ssoAuthorizePath+"?remember_me=true", nil)
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SSOAuthorize", fake.MockContext, subdomain, true).Return(authorizationURL, browserBinding, nil)
+
+		// call the handler
+		handler.SSOAuthorize(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"authorization_url":"%s"}`, authorizationURL), rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Set-Cookie"), auth.SSOCookieName+"="+browserBinding)
+	})
+}
+
+func TestHandler_SSOCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the state is missing", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		req, err := http.NewRequest(http.MethodPost, ssoCallbackPath, strings.NewReader(`{"code":"code"}`))
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.SSOCallback(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"should return unauthorized when the state is invalid", sso.ErrInvalidState, http.StatusUnauthorized},
+		{"should return unauthorized when the id token is invalid", sso.ErrInvalidIDToken, http.StatusUnauthorized},
+		{"should return unauthorized when the email is not allowed", sso.ErrEmailNotAllowed, http.StatusUnauthorized},
+		{"should return unauthorized when the user is not a member", auth.ErrSSOUserNotFound, http.StatusUnauthorized},
+		{"should return unauthorized when the user is disabled", auth.ErrUserDisabled, http.StatusUnauthorized},
+		{"should return forbidden when the organization is suspended", auth.ErrOrgSuspended, http.StatusForbidden},
+		{"should return bad gateway when the identity provider fails", sso.ErrIdentityProvider, http.StatusBadGateway},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			subdomain := gofakeit.LetterN(30)
+			req, err := http.NewRequest(http.MethodPost, ssoCallbackPath,
+				strings.NewReader(`{"code":"code","state":"state"}`))
+			require.NoError(t, err)
+
+			// set the organization resolved by the tenant middleware
+			req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+			mockService := auth.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := auth.NewHandler(mockService)
+
+			// mock the service calls
+			mockService.On("SSOLogin", fake.MockContext, subdomain, "code", "state", "", session.Device{}).
+				Return(auth.LoginResult{}, tc.err)
+
+			// call the handler
+			handler.SSOCallback(rr, req)
+
+			// check the result
+			require.Equal(t, tc.status, rr.Code)
+			assert.Empty(t, rr.Header().Get("Set-Cookie"))
+		})
+	}
+
+	t.Run("should set the session cookies and remove the browser binding cookie", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		jwt := gofakeit.UUID()
+		browserBinding := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, ssoCallbackPath,
+			strings.NewReader(`{"code":"code","state":"state"}`))
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: auth.SSOCookieName, Value: browserBinding})
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SSOLogin", fake.MockContext, subdomain, "code", "state", browserBinding, session.Device{}).
+			Return(auth.LoginResult{JWT: jwt, RefreshToken: gofakeit.UUID(), TTL: auth.DefaultSessionTTL}, nil)
+
+		// call the handler
+		handler.SSOCallback(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Values("Set-Cookie")[0], auth.SSOCookieName+"=;")
+		assert.Contains(t, rr.Header().Values("Set-Cookie")[1], auth.JWTCookieName+"="+jwt)
+		assert.Len(t, rr.Header().Values("Set-Cookie"), 3)
+	})
+}
+
+func TestHandler_Refresh(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return unauthorized when refresh token cookie is missing", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, refreshPath, nil)
+		require.NoError(t, err)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{Subdomain: gofakeit.LetterN(30)}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Refresh(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"refresh token is invalid or expired"}`, rr.Body.String())
+	})
+
+	t.Run("should clear the session cookies when refresh token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		refreshToken := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, refreshPath, nil)
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: auth.RefreshTokenCookieName, Value: refreshToken})
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Refresh", fake.MockContext, subdomain, refreshToken).
+			Return(auth.LoginResult{}, auth.ErrInvalidRefreshToken)
+
+		// call the handler
+		handler.Refresh(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.JSONEq(t, `{"error":"refresh token is invalid or expired"}`, rr.Body.String())
+		assert.Equal(
+			t,
+			[]string{
+				"jwt_session_id=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
+				"refresh_token=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
+			},
+			rr.Header().Values("Set-Cookie"),
+		)
+	})
+
+	t.Run("should return error when service call fails", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		refreshToken := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, refreshPath, nil)
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: auth.RefreshTokenCookieName, Value: refreshToken})
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Refresh", fake.MockContext, subdomain, refreshToken).
+			Return(auth.LoginResult{}, assert.AnError)
+
+		// call the handler
+		handler.Refresh(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("should set the renewed session cookies", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		refreshToken := gofakeit.UUID()
+		newJWT := gofakeit.UUID()
+		newRefreshToken := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPost, refreshPath, nil)
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: auth.RefreshTokenCookieName, Value: refreshToken})
+
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: subdomain}))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Refresh", fake.MockContext, subdomain, refreshToken).
+			Return(auth.LoginResult{JWT: newJWT, RefreshToken: newRefreshToken, TTL: auth.DefaultSessionTTL}, nil)
+
+		// call the handler
+		handler.Refresh(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(
+			t,
+			[]string{
+				fmt.Sprintf("jwt_session_id=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+					newJWT, int(auth.DefaultSessionTTL.Seconds())),
+				fmt.Sprintf("refresh_token=%s; Path=/; Max-Age=%d; HttpOnly; Secure; SameSite=Strict",
+					newRefreshToken, int(auth.DefaultSessionTTL.Seconds())),
+			},
+			rr.Header().Values("Set-Cookie"),
+		)
+	})
+}
+
+func TestHandler_Logout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should logout successfully", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		require.NoError(t, err)
+
+		// set required values in request context
+		subdomain := gofakeit.LetterN(30)
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		ctx := req.Context()
+		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
+		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
+		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
+		ctx = context.WithValue(ctx, request.CtxSessionIDKey, sessionID)
+		req = req.WithContext(ctx)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Logout", fake.MockContext, userID, orgID, sessionID).Return(nil)
+
+		// call the handler
+		handler.Logout(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(
+			t,
+			[]string{
+				"jwt_session_id=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
+				"refresh_token=; Path=/; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
+			},
+			rr.Header().Values("Set-Cookie"),
+		)
+	})
+
+	t.Run("should return error when service call fails", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		require.NoError(t, err)
+
+		// set required values in request context
+		subdomain := gofakeit.LetterN(30)
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		ctx := req.Context()
+		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
+		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
+		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
+		ctx = context.WithValue(ctx, request.CtxSessionIDKey, sessionID)
+		req = req.WithContext(ctx)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Logout", fake.MockContext, userID, orgID, sessionID).Return(assert.AnError)
+
+		// call the handler
+		handler.Logout(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.JSONEq(t, `{"error":""}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request when user-id is not found in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		require.NoError(t, err)
+
+		// set required values in request context
+		subdomain := gofakeit.LetterN(30)
+		orgID := gofakeit.Int64()
+		ctx := req.Context()
+		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
+		ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
+		req = req.WithContext(ctx)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Logout(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"user id not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request when org-id is not found in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, logoutPath, nil)
+		require.NoError(t, err)
+
+		// set required values in request context
+		subdomain := gofakeit.LetterN(30)
+		userID := gofakeit.Int64()
+		ctx := req.Context()
+		ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
+		ctx = context.WithValue(ctx, request.CtxUserIDKey, userID)
+		req = req.WithContext(ctx)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.Logout(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"org id not found in the request context: invalid context"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_UnlockUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when user-id param is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, unlockUserPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithURLParam(req, "userID", "invalid")
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// call the handler
+		handler.UnlockUser(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found when the user is not found in the organization", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, unlockUserPath, nil)
+		require.NoError(t, err)
+
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := int64(gofakeit.IntRange(1, 1000))
+		req = tests.WithAuthContext(req, actorID, orgID)
+		req = tests.WithURLParam(req, "userID", strconv.FormatInt(userID, 10))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("UnlockUser", fake.MockContext, orgID, userID).
+			Return(base.NewNotFoundError("user not found for the given id"))
+
+		// call the handler
+		handler.UnlockUser(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error":"user not found for the given id"}`, rr.Body.String())
+	})
+
+	t.Run("should unlock the user", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, unlockUserPath, nil)
+		require.NoError(t, err)
+
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := int64(gofakeit.IntRange(1, 1000))
+		req = tests.WithAuthContext(req, actorID, orgID)
+		req = tests.WithURLParam(req, "userID", strconv.FormatInt(userID, 10))
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("UnlockUser", fake.MockContext, orgID, userID).Return(nil)
+
+		// call the handler
+		handler.UnlockUser(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestHandler_JWKS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the json web key set", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+
+		mockService := auth.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := auth.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("JWKS").Return(auth.JWKS{Keys: []auth.JWK{{
+			KeyType:   "OKP",
+			KeyID:     "kid",
+			Use:       "sig",
+			Algorithm: "EdDSA",
+			Curve:     "Ed25519",
+			X:         "x",
+		}}})
+
+		// call the handler
+		handler.JWKS(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"keys":[{"kty":"OKP","kid":"kid","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"x"}]}`,
+			rr.Body.String())
 	})
 }
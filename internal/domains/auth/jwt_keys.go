@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+var (
+	ErrInvalidSigningKey      = errors.New("jwt signing key must be a pem encoded pkcs8 rsa or ed25519 private key")
+	ErrInvalidVerificationKey = errors.New("jwt verification key must be a pem encoded pkix rsa or ed25519 public key")
+	ErrWeakRSAKey             = errors.New("rsa key must be at least 2048 bits")
+	ErrMissingSigningKey      = errors.New("jwt verification keys require a jwt signing key")
+	ErrUnknownKeyID           = errors.New("unknown key id")
+)
+
+// KeySet holds the keys used to sign and verify the access tokens.
+// The tokens are signed using RS256 or EdDSA depending on the type of the signing key
+// and the key id is set in the kid header. Any of the verification keys is accepted so that
+// the signing key can be rotated without invalidating the tokens issued with the previous key.
+// When no signing key is configured, the tokens are signed using HS256 with the app secret.
+type KeySet struct {
+	signingMethod    jwt.SigningMethod
+	signingKey       any
+	signingKeyID     string
+	verificationKeys map[string]verificationKey
+}
+
+type verificationKey struct {
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+}
+
+// JWK represents a public key in the json web key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKS represents the json web key set published to verify the access tokens.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewKeySet loads the signing key and the verification keys from the config.
+// The public key of the signing key is always a verification key.
+func NewKeySet(conf config.Config) (*KeySet, error) {
+	if conf.JWTSigningKey == "" {
+		if conf.JWTVerificationKeys != "" {
+			return nil, ErrMissingSigningKey
+		}
+
+		return NewHMACKeySet(conf.AppSecret), nil
+	}
+
+	block, _ := pem.Decode([]byte(conf.JWTSigningKey))
+	if block == nil {
+		return nil, ErrInvalidSigningKey
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse jwt signing key: %w", ErrInvalidSigningKey)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidSigningKey
+	}
+
+	k := &KeySet{signingKey: privateKey, verificationKeys: map[string]verificationKey{}}
+
+	k.signingKeyID, err = k.addVerificationKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	k.signingMethod = k.verificationKeys[k.signingKeyID].method
+
+	// the public keys of the previous and the upcoming signing keys
+	rest := []byte(conf.JWTVerificationKeys)
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwt verification key: %w", ErrInvalidVerificationKey)
+		}
+
+		if _, err := k.addVerificationKey(publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+// NewHMACKeySet returns a key set that signs and verifies the tokens using HS256 with the given secret.
+func NewHMACKeySet(appSecret string) *KeySet {
+	return &KeySet{signingMethod: jwt.SigningMethodHS256, signingKey: []byte(appSecret)}
+}
+
+// JWKS returns the public verification keys. It is empty when the tokens are signed using HS256.
+func (k *KeySet) JWKS() JWKS {
+	keys := make([]JWK, 0, len(k.verificationKeys))
+	for kid, vk := range k.verificationKeys {
+		keys = append(keys, toJWK(kid, vk))
+	}
+
+	// keep the output stable
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyID < keys[j].KeyID })
+
+	return JWKS{Keys: keys}
+}
+
+// sign signs the given claims using the signing key.
+func (k *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signingMethod, claims)
+	if k.signingKeyID != "" {
+		token.Header["kid"] = k.signingKeyID
+	}
+
+	return token.SignedString(k.signingKey)
+}
+
+// keyFunc returns the key to verify the given token with.
+func (k *KeySet) keyFunc(token *jwt.Token) (any, error) {
+	if k.signingKeyID == "" {
+		return k.signingKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	vk, ok := k.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("key id %q: %w", kid, ErrUnknownKeyID)
+	}
+
+	// prevent the key from being used with another algorithm
+	if token.Method.Alg() != vk.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key id %q: %w",
+			token.Method.Alg(), kid, jwt.ErrTokenSignatureInvalid)
+	}
+
+	return vk.publicKey, nil
+}
+
+// validMethods returns the signing methods accepted by the key set.
+func (k *KeySet) validMethods() []string {
+	if k.signingKeyID == "" {
+		return []string{jwt.SigningMethodHS256.Name}
+	}
+
+	return []string{jwt.SigningMethodRS256.Name, jwt.SigningMethodEdDSA.Alg()}
+}
+
+// addVerificationKey adds the given public key and returns its key id.
+func (k *KeySet) addVerificationKey(publicKey crypto.PublicKey) (string, error) {
+	var method jwt.SigningMethod
+
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeyBits {
+			return "", ErrWeakRSAKey
+		}
+
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return "", ErrInvalidVerificationKey
+	}
+
+	vk := verificationKey{method: method, publicKey: publicKey}
+	kid := thumbprint(toJWK("", vk))
+	k.verificationKeys[kid] = vk
+
+	return kid, nil
+}
+
+// toJWK converts the verification key to the json web key format.
+func toJWK(kid string, vk verificationKey) JWK {
+	jwk := JWK{KeyID: kid, Use: "sig", Algorithm: vk.method.Alg()}
+
+	switch pub := vk.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// thumbprint returns the rfc 7638 thumbprint of the json web key which is used as the key id.
+// Only the required members are hashed in the lexicographic order.
+func thumbprint(jwk JWK) string {
+	var members []byte
+
+	// the marshalling of the string values can not fail
+	if jwk.KeyType == "RSA" {
+		members, _ = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N})
+	} else {
+		members, _ = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X})
+	}
+
+	sum := sha256.Sum256(members)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
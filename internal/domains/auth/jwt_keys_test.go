@@ -0,0 +1,278 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeySet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should fall back to hmac when no signing key is configured", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		keys, err := auth.NewKeySet(config.Config{AppSecret: appSecret})
+		require.NoError(t, err)
+		assert.Empty(t, keys.JWKS().Keys)
+
+		token, err := auth.GenerateJWT(time.Hour, keys, gofakeit.Int64(), gofakeit.Int64(), gofakeit.Username(),
+			gofakeit.UUID())
+		require.NoError(t, err)
+
+		parsedToken, err := jwt.Parse(token, func(*jwt.Token) (any, error) { return []byte(appSecret), nil })
+		require.NoError(t, err)
+		assert.Equal(t, jwt.SigningMethodHS256, parsedToken.Method)
+		assert.NotContains(t, parsedToken.Header, "kid")
+	})
+
+	t.Run("should return error when verification keys are configured without a signing key", func(t *testing.T) {
+		t.Parallel()
+
+		_, publicKey := generateEd25519Key(t)
+
+		_, err := auth.NewKeySet(config.Config{AppSecret: gofakeit.UUID(), JWTVerificationKeys: publicKey})
+		require.ErrorIs(t, err, auth.ErrMissingSigningKey)
+	})
+
+	t.Run("should return error when signing key is not pem encoded", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := auth.NewKeySet(config.Config{JWTSigningKey: "invalid"})
+		require.ErrorIs(t, err, auth.ErrInvalidSigningKey)
+	})
+
+	t.Run("should return error when signing key is not a pkcs8 private key", func(t *testing.T) {
+		t.Parallel()
+
+		_, publicKey := generateEd25519Key(t)
+
+		_, err := auth.NewKeySet(config.Config{JWTSigningKey: publicKey})
+		require.ErrorIs(t, err, auth.ErrInvalidSigningKey)
+	})
+
+	t.Run("should return error when verification key is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		privateKey, _ := generateEd25519Key(t)
+		invalidKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")}))
+
+		_, err := auth.NewKeySet(config.Config{JWTSigningKey: privateKey, JWTVerificationKeys: invalidKey})
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationKey)
+	})
+
+	t.Run("should return error when rsa key is weaker than 2048 bits", func(t *testing.T) {
+		t.Parallel()
+
+		privateKey, _ := generateRSAKey(t, 1024)
+
+		_, err := auth.NewKeySet(config.Config{JWTSigningKey: privateKey})
+		require.ErrorIs(t, err, auth.ErrWeakRSAKey)
+	})
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish the signing key and the verification keys", func(t *testing.T) {
+		t.Parallel()
+
+		signingKey, _ := generateEd25519Key(t)
+		_, rsaPublicKey := generateRSAKey(t, 2048)
+		_, ed25519PublicKey := generateEd25519Key(t)
+
+		keys, err := auth.NewKeySet(config.Config{
+			JWTSigningKey:       signingKey,
+			JWTVerificationKeys: rsaPublicKey + ed25519PublicKey,
+		})
+		require.NoError(t, err)
+
+		jwks := keys.JWKS()
+		require.Len(t, jwks.Keys, 3)
+
+		algs := map[string]int{}
+
+		for i, jwk := range jwks.Keys {
+			require.NotEmpty(t, jwk.KeyID)
+			assert.Equal(t, "sig", jwk.Use)
+
+			algs[jwk.Algorithm]++
+
+			if i > 0 {
+				assert.Less(t, jwks.Keys[i-1].KeyID, jwk.KeyID)
+			}
+
+			switch jwk.KeyType {
+			case "RSA":
+				assert.Equal(t, "RS256", jwk.Algorithm)
+				assert.NotEmpty(t, jwk.N)
+				assert.Equal(t, "AQAB", jwk.E)
+			case "OKP":
+				assert.Equal(t, "EdDSA", jwk.Algorithm)
+				assert.Equal(t, "Ed25519", jwk.Curve)
+				assert.NotEmpty(t, jwk.X)
+			default:
+				t.Fatalf("unexpected key type %s", jwk.KeyType)
+			}
+		}
+
+		assert.Equal(t, map[string]int{"RS256": 1, "EdDSA": 2}, algs)
+	})
+}
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should sign and verify using rsa and ed25519 keys", func(t *testing.T) {
+		t.Parallel()
+
+		rsaKey, _ := generateRSAKey(t, 2048)
+		ed25519Key, _ := generateEd25519Key(t)
+
+		for _, tc := range []struct {
+			signingKey string
+			method     jwt.SigningMethod
+		}{
+			{rsaKey, jwt.SigningMethodRS256},
+			{ed25519Key, jwt.SigningMethodEdDSA},
+		} {
+			keys, err := auth.NewKeySet(config.Config{JWTSigningKey: tc.signingKey})
+			require.NoError(t, err)
+
+			userID := gofakeit.Int64()
+			token, err := auth.GenerateJWT(time.Hour, keys, userID, gofakeit.Int64(), gofakeit.Username(),
+				gofakeit.UUID())
+			require.NoError(t, err)
+
+			parsedToken, claims, err := auth.ParseAndValidateJWT(token, keys)
+			require.NoError(t, err)
+			assert.Equal(t, tc.method, parsedToken.Method)
+			assert.Equal(t, keys.JWKS().Keys[0].KeyID, parsedToken.Header["kid"])
+			assert.Equal(t, userID, claims.UserID)
+		}
+	})
+
+	t.Run("should verify the tokens signed by the rotated key", func(t *testing.T) {
+		t.Parallel()
+
+		oldSigningKey, oldPublicKey := generateEd25519Key(t)
+		newSigningKey, _ := generateRSAKey(t, 2048)
+
+		oldKeys, err := auth.NewKeySet(config.Config{JWTSigningKey: oldSigningKey})
+		require.NoError(t, err)
+
+		token, err := auth.GenerateJWT(time.Hour, oldKeys, gofakeit.Int64(), gofakeit.Int64(), gofakeit.Username(),
+			gofakeit.UUID())
+		require.NoError(t, err)
+
+		// the old public key is kept as a verification key after the rotation
+		newKeys, err := auth.NewKeySet(config.Config{JWTSigningKey: newSigningKey, JWTVerificationKeys: oldPublicKey})
+		require.NoError(t, err)
+
+		_, _, err = auth.ParseAndValidateJWT(token, newKeys)
+		require.NoError(t, err)
+
+		// the token is rejected once the old public key is removed
+		newKeys, err = auth.NewKeySet(config.Config{JWTSigningKey: newSigningKey})
+		require.NoError(t, err)
+
+		_, _, err = auth.ParseAndValidateJWT(token, newKeys)
+		require.ErrorIs(t, err, auth.ErrUnknownKeyID)
+	})
+
+	t.Run("should reject the hmac tokens when signing with asymmetric keys", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		signingKey, _ := generateEd25519Key(t)
+
+		keys, err := auth.NewKeySet(config.Config{AppSecret: appSecret, JWTSigningKey: signingKey})
+		require.NoError(t, err)
+
+		token, err := auth.GenerateJWT(time.Hour, auth.NewHMACKeySet(appSecret), gofakeit.Int64(), gofakeit.Int64(),
+			gofakeit.Username(), gofakeit.UUID())
+		require.NoError(t, err)
+
+		_, _, err = auth.ParseAndValidateJWT(token, keys)
+		require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("should reject the token when the algorithm does not match the key", func(t *testing.T) {
+		t.Parallel()
+
+		ed25519Key, _ := generateEd25519Key(t)
+		_, rsaPublicKey := generateRSAKey(t, 2048)
+
+		keys, err := auth.NewKeySet(config.Config{JWTSigningKey: ed25519Key, JWTVerificationKeys: rsaPublicKey})
+		require.NoError(t, err)
+
+		// sign an EdDSA token with the kid of the rsa key
+		var rsaKeyID string
+
+		for _, jwk := range keys.JWKS().Keys {
+			if jwk.KeyType == "RSA" {
+				rsaKeyID = jwk.KeyID
+			}
+		}
+
+		block, _ := pem.Decode([]byte(ed25519Key))
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		require.NoError(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, auth.AppClaims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		})
+		token.Header["kid"] = rsaKeyID
+		tokenString, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+
+		_, _, err = auth.ParseAndValidateJWT(tokenString, keys)
+		require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+}
+
+// generateEd25519Key returns a pem encoded pkcs8 ed25519 private key and its pkix public key.
+func generateEd25519Key(t *testing.T) (string, string) {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return encodeKeyPair(t, privateKey)
+}
+
+// generateRSAKey returns a pem encoded pkcs8 rsa private key and its pkix public key.
+func generateRSAKey(t *testing.T, bits int) (string, string) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+
+	return encodeKeyPair(t, privateKey)
+}
+
+func encodeKeyPair(t *testing.T, privateKey crypto.Signer) (string, string) {
+	t.Helper()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+}
@@ -8,13 +8,28 @@ import (
 )
 
 // AppClaims represents the claims in the jwt token.
+// The actor claim is set only when the session is used by a platform operator impersonating the user.
 type AppClaims struct {
-	UserID       int64  `json:"user_id"`
-	OrgID        int64  `json:"org_id"`
-	OrgSubdomain string `json:"org_subdomain"`
+	UserID       int64       `json:"user_id"`
+	OrgID        int64       `json:"org_id"`
+	OrgSubdomain string      `json:"org_subdomain"`
+	SessionID    string      `json:"sid"`
+	Actor        *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// ActorClaim represents the party acting on behalf of the user of the jwt token.
+// It follows the act claim of RFC 8693.
+type ActorClaim struct {
+	// Operator is the name of the platform operator impersonating the user.
+	Operator string `json:"sub"`
+}
+
+// IsImpersonated returns true if the token is used by a platform operator impersonating the user.
+func (c *AppClaims) IsImpersonated() bool {
+	return c.Actor != nil
+}
+
 // Validate validates the claims.
 // It will be called by the jwt.ParseWithClaims after parsing the token.
 func (c *AppClaims) Validate() error {
@@ -30,50 +45,146 @@ func (c *AppClaims) Validate() error {
 		return fmt.Errorf("missing org subdomain in claims: %w", jwt.ErrTokenInvalidClaims)
 	}
 
+	if c.SessionID == "" {
+		return fmt.Errorf("missing session id in claims: %w", jwt.ErrTokenInvalidClaims)
+	}
+
+	if c.Actor != nil && c.Actor.Operator == "" {
+		return fmt.Errorf("missing operator in actor claim: %w", jwt.ErrTokenInvalidClaims)
+	}
+
 	return nil
 }
 
-// GenerateJWT generates a new jwt token.
-func GenerateJWT(ttl time.Duration, appSecret string, userID, orgID int64, orgSubdomain string) (string, error) {
-	claims := AppClaims{
+// GenerateJWT generates a new jwt token for the given session signed using the signing key of the key set.
+func GenerateJWT(
+	ttl time.Duration,
+	keys *KeySet,
+	userID, orgID int64,
+	orgSubdomain, sessionID string,
+) (string, error) {
+	return keys.sign(newAppClaims(ttl, userID, orgID, orgSubdomain, sessionID))
+}
+
+// GenerateImpersonationJWT generates a new jwt token for the given session of the user
+// carrying the operator impersonating the user in the actor claim.
+func GenerateImpersonationJWT(
+	ttl time.Duration,
+	keys *KeySet,
+	userID, orgID int64,
+	orgSubdomain, sessionID, operator string,
+) (string, error) {
+	claims := newAppClaims(ttl, userID, orgID, orgSubdomain, sessionID)
+	claims.Actor = &ActorClaim{Operator: operator}
+
+	return keys.sign(claims)
+}
+
+// newAppClaims returns the claims of a jwt token for the given session expiring after the ttl.
+func newAppClaims(ttl time.Duration, userID, orgID int64, orgSubdomain, sessionID string) AppClaims {
+	return AppClaims{
 		UserID:       userID,
 		OrgID:        orgID,
 		OrgSubdomain: orgSubdomain,
+		SessionID:    sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(ttl)),
 		},
 	}
+}
 
-	// create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// ParseAndValidateJWT parses and validates the given jwt token string using the verification keys of the key set.
+func ParseAndValidateJWT(tokenString string, keys *KeySet) (*jwt.Token, *AppClaims, error) {
+	claims := &AppClaims{}
 
-	// generate signed token using the secret signing key
-	t, err := token.SignedString([]byte(appSecret))
+	t, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		keys.keyFunc,
+		jwt.WithValidMethods(keys.validMethods()),
+		jwt.WithExpirationRequired(), // make sure the exp claim is passed
+	)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
-	return t, nil
+	return t, claims, nil
 }
 
-// ParseAndValidateJWT parses and validates the given jwt token string using the secret key.
-func ParseAndValidateJWT(tokenString string, appSecret string) (*jwt.Token, *AppClaims, error) {
-	claims := &AppClaims{}
+// VerificationClaims represents the claims in a one-time verification token.
+// The purpose claim makes sure that a token issued for one flow can not be used in another.
+type VerificationClaims struct {
+	Purpose string `json:"purpose"`
+	UserID  int64  `json:"user_id"`
+	OrgID   int64  `json:"org_id"`
+	Email   string `json:"email"`
+	jwt.RegisteredClaims
+}
 
-	t, err := jwt.ParseWithClaims(
+// Validate validates the claims.
+// It will be called by the jwt.ParseWithClaims after parsing the token.
+func (c *VerificationClaims) Validate() error {
+	if c.Purpose == "" {
+		return fmt.Errorf("missing purpose in claims: %w", jwt.ErrTokenInvalidClaims)
+	}
+
+	if c.UserID == 0 {
+		return fmt.Errorf("missing user id in claims: %w", jwt.ErrTokenInvalidClaims)
+	}
+
+	if c.OrgID == 0 {
+		return fmt.Errorf("missing org id in claims: %w", jwt.ErrTokenInvalidClaims)
+	}
+
+	if c.Email == "" {
+		return fmt.Errorf("missing email in claims: %w", jwt.ErrTokenInvalidClaims)
+	}
+
+	return nil
+}
+
+// GenerateVerificationToken generates a new signed verification token for the given purpose.
+func GenerateVerificationToken(
+	ttl time.Duration, appSecret, purpose string, userID, orgID int64, email string,
+) (string, error) {
+	claims := VerificationClaims{
+		Purpose: purpose,
+		UserID:  userID,
+		OrgID:   orgID,
+		Email:   email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(ttl)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString([]byte(appSecret))
+}
+
+// ParseAndValidateVerificationToken parses and validates the given verification token string.
+// It returns error if the token was not issued for the given purpose.
+func ParseAndValidateVerificationToken(tokenString, appSecret, purpose string) (*VerificationClaims, error) {
+	claims := &VerificationClaims{}
+
+	_, err := jwt.ParseWithClaims(
 		tokenString,
 		claims,
 		func(*jwt.Token) (any, error) {
-			// return the secret signing key
 			return []byte(appSecret), nil
 		},
 		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
-		jwt.WithExpirationRequired(), // make sure the exp claim is passed
+		jwt.WithExpirationRequired(),
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return t, claims, nil
+	if claims.Purpose != purpose {
+		return nil, fmt.Errorf("unexpected token purpose %s: %w", claims.Purpose, jwt.ErrTokenInvalidClaims)
+	}
+
+	return claims, nil
 }
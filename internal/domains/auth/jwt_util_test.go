@@ -26,7 +26,10 @@ func TestGenerateJWT(t *testing.T) {
 		orgSubdomain := gofakeit.Username()
 
 		// generate jwt token
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, orgSubdomain)
+		sessionID := gofakeit.UUID()
+
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, auth.NewHMACKeySet(appSecret), userID, orgID,
+			orgSubdomain, sessionID)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -43,6 +46,9 @@ func TestGenerateJWT(t *testing.T) {
 		assert.Equal(t, userID, appClaims.UserID)
 		assert.Equal(t, orgID, appClaims.OrgID)
 		assert.Equal(t, orgSubdomain, appClaims.OrgSubdomain)
+		assert.Equal(t, sessionID, appClaims.SessionID)
+		assert.Nil(t, appClaims.Actor)
+		assert.False(t, appClaims.IsImpersonated())
 
 		now := time.Now()
 		expiry, err := parsedToken.Claims.GetExpirationTime()
@@ -57,6 +63,40 @@ func TestGenerateJWT(t *testing.T) {
 	})
 }
 
+func TestGenerateImpersonationJWT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should generate a valid jwt token carrying the operator in the actor claim", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		keys := auth.NewHMACKeySet(appSecret)
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		orgSubdomain := gofakeit.Username()
+		sessionID := gofakeit.UUID()
+		operator := gofakeit.Email()
+
+		token, err := auth.GenerateImpersonationJWT(auth.ImpersonationSessionTTL, keys, userID, orgID,
+			orgSubdomain, sessionID, operator)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		// verify the claims in the token
+		_, claims, err := auth.ParseAndValidateJWT(token, keys)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, orgID, claims.OrgID)
+		assert.Equal(t, orgSubdomain, claims.OrgSubdomain)
+		assert.Equal(t, sessionID, claims.SessionID)
+		require.NotNil(t, claims.Actor)
+		assert.Equal(t, operator, claims.Actor.Operator)
+		assert.True(t, claims.IsImpersonated())
+		assert.WithinDuration(t, time.Now().Add(auth.ImpersonationSessionTTL), claims.ExpiresAt.Time, time.Minute)
+	})
+}
+
 func TestParseAndValidateJWT(t *testing.T) {
 	t.Parallel()
 
@@ -77,6 +117,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			UserID:       userID,
 			OrgID:        orgID,
 			OrgSubdomain: orgSubdomain,
+			SessionID:    gofakeit.UUID(),
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt:  jwt.NewNumericDate(time.Now()),
 				ExpiresAt: jwt.NewNumericDate(time.Now().Add(hoursValid * time.Hour)),
@@ -87,7 +128,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 		require.NoError(t, err)
 		require.True(t, parsedToken.Valid)
 		require.Equal(t, parsedToken.Claims, parsedClaims) // claims from token and parsed claims should be same
@@ -115,6 +156,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			UserID:       userID,
 			OrgID:        orgID,
 			OrgSubdomain: orgSubdomain,
+			SessionID:    gofakeit.UUID(),
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt:  jwt.NewNumericDate(time.Now()),
 				ExpiresAt: jwt.NewNumericDate(time.Now()),
@@ -125,7 +167,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 		require.Error(t, err)
 		require.ErrorIs(t, err, jwt.ErrTokenExpired)
 		require.ErrorContains(t, err, "token is expired")
@@ -150,7 +192,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 		require.Error(t, err)
 		require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
 		require.ErrorContains(t, err, "missing user id in claims")
@@ -167,6 +209,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			userID    int64
 			orgID     int64
 			orgSub    string
+			sessionID string
 			errString string
 		}{
 			{
@@ -174,6 +217,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 				userID:    0,
 				orgID:     gofakeit.Int64(),
 				orgSub:    gofakeit.Word(),
+				sessionID: gofakeit.UUID(),
 				errString: "missing user id in claims",
 			},
 			{
@@ -181,6 +225,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 				userID:    gofakeit.Int64(),
 				orgID:     0,
 				orgSub:    gofakeit.Word(),
+				sessionID: gofakeit.UUID(),
 				errString: "missing org id in claims",
 			},
 			{
@@ -188,8 +233,17 @@ func TestParseAndValidateJWT(t *testing.T) {
 				userID:    gofakeit.Int64(),
 				orgID:     gofakeit.Int64(),
 				orgSub:    "",
+				sessionID: gofakeit.UUID(),
 				errString: "missing org subdomain in claims",
 			},
+			{
+				testName:  "missing session id",
+				userID:    gofakeit.Int64(),
+				orgID:     gofakeit.Int64(),
+				orgSub:    gofakeit.Word(),
+				sessionID: "",
+				errString: "missing session id in claims",
+			},
 		}
 
 		for _, tt := range tests {
@@ -197,6 +251,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			userID := tt.userID
 			orgID := tt.orgID
 			orgSub := tt.orgSub
+			sessionID := tt.sessionID
 			errString := tt.errString
 
 			t.Run(tt.testName, func(t *testing.T) {
@@ -211,6 +266,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 					UserID:       userID,
 					OrgID:        orgID,
 					OrgSubdomain: orgSub,
+					SessionID:    sessionID,
 					RegisteredClaims: jwt.RegisteredClaims{
 						IssuedAt:  jwt.NewNumericDate(time.Now()),
 						ExpiresAt: jwt.NewNumericDate(time.Now().Add(hoursValid * time.Hour)),
@@ -221,7 +277,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 				require.NotEmpty(t, token)
 
 				// parse and validate the token
-				parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+				parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 				require.Error(t, err)
 				require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
 				require.ErrorContains(t, err, errString)
@@ -242,6 +298,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			UserID:       gofakeit.Int64(),
 			OrgID:        gofakeit.Int64(),
 			OrgSubdomain: gofakeit.Word(),
+			SessionID:    gofakeit.UUID(),
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt: jwt.NewNumericDate(time.Now()),
 			},
@@ -251,7 +308,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 		require.Error(t, err)
 		require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
 		require.ErrorContains(t, err, "exp claim is required")
@@ -271,6 +328,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			UserID:       gofakeit.Int64(),
 			OrgID:        gofakeit.Int64(),
 			OrgSubdomain: gofakeit.Word(),
+			SessionID:    gofakeit.UUID(),
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt:  jwt.NewNumericDate(time.Now()),
 				ExpiresAt: jwt.NewNumericDate(time.Now().Add(hoursValid * time.Hour)),
@@ -281,7 +339,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, appSecret)
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
 		require.Error(t, err)
 		require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
 		require.ErrorContains(t, err, "signing method HS384 is invalid")
@@ -298,6 +356,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 			UserID:       gofakeit.Int64(),
 			OrgID:        gofakeit.Int64(),
 			OrgSubdomain: gofakeit.Word(),
+			SessionID:    gofakeit.UUID(),
 			RegisteredClaims: jwt.RegisteredClaims{
 				IssuedAt:  jwt.NewNumericDate(time.Now()),
 				ExpiresAt: jwt.NewNumericDate(time.Now().Add(hoursValid * time.Hour)),
@@ -308,7 +367,7 @@ func TestParseAndValidateJWT(t *testing.T) {
 		require.NotEmpty(t, token)
 
 		// parse and validate the token
-		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, "")
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(""))
 		require.Error(t, err)
 		require.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
 		require.ErrorContains(t, err, "signing method none is invalid")
@@ -328,7 +387,10 @@ func TestParseAndValidateJWT(t *testing.T) {
 		orgSubdomain := gofakeit.Username()
 
 		// generate jwt token
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, orgSubdomain)
+		sessionID := gofakeit.UUID()
+
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, auth.NewHMACKeySet(appSecret), userID, orgID,
+			orgSubdomain, sessionID)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -336,7 +398,97 @@ func TestParseAndValidateJWT(t *testing.T) {
 		token += "invalid"
 
 		// parse and validate the token
-		_, _, err = auth.ParseAndValidateJWT(token, appSecret)
+		_, _, err = auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
+		require.Error(t, err)
+	})
+
+	t.Run("should return error if the actor claim misses the operator", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		claims := auth.AppClaims{
+			UserID:       gofakeit.Int64(),
+			OrgID:        gofakeit.Int64(),
+			OrgSubdomain: gofakeit.Username(),
+			SessionID:    gofakeit.UUID(),
+			Actor:        &auth.ActorClaim{},
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt:  jwt.NewNumericDate(time.Now()),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(appSecret))
+		require.NoError(t, err)
+
+		// parse and validate the token
+		parsedToken, parsedClaims, err := auth.ParseAndValidateJWT(token, auth.NewHMACKeySet(appSecret))
+		require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+		require.ErrorContains(t, err, "missing operator in actor claim")
+		assert.Nil(t, parsedToken)
+		assert.Nil(t, parsedClaims)
+	})
+}
+
+func TestParseAndValidateVerificationToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse and validate a valid verification token", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		email := gofakeit.Email()
+
+		token, err := auth.GenerateVerificationToken(time.Hour, appSecret, auth.EmailVerificationPurpose,
+			userID, orgID, email)
+		require.NoError(t, err)
+
+		claims, err := auth.ParseAndValidateVerificationToken(token, appSecret, auth.EmailVerificationPurpose)
+		require.NoError(t, err)
+		assert.Equal(t, auth.EmailVerificationPurpose, claims.Purpose)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, orgID, claims.OrgID)
+		assert.Equal(t, email, claims.Email)
+	})
+
+	t.Run("should return error when purpose does not match", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		token, err := auth.GenerateVerificationToken(time.Hour, appSecret, "other_purpose",
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
+
+		_, err = auth.ParseAndValidateVerificationToken(token, appSecret, auth.EmailVerificationPurpose)
+		require.Error(t, err)
+		require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+	})
+
+	t.Run("should return error when a session jwt is used as verification token", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		token, err := auth.GenerateJWT(time.Hour, auth.NewHMACKeySet(appSecret), gofakeit.Int64(), gofakeit.Int64(),
+			gofakeit.Username(),
+			gofakeit.UUID())
+		require.NoError(t, err)
+
+		_, err = auth.ParseAndValidateVerificationToken(token, appSecret, auth.EmailVerificationPurpose)
 		require.Error(t, err)
+		require.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+	})
+
+	t.Run("should return error when token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		appSecret := gofakeit.UUID()
+		token, err := auth.GenerateVerificationToken(-time.Hour, appSecret, auth.EmailVerificationPurpose,
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
+
+		_, err = auth.ParseAndValidateVerificationToken(token, appSecret, auth.EmailVerificationPurpose)
+		require.Error(t, err)
+		require.ErrorIs(t, err, jwt.ErrTokenExpired)
 	})
 }
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/camelhr/camelhr-api/internal/mailer"
+)
+
+// verificationEmail returns the email message with the link to verify the email address.
+func verificationEmail(to, appURL, token string) mailer.Message {
+	link := fmt.Sprintf("%s/verify-email?token=%s", appURL, url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Welcome to CamelHR!\n\nPlease verify your email address by opening the link below. "+
+			"The link expires in %d hours.\n\n%s\n", int(EmailVerificationTokenTTL.Hours()), link),
+	}
+}
+
+// passwordResetEmail returns the email message with the link to reset the password.
+func passwordResetEmail(to, appURL, subdomain, token string) mailer.Message {
+	link := fmt.Sprintf("%s/reset-password?subdomain=%s&token=%s", appURL, url.QueryEscape(subdomain),
+		url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("We received a request to reset your password.\n\nOpen the link below to choose a new "+
+			"password. The link expires in %d minutes and can be used only once.\n\n%s\n\n"+
+			"If you did not request a password reset, you can safely ignore this email.\n",
+			int(PasswordResetTokenTTL.Minutes()), link),
+	}
+}
+
+// organizationRestoreEmail returns the email message with the link to restore the deleted organization.
+func organizationRestoreEmail(to, appURL, subdomain, orgName, token string) mailer.Message {
+	link := fmt.Sprintf("%s/restore-organization?subdomain=%s&token=%s", appURL, url.QueryEscape(subdomain),
+		url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Restore %s", orgName),
+		Body: fmt.Sprintf("We received a request to restore %s on CamelHR.\n\nOpen the link below to restore the "+
+			"organization along with its users. The link expires in %d hours.\n\n%s\n\n"+
+			"If you did not request the restore, you can safely ignore this email.\n",
+			orgName, int(OrganizationRestoreTokenTTL.Hours()), link),
+	}
+}
+
+// emailChangeEmail returns the email message sent to the new email address with the link to confirm the change.
+func emailChangeEmail(to, appURL, subdomain, token string) mailer.Message {
+	link := fmt.Sprintf("%s/confirm-email-change?subdomain=%s&token=%s", appURL, url.QueryEscape(subdomain),
+		url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("We received a request to change the email address of your CamelHR account to this "+
+			"address.\n\nOpen the link below to confirm the change. The link expires in %d hours and can be used "+
+			"only once.\n\n%s\n\nIf you did not request this change, you can safely ignore this email.\n",
+			int(EmailChangeTokenTTL.Hours()), link),
+	}
+}
+
+// emailChangedEmail returns the email message sent to the previous email address once the change is confirmed.
+func emailChangedEmail(to, newEmail string) mailer.Message {
+	return mailer.Message{
+		To:      to,
+		Subject: "Your email address has been changed",
+		Body: fmt.Sprintf("The email address of your CamelHR account has been changed to %s.\n\n"+
+			"If you did not make this change, please contact the administrator of your organization "+
+			"immediately.\n", newEmail),
+	}
+}
+
+// magicLinkEmail returns the email message with the one-time link to sign in without a password.
+func magicLinkEmail(to, appURL, subdomain, orgName, token string) mailer.Message {
+	link := fmt.Sprintf("%s/magic-link?subdomain=%s&token=%s", appURL, url.QueryEscape(subdomain),
+		url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Your sign-in link for %s", orgName),
+		Body: fmt.Sprintf("Open the link below to sign in to %s on CamelHR. The link expires in %d minutes, "+
+			"can be used only once and works only in the browser where it was requested.\n\n%s\n\n"+
+			"If you did not request this link, you can safely ignore this email.\n",
+			orgName, int(MagicLinkTokenTTL.Minutes()), link),
+	}
+}
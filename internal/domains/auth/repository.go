@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+// Repository is a repository for managing the auth tokens in the database.
+type Repository interface {
+	// CreatePasswordResetToken stores the hash of a password reset token for the user.
+	CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, ttl time.Duration) error
+
+	// UsePasswordResetToken marks the password reset token as used and returns the associated user id.
+	// It returns sql.ErrNoRows if the token is not found, already used or expired.
+	UsePasswordResetToken(ctx context.Context, tokenHash string) (int64, error)
+
+	// InvalidatePasswordResetTokens marks all the unused password reset tokens of the user as used.
+	InvalidatePasswordResetTokens(ctx context.Context, userID int64) error
+
+	// CreateEmailChangeToken stores the hash of an email change token along with the new email of the user.
+	CreateEmailChangeToken(ctx context.Context, userID int64, newEmail, tokenHash string, ttl time.Duration) error
+
+	// UseEmailChangeToken marks the email change token as used and returns the associated email change.
+	// It returns sql.ErrNoRows if the token is not found, already used or expired.
+	UseEmailChangeToken(ctx context.Context, tokenHash string) (EmailChange, error)
+
+	// InvalidateEmailChangeTokens marks all the unused email change tokens of the user as used.
+	InvalidateEmailChangeTokens(ctx context.Context, userID int64) error
+
+	// CreateMagicLinkToken stores the hash of a magic link token along with the hash of the browser binding
+	// of the browser that requested it.
+	CreateMagicLinkToken(ctx context.Context, m MagicLink, tokenHash, browserHash string, ttl time.Duration) error
+
+	// UseMagicLinkToken marks the magic link token bound to the browser as used and returns the associated magic link.
+	// It returns sql.ErrNoRows if the token is not found, bound to another browser, already used or expired.
+	UseMagicLinkToken(ctx context.Context, tokenHash, browserHash string) (MagicLink, error)
+
+	// InvalidateMagicLinkTokens marks all the unused magic link tokens of the user as used.
+	InvalidateMagicLinkTokens(ctx context.Context, userID int64) error
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) CreatePasswordResetToken(
+	ctx context.Context, userID int64, tokenHash string, ttl time.Duration,
+) error {
+	return r.db.Exec(ctx, nil, createPasswordResetTokenQuery, userID, tokenHash, ttl.Seconds())
+}
+
+func (r *repository) UsePasswordResetToken(ctx context.Context, tokenHash string) (int64, error) {
+	var userID int64
+	err := r.db.Exec(ctx, &userID, usePasswordResetTokenQuery, tokenHash)
+
+	return userID, err
+}
+
+func (r *repository) InvalidatePasswordResetTokens(ctx context.Context, userID int64) error {
+	return r.db.Exec(ctx, nil, invalidatePasswordResetTokensQuery, userID)
+}
+
+func (r *repository) CreateEmailChangeToken(
+	ctx context.Context, userID int64, newEmail, tokenHash string, ttl time.Duration,
+) error {
+	return r.db.Exec(ctx, nil, createEmailChangeTokenQuery, userID, newEmail, tokenHash, ttl.Seconds())
+}
+
+func (r *repository) UseEmailChangeToken(ctx context.Context, tokenHash string) (EmailChange, error) {
+	var c EmailChange
+	err := r.db.Exec(ctx, &c, useEmailChangeTokenQuery, tokenHash)
+
+	return c, err
+}
+
+func (r *repository) InvalidateEmailChangeTokens(ctx context.Context, userID int64) error {
+	return r.db.Exec(ctx, nil, invalidateEmailChangeTokensQuery, userID)
+}
+
+func (r *repository) CreateMagicLinkToken(
+	ctx context.Context, m MagicLink, tokenHash, browserHash string, ttl time.Duration,
+) error {
+	return r.db.Exec(ctx, nil, createMagicLinkTokenQuery, m.UserID, tokenHash, browserHash, m.RememberMe, ttl.Seconds())
+}
+
+func (r *repository) UseMagicLinkToken(ctx context.Context, tokenHash, browserHash string) (MagicLink, error) {
+	var m MagicLink
+	err := r.db.Exec(ctx, &m, useMagicLinkTokenQuery, tokenHash, browserHash)
+
+	return m, err
+}
+
+func (r *repository) InvalidateMagicLinkTokens(ctx context.Context, userID int64) error {
+	return r.db.Exec(ctx, nil, invalidateMagicLinkTokensQuery, userID)
+}
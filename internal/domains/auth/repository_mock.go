@@ -0,0 +1,499 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package auth
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateEmailChangeToken provides a mock function with given fields: ctx, userID, newEmail, tokenHash, ttl
+func (_m *MockRepository) CreateEmailChangeToken(ctx context.Context, userID int64, newEmail string, tokenHash string, ttl time.Duration) error {
+	ret := _m.Called(ctx, userID, newEmail, tokenHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailChangeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, newEmail, tokenHash, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateEmailChangeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmailChangeToken'
+type MockRepository_CreateEmailChangeToken_Call struct {
+	*mock.Call
+}
+
+// CreateEmailChangeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - newEmail string
+//   - tokenHash string
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) CreateEmailChangeToken(ctx interface{}, userID interface{}, newEmail interface{}, tokenHash interface{}, ttl interface{}) *MockRepository_CreateEmailChangeToken_Call {
+	return &MockRepository_CreateEmailChangeToken_Call{Call: _e.mock.On("CreateEmailChangeToken", ctx, userID, newEmail, tokenHash, ttl)}
+}
+
+func (_c *MockRepository_CreateEmailChangeToken_Call) Run(run func(ctx context.Context, userID int64, newEmail string, tokenHash string, ttl time.Duration)) *MockRepository_CreateEmailChangeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateEmailChangeToken_Call) Return(_a0 error) *MockRepository_CreateEmailChangeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateEmailChangeToken_Call) RunAndReturn(run func(context.Context, int64, string, string, time.Duration) error) *MockRepository_CreateEmailChangeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMagicLinkToken provides a mock function with given fields: ctx, m, tokenHash, browserHash, ttl
+func (_m *MockRepository) CreateMagicLinkToken(ctx context.Context, m MagicLink, tokenHash string, browserHash string, ttl time.Duration) error {
+	ret := _m.Called(ctx, m, tokenHash, browserHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMagicLinkToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, MagicLink, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, m, tokenHash, browserHash, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateMagicLinkToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMagicLinkToken'
+type MockRepository_CreateMagicLinkToken_Call struct {
+	*mock.Call
+}
+
+// CreateMagicLinkToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - m MagicLink
+//   - tokenHash string
+//   - browserHash string
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) CreateMagicLinkToken(ctx interface{}, m interface{}, tokenHash interface{}, browserHash interface{}, ttl interface{}) *MockRepository_CreateMagicLinkToken_Call {
+	return &MockRepository_CreateMagicLinkToken_Call{Call: _e.mock.On("CreateMagicLinkToken", ctx, m, tokenHash, browserHash, ttl)}
+}
+
+func (_c *MockRepository_CreateMagicLinkToken_Call) Run(run func(ctx context.Context, m MagicLink, tokenHash string, browserHash string, ttl time.Duration)) *MockRepository_CreateMagicLinkToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(MagicLink), args[2].(string), args[3].(string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateMagicLinkToken_Call) Return(_a0 error) *MockRepository_CreateMagicLinkToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateMagicLinkToken_Call) RunAndReturn(run func(context.Context, MagicLink, string, string, time.Duration) error) *MockRepository_CreateMagicLinkToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePasswordResetToken provides a mock function with given fields: ctx, userID, tokenHash, ttl
+func (_m *MockRepository) CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash string, ttl time.Duration) error {
+	ret := _m.Called(ctx, userID, tokenHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, tokenHash, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreatePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordResetToken'
+type MockRepository_CreatePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - tokenHash string
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) CreatePasswordResetToken(ctx interface{}, userID interface{}, tokenHash interface{}, ttl interface{}) *MockRepository_CreatePasswordResetToken_Call {
+	return &MockRepository_CreatePasswordResetToken_Call{Call: _e.mock.On("CreatePasswordResetToken", ctx, userID, tokenHash, ttl)}
+}
+
+func (_c *MockRepository_CreatePasswordResetToken_Call) Run(run func(ctx context.Context, userID int64, tokenHash string, ttl time.Duration)) *MockRepository_CreatePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreatePasswordResetToken_Call) Return(_a0 error) *MockRepository_CreatePasswordResetToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreatePasswordResetToken_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *MockRepository_CreatePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateEmailChangeTokens provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) InvalidateEmailChangeTokens(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateEmailChangeTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InvalidateEmailChangeTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateEmailChangeTokens'
+type MockRepository_InvalidateEmailChangeTokens_Call struct {
+	*mock.Call
+}
+
+// InvalidateEmailChangeTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) InvalidateEmailChangeTokens(ctx interface{}, userID interface{}) *MockRepository_InvalidateEmailChangeTokens_Call {
+	return &MockRepository_InvalidateEmailChangeTokens_Call{Call: _e.mock.On("InvalidateEmailChangeTokens", ctx, userID)}
+}
+
+func (_c *MockRepository_InvalidateEmailChangeTokens_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_InvalidateEmailChangeTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InvalidateEmailChangeTokens_Call) Return(_a0 error) *MockRepository_InvalidateEmailChangeTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InvalidateEmailChangeTokens_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_InvalidateEmailChangeTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateMagicLinkTokens provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) InvalidateMagicLinkTokens(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateMagicLinkTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InvalidateMagicLinkTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateMagicLinkTokens'
+type MockRepository_InvalidateMagicLinkTokens_Call struct {
+	*mock.Call
+}
+
+// InvalidateMagicLinkTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) InvalidateMagicLinkTokens(ctx interface{}, userID interface{}) *MockRepository_InvalidateMagicLinkTokens_Call {
+	return &MockRepository_InvalidateMagicLinkTokens_Call{Call: _e.mock.On("InvalidateMagicLinkTokens", ctx, userID)}
+}
+
+func (_c *MockRepository_InvalidateMagicLinkTokens_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_InvalidateMagicLinkTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InvalidateMagicLinkTokens_Call) Return(_a0 error) *MockRepository_InvalidateMagicLinkTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InvalidateMagicLinkTokens_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_InvalidateMagicLinkTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidatePasswordResetTokens provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) InvalidatePasswordResetTokens(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidatePasswordResetTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InvalidatePasswordResetTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidatePasswordResetTokens'
+type MockRepository_InvalidatePasswordResetTokens_Call struct {
+	*mock.Call
+}
+
+// InvalidatePasswordResetTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) InvalidatePasswordResetTokens(ctx interface{}, userID interface{}) *MockRepository_InvalidatePasswordResetTokens_Call {
+	return &MockRepository_InvalidatePasswordResetTokens_Call{Call: _e.mock.On("InvalidatePasswordResetTokens", ctx, userID)}
+}
+
+func (_c *MockRepository_InvalidatePasswordResetTokens_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_InvalidatePasswordResetTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InvalidatePasswordResetTokens_Call) Return(_a0 error) *MockRepository_InvalidatePasswordResetTokens_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InvalidatePasswordResetTokens_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_InvalidatePasswordResetTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseEmailChangeToken provides a mock function with given fields: ctx, tokenHash
+func (_m *MockRepository) UseEmailChangeToken(ctx context.Context, tokenHash string) (EmailChange, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseEmailChangeToken")
+	}
+
+	var r0 EmailChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (EmailChange, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) EmailChange); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(EmailChange)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UseEmailChangeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseEmailChangeToken'
+type MockRepository_UseEmailChangeToken_Call struct {
+	*mock.Call
+}
+
+// UseEmailChangeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockRepository_Expecter) UseEmailChangeToken(ctx interface{}, tokenHash interface{}) *MockRepository_UseEmailChangeToken_Call {
+	return &MockRepository_UseEmailChangeToken_Call{Call: _e.mock.On("UseEmailChangeToken", ctx, tokenHash)}
+}
+
+func (_c *MockRepository_UseEmailChangeToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockRepository_UseEmailChangeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UseEmailChangeToken_Call) Return(_a0 EmailChange, _a1 error) *MockRepository_UseEmailChangeToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UseEmailChangeToken_Call) RunAndReturn(run func(context.Context, string) (EmailChange, error)) *MockRepository_UseEmailChangeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseMagicLinkToken provides a mock function with given fields: ctx, tokenHash, browserHash
+func (_m *MockRepository) UseMagicLinkToken(ctx context.Context, tokenHash string, browserHash string) (MagicLink, error) {
+	ret := _m.Called(ctx, tokenHash, browserHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseMagicLinkToken")
+	}
+
+	var r0 MagicLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (MagicLink, error)); ok {
+		return rf(ctx, tokenHash, browserHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) MagicLink); ok {
+		r0 = rf(ctx, tokenHash, browserHash)
+	} else {
+		r0 = ret.Get(0).(MagicLink)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tokenHash, browserHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UseMagicLinkToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseMagicLinkToken'
+type MockRepository_UseMagicLinkToken_Call struct {
+	*mock.Call
+}
+
+// UseMagicLinkToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+//   - browserHash string
+func (_e *MockRepository_Expecter) UseMagicLinkToken(ctx interface{}, tokenHash interface{}, browserHash interface{}) *MockRepository_UseMagicLinkToken_Call {
+	return &MockRepository_UseMagicLinkToken_Call{Call: _e.mock.On("UseMagicLinkToken", ctx, tokenHash, browserHash)}
+}
+
+func (_c *MockRepository_UseMagicLinkToken_Call) Run(run func(ctx context.Context, tokenHash string, browserHash string)) *MockRepository_UseMagicLinkToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UseMagicLinkToken_Call) Return(_a0 MagicLink, _a1 error) *MockRepository_UseMagicLinkToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UseMagicLinkToken_Call) RunAndReturn(run func(context.Context, string, string) (MagicLink, error)) *MockRepository_UseMagicLinkToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsePasswordResetToken provides a mock function with given fields: ctx, tokenHash
+func (_m *MockRepository) UsePasswordResetToken(ctx context.Context, tokenHash string) (int64, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsePasswordResetToken")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UsePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsePasswordResetToken'
+type MockRepository_UsePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// UsePasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockRepository_Expecter) UsePasswordResetToken(ctx interface{}, tokenHash interface{}) *MockRepository_UsePasswordResetToken_Call {
+	return &MockRepository_UsePasswordResetToken_Call{Call: _e.mock.On("UsePasswordResetToken", ctx, tokenHash)}
+}
+
+func (_c *MockRepository_UsePasswordResetToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockRepository_UsePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UsePasswordResetToken_Call) Return(_a0 int64, _a1 error) *MockRepository_UsePasswordResetToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UsePasswordResetToken_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockRepository_UsePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,300 @@
+package auth_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreatePasswordResetToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+		tokenHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("createPasswordResetTokenQuery"), userID, tokenHash, float64(3600)).
+			Return(assert.AnError)
+
+		err := repo.CreatePasswordResetToken(context.Background(), userID, tokenHash, time.Hour)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should create the password reset token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+		tokenHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("createPasswordResetTokenQuery"), userID, tokenHash, float64(3600)).
+			Return(nil)
+
+		err := repo.CreatePasswordResetToken(context.Background(), userID, tokenHash, time.Hour)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_UsePasswordResetToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the token is not usable", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("usePasswordResetTokenQuery"), tokenHash).
+			Return(sql.ErrNoRows)
+
+		_, err := repo.UsePasswordResetToken(context.Background(), tokenHash)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("should return the user id of the token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+		userID := gofakeit.Int64()
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("usePasswordResetTokenQuery"), tokenHash).
+			Run(func(args mock.Arguments) {
+				// populate the passed argument with the user id
+				arg, ok := args.Get(1).(*int64)
+				require.True(t, ok)
+				*arg = userID
+			}).
+			Return(nil)
+
+		result, err := repo.UsePasswordResetToken(context.Background(), tokenHash)
+		require.NoError(t, err)
+		assert.Equal(t, userID, result)
+	})
+}
+
+func TestRepository_InvalidatePasswordResetTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("invalidatePasswordResetTokensQuery"), userID).
+			Return(assert.AnError)
+
+		err := repo.InvalidatePasswordResetTokens(context.Background(), userID)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should invalidate the password reset tokens", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("invalidatePasswordResetTokensQuery"), userID).
+			Return(nil)
+
+		err := repo.InvalidatePasswordResetTokens(context.Background(), userID)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_CreateEmailChangeToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should create the email change token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+		email := gofakeit.Email()
+		tokenHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("createEmailChangeTokenQuery"), userID, email, tokenHash, float64(3600)).
+			Return(nil)
+
+		err := repo.CreateEmailChangeToken(context.Background(), userID, email, tokenHash, time.Hour)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_UseEmailChangeToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the token is not usable", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useEmailChangeTokenQuery"), tokenHash).
+			Return(sql.ErrNoRows)
+
+		_, err := repo.UseEmailChangeToken(context.Background(), tokenHash)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("should return the email change of the token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+		change := auth.EmailChange{UserID: gofakeit.Int64(), NewEmail: gofakeit.Email()}
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useEmailChangeTokenQuery"), tokenHash).
+			Run(func(args mock.Arguments) {
+				// populate the passed argument with the email change
+				arg, ok := args.Get(1).(*auth.EmailChange)
+				require.True(t, ok)
+				*arg = change
+			}).
+			Return(nil)
+
+		result, err := repo.UseEmailChangeToken(context.Background(), tokenHash)
+		require.NoError(t, err)
+		assert.Equal(t, change, result)
+	})
+}
+
+func TestRepository_InvalidateEmailChangeTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should invalidate the email change tokens", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("invalidateEmailChangeTokensQuery"), userID).
+			Return(nil)
+
+		err := repo.InvalidateEmailChangeTokens(context.Background(), userID)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_CreateMagicLinkToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should create the magic link token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		m := auth.MagicLink{UserID: gofakeit.Int64(), RememberMe: true}
+		tokenHash := gofakeit.UUID()
+		browserHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("createMagicLinkTokenQuery"), m.UserID, tokenHash, browserHash, m.RememberMe,
+			float64(900)).
+			Return(nil)
+
+		err := repo.CreateMagicLinkToken(context.Background(), m, tokenHash, browserHash, 15*time.Minute)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_UseMagicLinkToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the token is not usable", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+		browserHash := gofakeit.UUID()
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useMagicLinkTokenQuery"), tokenHash, browserHash).
+			Return(sql.ErrNoRows)
+
+		_, err := repo.UseMagicLinkToken(context.Background(), tokenHash, browserHash)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("should return the magic link of the token", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		tokenHash := gofakeit.UUID()
+		browserHash := gofakeit.UUID()
+		m := auth.MagicLink{UserID: gofakeit.Int64(), RememberMe: true}
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useMagicLinkTokenQuery"), tokenHash, browserHash).
+			Run(func(args mock.Arguments) {
+				// populate the passed argument with the magic link
+				arg, ok := args.Get(1).(*auth.MagicLink)
+				require.True(t, ok)
+				*arg = m
+			}).
+			Return(nil)
+
+		result, err := repo.UseMagicLinkToken(context.Background(), tokenHash, browserHash)
+		require.NoError(t, err)
+		assert.Equal(t, m, result)
+	})
+}
+
+func TestRepository_InvalidateMagicLinkTokens(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should invalidate the magic link tokens", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := auth.NewRepository(mockDB)
+		userID := gofakeit.Int64()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("invalidateMagicLinkTokensQuery"), userID).
+			Return(nil)
+
+		err := repo.InvalidateMagicLinkTokens(context.Background(), userID)
+		require.NoError(t, err)
+	})
+}
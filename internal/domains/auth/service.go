@@ -2,67 +2,211 @@ package auth
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"time"
 
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+	"github.com/camelhr/log"
 )
 
 type Service interface {
 	// Register registers a new organization with owner.
 	// The organization is set disabled by default.
+	// A verification email is sent to the owner to activate the organization.
 	Register(ctx context.Context, email, password, subdomain, orgName string) error
 
+	// VerifyEmail verifies the email of the user associated with the given verification token.
+	// If the organization of the user is pending verification, it is restored as well.
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ForgotPassword mails a one-time password reset link to the user of the given organization.
+	// It does not return error when the user is not found so that it can not be used to enumerate accounts.
+	// The link is mailed in the background and the failures to send it are logged.
+	ForgotPassword(ctx context.Context, subdomain, email string) error
+
+	// ResetPassword resets the password of the user associated with the given password reset token.
+	// All the existing sessions of the user are deleted upon success.
+	ResetPassword(ctx context.Context, subdomain, token, newPassword string) error
+
+	// RequestOrganizationRestore mails a link to restore the deleted organization to its owner when the organization
+	// is within its restore grace period. It does not return error when the organization or the owner is not found
+	// so that it can not be used to enumerate accounts.
+	RequestOrganizationRestore(ctx context.Context, subdomain, email string) error
+
+	// ConfirmOrganizationRestore restores the deleted organization associated with the given restore token
+	// along with its users. It returns ErrRestoreWindowExpired when the restore grace period has ended.
+	ConfirmOrganizationRestore(ctx context.Context, subdomain, token string) error
+
+	// RequestEmailChange mails a one-time link to confirm the new email of the user.
+	// The email of the user is not changed until the link is used.
+	// It returns ErrEmailAlreadyExists when another user of the organization has the new email.
+	RequestEmailChange(ctx context.Context, userID, orgID int64, newEmail string) error
+
+	// ConfirmEmailChange changes the email of the user associated with the given email change token.
+	// A notice of the change is mailed to the previous email of the user.
+	ConfirmEmailChange(ctx context.Context, subdomain, token string) error
+
 	// Login logs in a user and returns a jwt token and ttl.
-	Login(ctx context.Context, subdomain, email, password string, rememberMe bool) (string, time.Duration, error)
+	// If the user has mfa enabled or the organization requires mfa, an mfa token is returned instead.
+	// If the password of the user has expired, a password change token is returned instead once the mfa step
+	// is completed. The session is created for the given device and the other sessions of the user are kept.
+	// The failed attempts are counted per account and per device ip. When either of them is locked,
+	// lockout.LockedError is returned. The failed attempts of the account are kept until the mfa step is completed.
+	Login(ctx context.Context, subdomain, email, password string, rememberMe bool, device session.Device) (
+		LoginResult, error,
+	)
+
+	// ChangeExpiredPassword completes the login of a user whose password has expired by exchanging the password
+	// change token returned by Login and a new password. The new password must satisfy the password policy of
+	// the organization and differ from the expired one. The token is issued only after the mfa step is completed.
+	ChangeExpiredPassword(
+		ctx context.Context, subdomain, token, newPassword string, rememberMe bool, device session.Device,
+	) (LoginResult, error)
+
+	// RequestMagicLink mails a one-time login link to the user of the given organization when the organization
+	// allows the magic link login. It returns the browser binding which must be kept by the requesting browser
+	// since the link can be used only along with it. The link is created and mailed in the background
+	// and the failures are logged so that it can not be used to enumerate accounts.
+	RequestMagicLink(ctx context.Context, subdomain, email string, rememberMe bool) (string, error)
+
+	// MagicLinkLogin logs in the user of the magic link token when it is used along with its browser binding.
+	// The session is created as done by Login. An mfa token is returned instead when the mfa step is required.
+	MagicLinkLogin(ctx context.Context, subdomain, token, browserBinding string, device session.Device) (
+		LoginResult, error,
+	)
+
+	// SetupMFA starts the mfa enrollment during login for the users of an organization that requires mfa.
+	SetupMFA(ctx context.Context, subdomain, mfaToken string) (mfa.Enrollment, error)
+
+	// VerifyMFA completes the login by exchanging the mfa token and the mfa code for a jwt token.
+	// If the enrollment is pending, it is activated and the recovery codes are returned along.
+	// If the password of the user has expired, a password change token is returned instead of the jwt token.
+	// The invalid codes are counted as failed login attempts and lock the login of the account the same way.
+	VerifyMFA(ctx context.Context, subdomain, mfaToken, code string, rememberMe bool, device session.Device) (
+		LoginResult, error,
+	)
+
+	// SSOAuthorize starts the login at the identity provider of the organization
+	// and returns the url to redirect the user to along with the browser binding of the login.
+	SSOAuthorize(ctx context.Context, subdomain string, rememberMe bool) (string, string, error)
+
+	// SSOLogin completes the login at the identity provider and creates a session for the device.
+	// The login must be completed along with the browser binding returned when it was started.
+	// The user is matched by the email of the identity. When the user is not found and the
+	// organization enables just-in-time provisioning, the user is created with a verified email.
+	// The second factor is left to the identity provider.
+	SSOLogin(ctx context.Context, subdomain, code, state, browserBinding string, device session.Device) (
+		LoginResult, error,
+	)
+
+	// Refresh renews the session of the given refresh token and returns a new jwt and refresh token.
+	// The session is extended by its ttl. Reusing a rotated refresh token revokes the session.
+	Refresh(ctx context.Context, subdomain, refreshToken string) (LoginResult, error)
+
+	// Logout logs out a user by revoking the given session. The sessions on other devices are kept.
+	Logout(ctx context.Context, userID, orgID int64, sessionID string) error
 
-	// Logout logs out a user by deleting the session.
-	Logout(ctx context.Context, userID, orgID int64) error
+	// UnlockUser clears the login lockout of the given user of the organization.
+	UnlockUser(ctx context.Context, orgID, userID int64) error
+
+	// Impersonate creates a session of the given user of the organization for the platform operator.
+	// The jwt carries the operator in its actor claim and is valid for the ImpersonationSessionTTL.
+	// No refresh token is returned so that the session can not be renewed.
+	// The caller is responsible for checking that the organization allows the impersonation.
+	Impersonate(ctx context.Context, operator string, orgID, userID int64, device session.Device) (
+		LoginResult, error,
+	)
+
+	// JWKS returns the public keys to verify the access tokens.
+	JWKS() JWKS
 }
 
 type service struct {
-	appSecret      string
-	transactor     database.Transactor
-	orgService     organization.Service
-	userService    user.Service
-	sessionManager session.SessionManager
+	appSecret          string
+	appURL             string
+	restoreGracePeriod time.Duration
+	jwtKeys            *KeySet
+	repo               Repository
+	transactor         database.Transactor
+	orgService         organization.Service
+	userService        user.Service
+	mfaService         mfa.Service
+	ssoService         sso.Service
+	sessionManager     session.SessionManager
+	lockoutManager     lockout.LockoutManager
+	mailer             mailer.Mailer
 }
 
 func NewService(
-	appSecret string, transactor database.Transactor, orgService organization.Service,
-	userService user.Service, sessionManager session.SessionManager,
+	conf config.Config, jwtKeys *KeySet, repo Repository, transactor database.Transactor, orgService organization.Service,
+	userService user.Service, mfaService mfa.Service, ssoService sso.Service, sessionManager session.SessionManager,
+	lockoutManager lockout.LockoutManager, mailer mailer.Mailer,
 ) Service {
 	return &service{
-		appSecret:      appSecret,
-		transactor:     transactor,
-		orgService:     orgService,
-		userService:    userService,
-		sessionManager: sessionManager,
+		appSecret:          conf.AppSecret,
+		appURL:             conf.AppURL,
+		restoreGracePeriod: organization.RestoreGracePeriod(conf),
+		jwtKeys:            jwtKeys,
+		repo:               repo,
+		transactor:         transactor,
+		orgService:         orgService,
+		userService:        userService,
+		mfaService:         mfaService,
+		ssoService:         ssoService,
+		sessionManager:     sessionManager,
+		lockoutManager:     lockoutManager,
+		mailer:             mailer,
 	}
 }
 
 var (
-	ErrInvalidCredentials     = errors.New("email or password is invalid")
-	ErrUserDisabled           = errors.New("user is disabled")
-	ErrSubdomainAlreadyExists = errors.New("subdomain already exists")
+	ErrInvalidCredentials       = errors.New("email or password is invalid")
+	ErrUserDisabled             = errors.New("user is disabled")
+	ErrSubdomainAlreadyExists   = errors.New("subdomain already exists")
+	ErrInvalidVerificationToken = errors.New("verification token is invalid or expired")
+	ErrVerificationTokenUsed    = errors.New("verification token has already been used")
+	ErrInvalidResetToken        = errors.New("password reset token is invalid or expired")
+	ErrInvalidMFAToken          = errors.New("mfa token is invalid or expired")
+	ErrInvalidRefreshToken      = errors.New("refresh token is invalid or expired")
+	ErrSSOUserNotFound          = errors.New("user is not a member of the organization")
+	ErrOrgSuspended             = errors.New("organization is suspended")
+	ErrEmailAlreadyExists       = errors.New("email is already used by another user of the organization")
+	ErrInvalidEmailChangeToken  = errors.New("email change token is invalid or expired")
+	ErrMagicLinkDisabled        = errors.New("magic link login is disabled for the organization")
+	ErrInvalidMagicLink         = errors.New("magic link is invalid or expired")
+	ErrInvalidPasswordChange    = errors.New("password change token is invalid or expired")
+	ErrInvalidRestoreToken      = errors.New("organization restore token is invalid or expired")
+	ErrRestoreWindowExpired     = errors.New("organization can no longer be restored")
 )
 
 func (s *service) Register(ctx context.Context, email, password, subdomain, orgName string) error {
-	var err error
+	// the subdomain of an unverified organization and the former subdomain of an organization
+	// are not available either
+	availability, err := s.orgService.CheckSubdomainAvailability(ctx, subdomain)
+	if err != nil {
+		return err
+	}
 
-	// check if the subdomain already exists
-	_, err = s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
-	if err == nil {
+	if availability.Reason == organization.SubdomainReasonReserved {
+		return base.NewInputValidationError("subdomain is reserved")
+	}
+
+	if !availability.Available {
 		return ErrSubdomainAlreadyExists
-	} else if !base.IsNotFoundError(err) {
-		return err
 	}
 
+	var owner user.User
+
 	// create a new organization with owner. keep the organization in deleted state until verified
 	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
 		org, err := s.orgService.CreateOrganization(ctx, subdomain, orgName)
@@ -70,80 +214,1033 @@ func (s *service) Register(ctx context.Context, email, password, subdomain, orgN
 			return err
 		}
 
-		if _, err = s.userService.CreateOwner(ctx, org.ID, email, password); err != nil {
+		if owner, err = s.userService.CreateOwner(ctx, org.ID, email, password); err != nil {
 			return err
 		}
 
 		// delete the newly registered organization with a predefined comment
-		// the organization & owner should be activated through backoffice upon verification
+		// the organization & owner are restored once the owner verifies the email
 		return s.orgService.DeleteOrganization(ctx, org.ID, NewOrgDeleteComment)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	// the registration is complete at this point. a failure to send the email should not fail the request
+	if err := s.sendVerificationEmail(ctx, owner); err != nil {
+		log.Error("failed to send verification email for user:%d org:%d: %v", owner.ID, owner.OrganizationID, err)
+	}
+
+	return nil
 }
 
-func (s *service) Login(ctx context.Context, subdomain, email, password string, rememberMe bool) (
-	string, time.Duration, error,
-) {
+func (s *service) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := ParseAndValidateVerificationToken(token, s.appSecret, EmailVerificationPurpose)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+
+	return s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// restore the organization if it is pending verification.
+		// this restores the owner that was deleted along with the organization as well.
+		deletedOrg, err := s.orgService.GetDeletedOrganizationByID(ctx, claims.OrgID)
+		if err != nil && !base.IsNotFoundError(err) {
+			return err
+		}
+
+		if err == nil && isPendingVerification(deletedOrg) {
+			if err := s.orgService.RestoreOrganization(ctx, deletedOrg.ID, NewOrgVerifiedComment); err != nil {
+				return err
+			}
+		}
+
+		u, err := s.userService.GetUserByID(ctx, claims.UserID)
+		if err != nil {
+			if base.IsNotFoundError(err) {
+				return ErrInvalidVerificationToken
+			}
+
+			return err
+		}
+
+		// the token is bound to the email it was issued for
+		if u.OrganizationID != claims.OrgID || u.Email != claims.Email {
+			return ErrInvalidVerificationToken
+		}
+
+		// a verified user means that the token is already consumed
+		if u.IsEmailVerified {
+			return ErrVerificationTokenUsed
+		}
+
+		return s.userService.SetEmailVerified(ctx, u.ID)
+	})
+}
+
+func (s *service) ForgotPassword(ctx context.Context, subdomain, email string) error {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	u, err := s.userService.GetUserByOrgIDEmail(ctx, org.ID, email)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// disabled users are not allowed to reset the password
+	if u.DisabledAt != nil {
+		return nil
+	}
+
+	// the reset link is issued off the request path and its failures are logged instead of returned
+	// so that neither the response nor its timing tells whether the user exists
+	go func() {
+		if err := s.sendPasswordResetEmail(context.WithoutCancel(ctx), org, u); err != nil {
+			log.Error("failed to send password reset email for user:%d org:%d: %v", u.ID, org.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *service) ResetPassword(ctx context.Context, subdomain, token, newPassword string) error {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return err
+	}
+
+	var u user.User
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// mark the token as used first so that concurrent requests can not use the same token
+		userID, err := s.repo.UsePasswordResetToken(ctx, base.HashToken(token))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidResetToken
+			}
+
+			return err
+		}
+
+		u, err = s.userService.GetUserByID(ctx, userID)
+		if err != nil {
+			if base.IsNotFoundError(err) {
+				return ErrInvalidResetToken
+			}
+
+			return err
+		}
+
+		// the token must belong to a user of the requested organization
+		if u.OrganizationID != org.ID {
+			return ErrInvalidResetToken
+		}
+
+		return s.userService.ResetPassword(ctx, u.ID, newPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	// sign out the user everywhere since the old password might have been compromised
+	return s.sessionManager.DeleteSession(ctx, u.ID, u.OrganizationID)
+}
+
+func (s *service) RequestOrganizationRestore(ctx context.Context, subdomain, email string) error {
+	org, err := s.orgService.GetDeletedOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// the organizations pending verification are restored by verifying the email of the owner instead
+	if isPendingVerification(org) || !org.IsRestorable(s.restoreGracePeriod) {
+		return nil
+	}
+
+	owner, err := s.userService.GetDeletedOwnerByOrgID(ctx, org.ID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if owner.Email != email {
+		return nil
+	}
+
+	token, err := GenerateVerificationToken(OrganizationRestoreTokenTTL, s.appSecret, OrganizationRestorePurpose,
+		owner.ID, org.ID, owner.Email)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, organizationRestoreEmail(owner.Email, s.appURL, org.Subdomain, org.Name, token))
+}
+
+func (s *service) ConfirmOrganizationRestore(ctx context.Context, subdomain, token string) error {
+	claims, err := ParseAndValidateVerificationToken(token, s.appSecret, OrganizationRestorePurpose)
+	if err != nil {
+		return ErrInvalidRestoreToken
+	}
+
+	// the organization is no longer deleted once the token is used
+	org, err := s.orgService.GetDeletedOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return ErrInvalidRestoreToken
+		}
+
+		return err
+	}
+
+	// the token must be issued for the current deletion of the organization
+	if org.ID != claims.OrgID || isPendingVerification(org) ||
+		claims.IssuedAt == nil || claims.IssuedAt.Before(org.DeletedAt.Truncate(time.Second)) {
+		return ErrInvalidRestoreToken
+	}
+
+	if !org.IsRestorable(s.restoreGracePeriod) {
+		return ErrRestoreWindowExpired
+	}
+
+	owner, err := s.userService.GetDeletedOwnerByOrgID(ctx, org.ID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return ErrInvalidRestoreToken
+		}
+
+		return err
+	}
+
+	// the token is bound to the owner it was issued for
+	if owner.ID != claims.UserID || owner.Email != claims.Email {
+		return ErrInvalidRestoreToken
+	}
+
+	return s.orgService.RestoreOrganization(ctx, org.ID, OrgRestoredByOwnerComment)
+}
+
+func (s *service) RequestEmailChange(ctx context.Context, userID, orgID int64, newEmail string) error {
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if u.Email == newEmail {
+		return base.NewInputValidationError("new email must be different from the current email")
+	}
+
+	if err := s.checkEmailAvailable(ctx, orgID, newEmail); err != nil {
+		return err
+	}
+
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	// only the most recently requested email can be confirmed
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.InvalidateEmailChangeTokens(ctx, u.ID); err != nil {
+			return err
+		}
+
+		return s.repo.CreateEmailChangeToken(ctx, u.ID, newEmail, base.HashToken(token), EmailChangeTokenTTL)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, emailChangeEmail(newEmail, s.appURL, org.Subdomain, token))
+}
+
+func (s *service) ConfirmEmailChange(ctx context.Context, subdomain, token string) error {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return err
+	}
+
+	var (
+		u      user.User
+		change EmailChange
+	)
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// mark the token as used first so that concurrent requests can not use the same token
+		change, err = s.repo.UseEmailChangeToken(ctx, base.HashToken(token))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidEmailChangeToken
+			}
+
+			return err
+		}
+
+		u, err = s.userService.GetUserByID(ctx, change.UserID)
+		if err != nil {
+			if base.IsNotFoundError(err) {
+				return ErrInvalidEmailChangeToken
+			}
+
+			return err
+		}
+
+		// the token must belong to a user of the requested organization
+		if u.OrganizationID != org.ID {
+			return ErrInvalidEmailChangeToken
+		}
+
+		// another user may have taken the email since the change was requested
+		if err := s.checkEmailAvailable(ctx, org.ID, change.NewEmail); err != nil {
+			return err
+		}
+
+		return s.userService.ChangeEmail(ctx, u.ID, change.NewEmail)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the email is changed at this point. a failure to send the notice should not fail the request
+	if err := s.mailer.Send(ctx, emailChangedEmail(u.Email, change.NewEmail)); err != nil {
+		log.Error("failed to send email change notice for user:%d org:%d: %v", u.ID, u.OrganizationID, err)
+	}
+
+	return nil
+}
+
+func (s *service) Login(
+	ctx context.Context,
+	subdomain, email, password string,
+	rememberMe bool,
+	device session.Device,
+) (LoginResult, error) {
+	// reject the attempt before verifying the password when the account or the device ip is locked
+	if err := s.lockoutManager.CheckLockout(ctx, subdomain, email, device.IP); err != nil {
+		return LoginResult{}, err
+	}
+
 	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
 	if err != nil {
-		return "", 0, err
+		return LoginResult{}, err
+	}
+
+	// prevent login for suspended organization
+	if org.IsSuspended() {
+		return LoginResult{}, ErrOrgSuspended
 	}
 
 	u, err := s.userService.GetUserByOrgIDEmail(ctx, org.ID, email)
 	if err != nil {
 		if base.IsNotFoundError(err) {
-			return "", 0, ErrInvalidCredentials
+			// count the attempt for unknown emails as well so that the accounts can not be enumerated
+			return LoginResult{}, s.loginFailed(ctx, subdomain, email, device.IP)
 		}
 
-		return "", 0, err
+		return LoginResult{}, err
 	}
 
 	// prevent login for disabled user
 	if u.DisabledAt != nil {
-		return "", 0, ErrUserDisabled
+		return LoginResult{}, ErrUserDisabled
 	}
 
-	// compare the password with bcrypt hash
-	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
-		return "", 0, ErrInvalidCredentials
+	// verify the password. the legacy bcrypt hashes are upgraded to argon2id once verified
+	matched, err := s.userService.VerifyPassword(ctx, u, password)
+	if err != nil {
+		return LoginResult{}, err
 	}
 
-	ttl := DefaultSessionTTL
-	if rememberMe {
-		ttl = RememberMeSessionTTL
+	if !matched {
+		return LoginResult{}, s.loginFailed(ctx, subdomain, email, device.IP)
 	}
 
-	// generate a new jwt token with user and organization data
-	jwtToken, err := GenerateJWT(ttl, s.appSecret, u.ID, org.ID, org.Subdomain)
+	return s.completeLogin(ctx, u, org, rememberMe, device)
+}
+
+func (s *service) ChangeExpiredPassword(
+	ctx context.Context,
+	subdomain, token, newPassword string,
+	rememberMe bool,
+	device session.Device,
+) (LoginResult, error) {
+	u, org, err := s.challengeUser(ctx, subdomain, token, PasswordChangePurpose, ErrInvalidPasswordChange)
 	if err != nil {
-		return "", 0, err
+		return LoginResult{}, err
 	}
 
-	// create session with the currently generated jwt token
-	// if the session already exists, it will be updated with the new jwt token
-	if err := s.sessionManager.CreateSession(
-		ctx,
-		u.ID,
-		org.ID,
-		jwtToken,
-		ptrToString(u.APIToken),
-		ttl,
-	); err != nil {
-		return "", 0, err
+	// the token can not be used again once the password is changed
+	expired, err := s.userService.IsPasswordExpired(ctx, u)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if !expired {
+		return LoginResult{}, ErrInvalidPasswordChange
+	}
+
+	// the expired password can not be set again even when the policy keeps no history
+	matched, err := s.userService.VerifyPassword(ctx, u, newPassword)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if matched {
+		return LoginResult{}, user.ErrPasswordReused
 	}
 
-	return jwtToken, ttl, nil
+	if err := s.userService.ResetPassword(ctx, u.ID, newPassword); err != nil {
+		return LoginResult{}, err
+	}
+
+	// the token is issued once the mfa step is completed already
+	return s.createSession(ctx, u, org, rememberMe, device)
 }
 
-func (s *service) Logout(ctx context.Context, userID, orgID int64) error {
-	return s.sessionManager.DeleteSession(ctx, userID, orgID)
+func (s *service) RequestMagicLink(ctx context.Context, subdomain, email string, rememberMe bool) (string, error) {
+	org, err := s.magicLinkOrganization(ctx, subdomain)
+	if err != nil {
+		return "", err
+	}
+
+	// the binding is returned for the unknown emails as well so that the accounts can not be enumerated
+	browserBinding, err := base.GenerateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	// the link is issued off the request path and its failures are logged instead of returned
+	// so that neither the response nor its timing tells whether the user exists
+	go func() {
+		if err := s.sendMagicLink(context.WithoutCancel(ctx), org, email, browserBinding, rememberMe); err != nil {
+			log.Error("failed to send magic link email for org:%d: %v", org.ID, err)
+		}
+	}()
+
+	return browserBinding, nil
 }
 
-func ptrToString(s *string) string {
-	if s != nil {
-		return *s
+func (s *service) MagicLinkLogin(
+	ctx context.Context,
+	subdomain, token, browserBinding string,
+	device session.Device,
+) (LoginResult, error) {
+	org, err := s.magicLinkOrganization(ctx, subdomain)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the link can be used only from the browser that requested it
+	if browserBinding == "" {
+		return LoginResult{}, ErrInvalidMagicLink
+	}
+
+	m, err := s.repo.UseMagicLinkToken(ctx, base.HashToken(token), base.HashToken(browserBinding))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LoginResult{}, ErrInvalidMagicLink
+		}
+
+		return LoginResult{}, err
+	}
+
+	u, err := s.userService.GetUserByID(ctx, m.UserID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return LoginResult{}, ErrInvalidMagicLink
+		}
+
+		return LoginResult{}, err
+	}
+
+	// the token must belong to a user of the requested organization
+	if u.OrganizationID != org.ID {
+		return LoginResult{}, ErrInvalidMagicLink
+	}
+
+	// the user might have been disabled after the link was mailed
+	if u.DisabledAt != nil {
+		return LoginResult{}, ErrUserDisabled
+	}
+
+	return s.completeLogin(ctx, u, org, m.RememberMe, device)
+}
+
+func (s *service) SetupMFA(ctx context.Context, subdomain, mfaToken string) (mfa.Enrollment, error) {
+	u, _, err := s.challengeUser(ctx, subdomain, mfaToken, MFAChallengePurpose, ErrInvalidMFAToken)
+	if err != nil {
+		return mfa.Enrollment{}, err
+	}
+
+	return s.mfaService.Enroll(ctx, u.ID)
+}
+
+func (s *service) VerifyMFA(
+	ctx context.Context,
+	subdomain, mfaToken, code string,
+	rememberMe bool,
+	device session.Device,
+) (LoginResult, error) {
+	u, org, err := s.challengeUser(ctx, subdomain, mfaToken, MFAChallengePurpose, ErrInvalidMFAToken)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the mfa token can be used until it expires. the invalid codes are counted along with the failed
+	// logins of the account so that the code can not be guessed by the holder of the password
+	if err := s.lockoutManager.CheckLockout(ctx, subdomain, u.Email, device.IP); err != nil {
+		return LoginResult{}, err
+	}
+
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, u.ID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if mfaEnabled {
+		if err := s.mfaService.Verify(ctx, u.ID, code); err != nil {
+			return LoginResult{}, s.mfaFailed(ctx, subdomain, u.Email, device.IP, err)
+		}
+
+		if err := s.lockoutManager.Unlock(ctx, subdomain, u.Email); err != nil {
+			return LoginResult{}, err
+		}
+
+		return s.sessionOrPasswordChange(ctx, u, org, rememberMe, device)
+	}
+
+	// complete the pending enrollment of the user as part of the login
+	recoveryCodes, err := s.mfaService.Activate(ctx, u.ID, code)
+	if err != nil {
+		return LoginResult{}, s.mfaFailed(ctx, subdomain, u.Email, device.IP, err)
+	}
+
+	if err := s.lockoutManager.Unlock(ctx, subdomain, u.Email); err != nil {
+		return LoginResult{}, err
+	}
+
+	// the recovery codes are returned along with the password change token as well
+	// since they can not be shown again
+	result, err := s.sessionOrPasswordChange(ctx, u, org, rememberMe, device)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	result.RecoveryCodes = recoveryCodes
+
+	return result, nil
+}
+
+func (s *service) SSOAuthorize(ctx context.Context, subdomain string, rememberMe bool) (string, string, error) {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.ssoService.AuthorizationURL(ctx, org, rememberMe)
+}
+
+func (s *service) SSOLogin(
+	ctx context.Context,
+	subdomain, code, state, browserBinding string,
+	device session.Device,
+) (LoginResult, error) {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// prevent login for suspended organization
+	if org.IsSuspended() {
+		return LoginResult{}, ErrOrgSuspended
+	}
+
+	identity, err := s.ssoService.Authenticate(ctx, org, code, state, browserBinding)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	u, err := s.userService.GetUserByOrgIDEmail(ctx, org.ID, identity.Email)
+	if err != nil {
+		if !base.IsNotFoundError(err) {
+			return LoginResult{}, err
+		}
+
+		if !identity.JITProvisioning {
+			return LoginResult{}, ErrSSOUserNotFound
+		}
+
+		if u, err = s.provisionSSOUser(ctx, org.ID, identity.Email); err != nil {
+			return LoginResult{}, err
+		}
+	}
+
+	// prevent login for disabled user
+	if u.DisabledAt != nil {
+		return LoginResult{}, ErrUserDisabled
+	}
+
+	return s.createSession(ctx, u, org, identity.RememberMe, device)
+}
+
+func (s *service) Refresh(ctx context.Context, subdomain, refreshToken string) (LoginResult, error) {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// prevent renewal for suspended organization
+	if org.IsSuspended() {
+		return LoginResult{}, ErrOrgSuspended
+	}
+
+	// the refresh token must belong to a session of the organization. it is checked before the token is used
+	// so that the token presented to another organization does not look reused on its next legitimate use
+	sess, err := s.sessionManager.ConsumeRefreshToken(ctx, org.ID, refreshToken)
+	if err != nil {
+		if errors.Is(err, session.ErrInvalidSession) || errors.Is(err, session.ErrRefreshTokenReused) ||
+			errors.Is(err, session.ErrMissingToken) {
+			return LoginResult{}, ErrInvalidRefreshToken
+		}
+
+		return LoginResult{}, err
+	}
+
+	u, err := s.userService.GetUserByID(ctx, sess.UserID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// prevent renewal for disabled user
+	if u.DisabledAt != nil {
+		return LoginResult{}, ErrUserDisabled
+	}
+
+	jwtToken, err := GenerateJWT(AccessTokenTTL, s.jwtKeys, u.ID, org.ID, org.Subdomain, sess.ID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	newRefreshToken, err := base.GenerateRandomToken()
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	err = s.sessionManager.RenewSession(ctx, u.ID, org.ID, sess.ID, jwtToken, newRefreshToken, sess.TTL)
+	if err != nil {
+		if errors.Is(err, session.ErrInvalidSession) {
+			return LoginResult{}, ErrInvalidRefreshToken
+		}
+
+		return LoginResult{}, err
+	}
+
+	return LoginResult{JWT: jwtToken, RefreshToken: newRefreshToken, TTL: sess.TTL}, nil
+}
+
+func (s *service) Logout(ctx context.Context, userID, orgID int64, sessionID string) error {
+	// there is no jwt session to revoke when authenticated using api token
+	if sessionID == "" {
+		return nil
+	}
+
+	return s.sessionManager.RevokeSession(ctx, userID, orgID, sessionID)
+}
+
+func (s *service) UnlockUser(ctx context.Context, orgID, userID int64) error {
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// the user must belong to the organization
+	if u.OrganizationID != orgID {
+		return base.NewNotFoundError("user not found for the given id")
+	}
+
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	return s.lockoutManager.Unlock(ctx, org.Subdomain, u.Email)
+}
+
+func (s *service) Impersonate(
+	ctx context.Context, operator string, orgID, userID int64, device session.Device,
+) (LoginResult, error) {
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if org.IsSuspended() {
+		return LoginResult{}, ErrOrgSuspended
+	}
+
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the user must belong to the organization
+	if u.OrganizationID != orgID {
+		return LoginResult{}, base.NewNotFoundError("user not found for the given id")
+	}
+
+	if u.DisabledAt != nil {
+		return LoginResult{}, ErrUserDisabled
+	}
+
+	sessionID, err := base.GenerateRandomToken()
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the jwt lives as long as the session since the session is not renewed
+	jwtToken, err := GenerateImpersonationJWT(ImpersonationSessionTTL, s.jwtKeys, u.ID, org.ID, org.Subdomain,
+		sessionID, operator)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the session requires a refresh token. it is never handed out so that the session can not be renewed
+	refreshToken, err := base.GenerateRandomToken()
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if err := s.sessionManager.CreateSession(
+		ctx,
+		u.ID,
+		org.ID,
+		sessionID,
+		jwtToken,
+		refreshToken,
+		device,
+		ImpersonationSessionTTL,
+	); err != nil {
+		return LoginResult{}, err
+	}
+
+	return LoginResult{JWT: jwtToken, TTL: ImpersonationSessionTTL}, nil
+}
+
+func (s *service) JWKS() JWKS {
+	return s.jwtKeys.JWKS()
+}
+
+// completeLogin creates the session of the user whose credentials are verified.
+// An mfa token is returned instead when the user must complete the mfa step, and a password change token
+// when the password of the user has expired.
+// The failed attempts of the account are forgotten only once all the login steps are completed.
+func (s *service) completeLogin(
+	ctx context.Context, u user.User, org organization.Organization, rememberMe bool, device session.Device,
+) (LoginResult, error) {
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, u.ID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// the session is issued only after the mfa step is completed
+	if mfaEnabled || org.MFARequired {
+		mfaToken, err := GenerateVerificationToken(MFAChallengeTokenTTL, s.appSecret, MFAChallengePurpose,
+			u.ID, org.ID, u.Email)
+		if err != nil {
+			return LoginResult{}, err
+		}
+
+		return LoginResult{MFAToken: mfaToken, MFAEnrollmentRequired: !mfaEnabled}, nil
+	}
+
+	if err := s.lockoutManager.Unlock(ctx, org.Subdomain, u.Email); err != nil {
+		return LoginResult{}, err
+	}
+
+	return s.sessionOrPasswordChange(ctx, u, org, rememberMe, device)
+}
+
+// sessionOrPasswordChange creates the session of the user who has completed all the login steps.
+// A password change token is returned instead when the password of the user has expired. It is issued only
+// after the mfa step so that the password can not be changed by someone knowing the expired password alone.
+func (s *service) sessionOrPasswordChange(
+	ctx context.Context, u user.User, org organization.Organization, rememberMe bool, device session.Device,
+) (LoginResult, error) {
+	expired, err := s.userService.IsPasswordExpired(ctx, u)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	if !expired {
+		return s.createSession(ctx, u, org, rememberMe, device)
+	}
+
+	token, err := GenerateVerificationToken(PasswordChangeTokenTTL, s.appSecret, PasswordChangePurpose,
+		u.ID, org.ID, u.Email)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	return LoginResult{PasswordChangeToken: token}, nil
+}
+
+// magicLinkOrganization returns the organization of the subdomain when its users can login using magic links.
+func (s *service) magicLinkOrganization(ctx context.Context, subdomain string) (organization.Organization, error) {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return organization.Organization{}, err
+	}
+
+	if !org.MagicLinkEnabled {
+		return organization.Organization{}, ErrMagicLinkDisabled
+	}
+
+	// prevent login for suspended organization
+	if org.IsSuspended() {
+		return organization.Organization{}, ErrOrgSuspended
+	}
+
+	return org, nil
+}
+
+// loginFailed registers the failed login attempt and returns ErrInvalidCredentials.
+func (s *service) loginFailed(ctx context.Context, subdomain, email, ip string) error {
+	if err := s.lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ip); err != nil {
+		return err
+	}
+
+	return ErrInvalidCredentials
+}
+
+// mfaFailed registers the failed login attempt when the mfa code is invalid and returns the given error.
+func (s *service) mfaFailed(ctx context.Context, subdomain, email, ip string, err error) error {
+	if !errors.Is(err, mfa.ErrInvalidCode) {
+		return err
+	}
+
+	if lockoutErr := s.lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ip); lockoutErr != nil {
+		return lockoutErr
+	}
+
+	return err
+}
+
+// createSession generates a new jwt token for the user and stores it in a new session of the device.
+func (s *service) createSession(
+	ctx context.Context, u user.User, org organization.Organization, rememberMe bool, device session.Device,
+) (LoginResult, error) {
+	ttl := DefaultSessionTTL
+	if rememberMe {
+		ttl = RememberMeSessionTTL
+	}
+
+	sessionID, err := base.GenerateRandomToken()
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// generate a new short-lived jwt token with user, organization and session data
+	jwtToken, err := GenerateJWT(AccessTokenTTL, s.jwtKeys, u.ID, org.ID, org.Subdomain, sessionID)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	refreshToken, err := base.GenerateRandomToken()
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	// create session with the currently generated jwt token
+	// the existing sessions of the user on other devices are kept
+	if err := s.sessionManager.CreateSession(
+		ctx,
+		u.ID,
+		org.ID,
+		sessionID,
+		jwtToken,
+		refreshToken,
+		device,
+		ttl,
+	); err != nil {
+		return LoginResult{}, err
+	}
+
+	return LoginResult{JWT: jwtToken, RefreshToken: refreshToken, TTL: ttl}, nil
+}
+
+// provisionSSOUser creates the user authenticated by the identity provider on the first login.
+// The email is verified by the identity provider already.
+func (s *service) provisionSSOUser(ctx context.Context, orgID int64, email string) (user.User, error) {
+	var u user.User
+
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		if u, err = s.userService.CreateExternalUser(ctx, orgID, email); err != nil {
+			return err
+		}
+
+		return s.userService.SetEmailVerified(ctx, u.ID)
+	})
+	if err != nil {
+		return user.User{}, err
+	}
+
+	u.IsEmailVerified = true
+
+	return u, nil
+}
+
+// challengeUser returns the user and the organization of the token issued by a login step for the given purpose.
+// The token must be issued for a user of the organization with the given subdomain.
+// errInvalidToken is returned when the token is not valid.
+func (s *service) challengeUser(ctx context.Context, subdomain, token, purpose string, errInvalidToken error) (
+	user.User, organization.Organization, error,
+) {
+	claims, err := ParseAndValidateVerificationToken(token, s.appSecret, purpose)
+	if err != nil {
+		return user.User{}, organization.Organization{}, errInvalidToken
+	}
+
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return user.User{}, organization.Organization{}, err
+	}
+
+	if claims.OrgID != org.ID {
+		return user.User{}, organization.Organization{}, errInvalidToken
+	}
+
+	// the organization might have been suspended after the login step
+	if org.IsSuspended() {
+		return user.User{}, organization.Organization{}, ErrOrgSuspended
+	}
+
+	u, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return user.User{}, organization.Organization{}, errInvalidToken
+		}
+
+		return user.User{}, organization.Organization{}, err
+	}
+
+	// the user might have been disabled after the login step
+	if u.DisabledAt != nil {
+		return user.User{}, organization.Organization{}, ErrUserDisabled
+	}
+
+	return u, org, nil
+}
+
+// checkEmailAvailable returns ErrEmailAlreadyExists when a user of the organization has the given email.
+func (s *service) checkEmailAvailable(ctx context.Context, orgID int64, email string) error {
+	_, err := s.userService.GetUserByOrgIDEmail(ctx, orgID, email)
+	if err == nil {
+		return ErrEmailAlreadyExists
+	}
+
+	if !base.IsNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// isPendingVerification returns true if the deleted organization is a new organization
+// whose owner has not verified the email yet.
+func isPendingVerification(org organization.Organization) bool {
+	return org.Comment != nil && *org.Comment == NewOrgDeleteComment
+}
+
+// sendPasswordResetEmail generates a password reset token for the user and mails the reset link.
+// Only the most recently issued token is usable.
+func (s *service) sendPasswordResetEmail(ctx context.Context, org organization.Organization, u user.User) error {
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.InvalidatePasswordResetTokens(ctx, u.ID); err != nil {
+			return err
+		}
+
+		return s.repo.CreatePasswordResetToken(ctx, u.ID, base.HashToken(token), PasswordResetTokenTTL)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, passwordResetEmail(u.Email, s.appURL, org.Subdomain, token))
+}
+
+// sendMagicLink mails a new magic link bound to the given browser binding to the user of the email.
+// Nothing is sent when the user is not found or is disabled.
+func (s *service) sendMagicLink(
+	ctx context.Context, org organization.Organization, email, browserBinding string, rememberMe bool,
+) error {
+	u, err := s.userService.GetUserByOrgIDEmail(ctx, org.ID, email)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// disabled users are not allowed to login
+	if u.DisabledAt != nil {
+		return nil
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	// only the most recently requested link should be usable
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.InvalidateMagicLinkTokens(ctx, u.ID); err != nil {
+			return err
+		}
+
+		return s.repo.CreateMagicLinkToken(ctx, MagicLink{UserID: u.ID, RememberMe: rememberMe},
+			base.HashToken(token), base.HashToken(browserBinding), MagicLinkTokenTTL)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, magicLinkEmail(u.Email, s.appURL, org.Subdomain, org.Name, token))
+}
+
+// sendVerificationEmail generates an email verification token for the user and mails it.
+func (s *service) sendVerificationEmail(ctx context.Context, u user.User) error {
+	token, err := GenerateVerificationToken(EmailVerificationTokenTTL, s.appSecret, EmailVerificationPurpose,
+		u.ID, u.OrganizationID, u.Email)
+	if err != nil {
+		return err
 	}
 
-	return ""
+	return s.mailer.Send(ctx, verificationEmail(u.Email, s.appURL, token))
 }
@@ -4,15 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/mock"
 )
 
 func (s *AuthTestSuite) TestServiceIntegration_Register() {
@@ -49,12 +56,15 @@ func (s *AuthTestSuite) TestServiceIntegration_Register() {
 
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 		userRepo := user.NewRepository(s.DB)
-		userService := user.NewService(userRepo, nil)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		orgRepo := organization.NewRepository(s.DB)
-		orgService := organization.NewService(orgRepo, sessionManager)
-		authService := auth.NewService(s.Config.AppSecret, s.DB, orgService, userService, sessionManager)
+		orgService := organization.NewService(s.Config, orgRepo, sessionManager, nil)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
 
-		subdomain := gofakeit.LetterN(20)
+		subdomain := strings.ToLower(gofakeit.LetterN(20))
 		orgName := gofakeit.LetterN(50)
 
 		// provide an invalid email to trigger user creation error
@@ -75,12 +85,15 @@ func (s *AuthTestSuite) TestServiceIntegration_Register() {
 
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 		userRepo := user.NewRepository(s.DB)
-		userService := user.NewService(userRepo, nil)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		orgRepo := organization.NewRepository(s.DB)
-		orgService := organization.NewService(orgRepo, sessionManager)
-		authService := auth.NewService(s.Config.AppSecret, s.DB, orgService, userService, sessionManager)
+		orgService := organization.NewService(s.Config, orgRepo, sessionManager, nil)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
 
-		subdomain := gofakeit.LetterN(20)
+		subdomain := strings.ToLower(gofakeit.LetterN(20))
 		orgName := gofakeit.LetterN(50)
 		email := gofakeit.Email()
 		password := validPassword
@@ -109,33 +122,150 @@ func (s *AuthTestSuite) TestServiceIntegration_Register() {
 	})
 }
 
+func (s *AuthTestSuite) TestServiceIntegration_VerifyEmail() {
+	s.Run("should restore the organization and verify the owner email", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		userRepo := user.NewRepository(s.DB)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgRepo := organization.NewRepository(s.DB)
+		orgService := organization.NewService(s.Config, orgRepo, sessionManager, nil)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
+
+		subdomain := strings.ToLower(gofakeit.LetterN(20))
+		email := gofakeit.Email()
+
+		err := authService.Register(ctx, email, validPassword, subdomain, gofakeit.LetterN(50))
+		s.Require().NoError(err)
+
+		// the organization is pending verification
+		_, err = orgService.GetOrganizationBySubdomain(ctx, subdomain)
+		s.Require().Error(err)
+
+		var owner user.User
+
+		err = s.DB.Get(ctx, &owner, "SELECT * FROM users WHERE email = $1", email)
+		s.Require().NoError(err)
+
+		token, err := auth.GenerateVerificationToken(auth.EmailVerificationTokenTTL, s.Config.AppSecret,
+			auth.EmailVerificationPurpose, owner.ID, owner.OrganizationID, owner.Email)
+		s.Require().NoError(err)
+
+		err = authService.VerifyEmail(ctx, token)
+		s.Require().NoError(err)
+
+		org, err := orgService.GetOrganizationBySubdomain(ctx, subdomain)
+		s.Require().NoError(err)
+		s.Nil(org.DeletedAt)
+
+		u, err := userService.GetUserByID(ctx, owner.ID)
+		s.Require().NoError(err)
+		s.True(u.IsEmailVerified)
+
+		// the token can not be used again
+		err = authService.VerifyEmail(ctx, token)
+		s.Require().ErrorIs(err, auth.ErrVerificationTokenUsed)
+	})
+}
+
+func (s *AuthTestSuite) TestServiceIntegration_ResetPassword() {
+	s.Run("should reset the password only once with the mailed token", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		o := fake.NewOrganization(s.DB)
+		u := o.AddUser(s.DB, fake.UserPassword(validPassword))
+		newPassword := "@n3wPassw0rd"
+
+		tokens := make(chan string, 1)
+
+		// capture the token from the reset link mailed in the background
+		mockMailer := mailer.NewMockMailer(s.T())
+		mockMailer.On("Send", fake.MockContext, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg, _ := args.Get(1).(mailer.Message)
+				link := msg.Body[strings.Index(msg.Body, s.Config.AppURL):]
+				link = link[:strings.Index(link, "\n")]
+				parsed, _ := url.Parse(link)
+
+				tokens <- parsed.Query().Get("token")
+			}).
+			Return(nil)
+
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		userService := user.NewService(user.NewRepository(s.DB), nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgService := organization.NewService(s.Config, organization.NewRepository(s.DB), sessionManager, nil)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, auth.NewRepository(s.DB), s.DB, orgService, userService,
+			mfaService, nil, sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mockMailer)
+
+		err := authService.ForgotPassword(ctx, o.Subdomain, u.Email)
+		s.Require().NoError(err)
+
+		var token string
+
+		select {
+		case token = <-tokens:
+		case <-time.After(5 * time.Second):
+			s.FailNow("timed out waiting for the reset email")
+		}
+
+		s.Require().NotEmpty(token)
+
+		err = authService.ResetPassword(ctx, o.Subdomain, token, newPassword)
+		s.Require().NoError(err)
+
+		_, err = authService.Login(ctx, o.Subdomain, u.Email, newPassword, false, session.Device{})
+		s.Require().NoError(err)
+
+		// the token can not be used again
+		err = authService.ResetPassword(ctx, o.Subdomain, token, validPassword)
+		s.Require().ErrorIs(err, auth.ErrInvalidResetToken)
+	})
+}
+
 func (s *AuthTestSuite) TestServiceIntegration_Login() {
 	s.Run("should login successfully", func() {
 		s.T().Parallel()
 
 		ctx := context.Background()
 		userRepo := user.NewRepository(s.DB)
-		userService := user.NewService(userRepo, nil)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		orgRepo := organization.NewRepository(s.DB)
-		orgService := organization.NewService(orgRepo, nil)
+		orgService := organization.NewService(s.Config, orgRepo, nil, nil)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
-		authService := auth.NewService(s.Config.AppSecret, s.DB, orgService, userService, sessionManager)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
 
 		password := validPassword
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(password))
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", o.ID, u.ID)
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+
+		result, err := authService.Login(context.Background(), o.Subdomain, u.Email, password, false, device)
+		s.Require().NoError(err)
+		s.NotEmpty(result.JWT)
+		s.Equal(auth.DefaultSessionTTL, result.TTL)
 
-		jwt, ttl, err := authService.Login(context.Background(), o.Subdomain, u.Email, password, false)
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, s.JWTKeys)
 		s.Require().NoError(err)
-		s.NotEmpty(jwt)
-		s.Equal(auth.DefaultSessionTTL, ttl)
+		sessionKey := fmt.Sprintf("session:org:%v:user:%v:sid:%v", o.ID, u.ID, claims.SessionID)
 
 		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Require().Len(sessionData, 4)
+		s.Require().Len(sessionData, 8)
 		s.Equal(strconv.FormatInt(u.ID, 10), sessionData["user"])
 		s.Equal(strconv.FormatInt(o.ID, 10), sessionData["org"])
-		s.Equal(jwt, sessionData["jwt"])
+		s.Equal(result.JWT, sessionData["jwt"])
+		s.Equal(device.UserAgent, sessionData["userAgent"])
+		s.Equal(device.IP, sessionData["ip"])
 
 		sessionTTL := s.RedisClient.TTL(ctx, sessionKey).Val()
 		s.Require().Equal(auth.DefaultSessionTTL, sessionTTL)
@@ -146,31 +276,291 @@ func (s *AuthTestSuite) TestServiceIntegration_Login() {
 
 		ctx := context.Background()
 		userRepo := user.NewRepository(s.DB)
-		userService := user.NewService(userRepo, nil)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		orgRepo := organization.NewRepository(s.DB)
-		orgService := organization.NewService(orgRepo, nil)
+		orgService := organization.NewService(s.Config, orgRepo, nil, nil)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
-		authService := auth.NewService(s.Config.AppSecret, s.DB, orgService, userService, sessionManager)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
 
 		password := validPassword
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(password))
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", o.ID, u.ID)
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
 
-		jwt, ttl, err := authService.Login(context.Background(), o.Subdomain, u.Email, password, true)
+		result, err := authService.Login(context.Background(), o.Subdomain, u.Email, password, true, device)
 		s.Require().NoError(err)
-		s.NotEmpty(jwt)
-		s.Equal(auth.RememberMeSessionTTL, ttl)
+		s.NotEmpty(result.JWT)
+		s.Equal(auth.RememberMeSessionTTL, result.TTL)
+
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, s.JWTKeys)
+		s.Require().NoError(err)
+		sessionKey := fmt.Sprintf("session:org:%v:user:%v:sid:%v", o.ID, u.ID, claims.SessionID)
 
 		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Require().Len(sessionData, 4)
+		s.Require().Len(sessionData, 8)
 		s.Equal(strconv.FormatInt(u.ID, 10), sessionData["user"])
 		s.Equal(strconv.FormatInt(o.ID, 10), sessionData["org"])
-		s.Equal(jwt, sessionData["jwt"])
+		s.Equal(result.JWT, sessionData["jwt"])
+		s.Equal(device.UserAgent, sessionData["userAgent"])
+		s.Equal(device.IP, sessionData["ip"])
 
 		sessionTTL := s.RedisClient.TTL(ctx, sessionKey).Val()
 		s.Require().Equal(auth.RememberMeSessionTTL, sessionTTL)
 	})
+
+	s.Run("should upgrade the bcrypt password hash to argon2id", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userRepo := user.NewRepository(s.DB)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgRepo := organization.NewRepository(s.DB)
+		orgService := organization.NewService(s.Config, orgRepo, nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
+
+		// the fake users are created with bcrypt hashes
+		password := validPassword
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(password))
+		s.Require().True(strings.HasPrefix(u.PasswordHash, "$2"))
+
+		_, err := authService.Login(ctx, o.Subdomain, u.Email, password, false, session.Device{})
+		s.Require().NoError(err)
+
+		result, err := userService.GetUserByID(ctx, u.ID)
+		s.Require().NoError(err)
+		s.True(strings.HasPrefix(result.PasswordHash, "$argon2id$"))
+
+		// the password still works with the upgraded hash
+		_, err = authService.Login(ctx, o.Subdomain, u.Email, password, false, session.Device{})
+		s.Require().NoError(err)
+	})
+}
+
+func (s *AuthTestSuite) TestServiceIntegration_LoginWithMFA() {
+	s.Run("should issue the session only after the mfa step", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userService := user.NewService(user.NewRepository(s.DB), nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgService := organization.NewService(s.Config, organization.NewRepository(s.DB), nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		enrollment, err := mfaService.Enroll(ctx, u.ID)
+		s.Require().NoError(err)
+
+		step := mfa.TimeStep(time.Now())
+		code, err := mfa.GenerateCode(enrollment.Secret, step)
+		s.Require().NoError(err)
+
+		recoveryCodes, err := mfaService.Activate(ctx, u.ID, code)
+		s.Require().NoError(err)
+		s.Len(recoveryCodes, mfa.RecoveryCodeCount)
+
+		result, err := authService.Login(ctx, o.Subdomain, u.Email, validPassword, false, session.Device{})
+		s.Require().NoError(err)
+		s.Empty(result.JWT)
+		s.Require().NotEmpty(result.MFAToken)
+
+		// the code used for activation can not be replayed
+		_, err = authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, code, false, session.Device{})
+		s.Require().ErrorIs(err, mfa.ErrInvalidCode)
+
+		nextCode, err := mfa.GenerateCode(enrollment.Secret, step+1)
+		s.Require().NoError(err)
+
+		verified, err := authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, nextCode, false,
+			session.Device{})
+		s.Require().NoError(err)
+		s.NotEmpty(verified.JWT)
+
+		// a recovery code can be used instead of the totp code
+		verified, err = authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, recoveryCodes[0], false,
+			session.Device{})
+		s.Require().NoError(err)
+		s.NotEmpty(verified.JWT)
+	})
+
+	s.Run("should lock the mfa step after too many invalid codes", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		conf := s.Config
+		conf.LoginMaxFailedAttempts = 3
+		conf.LoginFailedAttemptsWindow = 60
+		conf.LoginLockoutDuration = 60
+		conf.LoginMaxLockoutDuration = 60
+		userService := user.NewService(user.NewRepository(s.DB), nil, user.NewArgon2idPasswordHasher(conf),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgService := organization.NewService(conf, organization.NewRepository(s.DB), nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(conf, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, conf), mailer.NewLogMailer())
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		enrollment, err := mfaService.Enroll(ctx, u.ID)
+		s.Require().NoError(err)
+
+		step := mfa.TimeStep(time.Now())
+		code, err := mfa.GenerateCode(enrollment.Secret, step)
+		s.Require().NoError(err)
+
+		_, err = mfaService.Activate(ctx, u.ID, code)
+		s.Require().NoError(err)
+
+		result, err := authService.Login(ctx, o.Subdomain, u.Email, validPassword, false, session.Device{})
+		s.Require().NoError(err)
+		s.Require().NotEmpty(result.MFAToken)
+
+		for range conf.LoginMaxFailedAttempts {
+			_, err = authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, "invalid-code", false,
+				session.Device{})
+			s.Require().ErrorIs(err, mfa.ErrInvalidCode)
+		}
+
+		// the valid code is rejected as well while locked
+		nextCode, err := mfa.GenerateCode(enrollment.Secret, step+1)
+		s.Require().NoError(err)
+
+		_, err = authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, nextCode, false, session.Device{})
+
+		var lockedErr *lockout.LockedError
+		s.Require().ErrorAs(err, &lockedErr)
+	})
+
+	s.Run("should keep counting the invalid codes across the password logins", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		conf := s.Config
+		conf.LoginMaxFailedAttempts = 3
+		conf.LoginFailedAttemptsWindow = 60
+		conf.LoginLockoutDuration = 60
+		conf.LoginMaxLockoutDuration = 60
+		userService := user.NewService(user.NewRepository(s.DB), nil, user.NewArgon2idPasswordHasher(conf),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgService := organization.NewService(conf, organization.NewRepository(s.DB), nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(conf, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, conf), mailer.NewLogMailer())
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		enrollment, err := mfaService.Enroll(ctx, u.ID)
+		s.Require().NoError(err)
+
+		code, err := mfa.GenerateCode(enrollment.Secret, mfa.TimeStep(time.Now()))
+		s.Require().NoError(err)
+
+		_, err = mfaService.Activate(ctx, u.ID, code)
+		s.Require().NoError(err)
+
+		// the holder of the password logs in again before every guess
+		for range conf.LoginMaxFailedAttempts {
+			result, err := authService.Login(ctx, o.Subdomain, u.Email, validPassword, false, session.Device{})
+			s.Require().NoError(err)
+			s.Require().NotEmpty(result.MFAToken)
+
+			_, err = authService.VerifyMFA(ctx, o.Subdomain, result.MFAToken, "invalid-code", false,
+				session.Device{})
+			s.Require().ErrorIs(err, mfa.ErrInvalidCode)
+		}
+
+		_, err = authService.Login(ctx, o.Subdomain, u.Email, validPassword, false, session.Device{})
+
+		var lockedErr *lockout.LockedError
+		s.Require().ErrorAs(err, &lockedErr)
+	})
+}
+
+func (s *AuthTestSuite) TestServiceIntegration_Refresh() {
+	s.Run("should renew the session and reject the reuse of the rotated refresh token", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userRepo := user.NewRepository(s.DB)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgRepo := organization.NewRepository(s.DB)
+		orgService := organization.NewService(s.Config, orgRepo, nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
+
+		password := validPassword
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(password))
+
+		loginResult, err := authService.Login(ctx, o.Subdomain, u.Email, password, false, session.Device{})
+		s.Require().NoError(err)
+
+		result, err := authService.Refresh(ctx, o.Subdomain, loginResult.RefreshToken)
+		s.Require().NoError(err)
+		s.NotEqual(loginResult.RefreshToken, result.RefreshToken)
+		s.Equal(auth.DefaultSessionTTL, result.TTL)
+
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, s.JWTKeys)
+		s.Require().NoError(err)
+		err = sessionManager.ValidateJWTSession(ctx, u.ID, o.ID, claims.SessionID, result.JWT)
+		s.Require().NoError(err)
+
+		// reusing the rotated refresh token revokes the session
+		_, err = authService.Refresh(ctx, o.Subdomain, loginResult.RefreshToken)
+		s.Require().ErrorIs(err, auth.ErrInvalidRefreshToken)
+
+		err = sessionManager.ValidateJWTSession(ctx, u.ID, o.ID, claims.SessionID, result.JWT)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+		_, err = authService.Refresh(ctx, o.Subdomain, result.RefreshToken)
+		s.Require().ErrorIs(err, auth.ErrInvalidRefreshToken)
+	})
+
+	s.Run("should keep the refresh token presented to another organization usable", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userService := user.NewService(user.NewRepository(s.DB), nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		orgService := organization.NewService(s.Config, organization.NewRepository(s.DB), nil, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
+
+		o := fake.NewOrganization(s.DB)
+		otherOrg := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(validPassword))
+
+		loginResult, err := authService.Login(ctx, o.Subdomain, u.Email, validPassword, false, session.Device{})
+		s.Require().NoError(err)
+
+		_, err = authService.Refresh(ctx, otherOrg.Subdomain, loginResult.RefreshToken)
+		s.Require().ErrorIs(err, auth.ErrInvalidRefreshToken)
+
+		// the token is not used up so its next use is not taken as a reuse
+		result, err := authService.Refresh(ctx, o.Subdomain, loginResult.RefreshToken)
+		s.Require().NoError(err)
+		s.NotEmpty(result.JWT)
+	})
 }
 
 func (s *AuthTestSuite) TestServiceIntegration_Logout() {
@@ -179,26 +569,37 @@ func (s *AuthTestSuite) TestServiceIntegration_Logout() {
 
 		ctx := context.Background()
 		userRepo := user.NewRepository(s.DB)
-		userService := user.NewService(userRepo, nil)
+		userService := user.NewService(userRepo, nil, user.NewArgon2idPasswordHasher(s.Config),
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		orgRepo := organization.NewRepository(s.DB)
-		orgService := organization.NewService(orgRepo, nil)
+		orgService := organization.NewService(s.Config, orgRepo, nil, nil)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
-		authService := auth.NewService(s.Config.AppSecret, s.DB, orgService, userService, sessionManager)
+		mfaService := mfa.NewService(mfa.NewRepository(s.DB), s.DB, orgService, userService)
+		authService := auth.NewService(s.Config, s.JWTKeys, nil, s.DB, orgService, userService, mfaService, nil,
+			sessionManager, lockout.NewRedisLockoutManager(s.RedisClient, s.Config), mailer.NewLogMailer())
 
 		password := validPassword
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserPassword(password))
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", o.ID, u.ID)
 
-		jwt, ttl, err := authService.Login(context.Background(), o.Subdomain, u.Email, password, false)
+		// login from two devices
+		result, err := authService.Login(ctx, o.Subdomain, u.Email, password, false, session.Device{})
+		s.Require().NoError(err)
+		otherResult, err := authService.Login(ctx, o.Subdomain, u.Email, password, false, session.Device{})
 		s.Require().NoError(err)
-		s.NotEmpty(jwt)
-		s.Equal(auth.DefaultSessionTTL, ttl)
 
-		err = authService.Logout(context.Background(), u.ID, o.ID)
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, s.JWTKeys)
+		s.Require().NoError(err)
+		_, otherClaims, err := auth.ParseAndValidateJWT(otherResult.JWT, s.JWTKeys)
 		s.Require().NoError(err)
 
-		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Require().Empty(sessionData)
+		err = authService.Logout(ctx, u.ID, o.ID, claims.SessionID)
+		s.Require().NoError(err)
+
+		// only the session of the first device is revoked
+		err = sessionManager.ValidateJWTSession(ctx, u.ID, o.ID, claims.SessionID, result.JWT)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+		err = sessionManager.ValidateJWTSession(ctx, u.ID, o.ID, otherClaims.SessionID, otherResult.JWT)
+		s.Require().NoError(err)
 	})
 }
@@ -4,9 +4,11 @@ package auth
 
 import (
 	context "context"
-	time "time"
 
+	mfa "github.com/camelhr/camelhr-api/internal/domains/mfa"
 	mock "github.com/stretchr/testify/mock"
+
+	session "github.com/camelhr/camelhr-api/internal/domains/session"
 )
 
 // MockService is an autogenerated mock type for the Service type
@@ -22,39 +24,342 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: ctx, subdomain, email, password, rememberMe
-func (_m *MockService) Login(ctx context.Context, subdomain string, email string, password string, rememberMe bool) (string, time.Duration, error) {
-	ret := _m.Called(ctx, subdomain, email, password, rememberMe)
+// ChangeExpiredPassword provides a mock function with given fields: ctx, subdomain, token, newPassword, rememberMe, device
+func (_m *MockService) ChangeExpiredPassword(ctx context.Context, subdomain string, token string, newPassword string, rememberMe bool, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, token, newPassword, rememberMe, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for ChangeExpiredPassword")
 	}
 
-	var r0 string
-	var r1 time.Duration
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) (string, time.Duration, error)); ok {
-		return rf(ctx, subdomain, email, password, rememberMe)
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, token, newPassword, rememberMe, device)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) string); ok {
-		r0 = rf(ctx, subdomain, email, password, rememberMe)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) LoginResult); ok {
+		r0 = rf(ctx, subdomain, token, newPassword, rememberMe, device)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(LoginResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool) time.Duration); ok {
-		r1 = rf(ctx, subdomain, email, password, rememberMe)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool, session.Device) error); ok {
+		r1 = rf(ctx, subdomain, token, newPassword, rememberMe, device)
 	} else {
-		r1 = ret.Get(1).(time.Duration)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ChangeExpiredPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeExpiredPassword'
+type MockService_ChangeExpiredPassword_Call struct {
+	*mock.Call
+}
+
+// ChangeExpiredPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - token string
+//   - newPassword string
+//   - rememberMe bool
+//   - device session.Device
+func (_e *MockService_Expecter) ChangeExpiredPassword(ctx interface{}, subdomain interface{}, token interface{}, newPassword interface{}, rememberMe interface{}, device interface{}) *MockService_ChangeExpiredPassword_Call {
+	return &MockService_ChangeExpiredPassword_Call{Call: _e.mock.On("ChangeExpiredPassword", ctx, subdomain, token, newPassword, rememberMe, device)}
+}
+
+func (_c *MockService_ChangeExpiredPassword_Call) Run(run func(ctx context.Context, subdomain string, token string, newPassword string, rememberMe bool, device session.Device)) *MockService_ChangeExpiredPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(session.Device))
+	})
+	return _c
+}
+
+func (_c *MockService_ChangeExpiredPassword_Call) Return(_a0 LoginResult, _a1 error) *MockService_ChangeExpiredPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ChangeExpiredPassword_Call) RunAndReturn(run func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)) *MockService_ChangeExpiredPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEmailChange provides a mock function with given fields: ctx, subdomain, token
+func (_m *MockService) ConfirmEmailChange(ctx context.Context, subdomain string, token string) error {
+	ret := _m.Called(ctx, subdomain, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, bool) error); ok {
-		r2 = rf(ctx, subdomain, email, password, rememberMe)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subdomain, token)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
+}
+
+// MockService_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockService_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - token string
+func (_e *MockService_Expecter) ConfirmEmailChange(ctx interface{}, subdomain interface{}, token interface{}) *MockService_ConfirmEmailChange_Call {
+	return &MockService_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, subdomain, token)}
+}
+
+func (_c *MockService_ConfirmEmailChange_Call) Run(run func(ctx context.Context, subdomain string, token string)) *MockService_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ConfirmEmailChange_Call) Return(_a0 error) *MockService_ConfirmEmailChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ConfirmEmailChange_Call) RunAndReturn(run func(context.Context, string, string) error) *MockService_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmOrganizationRestore provides a mock function with given fields: ctx, subdomain, token
+func (_m *MockService) ConfirmOrganizationRestore(ctx context.Context, subdomain string, token string) error {
+	ret := _m.Called(ctx, subdomain, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmOrganizationRestore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subdomain, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ConfirmOrganizationRestore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmOrganizationRestore'
+type MockService_ConfirmOrganizationRestore_Call struct {
+	*mock.Call
+}
+
+// ConfirmOrganizationRestore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - token string
+func (_e *MockService_Expecter) ConfirmOrganizationRestore(ctx interface{}, subdomain interface{}, token interface{}) *MockService_ConfirmOrganizationRestore_Call {
+	return &MockService_ConfirmOrganizationRestore_Call{Call: _e.mock.On("ConfirmOrganizationRestore", ctx, subdomain, token)}
+}
+
+func (_c *MockService_ConfirmOrganizationRestore_Call) Run(run func(ctx context.Context, subdomain string, token string)) *MockService_ConfirmOrganizationRestore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ConfirmOrganizationRestore_Call) Return(_a0 error) *MockService_ConfirmOrganizationRestore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ConfirmOrganizationRestore_Call) RunAndReturn(run func(context.Context, string, string) error) *MockService_ConfirmOrganizationRestore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, subdomain, email
+func (_m *MockService) ForgotPassword(ctx context.Context, subdomain string, email string) error {
+	ret := _m.Called(ctx, subdomain, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subdomain, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type MockService_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+func (_e *MockService_Expecter) ForgotPassword(ctx interface{}, subdomain interface{}, email interface{}) *MockService_ForgotPassword_Call {
+	return &MockService_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, subdomain, email)}
+}
+
+func (_c *MockService_ForgotPassword_Call) Run(run func(ctx context.Context, subdomain string, email string)) *MockService_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ForgotPassword_Call) Return(_a0 error) *MockService_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ForgotPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *MockService_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Impersonate provides a mock function with given fields: ctx, operator, orgID, userID, device
+func (_m *MockService) Impersonate(ctx context.Context, operator string, orgID int64, userID int64, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, operator, orgID, userID, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Impersonate")
+	}
+
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, operator, orgID, userID, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, session.Device) LoginResult); ok {
+		r0 = rf(ctx, operator, orgID, userID, device)
+	} else {
+		r0 = ret.Get(0).(LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, session.Device) error); ok {
+		r1 = rf(ctx, operator, orgID, userID, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Impersonate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Impersonate'
+type MockService_Impersonate_Call struct {
+	*mock.Call
+}
+
+// Impersonate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operator string
+//   - orgID int64
+//   - userID int64
+//   - device session.Device
+func (_e *MockService_Expecter) Impersonate(ctx interface{}, operator interface{}, orgID interface{}, userID interface{}, device interface{}) *MockService_Impersonate_Call {
+	return &MockService_Impersonate_Call{Call: _e.mock.On("Impersonate", ctx, operator, orgID, userID, device)}
+}
+
+func (_c *MockService_Impersonate_Call) Run(run func(ctx context.Context, operator string, orgID int64, userID int64, device session.Device)) *MockService_Impersonate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64), args[4].(session.Device))
+	})
+	return _c
+}
+
+func (_c *MockService_Impersonate_Call) Return(_a0 LoginResult, _a1 error) *MockService_Impersonate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Impersonate_Call) RunAndReturn(run func(context.Context, string, int64, int64, session.Device) (LoginResult, error)) *MockService_Impersonate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JWKS provides a mock function with no fields
+func (_m *MockService) JWKS() JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 JWKS
+	if rf, ok := ret.Get(0).(func() JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(JWKS)
+	}
+
+	return r0
+}
+
+// MockService_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type MockService_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *MockService_Expecter) JWKS() *MockService_JWKS_Call {
+	return &MockService_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *MockService_JWKS_Call) Run(run func()) *MockService_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_JWKS_Call) Return(_a0 JWKS) *MockService_JWKS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_JWKS_Call) RunAndReturn(run func() JWKS) *MockService_JWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, subdomain, email, password, rememberMe, device
+func (_m *MockService) Login(ctx context.Context, subdomain string, email string, password string, rememberMe bool, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, email, password, rememberMe, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, email, password, rememberMe, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) LoginResult); ok {
+		r0 = rf(ctx, subdomain, email, password, rememberMe, device)
+	} else {
+		r0 = ret.Get(0).(LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool, session.Device) error); ok {
+		r1 = rf(ctx, subdomain, email, password, rememberMe, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
@@ -68,38 +373,39 @@ type MockService_Login_Call struct {
 //   - email string
 //   - password string
 //   - rememberMe bool
-func (_e *MockService_Expecter) Login(ctx interface{}, subdomain interface{}, email interface{}, password interface{}, rememberMe interface{}) *MockService_Login_Call {
-	return &MockService_Login_Call{Call: _e.mock.On("Login", ctx, subdomain, email, password, rememberMe)}
+//   - device session.Device
+func (_e *MockService_Expecter) Login(ctx interface{}, subdomain interface{}, email interface{}, password interface{}, rememberMe interface{}, device interface{}) *MockService_Login_Call {
+	return &MockService_Login_Call{Call: _e.mock.On("Login", ctx, subdomain, email, password, rememberMe, device)}
 }
 
-func (_c *MockService_Login_Call) Run(run func(ctx context.Context, subdomain string, email string, password string, rememberMe bool)) *MockService_Login_Call {
+func (_c *MockService_Login_Call) Run(run func(ctx context.Context, subdomain string, email string, password string, rememberMe bool, device session.Device)) *MockService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(session.Device))
 	})
 	return _c
 }
 
-func (_c *MockService_Login_Call) Return(_a0 string, _a1 time.Duration, _a2 error) *MockService_Login_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockService_Login_Call) Return(_a0 LoginResult, _a1 error) *MockService_Login_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_Login_Call) RunAndReturn(run func(context.Context, string, string, string, bool) (string, time.Duration, error)) *MockService_Login_Call {
+func (_c *MockService_Login_Call) RunAndReturn(run func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)) *MockService_Login_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Logout provides a mock function with given fields: ctx, userID, orgID
-func (_m *MockService) Logout(ctx context.Context, userID int64, orgID int64) error {
-	ret := _m.Called(ctx, userID, orgID)
+// Logout provides a mock function with given fields: ctx, userID, orgID, sessionID
+func (_m *MockService) Logout(ctx context.Context, userID int64, orgID int64, sessionID string) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Logout")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
-		r0 = rf(ctx, userID, orgID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -116,13 +422,14 @@ type MockService_Logout_Call struct {
 //   - ctx context.Context
 //   - userID int64
 //   - orgID int64
-func (_e *MockService_Expecter) Logout(ctx interface{}, userID interface{}, orgID interface{}) *MockService_Logout_Call {
-	return &MockService_Logout_Call{Call: _e.mock.On("Logout", ctx, userID, orgID)}
+//   - sessionID string
+func (_e *MockService_Expecter) Logout(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}) *MockService_Logout_Call {
+	return &MockService_Logout_Call{Call: _e.mock.On("Logout", ctx, userID, orgID, sessionID)}
 }
 
-func (_c *MockService_Logout_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockService_Logout_Call {
+func (_c *MockService_Logout_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string)) *MockService_Logout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
 	})
 	return _c
 }
@@ -132,57 +439,720 @@ func (_c *MockService_Logout_Call) Return(_a0 error) *MockService_Logout_Call {
 	return _c
 }
 
-func (_c *MockService_Logout_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_Logout_Call {
+func (_c *MockService_Logout_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockService_Logout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Register provides a mock function with given fields: ctx, email, password, subdomain, orgName
-func (_m *MockService) Register(ctx context.Context, email string, password string, subdomain string, orgName string) error {
-	ret := _m.Called(ctx, email, password, subdomain, orgName)
+// MagicLinkLogin provides a mock function with given fields: ctx, subdomain, token, browserBinding, device
+func (_m *MockService) MagicLinkLogin(ctx context.Context, subdomain string, token string, browserBinding string, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, token, browserBinding, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Register")
+		panic("no return value specified for MagicLinkLogin")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, email, password, subdomain, orgName)
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, token, browserBinding, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) LoginResult); ok {
+		r0 = rf(ctx, subdomain, token, browserBinding, device)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(LoginResult)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, session.Device) error); ok {
+		r1 = rf(ctx, subdomain, token, browserBinding, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
-type MockService_Register_Call struct {
+// MockService_MagicLinkLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MagicLinkLogin'
+type MockService_MagicLinkLogin_Call struct {
 	*mock.Call
 }
 
-// Register is a helper method to define mock.On call
+// MagicLinkLogin is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - password string
 //   - subdomain string
-//   - orgName string
-func (_e *MockService_Expecter) Register(ctx interface{}, email interface{}, password interface{}, subdomain interface{}, orgName interface{}) *MockService_Register_Call {
-	return &MockService_Register_Call{Call: _e.mock.On("Register", ctx, email, password, subdomain, orgName)}
+//   - token string
+//   - browserBinding string
+//   - device session.Device
+func (_e *MockService_Expecter) MagicLinkLogin(ctx interface{}, subdomain interface{}, token interface{}, browserBinding interface{}, device interface{}) *MockService_MagicLinkLogin_Call {
+	return &MockService_MagicLinkLogin_Call{Call: _e.mock.On("MagicLinkLogin", ctx, subdomain, token, browserBinding, device)}
 }
 
-func (_c *MockService_Register_Call) Run(run func(ctx context.Context, email string, password string, subdomain string, orgName string)) *MockService_Register_Call {
+func (_c *MockService_MagicLinkLogin_Call) Run(run func(ctx context.Context, subdomain string, token string, browserBinding string, device session.Device)) *MockService_MagicLinkLogin_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(session.Device))
 	})
 	return _c
 }
 
-func (_c *MockService_Register_Call) Return(_a0 error) *MockService_Register_Call {
-	_c.Call.Return(_a0)
+func (_c *MockService_MagicLinkLogin_Call) Return(_a0 LoginResult, _a1 error) *MockService_MagicLinkLogin_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_Register_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockService_Register_Call {
+func (_c *MockService_MagicLinkLogin_Call) RunAndReturn(run func(context.Context, string, string, string, session.Device) (LoginResult, error)) *MockService_MagicLinkLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, subdomain, refreshToken
+func (_m *MockService) Refresh(ctx context.Context, subdomain string, refreshToken string) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) LoginResult); ok {
+		r0 = rf(ctx, subdomain, refreshToken)
+	} else {
+		r0 = ret.Get(0).(LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, subdomain, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - refreshToken string
+func (_e *MockService_Expecter) Refresh(ctx interface{}, subdomain interface{}, refreshToken interface{}) *MockService_Refresh_Call {
+	return &MockService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, subdomain, refreshToken)}
+}
+
+func (_c *MockService_Refresh_Call) Run(run func(ctx context.Context, subdomain string, refreshToken string)) *MockService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Refresh_Call) Return(_a0 LoginResult, _a1 error) *MockService_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Refresh_Call) RunAndReturn(run func(context.Context, string, string) (LoginResult, error)) *MockService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, email, password, subdomain, orgName
+func (_m *MockService) Register(ctx context.Context, email string, password string, subdomain string, orgName string) error {
+	ret := _m.Called(ctx, email, password, subdomain, orgName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, email, password, subdomain, orgName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockService_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - subdomain string
+//   - orgName string
+func (_e *MockService_Expecter) Register(ctx interface{}, email interface{}, password interface{}, subdomain interface{}, orgName interface{}) *MockService_Register_Call {
+	return &MockService_Register_Call{Call: _e.mock.On("Register", ctx, email, password, subdomain, orgName)}
+}
+
+func (_c *MockService_Register_Call) Run(run func(ctx context.Context, email string, password string, subdomain string, orgName string)) *MockService_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Register_Call) Return(_a0 error) *MockService_Register_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_Register_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestEmailChange provides a mock function with given fields: ctx, userID, orgID, newEmail
+func (_m *MockService) RequestEmailChange(ctx context.Context, userID int64, orgID int64, newEmail string) error {
+	ret := _m.Called(ctx, userID, orgID, newEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestEmailChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, userID, orgID, newEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RequestEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestEmailChange'
+type MockService_RequestEmailChange_Call struct {
+	*mock.Call
+}
+
+// RequestEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - newEmail string
+func (_e *MockService_Expecter) RequestEmailChange(ctx interface{}, userID interface{}, orgID interface{}, newEmail interface{}) *MockService_RequestEmailChange_Call {
+	return &MockService_RequestEmailChange_Call{Call: _e.mock.On("RequestEmailChange", ctx, userID, orgID, newEmail)}
+}
+
+func (_c *MockService_RequestEmailChange_Call) Run(run func(ctx context.Context, userID int64, orgID int64, newEmail string)) *MockService_RequestEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RequestEmailChange_Call) Return(_a0 error) *MockService_RequestEmailChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RequestEmailChange_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockService_RequestEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestMagicLink provides a mock function with given fields: ctx, subdomain, email, rememberMe
+func (_m *MockService) RequestMagicLink(ctx context.Context, subdomain string, email string, rememberMe bool) (string, error) {
+	ret := _m.Called(ctx, subdomain, email, rememberMe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestMagicLink")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (string, error)); ok {
+		return rf(ctx, subdomain, email, rememberMe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) string); ok {
+		r0 = rf(ctx, subdomain, email, rememberMe)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, subdomain, email, rememberMe)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_RequestMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestMagicLink'
+type MockService_RequestMagicLink_Call struct {
+	*mock.Call
+}
+
+// RequestMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+//   - rememberMe bool
+func (_e *MockService_Expecter) RequestMagicLink(ctx interface{}, subdomain interface{}, email interface{}, rememberMe interface{}) *MockService_RequestMagicLink_Call {
+	return &MockService_RequestMagicLink_Call{Call: _e.mock.On("RequestMagicLink", ctx, subdomain, email, rememberMe)}
+}
+
+func (_c *MockService_RequestMagicLink_Call) Run(run func(ctx context.Context, subdomain string, email string, rememberMe bool)) *MockService_RequestMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_RequestMagicLink_Call) Return(_a0 string, _a1 error) *MockService_RequestMagicLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_RequestMagicLink_Call) RunAndReturn(run func(context.Context, string, string, bool) (string, error)) *MockService_RequestMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestOrganizationRestore provides a mock function with given fields: ctx, subdomain, email
+func (_m *MockService) RequestOrganizationRestore(ctx context.Context, subdomain string, email string) error {
+	ret := _m.Called(ctx, subdomain, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestOrganizationRestore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subdomain, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RequestOrganizationRestore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestOrganizationRestore'
+type MockService_RequestOrganizationRestore_Call struct {
+	*mock.Call
+}
+
+// RequestOrganizationRestore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+func (_e *MockService_Expecter) RequestOrganizationRestore(ctx interface{}, subdomain interface{}, email interface{}) *MockService_RequestOrganizationRestore_Call {
+	return &MockService_RequestOrganizationRestore_Call{Call: _e.mock.On("RequestOrganizationRestore", ctx, subdomain, email)}
+}
+
+func (_c *MockService_RequestOrganizationRestore_Call) Run(run func(ctx context.Context, subdomain string, email string)) *MockService_RequestOrganizationRestore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RequestOrganizationRestore_Call) Return(_a0 error) *MockService_RequestOrganizationRestore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RequestOrganizationRestore_Call) RunAndReturn(run func(context.Context, string, string) error) *MockService_RequestOrganizationRestore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, subdomain, token, newPassword
+func (_m *MockService) ResetPassword(ctx context.Context, subdomain string, token string, newPassword string) error {
+	ret := _m.Called(ctx, subdomain, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, subdomain, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - token string
+//   - newPassword string
+func (_e *MockService_Expecter) ResetPassword(ctx interface{}, subdomain interface{}, token interface{}, newPassword interface{}) *MockService_ResetPassword_Call {
+	return &MockService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, subdomain, token, newPassword)}
+}
+
+func (_c *MockService_ResetPassword_Call) Run(run func(ctx context.Context, subdomain string, token string, newPassword string)) *MockService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ResetPassword_Call) Return(_a0 error) *MockService_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSOAuthorize provides a mock function with given fields: ctx, subdomain, rememberMe
+func (_m *MockService) SSOAuthorize(ctx context.Context, subdomain string, rememberMe bool) (string, string, error) {
+	ret := _m.Called(ctx, subdomain, rememberMe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSOAuthorize")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (string, string, error)); ok {
+		return rf(ctx, subdomain, rememberMe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) string); ok {
+		r0 = rf(ctx, subdomain, rememberMe)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) string); ok {
+		r1 = rf(ctx, subdomain, rememberMe)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, subdomain, rememberMe)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockService_SSOAuthorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSOAuthorize'
+type MockService_SSOAuthorize_Call struct {
+	*mock.Call
+}
+
+// SSOAuthorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - rememberMe bool
+func (_e *MockService_Expecter) SSOAuthorize(ctx interface{}, subdomain interface{}, rememberMe interface{}) *MockService_SSOAuthorize_Call {
+	return &MockService_SSOAuthorize_Call{Call: _e.mock.On("SSOAuthorize", ctx, subdomain, rememberMe)}
+}
+
+func (_c *MockService_SSOAuthorize_Call) Run(run func(ctx context.Context, subdomain string, rememberMe bool)) *MockService_SSOAuthorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SSOAuthorize_Call) Return(_a0 string, _a1 string, _a2 error) *MockService_SSOAuthorize_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockService_SSOAuthorize_Call) RunAndReturn(run func(context.Context, string, bool) (string, string, error)) *MockService_SSOAuthorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SSOLogin provides a mock function with given fields: ctx, subdomain, code, state, browserBinding, device
+func (_m *MockService) SSOLogin(ctx context.Context, subdomain string, code string, state string, browserBinding string, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, code, state, browserBinding, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SSOLogin")
+	}
+
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, code, state, browserBinding, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, session.Device) LoginResult); ok {
+		r0 = rf(ctx, subdomain, code, state, browserBinding, device)
+	} else {
+		r0 = ret.Get(0).(LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, session.Device) error); ok {
+		r1 = rf(ctx, subdomain, code, state, browserBinding, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SSOLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SSOLogin'
+type MockService_SSOLogin_Call struct {
+	*mock.Call
+}
+
+// SSOLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - code string
+//   - state string
+//   - browserBinding string
+//   - device session.Device
+func (_e *MockService_Expecter) SSOLogin(ctx interface{}, subdomain interface{}, code interface{}, state interface{}, browserBinding interface{}, device interface{}) *MockService_SSOLogin_Call {
+	return &MockService_SSOLogin_Call{Call: _e.mock.On("SSOLogin", ctx, subdomain, code, state, browserBinding, device)}
+}
+
+func (_c *MockService_SSOLogin_Call) Run(run func(ctx context.Context, subdomain string, code string, state string, browserBinding string, device session.Device)) *MockService_SSOLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(session.Device))
+	})
+	return _c
+}
+
+func (_c *MockService_SSOLogin_Call) Return(_a0 LoginResult, _a1 error) *MockService_SSOLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_SSOLogin_Call) RunAndReturn(run func(context.Context, string, string, string, string, session.Device) (LoginResult, error)) *MockService_SSOLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetupMFA provides a mock function with given fields: ctx, subdomain, mfaToken
+func (_m *MockService) SetupMFA(ctx context.Context, subdomain string, mfaToken string) (mfa.Enrollment, error) {
+	ret := _m.Called(ctx, subdomain, mfaToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetupMFA")
+	}
+
+	var r0 mfa.Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (mfa.Enrollment, error)); ok {
+		return rf(ctx, subdomain, mfaToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) mfa.Enrollment); ok {
+		r0 = rf(ctx, subdomain, mfaToken)
+	} else {
+		r0 = ret.Get(0).(mfa.Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, subdomain, mfaToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SetupMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetupMFA'
+type MockService_SetupMFA_Call struct {
+	*mock.Call
+}
+
+// SetupMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - mfaToken string
+func (_e *MockService_Expecter) SetupMFA(ctx interface{}, subdomain interface{}, mfaToken interface{}) *MockService_SetupMFA_Call {
+	return &MockService_SetupMFA_Call{Call: _e.mock.On("SetupMFA", ctx, subdomain, mfaToken)}
+}
+
+func (_c *MockService_SetupMFA_Call) Run(run func(ctx context.Context, subdomain string, mfaToken string)) *MockService_SetupMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_SetupMFA_Call) Return(_a0 mfa.Enrollment, _a1 error) *MockService_SetupMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_SetupMFA_Call) RunAndReturn(run func(context.Context, string, string) (mfa.Enrollment, error)) *MockService_SetupMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: ctx, orgID, userID
+func (_m *MockService) UnlockUser(ctx context.Context, orgID int64, userID int64) error {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type MockService_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - userID int64
+func (_e *MockService_Expecter) UnlockUser(ctx interface{}, orgID interface{}, userID interface{}) *MockService_UnlockUser_Call {
+	return &MockService_UnlockUser_Call{Call: _e.mock.On("UnlockUser", ctx, orgID, userID)}
+}
+
+func (_c *MockService_UnlockUser_Call) Run(run func(ctx context.Context, orgID int64, userID int64)) *MockService_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_UnlockUser_Call) Return(_a0 error) *MockService_UnlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_UnlockUser_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_UnlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *MockService) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockService_Expecter) VerifyEmail(ctx interface{}, token interface{}) *MockService_VerifyEmail_Call {
+	return &MockService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, token)}
+}
+
+func (_c *MockService_VerifyEmail_Call) Run(run func(ctx context.Context, token string)) *MockService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyEmail_Call) Return(_a0 error) *MockService_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_VerifyEmail_Call) RunAndReturn(run func(context.Context, string) error) *MockService_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, subdomain, mfaToken, code, rememberMe, device
+func (_m *MockService) VerifyMFA(ctx context.Context, subdomain string, mfaToken string, code string, rememberMe bool, device session.Device) (LoginResult, error) {
+	ret := _m.Called(ctx, subdomain, mfaToken, code, rememberMe, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)); ok {
+		return rf(ctx, subdomain, mfaToken, code, rememberMe, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool, session.Device) LoginResult); ok {
+		r0 = rf(ctx, subdomain, mfaToken, code, rememberMe, device)
+	} else {
+		r0 = ret.Get(0).(LoginResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool, session.Device) error); ok {
+		r1 = rf(ctx, subdomain, mfaToken, code, rememberMe, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_VerifyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFA'
+type MockService_VerifyMFA_Call struct {
+	*mock.Call
+}
+
+// VerifyMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - mfaToken string
+//   - code string
+//   - rememberMe bool
+//   - device session.Device
+func (_e *MockService_Expecter) VerifyMFA(ctx interface{}, subdomain interface{}, mfaToken interface{}, code interface{}, rememberMe interface{}, device interface{}) *MockService_VerifyMFA_Call {
+	return &MockService_VerifyMFA_Call{Call: _e.mock.On("VerifyMFA", ctx, subdomain, mfaToken, code, rememberMe, device)}
+}
+
+func (_c *MockService_VerifyMFA_Call) Run(run func(ctx context.Context, subdomain string, mfaToken string, code string, rememberMe bool, device session.Device)) *MockService_VerifyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(session.Device))
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyMFA_Call) Return(_a0 LoginResult, _a1 error) *MockService_VerifyMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_VerifyMFA_Call) RunAndReturn(run func(context.Context, string, string, string, bool, session.Device) (LoginResult, error)) *MockService_VerifyMFA_Call {
 	_c.Call.Return(run)
 	return _c
 }
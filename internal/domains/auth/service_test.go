@@ -2,21 +2,29 @@ package auth_test
 
 import (
 	"context"
+	"database/sql"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/database"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+	"github.com/camelhr/camelhr-api/internal/tests"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -35,36 +43,57 @@ func TestService_Register(t *testing.T) {
 		subdomain := gofakeit.LetterN(30)
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).
-			Return(organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}, nil)
+		orgService.On("CheckSubdomainAvailability", ctx, subdomain).Return(organization.SubdomainAvailability{
+			Subdomain: subdomain,
+			Reason:    organization.SubdomainReasonTaken,
+		}, nil)
 
-		authService := auth.NewService("", nil, orgService, nil, nil)
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
 		err := authService.Register(ctx, email, validPassword, subdomain, orgName)
 
 		require.Error(t, err)
 		require.ErrorIs(t, auth.ErrSubdomainAlreadyExists, err)
 	})
 
-	t.Run("should return error when orgService.GetOrganizationBySubdomain returns error other than not found",
-		func(t *testing.T) {
-			t.Parallel()
+	t.Run("should return validation error when subdomain is reserved", func(t *testing.T) {
+		t.Parallel()
 
-			ctx := context.Background()
-			email := gofakeit.Email()
+		ctx := context.Background()
+		email := gofakeit.Email()
+		orgName := gofakeit.Company()
 
-			orgName := gofakeit.Company()
-			subdomain := gofakeit.LetterN(30)
+		orgService := organization.NewMockService(t)
+		orgService.On("CheckSubdomainAvailability", ctx, "admin").Return(organization.SubdomainAvailability{
+			Subdomain: "admin",
+			Reason:    organization.SubdomainReasonReserved,
+		}, nil)
 
-			orgService := organization.NewMockService(t)
-			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).
-				Return(organization.Organization{}, assert.AnError)
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.Register(ctx, email, validPassword, "admin", orgName)
 
-			authService := auth.NewService("", nil, orgService, nil, nil)
-			err := authService.Register(ctx, email, validPassword, subdomain, orgName)
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+		assert.EqualError(t, err, "subdomain is reserved")
+	})
 
-			require.Error(t, err)
-			require.ErrorIs(t, assert.AnError, err)
-		})
+	t.Run("should return error when orgService.CheckSubdomainAvailability returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		email := gofakeit.Email()
+		orgName := gofakeit.Company()
+		subdomain := gofakeit.LetterN(30)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("CheckSubdomainAvailability", ctx, subdomain).
+			Return(organization.SubdomainAvailability{}, assert.AnError)
+
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.Register(ctx, email, validPassword, subdomain, orgName)
+
+		require.Error(t, err)
+		require.ErrorIs(t, assert.AnError, err)
+	})
 
 	t.Run("should return error when transactor.WithTx returns error", func(t *testing.T) {
 		t.Parallel()
@@ -73,16 +102,16 @@ func TestService_Register(t *testing.T) {
 		email := gofakeit.Email()
 		orgName := gofakeit.Company()
 		subdomain := gofakeit.LetterN(30)
-		notFoundErr := base.NewNotFoundError("not found")
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).
-			Return(organization.Organization{}, notFoundErr)
+		orgService.On("CheckSubdomainAvailability", ctx, subdomain).
+			Return(organization.SubdomainAvailability{Subdomain: subdomain, Available: true}, nil)
 
 		transactor := database.NewMockTransactor(t)
 		transactor.On("WithTx", ctx, mock.Anything).Return(assert.AnError)
 
-		authService := auth.NewService("", transactor, orgService, nil, nil)
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, transactor, orgService, nil, nil, nil, nil,
+			nil, nil)
 		err := authService.Register(ctx, email, validPassword, subdomain, orgName)
 
 		require.Error(t, err)
@@ -96,281 +125,2673 @@ func TestService_Register(t *testing.T) {
 		email := gofakeit.Email()
 		orgName := gofakeit.Company()
 		subdomain := gofakeit.LetterN(30)
-		notFoundErr := base.NewNotFoundError("not found")
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(organization.Organization{}, notFoundErr)
+		orgService.On("CheckSubdomainAvailability", ctx, subdomain).
+			Return(organization.SubdomainAvailability{Subdomain: subdomain, Available: true}, nil)
 
 		transactor := database.NewMockTransactor(t)
 		transactor.On("WithTx", ctx, mock.Anything).Return(nil)
 
-		authService := auth.NewService("", transactor, orgService, nil, nil)
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, transactor, orgService, nil, nil, nil, nil,
+			nil, mockMailer)
 		err := authService.Register(ctx, email, validPassword, subdomain, orgName)
 
 		require.NoError(t, err)
 	})
-}
 
-func TestService_Login(t *testing.T) {
-	t.Parallel()
+	t.Run("should not return error when sending verification email fails", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("should return error when orgService.GetOrganizationBySubdomain returns error",
-		func(t *testing.T) {
-			t.Parallel()
+		ctx := context.Background()
+		email := gofakeit.Email()
+		orgName := gofakeit.Company()
+		subdomain := gofakeit.LetterN(30)
 
-			ctx := context.Background()
-			subdomain := gofakeit.LetterN(30)
+		orgService := organization.NewMockService(t)
+		orgService.On("CheckSubdomainAvailability", ctx, subdomain).
+			Return(organization.SubdomainAvailability{Subdomain: subdomain, Available: true}, nil)
 
-			orgService := organization.NewMockService(t)
-			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(organization.Organization{}, assert.AnError)
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(nil)
 
-			authService := auth.NewService("secret", nil, orgService, nil, nil)
-			_, _, err := authService.Login(ctx, subdomain, gofakeit.Email(), "@paSSw0rd", false)
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).Return(assert.AnError)
 
-			require.Error(t, err)
-			require.ErrorIs(t, assert.AnError, err)
-		})
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, transactor, orgService, nil, nil, nil, nil,
+			nil, mockMailer)
+		err := authService.Register(ctx, email, validPassword, subdomain, orgName)
 
-	t.Run("should return error when userService.GetUserByOrgIDEmail returns error",
-		func(t *testing.T) {
-			t.Parallel()
+		require.NoError(t, err)
+	})
+}
 
-			ctx := context.Background()
-			subdomain := gofakeit.LetterN(30)
-			o := organization.Organization{ID: gofakeit.Int64()}
-			email := gofakeit.Email()
+func TestService_VerifyEmail(t *testing.T) {
+	t.Parallel()
 
-			orgService := organization.NewMockService(t)
-			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+	t.Run("should return error when token is invalid", func(t *testing.T) {
+		t.Parallel()
 
-			userService := user.NewMockService(t)
-			userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, assert.AnError)
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		err := authService.VerifyEmail(context.Background(), "invalid-token")
 
-			authService := auth.NewService("secret", nil, orgService, userService, nil)
-			_, _, err := authService.Login(ctx, subdomain, email, validPassword, false)
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationToken)
+	})
 
-			require.Error(t, err)
-			require.ErrorIs(t, assert.AnError, err)
-		})
+	t.Run("should return error when token is issued for a different purpose", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("should return error when userService.GetUserByOrgIDEmail returns not found error",
-		func(t *testing.T) {
-			t.Parallel()
+		token, err := auth.GenerateVerificationToken(time.Hour, "secret", "other_purpose",
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
 
-			ctx := context.Background()
-			subdomain := gofakeit.LetterN(30)
-			o := organization.Organization{ID: gofakeit.Int64()}
-			email := gofakeit.Email()
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(context.Background(), token)
 
-			orgService := organization.NewMockService(t)
-			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationToken)
+	})
 
-			userService := user.NewMockService(t)
-			userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, base.NewNotFoundError("not found"))
+	t.Run("should return error when token is expired", func(t *testing.T) {
+		t.Parallel()
 
-			authService := auth.NewService("secret", nil, orgService, userService, nil)
-			_, _, err := authService.Login(ctx, subdomain, email, validPassword, false)
+		token, err := auth.GenerateVerificationToken(-time.Hour, "secret", auth.EmailVerificationPurpose,
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
 
-			require.Error(t, err)
-			require.ErrorIs(t, auth.ErrInvalidCredentials, err)
-		})
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(context.Background(), token)
 
-	t.Run("should return error when user is disabled", func(t *testing.T) {
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationToken)
+	})
+
+	t.Run("should return error when email is already verified", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		subdomain := gofakeit.LetterN(30)
-		email := gofakeit.Email()
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
-		require.NoError(t, err)
-
-		now := time.Now()
 		u := user.User{
-			ID:           gofakeit.Int64(),
-			PasswordHash: string(passwordHash),
-			DisabledAt:   &now,
+			ID:              gofakeit.Int64(),
+			OrganizationID:  gofakeit.Int64(),
+			Email:           gofakeit.Email(),
+			IsEmailVerified: true,
 		}
-		o := organization.Organization{ID: gofakeit.Int64()}
+		token, err := auth.GenerateVerificationToken(time.Hour, "secret", auth.EmailVerificationPurpose,
+			u.ID, u.OrganizationID, u.Email)
+		require.NoError(t, err)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		orgService.On("GetDeletedOrganizationByID", ctx, u.OrganizationID).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
 
 		userService := user.NewMockService(t)
-		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
 
-		authService := auth.NewService("secret", nil, orgService, userService, nil)
-		_, _, err = authService.Login(ctx, subdomain, email, validPassword, false)
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, transactor,
+			orgService, userService, nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(ctx, token)
 
 		require.Error(t, err)
-		require.ErrorIs(t, auth.ErrUserDisabled, err)
+		require.ErrorIs(t, err, auth.ErrVerificationTokenUsed)
 	})
 
-	t.Run("should return error when password is invalid", func(t *testing.T) {
+	t.Run("should return error when email does not match the token", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		subdomain := gofakeit.LetterN(30)
-		email := gofakeit.Email()
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
-		require.NoError(t, err)
-
 		u := user.User{
-			ID:           gofakeit.Int64(),
-			PasswordHash: string(passwordHash),
+			ID:             gofakeit.Int64(),
+			OrganizationID: gofakeit.Int64(),
+			Email:          gofakeit.Email(),
 		}
-		o := organization.Organization{ID: gofakeit.Int64()}
+		token, err := auth.GenerateVerificationToken(time.Hour, "secret", auth.EmailVerificationPurpose,
+			u.ID, u.OrganizationID, "other"+u.Email)
+		require.NoError(t, err)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		orgService.On("GetDeletedOrganizationByID", ctx, u.OrganizationID).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
 
 		userService := user.NewMockService(t)
-		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
 
-		authService := auth.NewService("secret", nil, orgService, userService, nil)
-		_, _, err = authService.Login(ctx, subdomain, email, validPassword+"ZZZ", false)
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, transactor,
+			orgService, userService, nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(ctx, token)
 
 		require.Error(t, err)
-		require.ErrorIs(t, auth.ErrInvalidCredentials, err)
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationToken)
 	})
 
-	t.Run("should return error when sessionManager.CreateSession returns error", func(t *testing.T) {
+	t.Run("should restore pending organization and verify email", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		subdomain := gofakeit.LetterN(30)
-		email := gofakeit.Email()
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
+		comment := auth.NewOrgDeleteComment
+		o := organization.Organization{ID: gofakeit.Int64(), Comment: &comment}
+		u := user.User{
+			ID:             gofakeit.Int64(),
+			OrganizationID: o.ID,
+			Email:          gofakeit.Email(),
+		}
+		token, err := auth.GenerateVerificationToken(time.Hour, "secret", auth.EmailVerificationPurpose,
+			u.ID, u.OrganizationID, u.Email)
+		require.NoError(t, err)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationByID", ctx, o.ID).Return(o, nil)
+		orgService.On("RestoreOrganization", ctx, o.ID, auth.NewOrgVerifiedComment).Return(nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("SetEmailVerified", ctx, u.ID).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, transactor,
+			orgService, userService, nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(ctx, token)
+
 		require.NoError(t, err)
+	})
+
+	t.Run("should not restore organization deleted for other reasons", func(t *testing.T) {
+		t.Parallel()
 
-		apiToken := gofakeit.UUID()
+		ctx := context.Background()
+		comment := gofakeit.Sentence(5)
+		o := organization.Organization{ID: gofakeit.Int64(), Comment: &comment}
 		u := user.User{
-			ID:           gofakeit.Int64(),
-			PasswordHash: string(passwordHash),
-			APIToken:     &apiToken,
+			ID:             gofakeit.Int64(),
+			OrganizationID: o.ID,
+			Email:          gofakeit.Email(),
 		}
-		o := organization.Organization{ID: gofakeit.Int64()}
+		token, err := auth.GenerateVerificationToken(time.Hour, "secret", auth.EmailVerificationPurpose,
+			u.ID, u.OrganizationID, u.Email)
+		require.NoError(t, err)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		orgService.On("GetDeletedOrganizationByID", ctx, o.ID).Return(o, nil)
 
 		userService := user.NewMockService(t)
-		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("GetUserByID", ctx, u.ID).Return(user.User{}, base.NewNotFoundError("not found"))
 
-		sessionManager := session.NewMockSessionManager(t)
-		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, apiToken,
-			auth.DefaultSessionTTL).Return(assert.AnError)
-
-		authService := auth.NewService("jwt_secret", nil, orgService, userService, sessionManager)
-		_, _, err = authService.Login(ctx, subdomain, email, validPassword, false)
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, transactor,
+			orgService, userService, nil, nil, nil, nil, nil)
+		err = authService.VerifyEmail(ctx, token)
 
 		require.Error(t, err)
-		require.ErrorIs(t, assert.AnError, err)
+		require.ErrorIs(t, err, auth.ErrInvalidVerificationToken)
 	})
+}
 
-	t.Run("should return the jwt token and default ttl", func(t *testing.T) {
+// waitFor waits until the work done in the background closes the channel.
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the background work")
+	}
+}
+
+func TestService_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not return error when organization is not found", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		subdomain := gofakeit.LetterN(30)
-		email := gofakeit.Email()
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ForgotPassword(ctx, subdomain, gofakeit.Email())
+
 		require.NoError(t, err)
+	})
 
-		apiToken := gofakeit.UUID()
-		u := user.User{
-			ID:           gofakeit.Int64(),
-			PasswordHash: string(passwordHash),
-			APIToken:     &apiToken,
-		}
-		o := organization.Organization{ID: gofakeit.Int64()}
+	t.Run("should not return error when user is not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		email := gofakeit.Email()
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
 
 		userService := user.NewMockService(t)
-		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, base.NewNotFoundError("not found"))
 
-		sessionManager := session.NewMockSessionManager(t)
-		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, apiToken,
-			auth.DefaultSessionTTL).Return(nil)
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.ForgotPassword(ctx, o.Subdomain, email)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should not send email when user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email(), DisabledAt: &now}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, u.Email).Return(u, nil)
 
-		authService := auth.NewService("jwt_secret", nil, orgService, userService, sessionManager)
-		token, ttl, err := authService.Login(ctx, subdomain, email, validPassword, false)
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.ForgotPassword(ctx, o.Subdomain, u.Email)
 
 		require.NoError(t, err)
-		require.NotEmpty(t, token)
-		assert.Equal(t, auth.DefaultSessionTTL, ttl)
 	})
 
-	t.Run("should return the jwt token and remember-me ttl", func(t *testing.T) {
+	t.Run("should return error when userService.GetUserByOrgIDEmail returns error", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		subdomain := gofakeit.LetterN(30)
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
 		email := gofakeit.Email()
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
-		require.NoError(t, err)
 
-		apiToken := gofakeit.UUID()
-		u := user.User{
-			ID:           gofakeit.Int64(),
-			PasswordHash: string(passwordHash),
-			APIToken:     &apiToken,
-		}
-		o := organization.Organization{ID: gofakeit.Int64()}
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, assert.AnError)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.ForgotPassword(ctx, o.Subdomain, email)
+
+		require.Error(t, err)
+		require.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should not return error when storing the token fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		done := make(chan struct{})
 
 		orgService := organization.NewMockService(t)
-		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
 
 		userService := user.NewMockService(t)
-		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, u.Email).Return(u, nil)
 
-		sessionManager := session.NewMockSessionManager(t)
-		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, apiToken,
-			auth.RememberMeSessionTTL).Return(nil)
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", fake.MockContext, mock.Anything).Return(tests.RunTx)
+
+		// the token is stored in the background after the response
+		repo := auth.NewMockRepository(t)
+		repo.On("InvalidatePasswordResetTokens", fake.MockContext, u.ID).Return(nil)
+		repo.On("CreatePasswordResetToken", fake.MockContext, u.ID, fake.MockString, auth.PasswordResetTokenTTL).
+			Run(func(mock.Arguments) { close(done) }).
+			Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		err := authService.ForgotPassword(ctx, o.Subdomain, u.Email)
+
+		require.NoError(t, err)
+		waitFor(t, done)
+	})
+
+	t.Run("should store the token hash and send the reset email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		done := make(chan struct{})
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, u.Email).Return(u, nil)
 
-		authService := auth.NewService("jwt_secret", nil, orgService, userService, sessionManager)
-		token, ttl, err := authService.Login(ctx, subdomain, email, validPassword, true)
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", fake.MockContext, mock.Anything).Return(tests.RunTx)
+
+		var tokenHash string
+
+		repo := auth.NewMockRepository(t)
+		repo.On("InvalidatePasswordResetTokens", fake.MockContext, u.ID).Return(nil)
+		repo.On("CreatePasswordResetToken", fake.MockContext, u.ID, fake.MockString, auth.PasswordResetTokenTTL).
+			Run(func(args mock.Arguments) {
+				tokenHash = args.String(2)
+			}).
+			Return(nil)
+
+		// the failure to send the email is not returned
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", fake.MockContext, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				defer close(done)
+
+				msg, ok := args.Get(1).(mailer.Message)
+				assert.True(t, ok)
+				assert.Equal(t, u.Email, msg.To)
+
+				// the mailed token must match the stored hash
+				link := msg.Body[strings.Index(msg.Body, "https://"):]
+				link = link[:strings.Index(link, "\n")]
+				parsed, err := url.Parse(link)
+				assert.NoError(t, err)
+				assert.Equal(t, o.Subdomain, parsed.Query().Get("subdomain"))
+				assert.Equal(t, tokenHash, base.HashToken(parsed.Query().Get("token")))
+			}).
+			Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{AppURL: "https://camelhr.com"}, nil, repo, transactor, orgService,
+			userService, nil, nil, nil, nil, mockMailer)
+		err := authService.ForgotPassword(ctx, o.Subdomain, u.Email)
 
 		require.NoError(t, err)
-		require.NotEmpty(t, token)
-		assert.Equal(t, auth.RememberMeSessionTTL, ttl)
+		waitFor(t, done)
 	})
 }
 
-func TestService_Logout(t *testing.T) {
+func TestService_ResetPassword(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should return error when sessionManager.DeleteSession returns error", func(t *testing.T) {
+	t.Run("should return error when token is not usable", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		userID := gofakeit.Int64()
-		orgID := gofakeit.Int64()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
 
-		sessionManager := session.NewMockSessionManager(t)
-		sessionManager.On("DeleteSession", ctx, userID, orgID).Return(assert.AnError)
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UsePasswordResetToken", ctx, base.HashToken(token)).Return(int64(0), sql.ErrNoRows)
 
-		authService := auth.NewService("", nil, nil, nil, sessionManager)
-		err := authService.Logout(ctx, userID, orgID)
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ResetPassword(ctx, o.Subdomain, token, validPassword)
 
 		require.Error(t, err)
-		require.ErrorIs(t, assert.AnError, err)
+		require.ErrorIs(t, err, auth.ErrInvalidResetToken)
 	})
 
-	t.Run("should logout successfully", func(t *testing.T) {
+	t.Run("should return error when token belongs to a different organization", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
-		userID := gofakeit.Int64()
-		orgID := gofakeit.Int64()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID + 1}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UsePasswordResetToken", ctx, base.HashToken(token)).Return(u.ID, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		err := authService.ResetPassword(ctx, o.Subdomain, token, validPassword)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidResetToken)
+	})
+
+	t.Run("should return error when userService.ResetPassword returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UsePasswordResetToken", ctx, base.HashToken(token)).Return(u.ID, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("ResetPassword", ctx, u.ID, validPassword).Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		err := authService.ResetPassword(ctx, o.Subdomain, token, validPassword)
+
+		require.Error(t, err)
+		require.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should reset password and delete the session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UsePasswordResetToken", ctx, base.HashToken(token)).Return(u.ID, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("ResetPassword", ctx, u.ID, validPassword).Return(nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("DeleteSession", ctx, u.ID, o.ID).Return(nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil,
+			sessionManager, nil, nil)
+		err := authService.ResetPassword(ctx, o.Subdomain, token, validPassword)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_RequestOrganizationRestore(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{AppSecret: gofakeit.LetterN(32), AppURL: "https://camelhr.com", OrgRestoreGracePeriod: 30}
+
+	t.Run("should not return error when deleted organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.RequestOrganizationRestore(ctx, subdomain, gofakeit.Email())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should not send email when organization is pending verification", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		comment := auth.NewOrgDeleteComment
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), Comment: &comment,
+			Timestamps: base.Timestamps{DeletedAt: &now}}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.RequestOrganizationRestore(ctx, o.Subdomain, gofakeit.Email())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should not send email when restore window has ended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		deletedAt := time.Now().UTC().Add(-31 * 24 * time.Hour)
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30),
+			Timestamps: base.Timestamps{DeletedAt: &deletedAt}}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.RequestOrganizationRestore(ctx, o.Subdomain, gofakeit.Email())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should not send email when email is not of the owner", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30),
+			Timestamps: base.Timestamps{DeletedAt: &now}}
+		owner := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email(), IsOwner: true}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetDeletedOwnerByOrgID", ctx, o.ID).Return(owner, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.RequestOrganizationRestore(ctx, o.Subdomain, "other."+owner.Email)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when userService.GetDeletedOwnerByOrgID returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30),
+			Timestamps: base.Timestamps{DeletedAt: &now}}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetDeletedOwnerByOrgID", ctx, o.ID).Return(user.User{}, assert.AnError)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.RequestOrganizationRestore(ctx, o.Subdomain, gofakeit.Email())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should send the restore email to the owner", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now().UTC()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), Name: gofakeit.Company(),
+			Timestamps: base.Timestamps{DeletedAt: &now}}
+		owner := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email(), IsOwner: true}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetDeletedOwnerByOrgID", ctx, o.ID).Return(owner, nil)
+
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg, ok := args.Get(1).(mailer.Message)
+				require.True(t, ok)
+				assert.Equal(t, owner.Email, msg.To)
+
+				// the mailed token must be issued for the owner
+				link := msg.Body[strings.Index(msg.Body, "https://"):]
+				link = link[:strings.Index(link, "\n")]
+				parsed, err := url.Parse(link)
+				require.NoError(t, err)
+				assert.Equal(t, o.Subdomain, parsed.Query().Get("subdomain"))
+
+				claims, err := auth.ParseAndValidateVerificationToken(parsed.Query().Get("token"), conf.AppSecret,
+					auth.OrganizationRestorePurpose)
+				require.NoError(t, err)
+				assert.Equal(t, owner.ID, claims.UserID)
+				assert.Equal(t, o.ID, claims.OrgID)
+			}).
+			Return(nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, userService, nil, nil, nil, nil, mockMailer)
+		err := authService.RequestOrganizationRestore(ctx, o.Subdomain, owner.Email)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ConfirmOrganizationRestore(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{AppSecret: gofakeit.LetterN(32), OrgRestoreGracePeriod: 30}
+
+	// deletedOrgWithOwner returns a deleted organization along with its owner
+	// and a restore token issued for them after the deletion
+	deletedOrgWithOwner := func(t *testing.T, deletedAt time.Time) (organization.Organization, user.User, string) {
+		t.Helper()
+
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30),
+			Timestamps: base.Timestamps{DeletedAt: &deletedAt}}
+		owner := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email(), IsOwner: true}
+
+		token, err := auth.GenerateVerificationToken(time.Hour, conf.AppSecret, auth.OrganizationRestorePurpose,
+			owner.ID, o.ID, owner.Email)
+		require.NoError(t, err)
+
+		return o, owner, token
+	}
+
+	t.Run("should return error when token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		authService := auth.NewService(conf, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(context.Background(), gofakeit.LetterN(30), gofakeit.UUID())
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should return error when token is issued for another purpose", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := auth.GenerateVerificationToken(time.Hour, conf.AppSecret, auth.EmailVerificationPurpose,
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
+
+		authService := auth.NewService(conf, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		err = authService.ConfirmOrganizationRestore(context.Background(), gofakeit.LetterN(30), token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should return error when organization is no longer deleted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, _, token := deletedOrgWithOwner(t, time.Now().UTC())
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should return error when token is issued for another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, _, token := deletedOrgWithOwner(t, time.Now().UTC())
+		other := o
+		other.ID = o.ID + 1
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(other, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should return error when token is issued before the organization was deleted", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, _, token := deletedOrgWithOwner(t, time.Now().UTC())
+		deletedAt := time.Now().UTC().Add(time.Minute)
+		o.DeletedAt = &deletedAt
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should return error when restore window has ended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, _, token := deletedOrgWithOwner(t, time.Now().UTC().Add(-31*24*time.Hour))
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrRestoreWindowExpired)
+	})
+
+	t.Run("should return error when token is issued for another owner", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, owner, token := deletedOrgWithOwner(t, time.Now().UTC().Add(-time.Minute))
+		owner.Email = "other." + owner.Email
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetDeletedOwnerByOrgID", ctx, o.ID).Return(owner, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidRestoreToken)
+	})
+
+	t.Run("should restore the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o, owner, token := deletedOrgWithOwner(t, time.Now().UTC().Add(-time.Minute))
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetDeletedOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+		orgService.On("RestoreOrganization", ctx, o.ID, auth.OrgRestoredByOwnerComment).Return(nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetDeletedOwnerByOrgID", ctx, o.ID).Return(owner, nil)
+
+		authService := auth.NewService(conf, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		err := authService.ConfirmOrganizationRestore(ctx, o.Subdomain, token)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_RequestEmailChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the new email is the current email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), Email: gofakeit.Email()}
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, nil, userService, nil, nil, nil, nil, nil)
+		err := authService.RequestEmailChange(ctx, u.ID, u.OrganizationID, u.Email)
+
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return error when another user of the organization has the new email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), Email: gofakeit.Email()}
+		newEmail := gofakeit.Email()
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, u.OrganizationID, newEmail).
+			Return(user.User{ID: gofakeit.Int64()}, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, nil, userService, nil, nil, nil, nil, nil)
+		err := authService.RequestEmailChange(ctx, u.ID, u.OrganizationID, newEmail)
+
+		require.ErrorIs(t, err, auth.ErrEmailAlreadyExists)
+	})
+
+	t.Run("should store the hash of the token and mail the link to the new email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel"}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		newEmail := gofakeit.Email()
+
+		var (
+			tokenHash string
+			msg       mailer.Message
+		)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, newEmail).Return(user.User{}, base.NewNotFoundError("not found"))
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		invalidateCall := repo.On("InvalidateEmailChangeTokens", ctx, u.ID).Return(nil)
+		repo.On("CreateEmailChangeToken", ctx, u.ID, newEmail, mock.AnythingOfType("string"), auth.EmailChangeTokenTTL).
+			Run(func(args mock.Arguments) {
+				tokenHash = args.String(3)
+			}).
+			Return(nil).NotBefore(invalidateCall)
+
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg = args.Get(1).(mailer.Message) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(nil)
+
+		authService := auth.NewService(config.Config{AppURL: "https://camelhr.com"}, nil, repo, transactor, orgService,
+			userService, nil, nil, nil, nil, mockMailer)
+		err := authService.RequestEmailChange(ctx, u.ID, o.ID, newEmail)
+
+		require.NoError(t, err)
+		assert.Equal(t, newEmail, msg.To)
+		assert.Contains(t, msg.Body, "https://camelhr.com/confirm-email-change?subdomain=camel&token=")
+
+		token := msg.Body[strings.Index(msg.Body, "token=")+len("token="):]
+		token = strings.Fields(token)[0]
+		unescaped, err := url.QueryUnescape(token)
+		require.NoError(t, err)
+		assert.Equal(t, base.HashToken(unescaped), tokenHash)
+	})
+}
+
+func TestService_ConfirmEmailChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when token is not usable", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseEmailChangeToken", ctx, base.HashToken(token)).Return(auth.EmailChange{}, sql.ErrNoRows)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, nil, nil, nil, nil, nil, nil)
+		err := authService.ConfirmEmailChange(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("should return error when token belongs to a different organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID + 1}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseEmailChangeToken", ctx, base.HashToken(token)).
+			Return(auth.EmailChange{UserID: u.ID, NewEmail: gofakeit.Email()}, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		err := authService.ConfirmEmailChange(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("should return error when the new email was taken after the request", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		change := auth.EmailChange{UserID: u.ID, NewEmail: gofakeit.Email()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseEmailChangeToken", ctx, base.HashToken(token)).Return(change, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, change.NewEmail).Return(user.User{ID: gofakeit.Int64()}, nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		err := authService.ConfirmEmailChange(ctx, o.Subdomain, token)
+
+		require.ErrorIs(t, err, auth.ErrEmailAlreadyExists)
+	})
+
+	t.Run("should change the email and mail a notice to the previous email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		token := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		change := auth.EmailChange{UserID: u.ID, NewEmail: gofakeit.Email()}
+
+		var msg mailer.Message
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseEmailChangeToken", ctx, base.HashToken(token)).Return(change, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, change.NewEmail).
+			Return(user.User{}, base.NewNotFoundError("not found"))
+		userService.On("ChangeEmail", ctx, u.ID, change.NewEmail).Return(nil)
+
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg = args.Get(1).(mailer.Message) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			mockMailer)
+		err := authService.ConfirmEmailChange(ctx, o.Subdomain, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, u.Email, msg.To)
+		assert.Contains(t, msg.Body, change.NewEmail)
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return locked error without verifying the password when login is locked", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		device := session.Device{IP: gofakeit.IPv4Address()}
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, device.IP).
+			Return(&lockout.LockedError{RetryAfter: time.Minute})
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, device)
+
+		var lockedErr *lockout.LockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.Equal(t, time.Minute, lockedErr.RetryAfter)
+	})
+
+	t.Run("should return error when registering the failed attempt fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, base.NewNotFoundError("not found"))
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, "").Return(nil)
+		lockoutManager.On("RegisterFailedAttempt", ctx, subdomain, email, "").Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return error when orgService.GetOrganizationBySubdomain returns error",
+		func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			subdomain := gofakeit.LetterN(30)
+
+			orgService := organization.NewMockService(t)
+			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(organization.Organization{}, assert.AnError)
+
+			lockoutManager := lockout.NewMockLockoutManager(t)
+			lockoutManager.On("CheckLockout", ctx, subdomain, mock.Anything, fake.MockString).Return(nil)
+
+			authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil,
+				orgService, nil, nil, nil, nil, lockoutManager, nil)
+			_, err := authService.Login(ctx, subdomain, gofakeit.Email(), "@paSSw0rd", false, session.Device{})
+
+			require.Error(t, err)
+			require.ErrorIs(t, assert.AnError, err)
+		})
+
+	t.Run("should return error when the organization is suspended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		suspendedAt := time.Now().UTC()
+		o := organization.Organization{ID: gofakeit.Int64(), SuspendedAt: &suspendedAt}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, "").Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil,
+			orgService, nil, nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrOrgSuspended)
+	})
+
+	t.Run("should return error when userService.GetUserByOrgIDEmail returns error",
+		func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			subdomain := gofakeit.LetterN(30)
+			o := organization.Organization{ID: gofakeit.Int64()}
+			email := gofakeit.Email()
+
+			orgService := organization.NewMockService(t)
+			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+			userService := user.NewMockService(t)
+			userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, assert.AnError)
+
+			lockoutManager := lockout.NewMockLockoutManager(t)
+			lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+			authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil,
+				orgService, userService, nil, nil, nil, lockoutManager, nil)
+			_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+			require.Error(t, err)
+			require.ErrorIs(t, assert.AnError, err)
+		})
+
+	t.Run("should return error when userService.GetUserByOrgIDEmail returns not found error",
+		func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			subdomain := gofakeit.LetterN(30)
+			o := organization.Organization{ID: gofakeit.Int64()}
+			email := gofakeit.Email()
+
+			orgService := organization.NewMockService(t)
+			orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+			userService := user.NewMockService(t)
+			userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(user.User{}, base.NewNotFoundError("not found"))
+
+			lockoutManager := lockout.NewMockLockoutManager(t)
+			lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+			lockoutManager.On("RegisterFailedAttempt", ctx, subdomain, email, fake.MockString).Return(nil)
+
+			authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil,
+				orgService, userService, nil, nil, nil, lockoutManager, nil)
+			_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+			require.Error(t, err)
+			require.ErrorIs(t, auth.ErrInvalidCredentials, err)
+		})
+
+	t.Run("should return error when user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		now := time.Now()
+		u := user.User{
+			ID:         gofakeit.Int64(),
+			DisabledAt: &now,
+		}
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, auth.ErrUserDisabled, err)
+	})
+
+	t.Run("should return error when password is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64()}
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword+"ZZZ").Return(false, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+		lockoutManager.On("RegisterFailedAttempt", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword+"ZZZ", false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, auth.ErrInvalidCredentials, err)
+	})
+
+	t.Run("should return error when userService.VerifyPassword returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: gofakeit.UUID()}
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(false, user.ErrUnsupportedPasswordHash)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.ErrorIs(t, err, user.ErrUnsupportedPasswordHash)
+	})
+
+	t.Run("should return error when sessionManager.CreateSession returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.DefaultSessionTTL).Return(assert.AnError)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+		lockoutManager.On("Unlock", ctx, subdomain, email).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		_, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the jwt token and default ttl", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			device, auth.DefaultSessionTTL).Return(nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+		lockoutManager.On("Unlock", ctx, subdomain, email).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, false, device)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, result.JWT)
+		require.NotEmpty(t, result.RefreshToken)
+		assert.Empty(t, result.MFAToken)
+		assert.Equal(t, auth.DefaultSessionTTL, result.TTL)
+	})
+
+	t.Run("should return the jwt token and remember-me ttl", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.RememberMeSessionTTL).Return(nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+		lockoutManager.On("Unlock", ctx, subdomain, email).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, true, session.Device{})
+
+		require.NoError(t, err)
+		require.NotEmpty(t, result.JWT)
+		require.NotEmpty(t, result.RefreshToken)
+		assert.Equal(t, auth.RememberMeSessionTTL, result.TTL)
+	})
+	t.Run("should return the mfa token when mfa is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(true, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, nil, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.JWT)
+		assert.False(t, result.MFAEnrollmentRequired)
+
+		claims, err := auth.ParseAndValidateVerificationToken(result.MFAToken, "jwt_secret", auth.MFAChallengePurpose)
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, claims.UserID)
+		assert.Equal(t, o.ID, claims.OrgID)
+	})
+
+	t.Run("should require mfa enrollment when organization requires mfa", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64(), MFARequired: true}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, nil, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.JWT)
+		assert.NotEmpty(t, result.MFAToken)
+		assert.True(t, result.MFAEnrollmentRequired)
+	})
+
+	t.Run("should return the password change token when the password has expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(true, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+		lockoutManager.On("Unlock", ctx, subdomain, email).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, nil, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.JWT)
+		assert.Empty(t, result.MFAToken)
+
+		claims, err := auth.ParseAndValidateVerificationToken(result.PasswordChangeToken, "jwt_secret",
+			auth.PasswordChangePurpose)
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, claims.UserID)
+		assert.Equal(t, o.ID, claims.OrgID)
+	})
+
+	t.Run("should return the mfa token instead of the password change token when mfa is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+
+		u := user.User{ID: gofakeit.Int64(), Email: email}
+		o := organization.Organization{ID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).Return(o, nil)
+
+		// the password expiry is not checked until the mfa step is completed
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, email).Return(u, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(true, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, subdomain, email, fake.MockString).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "jwt_secret"}, auth.NewHMACKeySet("jwt_secret"), nil, nil,
+			orgService, userService, mfaService, nil, nil, lockoutManager, nil)
+		result, err := authService.Login(ctx, subdomain, email, validPassword, false, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.JWT)
+		assert.Empty(t, result.PasswordChangeToken)
+		assert.NotEmpty(t, result.MFAToken)
+	})
+}
+
+func TestService_ChangeExpiredPassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when token is issued for a different purpose", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		_, err = authService.ChangeExpiredPassword(context.Background(), gofakeit.LetterN(30), token, validPassword,
+			false, session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrInvalidPasswordChange)
+	})
+
+	t.Run("should return error when the password has already been changed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		token, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.PasswordChangePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, nil, nil)
+		_, err = authService.ChangeExpiredPassword(ctx, o.Subdomain, token, validPassword, false, session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrInvalidPasswordChange)
+	})
+
+	t.Run("should return error when the new password is the expired password", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		token, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.PasswordChangePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(true, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(true, nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, nil, nil, nil)
+		_, err = authService.ChangeExpiredPassword(ctx, o.Subdomain, token, validPassword, false, session.Device{})
+
+		require.ErrorIs(t, err, user.ErrPasswordReused)
+	})
+
+	t.Run("should change the password and create the session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		token, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.PasswordChangePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(true, nil)
+		userService.On("VerifyPassword", ctx, u, validPassword).Return(false, nil)
+		userService.On("ResetPassword", ctx, u.ID, validPassword).Return(nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.DefaultSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, sessionManager, nil, nil)
+		result, err := authService.ChangeExpiredPassword(ctx, o.Subdomain, token, validPassword, false, session.Device{})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.JWT)
+		assert.Equal(t, auth.DefaultSessionTTL, result.TTL)
+	})
+}
+
+func TestService_RequestMagicLink(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when magic link login is disabled for the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		_, err := authService.RequestMagicLink(ctx, o.Subdomain, gofakeit.Email(), false)
+
+		require.ErrorIs(t, err, auth.ErrMagicLinkDisabled)
+	})
+
+	t.Run("should return the browser binding without mailing when the user is not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+		email := gofakeit.Email()
+		done := make(chan struct{})
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		// the user is looked up in the background after the response
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", fake.MockContext, o.ID, email).
+			Run(func(mock.Arguments) { close(done) }).
+			Return(user.User{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		browserBinding, err := authService.RequestMagicLink(ctx, o.Subdomain, email, false)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, browserBinding)
+		waitFor(t, done)
+	})
+
+	t.Run("should return the browser binding when storing the token fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		done := make(chan struct{})
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", fake.MockContext, o.ID, u.Email).Return(u, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", fake.MockContext, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("InvalidateMagicLinkTokens", fake.MockContext, u.ID).Return(nil)
+		repo.On("CreateMagicLinkToken", fake.MockContext, auth.MagicLink{UserID: u.ID},
+			fake.MockString, fake.MockString, auth.MagicLinkTokenTTL).
+			Run(func(mock.Arguments) { close(done) }).
+			Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{}, nil, repo, transactor, orgService, userService, nil, nil, nil, nil,
+			nil)
+		browserBinding, err := authService.RequestMagicLink(ctx, o.Subdomain, u.Email, false)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, browserBinding)
+		waitFor(t, done)
+	})
+
+	t.Run("should store the hashes of the token and the browser binding and mail the link", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", MagicLinkEnabled: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+
+		var (
+			tokenHash   string
+			bindingHash string
+			msg         mailer.Message
+		)
+
+		done := make(chan struct{})
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		// the link is created and mailed in the background after the response
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", fake.MockContext, o.ID, u.Email).Return(u, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", fake.MockContext, mock.Anything).Return(tests.RunTx)
+
+		repo := auth.NewMockRepository(t)
+		invalidateCall := repo.On("InvalidateMagicLinkTokens", fake.MockContext, u.ID).Return(nil)
+		repo.On("CreateMagicLinkToken", fake.MockContext, auth.MagicLink{UserID: u.ID, RememberMe: true},
+			mock.AnythingOfType("string"), mock.AnythingOfType("string"), auth.MagicLinkTokenTTL).
+			Run(func(args mock.Arguments) {
+				tokenHash = args.String(2)
+				bindingHash = args.String(3)
+			}).
+			Return(nil).NotBefore(invalidateCall)
+
+		mockMailer := mailer.NewMockMailer(t)
+		mockMailer.On("Send", fake.MockContext, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg = args.Get(1).(mailer.Message) //nolint:forcetypeassert // type is asserted by the matcher
+				close(done)
+			}).
+			Return(nil)
+
+		authService := auth.NewService(config.Config{AppURL: "https://camelhr.com"}, nil, repo, transactor, orgService,
+			userService, nil, nil, nil, nil, mockMailer)
+		browserBinding, err := authService.RequestMagicLink(ctx, o.Subdomain, u.Email, true)
+
+		require.NoError(t, err)
+		waitFor(t, done)
+		assert.Equal(t, base.HashToken(browserBinding), bindingHash)
+		assert.Equal(t, u.Email, msg.To)
+		assert.Contains(t, msg.Body, "https://camelhr.com/magic-link?subdomain=camel&token=")
+
+		token := msg.Body[strings.Index(msg.Body, "token=")+len("token="):]
+		token = strings.Fields(token)[0]
+		unescaped, err := url.QueryUnescape(token)
+		require.NoError(t, err)
+		assert.Equal(t, base.HashToken(unescaped), tokenHash)
+	})
+}
+
+func TestService_MagicLinkLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the browser binding is missing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		_, err := authService.MagicLinkLogin(ctx, o.Subdomain, "token", "", session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrInvalidMagicLink)
+	})
+
+	t.Run("should return error when the token is not usable from the browser", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseMagicLinkToken", ctx, base.HashToken("token"), base.HashToken("binding")).
+			Return(auth.MagicLink{}, sql.ErrNoRows)
+
+		authService := auth.NewService(config.Config{}, nil, repo, nil, orgService, nil, nil, nil, nil, nil, nil)
+		_, err := authService.MagicLinkLogin(ctx, o.Subdomain, "token", "binding", session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrInvalidMagicLink)
+	})
+
+	t.Run("should return error when the user belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseMagicLinkToken", ctx, base.HashToken("token"), base.HashToken("binding")).
+			Return(auth.MagicLink{UserID: u.ID}, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, repo, nil, orgService, userService, nil, nil, nil, nil, nil)
+		_, err := authService.MagicLinkLogin(ctx, o.Subdomain, "token", "binding", session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrInvalidMagicLink)
+	})
+
+	t.Run("should create the session with the remember-me ttl of the link", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MagicLinkEnabled: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		repo := auth.NewMockRepository(t)
+		repo.On("UseMagicLinkToken", ctx, base.HashToken("token"), base.HashToken("binding")).
+			Return(auth.MagicLink{UserID: u.ID, RememberMe: true}, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			device, auth.RememberMeSessionTTL).Return(nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("Unlock", ctx, o.Subdomain, u.Email).Return(nil)
+
+		authService := auth.NewService(config.Config{}, auth.NewHMACKeySet("jwt_secret"), repo, nil, orgService,
+			userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		result, err := authService.MagicLinkLogin(ctx, o.Subdomain, "token", "binding", device)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, result.JWT)
+		assert.Empty(t, result.MFAToken)
+		assert.Equal(t, auth.RememberMeSessionTTL, result.TTL)
+	})
+}
+
+func TestService_SetupMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when mfa token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		_, err := authService.SetupMFA(context.Background(), gofakeit.LetterN(30), "invalid-token")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidMFAToken)
+	})
+
+	t.Run("should enroll the user of the mfa token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MFARequired: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		enrollment := mfa.Enrollment{Secret: gofakeit.LetterN(32), URI: gofakeit.URL()}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("Enroll", ctx, u.ID).Return(enrollment, nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfaService, nil, nil, nil, nil)
+		result, err := authService.SetupMFA(ctx, o.Subdomain, mfaToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, enrollment, result)
+	})
+}
+
+func TestService_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when mfa token is issued for a different purpose", func(t *testing.T) {
+		t.Parallel()
+
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.EmailVerificationPurpose,
+			gofakeit.Int64(), gofakeit.Int64(), gofakeit.Email())
+		require.NoError(t, err)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, nil, nil,
+			nil, nil, nil, nil, nil)
+		_, err = authService.VerifyMFA(context.Background(), gofakeit.LetterN(30), mfaToken, "123456", false,
+			session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidMFAToken)
+	})
+
+	t.Run("should return error when mfa token belongs to a different organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			gofakeit.Int64(), o.ID+1, gofakeit.Email())
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			nil, nil, nil, nil, nil, nil)
+		_, err = authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidMFAToken)
+	})
+
+	t.Run("should return error when mfa code is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(true, nil)
+		mfaService.On("Verify", ctx, u.ID, "123456").Return(mfa.ErrInvalidCode)
+
+		// the invalid code is counted as a failed login attempt
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, o.Subdomain, u.Email, "").Return(nil)
+		lockoutManager.On("RegisterFailedAttempt", ctx, o.Subdomain, u.Email, "").Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfaService, nil, nil, lockoutManager, nil)
+		_, err = authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", false, session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("should reject the code without verifying it when the login is locked", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		// the mfa service is not called once the invalid codes have locked the login
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, o.Subdomain, u.Email, "").
+			Return(&lockout.LockedError{RetryAfter: time.Minute})
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfa.NewMockService(t), nil, nil, lockoutManager, nil)
+		_, err = authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", false, session.Device{})
+
+		var lockedErr *lockout.LockedError
+		require.ErrorAs(t, err, &lockedErr)
+	})
+
+	t.Run("should create the session when mfa code is valid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(true, nil)
+		mfaService.On("Verify", ctx, u.ID, "123456").Return(nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, o.Subdomain, u.Email, "").Return(nil)
+		lockoutManager.On("Unlock", ctx, o.Subdomain, u.Email).Return(nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.RememberMeSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		result, err := authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", true, session.Device{})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.JWT)
+		assert.Equal(t, auth.RememberMeSessionTTL, result.TTL)
+		assert.Empty(t, result.RecoveryCodes)
+	})
+
+	t.Run("should activate the pending enrollment and return the recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), MFARequired: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		recoveryCodes := []string{"abcde-fghij", "klmno-pqrst"}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(false, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(false, nil)
+		mfaService.On("Activate", ctx, u.ID, "123456").Return(recoveryCodes, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, o.Subdomain, u.Email, "").Return(nil)
+		lockoutManager.On("Unlock", ctx, o.Subdomain, u.Email).Return(nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.DefaultSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfaService, nil, sessionManager, lockoutManager, nil)
+		result, err := authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", false, session.Device{})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.JWT)
+		assert.Equal(t, recoveryCodes, result.RecoveryCodes)
+	})
+
+	t.Run("should return the password change token when the password has expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+		mfaToken, err := auth.GenerateVerificationToken(time.Minute, "secret", auth.MFAChallengePurpose,
+			u.ID, o.ID, u.Email)
+		require.NoError(t, err)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		userService.On("IsPasswordExpired", ctx, u).Return(true, nil)
+
+		mfaService := mfa.NewMockService(t)
+		mfaService.On("IsEnabled", ctx, u.ID).Return(true, nil)
+		mfaService.On("Verify", ctx, u.ID, "123456").Return(nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("CheckLockout", ctx, o.Subdomain, u.Email, "").Return(nil)
+		lockoutManager.On("Unlock", ctx, o.Subdomain, u.Email).Return(nil)
+
+		// the session is not created until the expired password is changed
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, mfaService, nil, nil, lockoutManager, nil)
+		result, err := authService.VerifyMFA(ctx, o.Subdomain, mfaToken, "123456", false, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.JWT)
+
+		claims, err := auth.ParseAndValidateVerificationToken(result.PasswordChangeToken, "secret",
+			auth.PasswordChangePurpose)
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, claims.UserID)
+		assert.Equal(t, o.ID, claims.OrgID)
+	})
+}
+
+func TestService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when refresh token is reused", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).
+			Return(session.Session{}, session.ErrRefreshTokenReused)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			nil, nil, nil, sessionManager, nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return error when refresh token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).
+			Return(session.Session{}, session.ErrInvalidSession)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			nil, nil, nil, sessionManager, nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return error when session belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		// the session manager leaves the token of another organization unused
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).
+			Return(session.Session{}, session.ErrInvalidSession)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			nil, nil, nil, sessionManager, nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return error when the organization is suspended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		now := time.Now()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30), SuspendedAt: &now}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		// the refresh token is not used
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			nil, nil, nil, session.NewMockSessionManager(t), nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.ErrorIs(t, err, auth.ErrOrgSuspended)
+	})
+
+	t.Run("should return error when user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		now := time.Now()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, DisabledAt: &now}
+		sess := session.Session{ID: gofakeit.UUID(), UserID: u.ID, OrgID: o.ID, TTL: auth.DefaultSessionTTL}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).Return(sess, nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, sessionManager, nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrUserDisabled)
+	})
+
+	t.Run("should return error when session is revoked during renewal", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID}
+		sess := session.Session{ID: gofakeit.UUID(), UserID: u.ID, OrgID: o.ID, TTL: auth.DefaultSessionTTL}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).Return(sess, nil)
+		sessionManager.On("RenewSession", ctx, u.ID, o.ID, sess.ID, fake.MockString, fake.MockString,
+			auth.DefaultSessionTTL).Return(session.ErrInvalidSession)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, sessionManager, nil, nil)
+		_, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should issue a new jwt and rotate the refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		refreshToken := gofakeit.UUID()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID}
+		sess := session.Session{ID: gofakeit.UUID(), UserID: u.ID, OrgID: o.ID, TTL: auth.RememberMeSessionTTL}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("ConsumeRefreshToken", ctx, o.ID, refreshToken).Return(sess, nil)
+		sessionManager.On("RenewSession", ctx, u.ID, o.ID, sess.ID, fake.MockString, fake.MockString,
+			auth.RememberMeSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: "secret"}, auth.NewHMACKeySet("secret"), nil, nil, orgService,
+			userService, nil, nil, sessionManager, nil, nil)
+		result, err := authService.Refresh(ctx, o.Subdomain, refreshToken)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, refreshToken, result.RefreshToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.Equal(t, auth.RememberMeSessionTTL, result.TTL)
+
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, auth.NewHMACKeySet("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, sess.ID, claims.SessionID)
+		assert.Equal(t, u.ID, claims.UserID)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when sessionManager.RevokeSession returns error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("RevokeSession", ctx, userID, orgID, sessionID).Return(assert.AnError)
+
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, nil, nil, nil, nil, sessionManager, nil,
+			nil)
+		err := authService.Logout(ctx, userID, orgID, sessionID)
+
+		require.Error(t, err)
+		require.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should not revoke any session when session id is empty", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		sessionManager := session.NewMockSessionManager(t)
+
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, nil, nil, nil, nil, sessionManager, nil,
+			nil)
+		err := authService.Logout(ctx, gofakeit.Int64(), gofakeit.Int64(), "")
+
+		require.NoError(t, err)
+		sessionManager.AssertNotCalled(t, "RevokeSession")
+	})
+
+	t.Run("should logout successfully", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("RevokeSession", ctx, userID, orgID, sessionID).Return(nil)
+
+		authService := auth.NewService(config.Config{AppSecret: ""}, nil, nil, nil, nil, nil, nil, nil, sessionManager, nil,
+			nil)
+		err := authService.Logout(ctx, userID, orgID, sessionID)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_UnlockUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the user belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, nil, userService, nil, nil, nil, nil, nil)
+		err := authService.UnlockUser(ctx, orgID, u.ID)
+
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should unlock the login of the user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: gofakeit.Email()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		lockoutManager := lockout.NewMockLockoutManager(t)
+		lockoutManager.On("Unlock", ctx, o.Subdomain, u.Email).Return(nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, lockoutManager,
+			nil)
+		err := authService.UnlockUser(ctx, o.ID, u.ID)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_Impersonate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the organization is suspended", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), SuspendedAt: new(time.Time)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		_, err := authService.Impersonate(ctx, gofakeit.Email(), o.ID, gofakeit.Int64(), session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrOrgSuspended)
+	})
+
+	t.Run("should return not found error when the user belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64()}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		_, err := authService.Impersonate(ctx, gofakeit.Email(), o.ID, u.ID, session.Device{})
+
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64()}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, DisabledAt: new(time.Time)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, nil, nil, nil, nil)
+		_, err := authService.Impersonate(ctx, gofakeit.Email(), o.ID, u.ID, session.Device{})
+
+		require.ErrorIs(t, err, auth.ErrUserDisabled)
+	})
+
+	t.Run("should return a jwt carrying the operator without a refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		operator := gofakeit.Email()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID}
+		keys := auth.NewHMACKeySet("jwt_secret")
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, o.ID).Return(o, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.ImpersonationSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{}, keys, nil, nil, orgService, userService, nil, nil,
+			sessionManager, nil, nil)
+		result, err := authService.Impersonate(ctx, operator, o.ID, u.ID, session.Device{})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.RefreshToken)
+		assert.Equal(t, auth.ImpersonationSessionTTL, result.TTL)
+
+		_, claims, err := auth.ParseAndValidateJWT(result.JWT, keys)
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, claims.UserID)
+		assert.Equal(t, o.ID, claims.OrgID)
+		require.True(t, claims.IsImpersonated())
+		assert.Equal(t, operator, claims.Actor.Operator)
+	})
+}
+
+func TestService_SSOAuthorize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, nil, nil, nil, nil)
+		_, _, err := authService.SSOAuthorize(ctx, subdomain, false)
+
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return the authorization url and the browser binding of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		authURL := gofakeit.URL()
+		browserBinding := gofakeit.UUID()
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("AuthorizationURL", ctx, o, true).Return(authURL, browserBinding, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, ssoService, nil, nil, nil)
+		result, binding, err := authService.SSOAuthorize(ctx, o.Subdomain, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, authURL, result)
+		assert.Equal(t, browserBinding, binding)
+	})
+}
+
+func TestService_SSOLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when authentication at the identity provider fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("Authenticate", ctx, o, "code", "state", "binding").Return(sso.Identity{}, sso.ErrInvalidState)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, nil, nil, ssoService, nil, nil, nil)
+		_, err := authService.SSOLogin(ctx, o.Subdomain, "code", "state", "binding", session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	t.Run("should return error when user is not found and jit provisioning is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		identity := sso.Identity{Subject: gofakeit.UUID(), Email: gofakeit.Email()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("Authenticate", ctx, o, "code", "state", "binding").Return(identity, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, identity.Email).
+			Return(user.User{}, base.NewNotFoundError("not found"))
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, ssoService, nil, nil,
+			nil)
+		_, err := authService.SSOLogin(ctx, o.Subdomain, "code", "state", "binding", session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrSSOUserNotFound)
+	})
+
+	t.Run("should return error when user is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		identity := sso.Identity{Subject: gofakeit.UUID(), Email: gofakeit.Email()}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: identity.Email, DisabledAt: &now}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("Authenticate", ctx, o, "code", "state", "binding").Return(identity, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, identity.Email).Return(u, nil)
+
+		authService := auth.NewService(config.Config{}, nil, nil, nil, orgService, userService, nil, ssoService, nil, nil,
+			nil)
+		_, err := authService.SSOLogin(ctx, o.Subdomain, "code", "state", "binding", session.Device{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, auth.ErrUserDisabled)
+	})
+
+	t.Run("should provision the user when jit provisioning is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		identity := sso.Identity{Subject: gofakeit.UUID(), Email: gofakeit.Email(), JITProvisioning: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: identity.Email}
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("Authenticate", ctx, o, "code", "state", "binding").Return(identity, nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			// run the transaction function with the same context
+			fn, ok := args.Get(1).(func(context.Context) error)
+			require.True(t, ok)
+			require.NoError(t, fn(ctx))
+		}).Return(nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, identity.Email).
+			Return(user.User{}, base.NewNotFoundError("not found"))
+		userService.On("CreateExternalUser", ctx, o.ID, identity.Email).Return(u, nil)
+		userService.On("SetEmailVerified", ctx, u.ID).Return(nil)
+
+		sessionManager := session.NewMockSessionManager(t)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			device, auth.DefaultSessionTTL).Return(nil)
+
+		authService := auth.NewService(config.Config{}, auth.NewHMACKeySet("jwt_secret"), nil, transactor, orgService,
+			userService, nil, ssoService, sessionManager, nil, nil)
+		result, err := authService.SSOLogin(ctx, o.Subdomain, "code", "state", "binding", device)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, result.JWT)
+		require.NotEmpty(t, result.RefreshToken)
+		assert.Equal(t, auth.DefaultSessionTTL, result.TTL)
+	})
+
+	t.Run("should create the session with the remember-me ttl", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		o := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		identity := sso.Identity{Subject: gofakeit.UUID(), Email: gofakeit.Email(), RememberMe: true}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: o.ID, Email: identity.Email}
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationBySubdomain", ctx, o.Subdomain).Return(o, nil)
+
+		ssoService := sso.NewMockService(t)
+		ssoService.On("Authenticate", ctx, o, "code", "state", "binding").Return(identity, nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByOrgIDEmail", ctx, o.ID, identity.Email).Return(u, nil)
 
 		sessionManager := session.NewMockSessionManager(t)
-		sessionManager.On("DeleteSession", ctx, userID, orgID).Return(nil)
+		sessionManager.On("CreateSession", ctx, u.ID, o.ID, fake.MockString, fake.MockString, fake.MockString,
+			session.Device{}, auth.RememberMeSessionTTL).Return(nil)
 
-		authService := auth.NewService("", nil, nil, nil, sessionManager)
-		err := authService.Logout(ctx, userID, orgID)
+		authService := auth.NewService(config.Config{}, auth.NewHMACKeySet("jwt_secret"), nil, nil, orgService,
+			userService, nil, ssoService, sessionManager, nil, nil)
+		result, err := authService.SSOLogin(ctx, o.Subdomain, "code", "state", "binding", session.Device{})
 
 		require.NoError(t, err)
+		require.NotEmpty(t, result.JWT)
+		assert.Equal(t, auth.RememberMeSessionTTL, result.TTL)
 	})
 }
@@ -0,0 +1,30 @@
+package auth
+
+import _ "embed"
+
+//go:embed sql/create_password_reset_token.sql
+var createPasswordResetTokenQuery string
+
+//go:embed sql/use_password_reset_token.sql
+var usePasswordResetTokenQuery string
+
+//go:embed sql/invalidate_password_reset_tokens.sql
+var invalidatePasswordResetTokensQuery string
+
+//go:embed sql/create_email_change_token.sql
+var createEmailChangeTokenQuery string
+
+//go:embed sql/use_email_change_token.sql
+var useEmailChangeTokenQuery string
+
+//go:embed sql/invalidate_email_change_tokens.sql
+var invalidateEmailChangeTokensQuery string
+
+//go:embed sql/create_magic_link_token.sql
+var createMagicLinkTokenQuery string
+
+//go:embed sql/use_magic_link_token.sql
+var useMagicLinkTokenQuery string
+
+//go:embed sql/invalidate_magic_link_tokens.sql
+var invalidateMagicLinkTokensQuery string
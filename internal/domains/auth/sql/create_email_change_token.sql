@@ -0,0 +1,9 @@
+-- createEmailChangeTokenQuery
+-- $1: user_id
+-- $2: new_email
+-- $3: token_hash
+-- $4: ttl in seconds
+INSERT INTO
+    email_change_tokens(user_id, new_email, token_hash, expires_at)
+VALUES
+    ($1, $2, $3, NOW() + make_interval(secs => $4));
@@ -0,0 +1,10 @@
+-- createMagicLinkTokenQuery
+-- $1: user_id
+-- $2: token_hash
+-- $3: browser_hash
+-- $4: remember_me
+-- $5: ttl in seconds
+INSERT INTO
+    magic_link_tokens(user_id, token_hash, browser_hash, remember_me, expires_at)
+VALUES
+    ($1, $2, $3, $4, NOW() + make_interval(secs => $5));
@@ -0,0 +1,8 @@
+-- createPasswordResetTokenQuery
+-- $1: user_id
+-- $2: token_hash
+-- $3: ttl in seconds
+INSERT INTO
+    password_reset_tokens(user_id, token_hash, expires_at)
+VALUES
+    ($1, $2, NOW() + make_interval(secs => $3));
@@ -0,0 +1,9 @@
+-- invalidateEmailChangeTokensQuery
+-- $1: user_id
+UPDATE
+    email_change_tokens
+SET
+    used_at = NOW()
+WHERE
+    user_id = $1
+    AND used_at IS NULL;
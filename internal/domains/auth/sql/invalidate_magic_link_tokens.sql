@@ -0,0 +1,9 @@
+-- invalidateMagicLinkTokensQuery
+-- $1: user_id
+UPDATE
+    magic_link_tokens
+SET
+    used_at = NOW()
+WHERE
+    user_id = $1
+    AND used_at IS NULL;
@@ -0,0 +1,9 @@
+-- invalidatePasswordResetTokensQuery
+-- $1: user_id
+UPDATE
+    password_reset_tokens
+SET
+    used_at = NOW()
+WHERE
+    user_id = $1
+    AND used_at IS NULL;
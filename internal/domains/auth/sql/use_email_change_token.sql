@@ -0,0 +1,12 @@
+-- useEmailChangeTokenQuery
+-- $1: token_hash
+UPDATE
+    email_change_tokens
+SET
+    used_at = NOW()
+WHERE
+    token_hash = $1
+    AND used_at IS NULL
+    AND expires_at > NOW() RETURNING
+    user_id,
+    new_email;
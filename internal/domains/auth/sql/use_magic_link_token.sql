@@ -0,0 +1,14 @@
+-- useMagicLinkTokenQuery
+-- $1: token_hash
+-- $2: browser_hash
+UPDATE
+    magic_link_tokens
+SET
+    used_at = NOW()
+WHERE
+    token_hash = $1
+    AND browser_hash = $2
+    AND used_at IS NULL
+    AND expires_at > NOW() RETURNING
+    user_id,
+    remember_me;
@@ -0,0 +1,11 @@
+-- usePasswordResetTokenQuery
+-- $1: token_hash
+UPDATE
+    password_reset_tokens
+SET
+    used_at = NOW()
+WHERE
+    token_hash = $1
+    AND used_at IS NULL
+    AND expires_at > NOW() RETURNING
+    user_id;
@@ -7,6 +7,18 @@ const (
 	APITokenBasicAuthPassword = "api_token"
 	// JWTCookieName is the name of the cookie that stores the jwt token.
 	JWTCookieName = "jwt_session_id"
+	// RefreshTokenCookieName is the name of the cookie that stores the refresh token.
+	RefreshTokenCookieName = "refresh_token"
+	// MagicLinkCookieName is the name of the cookie that binds a magic link to the browser that requested it.
+	MagicLinkCookieName = "magic_link_binding"
+	// SSOCookieName is the name of the cookie that binds a sso login to the browser that started it.
+	SSOCookieName = "sso_binding"
+	// ImpersonatedByHeader is the response header carrying the operator impersonating the user of the session.
+	ImpersonatedByHeader = "X-Impersonated-By"
+
+	// AccessTokenTTL is the time duration for which the jwt token is valid.
+	// The jwt token is renewed using the refresh token within the session ttl.
+	AccessTokenTTL = 15 * time.Minute
 
 	// DefaultSessionTTL is the time duration to keep the session alive.
 	DefaultSessionTTL = 24 * time.Hour
@@ -17,17 +29,205 @@ const (
 	// NewOrgDeleteComment is the comment message to identify newly registered organizations
 	// that are pending verification.
 	NewOrgDeleteComment = "deletion_reason: new_unverified_organization"
+
+	// NewOrgVerifiedComment is the comment message set on the organization once its owner email is verified.
+	NewOrgVerifiedComment = "restore_reason: email_verified"
+
+	// OrgRestoredByOwnerComment is the comment message set on the organization once its owner restores it.
+	OrgRestoredByOwnerComment = "restore_reason: restored_by_owner"
+
+	// EmailVerificationTokenTTL is the time duration for which the email verification token is valid.
+	EmailVerificationTokenTTL = 48 * time.Hour
+
+	// EmailVerificationPurpose is the purpose claim of the email verification token.
+	EmailVerificationPurpose = "email_verification"
+
+	// PasswordResetTokenTTL is the time duration for which the password reset token is valid.
+	PasswordResetTokenTTL = time.Hour
+
+	// EmailChangeTokenTTL is the time duration for which the email change token is valid.
+	EmailChangeTokenTTL = 24 * time.Hour
+
+	// MagicLinkTokenTTL is the time duration for which the magic link is valid.
+	MagicLinkTokenTTL = 15 * time.Minute
+
+	// MFAChallengeTokenTTL is the time duration within which the mfa step of the login must be completed.
+	MFAChallengeTokenTTL = 5 * time.Minute
+
+	// MFAChallengePurpose is the purpose claim of the mfa challenge token.
+	MFAChallengePurpose = "mfa_challenge"
+
+	// PasswordChangeTokenTTL is the time duration within which the expired password must be changed during login.
+	PasswordChangeTokenTTL = 10 * time.Minute
+
+	// PasswordChangePurpose is the purpose claim of the password change token.
+	PasswordChangePurpose = "password_change"
+
+	// OrganizationRestoreTokenTTL is the time duration for which the organization restore token is valid.
+	OrganizationRestoreTokenTTL = 24 * time.Hour
+
+	// OrganizationRestorePurpose is the purpose claim of the organization restore token.
+	OrganizationRestorePurpose = "organization_restore"
+
+	// ImpersonationSessionTTL is the time duration for which the session of an impersonated user is valid.
+	// The session can not be renewed.
+	ImpersonationSessionTTL = 30 * time.Minute
 )
 
+// LoginResult represents the outcome of a login step.
+// Either the jwt is set or the mfa token is set when the user must complete the mfa step.
+// The password change token is set instead when the password of the user has expired.
+type LoginResult struct {
+	// JWT is the short-lived access token of the user.
+	JWT string
+
+	// RefreshToken is the one-time token to renew the jwt. It is rotated on every use.
+	RefreshToken string
+
+	// TTL is the time duration for which the session is valid unless renewed.
+	TTL time.Duration
+
+	// MFAToken is the short-lived token to be exchanged for the session along with the mfa code.
+	MFAToken string
+
+	// MFAEnrollmentRequired represents whether the user must enroll mfa before completing the login.
+	MFAEnrollmentRequired bool
+
+	// PasswordChangeToken is the short-lived token to be exchanged for the session along with a new password.
+	PasswordChangeToken string
+
+	// RecoveryCodes are the mfa recovery codes generated when the enrollment is completed during login.
+	RecoveryCodes []string
+}
+
+// EmailChange represents a requested change of the email address of a user.
+type EmailChange struct {
+	// UserID is the reference to the user whose email is changed.
+	UserID int64 `db:"user_id"`
+
+	// NewEmail is the email address the user requested to change to.
+	NewEmail string `db:"new_email"`
+}
+
+// MagicLink represents a requested passwordless login of a user.
+type MagicLink struct {
+	// UserID is the reference to the user who requested the login.
+	UserID int64 `db:"user_id"`
+
+	// RememberMe represents whether the session is kept alive longer once the login is completed.
+	RememberMe bool `db:"remember_me"`
+}
+
 type (
 	// RegisterRequest represents the request payload for the register endpoint.
 	RegisterRequest struct {
 		Email     string `json:"email" validate:"email,required"`
-		Password  string `json:"password" validate:"required,min=8,max=64"`
+		Password  string `json:"password" validate:"required"`
 		Subdomain string `json:"organization_subdomain" validate:"required,alphanum,max=30"`
 		OrgName   string `json:"organization_name" validate:"required,ascii,max=60"`
 	}
 
+	// VerifyEmailRequest represents the request payload for the verify email endpoint.
+	VerifyEmailRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	// ForgotPasswordRequest represents the request payload for the forgot password endpoint.
+	ForgotPasswordRequest struct {
+		Email string `json:"email" validate:"email,required"`
+	}
+
+	// ResetPasswordRequest represents the request payload for the reset password endpoint.
+	ResetPasswordRequest struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	// OrganizationRestoreRequest represents the request payload for the organization restore endpoint.
+	OrganizationRestoreRequest struct {
+		Email string `json:"email" validate:"email,required"`
+	}
+
+	// ConfirmOrganizationRestoreRequest represents the request payload for the confirm organization restore endpoint.
+	ConfirmOrganizationRestoreRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	// ChangeEmailRequest represents the request payload to change the email of the authenticated user.
+	ChangeEmailRequest struct {
+		Email string `json:"email" validate:"email,required"`
+	}
+
+	// ConfirmEmailChangeRequest represents the request payload for the confirm email change endpoint.
+	ConfirmEmailChangeRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	// MagicLinkRequest represents the request payload for the magic link endpoint.
+	MagicLinkRequest struct {
+		Email      string `json:"email" validate:"email,required"`
+		RememberMe bool   `json:"remember_me"`
+	}
+
+	// MagicLinkCallbackRequest represents the request payload for the magic link callback endpoint.
+	MagicLinkCallbackRequest struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	// MFASetupRequest represents the request payload for the mfa setup endpoint.
+	MFASetupRequest struct {
+		MFAToken string `json:"mfa_token" validate:"required"`
+	}
+
+	// MFAVerifyRequest represents the request payload for the mfa verify endpoint.
+	MFAVerifyRequest struct {
+		MFAToken   string `json:"mfa_token" validate:"required"`
+		Code       string `json:"code" validate:"required"`
+		RememberMe bool   `json:"remember_me"`
+	}
+
+	// MFAChallengeResponse represents the response payload of the login endpoint
+	// when the user must complete the mfa step.
+	MFAChallengeResponse struct {
+		MFARequired           bool   `json:"mfa_required"`
+		MFAToken              string `json:"mfa_token"`
+		MFAEnrollmentRequired bool   `json:"mfa_enrollment_required"`
+	}
+
+	// PasswordChangeRequiredResponse represents the response payload of the login endpoint
+	// when the password of the user has expired and must be changed.
+	PasswordChangeRequiredResponse struct {
+		PasswordChangeRequired bool   `json:"password_change_required"`
+		PasswordChangeToken    string `json:"password_change_token"`
+	}
+
+	// ExpiredPasswordRequest represents the request payload for the expired password endpoint.
+	ExpiredPasswordRequest struct {
+		Token      string `json:"token" validate:"required"`
+		Password   string `json:"password" validate:"required"`
+		RememberMe bool   `json:"remember_me"`
+	}
+
+	// MFAVerifyResponse represents the response payload of the mfa verify endpoint.
+	// The password change token is returned when the password of the user has expired.
+	MFAVerifyResponse struct {
+		RecoveryCodes          []string `json:"recovery_codes,omitempty"`
+		PasswordChangeRequired bool     `json:"password_change_required,omitempty"`
+		PasswordChangeToken    string   `json:"password_change_token,omitempty"`
+	}
+
+	// SSOAuthorizeResponse represents the response payload of the sso authorize endpoint.
+	SSOAuthorizeResponse struct {
+		AuthorizationURL string `json:"authorization_url"`
+	}
+
+	// SSOCallbackRequest represents the request payload for the sso callback endpoint.
+	// The code and the state are the query parameters the identity provider redirects the user with.
+	SSOCallbackRequest struct {
+		Code  string `json:"code" validate:"required"`
+		State string `json:"state" validate:"required"`
+	}
+
 	// LoginRequest represents the request payload for the login endpoint.
 	LoginRequest struct {
 		Email    string `json:"email" validate:"email,required"`
@@ -0,0 +1,117 @@
+package customdomain
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetDomain returns the custom domain of the organization of the authenticated user.
+func (h *handler) GetDomain(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	d, err := h.service.GetDomain(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(d))
+}
+
+// SetDomain creates or replaces the custom domain of the organization.
+// The response contains the dns txt record to be published to verify the domain.
+func (h *handler) SetDomain(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload Request
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	d, err := h.service.SetDomain(r.Context(), orgID, reqPayload.Domain)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(d))
+}
+
+// VerifyDomain verifies the custom domain of the organization using its dns txt record.
+func (h *handler) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	d, err := h.service.VerifyDomain(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(d))
+}
+
+// DeleteDomain removes the custom domain of the organization.
+func (h *handler) DeleteDomain(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.DeleteDomain(r.Context(), orgID); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+func toResponse(d CustomDomain) Response {
+	return Response{
+		Domain:     d.Domain,
+		Verified:   d.IsVerified(),
+		VerifiedAt: d.VerifiedAt,
+		VerificationRecord: VerificationRecord{
+			Type:  "TXT",
+			Name:  d.VerificationRecordName(),
+			Value: d.VerificationRecordValue(),
+		},
+	}
+}
+
+// mapError sets the http status of the known custom domain errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrDomainUnavailable):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict))
+	case errors.Is(err, ErrVerificationMissing):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnprocessableEntity))
+	default:
+		return err
+	}
+}
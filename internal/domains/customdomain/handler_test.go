@@ -0,0 +1,200 @@
+package customdomain_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customDomainPath = "/api/v1/subdomains/{subdomain}/organizations/custom-domain"
+
+// withOrgID sets the org-id in the request context as done by the auth middleware.
+func withOrgID(req *http.Request, orgID int64) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), request.CtxOrgIDKey, orgID))
+}
+
+func TestHandler_GetDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the org is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, customDomainPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(customdomain.NewMockService(t))
+
+		handler.GetDomain(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found when the custom domain is not set", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, customDomainPath, nil)
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("GetDomain", fake.MockContext, orgID).
+			Return(customdomain.CustomDomain{}, base.NewNotFoundError("custom domain not found for the organization"))
+
+		handler.GetDomain(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return the custom domain with its verification record", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, customDomainPath, nil)
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("GetDomain", fake.MockContext, orgID).Return(customdomain.CustomDomain{
+			OrganizationID:    orgID,
+			Domain:            "hr.acme.com",
+			VerificationToken: "token",
+		}, nil)
+
+		handler.GetDomain(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"domain":"hr.acme.com","verified":false,"verified_at":null,`+
+			`"verification_record":{"type":"TXT","name":"_camelhr-verification.hr.acme.com",`+
+			`"value":"camelhr-verification=token"}}`, rr.Body.String())
+	})
+}
+
+func TestHandler_SetDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the domain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, customDomainPath, strings.NewReader(`{"domain":"not a domain"}`))
+		require.NoError(t, err)
+		req = withOrgID(req, gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(customdomain.NewMockService(t))
+
+		handler.SetDomain(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return conflict when the domain is used by another organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, customDomainPath, strings.NewReader(`{"domain":"hr.acme.com"}`))
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("SetDomain", fake.MockContext, orgID, "hr.acme.com").
+			Return(customdomain.CustomDomain{}, customdomain.ErrDomainUnavailable)
+
+		handler.SetDomain(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		assert.JSONEq(t, `{"error":"domain is already used by another organization"}`, rr.Body.String())
+	})
+
+	t.Run("should set the custom domain", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, customDomainPath, strings.NewReader(`{"domain":"hr.acme.com"}`))
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("SetDomain", fake.MockContext, orgID, "hr.acme.com").Return(customdomain.CustomDomain{
+			OrganizationID:    orgID,
+			Domain:            "hr.acme.com",
+			VerificationToken: "token",
+		}, nil)
+
+		handler.SetDomain(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"value":"camelhr-verification=token"`)
+	})
+}
+
+func TestHandler_VerifyDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return unprocessable entity when the verification record is missing", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, customDomainPath+"/verify", nil)
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("VerifyDomain", fake.MockContext, orgID).
+			Return(customdomain.CustomDomain{}, customdomain.ErrVerificationMissing)
+
+		handler.VerifyDomain(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.JSONEq(t, `{"error":"verification record of the domain was not found"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_DeleteDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the custom domain", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, customDomainPath, nil)
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := customdomain.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := customdomain.NewHandler(mockService)
+
+		mockService.On("DeleteDomain", fake.MockContext, orgID).Return(nil)
+
+		handler.DeleteDomain(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
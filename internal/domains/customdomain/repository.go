@@ -0,0 +1,74 @@
+package customdomain
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// GetDomain returns the custom domain of the organization.
+	GetDomain(ctx context.Context, orgID int64) (CustomDomain, error)
+
+	// GetVerifiedDomain returns the verified custom domain by its host name.
+	GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error)
+
+	// IsDomainTaken returns whether the domain is the custom domain of an organization other than the given one.
+	IsDomainTaken(ctx context.Context, domain string, orgID int64) (bool, error)
+
+	// UpsertDomain creates or replaces the custom domain of the organization.
+	// The domain must be verified again when it is replaced.
+	UpsertDomain(ctx context.Context, orgID int64, domain, verificationToken string) (CustomDomain, error)
+
+	// MarkDomainVerified marks the custom domain of the organization as verified.
+	MarkDomainVerified(ctx context.Context, orgID int64) error
+
+	// DeleteDomain removes the custom domain of the organization.
+	DeleteDomain(ctx context.Context, orgID int64) error
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	var d CustomDomain
+	err := r.db.Get(ctx, &d, getCustomDomainQuery, orgID)
+
+	return d, err
+}
+
+func (r *repository) GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error) {
+	var d CustomDomain
+	err := r.db.Get(ctx, &d, getVerifiedCustomDomainQuery, domain)
+
+	return d, err
+}
+
+func (r *repository) IsDomainTaken(ctx context.Context, domain string, orgID int64) (bool, error) {
+	var taken bool
+	err := r.db.Get(ctx, &taken, isDomainTakenQuery, domain, orgID)
+
+	return taken, err
+}
+
+func (r *repository) UpsertDomain(
+	ctx context.Context, orgID int64, domain, verificationToken string,
+) (CustomDomain, error) {
+	var d CustomDomain
+	err := r.db.Exec(ctx, &d, upsertCustomDomainQuery, orgID, domain, verificationToken)
+
+	return d, err
+}
+
+func (r *repository) MarkDomainVerified(ctx context.Context, orgID int64) error {
+	return r.db.Exec(ctx, nil, markCustomDomainVerifiedQuery, orgID)
+}
+
+func (r *repository) DeleteDomain(ctx context.Context, orgID int64) error {
+	return r.db.Exec(ctx, nil, deleteCustomDomainQuery, orgID)
+}
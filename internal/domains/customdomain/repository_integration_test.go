@@ -0,0 +1,141 @@
+package customdomain_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web"
+)
+
+// randomDomain returns a random lowercase custom domain.
+func randomDomain() string {
+	return strings.ToLower(fmt.Sprintf("%s.%s.com", gofakeit.LetterN(10), gofakeit.LetterN(20)))
+}
+
+func (s *CustomDomainTestSuite) TestRepositoryIntegration_Domain() {
+	s.Run("should set, verify, replace and delete the custom domain", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := customdomain.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		domain := randomDomain()
+
+		_, err := repo.GetDomain(ctx, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		created, err := repo.UpsertDomain(ctx, o.ID, domain, "token")
+		s.Require().NoError(err)
+		s.Equal(domain, created.Domain)
+		s.Equal("token", created.VerificationToken)
+		s.False(created.IsVerified())
+		s.NotZero(created.CreatedAt)
+
+		// the domain is not served until it is verified
+		_, err = repo.GetVerifiedDomain(ctx, domain)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		err = repo.MarkDomainVerified(ctx, o.ID)
+		s.Require().NoError(err)
+
+		verified, err := repo.GetVerifiedDomain(ctx, domain)
+		s.Require().NoError(err)
+		s.Equal(o.ID, verified.OrganizationID)
+		s.True(verified.IsVerified())
+
+		// the replaced domain must be verified again
+		replaced, err := repo.UpsertDomain(ctx, o.ID, randomDomain(), "other-token")
+		s.Require().NoError(err)
+		s.False(replaced.IsVerified())
+
+		_, err = repo.GetVerifiedDomain(ctx, domain)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		err = repo.DeleteDomain(ctx, o.ID)
+		s.Require().NoError(err)
+
+		_, err = repo.GetDomain(ctx, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should report the domain taken by another organization only", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := customdomain.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		other := fake.NewOrganization(s.DB)
+		domain := randomDomain()
+
+		_, err := repo.UpsertDomain(ctx, o.ID, domain, "token")
+		s.Require().NoError(err)
+
+		taken, err := repo.IsDomainTaken(ctx, domain, o.ID)
+		s.Require().NoError(err)
+		s.False(taken)
+
+		taken, err = repo.IsDomainTaken(ctx, domain, other.ID)
+		s.Require().NoError(err)
+		s.True(taken)
+
+		// the domain can not be set for two organizations
+		_, err = repo.UpsertDomain(ctx, other.ID, domain, "token")
+		s.Require().Error(err)
+	})
+}
+
+func (s *CustomDomainTestSuite) TestHandlerIntegration_ResolveTenant() {
+	s.Run("should serve the organization of the verified custom domain", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := customdomain.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		domain := randomDomain()
+
+		_, err := repo.UpsertDomain(ctx, o.ID, domain, "token")
+		s.Require().NoError(err)
+
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
+
+		// the domain is not served until it is verified
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = domain
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		s.Require().Equal(http.StatusNotFound, rr.Code)
+
+		s.Require().NoError(repo.MarkDomainVerified(ctx, o.ID))
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = domain
+		req.Header.Set("Origin", "https://"+domain)
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		s.Require().Equal(http.StatusOK, rr.Code)
+		s.Contains(rr.Body.String(), fmt.Sprintf(`"subdomain":"%s"`, o.Subdomain))
+		s.Equal("https://"+domain, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	s.Run("should serve the organization of the subdomain of the host", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB, fake.OrganizationSubdomain(strings.ToLower(gofakeit.LetterN(20))))
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = o.Subdomain + "." + s.Config.BaseDomain
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+
+		s.Require().Equal(http.StatusOK, rr.Code)
+		s.Contains(rr.Body.String(), fmt.Sprintf(`"subdomain":"%s"`, o.Subdomain))
+	})
+}
@@ -0,0 +1,361 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package customdomain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteDomain provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) DeleteDomain(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDomain'
+type MockRepository_DeleteDomain_Call struct {
+	*mock.Call
+}
+
+// DeleteDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) DeleteDomain(ctx interface{}, orgID interface{}) *MockRepository_DeleteDomain_Call {
+	return &MockRepository_DeleteDomain_Call{Call: _e.mock.On("DeleteDomain", ctx, orgID)}
+}
+
+func (_c *MockRepository_DeleteDomain_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_DeleteDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteDomain_Call) Return(_a0 error) *MockRepository_DeleteDomain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteDomain_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_DeleteDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDomain provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (CustomDomain, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) CustomDomain); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDomain'
+type MockRepository_GetDomain_Call struct {
+	*mock.Call
+}
+
+// GetDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetDomain(ctx interface{}, orgID interface{}) *MockRepository_GetDomain_Call {
+	return &MockRepository_GetDomain_Call{Call: _e.mock.On("GetDomain", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetDomain_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockRepository_GetDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetDomain_Call) RunAndReturn(run func(context.Context, int64) (CustomDomain, error)) *MockRepository_GetDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVerifiedDomain provides a mock function with given fields: ctx, domain
+func (_m *MockRepository) GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (CustomDomain, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) CustomDomain); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetVerifiedDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedDomain'
+type MockRepository_GetVerifiedDomain_Call struct {
+	*mock.Call
+}
+
+// GetVerifiedDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+func (_e *MockRepository_Expecter) GetVerifiedDomain(ctx interface{}, domain interface{}) *MockRepository_GetVerifiedDomain_Call {
+	return &MockRepository_GetVerifiedDomain_Call{Call: _e.mock.On("GetVerifiedDomain", ctx, domain)}
+}
+
+func (_c *MockRepository_GetVerifiedDomain_Call) Run(run func(ctx context.Context, domain string)) *MockRepository_GetVerifiedDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetVerifiedDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockRepository_GetVerifiedDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetVerifiedDomain_Call) RunAndReturn(run func(context.Context, string) (CustomDomain, error)) *MockRepository_GetVerifiedDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsDomainTaken provides a mock function with given fields: ctx, domain, orgID
+func (_m *MockRepository) IsDomainTaken(ctx context.Context, domain string, orgID int64) (bool, error) {
+	ret := _m.Called(ctx, domain, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsDomainTaken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, domain, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, domain, orgID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, domain, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_IsDomainTaken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsDomainTaken'
+type MockRepository_IsDomainTaken_Call struct {
+	*mock.Call
+}
+
+// IsDomainTaken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - orgID int64
+func (_e *MockRepository_Expecter) IsDomainTaken(ctx interface{}, domain interface{}, orgID interface{}) *MockRepository_IsDomainTaken_Call {
+	return &MockRepository_IsDomainTaken_Call{Call: _e.mock.On("IsDomainTaken", ctx, domain, orgID)}
+}
+
+func (_c *MockRepository_IsDomainTaken_Call) Run(run func(ctx context.Context, domain string, orgID int64)) *MockRepository_IsDomainTaken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_IsDomainTaken_Call) Return(_a0 bool, _a1 error) *MockRepository_IsDomainTaken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_IsDomainTaken_Call) RunAndReturn(run func(context.Context, string, int64) (bool, error)) *MockRepository_IsDomainTaken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDomainVerified provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) MarkDomainVerified(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDomainVerified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_MarkDomainVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDomainVerified'
+type MockRepository_MarkDomainVerified_Call struct {
+	*mock.Call
+}
+
+// MarkDomainVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) MarkDomainVerified(ctx interface{}, orgID interface{}) *MockRepository_MarkDomainVerified_Call {
+	return &MockRepository_MarkDomainVerified_Call{Call: _e.mock.On("MarkDomainVerified", ctx, orgID)}
+}
+
+func (_c *MockRepository_MarkDomainVerified_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_MarkDomainVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_MarkDomainVerified_Call) Return(_a0 error) *MockRepository_MarkDomainVerified_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_MarkDomainVerified_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_MarkDomainVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertDomain provides a mock function with given fields: ctx, orgID, domain, verificationToken
+func (_m *MockRepository) UpsertDomain(ctx context.Context, orgID int64, domain string, verificationToken string) (CustomDomain, error) {
+	ret := _m.Called(ctx, orgID, domain, verificationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) (CustomDomain, error)); ok {
+		return rf(ctx, orgID, domain, verificationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) CustomDomain); ok {
+		r0 = rf(ctx, orgID, domain, verificationToken)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, orgID, domain, verificationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpsertDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertDomain'
+type MockRepository_UpsertDomain_Call struct {
+	*mock.Call
+}
+
+// UpsertDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - domain string
+//   - verificationToken string
+func (_e *MockRepository_Expecter) UpsertDomain(ctx interface{}, orgID interface{}, domain interface{}, verificationToken interface{}) *MockRepository_UpsertDomain_Call {
+	return &MockRepository_UpsertDomain_Call{Call: _e.mock.On("UpsertDomain", ctx, orgID, domain, verificationToken)}
+}
+
+func (_c *MockRepository_UpsertDomain_Call) Run(run func(ctx context.Context, orgID int64, domain string, verificationToken string)) *MockRepository_UpsertDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockRepository_UpsertDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpsertDomain_Call) RunAndReturn(run func(context.Context, int64, string, string) (CustomDomain, error)) *MockRepository_UpsertDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+package customdomain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TXTResolver is an interface for looking up the dns txt records.
+// It is used to verify the ownership of the custom domains.
+type TXTResolver interface {
+	// LookupTXT returns the txt records of the given name. No records are returned when the name does not exist.
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type netResolver struct {
+	resolver *net.Resolver
+}
+
+// NewNetResolver creates a new txt resolver using the given net resolver.
+// The default resolver of the host is used when the resolver is nil.
+func NewNetResolver(resolver *net.Resolver) TXTResolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &netResolver{resolver}
+}
+
+func (r *netResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	const lookupTimeout = 10 * time.Second
+
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	records, err := r.resolver.LookupTXT(ctx, name)
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup txt records of %s: %w", name, err)
+	}
+
+	return records, nil
+}
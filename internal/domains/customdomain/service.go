@@ -0,0 +1,116 @@
+package customdomain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+)
+
+var (
+	ErrDomainUnavailable   = errors.New("domain is already used by another organization")
+	ErrVerificationMissing = errors.New("verification record of the domain was not found")
+)
+
+type Service interface {
+	// GetDomain returns the custom domain of the organization.
+	GetDomain(ctx context.Context, orgID int64) (CustomDomain, error)
+
+	// GetVerifiedDomain returns the verified custom domain by its host name.
+	// The host name is matched case insensitively.
+	GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error)
+
+	// SetDomain creates or replaces the custom domain of the organization with a new verification token.
+	// The domain is served for the organization once it is verified.
+	// ErrDomainUnavailable is returned when the domain is the custom domain of another organization.
+	SetDomain(ctx context.Context, orgID int64, domain string) (CustomDomain, error)
+
+	// VerifyDomain verifies the ownership of the custom domain of the organization by looking up
+	// the dns txt record of the verification token. ErrVerificationMissing is returned when the record
+	// is not published yet.
+	VerifyDomain(ctx context.Context, orgID int64) (CustomDomain, error)
+
+	// DeleteDomain removes the custom domain of the organization.
+	DeleteDomain(ctx context.Context, orgID int64) error
+}
+
+type service struct {
+	repo       Repository
+	resolver   TXTResolver
+	baseDomain string
+}
+
+func NewService(conf config.Config, repo Repository, resolver TXTResolver) Service {
+	return &service{repo, resolver, conf.BaseDomain}
+}
+
+func (s *service) GetDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	d, err := s.repo.GetDomain(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return CustomDomain{}, base.NewNotFoundError("custom domain not found for the organization")
+	}
+
+	return d, err
+}
+
+func (s *service) GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error) {
+	d, err := s.repo.GetVerifiedDomain(ctx, strings.ToLower(domain))
+	if errors.Is(err, sql.ErrNoRows) {
+		return CustomDomain{}, base.NewNotFoundError("verified custom domain not found for the given domain")
+	}
+
+	return d, err
+}
+
+func (s *service) SetDomain(ctx context.Context, orgID int64, domain string) (CustomDomain, error) {
+	domain = strings.ToLower(domain)
+	if err := ValidateDomain(domain, s.baseDomain); err != nil {
+		return CustomDomain{}, err
+	}
+
+	taken, err := s.repo.IsDomainTaken(ctx, domain, orgID)
+	if err != nil {
+		return CustomDomain{}, err
+	}
+
+	if taken {
+		return CustomDomain{}, ErrDomainUnavailable
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return CustomDomain{}, err
+	}
+
+	return s.repo.UpsertDomain(ctx, orgID, domain, token)
+}
+
+func (s *service) VerifyDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	d, err := s.GetDomain(ctx, orgID)
+	if err != nil || d.IsVerified() {
+		return d, err
+	}
+
+	records, err := s.resolver.LookupTXT(ctx, d.VerificationRecordName())
+	if err != nil {
+		return CustomDomain{}, err
+	}
+
+	if !slices.Contains(records, d.VerificationRecordValue()) {
+		return CustomDomain{}, ErrVerificationMissing
+	}
+
+	if err := s.repo.MarkDomainVerified(ctx, orgID); err != nil {
+		return CustomDomain{}, err
+	}
+
+	return s.GetDomain(ctx, orgID)
+}
+
+func (s *service) DeleteDomain(ctx context.Context, orgID int64) error {
+	return s.repo.DeleteDomain(ctx, orgID)
+}
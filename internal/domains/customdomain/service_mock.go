@@ -0,0 +1,312 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package customdomain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteDomain provides a mock function with given fields: ctx, orgID
+func (_m *MockService) DeleteDomain(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_DeleteDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDomain'
+type MockService_DeleteDomain_Call struct {
+	*mock.Call
+}
+
+// DeleteDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) DeleteDomain(ctx interface{}, orgID interface{}) *MockService_DeleteDomain_Call {
+	return &MockService_DeleteDomain_Call{Call: _e.mock.On("DeleteDomain", ctx, orgID)}
+}
+
+func (_c *MockService_DeleteDomain_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_DeleteDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteDomain_Call) Return(_a0 error) *MockService_DeleteDomain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_DeleteDomain_Call) RunAndReturn(run func(context.Context, int64) error) *MockService_DeleteDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDomain provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (CustomDomain, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) CustomDomain); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDomain'
+type MockService_GetDomain_Call struct {
+	*mock.Call
+}
+
+// GetDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) GetDomain(ctx interface{}, orgID interface{}) *MockService_GetDomain_Call {
+	return &MockService_GetDomain_Call{Call: _e.mock.On("GetDomain", ctx, orgID)}
+}
+
+func (_c *MockService_GetDomain_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockService_GetDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetDomain_Call) RunAndReturn(run func(context.Context, int64) (CustomDomain, error)) *MockService_GetDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVerifiedDomain provides a mock function with given fields: ctx, domain
+func (_m *MockService) GetVerifiedDomain(ctx context.Context, domain string) (CustomDomain, error) {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (CustomDomain, error)); ok {
+		return rf(ctx, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) CustomDomain); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetVerifiedDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedDomain'
+type MockService_GetVerifiedDomain_Call struct {
+	*mock.Call
+}
+
+// GetVerifiedDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+func (_e *MockService_Expecter) GetVerifiedDomain(ctx interface{}, domain interface{}) *MockService_GetVerifiedDomain_Call {
+	return &MockService_GetVerifiedDomain_Call{Call: _e.mock.On("GetVerifiedDomain", ctx, domain)}
+}
+
+func (_c *MockService_GetVerifiedDomain_Call) Run(run func(ctx context.Context, domain string)) *MockService_GetVerifiedDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetVerifiedDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockService_GetVerifiedDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetVerifiedDomain_Call) RunAndReturn(run func(context.Context, string) (CustomDomain, error)) *MockService_GetVerifiedDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDomain provides a mock function with given fields: ctx, orgID, domain
+func (_m *MockService) SetDomain(ctx context.Context, orgID int64, domain string) (CustomDomain, error) {
+	ret := _m.Called(ctx, orgID, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (CustomDomain, error)); ok {
+		return rf(ctx, orgID, domain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) CustomDomain); ok {
+		r0 = rf(ctx, orgID, domain)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, orgID, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SetDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDomain'
+type MockService_SetDomain_Call struct {
+	*mock.Call
+}
+
+// SetDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - domain string
+func (_e *MockService_Expecter) SetDomain(ctx interface{}, orgID interface{}, domain interface{}) *MockService_SetDomain_Call {
+	return &MockService_SetDomain_Call{Call: _e.mock.On("SetDomain", ctx, orgID, domain)}
+}
+
+func (_c *MockService_SetDomain_Call) Run(run func(ctx context.Context, orgID int64, domain string)) *MockService_SetDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_SetDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockService_SetDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_SetDomain_Call) RunAndReturn(run func(context.Context, int64, string) (CustomDomain, error)) *MockService_SetDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyDomain provides a mock function with given fields: ctx, orgID
+func (_m *MockService) VerifyDomain(ctx context.Context, orgID int64) (CustomDomain, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyDomain")
+	}
+
+	var r0 CustomDomain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (CustomDomain, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) CustomDomain); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(CustomDomain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_VerifyDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyDomain'
+type MockService_VerifyDomain_Call struct {
+	*mock.Call
+}
+
+// VerifyDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) VerifyDomain(ctx interface{}, orgID interface{}) *MockService_VerifyDomain_Call {
+	return &MockService_VerifyDomain_Call{Call: _e.mock.On("VerifyDomain", ctx, orgID)}
+}
+
+func (_c *MockService_VerifyDomain_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_VerifyDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyDomain_Call) Return(_a0 CustomDomain, _a1 error) *MockService_VerifyDomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_VerifyDomain_Call) RunAndReturn(run func(context.Context, int64) (CustomDomain, error)) *MockService_VerifyDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
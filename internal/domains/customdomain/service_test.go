@@ -0,0 +1,241 @@
+package customdomain_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var conf = config.Config{BaseDomain: "camelhr.com"}
+
+func TestValidateDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should accept a lowercase host name", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, customdomain.ValidateDomain("hr.acme.com", conf.BaseDomain))
+		require.NoError(t, customdomain.ValidateDomain("acme-hr.co.uk", conf.BaseDomain))
+	})
+
+	t.Run("should reject an invalid domain", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			domain string
+			err    string
+		}{
+			{"", "domain is required"},
+			{gofakeit.LetterN(250) + ".com", "domain must be a maximum of 253 characters in length"},
+			{"localhost", "domain must be a valid lowercase host name"},
+			{"HR.acme.com", "domain must be a valid lowercase host name"},
+			{"-hr.acme.com", "domain must be a valid lowercase host name"},
+			{"hr.acme.com:8080", "domain must be a valid lowercase host name"},
+			{"camelhr.com", "domain can not be camelhr.com or one of its subdomains"},
+			{"acme.camelhr.com", "domain can not be camelhr.com or one of its subdomains"},
+		}
+
+		for _, tt := range tests {
+			err := customdomain.ValidateDomain(tt.domain, conf.BaseDomain)
+			require.Error(t, err, tt.domain)
+			assert.True(t, base.IsInputValidationError(err))
+			assert.EqualError(t, err, tt.err)
+		}
+	})
+}
+
+func TestCustomDomain_VerificationRecord(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the txt record holding the verification token", func(t *testing.T) {
+		t.Parallel()
+
+		d := customdomain.CustomDomain{Domain: "hr.acme.com", VerificationToken: "token"}
+
+		assert.Equal(t, "_camelhr-verification.hr.acme.com", d.VerificationRecordName())
+		assert.Equal(t, "camelhr-verification=token", d.VerificationRecordValue())
+		assert.False(t, d.IsVerified())
+	})
+}
+
+func TestService_GetVerifiedDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should look up the domain case insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+		d := customdomain.CustomDomain{OrganizationID: gofakeit.Int64(), Domain: "hr.acme.com"}
+
+		repo.On("GetVerifiedDomain", ctx, "hr.acme.com").Return(d, nil)
+
+		result, err := service.GetVerifiedDomain(ctx, "HR.Acme.com")
+		require.NoError(t, err)
+		assert.Equal(t, d, result)
+	})
+
+	t.Run("should return not found error when the domain is not verified", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+
+		repo.On("GetVerifiedDomain", ctx, "hr.acme.com").Return(customdomain.CustomDomain{}, sql.ErrNoRows)
+
+		_, err := service.GetVerifiedDomain(ctx, "hr.acme.com")
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+}
+
+func TestService_SetDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return validation error for a subdomain of the base domain", func(t *testing.T) {
+		t.Parallel()
+
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+
+		_, err := service.SetDomain(context.Background(), gofakeit.Int64(), "acme.camelhr.com")
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return error when the domain is used by another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+
+		repo.On("IsDomainTaken", ctx, "hr.acme.com", orgID).Return(true, nil)
+
+		_, err := service.SetDomain(ctx, orgID, "hr.acme.com")
+		require.ErrorIs(t, err, customdomain.ErrDomainUnavailable)
+	})
+
+	t.Run("should set the lowercase domain with a new verification token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+		d := customdomain.CustomDomain{OrganizationID: orgID, Domain: "hr.acme.com", VerificationToken: "token"}
+
+		repo.On("IsDomainTaken", ctx, "hr.acme.com", orgID).Return(false, nil)
+		repo.On("UpsertDomain", ctx, orgID, "hr.acme.com", mock.MatchedBy(func(token string) bool {
+			return token != ""
+		})).Return(d, nil)
+
+		result, err := service.SetDomain(ctx, orgID, "HR.acme.com")
+		require.NoError(t, err)
+		assert.Equal(t, d, result)
+	})
+}
+
+func TestService_VerifyDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the custom domain is not set", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := customdomain.NewMockRepository(t)
+		service := customdomain.NewService(conf, repo, nil)
+
+		repo.On("GetDomain", ctx, orgID).Return(customdomain.CustomDomain{}, sql.ErrNoRows)
+
+		_, err := service.VerifyDomain(ctx, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should not look up the domain verified already", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		verifiedAt := time.Now().UTC()
+		d := customdomain.CustomDomain{OrganizationID: gofakeit.Int64(), Domain: "hr.acme.com", VerifiedAt: &verifiedAt}
+		repo := customdomain.NewMockRepository(t)
+		resolver := customdomain.NewMockTXTResolver(t)
+		service := customdomain.NewService(conf, repo, resolver)
+
+		repo.On("GetDomain", ctx, d.OrganizationID).Return(d, nil)
+
+		result, err := service.VerifyDomain(ctx, d.OrganizationID)
+		require.NoError(t, err)
+		assert.True(t, result.IsVerified())
+	})
+
+	t.Run("should return error when the verification record is not published", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		d := customdomain.CustomDomain{OrganizationID: gofakeit.Int64(), Domain: "hr.acme.com", VerificationToken: "token"}
+		repo := customdomain.NewMockRepository(t)
+		resolver := customdomain.NewMockTXTResolver(t)
+		service := customdomain.NewService(conf, repo, resolver)
+
+		repo.On("GetDomain", ctx, d.OrganizationID).Return(d, nil)
+		resolver.On("LookupTXT", ctx, "_camelhr-verification.hr.acme.com").
+			Return([]string{"camelhr-verification=other", "v=spf1 -all"}, nil)
+
+		_, err := service.VerifyDomain(ctx, d.OrganizationID)
+		require.ErrorIs(t, err, customdomain.ErrVerificationMissing)
+	})
+
+	t.Run("should return error when the lookup fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		d := customdomain.CustomDomain{OrganizationID: gofakeit.Int64(), Domain: "hr.acme.com", VerificationToken: "token"}
+		repo := customdomain.NewMockRepository(t)
+		resolver := customdomain.NewMockTXTResolver(t)
+		service := customdomain.NewService(conf, repo, resolver)
+
+		repo.On("GetDomain", ctx, d.OrganizationID).Return(d, nil)
+		resolver.On("LookupTXT", ctx, "_camelhr-verification.hr.acme.com").Return(nil, assert.AnError)
+
+		_, err := service.VerifyDomain(ctx, d.OrganizationID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should mark the domain verified when the verification record is published", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		d := customdomain.CustomDomain{OrganizationID: gofakeit.Int64(), Domain: "hr.acme.com", VerificationToken: "token"}
+		verifiedAt := time.Now().UTC()
+		verified := d
+		verified.VerifiedAt = &verifiedAt
+		repo := customdomain.NewMockRepository(t)
+		resolver := customdomain.NewMockTXTResolver(t)
+		service := customdomain.NewService(conf, repo, resolver)
+
+		repo.On("GetDomain", ctx, d.OrganizationID).Return(d, nil).Once()
+		resolver.On("LookupTXT", ctx, "_camelhr-verification.hr.acme.com").
+			Return([]string{"camelhr-verification=token"}, nil)
+		repo.On("MarkDomainVerified", ctx, d.OrganizationID).Return(nil)
+		repo.On("GetDomain", ctx, d.OrganizationID).Return(verified, nil).Once()
+
+		result, err := service.VerifyDomain(ctx, d.OrganizationID)
+		require.NoError(t, err)
+		assert.True(t, result.IsVerified())
+	})
+}
@@ -0,0 +1,21 @@
+package customdomain
+
+import _ "embed"
+
+//go:embed sql/get_custom_domain.sql
+var getCustomDomainQuery string
+
+//go:embed sql/get_verified_custom_domain.sql
+var getVerifiedCustomDomainQuery string
+
+//go:embed sql/is_domain_taken.sql
+var isDomainTakenQuery string
+
+//go:embed sql/upsert_custom_domain.sql
+var upsertCustomDomainQuery string
+
+//go:embed sql/mark_custom_domain_verified.sql
+var markCustomDomainVerifiedQuery string
+
+//go:embed sql/delete_custom_domain.sql
+var deleteCustomDomainQuery string
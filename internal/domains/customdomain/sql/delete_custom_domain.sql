@@ -0,0 +1,6 @@
+-- deleteCustomDomainQuery
+-- $1: organization_id
+DELETE FROM
+    custom_domains
+WHERE
+    organization_id = $1;
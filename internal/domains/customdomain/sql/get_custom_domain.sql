@@ -0,0 +1,13 @@
+-- getCustomDomainQuery
+-- $1: organization_id
+SELECT
+    organization_id,
+    domain,
+    verification_token,
+    verified_at,
+    created_at,
+    updated_at
+FROM
+    custom_domains
+WHERE
+    organization_id = $1;
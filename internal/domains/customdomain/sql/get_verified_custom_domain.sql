@@ -0,0 +1,14 @@
+-- getVerifiedCustomDomainQuery
+-- $1: domain
+SELECT
+    organization_id,
+    domain,
+    verification_token,
+    verified_at,
+    created_at,
+    updated_at
+FROM
+    custom_domains
+WHERE
+    domain = $1
+    AND verified_at IS NOT NULL;
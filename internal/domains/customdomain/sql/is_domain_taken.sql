@@ -0,0 +1,13 @@
+-- isDomainTakenQuery
+-- $1: domain
+-- $2: organization_id
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            custom_domains
+        WHERE
+            domain = $1
+            AND organization_id <> $2
+    );
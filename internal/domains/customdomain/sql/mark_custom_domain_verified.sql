@@ -0,0 +1,10 @@
+-- markCustomDomainVerifiedQuery
+-- $1: organization_id
+UPDATE
+    custom_domains
+SET
+    verified_at = NOW(),
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND verified_at IS NULL;
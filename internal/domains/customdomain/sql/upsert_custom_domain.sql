@@ -0,0 +1,21 @@
+-- upsertCustomDomainQuery
+-- $1: organization_id
+-- $2: domain
+-- $3: verification_token
+INSERT INTO
+    custom_domains(organization_id, domain, verification_token)
+VALUES
+    ($1, $2, $3) ON CONFLICT (organization_id) DO
+UPDATE
+SET
+    domain = EXCLUDED.domain,
+    verification_token = EXCLUDED.verification_token,
+    verified_at = NULL,
+    updated_at = NOW()
+RETURNING
+    organization_id,
+    domain,
+    verification_token,
+    verified_at,
+    created_at,
+    updated_at;
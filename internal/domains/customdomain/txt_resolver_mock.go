@@ -0,0 +1,95 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package customdomain
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTXTResolver is an autogenerated mock type for the TXTResolver type
+type MockTXTResolver struct {
+	mock.Mock
+}
+
+type MockTXTResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTXTResolver) EXPECT() *MockTXTResolver_Expecter {
+	return &MockTXTResolver_Expecter{mock: &_m.Mock}
+}
+
+// LookupTXT provides a mock function with given fields: ctx, name
+func (_m *MockTXTResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupTXT")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTXTResolver_LookupTXT_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupTXT'
+type MockTXTResolver_LookupTXT_Call struct {
+	*mock.Call
+}
+
+// LookupTXT is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTXTResolver_Expecter) LookupTXT(ctx interface{}, name interface{}) *MockTXTResolver_LookupTXT_Call {
+	return &MockTXTResolver_LookupTXT_Call{Call: _e.mock.On("LookupTXT", ctx, name)}
+}
+
+func (_c *MockTXTResolver_LookupTXT_Call) Run(run func(ctx context.Context, name string)) *MockTXTResolver_LookupTXT_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTXTResolver_LookupTXT_Call) Return(_a0 []string, _a1 error) *MockTXTResolver_LookupTXT_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTXTResolver_LookupTXT_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockTXTResolver_LookupTXT_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTXTResolver creates a new instance of MockTXTResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTXTResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTXTResolver {
+	mock := &MockTXTResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
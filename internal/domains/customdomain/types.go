@@ -0,0 +1,70 @@
+package customdomain
+
+import (
+	"time"
+)
+
+const (
+	// VerificationRecordPrefix is prepended to the custom domain to get the name of the dns txt record
+	// holding the verification token.
+	VerificationRecordPrefix = "_camelhr-verification."
+
+	// VerificationValuePrefix is prepended to the verification token to get the value of the dns txt record.
+	VerificationValuePrefix = "camelhr-verification="
+)
+
+// CustomDomain represents the custom domain of an organization.
+// The requests to the custom domain are served for the organization once the domain is verified.
+type CustomDomain struct {
+	// OrganizationID is the reference to the organization the custom domain belongs to.
+	OrganizationID int64 `db:"organization_id"`
+
+	// Domain is the lowercase host name of the custom domain e.g. hr.acme.com
+	Domain string `db:"domain"`
+
+	// VerificationToken is the token that must be published in the dns txt record of the domain
+	// to prove the ownership of the domain.
+	VerificationToken string `db:"verification_token"`
+
+	// VerifiedAt is the timestamp when the ownership of the domain was verified.
+	VerifiedAt *time.Time `db:"verified_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// IsVerified returns true if the ownership of the domain is verified.
+func (d CustomDomain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// VerificationRecordName returns the name of the dns txt record holding the verification token.
+func (d CustomDomain) VerificationRecordName() string {
+	return VerificationRecordPrefix + d.Domain
+}
+
+// VerificationRecordValue returns the expected value of the dns txt record holding the verification token.
+func (d CustomDomain) VerificationRecordValue() string {
+	return VerificationValuePrefix + d.VerificationToken
+}
+
+// Request represents a http request to set the custom domain of an organization.
+type Request struct {
+	Domain string `json:"domain" validate:"required,fqdn,max=253"`
+}
+
+// Response represents a http response of the custom domain of an organization.
+// The verification record must be published to verify the domain.
+type Response struct {
+	Domain             string             `json:"domain"`
+	Verified           bool               `json:"verified"`
+	VerifiedAt         *time.Time         `json:"verified_at"`
+	VerificationRecord VerificationRecord `json:"verification_record"`
+}
+
+// VerificationRecord represents the dns record proving the ownership of a custom domain.
+type VerificationRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
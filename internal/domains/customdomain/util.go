@@ -0,0 +1,38 @@
+package customdomain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+)
+
+// ValidateDomain validates the custom domain string.
+// The subdomains of the base domain are served for the organizations already and can not be used.
+func ValidateDomain(domain, baseDomain string) error {
+	const allowedMaxLength = 253
+
+	// validate that domain is not empty
+	if domain == "" {
+		return base.NewInputValidationError("domain is required")
+	}
+
+	// validate that domain length does not exceed allowedMaxLength
+	if len(domain) > allowedMaxLength {
+		return base.NewInputValidationError("domain must be a maximum of 253 characters in length")
+	}
+
+	// validate that domain is a lowercase host name of at least two labels of up to 63 characters each
+	match, err := regexp.MatchString(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]([a-z0-9-]{0,61}[a-z0-9])?$`, domain)
+	if err != nil || !match {
+		return base.NewInputValidationError("domain must be a valid lowercase host name")
+	}
+
+	// validate that domain is not the base domain or one of its subdomains
+	if domain == baseDomain || strings.HasSuffix(domain, "."+baseDomain) {
+		return base.NewInputValidationError(fmt.Sprintf("domain can not be %s or one of its subdomains", baseDomain))
+	}
+
+	return nil
+}
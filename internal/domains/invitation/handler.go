@@ -0,0 +1,160 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// ListInvitations lists the pending invitations of the organization.
+func (h *handler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := make([]Response, 0, len(invitations))
+	for _, i := range invitations {
+		result = append(result, toResponse(i))
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// CreateInvitation invites a user to the organization by email.
+func (h *handler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload CreateRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	i, err := h.service.CreateInvitation(r.Context(), userID, orgID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toResponse(i))
+}
+
+// ResendInvitation mails a new invitation link for a pending invitation of the organization.
+func (h *handler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	invitationID, err := request.URLParamID(r, "invitationID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	i, err := h.service.ResendInvitation(r.Context(), orgID, invitationID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(i))
+}
+
+// RevokeInvitation revokes a pending invitation of the organization.
+func (h *handler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	invitationID, err := request.URLParamID(r, "invitationID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), orgID, invitationID); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// AcceptInvitation creates the invited user with the password chosen by the invitee.
+func (h *handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	org, err := organization.FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload AcceptRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	_, err = h.service.AcceptInvitation(r.Context(), org.Subdomain, reqPayload.Token, reqPayload.Password)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusCreated)
+}
+
+// mapError sets the http status of the known invitation errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest))
+	case errors.Is(err, role.ErrOwnerRole), errors.Is(err, role.ErrPermissionNotGranted):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	case errors.Is(err, ErrUserAlreadyExists), errors.Is(err, ErrAlreadyInvited):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict))
+	default:
+		return err
+	}
+}
+
+func toResponse(i Invitation) Response {
+	return Response{
+		ID:        i.ID,
+		Email:     i.Email,
+		RoleID:    i.RoleID,
+		InvitedBy: i.InvitedBy,
+		ExpiresAt: i.ExpiresAt,
+		IsExpired: i.IsExpired(),
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
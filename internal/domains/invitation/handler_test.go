@@ -0,0 +1,225 @@
+package invitation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/invitation"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invitationsPath = "/api/v1/subdomains/{subdomain}/invitations"
+
+func TestHandler_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the org is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, invitationsPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(invitation.NewMockService(t))
+
+		handler.ListInvitations(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should list the pending invitations of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, invitationsPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+
+		mockService := invitation.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(mockService)
+		invitations := []invitation.Invitation{
+			{ID: 1, Email: gofakeit.Email(), ExpiresAt: time.Now().UTC().Add(time.Hour)},
+			{ID: 2, Email: gofakeit.Email(), ExpiresAt: time.Now().UTC().Add(-time.Hour)},
+		}
+
+		mockService.On("ListInvitations", fake.MockContext, orgID).Return(invitations, nil)
+
+		handler.ListInvitations(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result []invitation.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, invitations[0].Email, result[0].Email)
+		assert.False(t, result[0].IsExpired)
+		assert.True(t, result[1].IsExpired)
+	})
+}
+
+func TestHandler_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, invitationsPath, strings.NewReader(`{"email":"invalid"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(invitation.NewMockService(t))
+
+		handler.CreateInvitation(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "should return conflict when the user already exists", err: invitation.ErrUserAlreadyExists,
+			code: http.StatusConflict},
+		{name: "should return conflict when the email is already invited", err: invitation.ErrAlreadyInvited,
+			code: http.StatusConflict},
+		{name: "should return forbidden when the role can not be assigned", err: role.ErrPermissionNotGranted,
+			code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userID := gofakeit.Int64()
+			orgID := gofakeit.Int64()
+			email := gofakeit.Email()
+			req, err := http.NewRequest(http.MethodPost, invitationsPath,
+				strings.NewReader(`{"email":"`+email+`"}`))
+			require.NoError(t, err)
+			req = tests.WithAuthContext(req, userID, orgID)
+
+			mockService := invitation.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := invitation.NewHandler(mockService)
+
+			mockService.On("CreateInvitation", fake.MockContext, userID, orgID, invitation.CreateRequest{Email: email}).
+				Return(invitation.Invitation{}, tc.err)
+
+			handler.CreateInvitation(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+
+	t.Run("should create the invitation with the role", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		email := gofakeit.Email()
+		req, err := http.NewRequest(http.MethodPost, invitationsPath,
+			strings.NewReader(`{"email":"`+email+`","role_id":`+strconv.FormatInt(roleID, 10)+`}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := invitation.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(mockService)
+		i := invitation.Invitation{ID: gofakeit.Int64(), OrganizationID: orgID, Email: email, RoleID: &roleID}
+
+		mockService.On("CreateInvitation", fake.MockContext, userID, orgID,
+			invitation.CreateRequest{Email: email, RoleID: &roleID}).Return(i, nil)
+
+		handler.CreateInvitation(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var result invitation.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, i.ID, result.ID)
+		assert.Equal(t, &roleID, result.RoleID)
+	})
+}
+
+func TestHandler_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should revoke the invitation of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		invitationID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, invitationsPath+"/{invitationID}", nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(tests.WithURLParam(req, "invitationID", strconv.FormatInt(invitationID, 10)),
+			gofakeit.Int64(), orgID)
+
+		mockService := invitation.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(mockService)
+
+		mockService.On("RevokeInvitation", fake.MockContext, orgID, invitationID).Return(nil)
+
+		handler.RevokeInvitation(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestHandler_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the token is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, invitationsPath+"/accept",
+			strings.NewReader(`{"token":"token","password":"Password@123"}`))
+		require.NoError(t, err)
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: "camel"}))
+
+		mockService := invitation.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(mockService)
+
+		mockService.On("AcceptInvitation", fake.MockContext, "camel", "token", "Password@123").
+			Return(user.User{}, invitation.ErrInvalidToken)
+
+		handler.AcceptInvitation(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should create the invited user", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, invitationsPath+"/accept",
+			strings.NewReader(`{"token":"token","password":"Password@123"}`))
+		require.NoError(t, err)
+		req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{Subdomain: "camel"}))
+
+		mockService := invitation.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := invitation.NewHandler(mockService)
+
+		mockService.On("AcceptInvitation", fake.MockContext, "camel", "token", "Password@123").
+			Return(user.User{ID: gofakeit.Int64()}, nil)
+
+		handler.AcceptInvitation(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+	})
+}
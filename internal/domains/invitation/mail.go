@@ -0,0 +1,22 @@
+package invitation
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/camelhr/camelhr-api/internal/mailer"
+)
+
+// invitationEmail returns the email message with the link to accept the invitation.
+func invitationEmail(to, appURL, subdomain, orgName, token string) mailer.Message {
+	link := fmt.Sprintf("%s/accept-invitation?subdomain=%s&token=%s", appURL, url.QueryEscape(subdomain),
+		url.QueryEscape(token))
+
+	return mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("You are invited to join %s on CamelHR", orgName),
+		Body: fmt.Sprintf("You have been invited to join %s on CamelHR.\n\nOpen the link below to choose your "+
+			"password and activate your account. The link expires in %d days.\n\n%s\n",
+			orgName, invitationTTLDays, link),
+	}
+}
@@ -0,0 +1,95 @@
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+// Repository is a repository for managing the invitations in the database.
+type Repository interface {
+	// ListInvitations returns the invitations of the organization that are neither accepted nor revoked.
+	ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error)
+
+	// GetInvitationByID returns an invitation of the organization by its id.
+	GetInvitationByID(ctx context.Context, orgID, invitationID int64) (Invitation, error)
+
+	// GetPendingInvitationByEmail returns the unexpired invitation of the email that is neither accepted nor revoked.
+	GetPendingInvitationByEmail(ctx context.Context, orgID int64, email string) (Invitation, error)
+
+	// CreateInvitation stores a new invitation along with the hash of its token.
+	CreateInvitation(ctx context.Context, i Invitation, ttl time.Duration) (Invitation, error)
+
+	// RenewInvitation replaces the token hash of a pending invitation and extends its expiry.
+	// It returns sql.ErrNoRows if the invitation is not found, accepted or revoked.
+	RenewInvitation(ctx context.Context, orgID, invitationID int64, tokenHash string, ttl time.Duration) (
+		Invitation, error,
+	)
+
+	// RevokeInvitation marks a pending invitation as revoked.
+	// It returns sql.ErrNoRows if the invitation is not found, accepted or revoked.
+	RevokeInvitation(ctx context.Context, orgID, invitationID int64) error
+
+	// AcceptInvitation marks the invitation of the token hash as accepted and returns it.
+	// It returns sql.ErrNoRows if the invitation is not found, accepted, revoked or expired.
+	AcceptInvitation(ctx context.Context, tokenHash string) (Invitation, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error) {
+	var invitations []Invitation
+	err := r.db.List(ctx, &invitations, listInvitationsQuery, orgID)
+
+	return invitations, err
+}
+
+func (r *repository) GetInvitationByID(ctx context.Context, orgID, invitationID int64) (Invitation, error) {
+	var i Invitation
+	err := r.db.Get(ctx, &i, getInvitationByIDQuery, orgID, invitationID)
+
+	return i, err
+}
+
+func (r *repository) GetPendingInvitationByEmail(ctx context.Context, orgID int64, email string) (Invitation, error) {
+	var i Invitation
+	err := r.db.Get(ctx, &i, getPendingInvitationByEmailQuery, orgID, email)
+
+	return i, err
+}
+
+func (r *repository) CreateInvitation(ctx context.Context, i Invitation, ttl time.Duration) (Invitation, error) {
+	var result Invitation
+	err := r.db.Exec(ctx, &result, createInvitationQuery,
+		i.OrganizationID, i.Email, i.RoleID, i.InvitedBy, i.TokenHash, ttl.Seconds())
+
+	return result, err
+}
+
+func (r *repository) RenewInvitation(
+	ctx context.Context, orgID, invitationID int64, tokenHash string, ttl time.Duration,
+) (Invitation, error) {
+	var i Invitation
+	err := r.db.Exec(ctx, &i, renewInvitationQuery, orgID, invitationID, tokenHash, ttl.Seconds())
+
+	return i, err
+}
+
+func (r *repository) RevokeInvitation(ctx context.Context, orgID, invitationID int64) error {
+	var id int64
+	return r.db.Exec(ctx, &id, revokeInvitationQuery, orgID, invitationID)
+}
+
+func (r *repository) AcceptInvitation(ctx context.Context, tokenHash string) (Invitation, error) {
+	var i Invitation
+	err := r.db.Exec(ctx, &i, acceptInvitationQuery, tokenHash)
+
+	return i, err
+}
@@ -0,0 +1,167 @@
+package invitation_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/invitation"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+// newInvitation creates an invitation of a new organization by its owner.
+func (s *InvitationTestSuite) newInvitation(ttl time.Duration) invitation.Invitation {
+	o := fake.NewOrganization(s.DB)
+	owner := o.AddUser(s.DB, fake.UserIsOwner())
+	roleID := fake.SystemRoleID(s.DB, role.SystemRoleManager)
+
+	i, err := invitation.NewRepository(s.DB).CreateInvitation(context.Background(), invitation.Invitation{
+		OrganizationID: o.ID,
+		Email:          gofakeit.Email(),
+		RoleID:         &roleID,
+		InvitedBy:      owner.ID,
+		TokenHash:      base.HashToken(gofakeit.UUID()),
+	}, ttl)
+	s.Require().NoError(err)
+
+	return i
+}
+
+func (s *InvitationTestSuite) TestRepositoryIntegration_CreateInvitation() {
+	s.Run("should create an invitation expiring after the ttl", func() {
+		s.T().Parallel()
+
+		i := s.newInvitation(invitation.InvitationTTL)
+
+		s.NotZero(i.ID)
+		s.NotNil(i.RoleID)
+		s.WithinDuration(time.Now().UTC().Add(invitation.InvitationTTL), i.ExpiresAt, time.Minute)
+		s.Nil(i.AcceptedAt)
+		s.Nil(i.RevokedAt)
+	})
+}
+
+func (s *InvitationTestSuite) TestRepositoryIntegration_ListInvitations() {
+	s.Run("should list only the pending invitations including the expired ones", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(invitation.InvitationTTL)
+		expired, err := repo.CreateInvitation(ctx, invitation.Invitation{
+			OrganizationID: i.OrganizationID,
+			Email:          gofakeit.Email(),
+			InvitedBy:      i.InvitedBy,
+			TokenHash:      base.HashToken(gofakeit.UUID()),
+		}, -time.Hour)
+		s.Require().NoError(err)
+		revoked, err := repo.CreateInvitation(ctx, invitation.Invitation{
+			OrganizationID: i.OrganizationID,
+			Email:          gofakeit.Email(),
+			InvitedBy:      i.InvitedBy,
+			TokenHash:      base.HashToken(gofakeit.UUID()),
+		}, invitation.InvitationTTL)
+		s.Require().NoError(err)
+		s.Require().NoError(repo.RevokeInvitation(ctx, i.OrganizationID, revoked.ID))
+
+		result, err := repo.ListInvitations(ctx, i.OrganizationID)
+		s.Require().NoError(err)
+		s.Require().Len(result, 2)
+		s.Equal(i.ID, result[0].ID)
+		s.Equal(expired.ID, result[1].ID)
+		s.True(result[1].IsExpired())
+	})
+}
+
+func (s *InvitationTestSuite) TestRepositoryIntegration_GetPendingInvitationByEmail() {
+	s.Run("should not return an expired invitation", func() {
+		s.T().Parallel()
+
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(-time.Hour)
+
+		_, err := repo.GetPendingInvitationByEmail(context.Background(), i.OrganizationID, i.Email)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should return the pending invitation of the email", func() {
+		s.T().Parallel()
+
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(invitation.InvitationTTL)
+
+		result, err := repo.GetPendingInvitationByEmail(context.Background(), i.OrganizationID, i.Email)
+		s.Require().NoError(err)
+		s.Equal(i.ID, result.ID)
+	})
+}
+
+func (s *InvitationTestSuite) TestRepositoryIntegration_RenewInvitation() {
+	s.Run("should replace the token hash and extend the expiry of an expired invitation", func() {
+		s.T().Parallel()
+
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(-time.Hour)
+		tokenHash := base.HashToken(gofakeit.UUID())
+
+		result, err := repo.RenewInvitation(context.Background(), i.OrganizationID, i.ID, tokenHash,
+			invitation.InvitationTTL)
+		s.Require().NoError(err)
+		s.Equal(tokenHash, result.TokenHash)
+		s.False(result.IsExpired())
+	})
+
+	s.Run("should not renew an invitation of another organization", func() {
+		s.T().Parallel()
+
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(invitation.InvitationTTL)
+
+		_, err := repo.RenewInvitation(context.Background(), fake.NewOrganization(s.DB).ID, i.ID,
+			base.HashToken(gofakeit.UUID()), invitation.InvitationTTL)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *InvitationTestSuite) TestRepositoryIntegration_AcceptInvitation() {
+	s.Run("should accept the invitation only once", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(invitation.InvitationTTL)
+
+		result, err := repo.AcceptInvitation(ctx, i.TokenHash)
+		s.Require().NoError(err)
+		s.Equal(i.ID, result.ID)
+		s.NotNil(result.AcceptedAt)
+
+		_, err = repo.AcceptInvitation(ctx, i.TokenHash)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not accept an expired invitation", func() {
+		s.T().Parallel()
+
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(-time.Hour)
+
+		_, err := repo.AcceptInvitation(context.Background(), i.TokenHash)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not accept a revoked invitation", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := invitation.NewRepository(s.DB)
+		i := s.newInvitation(invitation.InvitationTTL)
+		s.Require().NoError(repo.RevokeInvitation(ctx, i.OrganizationID, i.ID))
+
+		_, err := repo.AcceptInvitation(ctx, i.TokenHash)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
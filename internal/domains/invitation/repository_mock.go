@@ -0,0 +1,435 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package invitation
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, tokenHash
+func (_m *MockRepository) AcceptInvitation(ctx context.Context, tokenHash string) (Invitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Invitation, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Invitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockRepository_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockRepository_Expecter) AcceptInvitation(ctx interface{}, tokenHash interface{}) *MockRepository_AcceptInvitation_Call {
+	return &MockRepository_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, tokenHash)}
+}
+
+func (_c *MockRepository_AcceptInvitation_Call) Run(run func(ctx context.Context, tokenHash string)) *MockRepository_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcceptInvitation_Call) Return(_a0 Invitation, _a1 error) *MockRepository_AcceptInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AcceptInvitation_Call) RunAndReturn(run func(context.Context, string) (Invitation, error)) *MockRepository_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, i, ttl
+func (_m *MockRepository) CreateInvitation(ctx context.Context, i Invitation, ttl time.Duration) (Invitation, error) {
+	ret := _m.Called(ctx, i, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Invitation, time.Duration) (Invitation, error)); ok {
+		return rf(ctx, i, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Invitation, time.Duration) Invitation); ok {
+		r0 = rf(ctx, i, ttl)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Invitation, time.Duration) error); ok {
+		r1 = rf(ctx, i, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockRepository_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - i Invitation
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) CreateInvitation(ctx interface{}, i interface{}, ttl interface{}) *MockRepository_CreateInvitation_Call {
+	return &MockRepository_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, i, ttl)}
+}
+
+func (_c *MockRepository_CreateInvitation_Call) Run(run func(ctx context.Context, i Invitation, ttl time.Duration)) *MockRepository_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Invitation), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateInvitation_Call) Return(_a0 Invitation, _a1 error) *MockRepository_CreateInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CreateInvitation_Call) RunAndReturn(run func(context.Context, Invitation, time.Duration) (Invitation, error)) *MockRepository_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitationByID provides a mock function with given fields: ctx, orgID, invitationID
+func (_m *MockRepository) GetInvitationByID(ctx context.Context, orgID int64, invitationID int64) (Invitation, error) {
+	ret := _m.Called(ctx, orgID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitationByID")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (Invitation, error)); ok {
+		return rf(ctx, orgID, invitationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) Invitation); ok {
+		r0 = rf(ctx, orgID, invitationID)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, orgID, invitationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetInvitationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitationByID'
+type MockRepository_GetInvitationByID_Call struct {
+	*mock.Call
+}
+
+// GetInvitationByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - invitationID int64
+func (_e *MockRepository_Expecter) GetInvitationByID(ctx interface{}, orgID interface{}, invitationID interface{}) *MockRepository_GetInvitationByID_Call {
+	return &MockRepository_GetInvitationByID_Call{Call: _e.mock.On("GetInvitationByID", ctx, orgID, invitationID)}
+}
+
+func (_c *MockRepository_GetInvitationByID_Call) Run(run func(ctx context.Context, orgID int64, invitationID int64)) *MockRepository_GetInvitationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetInvitationByID_Call) Return(_a0 Invitation, _a1 error) *MockRepository_GetInvitationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetInvitationByID_Call) RunAndReturn(run func(context.Context, int64, int64) (Invitation, error)) *MockRepository_GetInvitationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingInvitationByEmail provides a mock function with given fields: ctx, orgID, email
+func (_m *MockRepository) GetPendingInvitationByEmail(ctx context.Context, orgID int64, email string) (Invitation, error) {
+	ret := _m.Called(ctx, orgID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingInvitationByEmail")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (Invitation, error)); ok {
+		return rf(ctx, orgID, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) Invitation); ok {
+		r0 = rf(ctx, orgID, email)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, orgID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPendingInvitationByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingInvitationByEmail'
+type MockRepository_GetPendingInvitationByEmail_Call struct {
+	*mock.Call
+}
+
+// GetPendingInvitationByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - email string
+func (_e *MockRepository_Expecter) GetPendingInvitationByEmail(ctx interface{}, orgID interface{}, email interface{}) *MockRepository_GetPendingInvitationByEmail_Call {
+	return &MockRepository_GetPendingInvitationByEmail_Call{Call: _e.mock.On("GetPendingInvitationByEmail", ctx, orgID, email)}
+}
+
+func (_c *MockRepository_GetPendingInvitationByEmail_Call) Run(run func(ctx context.Context, orgID int64, email string)) *MockRepository_GetPendingInvitationByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPendingInvitationByEmail_Call) Return(_a0 Invitation, _a1 error) *MockRepository_GetPendingInvitationByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPendingInvitationByEmail_Call) RunAndReturn(run func(context.Context, int64, string) (Invitation, error)) *MockRepository_GetPendingInvitationByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvitations provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]Invitation, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []Invitation); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type MockRepository_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) ListInvitations(ctx interface{}, orgID interface{}) *MockRepository_ListInvitations_Call {
+	return &MockRepository_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx, orgID)}
+}
+
+func (_c *MockRepository_ListInvitations_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListInvitations_Call) Return(_a0 []Invitation, _a1 error) *MockRepository_ListInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListInvitations_Call) RunAndReturn(run func(context.Context, int64) ([]Invitation, error)) *MockRepository_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewInvitation provides a mock function with given fields: ctx, orgID, invitationID, tokenHash, ttl
+func (_m *MockRepository) RenewInvitation(ctx context.Context, orgID int64, invitationID int64, tokenHash string, ttl time.Duration) (Invitation, error) {
+	ret := _m.Called(ctx, orgID, invitationID, tokenHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewInvitation")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Duration) (Invitation, error)); ok {
+		return rf(ctx, orgID, invitationID, tokenHash, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, time.Duration) Invitation); ok {
+		r0 = rf(ctx, orgID, invitationID, tokenHash, ttl)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, time.Duration) error); ok {
+		r1 = rf(ctx, orgID, invitationID, tokenHash, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_RenewInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewInvitation'
+type MockRepository_RenewInvitation_Call struct {
+	*mock.Call
+}
+
+// RenewInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - invitationID int64
+//   - tokenHash string
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) RenewInvitation(ctx interface{}, orgID interface{}, invitationID interface{}, tokenHash interface{}, ttl interface{}) *MockRepository_RenewInvitation_Call {
+	return &MockRepository_RenewInvitation_Call{Call: _e.mock.On("RenewInvitation", ctx, orgID, invitationID, tokenHash, ttl)}
+}
+
+func (_c *MockRepository_RenewInvitation_Call) Run(run func(ctx context.Context, orgID int64, invitationID int64, tokenHash string, ttl time.Duration)) *MockRepository_RenewInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_RenewInvitation_Call) Return(_a0 Invitation, _a1 error) *MockRepository_RenewInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_RenewInvitation_Call) RunAndReturn(run func(context.Context, int64, int64, string, time.Duration) (Invitation, error)) *MockRepository_RenewInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, orgID, invitationID
+func (_m *MockRepository) RevokeInvitation(ctx context.Context, orgID int64, invitationID int64) error {
+	ret := _m.Called(ctx, orgID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, orgID, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type MockRepository_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - invitationID int64
+func (_e *MockRepository_Expecter) RevokeInvitation(ctx interface{}, orgID interface{}, invitationID interface{}) *MockRepository_RevokeInvitation_Call {
+	return &MockRepository_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", ctx, orgID, invitationID)}
+}
+
+func (_c *MockRepository_RevokeInvitation_Call) Run(run func(ctx context.Context, orgID int64, invitationID int64)) *MockRepository_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_RevokeInvitation_Call) Return(_a0 error) *MockRepository_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_RevokeInvitation_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
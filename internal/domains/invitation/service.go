@@ -0,0 +1,229 @@
+package invitation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+)
+
+type Service interface {
+	// ListInvitations returns the invitations of the organization that are neither accepted nor revoked.
+	// The expired invitations are included so that they can be resent.
+	ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error)
+
+	// CreateInvitation invites the email to join the organization and mails the invitation link.
+	// The actor can pre-assign only the roles the actor can assign to the users.
+	CreateInvitation(ctx context.Context, actorID, orgID int64, req CreateRequest) (Invitation, error)
+
+	// ResendInvitation renews the token and the expiry of a pending invitation and mails the new invitation link.
+	// The previously mailed link can no longer be used.
+	ResendInvitation(ctx context.Context, orgID, invitationID int64) (Invitation, error)
+
+	// RevokeInvitation revokes a pending invitation of the organization.
+	RevokeInvitation(ctx context.Context, orgID, invitationID int64) error
+
+	// AcceptInvitation creates the invited user with the given password and a verified email.
+	// The role of the invitation is assigned to the user when it is set.
+	AcceptInvitation(ctx context.Context, subdomain, token, password string) (user.User, error)
+}
+
+type service struct {
+	appURL      string
+	repo        Repository
+	transactor  database.Transactor
+	orgService  organization.Service
+	userService user.Service
+	roleService role.Service
+	mailer      mailer.Mailer
+}
+
+func NewService(
+	conf config.Config, repo Repository, transactor database.Transactor, orgService organization.Service,
+	userService user.Service, roleService role.Service, mailer mailer.Mailer,
+) Service {
+	return &service{
+		appURL:      conf.AppURL,
+		repo:        repo,
+		transactor:  transactor,
+		orgService:  orgService,
+		userService: userService,
+		roleService: roleService,
+		mailer:      mailer,
+	}
+}
+
+var (
+	ErrUserAlreadyExists = errors.New("user with the same email already exists")
+	ErrAlreadyInvited    = errors.New("email is already invited")
+	ErrInvalidToken      = errors.New("invitation token is invalid or expired")
+)
+
+func (s *service) ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error) {
+	return s.repo.ListInvitations(ctx, orgID)
+}
+
+func (s *service) CreateInvitation(ctx context.Context, actorID, orgID int64, req CreateRequest) (Invitation, error) {
+	if err := s.checkNotMember(ctx, orgID, req.Email); err != nil {
+		return Invitation{}, err
+	}
+
+	_, err := s.repo.GetPendingInvitationByEmail(ctx, orgID, req.Email)
+	if err == nil {
+		return Invitation{}, ErrAlreadyInvited
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return Invitation{}, err
+	}
+
+	if req.RoleID != nil {
+		if err := s.roleService.CheckAssignable(ctx, actorID, orgID, *req.RoleID); err != nil {
+			return Invitation{}, err
+		}
+	}
+
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	i, err := s.repo.CreateInvitation(ctx, Invitation{
+		OrganizationID: orgID,
+		Email:          req.Email,
+		RoleID:         req.RoleID,
+		InvitedBy:      actorID,
+		TokenHash:      base.HashToken(token),
+	}, InvitationTTL)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	if err := s.mailer.Send(ctx, invitationEmail(i.Email, s.appURL, org.Subdomain, org.Name, token)); err != nil {
+		return Invitation{}, err
+	}
+
+	return i, nil
+}
+
+func (s *service) ResendInvitation(ctx context.Context, orgID, invitationID int64) (Invitation, error) {
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	i, err := s.repo.RenewInvitation(ctx, orgID, invitationID, base.HashToken(token), InvitationTTL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Invitation{}, base.NewNotFoundError("invitation not found for the given id")
+		}
+
+		return Invitation{}, err
+	}
+
+	if err := s.mailer.Send(ctx, invitationEmail(i.Email, s.appURL, org.Subdomain, org.Name, token)); err != nil {
+		return Invitation{}, err
+	}
+
+	return i, nil
+}
+
+func (s *service) RevokeInvitation(ctx context.Context, orgID, invitationID int64) error {
+	if err := s.repo.RevokeInvitation(ctx, orgID, invitationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return base.NewNotFoundError("invitation not found for the given id")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) AcceptInvitation(ctx context.Context, subdomain, token, password string) (user.User, error) {
+	org, err := s.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	var u user.User
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// mark the invitation as accepted first so that concurrent requests can not use the same token
+		i, err := s.repo.AcceptInvitation(ctx, base.HashToken(token))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidToken
+			}
+
+			return err
+		}
+
+		// the invitation must belong to the requested organization
+		if i.OrganizationID != org.ID {
+			return ErrInvalidToken
+		}
+
+		if err := s.checkNotMember(ctx, org.ID, i.Email); err != nil {
+			return err
+		}
+
+		u, err = s.userService.CreateUser(ctx, org.ID, i.Email, password)
+		if err != nil {
+			return err
+		}
+
+		// the invitee proved the ownership of the email by opening the invitation link
+		if err := s.userService.SetEmailVerified(ctx, u.ID); err != nil {
+			return err
+		}
+
+		u.IsEmailVerified = true
+
+		if i.RoleID != nil {
+			if err := s.userService.SetRole(ctx, u.ID, *i.RoleID); err != nil {
+				return err
+			}
+
+			u.RoleID = *i.RoleID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return user.User{}, err
+	}
+
+	return u, nil
+}
+
+// checkNotMember returns ErrUserAlreadyExists when a user of the organization has the given email.
+func (s *service) checkNotMember(ctx context.Context, orgID int64, email string) error {
+	_, err := s.userService.GetUserByOrgIDEmail(ctx, orgID, email)
+	if err == nil {
+		return ErrUserAlreadyExists
+	}
+
+	if !base.IsNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package invitation
+
+import (
+	context "context"
+
+	user "github.com/camelhr/camelhr-api/internal/domains/user"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, subdomain, token, password
+func (_m *MockService) AcceptInvitation(ctx context.Context, subdomain string, token string, password string) (user.User, error) {
+	ret := _m.Called(ctx, subdomain, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 user.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (user.User, error)); ok {
+		return rf(ctx, subdomain, token, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) user.User); ok {
+		r0 = rf(ctx, subdomain, token, password)
+	} else {
+		r0 = ret.Get(0).(user.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, subdomain, token, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockService_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - token string
+//   - password string
+func (_e *MockService_Expecter) AcceptInvitation(ctx interface{}, subdomain interface{}, token interface{}, password interface{}) *MockService_AcceptInvitation_Call {
+	return &MockService_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, subdomain, token, password)}
+}
+
+func (_c *MockService_AcceptInvitation_Call) Run(run func(ctx context.Context, subdomain string, token string, password string)) *MockService_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_AcceptInvitation_Call) Return(_a0 user.User, _a1 error) *MockService_AcceptInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_AcceptInvitation_Call) RunAndReturn(run func(context.Context, string, string, string) (user.User, error)) *MockService_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, actorID, orgID, req
+func (_m *MockService) CreateInvitation(ctx context.Context, actorID int64, orgID int64, req CreateRequest) (Invitation, error) {
+	ret := _m.Called(ctx, actorID, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, CreateRequest) (Invitation, error)); ok {
+		return rf(ctx, actorID, orgID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, CreateRequest) Invitation); ok {
+		r0 = rf(ctx, actorID, orgID, req)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, CreateRequest) error); ok {
+		r1 = rf(ctx, actorID, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockService_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - req CreateRequest
+func (_e *MockService_Expecter) CreateInvitation(ctx interface{}, actorID interface{}, orgID interface{}, req interface{}) *MockService_CreateInvitation_Call {
+	return &MockService_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, actorID, orgID, req)}
+}
+
+func (_c *MockService_CreateInvitation_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, req CreateRequest)) *MockService_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(CreateRequest))
+	})
+	return _c
+}
+
+func (_c *MockService_CreateInvitation_Call) Return(_a0 Invitation, _a1 error) *MockService_CreateInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CreateInvitation_Call) RunAndReturn(run func(context.Context, int64, int64, CreateRequest) (Invitation, error)) *MockService_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInvitations provides a mock function with given fields: ctx, orgID
+func (_m *MockService) ListInvitations(ctx context.Context, orgID int64) ([]Invitation, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvitations")
+	}
+
+	var r0 []Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]Invitation, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []Invitation); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ListInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInvitations'
+type MockService_ListInvitations_Call struct {
+	*mock.Call
+}
+
+// ListInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) ListInvitations(ctx interface{}, orgID interface{}) *MockService_ListInvitations_Call {
+	return &MockService_ListInvitations_Call{Call: _e.mock.On("ListInvitations", ctx, orgID)}
+}
+
+func (_c *MockService_ListInvitations_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_ListInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ListInvitations_Call) Return(_a0 []Invitation, _a1 error) *MockService_ListInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ListInvitations_Call) RunAndReturn(run func(context.Context, int64) ([]Invitation, error)) *MockService_ListInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendInvitation provides a mock function with given fields: ctx, orgID, invitationID
+func (_m *MockService) ResendInvitation(ctx context.Context, orgID int64, invitationID int64) (Invitation, error) {
+	ret := _m.Called(ctx, orgID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendInvitation")
+	}
+
+	var r0 Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (Invitation, error)); ok {
+		return rf(ctx, orgID, invitationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) Invitation); ok {
+		r0 = rf(ctx, orgID, invitationID)
+	} else {
+		r0 = ret.Get(0).(Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, orgID, invitationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ResendInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendInvitation'
+type MockService_ResendInvitation_Call struct {
+	*mock.Call
+}
+
+// ResendInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - invitationID int64
+func (_e *MockService_Expecter) ResendInvitation(ctx interface{}, orgID interface{}, invitationID interface{}) *MockService_ResendInvitation_Call {
+	return &MockService_ResendInvitation_Call{Call: _e.mock.On("ResendInvitation", ctx, orgID, invitationID)}
+}
+
+func (_c *MockService_ResendInvitation_Call) Run(run func(ctx context.Context, orgID int64, invitationID int64)) *MockService_ResendInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ResendInvitation_Call) Return(_a0 Invitation, _a1 error) *MockService_ResendInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ResendInvitation_Call) RunAndReturn(run func(context.Context, int64, int64) (Invitation, error)) *MockService_ResendInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, orgID, invitationID
+func (_m *MockService) RevokeInvitation(ctx context.Context, orgID int64, invitationID int64) error {
+	ret := _m.Called(ctx, orgID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, orgID, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RevokeInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeInvitation'
+type MockService_RevokeInvitation_Call struct {
+	*mock.Call
+}
+
+// RevokeInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - invitationID int64
+func (_e *MockService_Expecter) RevokeInvitation(ctx interface{}, orgID interface{}, invitationID interface{}) *MockService_RevokeInvitation_Call {
+	return &MockService_RevokeInvitation_Call{Call: _e.mock.On("RevokeInvitation", ctx, orgID, invitationID)}
+}
+
+func (_c *MockService_RevokeInvitation_Call) Run(run func(ctx context.Context, orgID int64, invitationID int64)) *MockService_RevokeInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_RevokeInvitation_Call) Return(_a0 error) *MockService_RevokeInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RevokeInvitation_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_RevokeInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
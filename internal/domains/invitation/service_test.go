@@ -0,0 +1,271 @@
+package invitation_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/invitation"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const appURL = "https://camelhr.com"
+
+func TestService_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when a user of the organization has the email", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		req := invitation.CreateRequest{Email: gofakeit.Email()}
+		userService := user.NewMockService(t)
+		service := invitation.NewService(config.Config{}, nil, nil, nil, userService, nil, nil)
+
+		userService.On("GetUserByOrgIDEmail", ctx, orgID, req.Email).Return(user.User{ID: gofakeit.Int64()}, nil)
+
+		_, err := service.CreateInvitation(ctx, gofakeit.Int64(), orgID, req)
+		require.ErrorIs(t, err, invitation.ErrUserAlreadyExists)
+	})
+
+	t.Run("should return error when the email is already invited", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		req := invitation.CreateRequest{Email: gofakeit.Email()}
+		repo := invitation.NewMockRepository(t)
+		userService := user.NewMockService(t)
+		service := invitation.NewService(config.Config{}, repo, nil, nil, userService, nil, nil)
+
+		userService.On("GetUserByOrgIDEmail", ctx, orgID, req.Email).
+			Return(user.User{}, base.NewNotFoundError("user not found"))
+		repo.On("GetPendingInvitationByEmail", ctx, orgID, req.Email).
+			Return(invitation.Invitation{ID: gofakeit.Int64()}, nil)
+
+		_, err := service.CreateInvitation(ctx, gofakeit.Int64(), orgID, req)
+		require.ErrorIs(t, err, invitation.ErrAlreadyInvited)
+	})
+
+	t.Run("should return error when the actor can not assign the role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		req := invitation.CreateRequest{Email: gofakeit.Email(), RoleID: &roleID}
+		repo := invitation.NewMockRepository(t)
+		userService := user.NewMockService(t)
+		roleService := role.NewMockService(t)
+		service := invitation.NewService(config.Config{}, repo, nil, nil, userService, roleService, nil)
+
+		userService.On("GetUserByOrgIDEmail", ctx, orgID, req.Email).
+			Return(user.User{}, base.NewNotFoundError("user not found"))
+		repo.On("GetPendingInvitationByEmail", ctx, orgID, req.Email).Return(invitation.Invitation{}, sql.ErrNoRows)
+		roleService.On("CheckAssignable", ctx, actorID, orgID, roleID).Return(role.ErrPermissionNotGranted)
+
+		_, err := service.CreateInvitation(ctx, actorID, orgID, req)
+		require.ErrorIs(t, err, role.ErrPermissionNotGranted)
+	})
+
+	t.Run("should store the hash of the token and mail the invitation link", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", Name: "Camel"}
+		roleID := gofakeit.Int64()
+		req := invitation.CreateRequest{Email: gofakeit.Email(), RoleID: &roleID}
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		userService := user.NewMockService(t)
+		roleService := role.NewMockService(t)
+		mockMailer := mailer.NewMockMailer(t)
+		service := invitation.NewService(config.Config{AppURL: appURL}, repo, nil, orgService, userService,
+			roleService, mockMailer)
+
+		var stored invitation.Invitation
+
+		var msg mailer.Message
+
+		userService.On("GetUserByOrgIDEmail", ctx, org.ID, req.Email).
+			Return(user.User{}, base.NewNotFoundError("user not found"))
+		repo.On("GetPendingInvitationByEmail", ctx, org.ID, req.Email).Return(invitation.Invitation{}, sql.ErrNoRows)
+		roleService.On("CheckAssignable", ctx, actorID, org.ID, roleID).Return(nil)
+		orgService.On("GetOrganizationByID", ctx, org.ID).Return(org, nil)
+		repo.On("CreateInvitation", ctx, mock.AnythingOfType("invitation.Invitation"), invitation.InvitationTTL).
+			Run(func(args mock.Arguments) {
+				stored = args.Get(1).(invitation.Invitation) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(func(_ context.Context, i invitation.Invitation, _ time.Duration) invitation.Invitation { return i }, nil)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg = args.Get(1).(mailer.Message) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(nil)
+
+		result, err := service.CreateInvitation(ctx, actorID, org.ID, req)
+		require.NoError(t, err)
+		assert.Equal(t, req.Email, result.Email)
+		assert.Equal(t, org.ID, stored.OrganizationID)
+		assert.Equal(t, actorID, stored.InvitedBy)
+		assert.Equal(t, &roleID, stored.RoleID)
+		assert.Equal(t, req.Email, msg.To)
+		assert.Contains(t, msg.Body, appURL+"/accept-invitation?subdomain=camel&token=")
+
+		token := msg.Body[strings.Index(msg.Body, "token=")+len("token="):]
+		assert.Equal(t, base.HashToken(strings.TrimSpace(token)), stored.TokenHash)
+	})
+}
+
+func TestService_ResendInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the invitation is not pending", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", Name: "Camel"}
+		invitationID := gofakeit.Int64()
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		service := invitation.NewService(config.Config{}, repo, nil, orgService, nil, nil, nil)
+
+		orgService.On("GetOrganizationByID", ctx, org.ID).Return(org, nil)
+		repo.On("RenewInvitation", ctx, org.ID, invitationID, fake.MockString, invitation.InvitationTTL).
+			Return(invitation.Invitation{}, sql.ErrNoRows)
+
+		_, err := service.ResendInvitation(ctx, org.ID, invitationID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should renew the token and mail the new invitation link", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", Name: "Camel"}
+		i := invitation.Invitation{ID: gofakeit.Int64(), OrganizationID: org.ID, Email: gofakeit.Email()}
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		mockMailer := mailer.NewMockMailer(t)
+		service := invitation.NewService(config.Config{AppURL: appURL}, repo, nil, orgService, nil, nil, mockMailer)
+
+		orgService.On("GetOrganizationByID", ctx, org.ID).Return(org, nil)
+		repo.On("RenewInvitation", ctx, org.ID, i.ID, fake.MockString, invitation.InvitationTTL).Return(i, nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(msg mailer.Message) bool {
+			return msg.To == i.Email
+		})).Return(nil)
+
+		result, err := service.ResendInvitation(ctx, org.ID, i.ID)
+		require.NoError(t, err)
+		assert.Equal(t, i, result)
+	})
+}
+
+func TestService_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the invitation is not pending", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		invitationID := gofakeit.Int64()
+		repo := invitation.NewMockRepository(t)
+		service := invitation.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+
+		repo.On("RevokeInvitation", ctx, orgID, invitationID).Return(sql.ErrNoRows)
+
+		err := service.RevokeInvitation(ctx, orgID, invitationID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+}
+
+func TestService_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the token is invalid or expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel"}
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := invitation.NewService(config.Config{}, repo, transactor, orgService, nil, nil, nil)
+
+		orgService.On("GetOrganizationBySubdomain", ctx, org.Subdomain).Return(org, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptInvitation", ctx, base.HashToken("token")).Return(invitation.Invitation{}, sql.ErrNoRows)
+
+		_, err := service.AcceptInvitation(ctx, org.Subdomain, "token", "password")
+		require.ErrorIs(t, err, invitation.ErrInvalidToken)
+	})
+
+	t.Run("should return error when the invitation belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel"}
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := invitation.NewService(config.Config{}, repo, transactor, orgService, nil, nil, nil)
+
+		orgService.On("GetOrganizationBySubdomain", ctx, org.Subdomain).Return(org, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptInvitation", ctx, base.HashToken("token")).
+			Return(invitation.Invitation{OrganizationID: org.ID + 1}, nil)
+
+		_, err := service.AcceptInvitation(ctx, org.Subdomain, "token", "password")
+		require.ErrorIs(t, err, invitation.ErrInvalidToken)
+	})
+
+	t.Run("should create the user with a verified email and the role of the invitation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel"}
+		roleID := gofakeit.Int64()
+		i := invitation.Invitation{OrganizationID: org.ID, Email: gofakeit.Email(), RoleID: &roleID}
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: org.ID, Email: i.Email}
+		repo := invitation.NewMockRepository(t)
+		orgService := organization.NewMockService(t)
+		userService := user.NewMockService(t)
+		transactor := database.NewMockTransactor(t)
+		service := invitation.NewService(config.Config{}, repo, transactor, orgService, userService, nil, nil)
+
+		orgService.On("GetOrganizationBySubdomain", ctx, org.Subdomain).Return(org, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptInvitation", ctx, base.HashToken("token")).Return(i, nil)
+		userService.On("GetUserByOrgIDEmail", ctx, org.ID, i.Email).
+			Return(user.User{}, base.NewNotFoundError("user not found"))
+		userService.On("CreateUser", ctx, org.ID, i.Email, "password").Return(u, nil)
+		userService.On("SetEmailVerified", ctx, u.ID).Return(nil)
+		userService.On("SetRole", ctx, u.ID, roleID).Return(nil)
+
+		result, err := service.AcceptInvitation(ctx, org.Subdomain, "token", "password")
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, result.ID)
+		assert.True(t, result.IsEmailVerified)
+		assert.Equal(t, roleID, result.RoleID)
+	})
+}
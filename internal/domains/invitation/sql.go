@@ -0,0 +1,24 @@
+package invitation
+
+import _ "embed"
+
+//go:embed sql/list_invitations.sql
+var listInvitationsQuery string
+
+//go:embed sql/get_invitation_by_id.sql
+var getInvitationByIDQuery string
+
+//go:embed sql/get_pending_invitation_by_email.sql
+var getPendingInvitationByEmailQuery string
+
+//go:embed sql/create_invitation.sql
+var createInvitationQuery string
+
+//go:embed sql/renew_invitation.sql
+var renewInvitationQuery string
+
+//go:embed sql/revoke_invitation.sql
+var revokeInvitationQuery string
+
+//go:embed sql/accept_invitation.sql
+var acceptInvitationQuery string
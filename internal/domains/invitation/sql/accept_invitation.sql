@@ -0,0 +1,23 @@
+-- acceptInvitationQuery
+-- $1: token_hash
+UPDATE
+    invitations
+SET
+    accepted_at = NOW(),
+    updated_at = NOW()
+WHERE
+    token_hash = $1
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL
+    AND expires_at > NOW() RETURNING
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at;
@@ -0,0 +1,29 @@
+-- createInvitationQuery
+-- $1: organization_id
+-- $2: email
+-- $3: role_id
+-- $4: invited_by
+-- $5: token_hash
+-- $6: ttl in seconds
+INSERT INTO
+    invitations(
+        organization_id,
+        email,
+        role_id,
+        invited_by,
+        token_hash,
+        expires_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, NOW() + make_interval(secs => $6)) RETURNING
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at;
@@ -0,0 +1,20 @@
+-- getInvitationByIDQuery
+-- $1: organization_id
+-- $2: invitation_id
+SELECT
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at
+FROM
+    invitations
+WHERE
+    organization_id = $1
+    AND invitation_id = $2;
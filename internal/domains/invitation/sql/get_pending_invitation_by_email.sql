@@ -0,0 +1,27 @@
+-- getPendingInvitationByEmailQuery
+-- $1: organization_id
+-- $2: email
+SELECT
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at
+FROM
+    invitations
+WHERE
+    organization_id = $1
+    AND email = $2
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL
+    AND expires_at > NOW()
+ORDER BY
+    invitation_id DESC
+LIMIT
+    1;
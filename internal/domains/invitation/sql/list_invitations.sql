@@ -0,0 +1,22 @@
+-- listInvitationsQuery
+-- $1: organization_id
+SELECT
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at
+FROM
+    invitations
+WHERE
+    organization_id = $1
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL
+ORDER BY
+    invitation_id;
@@ -0,0 +1,27 @@
+-- renewInvitationQuery
+-- $1: organization_id
+-- $2: invitation_id
+-- $3: token_hash
+-- $4: ttl in seconds
+UPDATE
+    invitations
+SET
+    token_hash = $3,
+    expires_at = NOW() + make_interval(secs => $4),
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND invitation_id = $2
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL RETURNING
+    invitation_id,
+    organization_id,
+    email,
+    role_id,
+    invited_by,
+    token_hash,
+    expires_at,
+    accepted_at,
+    revoked_at,
+    created_at,
+    updated_at;
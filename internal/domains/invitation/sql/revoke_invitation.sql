@@ -0,0 +1,13 @@
+-- revokeInvitationQuery
+-- $1: organization_id
+-- $2: invitation_id
+UPDATE
+    invitations
+SET
+    revoked_at = NOW(),
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND invitation_id = $2
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL RETURNING invitation_id;
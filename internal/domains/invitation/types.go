@@ -0,0 +1,76 @@
+package invitation
+
+import "time"
+
+const (
+	// InvitationTTL is the time duration for which an invitation can be accepted.
+	// Resending an invitation renews its token and its expiry.
+	InvitationTTL = invitationTTLDays * 24 * time.Hour
+
+	invitationTTLDays = 7
+)
+
+// Invitation represents an invitation of a user to join an organization.
+type Invitation struct {
+	// ID is the unique identifier of the invitation.
+	ID int64 `db:"invitation_id"`
+
+	// OrganizationID is the reference to the organization the user is invited to.
+	OrganizationID int64 `db:"organization_id"`
+
+	// Email is the email address of the invitee.
+	Email string `db:"email"`
+
+	// RoleID is the reference to the role assigned to the user once the invitation is accepted.
+	// The default role of the users is assigned when it is nil.
+	RoleID *int64 `db:"role_id"`
+
+	// InvitedBy is the reference to the user who created the invitation.
+	InvitedBy int64 `db:"invited_by"`
+
+	// TokenHash is the sha256 hash of the invitation token. The plaintext token is only mailed to the invitee.
+	TokenHash string `db:"token_hash"`
+
+	// ExpiresAt is the timestamp after which the invitation can not be accepted.
+	ExpiresAt time.Time `db:"expires_at"`
+
+	// AcceptedAt is the timestamp when the invitee accepted the invitation.
+	AcceptedAt *time.Time `db:"accepted_at"`
+
+	// RevokedAt is the timestamp when the invitation was revoked.
+	RevokedAt *time.Time `db:"revoked_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// IsExpired returns true if the invitation can no longer be accepted unless it is resent.
+func (i Invitation) IsExpired() bool {
+	return time.Now().UTC().After(i.ExpiresAt)
+}
+
+type (
+	// CreateRequest represents the request payload to invite a user.
+	CreateRequest struct {
+		Email  string `json:"email" validate:"email,required"`
+		RoleID *int64 `json:"role_id"`
+	}
+
+	// AcceptRequest represents the request payload to accept an invitation.
+	AcceptRequest struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	// Response represents the response payload of a pending invitation.
+	Response struct {
+		ID        int64     `json:"id"`
+		Email     string    `json:"email"`
+		RoleID    *int64    `json:"role_id"`
+		InvitedBy int64     `json:"invited_by"`
+		ExpiresAt time.Time `json:"expires_at"`
+		IsExpired bool      `json:"is_expired"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
@@ -0,0 +1,149 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	accountFailuresKeyFormat = "loginFailures:org:%s:email:%s"
+	accountLockoutKeyFormat  = "loginLockout:org:%s:email:%s"
+	ipFailuresKeyFormat      = "loginFailures:ip:%s"
+	ipLockoutKeyFormat       = "loginLockout:ip:%s"
+)
+
+// LockoutManager is an interface for protecting the login against brute-force attacks.
+// The failed login attempts are counted per account (subdomain + email) and per client ip.
+// Once the failed attempts exceed the threshold, the account or the ip is locked temporarily.
+type LockoutManager interface {
+	// CheckLockout returns LockedError when either the account or the client ip is locked.
+	CheckLockout(ctx context.Context, subdomain, email, ip string) error
+
+	// RegisterFailedAttempt counts a failed login attempt for the account and the client ip.
+	// Every failed attempt beyond the threshold doubles the lockout duration up to the max duration.
+	RegisterFailedAttempt(ctx context.Context, subdomain, email, ip string) error
+
+	// Unlock clears the failed attempts and the lockout of the account.
+	// The failed attempts of the client ip are kept.
+	Unlock(ctx context.Context, subdomain, email string) error
+}
+
+type lockoutManager struct {
+	redisClient          *redis.Client
+	maxFailedAttempts    int64
+	maxIPFailedAttempts  int64
+	failedAttemptsWindow time.Duration
+	lockoutDuration      time.Duration
+	maxLockoutDuration   time.Duration
+}
+
+func NewRedisLockoutManager(redisClient *redis.Client, conf config.Config) LockoutManager {
+	return &lockoutManager{
+		redisClient:          redisClient,
+		maxFailedAttempts:    int64(conf.LoginMaxFailedAttempts),
+		maxIPFailedAttempts:  int64(conf.LoginIPMaxFailedAttempts),
+		failedAttemptsWindow: time.Duration(conf.LoginFailedAttemptsWindow) * time.Second,
+		lockoutDuration:      time.Duration(conf.LoginLockoutDuration) * time.Second,
+		maxLockoutDuration:   time.Duration(conf.LoginMaxLockoutDuration) * time.Second,
+	}
+}
+
+func (m *lockoutManager) CheckLockout(ctx context.Context, subdomain, email, ip string) error {
+	lockoutKeys := []string{fmt.Sprintf(accountLockoutKeyFormat, subdomain, strings.ToLower(email))}
+	if ip != "" {
+		lockoutKeys = append(lockoutKeys, fmt.Sprintf(ipLockoutKeyFormat, ip))
+	}
+
+	var retryAfter time.Duration
+
+	for _, key := range lockoutKeys {
+		ttl, err := m.redisClient.TTL(ctx, key).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get login lockout of %s: %w", key, err)
+		}
+
+		// the ttl is negative when the key does not exist
+		retryAfter = max(retryAfter, ttl)
+	}
+
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (m *lockoutManager) RegisterFailedAttempt(ctx context.Context, subdomain, email, ip string) error {
+	email = strings.ToLower(email)
+
+	err := m.registerFailure(ctx, fmt.Sprintf(accountFailuresKeyFormat, subdomain, email),
+		fmt.Sprintf(accountLockoutKeyFormat, subdomain, email), m.maxFailedAttempts)
+	if err != nil {
+		return err
+	}
+
+	if ip == "" {
+		return nil
+	}
+
+	return m.registerFailure(ctx, fmt.Sprintf(ipFailuresKeyFormat, ip),
+		fmt.Sprintf(ipLockoutKeyFormat, ip), m.maxIPFailedAttempts)
+}
+
+func (m *lockoutManager) Unlock(ctx context.Context, subdomain, email string) error {
+	email = strings.ToLower(email)
+
+	err := m.redisClient.Del(ctx, fmt.Sprintf(accountFailuresKeyFormat, subdomain, email),
+		fmt.Sprintf(accountLockoutKeyFormat, subdomain, email)).Err()
+	if err != nil {
+		return fmt.Errorf("failed to unlock login of %s in %s: %w", email, subdomain, err)
+	}
+
+	return nil
+}
+
+// registerFailure increments the failed attempts counter and locks when the threshold is reached.
+// A non-positive threshold disables the lockout.
+func (m *lockoutManager) registerFailure(ctx context.Context, failuresKey, lockoutKey string, maxAttempts int64) error {
+	if maxAttempts <= 0 {
+		return nil
+	}
+
+	failures, err := m.redisClient.Incr(ctx, failuresKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count failed login attempt of %s: %w", failuresKey, err)
+	}
+
+	ttl := m.failedAttemptsWindow
+
+	if failures >= maxAttempts {
+		lockoutDuration := m.backoff(failures - maxAttempts)
+		if err := m.redisClient.Set(ctx, lockoutKey, failures, lockoutDuration).Err(); err != nil {
+			return fmt.Errorf("failed to set login lockout of %s: %w", lockoutKey, err)
+		}
+
+		// keep the failed attempts while locked so that the next failure backs off further
+		ttl += lockoutDuration
+	}
+
+	if err := m.redisClient.Expire(ctx, failuresKey, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set failed login attempts expiry of %s: %w", failuresKey, err)
+	}
+
+	return nil
+}
+
+// backoff returns the lockout duration doubled n times and capped at the max lockout duration.
+func (m *lockoutManager) backoff(n int64) time.Duration {
+	d := m.lockoutDuration
+	for i := int64(0); i < n && d < m.maxLockoutDuration; i++ {
+		d *= 2
+	}
+
+	return min(d, m.maxLockoutDuration)
+}
@@ -0,0 +1,73 @@
+package lockout_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+)
+
+func (s *LockoutTestSuite) TestLockoutManagerIntegration_Account() {
+	s.Run("should lock the account after too many failed attempts and unlock it", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		lockoutManager := lockout.NewRedisLockoutManager(s.RedisClient, testConfig)
+
+		for range testConfig.LoginMaxFailedAttempts - 1 {
+			s.Require().NoError(lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ""))
+			s.Require().NoError(lockoutManager.CheckLockout(ctx, subdomain, email, ""))
+		}
+
+		// the last allowed failed attempt locks the account
+		s.Require().NoError(lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ""))
+
+		var lockedErr *lockout.LockedError
+		s.Require().ErrorAs(lockoutManager.CheckLockout(ctx, subdomain, email, ""), &lockedErr)
+		s.InDelta(time.Minute, lockedErr.RetryAfter, float64(time.Second))
+
+		// the email is matched case-insensitively
+		err := lockoutManager.CheckLockout(ctx, subdomain, strings.ToUpper(email), "")
+		s.Require().ErrorAs(err, &lockedErr)
+
+		// another failed attempt during the lockout backs off further
+		s.Require().NoError(lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ""))
+		s.Require().ErrorAs(lockoutManager.CheckLockout(ctx, subdomain, email, ""), &lockedErr)
+		s.InDelta(2*time.Minute, lockedErr.RetryAfter, float64(time.Second))
+
+		s.Require().NoError(lockoutManager.Unlock(ctx, subdomain, email))
+		s.Require().NoError(lockoutManager.CheckLockout(ctx, subdomain, email, ""))
+	})
+}
+
+func (s *LockoutTestSuite) TestLockoutManagerIntegration_IP() {
+	s.Run("should lock the ip after too many failed attempts across the accounts", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		ip := gofakeit.IPv4Address()
+		lockoutManager := lockout.NewRedisLockoutManager(s.RedisClient, testConfig)
+
+		for range testConfig.LoginIPMaxFailedAttempts {
+			s.Require().NoError(lockoutManager.RegisterFailedAttempt(ctx, subdomain, gofakeit.Email(), ip))
+		}
+
+		// any account is locked for the ip
+		var lockedErr *lockout.LockedError
+		s.Require().ErrorAs(lockoutManager.CheckLockout(ctx, subdomain, gofakeit.Email(), ip), &lockedErr)
+		s.Positive(lockedErr.RetryAfter)
+
+		// the ip is not unlocked along with the account
+		email := gofakeit.Email()
+		s.Require().NoError(lockoutManager.Unlock(ctx, subdomain, email))
+		s.Require().ErrorAs(lockoutManager.CheckLockout(ctx, subdomain, email, ip), &lockedErr)
+
+		// other ips are not affected
+		s.Require().NoError(lockoutManager.CheckLockout(ctx, subdomain, email, gofakeit.IPv4Address()))
+	})
+}
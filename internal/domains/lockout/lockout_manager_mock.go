@@ -0,0 +1,182 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package lockout
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLockoutManager is an autogenerated mock type for the LockoutManager type
+type MockLockoutManager struct {
+	mock.Mock
+}
+
+type MockLockoutManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLockoutManager) EXPECT() *MockLockoutManager_Expecter {
+	return &MockLockoutManager_Expecter{mock: &_m.Mock}
+}
+
+// CheckLockout provides a mock function with given fields: ctx, subdomain, email, ip
+func (_m *MockLockoutManager) CheckLockout(ctx context.Context, subdomain string, email string, ip string) error {
+	ret := _m.Called(ctx, subdomain, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLockout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, subdomain, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLockoutManager_CheckLockout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckLockout'
+type MockLockoutManager_CheckLockout_Call struct {
+	*mock.Call
+}
+
+// CheckLockout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+//   - ip string
+func (_e *MockLockoutManager_Expecter) CheckLockout(ctx interface{}, subdomain interface{}, email interface{}, ip interface{}) *MockLockoutManager_CheckLockout_Call {
+	return &MockLockoutManager_CheckLockout_Call{Call: _e.mock.On("CheckLockout", ctx, subdomain, email, ip)}
+}
+
+func (_c *MockLockoutManager_CheckLockout_Call) Run(run func(ctx context.Context, subdomain string, email string, ip string)) *MockLockoutManager_CheckLockout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockLockoutManager_CheckLockout_Call) Return(_a0 error) *MockLockoutManager_CheckLockout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLockoutManager_CheckLockout_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockLockoutManager_CheckLockout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterFailedAttempt provides a mock function with given fields: ctx, subdomain, email, ip
+func (_m *MockLockoutManager) RegisterFailedAttempt(ctx context.Context, subdomain string, email string, ip string) error {
+	ret := _m.Called(ctx, subdomain, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterFailedAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, subdomain, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLockoutManager_RegisterFailedAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterFailedAttempt'
+type MockLockoutManager_RegisterFailedAttempt_Call struct {
+	*mock.Call
+}
+
+// RegisterFailedAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+//   - ip string
+func (_e *MockLockoutManager_Expecter) RegisterFailedAttempt(ctx interface{}, subdomain interface{}, email interface{}, ip interface{}) *MockLockoutManager_RegisterFailedAttempt_Call {
+	return &MockLockoutManager_RegisterFailedAttempt_Call{Call: _e.mock.On("RegisterFailedAttempt", ctx, subdomain, email, ip)}
+}
+
+func (_c *MockLockoutManager_RegisterFailedAttempt_Call) Run(run func(ctx context.Context, subdomain string, email string, ip string)) *MockLockoutManager_RegisterFailedAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockLockoutManager_RegisterFailedAttempt_Call) Return(_a0 error) *MockLockoutManager_RegisterFailedAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLockoutManager_RegisterFailedAttempt_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockLockoutManager_RegisterFailedAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function with given fields: ctx, subdomain, email
+func (_m *MockLockoutManager) Unlock(ctx context.Context, subdomain string, email string) error {
+	ret := _m.Called(ctx, subdomain, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subdomain, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLockoutManager_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockLockoutManager_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - email string
+func (_e *MockLockoutManager_Expecter) Unlock(ctx interface{}, subdomain interface{}, email interface{}) *MockLockoutManager_Unlock_Call {
+	return &MockLockoutManager_Unlock_Call{Call: _e.mock.On("Unlock", ctx, subdomain, email)}
+}
+
+func (_c *MockLockoutManager_Unlock_Call) Run(run func(ctx context.Context, subdomain string, email string)) *MockLockoutManager_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockLockoutManager_Unlock_Call) Return(_a0 error) *MockLockoutManager_Unlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLockoutManager_Unlock_Call) RunAndReturn(run func(context.Context, string, string) error) *MockLockoutManager_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLockoutManager creates a new instance of MockLockoutManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLockoutManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLockoutManager {
+	mock := &MockLockoutManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
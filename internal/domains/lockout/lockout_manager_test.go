@@ -0,0 +1,237 @@
+package lockout_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig locks after 3 failed attempts of the account and 10 failed attempts of the ip.
+// The lockout starts at a minute and is capped at 5 minutes.
+var testConfig = config.Config{ //nolint:gochecknoglobals // test configuration
+	LoginMaxFailedAttempts:    3,
+	LoginIPMaxFailedAttempts:  10,
+	LoginFailedAttemptsWindow: 900,
+	LoginLockoutDuration:      60,
+	LoginMaxLockoutDuration:   300,
+}
+
+func TestLockoutManager_CheckLockout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectTTL(fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))).
+			SetErr(assert.AnError)
+
+		err := lockoutManager.CheckLockout(ctx, subdomain, email, "")
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return nil when neither the account nor the ip is locked", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		ip := gofakeit.IPv4Address()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectTTL(fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))).
+			SetVal(-2)
+		redisClientMock.ExpectTTL("loginLockout:ip:" + ip).SetVal(-2)
+
+		err := lockoutManager.CheckLockout(ctx, subdomain, email, ip)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should return the longest remaining lockout", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		ip := gofakeit.IPv4Address()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectTTL(fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))).
+			SetVal(time.Minute)
+		redisClientMock.ExpectTTL("loginLockout:ip:" + ip).SetVal(2 * time.Minute)
+
+		err := lockoutManager.CheckLockout(ctx, subdomain, email, ip)
+
+		var lockedErr *lockout.LockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.Equal(t, 2*time.Minute, lockedErr.RetryAfter)
+	})
+}
+
+func TestLockoutManager_RegisterFailedAttempt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when counting the failed attempt fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectIncr(fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email))).
+			SetErr(assert.AnError)
+
+		err := lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, "")
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should count the failed attempt without locking below the threshold", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		ip := gofakeit.IPv4Address()
+		failuresKey := fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectIncr(failuresKey).SetVal(2)
+		redisClientMock.ExpectExpire(failuresKey, 15*time.Minute).SetVal(true)
+		redisClientMock.ExpectIncr("loginFailures:ip:" + ip).SetVal(1)
+		redisClientMock.ExpectExpire("loginFailures:ip:"+ip, 15*time.Minute).SetVal(true)
+
+		err := lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, ip)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should lock the account when the threshold is reached", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		failuresKey := fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email))
+		lockoutKey := fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectIncr(failuresKey).SetVal(3)
+		redisClientMock.ExpectSet(lockoutKey, int64(3), time.Minute).SetVal("OK")
+		redisClientMock.ExpectExpire(failuresKey, 16*time.Minute).SetVal(true)
+
+		err := lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, "")
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should double the lockout duration for every further failed attempt", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		failuresKey := fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email))
+		lockoutKey := fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectIncr(failuresKey).SetVal(5)
+		redisClientMock.ExpectSet(lockoutKey, int64(5), 4*time.Minute).SetVal("OK")
+		redisClientMock.ExpectExpire(failuresKey, 19*time.Minute).SetVal(true)
+
+		err := lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, "")
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should cap the lockout duration at the max lockout duration", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		failuresKey := fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email))
+		lockoutKey := fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectIncr(failuresKey).SetVal(100)
+		redisClientMock.ExpectSet(lockoutKey, int64(100), 5*time.Minute).SetVal("OK")
+		redisClientMock.ExpectExpire(failuresKey, 20*time.Minute).SetVal(true)
+
+		err := lockoutManager.RegisterFailedAttempt(ctx, subdomain, email, "")
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should not count the failed attempts when the lockout is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, config.Config{})
+
+		err := lockoutManager.RegisterFailedAttempt(context.Background(), gofakeit.LetterN(30), gofakeit.Email(),
+			gofakeit.IPv4Address())
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestLockoutManager_Unlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email)),
+			fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))).SetErr(assert.AnError)
+
+		err := lockoutManager.Unlock(context.Background(), subdomain, email)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should clear the failed attempts and the lockout of the account", func(t *testing.T) {
+		t.Parallel()
+
+		subdomain := gofakeit.LetterN(30)
+		email := gofakeit.Email()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		lockoutManager := lockout.NewRedisLockoutManager(redisClient, testConfig)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("loginFailures:org:%s:email:%s", subdomain, strings.ToLower(email)),
+			fmt.Sprintf("loginLockout:org:%s:email:%s", subdomain, strings.ToLower(email))).SetVal(2)
+
+		err := lockoutManager.Unlock(context.Background(), subdomain, email)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,17 @@
+package lockout_test
+
+import (
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockoutTestSuite struct {
+	tests.IntegrationBaseSuite
+}
+
+func TestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LockoutTestSuite))
+}
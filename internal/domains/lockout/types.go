@@ -0,0 +1,16 @@
+package lockout
+
+import (
+	"time"
+)
+
+// LockedError is returned when the login is temporarily locked due to too many failed attempts.
+type LockedError struct {
+	// RetryAfter is the remaining duration of the lockout.
+	RetryAfter time.Duration
+}
+
+// Error returns the error message.
+func (e *LockedError) Error() string {
+	return "too many failed login attempts. try again later"
+}
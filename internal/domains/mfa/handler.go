@@ -0,0 +1,117 @@
+package mfa
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// Enroll starts the mfa enrollment of the authenticated user.
+// The response contains the secret and the provisioning uri to be rendered as qr code.
+func (h *handler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, _, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	enrollment, err := h.service.Enroll(r.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, enrollment)
+}
+
+// Activate activates the pending mfa enrollment of the authenticated user.
+// The response contains the recovery codes which are shown only once.
+func (h *handler) Activate(w http.ResponseWriter, r *http.Request) {
+	userID, _, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload CodeRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	codes, err := h.service.Activate(r.Context(), userID, reqPayload.Code)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable disables the mfa of the authenticated user.
+func (h *handler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID, _, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload CodeRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.Disable(r.Context(), userID, reqPayload.Code); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// SetOrganizationRequirement sets whether all the users of the organization must use mfa to login.
+func (h *handler) SetOrganizationRequirement(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload RequirementRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.SetOrganizationRequirement(r.Context(), orgID, reqPayload.Required); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// mapError sets the http status of the known mfa errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidCode), errors.Is(err, ErrNotEnrolled), errors.Is(err, ErrNotEnabled),
+		errors.Is(err, ErrAlreadyEnabled):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest))
+	case errors.Is(err, ErrRequiredByOrganization):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	default:
+		return err
+	}
+}
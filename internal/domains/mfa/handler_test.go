@@ -0,0 +1,256 @@
+package mfa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	enrollPath      = "/api/v1/subdomains/{subdomain}/auth/mfa/enroll"
+	activatePath    = "/api/v1/subdomains/{subdomain}/auth/mfa/activate"
+	disablePath     = "/api/v1/subdomains/{subdomain}/auth/mfa/disable"
+	requirementPath = "/api/v1/subdomains/{subdomain}/organizations/mfa-requirement"
+)
+
+func TestHandler_Enroll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when user-id is not found in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, enrollPath, nil)
+		require.NoError(t, err)
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// call the handler
+		handler.Enroll(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"user id not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request when mfa is already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, enrollPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Enroll", fake.MockContext, userID).Return(mfa.Enrollment{}, mfa.ErrAlreadyEnabled)
+
+		// call the handler
+		handler.Enroll(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"mfa is already enabled"}`, rr.Body.String())
+	})
+
+	t.Run("should return the enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, enrollPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Enroll", fake.MockContext, userID).
+			Return(mfa.Enrollment{Secret: "SECRET", URI: "otpauth://totp/CamelHR:user?secret=SECRET"}, nil)
+
+		// call the handler
+		handler.Enroll(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"secret":"SECRET","uri":"otpauth://totp/CamelHR:user?secret=SECRET"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_Activate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when code is missing", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, activatePath, strings.NewReader(`{"code":""}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// call the handler
+		handler.Activate(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"code is a required field"}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request when code is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, activatePath, strings.NewReader(`{"code":"123456"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Activate", fake.MockContext, userID, "123456").Return(nil, mfa.ErrInvalidCode)
+
+		// call the handler
+		handler.Activate(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"mfa code is invalid"}`, rr.Body.String())
+	})
+
+	t.Run("should return the recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, activatePath, strings.NewReader(`{"code":"123456"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Activate", fake.MockContext, userID, "123456").
+			Return([]string{"abcde-fghij", "klmno-pqrst"}, nil)
+
+		// call the handler
+		handler.Activate(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"recovery_codes":["abcde-fghij","klmno-pqrst"]}`, rr.Body.String())
+	})
+}
+
+func TestHandler_Disable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return forbidden when organization requires mfa", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, disablePath, strings.NewReader(`{"code":"123456"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Disable", fake.MockContext, userID, "123456").Return(mfa.ErrRequiredByOrganization)
+
+		// call the handler
+		handler.Disable(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		assert.JSONEq(t, `{"error":"mfa is required by the organization"}`, rr.Body.String())
+	})
+
+	t.Run("should disable mfa", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, disablePath, strings.NewReader(`{"code":"123456"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("Disable", fake.MockContext, userID, "123456").Return(nil)
+
+		// call the handler
+		handler.Disable(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestHandler_SetOrganizationRequirement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the org is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, requirementPath, strings.NewReader(`{"required":true}`))
+		require.NoError(t, err)
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// call the handler
+		handler.SetOrganizationRequirement(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should update the requirement", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, requirementPath, strings.NewReader(`{"required":true}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := mfa.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := mfa.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetOrganizationRequirement", fake.MockContext, orgID, true).Return(nil)
+
+		// call the handler
+		handler.SetOrganizationRequirement(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
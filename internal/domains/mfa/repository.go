@@ -0,0 +1,81 @@
+package mfa
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// GetUserMFA returns the mfa enrollment of the user.
+	GetUserMFA(ctx context.Context, userID int64) (UserMFA, error)
+
+	// UpsertUserMFASecret creates or replaces the pending mfa enrollment of the user.
+	// An activated enrollment is left unchanged.
+	UpsertUserMFASecret(ctx context.Context, userID int64, secret string) error
+
+	// EnableUserMFA activates the pending mfa enrollment of the user.
+	EnableUserMFA(ctx context.Context, userID int64, step int64) error
+
+	// DeleteUserMFA deletes the mfa enrollment of the user.
+	DeleteUserMFA(ctx context.Context, userID int64) error
+
+	// UseTOTPStep records the totp time step as used.
+	// It returns sql.ErrNoRows if the same or a later time step is already used.
+	UseTOTPStep(ctx context.Context, userID int64, step int64) error
+
+	// CreateRecoveryCode stores the hash of a recovery code for the user.
+	CreateRecoveryCode(ctx context.Context, userID int64, codeHash string) error
+
+	// DeleteRecoveryCodes deletes all the recovery codes of the user.
+	DeleteRecoveryCodes(ctx context.Context, userID int64) error
+
+	// UseRecoveryCode marks the recovery code as used.
+	// It returns sql.ErrNoRows if the code is not found or already used.
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetUserMFA(ctx context.Context, userID int64) (UserMFA, error) {
+	var m UserMFA
+	err := r.db.Get(ctx, &m, getUserMFAQuery, userID)
+
+	return m, err
+}
+
+func (r *repository) UpsertUserMFASecret(ctx context.Context, userID int64, secret string) error {
+	return r.db.Exec(ctx, nil, upsertUserMFASecretQuery, userID, secret)
+}
+
+func (r *repository) EnableUserMFA(ctx context.Context, userID int64, step int64) error {
+	return r.db.Exec(ctx, nil, enableUserMFAQuery, userID, step)
+}
+
+func (r *repository) DeleteUserMFA(ctx context.Context, userID int64) error {
+	return r.db.Exec(ctx, nil, deleteUserMFAQuery, userID)
+}
+
+func (r *repository) UseTOTPStep(ctx context.Context, userID int64, step int64) error {
+	var id int64
+	return r.db.Exec(ctx, &id, useTOTPStepQuery, userID, step)
+}
+
+func (r *repository) CreateRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	return r.db.Exec(ctx, nil, createRecoveryCodeQuery, userID, codeHash)
+}
+
+func (r *repository) DeleteRecoveryCodes(ctx context.Context, userID int64) error {
+	return r.db.Exec(ctx, nil, deleteRecoveryCodesQuery, userID)
+}
+
+func (r *repository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	var id int64
+	return r.db.Exec(ctx, &id, useRecoveryCodeQuery, userID, codeHash)
+}
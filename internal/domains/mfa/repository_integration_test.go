@@ -0,0 +1,86 @@
+package mfa_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *MFATestSuite) TestRepositoryIntegration_Enrollment() {
+	s.Run("should keep the enrollment pending until enabled", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := mfa.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := o.AddUser(s.DB)
+
+		err := repo.UpsertUserMFASecret(ctx, u.ID, "FIRSTSECRET")
+		s.Require().NoError(err)
+
+		// a pending enrollment is replaced
+		err = repo.UpsertUserMFASecret(ctx, u.ID, "SECONDSECRET")
+		s.Require().NoError(err)
+
+		m, err := repo.GetUserMFA(ctx, u.ID)
+		s.Require().NoError(err)
+		s.Equal("SECONDSECRET", m.Secret)
+		s.Nil(m.EnabledAt)
+
+		step := mfa.TimeStep(time.Now())
+		err = repo.EnableUserMFA(ctx, u.ID, step)
+		s.Require().NoError(err)
+
+		// an enabled enrollment is not replaced
+		err = repo.UpsertUserMFASecret(ctx, u.ID, "THIRDSECRET")
+		s.Require().NoError(err)
+
+		m, err = repo.GetUserMFA(ctx, u.ID)
+		s.Require().NoError(err)
+		s.Equal("SECONDSECRET", m.Secret)
+		s.NotNil(m.EnabledAt)
+		s.Require().NotNil(m.LastUsedStep)
+		s.Equal(step, *m.LastUsedStep)
+
+		// the same step can not be used again
+		err = repo.UseTOTPStep(ctx, u.ID, step)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		err = repo.UseTOTPStep(ctx, u.ID, step+1)
+		s.Require().NoError(err)
+
+		err = repo.DeleteUserMFA(ctx, u.ID)
+		s.Require().NoError(err)
+
+		_, err = repo.GetUserMFA(ctx, u.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *MFATestSuite) TestRepositoryIntegration_RecoveryCodes() {
+	s.Run("should use a recovery code only once", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := mfa.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := o.AddUser(s.DB)
+		codeHash := base.HashToken("abcdefghij")
+
+		err := repo.CreateRecoveryCode(ctx, u.ID, codeHash)
+		s.Require().NoError(err)
+
+		err = repo.UseRecoveryCode(ctx, u.ID, codeHash)
+		s.Require().NoError(err)
+
+		err = repo.UseRecoveryCode(ctx, u.ID, codeHash)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		err = repo.DeleteRecoveryCodes(ctx, u.ID)
+		s.Require().NoError(err)
+	})
+}
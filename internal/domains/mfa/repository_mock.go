@@ -0,0 +1,427 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mfa
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *MockRepository) CreateRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CreateRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecoveryCode'
+type MockRepository_CreateRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// CreateRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - codeHash string
+func (_e *MockRepository_Expecter) CreateRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *MockRepository_CreateRecoveryCode_Call {
+	return &MockRepository_CreateRecoveryCode_Call{Call: _e.mock.On("CreateRecoveryCode", ctx, userID, codeHash)}
+}
+
+func (_c *MockRepository_CreateRecoveryCode_Call) Run(run func(ctx context.Context, userID int64, codeHash string)) *MockRepository_CreateRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateRecoveryCode_Call) Return(_a0 error) *MockRepository_CreateRecoveryCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CreateRecoveryCode_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_CreateRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) DeleteRecoveryCodes(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecoveryCodes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRecoveryCodes'
+type MockRepository_DeleteRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// DeleteRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) DeleteRecoveryCodes(ctx interface{}, userID interface{}) *MockRepository_DeleteRecoveryCodes_Call {
+	return &MockRepository_DeleteRecoveryCodes_Call{Call: _e.mock.On("DeleteRecoveryCodes", ctx, userID)}
+}
+
+func (_c *MockRepository_DeleteRecoveryCodes_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_DeleteRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteRecoveryCodes_Call) Return(_a0 error) *MockRepository_DeleteRecoveryCodes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteRecoveryCodes_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_DeleteRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserMFA provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) DeleteUserMFA(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteUserMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserMFA'
+type MockRepository_DeleteUserMFA_Call struct {
+	*mock.Call
+}
+
+// DeleteUserMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) DeleteUserMFA(ctx interface{}, userID interface{}) *MockRepository_DeleteUserMFA_Call {
+	return &MockRepository_DeleteUserMFA_Call{Call: _e.mock.On("DeleteUserMFA", ctx, userID)}
+}
+
+func (_c *MockRepository_DeleteUserMFA_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_DeleteUserMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteUserMFA_Call) Return(_a0 error) *MockRepository_DeleteUserMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteUserMFA_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_DeleteUserMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableUserMFA provides a mock function with given fields: ctx, userID, step
+func (_m *MockRepository) EnableUserMFA(ctx context.Context, userID int64, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUserMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_EnableUserMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableUserMFA'
+type MockRepository_EnableUserMFA_Call struct {
+	*mock.Call
+}
+
+// EnableUserMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - step int64
+func (_e *MockRepository_Expecter) EnableUserMFA(ctx interface{}, userID interface{}, step interface{}) *MockRepository_EnableUserMFA_Call {
+	return &MockRepository_EnableUserMFA_Call{Call: _e.mock.On("EnableUserMFA", ctx, userID, step)}
+}
+
+func (_c *MockRepository_EnableUserMFA_Call) Run(run func(ctx context.Context, userID int64, step int64)) *MockRepository_EnableUserMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_EnableUserMFA_Call) Return(_a0 error) *MockRepository_EnableUserMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_EnableUserMFA_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_EnableUserMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserMFA provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) GetUserMFA(ctx context.Context, userID int64) (UserMFA, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMFA")
+	}
+
+	var r0 UserMFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (UserMFA, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) UserMFA); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(UserMFA)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetUserMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserMFA'
+type MockRepository_GetUserMFA_Call struct {
+	*mock.Call
+}
+
+// GetUserMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockRepository_Expecter) GetUserMFA(ctx interface{}, userID interface{}) *MockRepository_GetUserMFA_Call {
+	return &MockRepository_GetUserMFA_Call{Call: _e.mock.On("GetUserMFA", ctx, userID)}
+}
+
+func (_c *MockRepository_GetUserMFA_Call) Run(run func(ctx context.Context, userID int64)) *MockRepository_GetUserMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetUserMFA_Call) Return(_a0 UserMFA, _a1 error) *MockRepository_GetUserMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetUserMFA_Call) RunAndReturn(run func(context.Context, int64) (UserMFA, error)) *MockRepository_GetUserMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertUserMFASecret provides a mock function with given fields: ctx, userID, secret
+func (_m *MockRepository) UpsertUserMFASecret(ctx context.Context, userID int64, secret string) error {
+	ret := _m.Called(ctx, userID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertUserMFASecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpsertUserMFASecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUserMFASecret'
+type MockRepository_UpsertUserMFASecret_Call struct {
+	*mock.Call
+}
+
+// UpsertUserMFASecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - secret string
+func (_e *MockRepository_Expecter) UpsertUserMFASecret(ctx interface{}, userID interface{}, secret interface{}) *MockRepository_UpsertUserMFASecret_Call {
+	return &MockRepository_UpsertUserMFASecret_Call{Call: _e.mock.On("UpsertUserMFASecret", ctx, userID, secret)}
+}
+
+func (_c *MockRepository_UpsertUserMFASecret_Call) Run(run func(ctx context.Context, userID int64, secret string)) *MockRepository_UpsertUserMFASecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertUserMFASecret_Call) Return(_a0 error) *MockRepository_UpsertUserMFASecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpsertUserMFASecret_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_UpsertUserMFASecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *MockRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockRepository_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - codeHash string
+func (_e *MockRepository_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *MockRepository_UseRecoveryCode_Call {
+	return &MockRepository_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, codeHash)}
+}
+
+func (_c *MockRepository_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID int64, codeHash string)) *MockRepository_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UseRecoveryCode_Call) Return(_a0 error) *MockRepository_UseRecoveryCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTOTPStep provides a mock function with given fields: ctx, userID, step
+func (_m *MockRepository) UseTOTPStep(ctx context.Context, userID int64, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UseTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPStep'
+type MockRepository_UseTOTPStep_Call struct {
+	*mock.Call
+}
+
+// UseTOTPStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - step int64
+func (_e *MockRepository_Expecter) UseTOTPStep(ctx interface{}, userID interface{}, step interface{}) *MockRepository_UseTOTPStep_Call {
+	return &MockRepository_UseTOTPStep_Call{Call: _e.mock.On("UseTOTPStep", ctx, userID, step)}
+}
+
+func (_c *MockRepository_UseTOTPStep_Call) Run(run func(ctx context.Context, userID int64, step int64)) *MockRepository_UseTOTPStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UseTOTPStep_Call) Return(_a0 error) *MockRepository_UseTOTPStep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UseTOTPStep_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_UseTOTPStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
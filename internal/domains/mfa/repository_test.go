@@ -0,0 +1,222 @@
+package mfa_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetUserMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserMFAQuery"), int64(1)).
+			Return(assert.AnError)
+
+		_, err := repo.GetUserMFA(context.Background(), 1)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the mfa enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+		m := mfa.UserMFA{UserID: 1, Secret: gofakeit.LetterN(32)}
+
+		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserMFAQuery"), int64(1)).
+			Run(func(args mock.Arguments) {
+				// populate the passed argument with the enrollment
+				arg, ok := args.Get(1).(*mfa.UserMFA)
+				require.True(t, ok)
+				*arg = m
+			}).Return(nil)
+
+		result, err := repo.GetUserMFA(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, m, result)
+	})
+}
+
+func TestRepository_UpsertUserMFASecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("upsertUserMFASecretQuery"), int64(1), "SECRET").
+			Return(assert.AnError)
+
+		err := repo.UpsertUserMFASecret(context.Background(), 1, "SECRET")
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should store the secret", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("upsertUserMFASecretQuery"), int64(1), "SECRET").
+			Return(nil)
+
+		err := repo.UpsertUserMFASecret(context.Background(), 1, "SECRET")
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_EnableUserMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should enable the mfa enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("enableUserMFAQuery"), int64(1), int64(100)).
+			Return(nil)
+
+		err := repo.EnableUserMFA(context.Background(), 1, 100)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_DeleteUserMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the mfa enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("deleteUserMFAQuery"), int64(1)).
+			Return(nil)
+
+		err := repo.DeleteUserMFA(context.Background(), 1)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_UseTOTPStep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return no rows error when the step is already used", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useTOTPStepQuery"), int64(1), int64(100)).
+			Return(sql.ErrNoRows)
+
+		err := repo.UseTOTPStep(context.Background(), 1, 100)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("should record the step as used", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useTOTPStepQuery"), int64(1), int64(100)).
+			Return(nil)
+
+		err := repo.UseTOTPStep(context.Background(), 1, 100)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_CreateRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should store the recovery code hash", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("createRecoveryCodeQuery"), int64(1), "hash").
+			Return(nil)
+
+		err := repo.CreateRecoveryCode(context.Background(), 1, "hash")
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_DeleteRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("deleteRecoveryCodesQuery"), int64(1)).
+			Return(nil)
+
+		err := repo.DeleteRecoveryCodes(context.Background(), 1)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_UseRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return no rows error when the code is not usable", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useRecoveryCodeQuery"), int64(1), "hash").
+			Return(sql.ErrNoRows)
+
+		err := repo.UseRecoveryCode(context.Background(), 1, "hash")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("should mark the code as used", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := mfa.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), mock.Anything,
+			tests.QueryMatcher("useRecoveryCodeQuery"), int64(1), "hash").
+			Return(nil)
+
+		err := repo.UseRecoveryCode(context.Background(), 1, "hash")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,251 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+)
+
+type Service interface {
+	// GetUserMFA returns the mfa enrollment of the user.
+	GetUserMFA(ctx context.Context, userID int64) (UserMFA, error)
+
+	// IsEnabled returns whether the user has an activated mfa enrollment.
+	IsEnabled(ctx context.Context, userID int64) (bool, error)
+
+	// Enroll generates a new totp secret for the user.
+	// The enrollment stays pending until it is activated with a valid code.
+	Enroll(ctx context.Context, userID int64) (Enrollment, error)
+
+	// Activate activates the pending enrollment of the user and returns the one-time recovery codes.
+	// The recovery codes are stored hashed and can not be retrieved again.
+	Activate(ctx context.Context, userID int64, code string) ([]string, error)
+
+	// Disable removes the mfa enrollment and the recovery codes of the user.
+	// It is not allowed when the organization of the user requires mfa.
+	Disable(ctx context.Context, userID int64, code string) error
+
+	// Verify validates the totp code or an unused recovery code of the user.
+	// A code can be used only once.
+	Verify(ctx context.Context, userID int64, code string) error
+
+	// SetOrganizationRequirement sets whether all the users of the organization must use mfa to login.
+	SetOrganizationRequirement(ctx context.Context, orgID int64, required bool) error
+}
+
+type service struct {
+	repo        Repository
+	transactor  database.Transactor
+	orgService  organization.Service
+	userService user.Service
+}
+
+func NewService(
+	repo Repository, transactor database.Transactor, orgService organization.Service, userService user.Service,
+) Service {
+	return &service{repo, transactor, orgService, userService}
+}
+
+var (
+	ErrNotEnrolled            = errors.New("mfa enrollment not found")
+	ErrNotEnabled             = errors.New("mfa is not enabled")
+	ErrAlreadyEnabled         = errors.New("mfa is already enabled")
+	ErrInvalidCode            = errors.New("mfa code is invalid")
+	ErrRequiredByOrganization = errors.New("mfa is required by the organization")
+)
+
+func (s *service) GetUserMFA(ctx context.Context, userID int64) (UserMFA, error) {
+	m, err := s.repo.GetUserMFA(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserMFA{}, base.NewNotFoundError("mfa enrollment not found for the given user id")
+	}
+
+	return m, err
+}
+
+func (s *service) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	m, err := s.GetUserMFA(ctx, userID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return m.EnabledAt != nil, nil
+}
+
+func (s *service) Enroll(ctx context.Context, userID int64) (Enrollment, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	if enabled {
+		return Enrollment{}, ErrAlreadyEnabled
+	}
+
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	// a previous pending enrollment is replaced with the new secret
+	if err := s.repo.UpsertUserMFASecret(ctx, userID, secret); err != nil {
+		return Enrollment{}, err
+	}
+
+	return Enrollment{Secret: secret, URI: ProvisioningURI(Issuer, u.Email, secret)}, nil
+}
+
+func (s *service) Activate(ctx context.Context, userID int64, code string) ([]string, error) {
+	m, err := s.GetUserMFA(ctx, userID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return nil, ErrNotEnrolled
+		}
+
+		return nil, err
+	}
+
+	if m.EnabledAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	step, ok := ValidateCode(m.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// the activation code is recorded as used so that it can not be replayed for login
+		if err := s.repo.EnableUserMFA(ctx, userID, step); err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+
+		for _, c := range codes {
+			if err := s.repo.CreateRecoveryCode(ctx, userID, base.HashToken(normalizeRecoveryCode(c))); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *service) Disable(ctx context.Context, userID int64, code string) error {
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	org, err := s.orgService.GetOrganizationByID(ctx, u.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if org.MFARequired {
+		return ErrRequiredByOrganization
+	}
+
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	return s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+
+		return s.repo.DeleteUserMFA(ctx, userID)
+	})
+}
+
+func (s *service) Verify(ctx context.Context, userID int64, code string) error {
+	m, err := s.GetUserMFA(ctx, userID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return ErrNotEnabled
+		}
+
+		return err
+	}
+
+	if m.EnabledAt == nil {
+		return ErrNotEnabled
+	}
+
+	// totp codes are numeric. anything else is considered as a recovery code
+	if step, ok := ValidateCode(m.Secret, code, time.Now()); ok {
+		err = s.repo.UseTOTPStep(ctx, userID, step)
+	} else {
+		err = s.repo.UseRecoveryCode(ctx, userID, base.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidCode
+	}
+
+	return err
+}
+
+func (s *service) SetOrganizationRequirement(ctx context.Context, orgID int64, required bool) error {
+	return s.orgService.SetMFARequired(ctx, orgID, required)
+}
+
+// generateRecoveryCodes returns random recovery codes in xxxxx-xxxxx format.
+func generateRecoveryCodes() ([]string, error) {
+	const (
+		codeBytes  = 7 // encodes to 12 base32 characters of which 10 are used
+		codeLength = 10
+	)
+
+	codes := make([]string, 0, RecoveryCodeCount)
+
+	for range RecoveryCodeCount {
+		b := make([]byte, codeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		c := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:codeLength]
+		codes = append(codes, c[:codeLength/2]+"-"+c[codeLength/2:])
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode strips the separators and the letter case from the recovery code
+// so that the code is accepted however the user types it.
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+}
@@ -0,0 +1,411 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package mfa
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Activate provides a mock function with given fields: ctx, userID, code
+func (_m *MockService) Activate(ctx context.Context, userID int64, code string) ([]string, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]string, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []string); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Activate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Activate'
+type MockService_Activate_Call struct {
+	*mock.Call
+}
+
+// Activate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - code string
+func (_e *MockService_Expecter) Activate(ctx interface{}, userID interface{}, code interface{}) *MockService_Activate_Call {
+	return &MockService_Activate_Call{Call: _e.mock.On("Activate", ctx, userID, code)}
+}
+
+func (_c *MockService_Activate_Call) Run(run func(ctx context.Context, userID int64, code string)) *MockService_Activate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Activate_Call) Return(_a0 []string, _a1 error) *MockService_Activate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Activate_Call) RunAndReturn(run func(context.Context, int64, string) ([]string, error)) *MockService_Activate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Disable provides a mock function with given fields: ctx, userID, code
+func (_m *MockService) Disable(ctx context.Context, userID int64, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
+type MockService_Disable_Call struct {
+	*mock.Call
+}
+
+// Disable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - code string
+func (_e *MockService_Expecter) Disable(ctx interface{}, userID interface{}, code interface{}) *MockService_Disable_Call {
+	return &MockService_Disable_Call{Call: _e.mock.On("Disable", ctx, userID, code)}
+}
+
+func (_c *MockService_Disable_Call) Run(run func(ctx context.Context, userID int64, code string)) *MockService_Disable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Disable_Call) Return(_a0 error) *MockService_Disable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_Disable_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockService_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enroll provides a mock function with given fields: ctx, userID
+func (_m *MockService) Enroll(ctx context.Context, userID int64) (Enrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 Enrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Enrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Enrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(Enrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Enroll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enroll'
+type MockService_Enroll_Call struct {
+	*mock.Call
+}
+
+// Enroll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockService_Expecter) Enroll(ctx interface{}, userID interface{}) *MockService_Enroll_Call {
+	return &MockService_Enroll_Call{Call: _e.mock.On("Enroll", ctx, userID)}
+}
+
+func (_c *MockService_Enroll_Call) Run(run func(ctx context.Context, userID int64)) *MockService_Enroll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_Enroll_Call) Return(_a0 Enrollment, _a1 error) *MockService_Enroll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Enroll_Call) RunAndReturn(run func(context.Context, int64) (Enrollment, error)) *MockService_Enroll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserMFA provides a mock function with given fields: ctx, userID
+func (_m *MockService) GetUserMFA(ctx context.Context, userID int64) (UserMFA, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMFA")
+	}
+
+	var r0 UserMFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (UserMFA, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) UserMFA); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(UserMFA)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetUserMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserMFA'
+type MockService_GetUserMFA_Call struct {
+	*mock.Call
+}
+
+// GetUserMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockService_Expecter) GetUserMFA(ctx interface{}, userID interface{}) *MockService_GetUserMFA_Call {
+	return &MockService_GetUserMFA_Call{Call: _e.mock.On("GetUserMFA", ctx, userID)}
+}
+
+func (_c *MockService_GetUserMFA_Call) Run(run func(ctx context.Context, userID int64)) *MockService_GetUserMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetUserMFA_Call) Return(_a0 UserMFA, _a1 error) *MockService_GetUserMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetUserMFA_Call) RunAndReturn(run func(context.Context, int64) (UserMFA, error)) *MockService_GetUserMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsEnabled provides a mock function with given fields: ctx, userID
+func (_m *MockService) IsEnabled(ctx context.Context, userID int64) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEnabled")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_IsEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEnabled'
+type MockService_IsEnabled_Call struct {
+	*mock.Call
+}
+
+// IsEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *MockService_Expecter) IsEnabled(ctx interface{}, userID interface{}) *MockService_IsEnabled_Call {
+	return &MockService_IsEnabled_Call{Call: _e.mock.On("IsEnabled", ctx, userID)}
+}
+
+func (_c *MockService_IsEnabled_Call) Run(run func(ctx context.Context, userID int64)) *MockService_IsEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_IsEnabled_Call) Return(_a0 bool, _a1 error) *MockService_IsEnabled_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_IsEnabled_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockService_IsEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOrganizationRequirement provides a mock function with given fields: ctx, orgID, required
+func (_m *MockService) SetOrganizationRequirement(ctx context.Context, orgID int64, required bool) error {
+	ret := _m.Called(ctx, orgID, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOrganizationRequirement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, orgID, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetOrganizationRequirement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOrganizationRequirement'
+type MockService_SetOrganizationRequirement_Call struct {
+	*mock.Call
+}
+
+// SetOrganizationRequirement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - required bool
+func (_e *MockService_Expecter) SetOrganizationRequirement(ctx interface{}, orgID interface{}, required interface{}) *MockService_SetOrganizationRequirement_Call {
+	return &MockService_SetOrganizationRequirement_Call{Call: _e.mock.On("SetOrganizationRequirement", ctx, orgID, required)}
+}
+
+func (_c *MockService_SetOrganizationRequirement_Call) Run(run func(ctx context.Context, orgID int64, required bool)) *MockService_SetOrganizationRequirement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SetOrganizationRequirement_Call) Return(_a0 error) *MockService_SetOrganizationRequirement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetOrganizationRequirement_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockService_SetOrganizationRequirement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx, userID, code
+func (_m *MockService) Verify(ctx context.Context, userID int64, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockService_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - code string
+func (_e *MockService_Expecter) Verify(ctx interface{}, userID interface{}, code interface{}) *MockService_Verify_Call {
+	return &MockService_Verify_Call{Call: _e.mock.On("Verify", ctx, userID, code)}
+}
+
+func (_c *MockService_Verify_Call) Run(run func(ctx context.Context, userID int64, code string)) *MockService_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Verify_Call) Return(_a0 error) *MockService_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_Verify_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockService_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
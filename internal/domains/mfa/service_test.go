@@ -0,0 +1,350 @@
+package mfa_test
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_IsEnabled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return false when enrollment is not found", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).Return(mfa.UserMFA{}, sql.ErrNoRows)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		enabled, err := service.IsEnabled(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("should return false when enrollment is pending", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).Return(mfa.UserMFA{UserID: 1}, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		enabled, err := service.IsEnabled(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("should return true when enrollment is activated", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, EnabledAt: &now}, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		enabled, err := service.IsEnabled(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, enabled)
+	})
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).Return(mfa.UserMFA{}, assert.AnError)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		_, err := service.IsEnabled(context.Background(), 1)
+
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestService_Enroll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when mfa is already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, EnabledAt: &now}, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		_, err := service.Enroll(context.Background(), 1)
+
+		require.ErrorIs(t, err, mfa.ErrAlreadyEnabled)
+	})
+
+	t.Run("should store a new secret and return the provisioning uri", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), Email: gofakeit.Email()}
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", ctx, u.ID).Return(mfa.UserMFA{}, sql.ErrNoRows)
+		mockRepo.On("UpsertUserMFASecret", ctx, u.ID, mock.AnythingOfType("string")).Return(nil)
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, userService)
+		enrollment, err := service.Enroll(ctx, u.ID)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, enrollment.Secret)
+		assert.Equal(t, mfa.ProvisioningURI(mfa.Issuer, u.Email, enrollment.Secret), enrollment.URI)
+		mockRepo.AssertCalled(t, "UpsertUserMFASecret", ctx, u.ID, enrollment.Secret)
+	})
+}
+
+func TestService_Activate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when not enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).Return(mfa.UserMFA{}, sql.ErrNoRows)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		_, err := service.Activate(context.Background(), 1, "123456")
+
+		require.ErrorIs(t, err, mfa.ErrNotEnrolled)
+	})
+
+	t.Run("should return an error when code is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, Secret: secret}, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		_, err = service.Activate(context.Background(), 1, "abcdef")
+
+		require.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("should activate the enrollment and store the recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		step := mfa.TimeStep(time.Now())
+		code, err := mfa.GenerateCode(secret, step)
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", ctx, int64(1)).Return(mfa.UserMFA{UserID: 1, Secret: secret}, nil)
+		mockRepo.On("EnableUserMFA", ctx, int64(1), mock.AnythingOfType("int64")).Return(nil)
+		mockRepo.On("DeleteRecoveryCodes", ctx, int64(1)).Return(nil)
+		mockRepo.On("CreateRecoveryCode", ctx, int64(1), mock.AnythingOfType("string")).
+			Return(nil).Times(mfa.RecoveryCodeCount)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		service := mfa.NewService(mockRepo, transactor, nil, nil)
+		codes, err := service.Activate(ctx, 1, code)
+
+		require.NoError(t, err)
+		require.Len(t, codes, mfa.RecoveryCodeCount)
+
+		for _, c := range codes {
+			assert.Regexp(t, regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`), c)
+			// the stored hash ignores the separator
+			mockRepo.AssertCalled(t, "CreateRecoveryCode", ctx, int64(1), base.HashToken(c[:5]+c[6:]))
+		}
+	})
+}
+
+func TestService_Verify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("should return an error when mfa is not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).Return(mfa.UserMFA{UserID: 1}, nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		err := service.Verify(context.Background(), 1, "123456")
+
+		require.ErrorIs(t, err, mfa.ErrNotEnabled)
+	})
+
+	t.Run("should return an error when totp code is replayed", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		code, err := mfa.GenerateCode(secret, mfa.TimeStep(time.Now()))
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, Secret: secret, EnabledAt: &now}, nil)
+		mockRepo.On("UseTOTPStep", context.Background(), int64(1), mock.AnythingOfType("int64")).
+			Return(sql.ErrNoRows)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		err = service.Verify(context.Background(), 1, code)
+
+		require.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+
+	t.Run("should accept a valid totp code", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		code, err := mfa.GenerateCode(secret, mfa.TimeStep(time.Now()))
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, Secret: secret, EnabledAt: &now}, nil)
+		mockRepo.On("UseTOTPStep", context.Background(), int64(1), mock.AnythingOfType("int64")).
+			Return(nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		err = service.Verify(context.Background(), 1, code)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should accept an unused recovery code regardless of the format", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, Secret: secret, EnabledAt: &now}, nil)
+		mockRepo.On("UseRecoveryCode", context.Background(), int64(1), base.HashToken("abcdefghij")).
+			Return(nil)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		err = service.Verify(context.Background(), 1, "ABCDE-FGHIJ")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should return an error when recovery code is already used", func(t *testing.T) {
+		t.Parallel()
+
+		secret, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", context.Background(), int64(1)).
+			Return(mfa.UserMFA{UserID: 1, Secret: secret, EnabledAt: &now}, nil)
+		mockRepo.On("UseRecoveryCode", context.Background(), int64(1), base.HashToken("abcdefghij")).
+			Return(sql.ErrNoRows)
+
+		service := mfa.NewService(mockRepo, nil, nil, nil)
+		err = service.Verify(context.Background(), 1, "abcde-fghij")
+
+		require.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+}
+
+func TestService_Disable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when organization requires mfa", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, u.OrganizationID).
+			Return(organization.Organization{ID: u.OrganizationID, MFARequired: true}, nil)
+
+		service := mfa.NewService(nil, nil, orgService, userService)
+		err := service.Disable(ctx, u.ID, "123456")
+
+		require.ErrorIs(t, err, mfa.ErrRequiredByOrganization)
+	})
+
+	t.Run("should delete the enrollment and the recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		now := time.Now()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+
+		userService := user.NewMockService(t)
+		userService.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		orgService := organization.NewMockService(t)
+		orgService.On("GetOrganizationByID", ctx, u.OrganizationID).
+			Return(organization.Organization{ID: u.OrganizationID}, nil)
+
+		mockRepo := mfa.NewMockRepository(t)
+		mockRepo.On("GetUserMFA", ctx, u.ID).Return(mfa.UserMFA{UserID: u.ID, EnabledAt: &now}, nil)
+		mockRepo.On("UseRecoveryCode", ctx, u.ID, base.HashToken("abcdefghij")).Return(nil)
+		mockRepo.On("DeleteRecoveryCodes", ctx, u.ID).Return(nil)
+		mockRepo.On("DeleteUserMFA", ctx, u.ID).Return(nil)
+
+		transactor := database.NewMockTransactor(t)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+
+		service := mfa.NewService(mockRepo, transactor, orgService, userService)
+		err := service.Disable(ctx, u.ID, "abcde-fghij")
+
+		require.NoError(t, err)
+	})
+}
+
+func TestService_SetOrganizationRequirement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should update the organization requirement", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+
+		orgService := organization.NewMockService(t)
+		orgService.On("SetMFARequired", ctx, orgID, true).Return(nil)
+
+		service := mfa.NewService(nil, nil, orgService, nil)
+		err := service.SetOrganizationRequirement(ctx, orgID, true)
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,27 @@
+package mfa
+
+import _ "embed"
+
+//go:embed sql/get_user_mfa.sql
+var getUserMFAQuery string
+
+//go:embed sql/upsert_user_mfa_secret.sql
+var upsertUserMFASecretQuery string
+
+//go:embed sql/enable_user_mfa.sql
+var enableUserMFAQuery string
+
+//go:embed sql/delete_user_mfa.sql
+var deleteUserMFAQuery string
+
+//go:embed sql/use_totp_step.sql
+var useTOTPStepQuery string
+
+//go:embed sql/create_recovery_code.sql
+var createRecoveryCodeQuery string
+
+//go:embed sql/delete_recovery_codes.sql
+var deleteRecoveryCodesQuery string
+
+//go:embed sql/use_recovery_code.sql
+var useRecoveryCodeQuery string
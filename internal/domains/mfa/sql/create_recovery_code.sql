@@ -0,0 +1,7 @@
+-- createRecoveryCodeQuery
+-- $1: user_id
+-- $2: code_hash
+INSERT INTO
+    mfa_recovery_codes(user_id, code_hash)
+VALUES
+    ($1, $2);
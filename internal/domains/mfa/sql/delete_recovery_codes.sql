@@ -0,0 +1,6 @@
+-- deleteRecoveryCodesQuery
+-- $1: user_id
+DELETE FROM
+    mfa_recovery_codes
+WHERE
+    user_id = $1;
@@ -0,0 +1,6 @@
+-- deleteUserMFAQuery
+-- $1: user_id
+DELETE FROM
+    user_mfa
+WHERE
+    user_id = $1;
@@ -0,0 +1,12 @@
+-- enableUserMFAQuery
+-- $1: user_id
+-- $2: last_used_step
+UPDATE
+    user_mfa
+SET
+    enabled_at = NOW(),
+    last_used_step = $2,
+    updated_at = NOW()
+WHERE
+    user_id = $1
+    AND enabled_at IS NULL;
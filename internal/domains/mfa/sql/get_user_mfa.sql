@@ -0,0 +1,13 @@
+-- getUserMFAQuery
+-- $1: user_id
+SELECT
+    user_id,
+    secret,
+    enabled_at,
+    last_used_step,
+    created_at,
+    updated_at
+FROM
+    user_mfa
+WHERE
+    user_id = $1;
@@ -0,0 +1,15 @@
+-- upsertUserMFASecretQuery
+-- $1: user_id
+-- $2: secret
+INSERT INTO
+    user_mfa(user_id, secret)
+VALUES
+    ($1, $2) ON CONFLICT (user_id) DO
+UPDATE
+SET
+    secret = EXCLUDED.secret,
+    enabled_at = NULL,
+    last_used_step = NULL,
+    updated_at = NOW()
+WHERE
+    user_mfa.enabled_at IS NULL;
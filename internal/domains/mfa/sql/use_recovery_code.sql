@@ -0,0 +1,12 @@
+-- useRecoveryCodeQuery
+-- $1: user_id
+-- $2: code_hash
+UPDATE
+    mfa_recovery_codes
+SET
+    used_at = NOW()
+WHERE
+    user_id = $1
+    AND code_hash = $2
+    AND used_at IS NULL RETURNING
+    mfa_recovery_code_id;
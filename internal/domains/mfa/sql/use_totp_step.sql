@@ -0,0 +1,16 @@
+-- useTOTPStepQuery
+-- $1: user_id
+-- $2: step
+UPDATE
+    user_mfa
+SET
+    last_used_step = $2,
+    updated_at = NOW()
+WHERE
+    user_id = $1
+    AND enabled_at IS NOT NULL
+    AND (
+        last_used_step IS NULL
+        OR last_used_step < $2
+    ) RETURNING
+    user_id;
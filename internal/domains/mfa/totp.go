@@ -0,0 +1,99 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // rfc 6238 uses hmac-sha1 by default which is supported by all authenticator apps
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits      = 6
+	totpModulo      = 1_000_000 // 10^totpDigits
+	totpPeriod      = 30        // seconds
+	totpSkew        = 1         // number of time steps accepted before and after the current one
+	totpSecretSize  = 20        // bytes
+	totpCounterSize = 8         // bytes
+	totpOffsetMask  = 0x0f
+	totpValueMask   = 0x7fffffff
+)
+
+// GenerateSecret returns a random base32 encoded totp secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, totpSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// ProvisioningURI returns the otpauth uri of the secret as specified by the key uri format.
+func ProvisioningURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", strconv.Itoa(totpDigits))
+	v.Set("period", strconv.Itoa(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(account), v.Encode())
+}
+
+// TimeStep returns the totp time step of the given time.
+func TimeStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// GenerateCode returns the totp code of the secret for the given time step.
+func GenerateCode(secret string, step int64) (string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, totpCounterSize)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation as specified by rfc 4226
+	offset := sum[len(sum)-1] & totpOffsetMask
+	value := binary.BigEndian.Uint32(sum[offset:]) & totpValueMask
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo), nil
+}
+
+// ValidateCode checks the code against the time steps around the given time.
+// It returns the matched time step so that the caller can prevent its reuse.
+func ValidateCode(secret, code string, t time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := TimeStep(t)
+
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,126 @@
+package mfa_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the base32 encoding of the sha1 seed "12345678901234567890" used by the rfc 6238 test vectors.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should generate the rfc 6238 test vector codes", func(t *testing.T) {
+		t.Parallel()
+
+		// the rfc lists 8 digit codes. the 6 digit codes are their last 6 digits
+		vectors := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for unix, expected := range vectors {
+			code, err := mfa.GenerateCode(rfcSecret, mfa.TimeStep(time.Unix(unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, expected, code, "time %d", unix)
+		}
+	})
+
+	t.Run("should return error when secret is not base32", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := mfa.GenerateCode("not-base32!", 1)
+		require.Error(t, err)
+	})
+}
+
+func TestValidateCode(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1111111109, 0)
+
+	t.Run("should accept the code of the current time step", func(t *testing.T) {
+		t.Parallel()
+
+		step, ok := mfa.ValidateCode(rfcSecret, "081804", now)
+		assert.True(t, ok)
+		assert.Equal(t, mfa.TimeStep(now), step)
+	})
+
+	t.Run("should accept the code of the adjacent time steps", func(t *testing.T) {
+		t.Parallel()
+
+		previous, err := mfa.GenerateCode(rfcSecret, mfa.TimeStep(now)-1)
+		require.NoError(t, err)
+
+		step, ok := mfa.ValidateCode(rfcSecret, previous, now)
+		assert.True(t, ok)
+		assert.Equal(t, mfa.TimeStep(now)-1, step)
+	})
+
+	t.Run("should reject the code outside the allowed skew", func(t *testing.T) {
+		t.Parallel()
+
+		old, err := mfa.GenerateCode(rfcSecret, mfa.TimeStep(now)-2)
+		require.NoError(t, err)
+
+		_, ok := mfa.ValidateCode(rfcSecret, old, now)
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject the code with invalid length", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := mfa.ValidateCode(rfcSecret, "81804", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should generate unique secrets usable for code generation", func(t *testing.T) {
+		t.Parallel()
+
+		s1, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		s2, err := mfa.GenerateSecret()
+		require.NoError(t, err)
+
+		assert.NotEqual(t, s1, s2)
+		assert.Len(t, s1, 32)
+
+		_, err = mfa.GenerateCode(s1, mfa.TimeStep(time.Now()))
+		require.NoError(t, err)
+	})
+}
+
+func TestProvisioningURI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the otpauth uri", func(t *testing.T) {
+		t.Parallel()
+
+		uri := mfa.ProvisioningURI(mfa.Issuer, "john@example.com", rfcSecret)
+		require.True(t, strings.HasPrefix(uri, "otpauth://totp/CamelHR:john@example.com?"))
+
+		parsed, err := url.Parse(uri)
+		require.NoError(t, err)
+		assert.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+		assert.Equal(t, mfa.Issuer, parsed.Query().Get("issuer"))
+		assert.Equal(t, "6", parsed.Query().Get("digits"))
+		assert.Equal(t, "30", parsed.Query().Get("period"))
+	})
+}
@@ -0,0 +1,55 @@
+package mfa
+
+import "time"
+
+const (
+	// Issuer is the issuer name shown in the authenticator apps.
+	Issuer = "CamelHR"
+
+	// RecoveryCodeCount is the number of recovery codes generated upon activation.
+	RecoveryCodeCount = 10
+)
+
+// UserMFA represents the totp enrollment of a user.
+type UserMFA struct {
+	// UserID is the reference to the user the enrollment belongs to.
+	UserID int64 `db:"user_id"`
+
+	// Secret is the base32 encoded totp secret.
+	Secret string `db:"secret"`
+
+	// EnabledAt is the timestamp when the enrollment was activated.
+	// The enrollment is pending until it is activated.
+	EnabledAt *time.Time `db:"enabled_at"`
+
+	// LastUsedStep is the most recent totp time step used by the user.
+	// It is used to prevent the replay of a code within its validity window.
+	LastUsedStep *int64 `db:"last_used_step"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Enrollment represents a pending totp enrollment.
+type Enrollment struct {
+	// Secret is the base32 encoded totp secret for manual entry.
+	Secret string `json:"secret"`
+
+	// URI is the otpauth provisioning uri to be rendered as qr code.
+	URI string `json:"uri"`
+}
+
+// CodeRequest represents the request payload containing a totp or recovery code.
+type CodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// RecoveryCodesResponse represents the response payload containing the recovery codes.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RequirementRequest represents the request payload to update the mfa requirement of the organization.
+type RequirementRequest struct {
+	Required bool `json:"required"`
+}
@@ -0,0 +1,25 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/web/request"
+)
+
+// NewContext returns a copy of the context carrying the organization the request is made to.
+func NewContext(ctx context.Context, org Organization) context.Context {
+	return context.WithValue(ctx, request.CtxOrganizationKey, org)
+}
+
+// FromRequest returns the organization the request is made to.
+// The organization is resolved from the request and set in the request context by the tenant middleware.
+func FromRequest(r *http.Request) (Organization, error) {
+	org, ok := r.Context().Value(request.CtxOrganizationKey).(Organization)
+	if !ok {
+		return Organization{}, fmt.Errorf("organization not found in the request context: %w", request.ErrInvalidContext)
+	}
+
+	return org, nil
+}
@@ -1,8 +1,10 @@
 package organization
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/web/request"
 	"github.com/camelhr/camelhr-api/internal/web/response"
 )
@@ -16,26 +18,37 @@ func NewHandler(service Service) *handler {
 }
 
 func (h *handler) GetOrganizationBySubdomain(w http.ResponseWriter, r *http.Request) {
-	subdomain := request.URLParam(r, "subdomain")
-	if err := ValidateSubdomain(subdomain); err != nil {
-		response.ErrorResponse(w, err)
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
 		return
 	}
 
-	org, err := h.service.GetOrganizationBySubdomain(r.Context(), subdomain)
+	resp := h.toResponse(org)
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// CheckSubdomainAvailability responds whether the subdomain of the path can be used by a new organization
+// along with the suggested alternatives when it is not available.
+func (h *handler) CheckSubdomainAvailability(w http.ResponseWriter, r *http.Request) {
+	a, err := h.service.CheckSubdomainAvailability(r.Context(), request.URLParam(r, "subdomain"))
 	if err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
 
-	resp := h.toResponse(org)
-	response.JSON(w, http.StatusOK, resp)
+	response.JSON(w, http.StatusOK, AvailabilityResponse{
+		Subdomain:   a.Subdomain,
+		Available:   a.Available,
+		Reason:      a.Reason,
+		Suggestions: a.Suggestions,
+	})
 }
 
 func (h *handler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
-	subdomain := request.URLParam(r, "subdomain")
-	if err := ValidateSubdomain(subdomain); err != nil {
-		response.ErrorResponse(w, err)
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
 		return
 	}
 
@@ -46,33 +59,57 @@ func (h *handler) UpdateOrganization(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	org, err := h.service.GetOrganizationBySubdomain(r.Context(), subdomain)
-	if err != nil {
+	if err := h.service.UpdateOrganization(r.Context(), org.ID, reqPayload.Name); err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
 
-	if err := h.service.UpdateOrganization(r.Context(), org.ID, reqPayload.Name); err != nil {
+	response.Empty(w, http.StatusOK)
+}
+
+// ChangeSubdomain changes the subdomain of the organization and responds with the updated organization.
+// The requests to the former subdomain are redirected to the new subdomain until the alias expires.
+// The sessions are kept. Their access tokens are reissued with the new subdomain once refreshed.
+func (h *handler) ChangeSubdomain(w http.ResponseWriter, r *http.Request) {
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ChangeSubdomainRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
 
-	response.Empty(w, http.StatusOK)
-}
+	if err := h.service.ChangeSubdomain(r.Context(), org.ID, reqPayload.Subdomain); err != nil {
+		if errors.Is(err, ErrSubdomainUnavailable) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict)))
+			return
+		}
 
-func (h *handler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
-	subdomain := request.URLParam(r, "subdomain")
-	if err := ValidateSubdomain(subdomain); err != nil {
 		response.ErrorResponse(w, err)
+
 		return
 	}
 
-	org, err := h.service.GetOrganizationBySubdomain(r.Context(), subdomain)
+	org, err = h.service.GetOrganizationByID(r.Context(), org.ID)
 	if err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
 
+	response.JSON(w, http.StatusOK, h.toResponse(org))
+}
+
+func (h *handler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
 	var reqPayload DeleteRequest
 	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
 		response.ErrorResponse(w, err)
@@ -93,12 +130,60 @@ func (h *handler) DeleteOrganization(w http.ResponseWriter, r *http.Request) {
 	response.Empty(w, http.StatusOK)
 }
 
+// SetMagicLinkLogin enables or disables the login of the users of the organization using a link mailed to them.
+func (h *handler) SetMagicLinkLogin(w http.ResponseWriter, r *http.Request) {
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload MagicLinkRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.SetMagicLinkEnabled(r.Context(), org.ID, reqPayload.Enabled); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// SetImpersonation allows or denies the impersonation of the users of the organization by the platform operators.
+// Denying it ends the impersonation sessions in progress as well.
+func (h *handler) SetImpersonation(w http.ResponseWriter, r *http.Request) {
+	org, err := FromRequest(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ImpersonationRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.SetImpersonationAllowed(r.Context(), org.ID, reqPayload.Allowed); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
 func (h *handler) toResponse(org Organization) *Response {
 	return &Response{
-		ID:          org.ID,
-		Subdomain:   org.Subdomain,
-		Name:        org.Name,
-		SuspendedAt: org.SuspendedAt,
-		Timestamps:  org.Timestamps,
+		ID:                   org.ID,
+		Subdomain:            org.Subdomain,
+		Name:                 org.Name,
+		SuspendedAt:          org.SuspendedAt,
+		MFARequired:          org.MFARequired,
+		MagicLinkEnabled:     org.MagicLinkEnabled,
+		ImpersonationAllowed: org.ImpersonationAllowed,
+		Timestamps:           org.Timestamps,
 	}
 }
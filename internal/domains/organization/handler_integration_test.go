@@ -35,7 +35,7 @@ func (s *OrganizationTestSuite) TestHandlerIntegration_GetOrganizationBySubdomai
 		s.Require().NoError(err)
 
 		rr := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(rr, req)
 
 		// assert the response
@@ -61,7 +61,8 @@ func (s *OrganizationTestSuite) TestHandlerIntegration_UpdateOrganization() {
 		s.T().Parallel()
 
 		fakeOrg := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, fakeOrg.Organization.ID)
+		u := fake.NewUser(s.DB, fakeOrg.Organization.ID, fake.UserIsOwner())
+		apiToken := fake.NewAPIToken(s.DB, u.ID)
 		updatePayload := organization.UpdateRequest{
 			Name: randomOrganizationName(),
 		}
@@ -75,10 +76,10 @@ func (s *OrganizationTestSuite) TestHandlerIntegration_UpdateOrganization() {
 			bytes.NewReader(orgJSON),
 		)
 		s.Require().NoError(err)
-		req.SetBasicAuth(*u.APIToken, auth.APITokenBasicAuthPassword)
+		req.SetBasicAuth(apiToken.Token, auth.APITokenBasicAuthPassword)
 
 		rr := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(rr, req)
 
 		// assert the response status code
@@ -97,7 +98,8 @@ func (s *OrganizationTestSuite) TestHandlerIntegration_DeleteOrganization() {
 		s.T().Parallel()
 
 		fakeOrg := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, fakeOrg.Organization.ID)
+		u := fake.NewUser(s.DB, fakeOrg.Organization.ID, fake.UserIsOwner())
+		apiToken := fake.NewAPIToken(s.DB, u.ID)
 
 		deletePayload := organization.DeleteRequest{
 			Comment: gofakeit.Sentence(5),
@@ -112,10 +114,10 @@ func (s *OrganizationTestSuite) TestHandlerIntegration_DeleteOrganization() {
 			bytes.NewReader(deleteJSON),
 		)
 		s.Require().NoError(err)
-		req.SetBasicAuth(*u.APIToken, auth.APITokenBasicAuthPassword)
+		req.SetBasicAuth(apiToken.Token, auth.APITokenBasicAuthPassword)
 
 		rr := httptest.NewRecorder()
-		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config)
+		h := web.SetupRoutes(s.DB, s.RedisClient, s.Config, s.JWTKeys)
 		h.ServeHTTP(rr, req)
 
 		// assert the response status code
@@ -134,6 +136,7 @@ func toOrganizationResponse(org organization.Organization) organization.Response
 		Subdomain:   org.Subdomain,
 		Name:        org.Name,
 		SuspendedAt: org.SuspendedAt,
+		MFARequired: org.MFARequired,
 		Timestamps:  org.Timestamps,
 	}
 }
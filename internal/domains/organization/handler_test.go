@@ -1,7 +1,6 @@
 package organization_test
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +11,7 @@ import (
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
-	"github.com/go-chi/chi/v5"
+	"github.com/camelhr/camelhr-api/internal/tests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +20,10 @@ const (
 	getOrganizationBySubdomainPath = "/api/v1/subdomains/{subdomain}/organizations"
 	updateOrganizationPath         = "/api/v1/subdomains/{subdomain}/organizations"
 	deleteOrganizationPath         = "/api/v1/subdomains/{subdomain}/organizations"
+	setMagicLinkLoginPath          = "/api/v1/subdomains/{subdomain}/organizations/magic-link"
+	setImpersonationPath           = "/api/v1/subdomains/{subdomain}/organizations/impersonation"
+	changeSubdomainPath            = "/api/v1/subdomains/{subdomain}/organizations/subdomain"
+	subdomainAvailabilityPath      = "/api/v1/subdomains/{subdomain}/availability"
 )
 
 func TestHandler_GetOrganizationBySubdomain(t *testing.T) {
@@ -41,22 +44,17 @@ func TestHandler_GetOrganizationBySubdomain(t *testing.T) {
 				UpdatedAt: time.Now().UTC(),
 			},
 		}
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", org.Subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		expectedBody := fmt.Sprintf(`{"id": %d, "subdomain": "%s", "name": "%s",
-		"suspended_at": null, "created_at": "%s", "updated_at": "%s"}`,
+		"suspended_at": null, "mfa_required": false, "magic_link_enabled": false, "impersonation_allowed": false,
+		"created_at": "%s", "updated_at": "%s"}`,
 			org.ID, org.Subdomain, org.Name, org.CreatedAt.Format(time.RFC3339Nano), org.UpdatedAt.Format(time.RFC3339Nano))
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the GetOrganizationBySubdomain function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), org.Subdomain).
-			Return(org, nil)
-
 		// call the GetOrganizationBySubdomain function
 		handler.GetOrganizationBySubdomain(rr, req)
 
@@ -65,59 +63,22 @@ func TestHandler_GetOrganizationBySubdomain(t *testing.T) {
 		assert.JSONEq(t, expectedBody, rr.Body.String())
 	})
 
-	t.Run("should return an error when the organization is not found", func(t *testing.T) {
+	t.Run("should return bad request when the organization is not in the request context", func(t *testing.T) {
 		t.Parallel()
 
-		// create a new request with a URL parameter
-		req, err := http.NewRequest(http.MethodGet, getOrganizationBySubdomainPath, nil)
-		require.NoError(t, err)
-
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", "sub1")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
-
-		mockService := organization.NewMockService(t)
-		rr := httptest.NewRecorder()
-		handler := organization.NewHandler(mockService)
-
-		// mock the GetOrganizationBySubdomain function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), "sub1").
-			Return(organization.Organization{}, base.NewNotFoundError("organization not found for the given subdomain"))
-
-		// call the GetOrganizationBySubdomain function
-		handler.GetOrganizationBySubdomain(rr, req)
-
-		// check the result
-		require.Equal(t, http.StatusNotFound, rr.Code)
-		assert.JSONEq(t, `{"error": "organization not found for the given subdomain"}`, rr.Body.String())
-	})
-
-	t.Run("should return an error when the service call fails", func(t *testing.T) {
-		t.Parallel()
-		// create a new request with a URL parameter
 		req, err := http.NewRequest(http.MethodGet, getOrganizationBySubdomainPath, nil)
 		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", "sub1")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
-
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the GetOrganizationBySubdomain function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), "sub1").
-			Return(organization.Organization{}, assert.AnError)
-
 		// call the GetOrganizationBySubdomain function
 		handler.GetOrganizationBySubdomain(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.JSONEq(t, `{"error": ""}`, rr.Body.String())
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "organization not found in the request context: invalid context"}`, rr.Body.String())
 	})
 }
 
@@ -138,17 +99,14 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 		req, err := http.NewRequest(http.MethodPut, updateOrganizationPath, strings.NewReader(payload))
 		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), currentOrg))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("GetOrganizationBySubdomain", req.Context(), subdomain).Return(currentOrg, nil)
 		mockService.On("UpdateOrganization", req.Context(), currentOrg.ID, newOrgName).Return(nil)
 
 		// call the UpdateOrganization function
@@ -159,64 +117,23 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 		assert.Empty(t, rr.Body.String())
 	})
 
-	t.Run("should return an error when get service call fails", func(t *testing.T) {
+	t.Run("should return bad request when the organization is not in the request context", func(t *testing.T) {
 		t.Parallel()
 
-		subdomain := randomOrganizationSubdomain()
-		// create a new request with a JSON payload
-		payload := fmt.Sprintf(`{"name": "%s"}`, randomOrganizationName())
-		req, err := http.NewRequest(http.MethodPut, updateOrganizationPath, strings.NewReader(payload))
+		req, err := http.NewRequest(http.MethodPut, updateOrganizationPath,
+			strings.NewReader(`{"name": "test org pvt ltd."}`))
 		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
-
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the service calls
-		mockService.On("GetOrganizationBySubdomain", req.Context(), subdomain).
-			Return(organization.Organization{}, assert.AnError)
-
 		// call the UpdateOrganization function
 		handler.UpdateOrganization(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.JSONEq(t, `{"error": ""}`, rr.Body.String())
-	})
-
-	t.Run("should return an error when the organization is not found", func(t *testing.T) {
-		t.Parallel()
-
-		subdomain := randomOrganizationSubdomain()
-		// create a new request with a JSON payload
-		payload := fmt.Sprintf(`{"name": "%s"}`, randomOrganizationName())
-		req, err := http.NewRequest(http.MethodPut, updateOrganizationPath, strings.NewReader(payload))
-		require.NoError(t, err)
-
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
-
-		mockService := organization.NewMockService(t)
-		rr := httptest.NewRecorder()
-		handler := organization.NewHandler(mockService)
-
-		// mock the service calls
-		mockService.On("GetOrganizationBySubdomain", req.Context(), subdomain).
-			Return(organization.Organization{}, base.NewNotFoundError("organization not found for the given subdomain"))
-
-		// call the UpdateOrganization function
-		handler.UpdateOrganization(rr, req)
-
-		// check the result
-		require.Equal(t, http.StatusNotFound, rr.Code)
-		assert.JSONEq(t, `{"error": "organization not found for the given subdomain"}`, rr.Body.String())
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "organization not found in the request context: invalid context"}`, rr.Body.String())
 	})
 
 	t.Run("should return an error when update service call fails", func(t *testing.T) {
@@ -233,17 +150,14 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 		req, err := http.NewRequest(http.MethodPut, updateOrganizationPath, strings.NewReader(payload))
 		require.NoError(t, err)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), currentOrg))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
 		// mock the service calls
-		mockService.On("GetOrganizationBySubdomain", req.Context(), subdomain).Return(currentOrg, nil)
 		mockService.On("UpdateOrganization", req.Context(), currentOrg.ID, newOrgName).Return(assert.AnError)
 
 		// call the UpdateOrganization function
@@ -258,46 +172,24 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 		t.Parallel()
 
 		tests := []struct {
-			testName  string
-			payload   string
-			subdomain string
-			err       string
+			testName string
+			payload  string
+			err      string
 		}{
 			{
-				testName:  "subdomain is missing",
-				payload:   `{"name": "test org pvt ltd."}`,
-				subdomain: "",
-				err:       `{"error": "subdomain is required"}`,
-			},
-			{
-				testName:  "name is missing",
-				payload:   `{}`,
-				subdomain: "sub1",
-				err:       `{"error": "name is a required field"}`,
-			},
-			{
-				testName:  "subdomain is too long",
-				payload:   `{"name": "test org pvt ltd."}`,
-				subdomain: gofakeit.LetterN(31),
-				err:       `{"error": "subdomain must be a maximum of 30 characters in length"}`,
-			},
-			{
-				testName:  "name is too long",
-				payload:   fmt.Sprintf(`{"name": "%s"}`, gofakeit.LetterN(61)),
-				subdomain: "sub1",
-				err:       `{"error": "name must be a maximum of 60 characters in length"}`,
+				testName: "name is missing",
+				payload:  `{}`,
+				err:      `{"error": "name is a required field"}`,
 			},
 			{
-				testName:  "subdomain is not alphanumeric",
-				payload:   `{"name": "test org pvt ltd."}`,
-				subdomain: "sub1!",
-				err:       `{"error": "subdomain can only contain alphanumeric characters"}`,
+				testName: "name is too long",
+				payload:  fmt.Sprintf(`{"name": "%s"}`, gofakeit.LetterN(61)),
+				err:      `{"error": "name must be a maximum of 60 characters in length"}`,
 			},
 			{
-				testName:  "name contains non ascii characters",
-				payload:   `{"name": "€€"}`,
-				subdomain: "sub1",
-				err:       `{"error": "name must contain only ascii characters"}`,
+				testName: "name contains non ascii characters",
+				payload:  `{"name": "€€"}`,
+				err:      `{"error": "name must contain only ascii characters"}`,
 			},
 		}
 
@@ -305,7 +197,6 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 			// avoid loop closure issue by defining the variables here
 			payload := tt.payload
 			errResponse := tt.err
-			subdomain := tt.subdomain
 
 			t.Run(tt.testName, func(t *testing.T) {
 				t.Parallel()
@@ -313,10 +204,8 @@ func TestHandler_UpdateOrganization(t *testing.T) {
 				req, err := http.NewRequest(http.MethodPut, updateOrganizationPath, strings.NewReader(payload))
 				require.NoError(t, err)
 
-				// simulate chi's URL parameters
-				routeContext := chi.NewRouteContext()
-				routeContext.URLParams.Add("subdomain", subdomain)
-				req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+				// set the organization resolved by the tenant middleware
+				req = req.WithContext(organization.NewContext(req.Context(), organization.Organization{ID: gofakeit.Int64()}))
 
 				mockService := organization.NewMockService(t)
 				rr := httptest.NewRecorder()
@@ -347,17 +236,14 @@ func TestHandler_DeleteOrganization(t *testing.T) {
 			ID:        gofakeit.Int64(),
 			Subdomain: randomOrganizationSubdomain(),
 		}
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", org.Subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
 		// mock the DeleteOrganization function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), org.Subdomain).Return(org, nil)
 		mockService.On("DeleteOrganization", req.Context(), org.ID, comment).Return(nil)
 
 		// call the DeleteOrganization function
@@ -368,7 +254,26 @@ func TestHandler_DeleteOrganization(t *testing.T) {
 		assert.Empty(t, rr.Body.String())
 	})
 
-	t.Run("should return an error when get service call fails", func(t *testing.T) {
+	t.Run("should return bad request when the organization is not in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodDelete, deleteOrganizationPath,
+			strings.NewReader(`{"comment": "test comment"}`))
+		require.NoError(t, err)
+
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := organization.NewHandler(mockService)
+
+		// call the DeleteOrganization function
+		handler.DeleteOrganization(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "organization not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return an error when delete service call fails", func(t *testing.T) {
 		t.Parallel()
 
 		comment := gofakeit.Sentence(5)
@@ -381,18 +286,16 @@ func TestHandler_DeleteOrganization(t *testing.T) {
 			ID:        gofakeit.Int64(),
 			Subdomain: randomOrganizationSubdomain(),
 		}
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", org.Subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the GetOrganizationBySubdomain function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), org.Subdomain).
-			Return(organization.Organization{}, assert.AnError)
+		// mock the DeleteOrganization function
+		mockService.On("DeleteOrganization", req.Context(), org.ID, comment).
+			Return(assert.AnError)
 
 		// call the DeleteOrganization function
 		handler.DeleteOrganization(rr, req)
@@ -401,73 +304,214 @@ func TestHandler_DeleteOrganization(t *testing.T) {
 		require.Equal(t, http.StatusInternalServerError, rr.Code)
 		assert.JSONEq(t, `{"error": ""}`, rr.Body.String())
 	})
+}
+
+func TestHandler_SetMagicLinkLogin(t *testing.T) {
+	t.Parallel()
 
-	t.Run("should return an error when the organization is not found", func(t *testing.T) {
+	t.Run("should enable the magic link login of the organization", func(t *testing.T) {
 		t.Parallel()
 
-		comment := gofakeit.Sentence(5)
-		// create a new request with a json payload
-		payload := fmt.Sprintf(`{"comment": "%s"}`, comment)
-		req, err := http.NewRequest(http.MethodDelete, deleteOrganizationPath, strings.NewReader(payload))
+		req, err := http.NewRequest(http.MethodPut, setMagicLinkLoginPath, strings.NewReader(`{"enabled": true}`))
 		require.NoError(t, err)
 
 		org := organization.Organization{
 			ID:        gofakeit.Int64(),
 			Subdomain: randomOrganizationSubdomain(),
 		}
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", org.Subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the GetOrganizationBySubdomain function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), org.Subdomain).
-			Return(organization.Organization{}, base.NewNotFoundError("organization not found for the given subdomain"))
+		// mock the service calls
+		mockService.On("SetMagicLinkEnabled", req.Context(), org.ID, true).Return(nil)
 
-		// call the DeleteOrganization function
-		handler.DeleteOrganization(rr, req)
+		// call the SetMagicLinkLogin function
+		handler.SetMagicLinkLogin(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusNotFound, rr.Code)
-		assert.JSONEq(t, `{"error": "organization not found for the given subdomain"}`, rr.Body.String())
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
 	})
 
-	t.Run("should return an error when delete service call fails", func(t *testing.T) {
+	t.Run("should return bad request when the organization is not in the request context", func(t *testing.T) {
 		t.Parallel()
 
-		comment := gofakeit.Sentence(5)
-		// create a new request with a json payload
-		payload := fmt.Sprintf(`{"comment": "%s"}`, comment)
-		req, err := http.NewRequest(http.MethodDelete, deleteOrganizationPath, strings.NewReader(payload))
+		req, err := http.NewRequest(http.MethodPut, setMagicLinkLoginPath, strings.NewReader(`{"enabled": false}`))
+		require.NoError(t, err)
+
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := organization.NewHandler(mockService)
+
+		// call the SetMagicLinkLogin function
+		handler.SetMagicLinkLogin(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "organization not found in the request context: invalid context"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_SetImpersonation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow the impersonation of the users of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, setImpersonationPath, strings.NewReader(`{"allowed": true}`))
 		require.NoError(t, err)
 
 		org := organization.Organization{
 			ID:        gofakeit.Int64(),
 			Subdomain: randomOrganizationSubdomain(),
 		}
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", org.Subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		mockService := organization.NewMockService(t)
 		rr := httptest.NewRecorder()
 		handler := organization.NewHandler(mockService)
 
-		// mock the DeleteOrganization function
-		mockService.On("GetOrganizationBySubdomain", req.Context(), org.Subdomain).Return(org, nil)
-		mockService.On("DeleteOrganization", req.Context(), org.ID, comment).
-			Return(assert.AnError)
+		// mock the service calls
+		mockService.On("SetImpersonationAllowed", req.Context(), org.ID, true).Return(nil)
 
-		// call the DeleteOrganization function
-		handler.DeleteOrganization(rr, req)
+		// call the SetImpersonation function
+		handler.SetImpersonation(rr, req)
 
 		// check the result
-		require.Equal(t, http.StatusInternalServerError, rr.Code)
-		assert.JSONEq(t, `{"error": ""}`, rr.Body.String())
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("should return bad request when the organization is not in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, setImpersonationPath, strings.NewReader(`{"allowed": false}`))
+		require.NoError(t, err)
+
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := organization.NewHandler(mockService)
+
+		// call the SetImpersonation function
+		handler.SetImpersonation(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "organization not found in the request context: invalid context"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_ChangeSubdomain(t *testing.T) {
+	t.Parallel()
+
+	// newRequest creates a change subdomain request made to the given organization
+	newRequest := func(t *testing.T, org organization.Organization, payload string) *http.Request {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPut, changeSubdomainPath, strings.NewReader(payload))
+		require.NoError(t, err)
+
+		return req.WithContext(organization.NewContext(req.Context(), org))
+	}
+
+	t.Run("should return bad request for an invalid subdomain", func(t *testing.T) {
+		t.Parallel()
+
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: randomOrganizationSubdomain()}
+		req := newRequest(t, org, `{"subdomain": "new-subdomain"}`)
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+
+		organization.NewHandler(mockService).ChangeSubdomain(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return conflict when the subdomain is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		currentOrg := organization.Organization{ID: gofakeit.Int64(), Subdomain: randomOrganizationSubdomain()}
+		newSubdomain := gofakeit.LetterN(30)
+		req := newRequest(t, currentOrg, fmt.Sprintf(`{"subdomain": "%s"}`, newSubdomain))
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+
+		mockService.On("ChangeSubdomain", req.Context(), currentOrg.ID, newSubdomain).
+			Return(organization.ErrSubdomainUnavailable)
+
+		organization.NewHandler(mockService).ChangeSubdomain(rr, req)
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		assert.JSONEq(t, `{"error": "subdomain is already used by another organization"}`, rr.Body.String())
+	})
+
+	t.Run("should change the subdomain and return the organization", func(t *testing.T) {
+		t.Parallel()
+
+		currentOrg := organization.Organization{ID: gofakeit.Int64(), Subdomain: randomOrganizationSubdomain()}
+		changedOrg := organization.Organization{ID: currentOrg.ID, Subdomain: gofakeit.LetterN(30)}
+		req := newRequest(t, currentOrg, fmt.Sprintf(`{"subdomain": "%s"}`, changedOrg.Subdomain))
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+
+		mockService.On("ChangeSubdomain", req.Context(), currentOrg.ID, changedOrg.Subdomain).Return(nil)
+		mockService.On("GetOrganizationByID", req.Context(), currentOrg.ID).Return(changedOrg, nil)
+
+		organization.NewHandler(mockService).ChangeSubdomain(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`"subdomain":"%s"`, changedOrg.Subdomain))
+	})
+}
+
+func TestHandler_CheckSubdomainAvailability(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the availability with the suggestions", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, subdomainAvailabilityPath, nil)
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "subdomain", "acme")
+
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+
+		mockService.On("CheckSubdomainAvailability", req.Context(), "acme").Return(organization.SubdomainAvailability{
+			Subdomain:   "acme",
+			Reason:      organization.SubdomainReasonTaken,
+			Suggestions: []string{"acmehr", "acmeteam", "acmehq"},
+		}, nil)
+
+		organization.NewHandler(mockService).CheckSubdomainAvailability(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"subdomain": "acme", "available": false, "reason": "taken",
+		"suggestions": ["acmehr", "acmeteam", "acmehq"]}`, rr.Body.String())
+	})
+
+	t.Run("should return bad request for an invalid subdomain", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, subdomainAvailabilityPath, nil)
+		require.NoError(t, err)
+		req = tests.WithURLParam(req, "subdomain", "acme-hr")
+
+		validationErr := base.NewInputValidationError("subdomain can only contain alphanumeric characters")
+		mockService := organization.NewMockService(t)
+		rr := httptest.NewRecorder()
+
+		mockService.On("CheckSubdomainAvailability", req.Context(), "acme-hr").
+			Return(organization.SubdomainAvailability{}, validationErr)
+
+		organization.NewHandler(mockService).CheckSubdomainAvailability(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error": "subdomain can only contain alphanumeric characters"}`, rr.Body.String())
 	})
 }
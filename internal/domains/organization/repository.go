@@ -2,6 +2,8 @@ package organization
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/camelhr/camelhr-api/internal/database"
 )
@@ -10,9 +12,18 @@ type Repository interface {
 	// GetOrganizationByID returns an organization by its ID.
 	GetOrganizationByID(ctx context.Context, id int64) (Organization, error)
 
+	// GetDeletedOrganizationByID returns a soft deleted organization by its ID.
+	GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error)
+
 	// GetOrganizationBySubdomain returns an organization by its subdomain.
 	GetOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error)
 
+	// GetDeletedOrganizationBySubdomain returns a soft deleted organization by its subdomain.
+	GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error)
+
+	// GetOrganizationBySubdomainAlias returns an organization by an unexpired alias of its former subdomain.
+	GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error)
+
 	// GetOrganizationByName returns an organization by its name.
 	GetOrganizationByName(ctx context.Context, name string) (Organization, error)
 
@@ -22,6 +33,18 @@ type Repository interface {
 	// UpdateOrganization updates an organization.
 	UpdateOrganization(ctx context.Context, id int64, name string) error
 
+	// IsSubdomainReserved returns whether the subdomain is used by an organization, including the deleted ones,
+	// or by an unexpired alias of an organization other than the given one.
+	IsSubdomainReserved(ctx context.Context, subdomain string, orgID int64) (bool, error)
+
+	// ListAvailableSubdomains returns the given subdomains which are neither used by an organization,
+	// including the deleted ones, nor by an unexpired alias. The subdomains are returned in the given order.
+	ListAvailableSubdomains(ctx context.Context, subdomains []string) ([]string, error)
+
+	// ChangeSubdomain changes the subdomain of an organization by its ID.
+	// The former subdomain is kept as an alias of the organization until the given expiry.
+	ChangeSubdomain(ctx context.Context, id int64, subdomain string, aliasExpiresAt time.Time) error
+
 	// DeleteOrganization deletes an organization by its ID.
 	DeleteOrganization(ctx context.Context, id int64, comment string) error
 
@@ -30,6 +53,26 @@ type Repository interface {
 
 	// UnsuspendOrganization unsuspend an organization by its ID.
 	UnsuspendOrganization(ctx context.Context, id int64, comment string) error
+
+	// RestoreOrganization restores a soft deleted organization by its ID.
+	RestoreOrganization(ctx context.Context, id int64, comment string) error
+
+	// SetMFARequired sets whether the users of the organization must use mfa to login.
+	SetMFARequired(ctx context.Context, id int64, required bool) error
+
+	// SetMagicLinkEnabled sets whether the users of the organization can login using a link mailed to them.
+	SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error
+
+	// SetImpersonationAllowed sets whether the platform operators can impersonate the users of the organization.
+	SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error
+
+	// ListPurgeableOrganizations returns the organizations deleted before the given time
+	// starting with the earliest deleted.
+	ListPurgeableOrganizations(ctx context.Context, deletedBefore time.Time, limit int) ([]Organization, error)
+
+	// PurgeOrganization hard deletes a soft deleted organization along with its tenant data.
+	// A tombstone of the organization is kept and its subdomain is released.
+	PurgeOrganization(ctx context.Context, id int64) error
 }
 
 type repository struct {
@@ -47,6 +90,13 @@ func (r *repository) GetOrganizationByID(ctx context.Context, id int64) (Organiz
 	return org, err
 }
 
+func (r *repository) GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error) {
+	var org Organization
+	err := r.db.Get(ctx, &org, getDeletedOrganizationByIDQuery, id)
+
+	return org, err
+}
+
 func (r *repository) GetOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
 	var org Organization
 	err := r.db.Get(ctx, &org, getOrganizationBySubdomainQuery, subdomain)
@@ -54,6 +104,20 @@ func (r *repository) GetOrganizationBySubdomain(ctx context.Context, subdomain s
 	return org, err
 }
 
+func (r *repository) GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
+	var org Organization
+	err := r.db.Get(ctx, &org, getDeletedOrganizationBySubdomainQuery, subdomain)
+
+	return org, err
+}
+
+func (r *repository) GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error) {
+	var org Organization
+	err := r.db.Get(ctx, &org, getOrganizationBySubdomainAliasQuery, subdomain)
+
+	return org, err
+}
+
 func (r *repository) GetOrganizationByName(ctx context.Context, name string) (Organization, error) {
 	var org Organization
 	err := r.db.Get(ctx, &org, getOrganizationByNameQuery, name)
@@ -72,6 +136,26 @@ func (r *repository) UpdateOrganization(ctx context.Context, id int64, name stri
 	return r.db.Exec(ctx, nil, updateOrganizationQuery, id, name)
 }
 
+func (r *repository) IsSubdomainReserved(ctx context.Context, subdomain string, orgID int64) (bool, error) {
+	var reserved bool
+	err := r.db.Get(ctx, &reserved, isSubdomainReservedQuery, subdomain, orgID)
+
+	return reserved, err
+}
+
+func (r *repository) ListAvailableSubdomains(ctx context.Context, subdomains []string) ([]string, error) {
+	var available []string
+	err := r.db.List(ctx, &available, listAvailableSubdomainsQuery, strings.Join(subdomains, ","))
+
+	return available, err
+}
+
+func (r *repository) ChangeSubdomain(
+	ctx context.Context, id int64, subdomain string, aliasExpiresAt time.Time,
+) error {
+	return r.db.Exec(ctx, nil, changeSubdomainQuery, id, subdomain, aliasExpiresAt)
+}
+
 func (r *repository) DeleteOrganization(ctx context.Context, id int64, comment string) error {
 	return r.db.Exec(ctx, nil, deleteOrganizationQuery, id, comment)
 }
@@ -83,3 +167,32 @@ func (r *repository) SuspendOrganization(ctx context.Context, id int64, comment
 func (r *repository) UnsuspendOrganization(ctx context.Context, id int64, comment string) error {
 	return r.db.Exec(ctx, nil, unsuspendOrganizationQuery, id, comment)
 }
+
+func (r *repository) RestoreOrganization(ctx context.Context, id int64, comment string) error {
+	return r.db.Exec(ctx, nil, restoreOrganizationQuery, id, comment)
+}
+
+func (r *repository) SetMFARequired(ctx context.Context, id int64, required bool) error {
+	return r.db.Exec(ctx, nil, setMFARequiredQuery, id, required)
+}
+
+func (r *repository) SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error {
+	return r.db.Exec(ctx, nil, setMagicLinkEnabledQuery, id, enabled)
+}
+
+func (r *repository) SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error {
+	return r.db.Exec(ctx, nil, setImpersonationAllowedQuery, id, allowed)
+}
+
+func (r *repository) ListPurgeableOrganizations(
+	ctx context.Context, deletedBefore time.Time, limit int,
+) ([]Organization, error) {
+	var orgs []Organization
+	err := r.db.List(ctx, &orgs, listPurgeableOrganizationsQuery, deletedBefore, limit)
+
+	return orgs, err
+}
+
+func (r *repository) PurgeOrganization(ctx context.Context, id int64) error {
+	return r.db.Exec(ctx, nil, purgeOrganizationQuery, id)
+}
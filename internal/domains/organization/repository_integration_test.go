@@ -3,6 +3,7 @@ package organization_test
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -85,6 +86,28 @@ func (s *OrganizationTestSuite) TestRepositoryIntegration_GetOrganizationBySubdo
 	})
 }
 
+func (s *OrganizationTestSuite) TestRepositoryIntegration_GetDeletedOrganizationBySubdomain() {
+	s.Run("should return a deleted organization by subdomain", func() {
+		s.T().Parallel()
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB, fake.OrganizationDeleted())
+
+		result, err := repo.GetDeletedOrganizationBySubdomain(context.Background(), org.Subdomain)
+		s.Require().NoError(err)
+		s.Equal(org.ID, result.ID)
+		s.NotNil(result.DeletedAt)
+	})
+
+	s.Run("should return error when organization is not deleted", func() {
+		s.T().Parallel()
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+
+		_, err := repo.GetDeletedOrganizationBySubdomain(context.Background(), org.Subdomain)
+		s.ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
 func (s *OrganizationTestSuite) TestRepositoryIntegration_GetOrganizationByName() {
 	s.Run("should return an organization by name", func() {
 		s.T().Parallel()
@@ -338,3 +361,427 @@ func (s *OrganizationTestSuite) TestRepositoryIntegration_UnsuspendOrganization(
 		s.Nil(result.Comment)
 	})
 }
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_RestoreOrganization() {
+	s.Run("should restore an organization along with its users", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		u1 := fake.NewUser(s.DB, org.ID)
+		u2 := fake.NewUser(s.DB, org.ID, fake.UserDeleted())
+		org.Delete(s.DB)
+		comment := gofakeit.Sentence(5)
+
+		deletedOrg, err := repo.GetDeletedOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.NotNil(deletedOrg.DeletedAt)
+
+		err = repo.RestoreOrganization(context.Background(), org.ID, comment)
+		s.Require().NoError(err)
+
+		result := org.FetchLatest(s.DB)
+		s.Nil(result.DeletedAt)
+		s.Require().NotNil(result.Comment)
+		s.Equal(comment, *result.Comment)
+
+		// the user deleted along with the organization should be restored
+		s.False(u1.IsDeleted(s.DB))
+
+		// the user deleted before the organization should stay deleted
+		s.True(u2.IsDeleted(s.DB))
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_SetMFARequired() {
+	s.Run("should update the mfa requirement of the organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+
+		err := repo.SetMFARequired(context.Background(), org.ID, true)
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.True(result.MFARequired)
+
+		err = repo.SetMFARequired(context.Background(), org.ID, false)
+		s.Require().NoError(err)
+
+		result, err = repo.GetOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.False(result.MFARequired)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_SetMagicLinkEnabled() {
+	s.Run("should update the magic link login of the organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		s.False(org.MagicLinkEnabled)
+
+		err := repo.SetMagicLinkEnabled(context.Background(), org.ID, true)
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.True(result.MagicLinkEnabled)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_SetImpersonationAllowed() {
+	s.Run("should update the impersonation allowance of the organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		s.False(org.ImpersonationAllowed)
+
+		err := repo.SetImpersonationAllowed(context.Background(), org.ID, true)
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.True(result.ImpersonationAllowed)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_ListPurgeableOrganizations() {
+	s.Run("should return the organizations deleted before the given time", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		expired := fake.NewOrganization(s.DB)
+		expired.DeleteAt(s.DB, time.Now().UTC().Add(-90*24*time.Hour))
+		recent := fake.NewOrganization(s.DB, fake.OrganizationDeleted())
+		active := fake.NewOrganization(s.DB)
+
+		result, err := repo.ListPurgeableOrganizations(context.Background(),
+			time.Now().UTC().Add(-30*24*time.Hour), 1000)
+		s.Require().NoError(err)
+
+		ids := make([]int64, 0, len(result))
+		for _, o := range result {
+			ids = append(ids, o.ID)
+		}
+
+		s.Contains(ids, expired.ID)
+		s.NotContains(ids, recent.ID)
+		s.NotContains(ids, active.ID)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_PurgeOrganization() {
+	s.Run("should purge the organization along with its users and keep a tombstone", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		owner := fake.NewUser(s.DB, org.ID, fake.UserIsOwner())
+		r := fake.NewRole(s.DB, org.ID)
+		u := fake.NewUser(s.DB, org.ID, fake.UserRole(r.ID))
+		fake.NewAPIToken(s.DB, u.ID)
+
+		err := s.DB.Exec(context.Background(), nil, `INSERT INTO admin_audit_logs
+			(organization_id, operator, action, comment) VALUES ($1, 'operator', 'suspend', 'test')`, org.ID)
+		s.Require().NoError(err)
+
+		org.DeleteAt(s.DB, time.Now().UTC().Add(-90*24*time.Hour))
+
+		err = repo.PurgeOrganization(context.Background(), org.ID)
+		s.Require().NoError(err)
+
+		var count int64
+
+		err = s.DB.Get(context.Background(), &count,
+			"SELECT COUNT(*) FROM organizations WHERE organization_id = $1", org.ID)
+		s.Require().NoError(err)
+		s.Zero(count)
+
+		err = s.DB.Get(context.Background(), &count,
+			"SELECT COUNT(*) FROM users WHERE user_id IN ($1, $2)", owner.ID, u.ID)
+		s.Require().NoError(err)
+		s.Zero(count)
+
+		err = s.DB.Get(context.Background(), &count, "SELECT COUNT(*) FROM roles WHERE role_id = $1", r.ID)
+		s.Require().NoError(err)
+		s.Zero(count)
+
+		// the tombstone keeps the subdomain of the purged organization
+		var subdomain string
+		err = s.DB.Get(context.Background(), &subdomain,
+			"SELECT subdomain FROM organization_tombstones WHERE organization_id = $1", org.ID)
+		s.Require().NoError(err)
+		s.Equal(org.Subdomain, subdomain)
+
+		// the audit logs of the operators are kept
+		err = s.DB.Get(context.Background(), &count,
+			"SELECT COUNT(*) FROM admin_audit_logs WHERE organization_id = $1", org.ID)
+		s.Require().NoError(err)
+		s.Equal(int64(1), count)
+
+		// the subdomain is released
+		_, err = repo.CreateOrganization(context.Background(), org.Subdomain, randomOrganizationName())
+		s.Require().NoError(err)
+	})
+
+	s.Run("should delete the rows of every table referencing the organizations or the users", func() {
+		s.T().Parallel()
+
+		// the tables without ON DELETE CASCADE must be deleted explicitly by the purge function
+		var tables []string
+		err := s.DB.List(context.Background(), &tables, `SELECT DISTINCT conrelid::regclass::TEXT FROM pg_constraint
+			WHERE contype = 'f' AND confdeltype <> 'c' AND confrelid IN ('organizations'::regclass, 'users'::regclass)`)
+		s.Require().NoError(err)
+		s.Require().NotEmpty(tables)
+
+		var purgeFunction string
+		err = s.DB.Get(context.Background(), &purgeFunction,
+			"SELECT prosrc FROM pg_proc WHERE proname = 'purge_organization'")
+		s.Require().NoError(err)
+
+		for _, table := range tables {
+			s.Regexp(`DELETE FROM `+table+`\s`, purgeFunction, "table %s is not purged", table)
+		}
+	})
+
+	s.Run("should return error when organization is not deleted", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		fake.NewUser(s.DB, org.ID)
+
+		err := repo.PurgeOrganization(context.Background(), org.ID)
+		s.Require().Error(err)
+		s.ErrorContains(err, "is not deleted")
+		s.False(org.IsDeleted(s.DB))
+	})
+
+	s.Run("should not allow the hard delete of the users of another organization while purging", func() {
+		s.T().Parallel()
+
+		org := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, org.ID)
+
+		err := s.DB.WithTx(context.Background(), func(ctx context.Context) error {
+			err := s.DB.Exec(ctx, nil, "SELECT set_config('camelhr.purging_organization_id', '0', true)")
+			if err != nil {
+				return err
+			}
+
+			return s.DB.Exec(ctx, nil, "DELETE FROM users WHERE user_id = $1", u.ID)
+		})
+		s.Require().Error(err)
+		s.ErrorContains(err, "DELETE operation on table users is not allowed: prevent_hard_delete_on_users")
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_ChangeSubdomain() {
+	s.Run("should change the subdomain and keep the former subdomain as an alias", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		newSubdomain := strings.ToLower(gofakeit.LetterN(30))
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, newSubdomain, time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationBySubdomain(context.Background(), newSubdomain)
+		s.Require().NoError(err)
+		s.Equal(org.ID, result.ID)
+
+		result, err = repo.GetOrganizationBySubdomainAlias(context.Background(), org.Subdomain)
+		s.Require().NoError(err)
+		s.Equal(org.ID, result.ID)
+		s.Equal(newSubdomain, result.Subdomain)
+	})
+
+	s.Run("should let the organization reclaim its own alias", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		newSubdomain := strings.ToLower(gofakeit.LetterN(30))
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, newSubdomain, time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		err = repo.ChangeSubdomain(context.Background(), org.ID, org.Subdomain, time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationBySubdomain(context.Background(), org.Subdomain)
+		s.Require().NoError(err)
+		s.Equal(org.ID, result.ID)
+
+		_, err = repo.GetOrganizationBySubdomainAlias(context.Background(), org.Subdomain)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		result, err = repo.GetOrganizationBySubdomainAlias(context.Background(), newSubdomain)
+		s.Require().NoError(err)
+		s.Equal(org.ID, result.ID)
+	})
+
+	s.Run("should not change the subdomain of a deleted organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB, fake.OrganizationDeleted())
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		result, err := repo.GetDeletedOrganizationByID(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.Equal(org.Subdomain, result.Subdomain)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_GetOrganizationBySubdomainAlias() {
+	s.Run("should not return the organization of an expired alias", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(-time.Hour))
+		s.Require().NoError(err)
+
+		_, err = repo.GetOrganizationBySubdomainAlias(context.Background(), org.Subdomain)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not return a deleted organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		err = repo.DeleteOrganization(context.Background(), org.ID, "test")
+		s.Require().NoError(err)
+
+		_, err = repo.GetOrganizationBySubdomainAlias(context.Background(), org.Subdomain)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_IsSubdomainReserved() {
+	s.Run("should reserve the subdomain of an organization", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB, fake.OrganizationDeleted())
+
+		reserved, err := repo.IsSubdomainReserved(context.Background(), org.Subdomain, org.ID)
+		s.Require().NoError(err)
+		s.True(reserved)
+	})
+
+	s.Run("should reserve an unexpired alias for the other organizations only", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		otherOrg := fake.NewOrganization(s.DB)
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		reserved, err := repo.IsSubdomainReserved(context.Background(), org.Subdomain, otherOrg.ID)
+		s.Require().NoError(err)
+		s.True(reserved)
+
+		reserved, err = repo.IsSubdomainReserved(context.Background(), org.Subdomain, org.ID)
+		s.Require().NoError(err)
+		s.False(reserved)
+	})
+
+	s.Run("should release an expired alias", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB)
+		otherOrg := fake.NewOrganization(s.DB)
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(-time.Hour))
+		s.Require().NoError(err)
+
+		reserved, err := repo.IsSubdomainReserved(context.Background(), org.Subdomain, otherOrg.ID)
+		s.Require().NoError(err)
+		s.False(reserved)
+
+		// the expired alias is replaced once the subdomain is used again
+		err = repo.ChangeSubdomain(context.Background(), otherOrg.ID, org.Subdomain, time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		err = repo.ChangeSubdomain(context.Background(), otherOrg.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		result, err := repo.GetOrganizationBySubdomainAlias(context.Background(), org.Subdomain)
+		s.Require().NoError(err)
+		s.Equal(otherOrg.ID, result.ID)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_ListAvailableSubdomains() {
+	s.Run("should return the subdomains not used by an organization or an unexpired alias", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		org := fake.NewOrganization(s.DB, fake.OrganizationSubdomain(strings.ToLower(gofakeit.LetterN(30))))
+		deletedOrg := fake.NewOrganization(s.DB, fake.OrganizationSubdomain(strings.ToLower(gofakeit.LetterN(30))),
+			fake.OrganizationDeleted())
+		alias := org.Subdomain
+		free := strings.ToLower(gofakeit.LetterN(30))
+
+		err := repo.ChangeSubdomain(context.Background(), org.ID, strings.ToLower(gofakeit.LetterN(30)),
+			time.Now().UTC().Add(time.Hour))
+		s.Require().NoError(err)
+
+		available, err := repo.ListAvailableSubdomains(context.Background(),
+			[]string{alias, free, deletedOrg.Subdomain})
+		s.Require().NoError(err)
+		s.Equal([]string{free}, available)
+	})
+
+	s.Run("should return the available subdomains in the given order", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+		subdomains := []string{
+			strings.ToLower(gofakeit.LetterN(30)),
+			strings.ToLower(gofakeit.LetterN(30)),
+			strings.ToLower(gofakeit.LetterN(30)),
+		}
+
+		available, err := repo.ListAvailableSubdomains(context.Background(), subdomains)
+		s.Require().NoError(err)
+		s.Equal(subdomains, available)
+	})
+}
+
+func (s *OrganizationTestSuite) TestRepositoryIntegration_SubdomainLowercase() {
+	s.Run("should not store an uppercase subdomain", func() {
+		s.T().Parallel()
+
+		repo := organization.NewRepository(s.DB)
+
+		_, err := repo.CreateOrganization(context.Background(), "A"+strings.ToLower(gofakeit.LetterN(29)),
+			randomOrganizationName())
+		s.Require().ErrorContains(err, "organizations_subdomain_lowercase")
+	})
+}
@@ -4,6 +4,7 @@ package organization
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -21,6 +22,55 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// ChangeSubdomain provides a mock function with given fields: ctx, id, subdomain, aliasExpiresAt
+func (_m *MockRepository) ChangeSubdomain(ctx context.Context, id int64, subdomain string, aliasExpiresAt time.Time) error {
+	ret := _m.Called(ctx, id, subdomain, aliasExpiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeSubdomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, subdomain, aliasExpiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_ChangeSubdomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeSubdomain'
+type MockRepository_ChangeSubdomain_Call struct {
+	*mock.Call
+}
+
+// ChangeSubdomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - subdomain string
+//   - aliasExpiresAt time.Time
+func (_e *MockRepository_Expecter) ChangeSubdomain(ctx interface{}, id interface{}, subdomain interface{}, aliasExpiresAt interface{}) *MockRepository_ChangeSubdomain_Call {
+	return &MockRepository_ChangeSubdomain_Call{Call: _e.mock.On("ChangeSubdomain", ctx, id, subdomain, aliasExpiresAt)}
+}
+
+func (_c *MockRepository_ChangeSubdomain_Call) Run(run func(ctx context.Context, id int64, subdomain string, aliasExpiresAt time.Time)) *MockRepository_ChangeSubdomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ChangeSubdomain_Call) Return(_a0 error) *MockRepository_ChangeSubdomain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_ChangeSubdomain_Call) RunAndReturn(run func(context.Context, int64, string, time.Time) error) *MockRepository_ChangeSubdomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOrganization provides a mock function with given fields: ctx, subdomain, name
 func (_m *MockRepository) CreateOrganization(ctx context.Context, subdomain string, name string) (Organization, error) {
 	ret := _m.Called(ctx, subdomain, name)
@@ -127,6 +177,120 @@ func (_c *MockRepository_DeleteOrganization_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetDeletedOrganizationByID provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedOrganizationByID")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetDeletedOrganizationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOrganizationByID'
+type MockRepository_GetDeletedOrganizationByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedOrganizationByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockRepository_Expecter) GetDeletedOrganizationByID(ctx interface{}, id interface{}) *MockRepository_GetDeletedOrganizationByID_Call {
+	return &MockRepository_GetDeletedOrganizationByID_Call{Call: _e.mock.On("GetDeletedOrganizationByID", ctx, id)}
+}
+
+func (_c *MockRepository_GetDeletedOrganizationByID_Call) Run(run func(ctx context.Context, id int64)) *MockRepository_GetDeletedOrganizationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetDeletedOrganizationByID_Call) Return(_a0 Organization, _a1 error) *MockRepository_GetDeletedOrganizationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetDeletedOrganizationByID_Call) RunAndReturn(run func(context.Context, int64) (Organization, error)) *MockRepository_GetDeletedOrganizationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedOrganizationBySubdomain provides a mock function with given fields: ctx, subdomain
+func (_m *MockRepository) GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
+	ret := _m.Called(ctx, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedOrganizationBySubdomain")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Organization, error)); ok {
+		return rf(ctx, subdomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Organization); ok {
+		r0 = rf(ctx, subdomain)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subdomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetDeletedOrganizationBySubdomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOrganizationBySubdomain'
+type MockRepository_GetDeletedOrganizationBySubdomain_Call struct {
+	*mock.Call
+}
+
+// GetDeletedOrganizationBySubdomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+func (_e *MockRepository_Expecter) GetDeletedOrganizationBySubdomain(ctx interface{}, subdomain interface{}) *MockRepository_GetDeletedOrganizationBySubdomain_Call {
+	return &MockRepository_GetDeletedOrganizationBySubdomain_Call{Call: _e.mock.On("GetDeletedOrganizationBySubdomain", ctx, subdomain)}
+}
+
+func (_c *MockRepository_GetDeletedOrganizationBySubdomain_Call) Run(run func(ctx context.Context, subdomain string)) *MockRepository_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetDeletedOrganizationBySubdomain_Call) Return(_a0 Organization, _a1 error) *MockRepository_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetDeletedOrganizationBySubdomain_Call) RunAndReturn(run func(context.Context, string) (Organization, error)) *MockRepository_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOrganizationByID provides a mock function with given fields: ctx, id
 func (_m *MockRepository) GetOrganizationByID(ctx context.Context, id int64) (Organization, error) {
 	ret := _m.Called(ctx, id)
@@ -298,6 +462,479 @@ func (_c *MockRepository_GetOrganizationBySubdomain_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetOrganizationBySubdomainAlias provides a mock function with given fields: ctx, subdomain
+func (_m *MockRepository) GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error) {
+	ret := _m.Called(ctx, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizationBySubdomainAlias")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Organization, error)); ok {
+		return rf(ctx, subdomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Organization); ok {
+		r0 = rf(ctx, subdomain)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subdomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetOrganizationBySubdomainAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationBySubdomainAlias'
+type MockRepository_GetOrganizationBySubdomainAlias_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationBySubdomainAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+func (_e *MockRepository_Expecter) GetOrganizationBySubdomainAlias(ctx interface{}, subdomain interface{}) *MockRepository_GetOrganizationBySubdomainAlias_Call {
+	return &MockRepository_GetOrganizationBySubdomainAlias_Call{Call: _e.mock.On("GetOrganizationBySubdomainAlias", ctx, subdomain)}
+}
+
+func (_c *MockRepository_GetOrganizationBySubdomainAlias_Call) Run(run func(ctx context.Context, subdomain string)) *MockRepository_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetOrganizationBySubdomainAlias_Call) Return(_a0 Organization, _a1 error) *MockRepository_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetOrganizationBySubdomainAlias_Call) RunAndReturn(run func(context.Context, string) (Organization, error)) *MockRepository_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsSubdomainReserved provides a mock function with given fields: ctx, subdomain, orgID
+func (_m *MockRepository) IsSubdomainReserved(ctx context.Context, subdomain string, orgID int64) (bool, error) {
+	ret := _m.Called(ctx, subdomain, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSubdomainReserved")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, subdomain, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, subdomain, orgID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, subdomain, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_IsSubdomainReserved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSubdomainReserved'
+type MockRepository_IsSubdomainReserved_Call struct {
+	*mock.Call
+}
+
+// IsSubdomainReserved is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+//   - orgID int64
+func (_e *MockRepository_Expecter) IsSubdomainReserved(ctx interface{}, subdomain interface{}, orgID interface{}) *MockRepository_IsSubdomainReserved_Call {
+	return &MockRepository_IsSubdomainReserved_Call{Call: _e.mock.On("IsSubdomainReserved", ctx, subdomain, orgID)}
+}
+
+func (_c *MockRepository_IsSubdomainReserved_Call) Run(run func(ctx context.Context, subdomain string, orgID int64)) *MockRepository_IsSubdomainReserved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_IsSubdomainReserved_Call) Return(_a0 bool, _a1 error) *MockRepository_IsSubdomainReserved_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_IsSubdomainReserved_Call) RunAndReturn(run func(context.Context, string, int64) (bool, error)) *MockRepository_IsSubdomainReserved_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAvailableSubdomains provides a mock function with given fields: ctx, subdomains
+func (_m *MockRepository) ListAvailableSubdomains(ctx context.Context, subdomains []string) ([]string, error) {
+	ret := _m.Called(ctx, subdomains)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAvailableSubdomains")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, subdomains)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, subdomains)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, subdomains)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListAvailableSubdomains_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAvailableSubdomains'
+type MockRepository_ListAvailableSubdomains_Call struct {
+	*mock.Call
+}
+
+// ListAvailableSubdomains is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomains []string
+func (_e *MockRepository_Expecter) ListAvailableSubdomains(ctx interface{}, subdomains interface{}) *MockRepository_ListAvailableSubdomains_Call {
+	return &MockRepository_ListAvailableSubdomains_Call{Call: _e.mock.On("ListAvailableSubdomains", ctx, subdomains)}
+}
+
+func (_c *MockRepository_ListAvailableSubdomains_Call) Run(run func(ctx context.Context, subdomains []string)) *MockRepository_ListAvailableSubdomains_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListAvailableSubdomains_Call) Return(_a0 []string, _a1 error) *MockRepository_ListAvailableSubdomains_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListAvailableSubdomains_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockRepository_ListAvailableSubdomains_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPurgeableOrganizations provides a mock function with given fields: ctx, deletedBefore, limit
+func (_m *MockRepository) ListPurgeableOrganizations(ctx context.Context, deletedBefore time.Time, limit int) ([]Organization, error) {
+	ret := _m.Called(ctx, deletedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPurgeableOrganizations")
+	}
+
+	var r0 []Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]Organization, error)); ok {
+		return rf(ctx, deletedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []Organization); ok {
+		r0 = rf(ctx, deletedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, deletedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListPurgeableOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPurgeableOrganizations'
+type MockRepository_ListPurgeableOrganizations_Call struct {
+	*mock.Call
+}
+
+// ListPurgeableOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) ListPurgeableOrganizations(ctx interface{}, deletedBefore interface{}, limit interface{}) *MockRepository_ListPurgeableOrganizations_Call {
+	return &MockRepository_ListPurgeableOrganizations_Call{Call: _e.mock.On("ListPurgeableOrganizations", ctx, deletedBefore, limit)}
+}
+
+func (_c *MockRepository_ListPurgeableOrganizations_Call) Run(run func(ctx context.Context, deletedBefore time.Time, limit int)) *MockRepository_ListPurgeableOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListPurgeableOrganizations_Call) Return(_a0 []Organization, _a1 error) *MockRepository_ListPurgeableOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListPurgeableOrganizations_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]Organization, error)) *MockRepository_ListPurgeableOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeOrganization provides a mock function with given fields: ctx, id
+func (_m *MockRepository) PurgeOrganization(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_PurgeOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeOrganization'
+type MockRepository_PurgeOrganization_Call struct {
+	*mock.Call
+}
+
+// PurgeOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockRepository_Expecter) PurgeOrganization(ctx interface{}, id interface{}) *MockRepository_PurgeOrganization_Call {
+	return &MockRepository_PurgeOrganization_Call{Call: _e.mock.On("PurgeOrganization", ctx, id)}
+}
+
+func (_c *MockRepository_PurgeOrganization_Call) Run(run func(ctx context.Context, id int64)) *MockRepository_PurgeOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_PurgeOrganization_Call) Return(_a0 error) *MockRepository_PurgeOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_PurgeOrganization_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_PurgeOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreOrganization provides a mock function with given fields: ctx, id, comment
+func (_m *MockRepository) RestoreOrganization(ctx context.Context, id int64, comment string) error {
+	ret := _m.Called(ctx, id, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_RestoreOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreOrganization'
+type MockRepository_RestoreOrganization_Call struct {
+	*mock.Call
+}
+
+// RestoreOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - comment string
+func (_e *MockRepository_Expecter) RestoreOrganization(ctx interface{}, id interface{}, comment interface{}) *MockRepository_RestoreOrganization_Call {
+	return &MockRepository_RestoreOrganization_Call{Call: _e.mock.On("RestoreOrganization", ctx, id, comment)}
+}
+
+func (_c *MockRepository_RestoreOrganization_Call) Run(run func(ctx context.Context, id int64, comment string)) *MockRepository_RestoreOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_RestoreOrganization_Call) Return(_a0 error) *MockRepository_RestoreOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_RestoreOrganization_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_RestoreOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetImpersonationAllowed provides a mock function with given fields: ctx, id, allowed
+func (_m *MockRepository) SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error {
+	ret := _m.Called(ctx, id, allowed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetImpersonationAllowed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, allowed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetImpersonationAllowed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetImpersonationAllowed'
+type MockRepository_SetImpersonationAllowed_Call struct {
+	*mock.Call
+}
+
+// SetImpersonationAllowed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - allowed bool
+func (_e *MockRepository_Expecter) SetImpersonationAllowed(ctx interface{}, id interface{}, allowed interface{}) *MockRepository_SetImpersonationAllowed_Call {
+	return &MockRepository_SetImpersonationAllowed_Call{Call: _e.mock.On("SetImpersonationAllowed", ctx, id, allowed)}
+}
+
+func (_c *MockRepository_SetImpersonationAllowed_Call) Run(run func(ctx context.Context, id int64, allowed bool)) *MockRepository_SetImpersonationAllowed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetImpersonationAllowed_Call) Return(_a0 error) *MockRepository_SetImpersonationAllowed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetImpersonationAllowed_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockRepository_SetImpersonationAllowed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMFARequired provides a mock function with given fields: ctx, id, required
+func (_m *MockRepository) SetMFARequired(ctx context.Context, id int64, required bool) error {
+	ret := _m.Called(ctx, id, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMFARequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetMFARequired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMFARequired'
+type MockRepository_SetMFARequired_Call struct {
+	*mock.Call
+}
+
+// SetMFARequired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - required bool
+func (_e *MockRepository_Expecter) SetMFARequired(ctx interface{}, id interface{}, required interface{}) *MockRepository_SetMFARequired_Call {
+	return &MockRepository_SetMFARequired_Call{Call: _e.mock.On("SetMFARequired", ctx, id, required)}
+}
+
+func (_c *MockRepository_SetMFARequired_Call) Run(run func(ctx context.Context, id int64, required bool)) *MockRepository_SetMFARequired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetMFARequired_Call) Return(_a0 error) *MockRepository_SetMFARequired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetMFARequired_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockRepository_SetMFARequired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMagicLinkEnabled provides a mock function with given fields: ctx, id, enabled
+func (_m *MockRepository) SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error {
+	ret := _m.Called(ctx, id, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMagicLinkEnabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetMagicLinkEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMagicLinkEnabled'
+type MockRepository_SetMagicLinkEnabled_Call struct {
+	*mock.Call
+}
+
+// SetMagicLinkEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - enabled bool
+func (_e *MockRepository_Expecter) SetMagicLinkEnabled(ctx interface{}, id interface{}, enabled interface{}) *MockRepository_SetMagicLinkEnabled_Call {
+	return &MockRepository_SetMagicLinkEnabled_Call{Call: _e.mock.On("SetMagicLinkEnabled", ctx, id, enabled)}
+}
+
+func (_c *MockRepository_SetMagicLinkEnabled_Call) Run(run func(ctx context.Context, id int64, enabled bool)) *MockRepository_SetMagicLinkEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetMagicLinkEnabled_Call) Return(_a0 error) *MockRepository_SetMagicLinkEnabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetMagicLinkEnabled_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockRepository_SetMagicLinkEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SuspendOrganization provides a mock function with given fields: ctx, id, comment
 func (_m *MockRepository) SuspendOrganization(ctx context.Context, id int64, comment string) error {
 	ret := _m.Called(ctx, id, comment)
@@ -3,7 +3,9 @@ package organization_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/database"
@@ -104,6 +106,45 @@ func TestRepository_GetOrganizationBySubdomain(t *testing.T) {
 	})
 }
 
+func TestRepository_GetDeletedOrganizationBySubdomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getDeletedOrganizationBySubdomainQuery"), "org1").
+			Return(assert.AnError)
+
+		_, err := repo.GetDeletedOrganizationBySubdomain(context.Background(), "org1")
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the deleted organization", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		org := organization.Organization{ID: 1, Subdomain: "org1", Name: randomOrganizationName()}
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getDeletedOrganizationBySubdomainQuery"), "org1").
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*organization.Organization)
+				require.True(t, ok)
+				*arg = org
+			}).Return(nil)
+
+		result, err := repo.GetDeletedOrganizationBySubdomain(context.Background(), "org1")
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
 func TestRepository_GetOrganizationByName(t *testing.T) {
 	t.Parallel()
 
@@ -337,9 +378,375 @@ func TestRepository_UnsuspendOrganization(t *testing.T) {
 }
 
 func randomOrganizationSubdomain() string {
-	return gofakeit.LetterN(uint(gofakeit.Number(1, 30)))
+	return strings.ToLower(gofakeit.LetterN(uint(gofakeit.Number(1, 30))))
 }
 
 func randomOrganizationName() string {
 	return fmt.Sprint(gofakeit.LetterN(8), " ", gofakeit.Company())
 }
+
+func TestRepository_GetDeletedOrganizationByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getDeletedOrganizationByIDQuery"), int64(1)).
+			Return(assert.AnError)
+
+		_, err := repo.GetDeletedOrganizationByID(context.Background(), 1)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the deleted organization", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		org := organization.Organization{ID: 1, Name: randomOrganizationName()}
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getDeletedOrganizationByIDQuery"), int64(1)).
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*organization.Organization)
+				require.True(t, ok)
+				*arg = org
+			}).Return(nil)
+
+		result, err := repo.GetDeletedOrganizationByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestRepository_RestoreOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("restoreOrganizationQuery"), int64(1), "test restored").
+			Return(assert.AnError)
+
+		err := repo.RestoreOrganization(context.Background(), 1, "test restored")
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the organization is restored", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("restoreOrganizationQuery"), int64(1), "test restored").
+			Return(nil)
+
+		err := repo.RestoreOrganization(context.Background(), 1, "test restored")
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_SetMFARequired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("setMFARequiredQuery"), int64(1), true).
+			Return(assert.AnError)
+
+		err := repo.SetMFARequired(context.Background(), 1, true)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the mfa requirement is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("setMFARequiredQuery"), int64(1), true).
+			Return(nil)
+
+		err := repo.SetMFARequired(context.Background(), 1, true)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_SetMagicLinkEnabled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the magic link login is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("setMagicLinkEnabledQuery"), int64(1), true).
+			Return(nil)
+
+		err := repo.SetMagicLinkEnabled(context.Background(), 1, true)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_SetImpersonationAllowed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the impersonation allowance is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("setImpersonationAllowedQuery"), int64(1), true).
+			Return(nil)
+
+		err := repo.SetImpersonationAllowed(context.Background(), 1, true)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_ListPurgeableOrganizations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		deletedBefore := time.Now().UTC()
+
+		mockDB.On("List", context.Background(), mock.Anything,
+			tests.QueryMatcher("listPurgeableOrganizationsQuery"), deletedBefore, 10).
+			Return(assert.AnError)
+
+		_, err := repo.ListPurgeableOrganizations(context.Background(), deletedBefore, 10)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the purgeable organizations", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		deletedBefore := time.Now().UTC()
+		orgs := []organization.Organization{{ID: 1}, {ID: 2}}
+
+		mockDB.On("List", context.Background(), mock.Anything,
+			tests.QueryMatcher("listPurgeableOrganizationsQuery"), deletedBefore, 10).
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*[]organization.Organization)
+				require.True(t, ok)
+				*arg = orgs
+			}).Return(nil)
+
+		result, err := repo.ListPurgeableOrganizations(context.Background(), deletedBefore, 10)
+		require.NoError(t, err)
+		assert.Equal(t, orgs, result)
+	})
+}
+
+func TestRepository_PurgeOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("purgeOrganizationQuery"), int64(1)).
+			Return(assert.AnError)
+
+		err := repo.PurgeOrganization(context.Background(), 1)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the organization is purged", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("purgeOrganizationQuery"), int64(1)).
+			Return(nil)
+
+		err := repo.PurgeOrganization(context.Background(), 1)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepository_GetOrganizationBySubdomainAlias(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getOrganizationBySubdomainAliasQuery"), "org1").
+			Return(assert.AnError)
+
+		_, err := repo.GetOrganizationBySubdomainAlias(context.Background(), "org1")
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the organization of the alias", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		org := organization.Organization{ID: 1, Subdomain: "org2", Name: randomOrganizationName()}
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getOrganizationBySubdomainAliasQuery"), "org1").
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*organization.Organization)
+				require.True(t, ok)
+				*arg = org
+			}).Return(nil)
+
+		result, err := repo.GetOrganizationBySubdomainAlias(context.Background(), "org1")
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestRepository_IsSubdomainReserved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("isSubdomainReservedQuery"), "org1", int64(1)).
+			Return(assert.AnError)
+
+		_, err := repo.IsSubdomainReserved(context.Background(), "org1", 1)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return whether the subdomain is reserved", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("isSubdomainReservedQuery"), "org1", int64(1)).
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*bool)
+				require.True(t, ok)
+				*arg = true
+			}).Return(nil)
+
+		reserved, err := repo.IsSubdomainReserved(context.Background(), "org1", 1)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
+
+func TestRepository_ListAvailableSubdomains(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		mockDB.On("List", context.Background(), mock.Anything,
+			tests.QueryMatcher("listAvailableSubdomainsQuery"), "org1,org2").
+			Return(assert.AnError)
+
+		_, err := repo.ListAvailableSubdomains(context.Background(), []string{"org1", "org2"})
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return the available subdomains", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+
+		// the subdomains are passed as a comma separated list
+		mockDB.On("List", context.Background(), mock.Anything,
+			tests.QueryMatcher("listAvailableSubdomainsQuery"), "org1,org2").
+			Run(func(args mock.Arguments) {
+				arg, ok := args.Get(1).(*[]string)
+				require.True(t, ok)
+				*arg = []string{"org2"}
+			}).Return(nil)
+
+		available, err := repo.ListAvailableSubdomains(context.Background(), []string{"org1", "org2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"org2"}, available)
+	})
+}
+
+func TestRepository_ChangeSubdomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the database call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		expiresAt := time.Now().UTC()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("changeSubdomainQuery"), int64(1), "org1", expiresAt).
+			Return(assert.AnError)
+
+		err := repo.ChangeSubdomain(context.Background(), 1, "org1", expiresAt)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the subdomain is changed", func(t *testing.T) {
+		t.Parallel()
+
+		mockDB := database.NewMockDatabase(t)
+		repo := organization.NewRepository(mockDB)
+		expiresAt := time.Now().UTC()
+
+		mockDB.On("Exec", context.Background(), nil,
+			tests.QueryMatcher("changeSubdomainQuery"), int64(1), "org1", expiresAt).
+			Return(nil)
+
+		err := repo.ChangeSubdomain(context.Background(), 1, "org1", expiresAt)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package organization
+
+import (
+	"bufio"
+	_ "embed"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+)
+
+// reservedSubdomainsList is the bundled list of the subdomains which can not be used by an organization,
+// i.e. the names used by the platform itself and the offensive words. one lowercase subdomain per line.
+//
+//go:embed reserved_subdomains.txt
+var reservedSubdomainsList string
+
+// bundledReservedSubdomains returns the set of the bundled reserved subdomains.
+// The list is parsed once on the first use.
+//
+//nolint:gochecknoglobals // the parsed list is shared by all the services
+var bundledReservedSubdomains = sync.OnceValue(func() map[string]struct{} {
+	subdomains := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(strings.NewReader(reservedSubdomainsList))
+	for scanner.Scan() {
+		if s := strings.TrimSpace(scanner.Text()); s != "" {
+			subdomains[strings.ToLower(s)] = struct{}{}
+		}
+	}
+
+	return subdomains
+})
+
+// reservedSubdomains returns the set of the bundled reserved subdomains
+// along with the reserved subdomains configured in addition.
+func reservedSubdomains(conf config.Config) map[string]struct{} {
+	subdomains := maps.Clone(bundledReservedSubdomains())
+
+	for _, s := range strings.Split(conf.OrgReservedSubdomains, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			subdomains[strings.ToLower(s)] = struct{}{}
+		}
+	}
+
+	return subdomains
+}
@@ -4,44 +4,102 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 )
 
+var ErrSubdomainUnavailable = errors.New("subdomain is already used by another organization")
+
+// Service manages the organizations. The subdomains are case insensitive and kept in lowercase.
 type Service interface {
 	// GetOrganizationByID returns an organization by its ID.
 	GetOrganizationByID(ctx context.Context, id int64) (Organization, error)
 
+	// GetDeletedOrganizationByID returns a soft deleted organization by its ID.
+	GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error)
+
 	// GetOrganizationBySubdomain returns an organization by its subdomain.
 	GetOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error)
 
+	// GetDeletedOrganizationBySubdomain returns a soft deleted organization by its subdomain.
+	GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error)
+
+	// GetOrganizationBySubdomainAlias returns an organization by an unexpired alias of its former subdomain.
+	GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error)
+
 	// GetOrganizationByName returns an organization by its name.
 	GetOrganizationByName(ctx context.Context, name string) (Organization, error)
 
-	// CreateOrganization creates a new organization.
+	// CheckSubdomainAvailability returns whether the subdomain can be used by a new organization.
+	// The subdomain is not available when it is reserved or used by an organization, including the deleted ones,
+	// or by an unexpired alias. The available alternatives are suggested for an unavailable subdomain.
+	CheckSubdomainAvailability(ctx context.Context, subdomain string) (SubdomainAvailability, error)
+
+	// CreateOrganization creates a new organization. The reserved subdomains are rejected.
 	CreateOrganization(ctx context.Context, subdomain string, name string) (Organization, error)
 
 	// UpdateOrganization updates an organization.
 	UpdateOrganization(ctx context.Context, id int64, name string) error
 
+	// ChangeSubdomain changes the subdomain of an organization by its ID.
+	// The former subdomain is kept as an alias of the organization for the SubdomainAliasTTL.
+	// The organization can reclaim its own alias. ErrSubdomainUnavailable is returned when the subdomain
+	// is used by another organization or reserved by an alias of another organization.
+	// The reserved subdomains are rejected.
+	ChangeSubdomain(ctx context.Context, id int64, subdomain string) error
+
 	// DeleteOrganization deletes an organization by its ID.
 	DeleteOrganization(ctx context.Context, id int64, comment string) error
 
 	// SuspendOrganization suspends an organization by its ID.
+	// All the sessions of the organization are revoked.
 	SuspendOrganization(ctx context.Context, id int64, comment string) error
 
 	// UnsuspendOrganization unsuspend an organization by its ID.
 	UnsuspendOrganization(ctx context.Context, id int64, comment string) error
+
+	// IsSuspended returns whether the organization is suspended. The status is cached for the StatusCacheTTL.
+	IsSuspended(ctx context.Context, id int64) (bool, error)
+
+	// RestoreOrganization restores a soft deleted organization by its ID.
+	// The users deleted along with the organization are restored as well.
+	RestoreOrganization(ctx context.Context, id int64, comment string) error
+
+	// SetMFARequired sets whether the users of the organization must use mfa to login.
+	SetMFARequired(ctx context.Context, id int64, required bool) error
+
+	// SetMagicLinkEnabled sets whether the users of the organization can login using a link mailed to them.
+	SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error
+
+	// SetImpersonationAllowed sets whether the platform operators can impersonate the users of the organization.
+	SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error
+
+	// PurgeOrganizations hard deletes up to the given number of organizations deleted before the given time
+	// and returns the number of the purged organizations. It stops at the first organization failed to purge.
+	PurgeOrganizations(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
 }
 
 type service struct {
-	repo           Repository
-	sessionManager session.SessionManager
+	repo               Repository
+	sessionManager     session.SessionManager
+	statusCache        StatusCache
+	subdomainAliasTTL  time.Duration
+	reservedSubdomains map[string]struct{}
 }
 
-func NewService(repo Repository, sessionManager session.SessionManager) Service {
-	return &service{repo, sessionManager}
+func NewService(
+	conf config.Config,
+	repo Repository,
+	sessionManager session.SessionManager,
+	statusCache StatusCache,
+) Service {
+	return &service{repo, sessionManager, statusCache, SubdomainAliasTTL(conf), reservedSubdomains(conf)}
 }
 
 func (s *service) GetOrganizationByID(ctx context.Context, id int64) (Organization, error) {
@@ -53,7 +111,17 @@ func (s *service) GetOrganizationByID(ctx context.Context, id int64) (Organizati
 	return o, err
 }
 
+func (s *service) GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error) {
+	o, err := s.repo.GetDeletedOrganizationByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Organization{}, base.NewNotFoundError("deleted organization not found for the given id")
+	}
+
+	return o, err
+}
+
 func (s *service) GetOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
+	subdomain = strings.ToLower(subdomain)
 	if err := ValidateSubdomain(subdomain); err != nil {
 		return Organization{}, err
 	}
@@ -66,6 +134,34 @@ func (s *service) GetOrganizationBySubdomain(ctx context.Context, subdomain stri
 	return o, err
 }
 
+func (s *service) GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
+	subdomain = strings.ToLower(subdomain)
+	if err := ValidateSubdomain(subdomain); err != nil {
+		return Organization{}, err
+	}
+
+	o, err := s.repo.GetDeletedOrganizationBySubdomain(ctx, subdomain)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Organization{}, base.NewNotFoundError("deleted organization not found for the given subdomain")
+	}
+
+	return o, err
+}
+
+func (s *service) GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error) {
+	subdomain = strings.ToLower(subdomain)
+	if err := ValidateSubdomain(subdomain); err != nil {
+		return Organization{}, err
+	}
+
+	o, err := s.repo.GetOrganizationBySubdomainAlias(ctx, subdomain)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Organization{}, base.NewNotFoundError("organization not found for the given subdomain alias")
+	}
+
+	return o, err
+}
+
 func (s *service) GetOrganizationByName(ctx context.Context, name string) (Organization, error) {
 	if err := ValidateOrgName(name); err != nil {
 		return Organization{}, err
@@ -79,8 +175,45 @@ func (s *service) GetOrganizationByName(ctx context.Context, name string) (Organ
 	return o, err
 }
 
-func (s *service) CreateOrganization(ctx context.Context, subdomain string, name string) (Organization, error) {
+func (s *service) CheckSubdomainAvailability(ctx context.Context, subdomain string) (SubdomainAvailability, error) {
+	subdomain = strings.ToLower(subdomain)
 	if err := ValidateSubdomain(subdomain); err != nil {
+		return SubdomainAvailability{}, err
+	}
+
+	_, reserved := s.reservedSubdomains[subdomain]
+
+	// the subdomain is checked along with its alternatives using a single query
+	candidates := s.suggestSubdomains(subdomain)
+	if !reserved {
+		candidates = append([]string{subdomain}, candidates...)
+	}
+
+	available, err := s.repo.ListAvailableSubdomains(ctx, candidates)
+	if err != nil {
+		return SubdomainAvailability{}, err
+	}
+
+	a := SubdomainAvailability{Subdomain: subdomain, Suggestions: []string{}}
+
+	switch {
+	case reserved:
+		a.Reason = SubdomainReasonReserved
+	case len(available) > 0 && available[0] == subdomain:
+		a.Available = true
+		return a, nil
+	default:
+		a.Reason = SubdomainReasonTaken
+	}
+
+	a.Suggestions = available[:min(len(available), MaxSubdomainSuggestions)]
+
+	return a, nil
+}
+
+func (s *service) CreateOrganization(ctx context.Context, subdomain string, name string) (Organization, error) {
+	subdomain = strings.ToLower(subdomain)
+	if err := s.validateNewSubdomain(subdomain); err != nil {
 		return Organization{}, err
 	}
 
@@ -99,6 +232,33 @@ func (s *service) UpdateOrganization(ctx context.Context, id int64, name string)
 	return s.repo.UpdateOrganization(ctx, id, name)
 }
 
+func (s *service) ChangeSubdomain(ctx context.Context, id int64, subdomain string) error {
+	subdomain = strings.ToLower(subdomain)
+	if err := s.validateNewSubdomain(subdomain); err != nil {
+		return err
+	}
+
+	o, err := s.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if o.Subdomain == subdomain {
+		return base.NewInputValidationError("subdomain must be different from the current subdomain")
+	}
+
+	reserved, err := s.repo.IsSubdomainReserved(ctx, subdomain, id)
+	if err != nil {
+		return err
+	}
+
+	if reserved {
+		return ErrSubdomainUnavailable
+	}
+
+	return s.repo.ChangeSubdomain(ctx, id, subdomain, time.Now().UTC().Add(s.subdomainAliasTTL))
+}
+
 func (s *service) DeleteOrganization(ctx context.Context, id int64, comment string) error {
 	if err := ValidateComment(comment); err != nil {
 		return err
@@ -116,7 +276,17 @@ func (s *service) SuspendOrganization(ctx context.Context, id int64, comment str
 		return err
 	}
 
-	return s.repo.SuspendOrganization(ctx, id, comment)
+	if err := s.repo.SuspendOrganization(ctx, id, comment); err != nil {
+		return err
+	}
+
+	// update the cached status before revoking the sessions
+	// so that the requests in flight are rejected as well
+	if err := s.statusCache.SetSuspended(ctx, id, true); err != nil {
+		return err
+	}
+
+	return s.sessionManager.DeleteAllOrgSessions(ctx, id)
 }
 
 func (s *service) UnsuspendOrganization(ctx context.Context, id int64, comment string) error {
@@ -124,5 +294,103 @@ func (s *service) UnsuspendOrganization(ctx context.Context, id int64, comment s
 		return err
 	}
 
-	return s.repo.UnsuspendOrganization(ctx, id, comment)
+	if err := s.repo.UnsuspendOrganization(ctx, id, comment); err != nil {
+		return err
+	}
+
+	return s.statusCache.SetSuspended(ctx, id, false)
+}
+
+func (s *service) IsSuspended(ctx context.Context, id int64) (bool, error) {
+	suspended, err := s.statusCache.GetSuspended(ctx, id)
+	if err == nil {
+		return suspended, nil
+	}
+
+	if !errors.Is(err, ErrStatusCacheMiss) {
+		return false, err
+	}
+
+	o, err := s.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.statusCache.SetSuspended(ctx, id, o.IsSuspended()); err != nil {
+		return false, err
+	}
+
+	return o.IsSuspended(), nil
+}
+
+func (s *service) RestoreOrganization(ctx context.Context, id int64, comment string) error {
+	if err := ValidateComment(comment); err != nil {
+		return err
+	}
+
+	return s.repo.RestoreOrganization(ctx, id, comment)
+}
+
+func (s *service) SetMFARequired(ctx context.Context, id int64, required bool) error {
+	return s.repo.SetMFARequired(ctx, id, required)
+}
+
+func (s *service) SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error {
+	return s.repo.SetMagicLinkEnabled(ctx, id, enabled)
+}
+
+func (s *service) SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error {
+	return s.repo.SetImpersonationAllowed(ctx, id, allowed)
+}
+
+func (s *service) PurgeOrganizations(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	orgs, err := s.repo.ListPurgeableOrganizations(ctx, deletedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+
+	for _, o := range orgs {
+		if err := s.repo.PurgeOrganization(ctx, o.ID); err != nil {
+			return purged, fmt.Errorf("failed to purge organization %d: %w", o.ID, err)
+		}
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+// validateNewSubdomain validates the subdomain to be used by an organization.
+func (s *service) validateNewSubdomain(subdomain string) error {
+	if err := ValidateSubdomain(subdomain); err != nil {
+		return err
+	}
+
+	if _, reserved := s.reservedSubdomains[subdomain]; reserved {
+		return base.NewInputValidationError("subdomain is reserved")
+	}
+
+	return nil
+}
+
+// suggestSubdomains returns the alternatives of the subdomain which are not reserved.
+// The subdomain is shortened as needed to keep the alternatives within the maximum length.
+func (s *service) suggestSubdomains(subdomain string) []string {
+	const maxLength = 30
+
+	suggestions := make([]string, 0, len(subdomainSuggestionSuffixes))
+
+	for _, suffix := range subdomainSuggestionSuffixes {
+		suggestion := subdomain[:min(len(subdomain), maxLength-len(suffix))] + suffix
+		if _, reserved := s.reservedSubdomains[suggestion]; reserved ||
+			suggestion == subdomain || slices.Contains(suggestions, suggestion) {
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
 }
@@ -14,7 +14,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_GetOrganizationByID() {
 	s.Run("should return organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, nil)
 		org := fake.NewOrganization(s.DB)
 
 		result, err := svc.GetOrganizationByID(context.Background(), org.ID)
@@ -34,7 +34,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_GetOrganizationBySubdomai
 	s.Run("should return organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, nil)
 		org := fake.NewOrganization(s.DB)
 
 		result, err := svc.GetOrganizationBySubdomain(context.Background(), org.Subdomain)
@@ -53,7 +53,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_GetOrganizationByName() {
 	s.Run("should return organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, nil)
 		org := fake.NewOrganization(s.DB)
 
 		result, err := svc.GetOrganizationByName(context.Background(), org.Name)
@@ -72,7 +72,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_CreateOrganization() {
 	s.Run("should create organization with default values", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, nil)
 		org := organization.Organization{
 			Subdomain: randomOrganizationSubdomain(),
 			Name:      randomOrganizationName(),
@@ -95,7 +95,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_UpdateOrganization() {
 	s.Run("should update organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, nil)
 		org := fake.NewOrganization(s.DB)
 		newOrgName := randomOrganizationName()
 
@@ -119,7 +119,7 @@ func (s *OrganizationTestSuite) TestServiceIntegration_DeleteOrganization() {
 
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, sessionManager)
+		svc := organization.NewService(s.Config, repo, sessionManager, nil)
 		org := fake.NewOrganization(s.DB)
 		u1 := fake.NewUser(s.DB, org.ID)
 		u2 := fake.NewUser(s.DB, org.ID)
@@ -155,12 +155,17 @@ func (s *OrganizationTestSuite) TestServiceIntegration_SuspendOrganization() {
 	s.Run("should suspend organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+		svc := organization.NewService(s.Config, repo, sessionManager, organization.NewRedisStatusCache(s.RedisClient))
 		org := fake.NewOrganization(s.DB)
 
 		err := svc.SuspendOrganization(context.Background(), org.ID, "test suspend")
 		s.Require().NoError(err)
 
+		suspended, err := svc.IsSuspended(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.True(suspended)
+
 		result := org.FetchLatest(s.DB)
 		s.Require().NotNil(result.Comment)
 		s.Equal("test suspend", *result.Comment)
@@ -175,12 +180,16 @@ func (s *OrganizationTestSuite) TestServiceIntegration_UnsuspendOrganization() {
 	s.Run("should unsuspend organization", func() {
 		s.T().Parallel()
 		repo := organization.NewRepository(s.DB)
-		svc := organization.NewService(repo, nil)
+		svc := organization.NewService(s.Config, repo, nil, organization.NewRedisStatusCache(s.RedisClient))
 		org := fake.NewOrganization(s.DB, fake.OrganizationSuspended())
 
 		err := svc.UnsuspendOrganization(context.Background(), org.ID, "test unsuspend")
 		s.Require().NoError(err)
 
+		suspended, err := svc.IsSuspended(context.Background(), org.ID)
+		s.Require().NoError(err)
+		s.False(suspended)
+
 		result := org.FetchLatest(s.DB)
 		s.Require().NotNil(result.Comment)
 		s.Equal("test unsuspend", *result.Comment)
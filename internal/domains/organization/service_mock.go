@@ -4,6 +4,7 @@ package organization
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -21,6 +22,111 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// ChangeSubdomain provides a mock function with given fields: ctx, id, subdomain
+func (_m *MockService) ChangeSubdomain(ctx context.Context, id int64, subdomain string) error {
+	ret := _m.Called(ctx, id, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeSubdomain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, subdomain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ChangeSubdomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeSubdomain'
+type MockService_ChangeSubdomain_Call struct {
+	*mock.Call
+}
+
+// ChangeSubdomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - subdomain string
+func (_e *MockService_Expecter) ChangeSubdomain(ctx interface{}, id interface{}, subdomain interface{}) *MockService_ChangeSubdomain_Call {
+	return &MockService_ChangeSubdomain_Call{Call: _e.mock.On("ChangeSubdomain", ctx, id, subdomain)}
+}
+
+func (_c *MockService_ChangeSubdomain_Call) Run(run func(ctx context.Context, id int64, subdomain string)) *MockService_ChangeSubdomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ChangeSubdomain_Call) Return(_a0 error) *MockService_ChangeSubdomain_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ChangeSubdomain_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockService_ChangeSubdomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckSubdomainAvailability provides a mock function with given fields: ctx, subdomain
+func (_m *MockService) CheckSubdomainAvailability(ctx context.Context, subdomain string) (SubdomainAvailability, error) {
+	ret := _m.Called(ctx, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSubdomainAvailability")
+	}
+
+	var r0 SubdomainAvailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (SubdomainAvailability, error)); ok {
+		return rf(ctx, subdomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) SubdomainAvailability); ok {
+		r0 = rf(ctx, subdomain)
+	} else {
+		r0 = ret.Get(0).(SubdomainAvailability)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subdomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CheckSubdomainAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSubdomainAvailability'
+type MockService_CheckSubdomainAvailability_Call struct {
+	*mock.Call
+}
+
+// CheckSubdomainAvailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+func (_e *MockService_Expecter) CheckSubdomainAvailability(ctx interface{}, subdomain interface{}) *MockService_CheckSubdomainAvailability_Call {
+	return &MockService_CheckSubdomainAvailability_Call{Call: _e.mock.On("CheckSubdomainAvailability", ctx, subdomain)}
+}
+
+func (_c *MockService_CheckSubdomainAvailability_Call) Run(run func(ctx context.Context, subdomain string)) *MockService_CheckSubdomainAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_CheckSubdomainAvailability_Call) Return(_a0 SubdomainAvailability, _a1 error) *MockService_CheckSubdomainAvailability_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CheckSubdomainAvailability_Call) RunAndReturn(run func(context.Context, string) (SubdomainAvailability, error)) *MockService_CheckSubdomainAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOrganization provides a mock function with given fields: ctx, subdomain, name
 func (_m *MockService) CreateOrganization(ctx context.Context, subdomain string, name string) (Organization, error) {
 	ret := _m.Called(ctx, subdomain, name)
@@ -127,6 +233,120 @@ func (_c *MockService_DeleteOrganization_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetDeletedOrganizationByID provides a mock function with given fields: ctx, id
+func (_m *MockService) GetDeletedOrganizationByID(ctx context.Context, id int64) (Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedOrganizationByID")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetDeletedOrganizationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOrganizationByID'
+type MockService_GetDeletedOrganizationByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedOrganizationByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockService_Expecter) GetDeletedOrganizationByID(ctx interface{}, id interface{}) *MockService_GetDeletedOrganizationByID_Call {
+	return &MockService_GetDeletedOrganizationByID_Call{Call: _e.mock.On("GetDeletedOrganizationByID", ctx, id)}
+}
+
+func (_c *MockService_GetDeletedOrganizationByID_Call) Run(run func(ctx context.Context, id int64)) *MockService_GetDeletedOrganizationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetDeletedOrganizationByID_Call) Return(_a0 Organization, _a1 error) *MockService_GetDeletedOrganizationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetDeletedOrganizationByID_Call) RunAndReturn(run func(context.Context, int64) (Organization, error)) *MockService_GetDeletedOrganizationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedOrganizationBySubdomain provides a mock function with given fields: ctx, subdomain
+func (_m *MockService) GetDeletedOrganizationBySubdomain(ctx context.Context, subdomain string) (Organization, error) {
+	ret := _m.Called(ctx, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedOrganizationBySubdomain")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Organization, error)); ok {
+		return rf(ctx, subdomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Organization); ok {
+		r0 = rf(ctx, subdomain)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subdomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetDeletedOrganizationBySubdomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOrganizationBySubdomain'
+type MockService_GetDeletedOrganizationBySubdomain_Call struct {
+	*mock.Call
+}
+
+// GetDeletedOrganizationBySubdomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+func (_e *MockService_Expecter) GetDeletedOrganizationBySubdomain(ctx interface{}, subdomain interface{}) *MockService_GetDeletedOrganizationBySubdomain_Call {
+	return &MockService_GetDeletedOrganizationBySubdomain_Call{Call: _e.mock.On("GetDeletedOrganizationBySubdomain", ctx, subdomain)}
+}
+
+func (_c *MockService_GetDeletedOrganizationBySubdomain_Call) Run(run func(ctx context.Context, subdomain string)) *MockService_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetDeletedOrganizationBySubdomain_Call) Return(_a0 Organization, _a1 error) *MockService_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetDeletedOrganizationBySubdomain_Call) RunAndReturn(run func(context.Context, string) (Organization, error)) *MockService_GetDeletedOrganizationBySubdomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOrganizationByID provides a mock function with given fields: ctx, id
 func (_m *MockService) GetOrganizationByID(ctx context.Context, id int64) (Organization, error) {
 	ret := _m.Called(ctx, id)
@@ -298,6 +518,370 @@ func (_c *MockService_GetOrganizationBySubdomain_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// GetOrganizationBySubdomainAlias provides a mock function with given fields: ctx, subdomain
+func (_m *MockService) GetOrganizationBySubdomainAlias(ctx context.Context, subdomain string) (Organization, error) {
+	ret := _m.Called(ctx, subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganizationBySubdomainAlias")
+	}
+
+	var r0 Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Organization, error)); ok {
+		return rf(ctx, subdomain)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Organization); ok {
+		r0 = rf(ctx, subdomain)
+	} else {
+		r0 = ret.Get(0).(Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subdomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetOrganizationBySubdomainAlias_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganizationBySubdomainAlias'
+type MockService_GetOrganizationBySubdomainAlias_Call struct {
+	*mock.Call
+}
+
+// GetOrganizationBySubdomainAlias is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subdomain string
+func (_e *MockService_Expecter) GetOrganizationBySubdomainAlias(ctx interface{}, subdomain interface{}) *MockService_GetOrganizationBySubdomainAlias_Call {
+	return &MockService_GetOrganizationBySubdomainAlias_Call{Call: _e.mock.On("GetOrganizationBySubdomainAlias", ctx, subdomain)}
+}
+
+func (_c *MockService_GetOrganizationBySubdomainAlias_Call) Run(run func(ctx context.Context, subdomain string)) *MockService_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_GetOrganizationBySubdomainAlias_Call) Return(_a0 Organization, _a1 error) *MockService_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetOrganizationBySubdomainAlias_Call) RunAndReturn(run func(context.Context, string) (Organization, error)) *MockService_GetOrganizationBySubdomainAlias_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsSuspended provides a mock function with given fields: ctx, id
+func (_m *MockService) IsSuspended(ctx context.Context, id int64) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSuspended")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_IsSuspended_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSuspended'
+type MockService_IsSuspended_Call struct {
+	*mock.Call
+}
+
+// IsSuspended is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockService_Expecter) IsSuspended(ctx interface{}, id interface{}) *MockService_IsSuspended_Call {
+	return &MockService_IsSuspended_Call{Call: _e.mock.On("IsSuspended", ctx, id)}
+}
+
+func (_c *MockService_IsSuspended_Call) Run(run func(ctx context.Context, id int64)) *MockService_IsSuspended_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_IsSuspended_Call) Return(_a0 bool, _a1 error) *MockService_IsSuspended_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_IsSuspended_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockService_IsSuspended_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeOrganizations provides a mock function with given fields: ctx, deletedBefore, limit
+func (_m *MockService) PurgeOrganizations(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	ret := _m.Called(ctx, deletedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOrganizations")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return rf(ctx, deletedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = rf(ctx, deletedBefore, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, deletedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_PurgeOrganizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeOrganizations'
+type MockService_PurgeOrganizations_Call struct {
+	*mock.Call
+}
+
+// PurgeOrganizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+//   - limit int
+func (_e *MockService_Expecter) PurgeOrganizations(ctx interface{}, deletedBefore interface{}, limit interface{}) *MockService_PurgeOrganizations_Call {
+	return &MockService_PurgeOrganizations_Call{Call: _e.mock.On("PurgeOrganizations", ctx, deletedBefore, limit)}
+}
+
+func (_c *MockService_PurgeOrganizations_Call) Run(run func(ctx context.Context, deletedBefore time.Time, limit int)) *MockService_PurgeOrganizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockService_PurgeOrganizations_Call) Return(_a0 int, _a1 error) *MockService_PurgeOrganizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_PurgeOrganizations_Call) RunAndReturn(run func(context.Context, time.Time, int) (int, error)) *MockService_PurgeOrganizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreOrganization provides a mock function with given fields: ctx, id, comment
+func (_m *MockService) RestoreOrganization(ctx context.Context, id int64, comment string) error {
+	ret := _m.Called(ctx, id, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreOrganization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_RestoreOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreOrganization'
+type MockService_RestoreOrganization_Call struct {
+	*mock.Call
+}
+
+// RestoreOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - comment string
+func (_e *MockService_Expecter) RestoreOrganization(ctx interface{}, id interface{}, comment interface{}) *MockService_RestoreOrganization_Call {
+	return &MockService_RestoreOrganization_Call{Call: _e.mock.On("RestoreOrganization", ctx, id, comment)}
+}
+
+func (_c *MockService_RestoreOrganization_Call) Run(run func(ctx context.Context, id int64, comment string)) *MockService_RestoreOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RestoreOrganization_Call) Return(_a0 error) *MockService_RestoreOrganization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RestoreOrganization_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockService_RestoreOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetImpersonationAllowed provides a mock function with given fields: ctx, id, allowed
+func (_m *MockService) SetImpersonationAllowed(ctx context.Context, id int64, allowed bool) error {
+	ret := _m.Called(ctx, id, allowed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetImpersonationAllowed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, allowed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetImpersonationAllowed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetImpersonationAllowed'
+type MockService_SetImpersonationAllowed_Call struct {
+	*mock.Call
+}
+
+// SetImpersonationAllowed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - allowed bool
+func (_e *MockService_Expecter) SetImpersonationAllowed(ctx interface{}, id interface{}, allowed interface{}) *MockService_SetImpersonationAllowed_Call {
+	return &MockService_SetImpersonationAllowed_Call{Call: _e.mock.On("SetImpersonationAllowed", ctx, id, allowed)}
+}
+
+func (_c *MockService_SetImpersonationAllowed_Call) Run(run func(ctx context.Context, id int64, allowed bool)) *MockService_SetImpersonationAllowed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SetImpersonationAllowed_Call) Return(_a0 error) *MockService_SetImpersonationAllowed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetImpersonationAllowed_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockService_SetImpersonationAllowed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMFARequired provides a mock function with given fields: ctx, id, required
+func (_m *MockService) SetMFARequired(ctx context.Context, id int64, required bool) error {
+	ret := _m.Called(ctx, id, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMFARequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetMFARequired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMFARequired'
+type MockService_SetMFARequired_Call struct {
+	*mock.Call
+}
+
+// SetMFARequired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - required bool
+func (_e *MockService_Expecter) SetMFARequired(ctx interface{}, id interface{}, required interface{}) *MockService_SetMFARequired_Call {
+	return &MockService_SetMFARequired_Call{Call: _e.mock.On("SetMFARequired", ctx, id, required)}
+}
+
+func (_c *MockService_SetMFARequired_Call) Run(run func(ctx context.Context, id int64, required bool)) *MockService_SetMFARequired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SetMFARequired_Call) Return(_a0 error) *MockService_SetMFARequired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetMFARequired_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockService_SetMFARequired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMagicLinkEnabled provides a mock function with given fields: ctx, id, enabled
+func (_m *MockService) SetMagicLinkEnabled(ctx context.Context, id int64, enabled bool) error {
+	ret := _m.Called(ctx, id, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMagicLinkEnabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetMagicLinkEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMagicLinkEnabled'
+type MockService_SetMagicLinkEnabled_Call struct {
+	*mock.Call
+}
+
+// SetMagicLinkEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - enabled bool
+func (_e *MockService_Expecter) SetMagicLinkEnabled(ctx interface{}, id interface{}, enabled interface{}) *MockService_SetMagicLinkEnabled_Call {
+	return &MockService_SetMagicLinkEnabled_Call{Call: _e.mock.On("SetMagicLinkEnabled", ctx, id, enabled)}
+}
+
+func (_c *MockService_SetMagicLinkEnabled_Call) Run(run func(ctx context.Context, id int64, enabled bool)) *MockService_SetMagicLinkEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_SetMagicLinkEnabled_Call) Return(_a0 error) *MockService_SetMagicLinkEnabled_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetMagicLinkEnabled_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockService_SetMagicLinkEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SuspendOrganization provides a mock function with given fields: ctx, id, comment
 func (_m *MockService) SuspendOrganization(ctx context.Context, id int64, comment string) error {
 	ret := _m.Called(ctx, id, comment)
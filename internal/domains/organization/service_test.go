@@ -3,13 +3,17 @@ package organization_test
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,7 +24,7 @@ func TestService_GetOrganizationByID(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		mockRepo.On("GetOrganizationByID", context.Background(), int64(1)).
 			Return(organization.Organization{}, assert.AnError)
@@ -36,7 +40,7 @@ func TestService_GetOrganizationByID(t *testing.T) {
 		var notFoundErr *base.NotFoundError
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		mockRepo.On("GetOrganizationByID", context.Background(), int64(1)).
 			Return(organization.Organization{}, sql.ErrNoRows)
@@ -50,7 +54,7 @@ func TestService_GetOrganizationByID(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		org := organization.Organization{
 			ID:        1,
@@ -74,7 +78,7 @@ func TestService_GetOrganizationBySubdomain(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		subdomain := "#invalid-subdomain"
 
 		_, err := service.GetOrganizationBySubdomain(context.Background(), subdomain)
@@ -86,7 +90,7 @@ func TestService_GetOrganizationBySubdomain(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgSubdomain := randomOrganizationSubdomain()
 
 		mockRepo.On("GetOrganizationBySubdomain", context.Background(), orgSubdomain).
@@ -103,7 +107,7 @@ func TestService_GetOrganizationBySubdomain(t *testing.T) {
 		var notFoundErr *base.NotFoundError
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgSubdomain := randomOrganizationSubdomain()
 
 		mockRepo.On("GetOrganizationBySubdomain", context.Background(), orgSubdomain).
@@ -118,7 +122,7 @@ func TestService_GetOrganizationBySubdomain(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgSubdomain := randomOrganizationSubdomain()
 
 		org := organization.Organization{
@@ -133,6 +137,89 @@ func TestService_GetOrganizationBySubdomain(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, org, result)
 	})
+
+	t.Run("should look up the subdomain case insensitively", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		org := organization.Organization{ID: 1, Subdomain: "acme"}
+
+		mockRepo.On("GetOrganizationBySubdomain", context.Background(), "acme").Return(org, nil)
+
+		result, err := service.GetOrganizationBySubdomain(context.Background(), "ACME")
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestService_GetDeletedOrganizationBySubdomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the subdomain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		_, err := service.GetDeletedOrganizationBySubdomain(context.Background(), "#invalid-subdomain")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "subdomain can only contain alphanumeric characters")
+	})
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgSubdomain := randomOrganizationSubdomain()
+
+		mockRepo.On("GetDeletedOrganizationBySubdomain", context.Background(), orgSubdomain).
+			Return(organization.Organization{}, assert.AnError)
+
+		_, err := service.GetDeletedOrganizationBySubdomain(context.Background(), orgSubdomain)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return an error when the deleted organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		var notFoundErr *base.NotFoundError
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgSubdomain := randomOrganizationSubdomain()
+
+		mockRepo.On("GetDeletedOrganizationBySubdomain", context.Background(), orgSubdomain).
+			Return(organization.Organization{}, sql.ErrNoRows)
+
+		_, err := service.GetDeletedOrganizationBySubdomain(context.Background(), orgSubdomain)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("should return the deleted organization by subdomain", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgSubdomain := randomOrganizationSubdomain()
+
+		now := time.Now().UTC()
+		org := organization.Organization{
+			ID:         1,
+			Subdomain:  orgSubdomain,
+			Timestamps: base.Timestamps{DeletedAt: &now},
+		}
+
+		mockRepo.On("GetDeletedOrganizationBySubdomain", context.Background(), orgSubdomain).
+			Return(org, nil)
+
+		result, err := service.GetDeletedOrganizationBySubdomain(context.Background(), orgSubdomain)
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
 }
 
 func TestService_GetOrganizationByName(t *testing.T) {
@@ -142,7 +229,7 @@ func TestService_GetOrganizationByName(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgName := "ørg1-non-ascii"
 
 		_, err := service.GetOrganizationByName(context.Background(), orgName)
@@ -154,7 +241,7 @@ func TestService_GetOrganizationByName(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgName := randomOrganizationName()
 
 		mockRepo.On("GetOrganizationByName", context.Background(), orgName).
@@ -171,7 +258,7 @@ func TestService_GetOrganizationByName(t *testing.T) {
 		var notFoundErr *base.NotFoundError
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgName := randomOrganizationName()
 
 		mockRepo.On("GetOrganizationByName", context.Background(), orgName).
@@ -186,7 +273,7 @@ func TestService_GetOrganizationByName(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		org := organization.Organization{
 			ID:   1,
@@ -209,7 +296,7 @@ func TestService_CreateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		subdomain := "#invalid-subdomain"
 
 		_, err := service.CreateOrganization(context.Background(), subdomain, randomOrganizationName())
@@ -221,7 +308,7 @@ func TestService_CreateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgName := "ørg1"
 
 		_, err := service.CreateOrganization(context.Background(), randomOrganizationSubdomain(), orgName)
@@ -233,7 +320,7 @@ func TestService_CreateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		mockRepo.On("CreateOrganization", context.Background(), "sub1", "org1").
 			Return(organization.Organization{}, assert.AnError)
@@ -247,7 +334,7 @@ func TestService_CreateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 
 		org := organization.Organization{
 			Subdomain: randomOrganizationSubdomain(),
@@ -261,6 +348,138 @@ func TestService_CreateOrganization(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, org, result)
 	})
+
+	t.Run("should return an error when the subdomain is reserved", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{OrgReservedSubdomains: "Acme, beta"}, mockRepo, nil, nil)
+
+		// the bundled and the configured reserved subdomains are matched case insensitively
+		for _, subdomain := range []string{"www", "Admin", "acme", "BETA"} {
+			_, err := service.CreateOrganization(context.Background(), subdomain, randomOrganizationName())
+			require.Error(t, err, subdomain)
+			assert.True(t, base.IsInputValidationError(err))
+			assert.EqualError(t, err, "subdomain is reserved")
+		}
+	})
+
+	t.Run("should create the organization with the lowercase subdomain", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		org := organization.Organization{Subdomain: "acme", Name: randomOrganizationName()}
+
+		mockRepo.On("CreateOrganization", context.Background(), "acme", org.Name).Return(org, nil)
+
+		result, err := service.CreateOrganization(context.Background(), "Acme", org.Name)
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestService_CheckSubdomainAvailability(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the subdomain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		_, err := service.CheckSubdomainAvailability(context.Background(), "#invalid-subdomain")
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		mockRepo.On("ListAvailableSubdomains", context.Background(), mock.Anything).Return(nil, assert.AnError)
+
+		_, err := service.CheckSubdomainAvailability(context.Background(), "acme")
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return the lowercase subdomain available", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		candidates := []string{"acme", "acmehr", "acmeteam", "acmehq", "acmeinc", "acme1", "acme2", "acme3"}
+
+		mockRepo.On("ListAvailableSubdomains", context.Background(), candidates).Return(candidates, nil)
+
+		result, err := service.CheckSubdomainAvailability(context.Background(), "Acme")
+		require.NoError(t, err)
+		assert.Equal(t, organization.SubdomainAvailability{
+			Subdomain:   "acme",
+			Available:   true,
+			Suggestions: []string{},
+		}, result)
+	})
+
+	t.Run("should suggest the available alternatives when the subdomain is taken", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		mockRepo.On("ListAvailableSubdomains", context.Background(), mock.Anything).
+			Return([]string{"acmeteam", "acmeinc", "acme1", "acme3"}, nil)
+
+		result, err := service.CheckSubdomainAvailability(context.Background(), "acme")
+		require.NoError(t, err)
+		assert.Equal(t, organization.SubdomainAvailability{
+			Subdomain:   "acme",
+			Reason:      organization.SubdomainReasonTaken,
+			Suggestions: []string{"acmeteam", "acmeinc", "acme1"},
+		}, result)
+	})
+
+	t.Run("should suggest the available alternatives when the subdomain is reserved", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		candidates := []string{"adminhr", "adminteam", "adminhq", "admininc", "admin1", "admin2", "admin3"}
+
+		// the reserved subdomain itself is not looked up
+		mockRepo.On("ListAvailableSubdomains", context.Background(), candidates).Return(candidates, nil)
+
+		result, err := service.CheckSubdomainAvailability(context.Background(), "admin")
+		require.NoError(t, err)
+		assert.False(t, result.Available)
+		assert.Equal(t, organization.SubdomainReasonReserved, result.Reason)
+		assert.Equal(t, []string{"adminhr", "adminteam", "adminhq"}, result.Suggestions)
+	})
+
+	t.Run("should keep the suggestions within the maximum length", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		subdomain := strings.ToLower(gofakeit.LetterN(30))
+
+		mockRepo.On("ListAvailableSubdomains", context.Background(), mock.MatchedBy(func(candidates []string) bool {
+			for _, c := range candidates {
+				if len(c) > 30 {
+					return false
+				}
+			}
+
+			return len(candidates) == 8 && candidates[0] == subdomain && candidates[1] == subdomain[:28]+"hr"
+		})).Return([]string{}, nil)
+
+		result, err := service.CheckSubdomainAvailability(context.Background(), subdomain)
+		require.NoError(t, err)
+		assert.Equal(t, organization.SubdomainReasonTaken, result.Reason)
+		assert.Empty(t, result.Suggestions)
+	})
 }
 
 func TestService_UpdateOrganization(t *testing.T) {
@@ -270,7 +489,7 @@ func TestService_UpdateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		newOrgName := "ørg1"
 
@@ -283,7 +502,7 @@ func TestService_UpdateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		newOrgName := randomOrganizationName()
 
@@ -299,7 +518,7 @@ func TestService_UpdateOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		newOrgName := randomOrganizationName()
 
@@ -318,7 +537,7 @@ func TestService_DeleteOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 
 		err := service.DeleteOrganization(context.Background(), orgID, "")
@@ -331,7 +550,7 @@ func TestService_DeleteOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		comment := gofakeit.Sentence(5)
 
@@ -348,7 +567,7 @@ func TestService_DeleteOrganization(t *testing.T) {
 
 		mockRepo := organization.NewMockRepository(t)
 		mockSessionManager := session.NewMockSessionManager(t)
-		service := organization.NewService(mockRepo, mockSessionManager)
+		service := organization.NewService(config.Config{}, mockRepo, mockSessionManager, nil)
 		orgID := gofakeit.Int64()
 		comment := gofakeit.Sentence(5)
 
@@ -368,7 +587,7 @@ func TestService_DeleteOrganization(t *testing.T) {
 
 		mockRepo := organization.NewMockRepository(t)
 		mockSessionManager := session.NewMockSessionManager(t)
-		service := organization.NewService(mockRepo, mockSessionManager)
+		service := organization.NewService(config.Config{}, mockRepo, mockSessionManager, nil)
 		orgID := gofakeit.Int64()
 		comment := gofakeit.Sentence(5)
 
@@ -389,7 +608,7 @@ func TestService_SuspendOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 
 		err := service.SuspendOrganization(context.Background(), orgID, "")
@@ -402,7 +621,7 @@ func TestService_SuspendOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		comment := "test suspend"
 
@@ -414,16 +633,22 @@ func TestService_SuspendOrganization(t *testing.T) {
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return nil when the organization is suspended", func(t *testing.T) {
+	t.Run("should cache the status and revoke the sessions when the organization is suspended", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		mockSessionManager := session.NewMockSessionManager(t)
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, mockRepo, mockSessionManager, mockStatusCache)
 		orgID := gofakeit.Int64()
 		comment := "test suspend"
 
 		mockRepo.On("SuspendOrganization", context.Background(), orgID, comment).
 			Return(nil)
+		mockStatusCache.On("SetSuspended", context.Background(), orgID, true).
+			Return(nil)
+		mockSessionManager.On("DeleteAllOrgSessions", context.Background(), orgID).
+			Return(nil)
 
 		err := service.SuspendOrganization(context.Background(), orgID, comment)
 		require.NoError(t, err)
@@ -437,7 +662,7 @@ func TestService_UnsuspendOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 
 		err := service.UnsuspendOrganization(context.Background(), orgID, "")
@@ -450,7 +675,7 @@ func TestService_UnsuspendOrganization(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
 		orgID := gofakeit.Int64()
 		comment := "test unsuspend"
 
@@ -462,18 +687,443 @@ func TestService_UnsuspendOrganization(t *testing.T) {
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return nil when the organization is unsuspended", func(t *testing.T) {
+	t.Run("should cache the status when the organization is unsuspended", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := organization.NewMockRepository(t)
-		service := organization.NewService(mockRepo, nil)
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, mockStatusCache)
 		orgID := gofakeit.Int64()
 		comment := "test unsuspend"
 
 		mockRepo.On("UnsuspendOrganization", context.Background(), orgID, comment).
 			Return(nil)
+		mockStatusCache.On("SetSuspended", context.Background(), orgID, false).
+			Return(nil)
 
 		err := service.UnsuspendOrganization(context.Background(), orgID, comment)
 		require.NoError(t, err)
 	})
 }
+
+func TestService_IsSuspended(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the cached status", func(t *testing.T) {
+		t.Parallel()
+
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, nil, nil, mockStatusCache)
+		orgID := gofakeit.Int64()
+
+		mockStatusCache.On("GetSuspended", context.Background(), orgID).
+			Return(true, nil)
+
+		suspended, err := service.IsSuspended(context.Background(), orgID)
+		require.NoError(t, err)
+		assert.True(t, suspended)
+	})
+
+	t.Run("should load and cache the status on cache miss", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, mockStatusCache)
+		suspendedAt := time.Now().UTC()
+		org := organization.Organization{ID: gofakeit.Int64(), SuspendedAt: &suspendedAt}
+
+		mockStatusCache.On("GetSuspended", context.Background(), org.ID).
+			Return(false, organization.ErrStatusCacheMiss)
+		mockRepo.On("GetOrganizationByID", context.Background(), org.ID).
+			Return(org, nil)
+		mockStatusCache.On("SetSuspended", context.Background(), org.ID, true).
+			Return(nil)
+
+		suspended, err := service.IsSuspended(context.Background(), org.ID)
+		require.NoError(t, err)
+		assert.True(t, suspended)
+	})
+
+	t.Run("should return an error when the organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, mockStatusCache)
+		orgID := gofakeit.Int64()
+
+		mockStatusCache.On("GetSuspended", context.Background(), orgID).
+			Return(false, organization.ErrStatusCacheMiss)
+		mockRepo.On("GetOrganizationByID", context.Background(), orgID).
+			Return(organization.Organization{}, sql.ErrNoRows)
+
+		_, err := service.IsSuspended(context.Background(), orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return an error when the cache call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockStatusCache := organization.NewMockStatusCache(t)
+		service := organization.NewService(config.Config{}, nil, nil, mockStatusCache)
+		orgID := gofakeit.Int64()
+
+		mockStatusCache.On("GetSuspended", context.Background(), orgID).
+			Return(false, assert.AnError)
+
+		_, err := service.IsSuspended(context.Background(), orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestService_GetDeletedOrganizationByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		mockRepo.On("GetDeletedOrganizationByID", context.Background(), int64(1)).
+			Return(organization.Organization{}, assert.AnError)
+
+		_, err := service.GetDeletedOrganizationByID(context.Background(), int64(1))
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return an error when the deleted organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		var notFoundErr *base.NotFoundError
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		mockRepo.On("GetDeletedOrganizationByID", context.Background(), int64(1)).
+			Return(organization.Organization{}, sql.ErrNoRows)
+
+		_, err := service.GetDeletedOrganizationByID(context.Background(), int64(1))
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("should return the deleted organization by ID", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		now := time.Now().UTC()
+		org := organization.Organization{
+			ID:         1,
+			Subdomain:  randomOrganizationSubdomain(),
+			Name:       randomOrganizationName(),
+			Timestamps: base.Timestamps{DeletedAt: &now},
+		}
+
+		mockRepo.On("GetDeletedOrganizationByID", context.Background(), org.ID).
+			Return(org, nil)
+
+		result, err := service.GetDeletedOrganizationByID(context.Background(), org.ID)
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestService_RestoreOrganization(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when comment is empty", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		err := service.RestoreOrganization(context.Background(), gofakeit.Int64(), "")
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+		assert.ErrorContains(t, err, "comment is required")
+	})
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+		comment := "test restore"
+
+		mockRepo.On("RestoreOrganization", context.Background(), orgID, comment).
+			Return(assert.AnError)
+
+		err := service.RestoreOrganization(context.Background(), orgID, comment)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the organization is restored", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+		comment := "test restore"
+
+		mockRepo.On("RestoreOrganization", context.Background(), orgID, comment).
+			Return(nil)
+
+		err := service.RestoreOrganization(context.Background(), orgID, comment)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_SetMFARequired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+
+		mockRepo.On("SetMFARequired", context.Background(), orgID, true).
+			Return(assert.AnError)
+
+		err := service.SetMFARequired(context.Background(), orgID, true)
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should return nil when the mfa requirement is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+
+		mockRepo.On("SetMFARequired", context.Background(), orgID, false).
+			Return(nil)
+
+		err := service.SetMFARequired(context.Background(), orgID, false)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_SetMagicLinkEnabled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the magic link login is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+
+		mockRepo.On("SetMagicLinkEnabled", context.Background(), orgID, true).
+			Return(nil)
+
+		err := service.SetMagicLinkEnabled(context.Background(), orgID, true)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_SetImpersonationAllowed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when the impersonation allowance is updated", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		orgID := gofakeit.Int64()
+
+		mockRepo.On("SetImpersonationAllowed", context.Background(), orgID, true).
+			Return(nil)
+
+		err := service.SetImpersonationAllowed(context.Background(), orgID, true)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_PurgeOrganizations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when listing the organizations fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		deletedBefore := time.Now().UTC()
+
+		mockRepo.On("ListPurgeableOrganizations", context.Background(), deletedBefore, 10).
+			Return(nil, assert.AnError)
+
+		purged, err := service.PurgeOrganizations(context.Background(), deletedBefore, 10)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, purged)
+	})
+
+	t.Run("should stop at the organization failed to purge", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		deletedBefore := time.Now().UTC()
+
+		mockRepo.On("ListPurgeableOrganizations", context.Background(), deletedBefore, 10).
+			Return([]organization.Organization{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+		mockRepo.On("PurgeOrganization", context.Background(), int64(1)).Return(nil)
+		mockRepo.On("PurgeOrganization", context.Background(), int64(2)).Return(assert.AnError)
+
+		purged, err := service.PurgeOrganizations(context.Background(), deletedBefore, 10)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "failed to purge organization 2")
+		assert.Equal(t, 1, purged)
+	})
+
+	t.Run("should purge the organizations deleted before the given time", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		deletedBefore := time.Now().UTC()
+
+		mockRepo.On("ListPurgeableOrganizations", context.Background(), deletedBefore, 10).
+			Return([]organization.Organization{{ID: 1}, {ID: 2}}, nil)
+		mockRepo.On("PurgeOrganization", context.Background(), int64(1)).Return(nil)
+		mockRepo.On("PurgeOrganization", context.Background(), int64(2)).Return(nil)
+
+		purged, err := service.PurgeOrganizations(context.Background(), deletedBefore, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+	})
+}
+
+func TestService_GetOrganizationBySubdomainAlias(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the subdomain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		_, err := service.GetOrganizationBySubdomainAlias(context.Background(), "#invalid-subdomain")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "subdomain can only contain alphanumeric characters")
+	})
+
+	t.Run("should return an error when the organization is not found", func(t *testing.T) {
+		t.Parallel()
+
+		var notFoundErr *base.NotFoundError
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		alias := randomOrganizationSubdomain()
+
+		mockRepo.On("GetOrganizationBySubdomainAlias", context.Background(), alias).
+			Return(organization.Organization{}, sql.ErrNoRows)
+
+		_, err := service.GetOrganizationBySubdomainAlias(context.Background(), alias)
+		require.Error(t, err)
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("should return the organization of the alias", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		alias := randomOrganizationSubdomain()
+		org := organization.Organization{ID: 1, Subdomain: randomOrganizationSubdomain()}
+
+		mockRepo.On("GetOrganizationBySubdomainAlias", context.Background(), alias).Return(org, nil)
+
+		result, err := service.GetOrganizationBySubdomainAlias(context.Background(), alias)
+		require.NoError(t, err)
+		assert.Equal(t, org, result)
+	})
+}
+
+func TestService_ChangeSubdomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an error when the subdomain is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		err := service.ChangeSubdomain(context.Background(), 1, "#invalid-subdomain")
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "subdomain can only contain alphanumeric characters")
+	})
+
+	t.Run("should return an error when the subdomain is unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		org := organization.Organization{ID: 1, Subdomain: randomOrganizationSubdomain()}
+
+		mockRepo.On("GetOrganizationByID", context.Background(), org.ID).Return(org, nil)
+
+		err := service.ChangeSubdomain(context.Background(), org.ID, org.Subdomain)
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return an error when the subdomain is reserved", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+		org := organization.Organization{ID: 1, Subdomain: randomOrganizationSubdomain()}
+		newSubdomain := strings.ToLower(gofakeit.LetterN(30))
+
+		mockRepo.On("GetOrganizationByID", context.Background(), org.ID).Return(org, nil)
+		mockRepo.On("IsSubdomainReserved", context.Background(), newSubdomain, org.ID).Return(true, nil)
+
+		err := service.ChangeSubdomain(context.Background(), org.ID, newSubdomain)
+		require.ErrorIs(t, err, organization.ErrSubdomainUnavailable)
+	})
+
+	t.Run("should return an error when the subdomain is a reserved name", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{}, mockRepo, nil, nil)
+
+		err := service.ChangeSubdomain(context.Background(), 1, "API")
+		require.Error(t, err)
+		assert.EqualError(t, err, "subdomain is reserved")
+	})
+
+	t.Run("should keep the former subdomain as an alias for the configured ttl", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := organization.NewMockRepository(t)
+		service := organization.NewService(config.Config{OrgSubdomainAliasTTL: 90}, mockRepo, nil, nil)
+		org := organization.Organization{ID: 1, Subdomain: randomOrganizationSubdomain()}
+		newSubdomain := strings.ToLower(gofakeit.LetterN(30))
+		expectedExpiry := time.Now().UTC().Add(90 * 24 * time.Hour)
+
+		mockRepo.On("GetOrganizationByID", context.Background(), org.ID).Return(org, nil)
+		mockRepo.On("IsSubdomainReserved", context.Background(), newSubdomain, org.ID).Return(false, nil)
+		mockRepo.On("ChangeSubdomain", context.Background(), org.ID, newSubdomain,
+			mock.MatchedBy(func(expiresAt time.Time) bool {
+				return expiresAt.Sub(expectedExpiry).Abs() < time.Minute
+			})).Return(nil)
+
+		err := service.ChangeSubdomain(context.Background(), org.ID, newSubdomain)
+		require.NoError(t, err)
+	})
+}
@@ -5,9 +5,15 @@ import _ "embed"
 //go:embed sql/get_organization_by_id.sql
 var getOrganizationByIDQuery string
 
+//go:embed sql/get_deleted_organization_by_id.sql
+var getDeletedOrganizationByIDQuery string
+
 //go:embed sql/get_organization_by_subdomain.sql
 var getOrganizationBySubdomainQuery string
 
+//go:embed sql/get_deleted_organization_by_subdomain.sql
+var getDeletedOrganizationBySubdomainQuery string
+
 //go:embed sql/get_organization_by_name.sql
 var getOrganizationByNameQuery string
 
@@ -25,3 +31,33 @@ var suspendOrganizationQuery string
 
 //go:embed sql/unsuspend_organization.sql
 var unsuspendOrganizationQuery string
+
+//go:embed sql/restore_organization.sql
+var restoreOrganizationQuery string
+
+//go:embed sql/set_mfa_required.sql
+var setMFARequiredQuery string
+
+//go:embed sql/set_magic_link_enabled.sql
+var setMagicLinkEnabledQuery string
+
+//go:embed sql/set_impersonation_allowed.sql
+var setImpersonationAllowedQuery string
+
+//go:embed sql/list_purgeable_organizations.sql
+var listPurgeableOrganizationsQuery string
+
+//go:embed sql/purge_organization.sql
+var purgeOrganizationQuery string
+
+//go:embed sql/get_organization_by_subdomain_alias.sql
+var getOrganizationBySubdomainAliasQuery string
+
+//go:embed sql/is_subdomain_reserved.sql
+var isSubdomainReservedQuery string
+
+//go:embed sql/list_available_subdomains.sql
+var listAvailableSubdomainsQuery string
+
+//go:embed sql/change_subdomain.sql
+var changeSubdomainQuery string
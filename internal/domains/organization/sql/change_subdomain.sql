@@ -0,0 +1,46 @@
+-- changeSubdomainQuery
+-- $1: organization_id
+-- $2: subdomain
+-- $3: alias_expires_at
+WITH org AS (
+    SELECT
+        organization_id,
+        subdomain
+    FROM
+        organizations
+    WHERE
+        organization_id = $1
+        AND deleted_at IS NULL
+    FOR UPDATE
+),
+-- the organization reclaims its own alias of the new subdomain
+reclaimed_alias AS (
+    DELETE FROM
+        organization_subdomain_aliases
+    WHERE
+        subdomain = $2
+        AND organization_id IN (SELECT organization_id FROM org)
+),
+-- the current subdomain is kept as an alias. an expired alias of the same subdomain is replaced
+former_alias AS (
+    INSERT INTO
+        organization_subdomain_aliases(organization_id, subdomain, expires_at)
+    SELECT
+        organization_id,
+        subdomain,
+        $3
+    FROM
+        org
+    ON CONFLICT (subdomain) DO UPDATE
+    SET
+        organization_id = EXCLUDED.organization_id,
+        created_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+        expires_at = EXCLUDED.expires_at
+)
+UPDATE
+    organizations
+SET
+    subdomain = $2,
+    updated_at = NOW()
+WHERE
+    organization_id IN (SELECT organization_id FROM org);
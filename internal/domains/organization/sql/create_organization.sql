@@ -9,6 +9,9 @@ VALUES
     subdomain,
     name,
     suspended_at,
+    mfa_required,
+    magic_link_enabled,
+    impersonation_allowed,
     created_at,
     updated_at,
     deleted_at,
@@ -0,0 +1,19 @@
+-- getDeletedOrganizationByIDQuery
+-- $1: organization_id
+SELECT
+    organization_id,
+    subdomain,
+    name,
+    suspended_at,
+    mfa_required,
+    magic_link_enabled,
+    impersonation_allowed,
+    created_at,
+    updated_at,
+    deleted_at,
+    comment
+FROM
+    organizations
+WHERE
+    organization_id = $1
+    AND deleted_at IS NOT NULL;
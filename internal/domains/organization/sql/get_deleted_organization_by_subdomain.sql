@@ -0,0 +1,19 @@
+-- getDeletedOrganizationBySubdomainQuery
+-- $1: subdomain
+SELECT
+    organization_id,
+    subdomain,
+    name,
+    suspended_at,
+    mfa_required,
+    magic_link_enabled,
+    impersonation_allowed,
+    created_at,
+    updated_at,
+    deleted_at,
+    comment
+FROM
+    organizations
+WHERE
+    subdomain = $1
+    AND deleted_at IS NOT NULL;
@@ -0,0 +1,21 @@
+-- getOrganizationBySubdomainAliasQuery
+-- $1: subdomain
+SELECT
+    o.organization_id,
+    o.subdomain,
+    o.name,
+    o.suspended_at,
+    o.mfa_required,
+    o.magic_link_enabled,
+    o.impersonation_allowed,
+    o.created_at,
+    o.updated_at,
+    o.deleted_at,
+    o.comment
+FROM
+    organization_subdomain_aliases a
+    INNER JOIN organizations o ON o.organization_id = a.organization_id
+WHERE
+    a.subdomain = $1
+    AND a.expires_at > (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+    AND o.deleted_at IS NULL;
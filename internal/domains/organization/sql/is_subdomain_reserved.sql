@@ -0,0 +1,22 @@
+-- isSubdomainReservedQuery
+-- $1: subdomain
+-- $2: organization_id allowed to reclaim its own alias
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            organizations
+        WHERE
+            subdomain = $1
+    )
+    OR EXISTS (
+        SELECT
+            1
+        FROM
+            organization_subdomain_aliases
+        WHERE
+            subdomain = $1
+            AND expires_at > (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+            AND organization_id <> $2
+    );
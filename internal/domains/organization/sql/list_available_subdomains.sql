@@ -0,0 +1,26 @@
+-- listAvailableSubdomainsQuery
+-- $1: comma separated subdomains
+SELECT
+    c.subdomain
+FROM
+    unnest(string_to_array($1, ',')) WITH ORDINALITY AS c(subdomain, position)
+WHERE
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            organizations o
+        WHERE
+            o.subdomain = c.subdomain
+    )
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            organization_subdomain_aliases a
+        WHERE
+            a.subdomain = c.subdomain
+            AND a.expires_at > (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+    )
+ORDER BY
+    c.position;
@@ -0,0 +1,24 @@
+-- listPurgeableOrganizationsQuery
+-- $1: deleted_before
+-- $2: limit
+SELECT
+    organization_id,
+    subdomain,
+    name,
+    suspended_at,
+    mfa_required,
+    magic_link_enabled,
+    impersonation_allowed,
+    created_at,
+    updated_at,
+    deleted_at,
+    comment
+FROM
+    organizations
+WHERE
+    deleted_at IS NOT NULL
+    AND deleted_at < $1
+ORDER BY
+    deleted_at,
+    organization_id
+LIMIT $2;
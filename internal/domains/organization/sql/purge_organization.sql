@@ -0,0 +1,3 @@
+-- purgeOrganizationQuery
+-- $1: organization_id
+SELECT purge_organization($1);
@@ -0,0 +1,12 @@
+-- restoreOrganizationQuery
+-- $1: organization_id
+-- $2: comment
+UPDATE
+    organizations
+SET
+    deleted_at = NULL,
+    comment = $2,
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND deleted_at IS NOT NULL;
@@ -0,0 +1,11 @@
+-- setImpersonationAllowedQuery
+-- $1: organization_id
+-- $2: impersonation_allowed
+UPDATE
+    organizations
+SET
+    impersonation_allowed = $2,
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- setMagicLinkEnabledQuery
+-- $1: organization_id
+-- $2: magic_link_enabled
+UPDATE
+    organizations
+SET
+    magic_link_enabled = $2,
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- setMFARequiredQuery
+-- $1: organization_id
+-- $2: mfa_required
+UPDATE
+    organizations
+SET
+    mfa_required = $2,
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND deleted_at IS NULL;
@@ -0,0 +1,54 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const suspendedKeyFormat = "org:%v:suspended"
+
+var ErrStatusCacheMiss = errors.New("organization status not found in the cache")
+
+// StatusCache is an interface for caching the suspension status of the organizations.
+// It saves a database query on every authenticated request.
+type StatusCache interface {
+	// GetSuspended returns the cached suspension status of the organization.
+	// ErrStatusCacheMiss is returned when the status is not cached.
+	GetSuspended(ctx context.Context, orgID int64) (bool, error)
+
+	// SetSuspended caches the suspension status of the organization for the StatusCacheTTL.
+	SetSuspended(ctx context.Context, orgID int64, suspended bool) error
+}
+
+type statusCache struct {
+	redisClient *redis.Client
+}
+
+func NewRedisStatusCache(redisClient *redis.Client) StatusCache {
+	return &statusCache{redisClient}
+}
+
+func (c *statusCache) GetSuspended(ctx context.Context, orgID int64) (bool, error) {
+	suspended, err := c.redisClient.Get(ctx, fmt.Sprintf(suspendedKeyFormat, orgID)).Bool()
+	if errors.Is(err, redis.Nil) {
+		return false, ErrStatusCacheMiss
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve suspension status for org:%d: %w", orgID, err)
+	}
+
+	return suspended, nil
+}
+
+func (c *statusCache) SetSuspended(ctx context.Context, orgID int64, suspended bool) error {
+	if err := c.redisClient.Set(ctx, fmt.Sprintf(suspendedKeyFormat, orgID), suspended,
+		StatusCacheTTL).Err(); err != nil {
+		return fmt.Errorf("failed to cache suspension status for org:%d: %w", orgID, err)
+	}
+
+	return nil
+}
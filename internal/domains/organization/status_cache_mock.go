@@ -0,0 +1,141 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package organization
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStatusCache is an autogenerated mock type for the StatusCache type
+type MockStatusCache struct {
+	mock.Mock
+}
+
+type MockStatusCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatusCache) EXPECT() *MockStatusCache_Expecter {
+	return &MockStatusCache_Expecter{mock: &_m.Mock}
+}
+
+// GetSuspended provides a mock function with given fields: ctx, orgID
+func (_m *MockStatusCache) GetSuspended(ctx context.Context, orgID int64) (bool, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuspended")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStatusCache_GetSuspended_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuspended'
+type MockStatusCache_GetSuspended_Call struct {
+	*mock.Call
+}
+
+// GetSuspended is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockStatusCache_Expecter) GetSuspended(ctx interface{}, orgID interface{}) *MockStatusCache_GetSuspended_Call {
+	return &MockStatusCache_GetSuspended_Call{Call: _e.mock.On("GetSuspended", ctx, orgID)}
+}
+
+func (_c *MockStatusCache_GetSuspended_Call) Run(run func(ctx context.Context, orgID int64)) *MockStatusCache_GetSuspended_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStatusCache_GetSuspended_Call) Return(_a0 bool, _a1 error) *MockStatusCache_GetSuspended_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStatusCache_GetSuspended_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *MockStatusCache_GetSuspended_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSuspended provides a mock function with given fields: ctx, orgID, suspended
+func (_m *MockStatusCache) SetSuspended(ctx context.Context, orgID int64, suspended bool) error {
+	ret := _m.Called(ctx, orgID, suspended)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSuspended")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, orgID, suspended)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStatusCache_SetSuspended_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSuspended'
+type MockStatusCache_SetSuspended_Call struct {
+	*mock.Call
+}
+
+// SetSuspended is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - suspended bool
+func (_e *MockStatusCache_Expecter) SetSuspended(ctx interface{}, orgID interface{}, suspended interface{}) *MockStatusCache_SetSuspended_Call {
+	return &MockStatusCache_SetSuspended_Call{Call: _e.mock.On("SetSuspended", ctx, orgID, suspended)}
+}
+
+func (_c *MockStatusCache_SetSuspended_Call) Run(run func(ctx context.Context, orgID int64, suspended bool)) *MockStatusCache_SetSuspended_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStatusCache_SetSuspended_Call) Return(_a0 error) *MockStatusCache_SetSuspended_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStatusCache_SetSuspended_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockStatusCache_SetSuspended_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatusCache creates a new instance of MockStatusCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatusCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatusCache {
+	mock := &MockStatusCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
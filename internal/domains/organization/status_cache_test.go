@@ -0,0 +1,90 @@
+package organization_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCache_GetSuspended(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return cache miss when the status is not cached", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := organization.NewRedisStatusCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:suspended", orgID)).RedisNil()
+
+		_, err := cache.GetSuspended(context.Background(), orgID)
+		require.ErrorIs(t, err, organization.ErrStatusCacheMiss)
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := organization.NewRedisStatusCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:suspended", orgID)).SetErr(assert.AnError)
+
+		_, err := cache.GetSuspended(context.Background(), orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return the cached status", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := organization.NewRedisStatusCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:suspended", orgID)).SetVal("1")
+
+		suspended, err := cache.GetSuspended(context.Background(), orgID)
+		require.NoError(t, err)
+		assert.True(t, suspended)
+	})
+}
+
+func TestStatusCache_SetSuspended(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should cache the status with expiry", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := organization.NewRedisStatusCache(redisClient)
+
+		redisClientMock.ExpectSet(fmt.Sprintf("org:%d:suspended", orgID), false, organization.StatusCacheTTL).
+			SetVal("OK")
+
+		err := cache.SetSuspended(context.Background(), orgID, false)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := organization.NewRedisStatusCache(redisClient)
+
+		redisClientMock.ExpectSet(fmt.Sprintf("org:%d:suspended", orgID), true, organization.StatusCacheTTL).
+			SetErr(assert.AnError)
+
+		err := cache.SetSuspended(context.Background(), orgID, true)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
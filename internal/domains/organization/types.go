@@ -4,8 +4,28 @@ import (
 	"time"
 
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
 )
 
+// StatusCacheTTL is the duration for which the suspension status of an organization is cached.
+const StatusCacheTTL = 5 * time.Minute
+
+// MaxSubdomainSuggestions is the maximum number of the alternatives suggested for an unavailable subdomain.
+const MaxSubdomainSuggestions = 3
+
+const (
+	// SubdomainReasonReserved is the reason of an unavailable subdomain which can not be used by any organization.
+	SubdomainReasonReserved = "reserved"
+
+	// SubdomainReasonTaken is the reason of an unavailable subdomain used by another organization.
+	SubdomainReasonTaken = "taken"
+)
+
+// subdomainSuggestionSuffixes are appended to an unavailable subdomain to suggest the alternatives.
+//
+//nolint:gochecknoglobals // the slice is read only
+var subdomainSuggestionSuffixes = []string{"hr", "team", "hq", "inc", "1", "2", "3"}
+
 // Organization represents an organization.
 type Organization struct {
 	// ID is the unique identifier of the organization.
@@ -20,27 +40,99 @@ type Organization struct {
 	// SuspendedAt is the timestamp when the organization was suspended.
 	SuspendedAt *time.Time `db:"suspended_at"`
 
+	// MFARequired represents whether all the users of the organization must use mfa to login.
+	MFARequired bool `db:"mfa_required"`
+
+	// MagicLinkEnabled represents whether the users of the organization can login using a link mailed to them.
+	MagicLinkEnabled bool `db:"magic_link_enabled"`
+
+	// ImpersonationAllowed represents whether the platform operators can impersonate the users of the organization.
+	ImpersonationAllowed bool `db:"impersonation_allowed"`
+
 	// Comment represents any additional information about the organization's current state.
 	Comment *string `db:"comment"`
 
 	base.Timestamps
 }
 
+// IsSuspended returns true if the organization is suspended.
+func (o Organization) IsSuspended() bool {
+	return o.SuspendedAt != nil
+}
+
+// IsRestorable returns true if the organization is deleted and can still be restored,
+// i.e. it was deleted within the given grace period.
+func (o Organization) IsRestorable(gracePeriod time.Duration) bool {
+	return o.DeletedAt != nil && time.Since(*o.DeletedAt) < gracePeriod
+}
+
+// RestoreGracePeriod returns the duration for which a deleted organization can be restored before it is purged.
+func RestoreGracePeriod(conf config.Config) time.Duration {
+	return time.Duration(conf.OrgRestoreGracePeriod) * 24 * time.Hour
+}
+
+// SubdomainAliasTTL returns the duration for which the former subdomain of an organization is kept as an alias.
+func SubdomainAliasTTL(conf config.Config) time.Duration {
+	return time.Duration(conf.OrgSubdomainAliasTTL) * 24 * time.Hour
+}
+
+// SubdomainAvailability represents whether a subdomain can be used by a new organization.
+type SubdomainAvailability struct {
+	// Subdomain is the lowercase subdomain checked.
+	Subdomain string
+
+	// Available represents whether the subdomain can be used.
+	Available bool
+
+	// Reason is one of the SubdomainReasonReserved and the SubdomainReasonTaken
+	// when the subdomain is not available.
+	Reason string
+
+	// Suggestions are the available alternatives of the subdomain when it is not available.
+	Suggestions []string
+}
+
 // UpdateRequest represents a http request to update an organization.
 type UpdateRequest struct {
 	Name string `json:"name" validate:"required,ascii,max=60"`
 }
 
+// ChangeSubdomainRequest represents a http request to change the subdomain of an organization.
+type ChangeSubdomainRequest struct {
+	Subdomain string `json:"subdomain" validate:"required,alphanum,max=30"`
+}
+
 // DeleteRequest represents a http request to delete an organization.
 type DeleteRequest struct {
 	Comment string `json:"comment" validate:"required,max=255"`
 }
 
+// MagicLinkRequest represents a http request to enable or disable the magic link login of an organization.
+type MagicLinkRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ImpersonationRequest represents a http request to allow or deny the impersonation of the users of an organization.
+type ImpersonationRequest struct {
+	Allowed bool `json:"allowed"`
+}
+
+// AvailabilityResponse represents a http response of the availability of a subdomain.
+type AvailabilityResponse struct {
+	Subdomain   string   `json:"subdomain"`
+	Available   bool     `json:"available"`
+	Reason      string   `json:"reason,omitempty"`
+	Suggestions []string `json:"suggestions"`
+}
+
 // Response represents a response of an http response organization.
 type Response struct {
-	ID          int64      `json:"id"`
-	Subdomain   string     `json:"subdomain"`
-	Name        string     `json:"name"`
-	SuspendedAt *time.Time `json:"suspended_at"`
+	ID                   int64      `json:"id"`
+	Subdomain            string     `json:"subdomain"`
+	Name                 string     `json:"name"`
+	SuspendedAt          *time.Time `json:"suspended_at"`
+	MFARequired          bool       `json:"mfa_required"`
+	MagicLinkEnabled     bool       `json:"magic_link_enabled"`
+	ImpersonationAllowed bool       `json:"impersonation_allowed"`
 	base.Timestamps
 }
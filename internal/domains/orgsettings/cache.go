@@ -0,0 +1,75 @@
+package orgsettings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const settingsKeyFormat = "org:%v:settings"
+
+var ErrCacheMiss = errors.New("settings not found in the cache")
+
+// Cache is an interface for caching the settings of the organizations.
+// The settings are read by the other domains on most of the requests.
+type Cache interface {
+	// GetSettings returns the cached settings of the organization. ErrCacheMiss is returned when they are not cached.
+	GetSettings(ctx context.Context, orgID int64) (Settings, error)
+
+	// SetSettings caches the settings of the organization for the CacheTTL.
+	SetSettings(ctx context.Context, s Settings) error
+
+	// DeleteSettings deletes the cached settings of the organization.
+	DeleteSettings(ctx context.Context, orgID int64) error
+}
+
+type cache struct {
+	redisClient *redis.Client
+}
+
+func NewRedisCache(redisClient *redis.Client) Cache {
+	return &cache{redisClient}
+}
+
+func (c *cache) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	data, err := c.redisClient.Get(ctx, fmt.Sprintf(settingsKeyFormat, orgID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return Settings{}, ErrCacheMiss
+	}
+
+	if err != nil {
+		return Settings{}, fmt.Errorf("failed to retrieve settings for org:%d: %w", orgID, err)
+	}
+
+	var s Settings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Settings{}, fmt.Errorf("failed to decode cached settings for org:%d: %w", orgID, err)
+	}
+
+	return s, nil
+}
+
+func (c *cache) SetSettings(ctx context.Context, s Settings) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings for org:%d: %w", s.OrganizationID, err)
+	}
+
+	if err := c.redisClient.Set(ctx, fmt.Sprintf(settingsKeyFormat, s.OrganizationID), data,
+		CacheTTL).Err(); err != nil {
+		return fmt.Errorf("failed to cache settings for org:%d: %w", s.OrganizationID, err)
+	}
+
+	return nil
+}
+
+func (c *cache) DeleteSettings(ctx context.Context, orgID int64) error {
+	if err := c.redisClient.Del(ctx, fmt.Sprintf(settingsKeyFormat, orgID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete settings for org:%d: %w", orgID, err)
+	}
+
+	return nil
+}
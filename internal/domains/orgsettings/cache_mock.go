@@ -0,0 +1,187 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package orgsettings
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCache is an autogenerated mock type for the Cache type
+type MockCache struct {
+	mock.Mock
+}
+
+type MockCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCache) EXPECT() *MockCache_Expecter {
+	return &MockCache_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSettings provides a mock function with given fields: ctx, orgID
+func (_m *MockCache) DeleteSettings(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_DeleteSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSettings'
+type MockCache_DeleteSettings_Call struct {
+	*mock.Call
+}
+
+// DeleteSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockCache_Expecter) DeleteSettings(ctx interface{}, orgID interface{}) *MockCache_DeleteSettings_Call {
+	return &MockCache_DeleteSettings_Call{Call: _e.mock.On("DeleteSettings", ctx, orgID)}
+}
+
+func (_c *MockCache_DeleteSettings_Call) Run(run func(ctx context.Context, orgID int64)) *MockCache_DeleteSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockCache_DeleteSettings_Call) Return(_a0 error) *MockCache_DeleteSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_DeleteSettings_Call) RunAndReturn(run func(context.Context, int64) error) *MockCache_DeleteSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSettings provides a mock function with given fields: ctx, orgID
+func (_m *MockCache) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Settings, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Settings); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type MockCache_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockCache_Expecter) GetSettings(ctx interface{}, orgID interface{}) *MockCache_GetSettings_Call {
+	return &MockCache_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, orgID)}
+}
+
+func (_c *MockCache_GetSettings_Call) Run(run func(ctx context.Context, orgID int64)) *MockCache_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetSettings_Call) Return(_a0 Settings, _a1 error) *MockCache_GetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_GetSettings_Call) RunAndReturn(run func(context.Context, int64) (Settings, error)) *MockCache_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSettings provides a mock function with given fields: ctx, s
+func (_m *MockCache) SetSettings(ctx context.Context, s Settings) error {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Settings) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_SetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSettings'
+type MockCache_SetSettings_Call struct {
+	*mock.Call
+}
+
+// SetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s Settings
+func (_e *MockCache_Expecter) SetSettings(ctx interface{}, s interface{}) *MockCache_SetSettings_Call {
+	return &MockCache_SetSettings_Call{Call: _e.mock.On("SetSettings", ctx, s)}
+}
+
+func (_c *MockCache_SetSettings_Call) Run(run func(ctx context.Context, s Settings)) *MockCache_SetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Settings))
+	})
+	return _c
+}
+
+func (_c *MockCache_SetSettings_Call) Return(_a0 error) *MockCache_SetSettings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_SetSettings_Call) RunAndReturn(run func(context.Context, Settings) error) *MockCache_SetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCache creates a new instance of MockCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCache {
+	mock := &MockCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+package orgsettings_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/orgsettings"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return cache miss when the settings are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:settings", orgID)).RedisNil()
+
+		_, err := cache.GetSettings(context.Background(), orgID)
+		require.ErrorIs(t, err, orgsettings.ErrCacheMiss)
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:settings", orgID)).SetErr(assert.AnError)
+
+		_, err := cache.GetSettings(context.Background(), orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return the cached settings", func(t *testing.T) {
+		t.Parallel()
+
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectGet(fmt.Sprintf("org:%d:settings", s.OrganizationID)).SetVal(string(data))
+
+		result, err := cache.GetSettings(context.Background(), s.OrganizationID)
+		require.NoError(t, err)
+		assert.Equal(t, s, result)
+	})
+}
+
+func TestCache_SetSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should cache the settings for the cache ttl", func(t *testing.T) {
+		t.Parallel()
+
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectSet(fmt.Sprintf("org:%d:settings", s.OrganizationID), data, orgsettings.CacheTTL).
+			SetVal("OK")
+
+		err = cache.SetSettings(context.Background(), s)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestCache_DeleteSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("org:%d:settings", orgID)).SetErr(assert.AnError)
+
+		err := cache.DeleteSettings(context.Background(), orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should delete the cached settings", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := orgsettings.NewRedisCache(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("org:%d:settings", orgID)).SetVal(1)
+
+		err := cache.DeleteSettings(context.Background(), orgID)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,70 @@
+package orgsettings
+
+import (
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetSettings returns the settings of the organization of the authenticated user.
+func (h *handler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	s, err := h.service.GetSettings(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(s))
+}
+
+// UpdateSettings changes the settings of the organization set in the request payload.
+func (h *handler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, err := request.OrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload PatchRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	s, err := h.service.UpdateSettings(r.Context(), orgID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(s))
+}
+
+func toResponse(s Settings) Response {
+	return Response{
+		TimeZone:             s.TimeZone,
+		Locale:               s.Locale,
+		Currency:             s.Currency,
+		DateFormat:           s.DateFormat,
+		FiscalYearStartMonth: int(s.FiscalYearStartMonth),
+		WorkingDays:          s.WorkingDays.Names(),
+		WorkDayStart:         s.WorkDayStart,
+		WorkDayEnd:           s.WorkDayEnd,
+	}
+}
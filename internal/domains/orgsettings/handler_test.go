@@ -0,0 +1,148 @@
+package orgsettings_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/orgsettings"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const settingsPath = "/api/v1/subdomains/{subdomain}/organizations/settings"
+
+// withOrgID sets the org-id in the request context as done by the auth middleware.
+func withOrgID(req *http.Request, orgID int64) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), request.CtxOrgIDKey, orgID))
+}
+
+func TestHandler_GetSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the context is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, settingsPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := orgsettings.NewHandler(orgsettings.NewMockService(t))
+
+		handler.GetSettings(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return the settings of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, settingsPath, nil)
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := orgsettings.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := orgsettings.NewHandler(mockService)
+
+		mockService.On("GetSettings", fake.MockContext, orgID).Return(orgsettings.DefaultSettings(orgID), nil)
+
+		handler.GetSettings(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"time_zone":"UTC","locale":"en-US","currency":"USD","date_format":"YYYY-MM-DD",`+
+			`"fiscal_year_start_month":1,"working_days":["monday","tuesday","wednesday","thursday","friday"],`+
+			`"work_day_start":"09:00","work_day_end":"17:00"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_UpdateSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the payload is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			payload string
+		}{
+			{"invalid time zone", `{"time_zone":"Mars/Olympus"}`},
+			{"invalid locale", `{"locale":"not a locale"}`},
+			{"invalid currency", `{"currency":"usd"}`},
+			{"invalid fiscal year start month", `{"fiscal_year_start_month":13}`},
+			{"no working days", `{"working_days":[]}`},
+			{"duplicate working days", `{"working_days":["monday","monday"]}`},
+			{"invalid work day end", `{"work_day_end":"25:00"}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				req, err := http.NewRequest(http.MethodPatch, settingsPath, strings.NewReader(tt.payload))
+				require.NoError(t, err)
+				req = withOrgID(req, gofakeit.Int64())
+
+				rr := httptest.NewRecorder()
+				handler := orgsettings.NewHandler(orgsettings.NewMockService(t))
+
+				handler.UpdateSettings(rr, req)
+
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+			})
+		}
+	})
+
+	t.Run("should return bad request when the service rejects the settings", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPatch, settingsPath, strings.NewReader(`{"date_format":"YY"}`))
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := orgsettings.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := orgsettings.NewHandler(mockService)
+
+		mockService.On("UpdateSettings", fake.MockContext, orgID, mock.Anything).
+			Return(orgsettings.Settings{}, base.NewInputValidationError("date_format is not supported"))
+
+		handler.UpdateSettings(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"date_format is not supported"}`, rr.Body.String())
+	})
+
+	t.Run("should update the settings set in the payload only", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPatch, settingsPath, strings.NewReader(`{"currency":"EUR"}`))
+		require.NoError(t, err)
+		req = withOrgID(req, orgID)
+
+		mockService := orgsettings.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := orgsettings.NewHandler(mockService)
+
+		updated := orgsettings.DefaultSettings(orgID)
+		updated.Currency = "EUR"
+
+		mockService.On("UpdateSettings", fake.MockContext, orgID, orgsettings.PatchRequest{Currency: ptr("EUR")}).
+			Return(updated, nil)
+
+		handler.UpdateSettings(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"currency":"EUR"`)
+	})
+}
@@ -0,0 +1,38 @@
+package orgsettings
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// GetSettings returns the settings of the organization.
+	GetSettings(ctx context.Context, orgID int64) (Settings, error)
+
+	// UpsertSettings creates or replaces the settings of the organization.
+	UpsertSettings(ctx context.Context, s Settings) (Settings, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	var s Settings
+	err := r.db.Get(ctx, &s, getSettingsQuery, orgID)
+
+	return s, err
+}
+
+func (r *repository) UpsertSettings(ctx context.Context, s Settings) (Settings, error) {
+	var result Settings
+	err := r.db.Exec(ctx, &result, upsertSettingsQuery, s.OrganizationID, s.TimeZone, s.Locale, s.Currency,
+		s.DateFormat, s.FiscalYearStartMonth, s.WorkingDays, s.WorkDayStart, s.WorkDayEnd)
+
+	return result, err
+}
@@ -0,0 +1,76 @@
+package orgsettings_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/domains/orgsettings"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *OrgSettingsTestSuite) TestRepositoryIntegration_GetSettings() {
+	s.Run("should return no rows when the settings are not stored", func() {
+		s.T().Parallel()
+
+		repo := orgsettings.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		_, err := repo.GetSettings(context.Background(), o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *OrgSettingsTestSuite) TestRepositoryIntegration_UpsertSettings() {
+	s.Run("should create and then replace the settings", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := orgsettings.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		created, err := repo.UpsertSettings(ctx, orgsettings.DefaultSettings(o.ID))
+		s.Require().NoError(err)
+		s.Equal(orgsettings.DefaultSettings(o.ID).WorkingDays, created.WorkingDays)
+		s.Equal(time.January, created.FiscalYearStartMonth)
+		s.NotZero(created.CreatedAt)
+
+		settings := created
+		settings.TimeZone = "Asia/Kolkata"
+		settings.Locale = "hi-IN"
+		settings.Currency = "INR"
+		settings.DateFormat = "DD/MM/YYYY"
+		settings.FiscalYearStartMonth = time.April
+		settings.WorkingDays = orgsettings.Weekdays{time.Monday, time.Tuesday, time.Wednesday, time.Saturday}
+		settings.WorkDayStart = "10:00"
+		settings.WorkDayEnd = "18:30"
+
+		_, err = repo.UpsertSettings(ctx, settings)
+		s.Require().NoError(err)
+
+		result, err := repo.GetSettings(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Equal("Asia/Kolkata", result.TimeZone)
+		s.Equal("hi-IN", result.Locale)
+		s.Equal("INR", result.Currency)
+		s.Equal("DD/MM/YYYY", result.DateFormat)
+		s.Equal(time.April, result.FiscalYearStartMonth)
+		s.Equal(settings.WorkingDays, result.WorkingDays)
+		s.Equal("10:00", result.WorkDayStart)
+		s.Equal("18:30", result.WorkDayEnd)
+		s.Equal(created.CreatedAt, result.CreatedAt)
+	})
+
+	s.Run("should not store the working hours ending before they start", func() {
+		s.T().Parallel()
+
+		repo := orgsettings.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		settings := orgsettings.DefaultSettings(o.ID)
+		settings.WorkDayEnd = "08:00"
+
+		_, err := repo.UpsertSettings(context.Background(), settings)
+		s.Require().Error(err)
+	})
+}
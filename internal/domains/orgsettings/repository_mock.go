@@ -0,0 +1,150 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package orgsettings
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetSettings provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Settings, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Settings); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type MockRepository_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetSettings(ctx interface{}, orgID interface{}) *MockRepository_GetSettings_Call {
+	return &MockRepository_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetSettings_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSettings_Call) Return(_a0 Settings, _a1 error) *MockRepository_GetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSettings_Call) RunAndReturn(run func(context.Context, int64) (Settings, error)) *MockRepository_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertSettings provides a mock function with given fields: ctx, s
+func (_m *MockRepository) UpsertSettings(ctx context.Context, s Settings) (Settings, error) {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSettings")
+	}
+
+	var r0 Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Settings) (Settings, error)); ok {
+		return rf(ctx, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Settings) Settings); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Get(0).(Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Settings) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpsertSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSettings'
+type MockRepository_UpsertSettings_Call struct {
+	*mock.Call
+}
+
+// UpsertSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s Settings
+func (_e *MockRepository_Expecter) UpsertSettings(ctx interface{}, s interface{}) *MockRepository_UpsertSettings_Call {
+	return &MockRepository_UpsertSettings_Call{Call: _e.mock.On("UpsertSettings", ctx, s)}
+}
+
+func (_c *MockRepository_UpsertSettings_Call) Run(run func(ctx context.Context, s Settings)) *MockRepository_UpsertSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Settings))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertSettings_Call) Return(_a0 Settings, _a1 error) *MockRepository_UpsertSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpsertSettings_Call) RunAndReturn(run func(context.Context, Settings) (Settings, error)) *MockRepository_UpsertSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
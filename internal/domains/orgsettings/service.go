@@ -0,0 +1,162 @@
+package orgsettings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+)
+
+// Service is the api of the settings of the organizations. The other domains read the settings using GetSettings.
+type Service interface {
+	// GetSettings returns the settings of the organization. The settings are cached for the CacheTTL.
+	// The default settings are returned when the organization has not changed its settings.
+	GetSettings(ctx context.Context, orgID int64) (Settings, error)
+
+	// UpdateSettings changes the settings of the organization set in the request and keeps the others.
+	// The cached settings of the organization are deleted.
+	UpdateSettings(ctx context.Context, orgID int64, req PatchRequest) (Settings, error)
+}
+
+type service struct {
+	repo  Repository
+	cache Cache
+}
+
+func NewService(repo Repository, cache Cache) Service {
+	return &service{repo, cache}
+}
+
+func (s *service) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	settings, err := s.cache.GetSettings(ctx, orgID)
+	if err == nil {
+		return settings, nil
+	}
+
+	if !errors.Is(err, ErrCacheMiss) {
+		return Settings{}, err
+	}
+
+	settings, err = s.getStoredSettings(ctx, orgID)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	if err := s.cache.SetSettings(ctx, settings); err != nil {
+		return Settings{}, err
+	}
+
+	return settings, nil
+}
+
+func (s *service) UpdateSettings(ctx context.Context, orgID int64, req PatchRequest) (Settings, error) {
+	// the settings are read from the database since the cached ones may be stale
+	settings, err := s.getStoredSettings(ctx, orgID)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	if err := applyPatch(&settings, req); err != nil {
+		return Settings{}, err
+	}
+
+	settings, err = s.repo.UpsertSettings(ctx, settings)
+	if err != nil {
+		return Settings{}, err
+	}
+
+	if err := s.cache.DeleteSettings(ctx, orgID); err != nil {
+		return Settings{}, err
+	}
+
+	return settings, nil
+}
+
+// getStoredSettings returns the settings of the organization from the database
+// or the default settings when the organization has not changed them.
+func (s *service) getStoredSettings(ctx context.Context, orgID int64) (Settings, error) {
+	settings, err := s.repo.GetSettings(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultSettings(orgID), nil
+	}
+
+	return settings, err
+}
+
+// applyPatch sets the fields of the request in the settings.
+// The request is expected to be validated by the validator already.
+func applyPatch(settings *Settings, req PatchRequest) error {
+	if req.TimeZone != nil {
+		settings.TimeZone = *req.TimeZone
+	}
+
+	if req.Locale != nil {
+		settings.Locale = *req.Locale
+	}
+
+	if req.Currency != nil {
+		settings.Currency = *req.Currency
+	}
+
+	if req.DateFormat != nil {
+		if _, ok := DateFormats[*req.DateFormat]; !ok {
+			return base.NewInputValidationError("date_format is not supported")
+		}
+
+		settings.DateFormat = *req.DateFormat
+	}
+
+	if req.FiscalYearStartMonth != nil {
+		settings.FiscalYearStartMonth = time.Month(*req.FiscalYearStartMonth)
+	}
+
+	if req.WorkingDays != nil {
+		days := make(Weekdays, 0, len(req.WorkingDays))
+
+		for _, name := range req.WorkingDays {
+			d, err := ParseWeekday(name)
+			if err != nil {
+				return base.NewInputValidationError("working_days must contain the lowercase names of the days")
+			}
+
+			days = append(days, d)
+		}
+
+		// the days are kept in the order of the week
+		slices.Sort(days)
+		settings.WorkingDays = days
+	}
+
+	return applyWorkHours(settings, req)
+}
+
+// applyWorkHours sets the working hours of the request in the settings in the HH:MM format.
+func applyWorkHours(settings *Settings, req PatchRequest) error {
+	if req.WorkDayStart != nil {
+		t, err := time.Parse(workHoursLayout, *req.WorkDayStart)
+		if err != nil {
+			return base.NewInputValidationError("work_day_start must be in the HH:MM format")
+		}
+
+		settings.WorkDayStart = t.Format(workHoursLayout)
+	}
+
+	if req.WorkDayEnd != nil {
+		t, err := time.Parse(workHoursLayout, *req.WorkDayEnd)
+		if err != nil {
+			return base.NewInputValidationError("work_day_end must be in the HH:MM format")
+		}
+
+		settings.WorkDayEnd = t.Format(workHoursLayout)
+	}
+
+	// the times in the HH:MM format are ordered as strings
+	if settings.WorkDayEnd <= settings.WorkDayStart {
+		return base.NewInputValidationError("work_day_end must be after work_day_start")
+	}
+
+	return nil
+}
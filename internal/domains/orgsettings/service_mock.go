@@ -0,0 +1,151 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package orgsettings
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// GetSettings provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetSettings(ctx context.Context, orgID int64) (Settings, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Settings, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Settings); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type MockService_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) GetSettings(ctx interface{}, orgID interface{}) *MockService_GetSettings_Call {
+	return &MockService_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, orgID)}
+}
+
+func (_c *MockService_GetSettings_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetSettings_Call) Return(_a0 Settings, _a1 error) *MockService_GetSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetSettings_Call) RunAndReturn(run func(context.Context, int64) (Settings, error)) *MockService_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSettings provides a mock function with given fields: ctx, orgID, req
+func (_m *MockService) UpdateSettings(ctx context.Context, orgID int64, req PatchRequest) (Settings, error) {
+	ret := _m.Called(ctx, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 Settings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, PatchRequest) (Settings, error)); ok {
+		return rf(ctx, orgID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, PatchRequest) Settings); ok {
+		r0 = rf(ctx, orgID, req)
+	} else {
+		r0 = ret.Get(0).(Settings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, PatchRequest) error); ok {
+		r1 = rf(ctx, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_UpdateSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSettings'
+type MockService_UpdateSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - req PatchRequest
+func (_e *MockService_Expecter) UpdateSettings(ctx interface{}, orgID interface{}, req interface{}) *MockService_UpdateSettings_Call {
+	return &MockService_UpdateSettings_Call{Call: _e.mock.On("UpdateSettings", ctx, orgID, req)}
+}
+
+func (_c *MockService_UpdateSettings_Call) Run(run func(ctx context.Context, orgID int64, req PatchRequest)) *MockService_UpdateSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(PatchRequest))
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateSettings_Call) Return(_a0 Settings, _a1 error) *MockService_UpdateSettings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_UpdateSettings_Call) RunAndReturn(run func(context.Context, int64, PatchRequest) (Settings, error)) *MockService_UpdateSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
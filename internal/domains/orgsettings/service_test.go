@@ -0,0 +1,249 @@
+package orgsettings_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/orgsettings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings_Helpers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the helpers of the default settings", func(t *testing.T) {
+		t.Parallel()
+
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+
+		assert.Equal(t, time.UTC, s.Location())
+		assert.Equal(t, "2006-01-02", s.DateLayout())
+		assert.True(t, s.IsWorkingDay(time.Monday))
+		assert.False(t, s.IsWorkingDay(time.Sunday))
+	})
+
+	t.Run("should return the start of the fiscal year in the time zone of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+		s.TimeZone = "Asia/Kolkata"
+		s.FiscalYearStartMonth = time.April
+
+		loc := s.Location()
+		require.Equal(t, "Asia/Kolkata", loc.String())
+
+		// the last day of march in utc is already the first day of april in india
+		assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, loc),
+			s.FiscalYearStart(time.Date(2024, time.March, 31, 20, 0, 0, 0, time.UTC)))
+		assert.Equal(t, time.Date(2023, time.April, 1, 0, 0, 0, 0, loc),
+			s.FiscalYearStart(time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)))
+	})
+}
+
+func TestWeekdays_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should scan the comma separated names of the days", func(t *testing.T) {
+		t.Parallel()
+
+		var w orgsettings.Weekdays
+
+		require.NoError(t, w.Scan("sunday,saturday"))
+		assert.Equal(t, orgsettings.Weekdays{time.Sunday, time.Saturday}, w)
+
+		v, err := w.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "sunday,saturday", v)
+	})
+
+	t.Run("should return error for an invalid name", func(t *testing.T) {
+		t.Parallel()
+
+		var w orgsettings.Weekdays
+
+		require.Error(t, w.Scan("funday"))
+		require.Error(t, w.Scan(1))
+	})
+}
+
+func TestService_GetSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the cached settings", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+		repo := orgsettings.NewMockRepository(t)
+		cache := orgsettings.NewMockCache(t)
+		service := orgsettings.NewService(repo, cache)
+
+		cache.On("GetSettings", ctx, s.OrganizationID).Return(s, nil)
+
+		result, err := service.GetSettings(ctx, s.OrganizationID)
+		require.NoError(t, err)
+		assert.Equal(t, s, result)
+	})
+
+	t.Run("should return error when the cache call fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := orgsettings.NewMockRepository(t)
+		cache := orgsettings.NewMockCache(t)
+		service := orgsettings.NewService(repo, cache)
+
+		cache.On("GetSettings", ctx, orgID).Return(orgsettings.Settings{}, assert.AnError)
+
+		_, err := service.GetSettings(ctx, orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should cache and return the default settings when the settings are not stored", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := orgsettings.NewMockRepository(t)
+		cache := orgsettings.NewMockCache(t)
+		service := orgsettings.NewService(repo, cache)
+
+		cache.On("GetSettings", ctx, orgID).Return(orgsettings.Settings{}, orgsettings.ErrCacheMiss)
+		repo.On("GetSettings", ctx, orgID).Return(orgsettings.Settings{}, sql.ErrNoRows)
+		cache.On("SetSettings", ctx, orgsettings.DefaultSettings(orgID)).Return(nil)
+
+		result, err := service.GetSettings(ctx, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, orgsettings.DefaultSettings(orgID), result)
+	})
+
+	t.Run("should cache and return the stored settings", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := orgsettings.DefaultSettings(gofakeit.Int64())
+		s.Currency = "INR"
+		repo := orgsettings.NewMockRepository(t)
+		cache := orgsettings.NewMockCache(t)
+		service := orgsettings.NewService(repo, cache)
+
+		cache.On("GetSettings", ctx, s.OrganizationID).Return(orgsettings.Settings{}, orgsettings.ErrCacheMiss)
+		repo.On("GetSettings", ctx, s.OrganizationID).Return(s, nil)
+		cache.On("SetSettings", ctx, s).Return(nil)
+
+		result, err := service.GetSettings(ctx, s.OrganizationID)
+		require.NoError(t, err)
+		assert.Equal(t, s, result)
+	})
+}
+
+func TestService_UpdateSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return validation error for an invalid patch", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			req  orgsettings.PatchRequest
+			err  string
+		}{
+			{
+				"unsupported date format",
+				orgsettings.PatchRequest{DateFormat: ptr("YY/MM/DD")},
+				"date_format is not supported",
+			},
+			{
+				"invalid working day",
+				orgsettings.PatchRequest{WorkingDays: []string{"monday", "Funday"}},
+				"working_days must contain the lowercase names of the days",
+			},
+			{
+				"invalid work day start",
+				orgsettings.PatchRequest{WorkDayStart: ptr("9am")},
+				"work_day_start must be in the HH:MM format",
+			},
+			{
+				"work day end before the default start",
+				orgsettings.PatchRequest{WorkDayEnd: ptr("08:30")},
+				"work_day_end must be after work_day_start",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctx := context.Background()
+				orgID := gofakeit.Int64()
+				repo := orgsettings.NewMockRepository(t)
+				service := orgsettings.NewService(repo, orgsettings.NewMockCache(t))
+
+				repo.On("GetSettings", ctx, orgID).Return(orgsettings.Settings{}, sql.ErrNoRows)
+
+				_, err := service.UpdateSettings(ctx, orgID, tt.req)
+				require.Error(t, err)
+				assert.True(t, base.IsInputValidationError(err))
+				assert.EqualError(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("should return error when the repository call fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := orgsettings.NewMockRepository(t)
+		service := orgsettings.NewService(repo, orgsettings.NewMockCache(t))
+
+		repo.On("GetSettings", ctx, orgID).Return(orgsettings.Settings{}, sql.ErrNoRows)
+		repo.On("UpsertSettings", ctx, mock.Anything).Return(orgsettings.Settings{}, assert.AnError)
+
+		_, err := service.UpdateSettings(ctx, orgID, orgsettings.PatchRequest{Currency: ptr("EUR")})
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should change the patched settings and delete the cached settings", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		stored := orgsettings.DefaultSettings(gofakeit.Int64())
+		stored.Currency = "INR"
+		repo := orgsettings.NewMockRepository(t)
+		cache := orgsettings.NewMockCache(t)
+		service := orgsettings.NewService(repo, cache)
+
+		expected := stored
+		expected.TimeZone = "Europe/Berlin"
+		expected.DateFormat = "DD.MM.YYYY"
+		expected.FiscalYearStartMonth = time.July
+		expected.WorkingDays = orgsettings.Weekdays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday}
+		expected.WorkDayStart = "08:00"
+
+		repo.On("GetSettings", ctx, stored.OrganizationID).Return(stored, nil)
+		repo.On("UpsertSettings", ctx, expected).Return(expected, nil)
+		cache.On("DeleteSettings", ctx, stored.OrganizationID).Return(nil)
+
+		result, err := service.UpdateSettings(ctx, stored.OrganizationID, orgsettings.PatchRequest{
+			TimeZone:             ptr("Europe/Berlin"),
+			DateFormat:           ptr("DD.MM.YYYY"),
+			FiscalYearStartMonth: ptr(7),
+			WorkingDays:          []string{"thursday", "monday", "tuesday", "wednesday"},
+			WorkDayStart:         ptr("8:00"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,9 @@
+package orgsettings
+
+import _ "embed"
+
+//go:embed sql/get_settings.sql
+var getSettingsQuery string
+
+//go:embed sql/upsert_settings.sql
+var upsertSettingsQuery string
@@ -0,0 +1,18 @@
+-- getSettingsQuery
+-- $1: organization_id
+SELECT
+    organization_id,
+    time_zone,
+    locale,
+    currency,
+    date_format,
+    fiscal_year_start_month,
+    working_days,
+    work_day_start,
+    work_day_end,
+    created_at,
+    updated_at
+FROM
+    organization_settings
+WHERE
+    organization_id = $1;
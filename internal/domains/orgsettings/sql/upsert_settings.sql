@@ -0,0 +1,47 @@
+-- upsertSettingsQuery
+-- $1: organization_id
+-- $2: time_zone
+-- $3: locale
+-- $4: currency
+-- $5: date_format
+-- $6: fiscal_year_start_month
+-- $7: working_days
+-- $8: work_day_start
+-- $9: work_day_end
+INSERT INTO
+    organization_settings(
+        organization_id,
+        time_zone,
+        locale,
+        currency,
+        date_format,
+        fiscal_year_start_month,
+        working_days,
+        work_day_start,
+        work_day_end
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (organization_id) DO
+UPDATE
+SET
+    time_zone = EXCLUDED.time_zone,
+    locale = EXCLUDED.locale,
+    currency = EXCLUDED.currency,
+    date_format = EXCLUDED.date_format,
+    fiscal_year_start_month = EXCLUDED.fiscal_year_start_month,
+    working_days = EXCLUDED.working_days,
+    work_day_start = EXCLUDED.work_day_start,
+    work_day_end = EXCLUDED.work_day_end,
+    updated_at = NOW()
+RETURNING
+    organization_id,
+    time_zone,
+    locale,
+    currency,
+    date_format,
+    fiscal_year_start_month,
+    working_days,
+    work_day_start,
+    work_day_end,
+    created_at,
+    updated_at;
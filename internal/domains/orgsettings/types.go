@@ -0,0 +1,229 @@
+package orgsettings
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	// the time zone database is embedded since the runtime image does not ship one
+	_ "time/tzdata"
+)
+
+const (
+	// CacheTTL is the duration for which the settings of an organization are cached.
+	CacheTTL = 10 * time.Minute
+
+	// DefaultTimeZone is the time zone of the default settings.
+	DefaultTimeZone = "UTC"
+
+	// DefaultLocale is the locale of the default settings.
+	DefaultLocale = "en-US"
+
+	// DefaultCurrency is the currency of the default settings.
+	DefaultCurrency = "USD"
+
+	// DefaultDateFormat is the date format of the default settings.
+	DefaultDateFormat = "YYYY-MM-DD"
+
+	// DefaultWorkDayStart is the start of the working hours of the default settings.
+	DefaultWorkDayStart = "09:00"
+
+	// DefaultWorkDayEnd is the end of the working hours of the default settings.
+	DefaultWorkDayEnd = "17:00"
+
+	// workHoursLayout is the layout of the start and the end of the working hours.
+	workHoursLayout = "15:04"
+
+	weekdaysSeparator = ","
+)
+
+// DateFormats maps the supported date formats to their go time layouts.
+//
+//nolint:gochecknoglobals // the map is read only
+var DateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD.MM.YYYY": "02.01.2006",
+	"DD-MM-YYYY": "02-01-2006",
+}
+
+// Settings represents the configuration of an organization used by the hr features.
+type Settings struct {
+	// OrganizationID is the reference to the organization the settings belong to.
+	OrganizationID int64 `db:"organization_id"`
+
+	// TimeZone is the IANA time zone of the organization, e.g. Asia/Kolkata.
+	TimeZone string `db:"time_zone"`
+
+	// Locale is the BCP 47 language tag used to format the texts, e.g. en-US.
+	Locale string `db:"locale"`
+
+	// Currency is the ISO 4217 code of the currency of the organization, e.g. USD.
+	Currency string `db:"currency"`
+
+	// DateFormat is one of the DateFormats used to display the dates.
+	DateFormat string `db:"date_format"`
+
+	// FiscalYearStartMonth is the month the fiscal year of the organization starts in.
+	FiscalYearStartMonth time.Month `db:"fiscal_year_start_month"`
+
+	// WorkingDays are the days of the week the organization works on.
+	WorkingDays Weekdays `db:"working_days"`
+
+	// WorkDayStart is the default start of the working hours in the 24-hour HH:MM format.
+	WorkDayStart string `db:"work_day_start"`
+
+	// WorkDayEnd is the default end of the working hours in the 24-hour HH:MM format.
+	WorkDayEnd string `db:"work_day_end"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// DefaultSettings returns the settings of the organizations which have not changed their settings.
+func DefaultSettings(orgID int64) Settings {
+	return Settings{
+		OrganizationID:       orgID,
+		TimeZone:             DefaultTimeZone,
+		Locale:               DefaultLocale,
+		Currency:             DefaultCurrency,
+		DateFormat:           DefaultDateFormat,
+		FiscalYearStartMonth: time.January,
+		WorkingDays:          Weekdays{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		WorkDayStart:         DefaultWorkDayStart,
+		WorkDayEnd:           DefaultWorkDayEnd,
+	}
+}
+
+// Location returns the time zone of the organization. UTC is returned when the time zone can not be loaded.
+func (s Settings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// DateLayout returns the go time layout of the date format of the organization.
+func (s Settings) DateLayout() string {
+	if layout, ok := DateFormats[s.DateFormat]; ok {
+		return layout
+	}
+
+	return DateFormats[DefaultDateFormat]
+}
+
+// IsWorkingDay returns whether the organization works on the given day of the week.
+func (s Settings) IsWorkingDay(day time.Weekday) bool {
+	return slices.Contains(s.WorkingDays, day)
+}
+
+// FiscalYearStart returns the start of the fiscal year containing the given time
+// in the time zone of the organization.
+func (s Settings) FiscalYearStart(t time.Time) time.Time {
+	t = t.In(s.Location())
+
+	year := t.Year()
+	if t.Month() < s.FiscalYearStartMonth {
+		year--
+	}
+
+	return time.Date(year, s.FiscalYearStartMonth, 1, 0, 0, 0, 0, t.Location())
+}
+
+// Weekdays represents a set of the days of the week.
+// It is stored as the comma separated lowercase names of the days.
+type Weekdays []time.Weekday
+
+// ParseWeekday returns the day of the week of the given lowercase name.
+func ParseWeekday(name string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if weekdayName(d) == name {
+			return d, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid day of the week: %s", name)
+}
+
+// Names returns the lowercase names of the days.
+func (w Weekdays) Names() []string {
+	names := make([]string, 0, len(w))
+	for _, d := range w {
+		names = append(names, weekdayName(d))
+	}
+
+	return names
+}
+
+// Value implements the driver.Valuer interface.
+func (w Weekdays) Value() (driver.Value, error) {
+	return strings.Join(w.Names(), weekdaysSeparator), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (w *Weekdays) Scan(src any) error {
+	var s string
+
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("unsupported type for weekdays: %T", src)
+	}
+
+	days := Weekdays{}
+
+	for _, name := range strings.Split(s, weekdaysSeparator) {
+		if name == "" {
+			continue
+		}
+
+		d, err := ParseWeekday(name)
+		if err != nil {
+			return err
+		}
+
+		days = append(days, d)
+	}
+
+	*w = days
+
+	return nil
+}
+
+func weekdayName(d time.Weekday) string {
+	return strings.ToLower(d.String())
+}
+
+// PatchRequest represents the request payload to change the settings of the organization.
+// The fields which are not set are left unchanged.
+// The date format and the names of the working days are validated by the service.
+type PatchRequest struct {
+	TimeZone             *string  `json:"time_zone" validate:"omitnil,timezone"`
+	Locale               *string  `json:"locale" validate:"omitnil,bcp47_language_tag"`
+	Currency             *string  `json:"currency" validate:"omitnil,iso4217"`
+	DateFormat           *string  `json:"date_format"`
+	FiscalYearStartMonth *int     `json:"fiscal_year_start_month" validate:"omitnil,min=1,max=12"`
+	WorkingDays          []string `json:"working_days" validate:"omitnil,min=1,max=7,unique"`
+	WorkDayStart         *string  `json:"work_day_start" validate:"omitnil,datetime=15:04"`
+	WorkDayEnd           *string  `json:"work_day_end" validate:"omitnil,datetime=15:04"`
+}
+
+// Response represents the response payload of the settings.
+type Response struct {
+	TimeZone             string   `json:"time_zone"`
+	Locale               string   `json:"locale"`
+	Currency             string   `json:"currency"`
+	DateFormat           string   `json:"date_format"`
+	FiscalYearStartMonth int      `json:"fiscal_year_start_month"`
+	WorkingDays          []string `json:"working_days"`
+	WorkDayStart         string   `json:"work_day_start"`
+	WorkDayEnd           string   `json:"work_day_end"`
+}
@@ -0,0 +1,112 @@
+package ownership
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetPendingTransfer returns the pending ownership transfer of the organization to the owner or the nominee.
+func (h *handler) GetPendingTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	t, err := h.service.GetPendingTransfer(r.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(t))
+}
+
+// NominateOwner nominates a user of the organization to take over the ownership from the authenticated owner.
+func (h *handler) NominateOwner(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload NominateRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	t, err := h.service.NominateOwner(r.Context(), userID, orgID, reqPayload.UserID)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toResponse(t))
+}
+
+// AcceptOwnership makes the authenticated nominee the owner of the organization.
+func (h *handler) AcceptOwnership(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.AcceptOwnership(r.Context(), userID, orgID); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// CancelTransfer cancels the pending ownership transfer of the organization.
+func (h *handler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.CancelTransfer(r.Context(), userID, orgID); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// mapError sets the http status of the known ownership transfer errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrNotOwner):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	case errors.Is(err, ErrInvalidNominee):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest))
+	default:
+		return err
+	}
+}
+
+func toResponse(t Transfer) Response {
+	return Response{
+		ID:         t.ID,
+		FromUserID: t.FromUserID,
+		ToUserID:   t.ToUserID,
+		ExpiresAt:  t.ExpiresAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,210 @@
+package ownership_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/ownership"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transferPath = "/api/v1/subdomains/{subdomain}/organizations/ownership-transfer"
+
+func TestHandler_GetPendingTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the user is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, transferPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(ownership.NewMockService(t))
+
+		handler.GetPendingTransfer(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return not found when there is no pending transfer", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, transferPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := ownership.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(mockService)
+
+		mockService.On("GetPendingTransfer", fake.MockContext, userID, orgID).
+			Return(ownership.Transfer{}, base.NewNotFoundError("ownership transfer not found"))
+
+		handler.GetPendingTransfer(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestHandler_NominateOwner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the user id is missing", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPost, transferPath, strings.NewReader(`{}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(ownership.NewMockService(t))
+
+		handler.NominateOwner(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "should return forbidden when the user is not the owner", err: ownership.ErrNotOwner,
+			code: http.StatusForbidden},
+		{name: "should return bad request when the nominee is not eligible", err: ownership.ErrInvalidNominee,
+			code: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userID := gofakeit.Int64()
+			orgID := gofakeit.Int64()
+			nomineeID := gofakeit.Int64()
+			req, err := http.NewRequest(http.MethodPost, transferPath,
+				strings.NewReader(`{"user_id":`+strconv.FormatInt(nomineeID, 10)+`}`))
+			require.NoError(t, err)
+			req = tests.WithAuthContext(req, userID, orgID)
+
+			mockService := ownership.NewMockService(t)
+			rr := httptest.NewRecorder()
+			handler := ownership.NewHandler(mockService)
+
+			mockService.On("NominateOwner", fake.MockContext, userID, orgID, nomineeID).
+				Return(ownership.Transfer{}, tc.err)
+
+			handler.NominateOwner(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+
+	t.Run("should nominate the user", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		nomineeID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, transferPath,
+			strings.NewReader(`{"user_id":`+strconv.FormatInt(nomineeID, 10)+`}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := ownership.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(mockService)
+		transfer := ownership.Transfer{
+			ID: gofakeit.Int64(), OrganizationID: orgID, FromUserID: userID, ToUserID: nomineeID,
+		}
+
+		mockService.On("NominateOwner", fake.MockContext, userID, orgID, nomineeID).Return(transfer, nil)
+
+		handler.NominateOwner(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var result ownership.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, transfer.ID, result.ID)
+		assert.Equal(t, nomineeID, result.ToUserID)
+	})
+}
+
+func TestHandler_AcceptOwnership(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return forbidden when the nominating user is no longer the owner", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, transferPath+"/accept", nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := ownership.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(mockService)
+
+		mockService.On("AcceptOwnership", fake.MockContext, userID, orgID).Return(ownership.ErrNotOwner)
+
+		handler.AcceptOwnership(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should accept the ownership", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPost, transferPath+"/accept", nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := ownership.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(mockService)
+
+		mockService.On("AcceptOwnership", fake.MockContext, userID, orgID).Return(nil)
+
+		handler.AcceptOwnership(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestHandler_CancelTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should cancel the pending transfer", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, transferPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := ownership.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := ownership.NewHandler(mockService)
+
+		mockService.On("CancelTransfer", fake.MockContext, userID, orgID).Return(nil)
+
+		handler.CancelTransfer(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
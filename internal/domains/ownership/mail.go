@@ -0,0 +1,22 @@
+package ownership
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/camelhr/camelhr-api/internal/mailer"
+)
+
+// nominationEmail returns the email message asking the nominee to accept the ownership of the organization.
+func nominationEmail(to, appURL, subdomain, orgName string) mailer.Message {
+	link := fmt.Sprintf("%s/ownership-transfer?subdomain=%s", appURL, url.QueryEscape(subdomain))
+
+	return mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("You are nominated as the owner of %s on CamelHR", orgName),
+		Body: fmt.Sprintf("The owner of %s has nominated you to take over the ownership of the organization "+
+			"on CamelHR.\n\nSign in and open the link below to accept or decline the nomination. "+
+			"The nomination expires in %d days.\n\n%s\n",
+			orgName, transferTTLDays, link),
+	}
+}
@@ -0,0 +1,58 @@
+package ownership
+
+import (
+	"context"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+// Repository is a repository for managing the ownership transfers in the database.
+type Repository interface {
+	// GetPendingTransfer returns the unexpired transfer of the organization that is neither accepted nor cancelled.
+	GetPendingTransfer(ctx context.Context, orgID int64) (Transfer, error)
+
+	// CreateTransfer stores a new ownership transfer which expires after the given ttl.
+	CreateTransfer(ctx context.Context, t Transfer, ttl time.Duration) (Transfer, error)
+
+	// CancelPendingTransfers marks the transfers of the organization that are neither accepted nor cancelled
+	// as cancelled.
+	CancelPendingTransfers(ctx context.Context, orgID int64) error
+
+	// AcceptTransfer marks the pending transfer of the organization to the given user as accepted and returns it.
+	// It returns sql.ErrNoRows if there is no such transfer or it is expired.
+	AcceptTransfer(ctx context.Context, orgID, toUserID int64) (Transfer, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetPendingTransfer(ctx context.Context, orgID int64) (Transfer, error) {
+	var t Transfer
+	err := r.db.Get(ctx, &t, getPendingTransferQuery, orgID)
+
+	return t, err
+}
+
+func (r *repository) CreateTransfer(ctx context.Context, t Transfer, ttl time.Duration) (Transfer, error) {
+	var result Transfer
+	err := r.db.Exec(ctx, &result, createTransferQuery, t.OrganizationID, t.FromUserID, t.ToUserID, ttl.Seconds())
+
+	return result, err
+}
+
+func (r *repository) CancelPendingTransfers(ctx context.Context, orgID int64) error {
+	return r.db.Exec(ctx, nil, cancelPendingTransfersQuery, orgID)
+}
+
+func (r *repository) AcceptTransfer(ctx context.Context, orgID, toUserID int64) (Transfer, error) {
+	var t Transfer
+	err := r.db.Exec(ctx, &t, acceptTransferQuery, orgID, toUserID)
+
+	return t, err
+}
@@ -0,0 +1,115 @@
+package ownership_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/domains/ownership"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+// newTransfer creates an ownership transfer of a new organization from its owner to another user.
+func (s *OwnershipTestSuite) newTransfer(ttl time.Duration) ownership.Transfer {
+	o := fake.NewOrganization(s.DB)
+	owner := o.AddUser(s.DB, fake.UserIsOwner())
+	nominee := o.AddUser(s.DB)
+
+	t, err := ownership.NewRepository(s.DB).CreateTransfer(context.Background(), ownership.Transfer{
+		OrganizationID: o.ID,
+		FromUserID:     owner.ID,
+		ToUserID:       nominee.ID,
+	}, ttl)
+	s.Require().NoError(err)
+
+	return t
+}
+
+func (s *OwnershipTestSuite) TestRepositoryIntegration_CreateTransfer() {
+	s.Run("should create a transfer expiring after the ttl", func() {
+		s.T().Parallel()
+
+		t := s.newTransfer(ownership.TransferTTL)
+
+		s.NotZero(t.ID)
+		s.WithinDuration(time.Now().UTC().Add(ownership.TransferTTL), t.ExpiresAt, time.Minute)
+		s.Nil(t.AcceptedAt)
+		s.Nil(t.CancelledAt)
+	})
+}
+
+func (s *OwnershipTestSuite) TestRepositoryIntegration_GetPendingTransfer() {
+	s.Run("should return the pending transfer of the organization", func() {
+		s.T().Parallel()
+
+		t := s.newTransfer(ownership.TransferTTL)
+
+		result, err := ownership.NewRepository(s.DB).GetPendingTransfer(context.Background(), t.OrganizationID)
+		s.Require().NoError(err)
+		s.Equal(t.ID, result.ID)
+	})
+
+	s.Run("should not return an expired transfer", func() {
+		s.T().Parallel()
+
+		t := s.newTransfer(-time.Hour)
+
+		_, err := ownership.NewRepository(s.DB).GetPendingTransfer(context.Background(), t.OrganizationID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *OwnershipTestSuite) TestRepositoryIntegration_CancelPendingTransfers() {
+	s.Run("should cancel the pending transfers of the organization", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := ownership.NewRepository(s.DB)
+		t := s.newTransfer(ownership.TransferTTL)
+
+		err := repo.CancelPendingTransfers(ctx, t.OrganizationID)
+		s.Require().NoError(err)
+
+		_, err = repo.GetPendingTransfer(ctx, t.OrganizationID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		_, err = repo.AcceptTransfer(ctx, t.OrganizationID, t.ToUserID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *OwnershipTestSuite) TestRepositoryIntegration_AcceptTransfer() {
+	s.Run("should accept the pending transfer only once", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := ownership.NewRepository(s.DB)
+		t := s.newTransfer(ownership.TransferTTL)
+
+		result, err := repo.AcceptTransfer(ctx, t.OrganizationID, t.ToUserID)
+		s.Require().NoError(err)
+		s.Equal(t.ID, result.ID)
+		s.NotNil(result.AcceptedAt)
+
+		_, err = repo.AcceptTransfer(ctx, t.OrganizationID, t.ToUserID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not accept the transfer for another user", func() {
+		s.T().Parallel()
+
+		t := s.newTransfer(ownership.TransferTTL)
+
+		_, err := ownership.NewRepository(s.DB).AcceptTransfer(context.Background(), t.OrganizationID, t.FromUserID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should not accept an expired transfer", func() {
+		s.T().Parallel()
+
+		t := s.newTransfer(-time.Hour)
+
+		_, err := ownership.NewRepository(s.DB).AcceptTransfer(context.Background(), t.OrganizationID, t.ToUserID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,257 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package ownership
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcceptTransfer provides a mock function with given fields: ctx, orgID, toUserID
+func (_m *MockRepository) AcceptTransfer(ctx context.Context, orgID int64, toUserID int64) (Transfer, error) {
+	ret := _m.Called(ctx, orgID, toUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptTransfer")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (Transfer, error)); ok {
+		return rf(ctx, orgID, toUserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) Transfer); ok {
+		r0 = rf(ctx, orgID, toUserID)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, orgID, toUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AcceptTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptTransfer'
+type MockRepository_AcceptTransfer_Call struct {
+	*mock.Call
+}
+
+// AcceptTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - toUserID int64
+func (_e *MockRepository_Expecter) AcceptTransfer(ctx interface{}, orgID interface{}, toUserID interface{}) *MockRepository_AcceptTransfer_Call {
+	return &MockRepository_AcceptTransfer_Call{Call: _e.mock.On("AcceptTransfer", ctx, orgID, toUserID)}
+}
+
+func (_c *MockRepository_AcceptTransfer_Call) Run(run func(ctx context.Context, orgID int64, toUserID int64)) *MockRepository_AcceptTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcceptTransfer_Call) Return(_a0 Transfer, _a1 error) *MockRepository_AcceptTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AcceptTransfer_Call) RunAndReturn(run func(context.Context, int64, int64) (Transfer, error)) *MockRepository_AcceptTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelPendingTransfers provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) CancelPendingTransfers(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelPendingTransfers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CancelPendingTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelPendingTransfers'
+type MockRepository_CancelPendingTransfers_Call struct {
+	*mock.Call
+}
+
+// CancelPendingTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) CancelPendingTransfers(ctx interface{}, orgID interface{}) *MockRepository_CancelPendingTransfers_Call {
+	return &MockRepository_CancelPendingTransfers_Call{Call: _e.mock.On("CancelPendingTransfers", ctx, orgID)}
+}
+
+func (_c *MockRepository_CancelPendingTransfers_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_CancelPendingTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CancelPendingTransfers_Call) Return(_a0 error) *MockRepository_CancelPendingTransfers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CancelPendingTransfers_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_CancelPendingTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTransfer provides a mock function with given fields: ctx, t, ttl
+func (_m *MockRepository) CreateTransfer(ctx context.Context, t Transfer, ttl time.Duration) (Transfer, error) {
+	ret := _m.Called(ctx, t, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransfer")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Transfer, time.Duration) (Transfer, error)); ok {
+		return rf(ctx, t, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Transfer, time.Duration) Transfer); ok {
+		r0 = rf(ctx, t, ttl)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Transfer, time.Duration) error); ok {
+		r1 = rf(ctx, t, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CreateTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTransfer'
+type MockRepository_CreateTransfer_Call struct {
+	*mock.Call
+}
+
+// CreateTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - t Transfer
+//   - ttl time.Duration
+func (_e *MockRepository_Expecter) CreateTransfer(ctx interface{}, t interface{}, ttl interface{}) *MockRepository_CreateTransfer_Call {
+	return &MockRepository_CreateTransfer_Call{Call: _e.mock.On("CreateTransfer", ctx, t, ttl)}
+}
+
+func (_c *MockRepository_CreateTransfer_Call) Run(run func(ctx context.Context, t Transfer, ttl time.Duration)) *MockRepository_CreateTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Transfer), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateTransfer_Call) Return(_a0 Transfer, _a1 error) *MockRepository_CreateTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CreateTransfer_Call) RunAndReturn(run func(context.Context, Transfer, time.Duration) (Transfer, error)) *MockRepository_CreateTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransfer provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetPendingTransfer(ctx context.Context, orgID int64) (Transfer, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransfer")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Transfer, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Transfer); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransfer'
+type MockRepository_GetPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetPendingTransfer(ctx interface{}, orgID interface{}) *MockRepository_GetPendingTransfer_Call {
+	return &MockRepository_GetPendingTransfer_Call{Call: _e.mock.On("GetPendingTransfer", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetPendingTransfer_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransfer_Call) Return(_a0 Transfer, _a1 error) *MockRepository_GetPendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransfer_Call) RunAndReturn(run func(context.Context, int64) (Transfer, error)) *MockRepository_GetPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
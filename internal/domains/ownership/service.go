@@ -0,0 +1,210 @@
+package ownership
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+)
+
+type Service interface {
+	// GetPendingTransfer returns the pending ownership transfer of the organization.
+	// Only the owner and the nominee can view the transfer.
+	GetPendingTransfer(ctx context.Context, actorID, orgID int64) (Transfer, error)
+
+	// NominateOwner nominates a user of the organization to take over the ownership from the actor
+	// and mails the nominee. The actor must be the owner and the nominee must be an active user with
+	// a verified email. The previously pending transfer of the organization is cancelled.
+	NominateOwner(ctx context.Context, actorID, orgID, nomineeID int64) (Transfer, error)
+
+	// AcceptOwnership makes the actor the owner of the organization when the actor is the nominee of
+	// the pending transfer. The previous owner is assigned the admin role. The cached permissions of both
+	// the users are deleted so that their new permissions take effect on the next request of their sessions.
+	AcceptOwnership(ctx context.Context, actorID, orgID int64) error
+
+	// CancelTransfer cancels the pending ownership transfer of the organization.
+	// The owner can withdraw the nomination and the nominee can decline it.
+	CancelTransfer(ctx context.Context, actorID, orgID int64) error
+}
+
+type service struct {
+	appURL          string
+	repo            Repository
+	transactor      database.Transactor
+	orgService      organization.Service
+	userService     user.Service
+	permissionCache role.PermissionCache
+	mailer          mailer.Mailer
+}
+
+func NewService(
+	conf config.Config, repo Repository, transactor database.Transactor, orgService organization.Service,
+	userService user.Service, permissionCache role.PermissionCache, mailer mailer.Mailer,
+) Service {
+	return &service{
+		appURL:          conf.AppURL,
+		repo:            repo,
+		transactor:      transactor,
+		orgService:      orgService,
+		userService:     userService,
+		permissionCache: permissionCache,
+		mailer:          mailer,
+	}
+}
+
+var (
+	ErrNotOwner       = errors.New("only the owner can transfer the ownership")
+	ErrInvalidNominee = errors.New("the nominee must be another active user of the organization with a verified email")
+)
+
+func (s *service) GetPendingTransfer(ctx context.Context, actorID, orgID int64) (Transfer, error) {
+	t, err := s.getPendingTransfer(ctx, orgID)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	// the transfer is not revealed to the other users of the organization
+	if actorID != t.FromUserID && actorID != t.ToUserID {
+		return Transfer{}, base.NewNotFoundError("ownership transfer not found")
+	}
+
+	return t, nil
+}
+
+func (s *service) NominateOwner(ctx context.Context, actorID, orgID, nomineeID int64) (Transfer, error) {
+	owner, err := s.userService.GetUserByID(ctx, actorID)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	if !owner.IsOwner || owner.OrganizationID != orgID {
+		return Transfer{}, ErrNotOwner
+	}
+
+	nominee, err := s.getNominee(ctx, orgID, nomineeID)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	org, err := s.orgService.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	var t Transfer
+
+	err = s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// only the latest nomination can be accepted
+		if err := s.repo.CancelPendingTransfers(ctx, orgID); err != nil {
+			return err
+		}
+
+		t, err = s.repo.CreateTransfer(ctx, Transfer{
+			OrganizationID: orgID,
+			FromUserID:     owner.ID,
+			ToUserID:       nominee.ID,
+		}, TransferTTL)
+
+		return err
+	})
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	if err := s.mailer.Send(ctx, nominationEmail(nominee.Email, s.appURL, org.Subdomain, org.Name)); err != nil {
+		return Transfer{}, err
+	}
+
+	return t, nil
+}
+
+func (s *service) AcceptOwnership(ctx context.Context, actorID, orgID int64) error {
+	var t Transfer
+
+	err := s.transactor.WithTx(ctx, func(ctx context.Context) error {
+		// mark the transfer as accepted first so that concurrent requests can not accept it twice
+		var err error
+
+		t, err = s.repo.AcceptTransfer(ctx, orgID, actorID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return base.NewNotFoundError("ownership transfer not found")
+			}
+
+			return err
+		}
+
+		// the nominee may have been disabled since the nomination
+		if _, err := s.getNominee(ctx, orgID, actorID); err != nil {
+			return err
+		}
+
+		owner, err := s.userService.GetUserByID(ctx, t.FromUserID)
+		if err != nil {
+			return err
+		}
+
+		if !owner.IsOwner {
+			return ErrNotOwner
+		}
+
+		return s.userService.TransferOwnership(ctx, t.FromUserID, t.ToUserID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.permissionCache.DeleteUserPermissions(ctx, t.FromUserID, orgID); err != nil {
+		return err
+	}
+
+	return s.permissionCache.DeleteUserPermissions(ctx, t.ToUserID, orgID)
+}
+
+func (s *service) CancelTransfer(ctx context.Context, actorID, orgID int64) error {
+	if _, err := s.GetPendingTransfer(ctx, actorID, orgID); err != nil {
+		return err
+	}
+
+	return s.repo.CancelPendingTransfers(ctx, orgID)
+}
+
+// getPendingTransfer returns the pending transfer of the organization or a not found error.
+func (s *service) getPendingTransfer(ctx context.Context, orgID int64) (Transfer, error) {
+	t, err := s.repo.GetPendingTransfer(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Transfer{}, base.NewNotFoundError("ownership transfer not found")
+		}
+
+		return Transfer{}, err
+	}
+
+	return t, nil
+}
+
+// getNominee returns the user of the organization when the user can take over the ownership.
+// It returns ErrInvalidNominee when the user is the owner, disabled or has not verified the email.
+func (s *service) getNominee(ctx context.Context, orgID, userID int64) (user.User, error) {
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	if u.OrganizationID != orgID {
+		return user.User{}, base.NewNotFoundError("user not found for the given id")
+	}
+
+	if u.IsOwner || u.DisabledAt != nil || !u.IsEmailVerified {
+		return user.User{}, ErrInvalidNominee
+	}
+
+	return u, nil
+}
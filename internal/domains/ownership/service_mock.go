@@ -0,0 +1,249 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package ownership
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// AcceptOwnership provides a mock function with given fields: ctx, actorID, orgID
+func (_m *MockService) AcceptOwnership(ctx context.Context, actorID int64, orgID int64) error {
+	ret := _m.Called(ctx, actorID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptOwnership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, actorID, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_AcceptOwnership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptOwnership'
+type MockService_AcceptOwnership_Call struct {
+	*mock.Call
+}
+
+// AcceptOwnership is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+func (_e *MockService_Expecter) AcceptOwnership(ctx interface{}, actorID interface{}, orgID interface{}) *MockService_AcceptOwnership_Call {
+	return &MockService_AcceptOwnership_Call{Call: _e.mock.On("AcceptOwnership", ctx, actorID, orgID)}
+}
+
+func (_c *MockService_AcceptOwnership_Call) Run(run func(ctx context.Context, actorID int64, orgID int64)) *MockService_AcceptOwnership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_AcceptOwnership_Call) Return(_a0 error) *MockService_AcceptOwnership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_AcceptOwnership_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_AcceptOwnership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelTransfer provides a mock function with given fields: ctx, actorID, orgID
+func (_m *MockService) CancelTransfer(ctx context.Context, actorID int64, orgID int64) error {
+	ret := _m.Called(ctx, actorID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, actorID, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_CancelTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTransfer'
+type MockService_CancelTransfer_Call struct {
+	*mock.Call
+}
+
+// CancelTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+func (_e *MockService_Expecter) CancelTransfer(ctx interface{}, actorID interface{}, orgID interface{}) *MockService_CancelTransfer_Call {
+	return &MockService_CancelTransfer_Call{Call: _e.mock.On("CancelTransfer", ctx, actorID, orgID)}
+}
+
+func (_c *MockService_CancelTransfer_Call) Run(run func(ctx context.Context, actorID int64, orgID int64)) *MockService_CancelTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_CancelTransfer_Call) Return(_a0 error) *MockService_CancelTransfer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_CancelTransfer_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_CancelTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransfer provides a mock function with given fields: ctx, actorID, orgID
+func (_m *MockService) GetPendingTransfer(ctx context.Context, actorID int64, orgID int64) (Transfer, error) {
+	ret := _m.Called(ctx, actorID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransfer")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (Transfer, error)); ok {
+		return rf(ctx, actorID, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) Transfer); ok {
+		r0 = rf(ctx, actorID, orgID)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, actorID, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetPendingTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransfer'
+type MockService_GetPendingTransfer_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+func (_e *MockService_Expecter) GetPendingTransfer(ctx interface{}, actorID interface{}, orgID interface{}) *MockService_GetPendingTransfer_Call {
+	return &MockService_GetPendingTransfer_Call{Call: _e.mock.On("GetPendingTransfer", ctx, actorID, orgID)}
+}
+
+func (_c *MockService_GetPendingTransfer_Call) Run(run func(ctx context.Context, actorID int64, orgID int64)) *MockService_GetPendingTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetPendingTransfer_Call) Return(_a0 Transfer, _a1 error) *MockService_GetPendingTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetPendingTransfer_Call) RunAndReturn(run func(context.Context, int64, int64) (Transfer, error)) *MockService_GetPendingTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NominateOwner provides a mock function with given fields: ctx, actorID, orgID, nomineeID
+func (_m *MockService) NominateOwner(ctx context.Context, actorID int64, orgID int64, nomineeID int64) (Transfer, error) {
+	ret := _m.Called(ctx, actorID, orgID, nomineeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NominateOwner")
+	}
+
+	var r0 Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) (Transfer, error)); ok {
+		return rf(ctx, actorID, orgID, nomineeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) Transfer); ok {
+		r0 = rf(ctx, actorID, orgID, nomineeID)
+	} else {
+		r0 = ret.Get(0).(Transfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, actorID, orgID, nomineeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_NominateOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NominateOwner'
+type MockService_NominateOwner_Call struct {
+	*mock.Call
+}
+
+// NominateOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - nomineeID int64
+func (_e *MockService_Expecter) NominateOwner(ctx interface{}, actorID interface{}, orgID interface{}, nomineeID interface{}) *MockService_NominateOwner_Call {
+	return &MockService_NominateOwner_Call{Call: _e.mock.On("NominateOwner", ctx, actorID, orgID, nomineeID)}
+}
+
+func (_c *MockService_NominateOwner_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, nomineeID int64)) *MockService_NominateOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_NominateOwner_Call) Return(_a0 Transfer, _a1 error) *MockService_NominateOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_NominateOwner_Call) RunAndReturn(run func(context.Context, int64, int64, int64) (Transfer, error)) *MockService_NominateOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,331 @@
+package ownership_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/ownership"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const appURL = "https://camelhr.com"
+
+func TestService_GetPendingTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when there is no pending transfer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		service := ownership.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+
+		repo.On("GetPendingTransfer", ctx, orgID).Return(ownership.Transfer{}, sql.ErrNoRows)
+
+		_, err := service.GetPendingTransfer(ctx, gofakeit.Int64(), orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return not found error when the actor is neither the owner nor the nominee", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		service := ownership.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+
+		repo.On("GetPendingTransfer", ctx, orgID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: 1, ToUserID: 2}, nil)
+
+		_, err := service.GetPendingTransfer(ctx, 3, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return the pending transfer to the nominee", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		service := ownership.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+		transfer := ownership.Transfer{ID: gofakeit.Int64(), OrganizationID: orgID, FromUserID: 1, ToUserID: 2}
+
+		repo.On("GetPendingTransfer", ctx, orgID).Return(transfer, nil)
+
+		result, err := service.GetPendingTransfer(ctx, 2, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, transfer, result)
+	})
+}
+
+func TestService_NominateOwner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the actor is not the owner", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		actor := user.User{ID: gofakeit.Int64(), OrganizationID: orgID}
+		userService := user.NewMockService(t)
+		service := ownership.NewService(config.Config{}, nil, nil, nil, userService, nil, nil)
+
+		userService.On("GetUserByID", ctx, actor.ID).Return(actor, nil)
+
+		_, err := service.NominateOwner(ctx, actor.ID, orgID, gofakeit.Int64())
+		require.ErrorIs(t, err, ownership.ErrNotOwner)
+	})
+
+	t.Run("should return not found error when the nominee belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		actor := user.User{ID: 1, OrganizationID: orgID, IsOwner: true}
+		nominee := user.User{ID: 2, OrganizationID: orgID + 1, IsEmailVerified: true}
+		userService := user.NewMockService(t)
+		service := ownership.NewService(config.Config{}, nil, nil, nil, userService, nil, nil)
+
+		userService.On("GetUserByID", ctx, actor.ID).Return(actor, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+
+		_, err := service.NominateOwner(ctx, actor.ID, orgID, nominee.ID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	now := time.Now().UTC()
+	for _, tc := range []struct {
+		name    string
+		nominee user.User
+	}{
+		{name: "should return error when the nominee is the owner",
+			nominee: user.User{ID: 1, IsOwner: true, IsEmailVerified: true}},
+		{name: "should return error when the nominee is disabled",
+			nominee: user.User{ID: 2, IsEmailVerified: true, DisabledAt: &now}},
+		{name: "should return error when the email of the nominee is not verified",
+			nominee: user.User{ID: 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			orgID := gofakeit.Int64()
+			actor := user.User{ID: 1, OrganizationID: orgID, IsOwner: true}
+			nominee := tc.nominee
+			nominee.OrganizationID = orgID
+			userService := user.NewMockService(t)
+			service := ownership.NewService(config.Config{}, nil, nil, nil, userService, nil, nil)
+
+			userService.On("GetUserByID", ctx, actor.ID).Return(actor, nil)
+			userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+
+			_, err := service.NominateOwner(ctx, actor.ID, orgID, nominee.ID)
+			require.ErrorIs(t, err, ownership.ErrInvalidNominee)
+		})
+	}
+
+	t.Run("should cancel the pending transfer and mail the nominee", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "camel", Name: "Camel"}
+		actor := user.User{ID: 1, OrganizationID: org.ID, IsOwner: true}
+		nominee := user.User{ID: 2, OrganizationID: org.ID, Email: gofakeit.Email(), IsEmailVerified: true}
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		orgService := organization.NewMockService(t)
+		userService := user.NewMockService(t)
+		mockMailer := mailer.NewMockMailer(t)
+		service := ownership.NewService(config.Config{AppURL: appURL}, repo, transactor, orgService, userService,
+			nil, mockMailer)
+		transfer := ownership.Transfer{OrganizationID: org.ID, FromUserID: actor.ID, ToUserID: nominee.ID}
+
+		var msg mailer.Message
+
+		userService.On("GetUserByID", ctx, actor.ID).Return(actor, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+		orgService.On("GetOrganizationByID", ctx, org.ID).Return(org, nil)
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		cancelCall := repo.On("CancelPendingTransfers", ctx, org.ID).Return(nil)
+		repo.On("CreateTransfer", ctx, transfer, ownership.TransferTTL).Return(transfer, nil).NotBefore(cancelCall)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("mailer.Message")).
+			Run(func(args mock.Arguments) {
+				msg = args.Get(1).(mailer.Message) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(nil)
+
+		result, err := service.NominateOwner(ctx, actor.ID, org.ID, nominee.ID)
+		require.NoError(t, err)
+		assert.Equal(t, transfer, result)
+		assert.Equal(t, nominee.Email, msg.To)
+		assert.Contains(t, msg.Body, appURL+"/ownership-transfer?subdomain=camel")
+	})
+}
+
+func TestService_AcceptOwnership(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when there is no pending transfer to the actor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		service := ownership.NewService(config.Config{}, repo, transactor, nil, nil, nil, nil)
+
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptTransfer", ctx, orgID, actorID).Return(ownership.Transfer{}, sql.ErrNoRows)
+
+		err := service.AcceptOwnership(ctx, actorID, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the nominee is no longer eligible", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		nominee := user.User{ID: 2, OrganizationID: orgID}
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		userService := user.NewMockService(t)
+		service := ownership.NewService(config.Config{}, repo, transactor, nil, userService, nil, nil)
+
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptTransfer", ctx, orgID, nominee.ID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: 1, ToUserID: nominee.ID}, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+
+		err := service.AcceptOwnership(ctx, nominee.ID, orgID)
+		require.ErrorIs(t, err, ownership.ErrInvalidNominee)
+	})
+
+	t.Run("should return error when the nominating user is no longer the owner", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		owner := user.User{ID: 1, OrganizationID: orgID}
+		nominee := user.User{ID: 2, OrganizationID: orgID, IsEmailVerified: true}
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		userService := user.NewMockService(t)
+		service := ownership.NewService(config.Config{}, repo, transactor, nil, userService, nil, nil)
+
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptTransfer", ctx, orgID, nominee.ID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: owner.ID, ToUserID: nominee.ID}, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+		userService.On("GetUserByID", ctx, owner.ID).Return(owner, nil)
+
+		err := service.AcceptOwnership(ctx, nominee.ID, orgID)
+		require.ErrorIs(t, err, ownership.ErrNotOwner)
+	})
+
+	t.Run("should not delete the cached permissions when the transaction fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		owner := user.User{ID: 1, OrganizationID: orgID, IsOwner: true}
+		nominee := user.User{ID: 2, OrganizationID: orgID, IsEmailVerified: true}
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		userService := user.NewMockService(t)
+		permissionCache := role.NewMockPermissionCache(t)
+		service := ownership.NewService(config.Config{}, repo, transactor, nil, userService, permissionCache, nil)
+
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptTransfer", ctx, orgID, nominee.ID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: owner.ID, ToUserID: nominee.ID}, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+		userService.On("GetUserByID", ctx, owner.ID).Return(owner, nil)
+		userService.On("TransferOwnership", ctx, owner.ID, nominee.ID).Return(assert.AnError)
+
+		err := service.AcceptOwnership(ctx, nominee.ID, orgID)
+		require.ErrorIs(t, err, assert.AnError)
+		permissionCache.AssertNotCalled(t, "DeleteUserPermissions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should transfer the ownership and delete the cached permissions of both the users", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		owner := user.User{ID: 1, OrganizationID: orgID, IsOwner: true}
+		nominee := user.User{ID: 2, OrganizationID: orgID, IsEmailVerified: true}
+		repo := ownership.NewMockRepository(t)
+		transactor := database.NewMockTransactor(t)
+		userService := user.NewMockService(t)
+		permissionCache := role.NewMockPermissionCache(t)
+		service := ownership.NewService(config.Config{}, repo, transactor, nil, userService, permissionCache, nil)
+
+		transactor.On("WithTx", ctx, mock.Anything).Return(tests.RunTx)
+		repo.On("AcceptTransfer", ctx, orgID, nominee.ID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: owner.ID, ToUserID: nominee.ID}, nil)
+		userService.On("GetUserByID", ctx, nominee.ID).Return(nominee, nil)
+		userService.On("GetUserByID", ctx, owner.ID).Return(owner, nil)
+		userService.On("TransferOwnership", ctx, owner.ID, nominee.ID).Return(nil)
+		permissionCache.On("DeleteUserPermissions", ctx, owner.ID, orgID).Return(nil)
+		permissionCache.On("DeleteUserPermissions", ctx, nominee.ID, orgID).Return(nil)
+
+		err := service.AcceptOwnership(ctx, nominee.ID, orgID)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_CancelTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the actor is neither the owner nor the nominee", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		service := ownership.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+
+		repo.On("GetPendingTransfer", ctx, orgID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: 1, ToUserID: 2}, nil)
+
+		err := service.CancelTransfer(ctx, 3, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should allow the nominee to decline the transfer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := ownership.NewMockRepository(t)
+		service := ownership.NewService(config.Config{}, repo, nil, nil, nil, nil, nil)
+
+		repo.On("GetPendingTransfer", ctx, orgID).
+			Return(ownership.Transfer{OrganizationID: orgID, FromUserID: 1, ToUserID: 2}, nil)
+		repo.On("CancelPendingTransfers", ctx, orgID).Return(nil)
+
+		err := service.CancelTransfer(ctx, 2, orgID)
+		require.NoError(t, err)
+	})
+}
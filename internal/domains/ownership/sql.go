@@ -0,0 +1,15 @@
+package ownership
+
+import _ "embed"
+
+//go:embed sql/get_pending_transfer.sql
+var getPendingTransferQuery string
+
+//go:embed sql/create_transfer.sql
+var createTransferQuery string
+
+//go:embed sql/cancel_pending_transfers.sql
+var cancelPendingTransfersQuery string
+
+//go:embed sql/accept_transfer.sql
+var acceptTransferQuery string
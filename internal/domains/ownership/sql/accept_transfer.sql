@@ -0,0 +1,23 @@
+-- acceptTransferQuery
+-- $1: organization_id
+-- $2: to_user_id
+UPDATE
+    ownership_transfers
+SET
+    accepted_at = NOW(),
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND to_user_id = $2
+    AND accepted_at IS NULL
+    AND cancelled_at IS NULL
+    AND expires_at > NOW() RETURNING
+    ownership_transfer_id,
+    organization_id,
+    from_user_id,
+    to_user_id,
+    expires_at,
+    accepted_at,
+    cancelled_at,
+    created_at,
+    updated_at;
@@ -0,0 +1,11 @@
+-- cancelPendingTransfersQuery
+-- $1: organization_id
+UPDATE
+    ownership_transfers
+SET
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND accepted_at IS NULL
+    AND cancelled_at IS NULL;
@@ -0,0 +1,23 @@
+-- createTransferQuery
+-- $1: organization_id
+-- $2: from_user_id
+-- $3: to_user_id
+-- $4: ttl in seconds
+INSERT INTO
+    ownership_transfers(
+        organization_id,
+        from_user_id,
+        to_user_id,
+        expires_at
+    )
+VALUES
+    ($1, $2, $3, NOW() + make_interval(secs => $4)) RETURNING
+    ownership_transfer_id,
+    organization_id,
+    from_user_id,
+    to_user_id,
+    expires_at,
+    accepted_at,
+    cancelled_at,
+    created_at,
+    updated_at;
@@ -0,0 +1,23 @@
+-- getPendingTransferQuery
+-- $1: organization_id
+SELECT
+    ownership_transfer_id,
+    organization_id,
+    from_user_id,
+    to_user_id,
+    expires_at,
+    accepted_at,
+    cancelled_at,
+    created_at,
+    updated_at
+FROM
+    ownership_transfers
+WHERE
+    organization_id = $1
+    AND accepted_at IS NULL
+    AND cancelled_at IS NULL
+    AND expires_at > NOW()
+ORDER BY
+    ownership_transfer_id DESC
+LIMIT
+    1;
@@ -0,0 +1,53 @@
+package ownership
+
+import "time"
+
+const (
+	// TransferTTL is the time duration for which the nominee can accept an ownership transfer.
+	TransferTTL = transferTTLDays * 24 * time.Hour
+
+	transferTTLDays = 7
+)
+
+// Transfer represents the nomination of a user to take over the ownership of an organization.
+type Transfer struct {
+	// ID is the unique identifier of the ownership transfer.
+	ID int64 `db:"ownership_transfer_id"`
+
+	// OrganizationID is the reference to the organization whose ownership is transferred.
+	OrganizationID int64 `db:"organization_id"`
+
+	// FromUserID is the reference to the owner who nominated the user.
+	FromUserID int64 `db:"from_user_id"`
+
+	// ToUserID is the reference to the nominated user.
+	ToUserID int64 `db:"to_user_id"`
+
+	// ExpiresAt is the timestamp after which the transfer can not be accepted.
+	ExpiresAt time.Time `db:"expires_at"`
+
+	// AcceptedAt is the timestamp when the nominee accepted the transfer.
+	AcceptedAt *time.Time `db:"accepted_at"`
+
+	// CancelledAt is the timestamp when the transfer was cancelled by the owner or declined by the nominee.
+	CancelledAt *time.Time `db:"cancelled_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type (
+	// NominateRequest represents the request payload to nominate a user as the new owner.
+	NominateRequest struct {
+		UserID int64 `json:"user_id" validate:"required"`
+	}
+
+	// Response represents the response payload of a pending ownership transfer.
+	Response struct {
+		ID         int64     `json:"id"`
+		FromUserID int64     `json:"from_user_id"`
+		ToUserID   int64     `json:"to_user_id"`
+		ExpiresAt  time.Time `json:"expires_at"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+)
@@ -0,0 +1,29 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// commonPasswordsList is the bundled list of the commonly used and leaked passwords. one lowercase password per line.
+//
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswords returns the set of the bundled common passwords. The list is parsed once on the first use.
+//
+//nolint:gochecknoglobals // the parsed list is shared by all the policies
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordsList))
+	for scanner.Scan() {
+		if p := strings.TrimSpace(scanner.Text()); p != "" {
+			passwords[strings.ToLower(p)] = struct{}{}
+		}
+	}
+
+	return passwords
+})
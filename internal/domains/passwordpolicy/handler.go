@@ -0,0 +1,71 @@
+package passwordpolicy
+
+import (
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetPolicy returns the password policy of the organization of the authenticated user.
+func (h *handler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	p, err := h.service.GetPolicy(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(p))
+}
+
+// SetPolicy creates or replaces the password policy of the organization.
+func (h *handler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload Request
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	p, err := h.service.SetPolicy(r.Context(), orgID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(p))
+}
+
+func toResponse(p Policy) Response {
+	return Response{
+		MinLength:        p.MinLength,
+		MaxLength:        p.MaxLength,
+		RequireUppercase: p.RequireUppercase,
+		RequireLowercase: p.RequireLowercase,
+		RequireNumber:    p.RequireNumber,
+		RequireSpecial:   p.RequireSpecial,
+		RejectCommon:     p.RejectCommon,
+		HistoryCount:     p.HistoryCount,
+		MaxAgeDays:       p.MaxAgeDays,
+	}
+}
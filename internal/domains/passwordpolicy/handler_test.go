@@ -0,0 +1,126 @@
+package passwordpolicy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const policyPath = "/api/v1/subdomains/{subdomain}/organizations/password-policy"
+
+func TestHandler_GetPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the context is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, policyPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := passwordpolicy.NewHandler(passwordpolicy.NewMockService(t))
+
+		// call the handler
+		handler.GetPolicy(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return the password policy of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, policyPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+
+		mockService := passwordpolicy.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := passwordpolicy.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("GetPolicy", fake.MockContext, orgID).Return(passwordpolicy.DefaultPolicy(orgID), nil)
+
+		// call the handler
+		handler.GetPolicy(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"min_length":8,"max_length":64,"require_uppercase":true,"require_lowercase":true,`+
+			`"require_number":true,"require_special":true,"reject_common":false,"history_count":0,"max_age_days":0}`,
+			rr.Body.String())
+	})
+}
+
+func TestHandler_SetPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the maximum length is less than the minimum length", func(t *testing.T) {
+		t.Parallel()
+
+		payload := `{"min_length":12,"max_length":10}`
+		req, err := http.NewRequest(http.MethodPut, policyPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := passwordpolicy.NewHandler(passwordpolicy.NewMockService(t))
+
+		// call the handler
+		handler.SetPolicy(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should store the password policy", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		payload := `{"min_length":12,"max_length":64,"require_number":true,"reject_common":true,` +
+			`"history_count":5,"max_age_days":90}`
+		req, err := http.NewRequest(http.MethodPut, policyPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+
+		mockService := passwordpolicy.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := passwordpolicy.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetPolicy", fake.MockContext, orgID, passwordpolicy.Request{
+			MinLength:     12,
+			MaxLength:     64,
+			RequireNumber: true,
+			RejectCommon:  true,
+			HistoryCount:  5,
+			MaxAgeDays:    90,
+		}).Return(passwordpolicy.Policy{
+			OrganizationID: orgID,
+			MinLength:      12,
+			MaxLength:      64,
+			RequireNumber:  true,
+			RejectCommon:   true,
+			HistoryCount:   5,
+			MaxAgeDays:     90,
+		}, nil)
+
+		// call the handler
+		handler.SetPolicy(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"min_length":12,"max_length":64,"require_uppercase":false,"require_lowercase":false,`+
+			`"require_number":true,"require_special":false,"reject_common":true,"history_count":5,"max_age_days":90}`,
+			rr.Body.String())
+	})
+}
@@ -0,0 +1,74 @@
+package passwordpolicy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+)
+
+// Validate returns an input validation error when the password does not satisfy the policy.
+// The reuse of the previous passwords is checked by the user service since it requires the password hashes.
+func (p Policy) Validate(password string) error {
+	if password == "" {
+		return base.NewInputValidationError("password is required")
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return base.NewInputValidationError(
+			fmt.Sprintf("password must be at least %d characters in length", p.MinLength))
+	}
+
+	if length > p.MaxLength {
+		return base.NewInputValidationError(fmt.Sprintf("password must be at most %d characters", p.MaxLength))
+	}
+
+	var hasUppercase, hasLowercase, hasNumber, hasSpecial bool
+
+	for _, c := range password {
+		switch {
+		case unicode.IsSpace(c):
+			return base.NewInputValidationError("password must not contain whitespace")
+		case unicode.IsUpper(c):
+			hasUppercase = true
+		case unicode.IsLower(c):
+			hasLowercase = true
+		case unicode.IsDigit(c):
+			hasNumber = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUppercase {
+		return base.NewInputValidationError("password must contain at least one uppercase letter")
+	}
+
+	if p.RequireLowercase && !hasLowercase {
+		return base.NewInputValidationError("password must contain at least one lowercase letter")
+	}
+
+	if p.RequireNumber && !hasNumber {
+		return base.NewInputValidationError("password must contain at least one number")
+	}
+
+	if p.RequireSpecial && !hasSpecial {
+		return base.NewInputValidationError("password must contain at least one special character")
+	}
+
+	if p.RejectCommon && isCommonPassword(password) {
+		return base.NewInputValidationError("password is too common")
+	}
+
+	return nil
+}
+
+// isCommonPassword returns whether the password is found in the bundled common passwords list.
+// The comparison is case-insensitive.
+func isCommonPassword(password string) bool {
+	_, found := commonPasswords()[strings.ToLower(password)]
+	return found
+}
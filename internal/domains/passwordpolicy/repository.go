@@ -0,0 +1,39 @@
+package passwordpolicy
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// GetPolicy returns the password policy of the organization.
+	GetPolicy(ctx context.Context, orgID int64) (Policy, error)
+
+	// UpsertPolicy creates or replaces the password policy of the organization.
+	UpsertPolicy(ctx context.Context, p Policy) (Policy, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetPolicy(ctx context.Context, orgID int64) (Policy, error) {
+	var p Policy
+	err := r.db.Get(ctx, &p, getPasswordPolicyQuery, orgID)
+
+	return p, err
+}
+
+func (r *repository) UpsertPolicy(ctx context.Context, p Policy) (Policy, error) {
+	var result Policy
+	err := r.db.Exec(ctx, &result, upsertPasswordPolicyQuery, p.OrganizationID, p.MinLength, p.MaxLength,
+		p.RequireUppercase, p.RequireLowercase, p.RequireNumber, p.RequireSpecial, p.RejectCommon,
+		p.HistoryCount, p.MaxAgeDays)
+
+	return result, err
+}
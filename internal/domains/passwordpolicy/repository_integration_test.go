@@ -0,0 +1,47 @@
+package passwordpolicy_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *PasswordPolicyTestSuite) TestRepositoryIntegration_Policy() {
+	s.Run("should create and replace the password policy", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := passwordpolicy.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		_, err := repo.GetPolicy(ctx, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		p := passwordpolicy.DefaultPolicy(o.ID)
+		p.HistoryCount = 5
+
+		created, err := repo.UpsertPolicy(ctx, p)
+		s.Require().NoError(err)
+		s.Equal(o.ID, created.OrganizationID)
+		s.Equal(5, created.HistoryCount)
+		s.Zero(created.MaxAgeDays)
+		s.NotZero(created.CreatedAt)
+
+		// the policy is replaced for the same organization
+		p.MinLength = 12
+		p.RejectCommon = true
+		p.MaxAgeDays = 90
+
+		_, err = repo.UpsertPolicy(ctx, p)
+		s.Require().NoError(err)
+
+		result, err := repo.GetPolicy(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Equal(12, result.MinLength)
+		s.True(result.RejectCommon)
+		s.Equal(90, result.MaxAgeDays)
+		s.Equal(created.CreatedAt, result.CreatedAt)
+	})
+}
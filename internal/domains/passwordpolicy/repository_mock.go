@@ -0,0 +1,150 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package passwordpolicy
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetPolicy provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetPolicy(ctx context.Context, orgID int64) (Policy, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicy")
+	}
+
+	var r0 Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Policy, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Policy); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPolicy'
+type MockRepository_GetPolicy_Call struct {
+	*mock.Call
+}
+
+// GetPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetPolicy(ctx interface{}, orgID interface{}) *MockRepository_GetPolicy_Call {
+	return &MockRepository_GetPolicy_Call{Call: _e.mock.On("GetPolicy", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetPolicy_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPolicy_Call) Return(_a0 Policy, _a1 error) *MockRepository_GetPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPolicy_Call) RunAndReturn(run func(context.Context, int64) (Policy, error)) *MockRepository_GetPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertPolicy provides a mock function with given fields: ctx, p
+func (_m *MockRepository) UpsertPolicy(ctx context.Context, p Policy) (Policy, error) {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPolicy")
+	}
+
+	var r0 Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Policy) (Policy, error)); ok {
+		return rf(ctx, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Policy) Policy); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Get(0).(Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Policy) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpsertPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertPolicy'
+type MockRepository_UpsertPolicy_Call struct {
+	*mock.Call
+}
+
+// UpsertPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p Policy
+func (_e *MockRepository_Expecter) UpsertPolicy(ctx interface{}, p interface{}) *MockRepository_UpsertPolicy_Call {
+	return &MockRepository_UpsertPolicy_Call{Call: _e.mock.On("UpsertPolicy", ctx, p)}
+}
+
+func (_c *MockRepository_UpsertPolicy_Call) Run(run func(ctx context.Context, p Policy)) *MockRepository_UpsertPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Policy))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertPolicy_Call) Return(_a0 Policy, _a1 error) *MockRepository_UpsertPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpsertPolicy_Call) RunAndReturn(run func(context.Context, Policy) (Policy, error)) *MockRepository_UpsertPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+package passwordpolicy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type Service interface {
+	// GetPolicy returns the password policy of the organization.
+	// The default policy is returned when the organization has not configured a policy.
+	GetPolicy(ctx context.Context, orgID int64) (Policy, error)
+
+	// SetPolicy creates or replaces the password policy of the organization.
+	// The new policy applies to the passwords set afterwards. The existing passwords are kept
+	// except that they expire according to the new maximum age.
+	SetPolicy(ctx context.Context, orgID int64, req Request) (Policy, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo}
+}
+
+func (s *service) GetPolicy(ctx context.Context, orgID int64) (Policy, error) {
+	p, err := s.repo.GetPolicy(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultPolicy(orgID), nil
+	}
+
+	return p, err
+}
+
+func (s *service) SetPolicy(ctx context.Context, orgID int64, req Request) (Policy, error) {
+	return s.repo.UpsertPolicy(ctx, Policy{
+		OrganizationID:   orgID,
+		MinLength:        req.MinLength,
+		MaxLength:        req.MaxLength,
+		RequireUppercase: req.RequireUppercase,
+		RequireLowercase: req.RequireLowercase,
+		RequireNumber:    req.RequireNumber,
+		RequireSpecial:   req.RequireSpecial,
+		RejectCommon:     req.RejectCommon,
+		HistoryCount:     req.HistoryCount,
+		MaxAgeDays:       req.MaxAgeDays,
+	})
+}
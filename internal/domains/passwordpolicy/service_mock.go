@@ -0,0 +1,151 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package passwordpolicy
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// GetPolicy provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetPolicy(ctx context.Context, orgID int64) (Policy, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicy")
+	}
+
+	var r0 Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Policy, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Policy); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPolicy'
+type MockService_GetPolicy_Call struct {
+	*mock.Call
+}
+
+// GetPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) GetPolicy(ctx interface{}, orgID interface{}) *MockService_GetPolicy_Call {
+	return &MockService_GetPolicy_Call{Call: _e.mock.On("GetPolicy", ctx, orgID)}
+}
+
+func (_c *MockService_GetPolicy_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetPolicy_Call) Return(_a0 Policy, _a1 error) *MockService_GetPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetPolicy_Call) RunAndReturn(run func(context.Context, int64) (Policy, error)) *MockService_GetPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPolicy provides a mock function with given fields: ctx, orgID, req
+func (_m *MockService) SetPolicy(ctx context.Context, orgID int64, req Request) (Policy, error) {
+	ret := _m.Called(ctx, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPolicy")
+	}
+
+	var r0 Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, Request) (Policy, error)); ok {
+		return rf(ctx, orgID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, Request) Policy); ok {
+		r0 = rf(ctx, orgID, req)
+	} else {
+		r0 = ret.Get(0).(Policy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, Request) error); ok {
+		r1 = rf(ctx, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SetPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPolicy'
+type MockService_SetPolicy_Call struct {
+	*mock.Call
+}
+
+// SetPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - req Request
+func (_e *MockService_Expecter) SetPolicy(ctx interface{}, orgID interface{}, req interface{}) *MockService_SetPolicy_Call {
+	return &MockService_SetPolicy_Call{Call: _e.mock.On("SetPolicy", ctx, orgID, req)}
+}
+
+func (_c *MockService_SetPolicy_Call) Run(run func(ctx context.Context, orgID int64, req Request)) *MockService_SetPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(Request))
+	})
+	return _c
+}
+
+func (_c *MockService_SetPolicy_Call) Return(_a0 Policy, _a1 error) *MockService_SetPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_SetPolicy_Call) RunAndReturn(run func(context.Context, int64, Request) (Policy, error)) *MockService_SetPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,176 @@
+package passwordpolicy_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   passwordpolicy.Policy
+		password string
+		err      string
+	}{
+		{"empty password", passwordpolicy.DefaultPolicy(1), "", "password is required"},
+		{"too short", passwordpolicy.DefaultPolicy(1), "@2nR", "password must be at least 8 characters in length"},
+		{
+			"too long",
+			passwordpolicy.Policy{MinLength: 8, MaxLength: 10},
+			"abcdefghijk",
+			"password must be at most 10 characters",
+		},
+		{"whitespace", passwordpolicy.DefaultPolicy(1), "P@ss w0rd", "password must not contain whitespace"},
+		{"no uppercase", passwordpolicy.DefaultPolicy(1), "p@ssw0rd", "password must contain at least one uppercase letter"},
+		{"no lowercase", passwordpolicy.DefaultPolicy(1), "P@SSW0RD", "password must contain at least one lowercase letter"},
+		{"no number", passwordpolicy.DefaultPolicy(1), "P@ssword", "password must contain at least one number"},
+		{"no special", passwordpolicy.DefaultPolicy(1), "Passw0rd", "password must contain at least one special character"},
+		{
+			"common password",
+			passwordpolicy.Policy{MinLength: 8, MaxLength: 64, RejectCommon: true},
+			"Password1",
+			"password is too common",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("should reject the password when "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.Validate(tc.password)
+			require.Error(t, err)
+			assert.True(t, base.IsInputValidationError(err))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	t.Run("should accept the password satisfying the policy", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, passwordpolicy.DefaultPolicy(1).Validate("C@mel-hr-2024"))
+		require.NoError(t, passwordpolicy.Policy{MinLength: 8, MaxLength: 64}.Validate("correcthorse"))
+	})
+
+	t.Run("should count the characters instead of the bytes", func(t *testing.T) {
+		t.Parallel()
+
+		p := passwordpolicy.Policy{MinLength: 4, MaxLength: 4}
+
+		require.NoError(t, p.Validate("äöüß"))
+	})
+}
+
+func TestPolicy_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should never expire when the maximum age is not set", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, passwordpolicy.Policy{}.IsExpired(time.Now().UTC().AddDate(-5, 0, 0)))
+	})
+
+	t.Run("should expire once the maximum age is exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		p := passwordpolicy.Policy{MaxAgeDays: 30}
+
+		assert.True(t, p.IsExpired(time.Now().UTC().AddDate(0, 0, -31)))
+		assert.False(t, p.IsExpired(time.Now().UTC().AddDate(0, 0, -29)))
+	})
+}
+
+func TestService_GetPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the default policy when the policy is not configured", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := passwordpolicy.NewMockRepository(t)
+		service := passwordpolicy.NewService(repo)
+
+		repo.On("GetPolicy", ctx, orgID).Return(passwordpolicy.Policy{}, sql.ErrNoRows)
+
+		result, err := service.GetPolicy(ctx, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, passwordpolicy.DefaultPolicy(orgID), result)
+	})
+
+	t.Run("should return error when repository return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := passwordpolicy.NewMockRepository(t)
+		service := passwordpolicy.NewService(repo)
+
+		repo.On("GetPolicy", ctx, orgID).Return(passwordpolicy.Policy{}, assert.AnError)
+
+		_, err := service.GetPolicy(ctx, orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return the configured policy", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		p := passwordpolicy.Policy{OrganizationID: gofakeit.Int64(), MinLength: 12, MaxLength: 64, HistoryCount: 3}
+		repo := passwordpolicy.NewMockRepository(t)
+		service := passwordpolicy.NewService(repo)
+
+		repo.On("GetPolicy", ctx, p.OrganizationID).Return(p, nil)
+
+		result, err := service.GetPolicy(ctx, p.OrganizationID)
+		require.NoError(t, err)
+		assert.Equal(t, p, result)
+	})
+}
+
+func TestService_SetPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should store the policy of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		req := passwordpolicy.Request{
+			MinLength:      12,
+			MaxLength:      64,
+			RequireNumber:  true,
+			RejectCommon:   true,
+			HistoryCount:   5,
+			MaxAgeDays:     90,
+			RequireSpecial: true,
+		}
+		p := passwordpolicy.Policy{
+			OrganizationID: orgID,
+			MinLength:      12,
+			MaxLength:      64,
+			RequireNumber:  true,
+			RequireSpecial: true,
+			RejectCommon:   true,
+			HistoryCount:   5,
+			MaxAgeDays:     90,
+		}
+		repo := passwordpolicy.NewMockRepository(t)
+		service := passwordpolicy.NewService(repo)
+
+		repo.On("UpsertPolicy", ctx, p).Return(p, nil)
+
+		result, err := service.SetPolicy(ctx, orgID, req)
+		require.NoError(t, err)
+		assert.Equal(t, p, result)
+	})
+}
@@ -0,0 +1,9 @@
+package passwordpolicy
+
+import _ "embed"
+
+//go:embed sql/get_password_policy.sql
+var getPasswordPolicyQuery string
+
+//go:embed sql/upsert_password_policy.sql
+var upsertPasswordPolicyQuery string
@@ -0,0 +1,19 @@
+-- getPasswordPolicyQuery
+-- $1: organization_id
+SELECT
+    organization_id,
+    min_length,
+    max_length,
+    require_uppercase,
+    require_lowercase,
+    require_number,
+    require_special,
+    reject_common,
+    history_count,
+    max_age_days,
+    created_at,
+    updated_at
+FROM
+    password_policies
+WHERE
+    organization_id = $1;
@@ -0,0 +1,51 @@
+-- upsertPasswordPolicyQuery
+-- $1: organization_id
+-- $2: min_length
+-- $3: max_length
+-- $4: require_uppercase
+-- $5: require_lowercase
+-- $6: require_number
+-- $7: require_special
+-- $8: reject_common
+-- $9: history_count
+-- $10: max_age_days
+INSERT INTO
+    password_policies(
+        organization_id,
+        min_length,
+        max_length,
+        require_uppercase,
+        require_lowercase,
+        require_number,
+        require_special,
+        reject_common,
+        history_count,
+        max_age_days
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (organization_id) DO
+UPDATE
+SET
+    min_length = EXCLUDED.min_length,
+    max_length = EXCLUDED.max_length,
+    require_uppercase = EXCLUDED.require_uppercase,
+    require_lowercase = EXCLUDED.require_lowercase,
+    require_number = EXCLUDED.require_number,
+    require_special = EXCLUDED.require_special,
+    reject_common = EXCLUDED.reject_common,
+    history_count = EXCLUDED.history_count,
+    max_age_days = EXCLUDED.max_age_days,
+    updated_at = NOW()
+RETURNING
+    organization_id,
+    min_length,
+    max_length,
+    require_uppercase,
+    require_lowercase,
+    require_number,
+    require_special,
+    reject_common,
+    history_count,
+    max_age_days,
+    created_at,
+    updated_at;
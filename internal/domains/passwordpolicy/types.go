@@ -0,0 +1,100 @@
+package passwordpolicy
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMinLength is the minimum length of the passwords of the default policy.
+	DefaultMinLength = 8
+
+	// DefaultMaxLength is the maximum length of the passwords of the default policy.
+	DefaultMaxLength = 64
+)
+
+// Policy represents the password policy of an organization.
+// It applies whenever a user of the organization sets a new password.
+type Policy struct {
+	// OrganizationID is the reference to the organization the policy belongs to.
+	OrganizationID int64 `db:"organization_id"`
+
+	// MinLength is the minimum number of characters of a password.
+	MinLength int `db:"min_length"`
+
+	// MaxLength is the maximum number of characters of a password.
+	MaxLength int `db:"max_length"`
+
+	// RequireUppercase represents whether a password must contain an uppercase letter.
+	RequireUppercase bool `db:"require_uppercase"`
+
+	// RequireLowercase represents whether a password must contain a lowercase letter.
+	RequireLowercase bool `db:"require_lowercase"`
+
+	// RequireNumber represents whether a password must contain a number.
+	RequireNumber bool `db:"require_number"`
+
+	// RequireSpecial represents whether a password must contain a special character.
+	RequireSpecial bool `db:"require_special"`
+
+	// RejectCommon represents whether the passwords found in the common passwords list are rejected.
+	RejectCommon bool `db:"reject_common"`
+
+	// HistoryCount is the number of the most recent passwords of a user that can not be reused.
+	// Zero allows the reuse of any password.
+	HistoryCount int `db:"history_count"`
+
+	// MaxAgeDays is the number of days after which the password must be changed at the login.
+	// Zero means the passwords never expire.
+	MaxAgeDays int `db:"max_age_days"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// DefaultPolicy returns the policy of the organizations which have not configured a password policy.
+func DefaultPolicy(orgID int64) Policy {
+	return Policy{
+		OrganizationID:   orgID,
+		MinLength:        DefaultMinLength,
+		MaxLength:        DefaultMaxLength,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireNumber:    true,
+		RequireSpecial:   true,
+	}
+}
+
+// IsExpired returns whether a password set at the given time must be changed according to the policy.
+func (p Policy) IsExpired(passwordChangedAt time.Time) bool {
+	if p.MaxAgeDays <= 0 {
+		return false
+	}
+
+	return time.Now().UTC().After(passwordChangedAt.AddDate(0, 0, p.MaxAgeDays))
+}
+
+// Request represents the request payload to configure the password policy of the organization.
+type Request struct {
+	MinLength        int  `json:"min_length" validate:"required,min=6,max=128"`
+	MaxLength        int  `json:"max_length" validate:"required,max=128,gtefield=MinLength"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireNumber    bool `json:"require_number"`
+	RequireSpecial   bool `json:"require_special"`
+	RejectCommon     bool `json:"reject_common"`
+	HistoryCount     int  `json:"history_count" validate:"min=0,max=24"`
+	MaxAgeDays       int  `json:"max_age_days" validate:"min=0,max=365"`
+}
+
+// Response represents the response payload of the password policy.
+type Response struct {
+	MinLength        int  `json:"min_length"`
+	MaxLength        int  `json:"max_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireNumber    bool `json:"require_number"`
+	RequireSpecial   bool `json:"require_special"`
+	RejectCommon     bool `json:"reject_common"`
+	HistoryCount     int  `json:"history_count"`
+	MaxAgeDays       int  `json:"max_age_days"`
+}
@@ -0,0 +1,165 @@
+package role
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// ListRoles lists the system roles and the custom roles of the organization.
+func (h *handler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	roles, err := h.service.ListRoles(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	result := make([]Response, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, toResponse(role))
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// CreateRole creates a custom role of the organization.
+func (h *handler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload Request
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	role, err := h.service.CreateRole(r.Context(), userID, orgID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toResponse(role))
+}
+
+// UpdateRole updates a custom role of the organization.
+func (h *handler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	roleID, err := request.URLParamID(r, "roleID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload Request
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	role, err := h.service.UpdateRole(r.Context(), userID, orgID, roleID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toResponse(role))
+}
+
+// DeleteRole deletes a custom role of the organization.
+func (h *handler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	roleID, err := request.URLParamID(r, "roleID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.DeleteRole(r.Context(), orgID, roleID); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// AssignRole assigns a role to a user of the organization.
+func (h *handler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	actorID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	userID, err := request.URLParamID(r, "userID")
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload AssignRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	if err := h.service.AssignRole(r.Context(), actorID, orgID, userID, reqPayload.RoleID); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+// mapError sets the http status of the known role errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrSystemRole), errors.Is(err, ErrOwnerRole), errors.Is(err, ErrPermissionNotGranted):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	case errors.Is(err, ErrDuplicateName), errors.Is(err, ErrRoleInUse):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusConflict))
+	default:
+		return err
+	}
+}
+
+func toResponse(r Role) Response {
+	return Response{
+		ID:          r.ID,
+		Name:        r.Name,
+		Permissions: r.PermissionList(),
+		IsSystem:    r.IsSystem(),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
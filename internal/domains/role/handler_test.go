@@ -0,0 +1,376 @@
+package role_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rolesPath = "/api/v1/subdomains/{subdomain}/roles"
+
+func TestHandler_ListRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the org is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, rolesPath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(role.NewMockService(t))
+
+		handler.ListRoles(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should list the roles of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, rolesPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+		roles := []role.Role{
+			{ID: 1, Name: role.SystemRoleEmployee},
+			{ID: gofakeit.Int64(), OrganizationID: &orgID, Name: "auditor", Permissions: "users:read"},
+		}
+
+		// mock the service calls
+		mockService.On("ListRoles", fake.MockContext, orgID).Return(roles, nil)
+
+		// call the handler
+		handler.ListRoles(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result []role.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.True(t, result[0].IsSystem)
+		assert.Empty(t, result[0].Permissions)
+		assert.False(t, result[1].IsSystem)
+		assert.Equal(t, []string{role.PermissionUsersRead}, result[1].Permissions)
+	})
+}
+
+func TestHandler_CreateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the payload is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		payload := `{"name":"","permissions":["users:read"]}`
+		req, err := http.NewRequest(http.MethodPost, rolesPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(role.NewMockService(t))
+
+		handler.CreateRole(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when the permission is not granted to the actor", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		payload := `{"name":"auditor","permissions":["org:delete"]}`
+		req, err := http.NewRequest(http.MethodPost, rolesPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+		reqPayload := role.Request{Name: "auditor", Permissions: []string{role.PermissionOrgDelete}}
+
+		// mock the service calls
+		mockService.On("CreateRole", fake.MockContext, userID, orgID, reqPayload).
+			Return(role.Role{}, fmt.Errorf("%w: %s", role.ErrPermissionNotGranted, role.PermissionOrgDelete))
+
+		// call the handler
+		handler.CreateRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return conflict when a role with the same name exists", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		payload := `{"name":"auditor","permissions":[]}`
+		req, err := http.NewRequest(http.MethodPost, rolesPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+		reqPayload := role.Request{Name: "auditor", Permissions: []string{}}
+
+		// mock the service calls
+		mockService.On("CreateRole", fake.MockContext, userID, orgID, reqPayload).
+			Return(role.Role{}, role.ErrDuplicateName)
+
+		// call the handler
+		handler.CreateRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should create the role", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		payload := `{"name":"auditor","permissions":["users:read"]}`
+		req, err := http.NewRequest(http.MethodPost, rolesPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+		reqPayload := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead}}
+		created := role.Role{ID: gofakeit.Int64(), OrganizationID: &orgID, Name: "auditor", Permissions: "users:read"}
+
+		// mock the service calls
+		mockService.On("CreateRole", fake.MockContext, userID, orgID, reqPayload).Return(created, nil)
+
+		// call the handler
+		handler.CreateRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var result role.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, created.ID, result.ID)
+		assert.Equal(t, []string{role.PermissionUsersRead}, result.Permissions)
+	})
+}
+
+func TestHandler_UpdateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the role id is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, rolesPath+"/invalid", strings.NewReader(`{"name":"auditor"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithURLParam(req, "roleID", "invalid")
+
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(role.NewMockService(t))
+
+		handler.UpdateRole(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when the role is a system role", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, rolesPath, strings.NewReader(`{"name":"admins"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "roleID", strconv.FormatInt(roleID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("UpdateRole", fake.MockContext, userID, orgID, roleID, role.Request{Name: "admins"}).
+			Return(role.Role{}, role.ErrSystemRole)
+
+		// call the handler
+		handler.UpdateRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should update the role", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		payload := `{"name":"auditor","permissions":["users:read"]}`
+		req, err := http.NewRequest(http.MethodPut, rolesPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithURLParam(req, "roleID", strconv.FormatInt(roleID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+		reqPayload := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead}}
+		updated := role.Role{ID: roleID, OrganizationID: &orgID, Name: "auditor", Permissions: "users:read"}
+
+		// mock the service calls
+		mockService.On("UpdateRole", fake.MockContext, userID, orgID, roleID, reqPayload).Return(updated, nil)
+
+		// call the handler
+		handler.UpdateRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var result role.Response
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, roleID, result.ID)
+	})
+}
+
+func TestHandler_DeleteRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return conflict when the role is assigned to users", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, rolesPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+		req = tests.WithURLParam(req, "roleID", strconv.FormatInt(roleID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteRole", fake.MockContext, orgID, roleID).Return(role.ErrRoleInUse)
+
+		// call the handler
+		handler.DeleteRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("should delete the role", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, rolesPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+		req = tests.WithURLParam(req, "roleID", strconv.FormatInt(roleID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteRole", fake.MockContext, orgID, roleID).Return(nil)
+
+		// call the handler
+		handler.DeleteRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
+
+func TestHandler_AssignRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the payload is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/users/1/role", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithURLParam(req, "userID", "1")
+
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(role.NewMockService(t))
+
+		handler.AssignRole(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when the role of the owner is changed", func(t *testing.T) {
+		t.Parallel()
+
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/users/role", strings.NewReader(`{"role_id":2}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, actorID, orgID)
+		req = tests.WithURLParam(req, "userID", strconv.FormatInt(userID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("AssignRole", fake.MockContext, actorID, orgID, userID, int64(2)).Return(role.ErrOwnerRole)
+
+		// call the handler
+		handler.AssignRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should assign the role", func(t *testing.T) {
+		t.Parallel()
+
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodPut, "/api/v1/users/role", strings.NewReader(`{"role_id":3}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, actorID, orgID)
+		req = tests.WithURLParam(req, "userID", strconv.FormatInt(userID, 10))
+
+		mockService := role.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := role.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("AssignRole", fake.MockContext, actorID, orgID, userID, int64(3)).Return(nil)
+
+		// call the handler
+		handler.AssignRole(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,87 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// the permissions of the users of an organization are kept in a single hash
+// so that they can be invalidated together when a role of the organization changes.
+const permissionsHKeyFormat = "permissions:org:%v"
+
+var ErrCacheMiss = errors.New("permissions not found in the cache")
+
+// PermissionCache is an interface for caching the permissions of the users.
+type PermissionCache interface {
+	// GetPermissions returns the cached permissions of the user. ErrCacheMiss is returned when they are not cached.
+	GetPermissions(ctx context.Context, userID, orgID int64) ([]string, error)
+
+	// SetPermissions caches the permissions of the user.
+	// The cached permissions of the organization expire after the CacheTTL of the last update.
+	SetPermissions(ctx context.Context, userID, orgID int64, permissions []string) error
+
+	// DeleteUserPermissions deletes the cached permissions of the user.
+	DeleteUserPermissions(ctx context.Context, userID, orgID int64) error
+
+	// DeleteOrgPermissions deletes the cached permissions of all the users of the organization.
+	DeleteOrgPermissions(ctx context.Context, orgID int64) error
+}
+
+type permissionCache struct {
+	redisClient *redis.Client
+}
+
+func NewRedisPermissionCache(redisClient *redis.Client) PermissionCache {
+	return &permissionCache{redisClient}
+}
+
+func (c *permissionCache) GetPermissions(ctx context.Context, userID, orgID int64) ([]string, error) {
+	permissions, err := c.redisClient.HGet(ctx, fmt.Sprintf(permissionsHKeyFormat, orgID),
+		strconv.FormatInt(userID, 10)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve permissions for user:%d: %w", userID, err)
+	}
+
+	return splitPermissions(permissions), nil
+}
+
+func (c *permissionCache) SetPermissions(ctx context.Context, userID, orgID int64, permissions []string) error {
+	permissionsHKey := fmt.Sprintf(permissionsHKeyFormat, orgID)
+
+	if err := c.redisClient.HSet(ctx, permissionsHKey, strconv.FormatInt(userID, 10),
+		strings.Join(permissions, permissionsSeparator)).Err(); err != nil {
+		return fmt.Errorf("failed to cache permissions for user:%d: %w", userID, err)
+	}
+
+	if err := c.redisClient.Expire(ctx, permissionsHKey, CacheTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set permissions expiry for org:%d: %w", orgID, err)
+	}
+
+	return nil
+}
+
+func (c *permissionCache) DeleteUserPermissions(ctx context.Context, userID, orgID int64) error {
+	if err := c.redisClient.HDel(ctx, fmt.Sprintf(permissionsHKeyFormat, orgID),
+		strconv.FormatInt(userID, 10)).Err(); err != nil {
+		return fmt.Errorf("failed to delete permissions for user:%d: %w", userID, err)
+	}
+
+	return nil
+}
+
+func (c *permissionCache) DeleteOrgPermissions(ctx context.Context, orgID int64) error {
+	if err := c.redisClient.Del(ctx, fmt.Sprintf(permissionsHKeyFormat, orgID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete permissions for org:%d: %w", orgID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package role
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPermissionCache is an autogenerated mock type for the PermissionCache type
+type MockPermissionCache struct {
+	mock.Mock
+}
+
+type MockPermissionCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPermissionCache) EXPECT() *MockPermissionCache_Expecter {
+	return &MockPermissionCache_Expecter{mock: &_m.Mock}
+}
+
+// DeleteOrgPermissions provides a mock function with given fields: ctx, orgID
+func (_m *MockPermissionCache) DeleteOrgPermissions(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOrgPermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPermissionCache_DeleteOrgPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOrgPermissions'
+type MockPermissionCache_DeleteOrgPermissions_Call struct {
+	*mock.Call
+}
+
+// DeleteOrgPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockPermissionCache_Expecter) DeleteOrgPermissions(ctx interface{}, orgID interface{}) *MockPermissionCache_DeleteOrgPermissions_Call {
+	return &MockPermissionCache_DeleteOrgPermissions_Call{Call: _e.mock.On("DeleteOrgPermissions", ctx, orgID)}
+}
+
+func (_c *MockPermissionCache_DeleteOrgPermissions_Call) Run(run func(ctx context.Context, orgID int64)) *MockPermissionCache_DeleteOrgPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockPermissionCache_DeleteOrgPermissions_Call) Return(_a0 error) *MockPermissionCache_DeleteOrgPermissions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPermissionCache_DeleteOrgPermissions_Call) RunAndReturn(run func(context.Context, int64) error) *MockPermissionCache_DeleteOrgPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserPermissions provides a mock function with given fields: ctx, userID, orgID
+func (_m *MockPermissionCache) DeleteUserPermissions(ctx context.Context, userID int64, orgID int64) error {
+	ret := _m.Called(ctx, userID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserPermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, userID, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPermissionCache_DeleteUserPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserPermissions'
+type MockPermissionCache_DeleteUserPermissions_Call struct {
+	*mock.Call
+}
+
+// DeleteUserPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+func (_e *MockPermissionCache_Expecter) DeleteUserPermissions(ctx interface{}, userID interface{}, orgID interface{}) *MockPermissionCache_DeleteUserPermissions_Call {
+	return &MockPermissionCache_DeleteUserPermissions_Call{Call: _e.mock.On("DeleteUserPermissions", ctx, userID, orgID)}
+}
+
+func (_c *MockPermissionCache_DeleteUserPermissions_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockPermissionCache_DeleteUserPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockPermissionCache_DeleteUserPermissions_Call) Return(_a0 error) *MockPermissionCache_DeleteUserPermissions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPermissionCache_DeleteUserPermissions_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockPermissionCache_DeleteUserPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPermissions provides a mock function with given fields: ctx, userID, orgID
+func (_m *MockPermissionCache) GetPermissions(ctx context.Context, userID int64, orgID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]string, error)); ok {
+		return rf(ctx, userID, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []string); ok {
+		r0 = rf(ctx, userID, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPermissionCache_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type MockPermissionCache_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+func (_e *MockPermissionCache_Expecter) GetPermissions(ctx interface{}, userID interface{}, orgID interface{}) *MockPermissionCache_GetPermissions_Call {
+	return &MockPermissionCache_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx, userID, orgID)}
+}
+
+func (_c *MockPermissionCache_GetPermissions_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockPermissionCache_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockPermissionCache_GetPermissions_Call) Return(_a0 []string, _a1 error) *MockPermissionCache_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPermissionCache_GetPermissions_Call) RunAndReturn(run func(context.Context, int64, int64) ([]string, error)) *MockPermissionCache_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPermissions provides a mock function with given fields: ctx, userID, orgID, permissions
+func (_m *MockPermissionCache) SetPermissions(ctx context.Context, userID int64, orgID int64, permissions []string) error {
+	ret := _m.Called(ctx, userID, orgID, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPermissions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, []string) error); ok {
+		r0 = rf(ctx, userID, orgID, permissions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPermissionCache_SetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPermissions'
+type MockPermissionCache_SetPermissions_Call struct {
+	*mock.Call
+}
+
+// SetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - permissions []string
+func (_e *MockPermissionCache_Expecter) SetPermissions(ctx interface{}, userID interface{}, orgID interface{}, permissions interface{}) *MockPermissionCache_SetPermissions_Call {
+	return &MockPermissionCache_SetPermissions_Call{Call: _e.mock.On("SetPermissions", ctx, userID, orgID, permissions)}
+}
+
+func (_c *MockPermissionCache_SetPermissions_Call) Run(run func(ctx context.Context, userID int64, orgID int64, permissions []string)) *MockPermissionCache_SetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *MockPermissionCache_SetPermissions_Call) Return(_a0 error) *MockPermissionCache_SetPermissions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPermissionCache_SetPermissions_Call) RunAndReturn(run func(context.Context, int64, int64, []string) error) *MockPermissionCache_SetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPermissionCache creates a new instance of MockPermissionCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPermissionCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPermissionCache {
+	mock := &MockPermissionCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
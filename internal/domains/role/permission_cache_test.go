@@ -0,0 +1,165 @@
+package role_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionCache_GetPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return cache miss when the permissions are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHGet(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10)).RedisNil()
+
+		_, err := cache.GetPermissions(context.Background(), userID, orgID)
+		require.ErrorIs(t, err, role.ErrCacheMiss)
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHGet(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10)).
+			SetErr(assert.AnError)
+
+		_, err := cache.GetPermissions(context.Background(), userID, orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return the cached permissions", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHGet(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10)).
+			SetVal("users:read,employees:read")
+
+		permissions, err := cache.GetPermissions(context.Background(), userID, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{role.PermissionUsersRead, role.PermissionEmployeesRead}, permissions)
+	})
+
+	t.Run("should return no permissions when the cached permissions are empty", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHGet(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10)).SetVal("")
+
+		permissions, err := cache.GetPermissions(context.Background(), userID, orgID)
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+	})
+}
+
+func TestPermissionCache_SetPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should cache the permissions with expiry", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		permissionsHKey := fmt.Sprintf("permissions:org:%d", orgID)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHSet(permissionsHKey, strconv.FormatInt(userID, 10), "users:read,users:manage").SetVal(1)
+		redisClientMock.ExpectExpire(permissionsHKey, role.CacheTTL).SetVal(true)
+
+		err := cache.SetPermissions(context.Background(), userID, orgID,
+			[]string{role.PermissionUsersRead, role.PermissionUsersManage})
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHSet(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10), "").
+			SetErr(assert.AnError)
+
+		err := cache.SetPermissions(context.Background(), userID, orgID, []string{})
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestPermissionCache_DeleteUserPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the cached permissions of the user", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectHDel(fmt.Sprintf("permissions:org:%d", orgID), strconv.FormatInt(userID, 10)).SetVal(1)
+
+		err := cache.DeleteUserPermissions(context.Background(), userID, orgID)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestPermissionCache_DeleteOrgPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the cached permissions of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("permissions:org:%d", orgID)).SetVal(1)
+
+		err := cache.DeleteOrgPermissions(context.Background(), orgID)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		cache := role.NewRedisPermissionCache(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("permissions:org:%d", orgID)).SetErr(assert.AnError)
+
+		err := cache.DeleteOrgPermissions(context.Background(), orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
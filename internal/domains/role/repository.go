@@ -0,0 +1,87 @@
+package role
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// ListRoles returns the system roles followed by the custom roles of the organization.
+	ListRoles(ctx context.Context, orgID int64) ([]Role, error)
+
+	// GetRoleByID returns a system role or a custom role of the organization by its id.
+	GetRoleByID(ctx context.Context, orgID, roleID int64) (Role, error)
+
+	// CreateRole creates a new custom role of the organization.
+	CreateRole(ctx context.Context, orgID int64, name, permissions string) (Role, error)
+
+	// UpdateRole updates a custom role of the organization.
+	// It returns sql.ErrNoRows if the role is not found.
+	UpdateRole(ctx context.Context, orgID, roleID int64, name, permissions string) (Role, error)
+
+	// DeleteRole deletes a custom role of the organization.
+	// It returns sql.ErrNoRows if the role is not found.
+	DeleteRole(ctx context.Context, orgID, roleID int64) error
+
+	// CountRoleUsers returns the number of the users the role is assigned to.
+	CountRoleUsers(ctx context.Context, roleID int64) (int64, error)
+
+	// GetUserPermissions returns the comma separated permissions of the role of the user.
+	GetUserPermissions(ctx context.Context, userID, orgID int64) (string, error)
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) ListRoles(ctx context.Context, orgID int64) ([]Role, error) {
+	var roles []Role
+	err := r.db.List(ctx, &roles, listRolesQuery, orgID)
+
+	return roles, err
+}
+
+func (r *repository) GetRoleByID(ctx context.Context, orgID, roleID int64) (Role, error) {
+	var role Role
+	err := r.db.Get(ctx, &role, getRoleByIDQuery, orgID, roleID)
+
+	return role, err
+}
+
+func (r *repository) CreateRole(ctx context.Context, orgID int64, name, permissions string) (Role, error) {
+	var role Role
+	err := r.db.Exec(ctx, &role, createRoleQuery, orgID, name, permissions)
+
+	return role, err
+}
+
+func (r *repository) UpdateRole(ctx context.Context, orgID, roleID int64, name, permissions string) (Role, error) {
+	var role Role
+	err := r.db.Exec(ctx, &role, updateRoleQuery, orgID, roleID, name, permissions)
+
+	return role, err
+}
+
+func (r *repository) DeleteRole(ctx context.Context, orgID, roleID int64) error {
+	var id int64
+	return r.db.Exec(ctx, &id, deleteRoleQuery, orgID, roleID)
+}
+
+func (r *repository) CountRoleUsers(ctx context.Context, roleID int64) (int64, error) {
+	var count int64
+	err := r.db.Get(ctx, &count, countRoleUsersQuery, roleID)
+
+	return count, err
+}
+
+func (r *repository) GetUserPermissions(ctx context.Context, userID, orgID int64) (string, error) {
+	var permissions string
+	err := r.db.Get(ctx, &permissions, getUserPermissionsQuery, userID, orgID)
+
+	return permissions, err
+}
@@ -0,0 +1,176 @@
+package role_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *RoleTestSuite) TestRepositoryIntegration_ListRoles() {
+	s.Run("should list the system roles followed by the roles of the organization", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+		fake.NewRole(s.DB, fake.NewOrganization(s.DB).ID)
+
+		result, err := repo.ListRoles(context.Background(), o.ID)
+		s.Require().NoError(err)
+		s.Require().Len(result, 6)
+
+		names := make([]string, 0, len(result))
+		for _, systemRole := range result[:5] {
+			s.True(systemRole.IsSystem())
+			names = append(names, systemRole.Name)
+		}
+
+		s.ElementsMatch([]string{role.SystemRoleOwner, role.SystemRoleAdmin, role.SystemRoleHRManager,
+			role.SystemRoleManager, role.SystemRoleEmployee}, names)
+		s.Equal(r.ID, result[5].ID)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_GetRoleByID() {
+	s.Run("should return a system role", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		roleID := fake.SystemRoleID(s.DB, role.SystemRoleAdmin)
+
+		result, err := repo.GetRoleByID(context.Background(), o.ID, roleID)
+		s.Require().NoError(err)
+		s.Equal(role.SystemRoleAdmin, result.Name)
+		s.True(result.IsSystem())
+		s.NotContains(result.PermissionList(), role.PermissionOrgDelete)
+	})
+
+	s.Run("should not return a role of another organization", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		r := fake.NewRole(s.DB, fake.NewOrganization(s.DB).ID)
+
+		_, err := repo.GetRoleByID(context.Background(), fake.NewOrganization(s.DB).ID, r.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_CreateRole() {
+	s.Run("should create the role of the organization", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		result, err := repo.CreateRole(ctx, o.ID, "auditor", "users:read")
+		s.Require().NoError(err)
+		s.NotZero(result.ID)
+		s.Require().NotNil(result.OrganizationID)
+		s.Equal(o.ID, *result.OrganizationID)
+		s.Equal("auditor", result.Name)
+		s.Equal([]string{role.PermissionUsersRead}, result.PermissionList())
+
+		// the name is unique for the organization
+		_, err = repo.CreateRole(ctx, o.ID, "auditor", "")
+		s.Require().Error(err)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_UpdateRole() {
+	s.Run("should update the role of the organization", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+
+		result, err := repo.UpdateRole(context.Background(), o.ID, r.ID, "auditor", "users:manage")
+		s.Require().NoError(err)
+		s.Equal(r.ID, result.ID)
+		s.Equal("auditor", result.Name)
+		s.Equal([]string{role.PermissionUsersManage}, result.PermissionList())
+	})
+
+	s.Run("should not update a system role", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		roleID := fake.SystemRoleID(s.DB, role.SystemRoleEmployee)
+
+		_, err := repo.UpdateRole(context.Background(), o.ID, roleID, "employee", "org:delete")
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_DeleteRole() {
+	s.Run("should delete the role of the organization", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := role.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+
+		err := repo.DeleteRole(ctx, o.ID, r.ID)
+		s.Require().NoError(err)
+
+		_, err = repo.GetRoleByID(ctx, o.ID, r.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+
+	s.Run("should return error when the role belongs to another organization", func() {
+		s.T().Parallel()
+
+		repo := role.NewRepository(s.DB)
+		r := fake.NewRole(s.DB, fake.NewOrganization(s.DB).ID)
+
+		err := repo.DeleteRole(context.Background(), fake.NewOrganization(s.DB).ID, r.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_CountRoleUsers() {
+	s.Run("should count the users of the role", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+		fake.NewUser(s.DB, o.ID, fake.UserRole(r.ID))
+		fake.NewUser(s.DB, o.ID, fake.UserRole(r.ID), fake.UserDeleted())
+		fake.NewUser(s.DB, o.ID)
+
+		count, err := role.NewRepository(s.DB).CountRoleUsers(context.Background(), r.ID)
+		s.Require().NoError(err)
+		s.Equal(int64(2), count)
+	})
+}
+
+func (s *RoleTestSuite) TestRepositoryIntegration_GetUserPermissions() {
+	s.Run("should return the permissions of the role of the user", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID, fake.RolePermissions(role.PermissionUsersRead, role.PermissionUsersManage))
+		u := fake.NewUser(s.DB, o.ID, fake.UserRole(r.ID))
+
+		result, err := role.NewRepository(s.DB).GetUserPermissions(context.Background(), u.ID, o.ID)
+		s.Require().NoError(err)
+		s.Equal("users:read,users:manage", result)
+	})
+
+	s.Run("should return error when the user is deleted", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted())
+
+		_, err := role.NewRepository(s.DB).GetUserPermissions(context.Background(), u.ID, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,435 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package role
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountRoleUsers provides a mock function with given fields: ctx, roleID
+func (_m *MockRepository) CountRoleUsers(ctx context.Context, roleID int64) (int64, error) {
+	ret := _m.Called(ctx, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRoleUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, roleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CountRoleUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRoleUsers'
+type MockRepository_CountRoleUsers_Call struct {
+	*mock.Call
+}
+
+// CountRoleUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleID int64
+func (_e *MockRepository_Expecter) CountRoleUsers(ctx interface{}, roleID interface{}) *MockRepository_CountRoleUsers_Call {
+	return &MockRepository_CountRoleUsers_Call{Call: _e.mock.On("CountRoleUsers", ctx, roleID)}
+}
+
+func (_c *MockRepository_CountRoleUsers_Call) Run(run func(ctx context.Context, roleID int64)) *MockRepository_CountRoleUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountRoleUsers_Call) Return(_a0 int64, _a1 error) *MockRepository_CountRoleUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CountRoleUsers_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *MockRepository_CountRoleUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRole provides a mock function with given fields: ctx, orgID, name, permissions
+func (_m *MockRepository) CreateRole(ctx context.Context, orgID int64, name string, permissions string) (Role, error) {
+	ret := _m.Called(ctx, orgID, name, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) (Role, error)); ok {
+		return rf(ctx, orgID, name, permissions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) Role); ok {
+		r0 = rf(ctx, orgID, name, permissions)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, string) error); ok {
+		r1 = rf(ctx, orgID, name, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type MockRepository_CreateRole_Call struct {
+	*mock.Call
+}
+
+// CreateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - name string
+//   - permissions string
+func (_e *MockRepository_Expecter) CreateRole(ctx interface{}, orgID interface{}, name interface{}, permissions interface{}) *MockRepository_CreateRole_Call {
+	return &MockRepository_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, orgID, name, permissions)}
+}
+
+func (_c *MockRepository_CreateRole_Call) Run(run func(ctx context.Context, orgID int64, name string, permissions string)) *MockRepository_CreateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateRole_Call) Return(_a0 Role, _a1 error) *MockRepository_CreateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CreateRole_Call) RunAndReturn(run func(context.Context, int64, string, string) (Role, error)) *MockRepository_CreateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function with given fields: ctx, orgID, roleID
+func (_m *MockRepository) DeleteRole(ctx context.Context, orgID int64, roleID int64) error {
+	ret := _m.Called(ctx, orgID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, orgID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type MockRepository_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - roleID int64
+func (_e *MockRepository_Expecter) DeleteRole(ctx interface{}, orgID interface{}, roleID interface{}) *MockRepository_DeleteRole_Call {
+	return &MockRepository_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, orgID, roleID)}
+}
+
+func (_c *MockRepository_DeleteRole_Call) Run(run func(ctx context.Context, orgID int64, roleID int64)) *MockRepository_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteRole_Call) Return(_a0 error) *MockRepository_DeleteRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteRole_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoleByID provides a mock function with given fields: ctx, orgID, roleID
+func (_m *MockRepository) GetRoleByID(ctx context.Context, orgID int64, roleID int64) (Role, error) {
+	ret := _m.Called(ctx, orgID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoleByID")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (Role, error)); ok {
+		return rf(ctx, orgID, roleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) Role); ok {
+		r0 = rf(ctx, orgID, roleID)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, orgID, roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetRoleByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleByID'
+type MockRepository_GetRoleByID_Call struct {
+	*mock.Call
+}
+
+// GetRoleByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - roleID int64
+func (_e *MockRepository_Expecter) GetRoleByID(ctx interface{}, orgID interface{}, roleID interface{}) *MockRepository_GetRoleByID_Call {
+	return &MockRepository_GetRoleByID_Call{Call: _e.mock.On("GetRoleByID", ctx, orgID, roleID)}
+}
+
+func (_c *MockRepository_GetRoleByID_Call) Run(run func(ctx context.Context, orgID int64, roleID int64)) *MockRepository_GetRoleByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetRoleByID_Call) Return(_a0 Role, _a1 error) *MockRepository_GetRoleByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetRoleByID_Call) RunAndReturn(run func(context.Context, int64, int64) (Role, error)) *MockRepository_GetRoleByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserPermissions provides a mock function with given fields: ctx, userID, orgID
+func (_m *MockRepository) GetUserPermissions(ctx context.Context, userID int64, orgID int64) (string, error) {
+	ret := _m.Called(ctx, userID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserPermissions")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (string, error)); ok {
+		return rf(ctx, userID, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) string); ok {
+		r0 = rf(ctx, userID, orgID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetUserPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserPermissions'
+type MockRepository_GetUserPermissions_Call struct {
+	*mock.Call
+}
+
+// GetUserPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetUserPermissions(ctx interface{}, userID interface{}, orgID interface{}) *MockRepository_GetUserPermissions_Call {
+	return &MockRepository_GetUserPermissions_Call{Call: _e.mock.On("GetUserPermissions", ctx, userID, orgID)}
+}
+
+func (_c *MockRepository_GetUserPermissions_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockRepository_GetUserPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetUserPermissions_Call) Return(_a0 string, _a1 error) *MockRepository_GetUserPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetUserPermissions_Call) RunAndReturn(run func(context.Context, int64, int64) (string, error)) *MockRepository_GetUserPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) ListRoles(ctx context.Context, orgID int64) ([]Role, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]Role, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []Role); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockRepository_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) ListRoles(ctx interface{}, orgID interface{}) *MockRepository_ListRoles_Call {
+	return &MockRepository_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx, orgID)}
+}
+
+func (_c *MockRepository_ListRoles_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListRoles_Call) Return(_a0 []Role, _a1 error) *MockRepository_ListRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListRoles_Call) RunAndReturn(run func(context.Context, int64) ([]Role, error)) *MockRepository_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: ctx, orgID, roleID, name, permissions
+func (_m *MockRepository) UpdateRole(ctx context.Context, orgID int64, roleID int64, name string, permissions string) (Role, error) {
+	ret := _m.Called(ctx, orgID, roleID, name, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string) (Role, error)); ok {
+		return rf(ctx, orgID, roleID, name, permissions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string) Role); ok {
+		r0 = rf(ctx, orgID, roleID, name, permissions)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string, string) error); ok {
+		r1 = rf(ctx, orgID, roleID, name, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockRepository_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - roleID int64
+//   - name string
+//   - permissions string
+func (_e *MockRepository_Expecter) UpdateRole(ctx interface{}, orgID interface{}, roleID interface{}, name interface{}, permissions interface{}) *MockRepository_UpdateRole_Call {
+	return &MockRepository_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, orgID, roleID, name, permissions)}
+}
+
+func (_c *MockRepository_UpdateRole_Call) Run(run func(ctx context.Context, orgID int64, roleID int64, name string, permissions string)) *MockRepository_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateRole_Call) Return(_a0 Role, _a1 error) *MockRepository_UpdateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpdateRole_Call) RunAndReturn(run func(context.Context, int64, int64, string, string) (Role, error)) *MockRepository_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
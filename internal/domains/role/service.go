@@ -0,0 +1,269 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+)
+
+type Service interface {
+	// GetPermissions returns the permissions of the user. The permissions are cached for the CacheTTL.
+	GetPermissions(ctx context.Context, userID, orgID int64) ([]string, error)
+
+	// ListRoles returns the system roles and the custom roles of the organization.
+	ListRoles(ctx context.Context, orgID int64) ([]Role, error)
+
+	// CreateRole creates a new custom role of the organization.
+	// The actor can grant only the permissions granted to the actor.
+	CreateRole(ctx context.Context, actorID, orgID int64, req Request) (Role, error)
+
+	// UpdateRole updates a custom role of the organization.
+	// The actor can grant only the permissions granted to the actor.
+	// The cached permissions of the organization are deleted.
+	UpdateRole(ctx context.Context, actorID, orgID, roleID int64, req Request) (Role, error)
+
+	// DeleteRole deletes a custom role of the organization. The role must not be assigned to any user.
+	DeleteRole(ctx context.Context, orgID, roleID int64) error
+
+	// AssignRole assigns the role to the user of the organization.
+	// The actor can assign only the roles whose permissions are granted to the actor.
+	// The owner role can not be assigned and the role of the owner can not be changed.
+	AssignRole(ctx context.Context, actorID, orgID, userID, roleID int64) error
+
+	// CheckAssignable returns an error when the actor can not assign the role to the users of the organization.
+	// The owner role can not be assigned and the actor can assign only the roles whose permissions are granted
+	// to the actor.
+	CheckAssignable(ctx context.Context, actorID, orgID, roleID int64) error
+}
+
+type service struct {
+	repo            Repository
+	permissionCache PermissionCache
+	userService     user.Service
+}
+
+func NewService(repo Repository, permissionCache PermissionCache, userService user.Service) Service {
+	return &service{repo, permissionCache, userService}
+}
+
+var (
+	ErrSystemRole           = errors.New("system roles can not be changed")
+	ErrOwnerRole            = errors.New("the owner role can be changed only by transferring the ownership")
+	ErrDuplicateName        = errors.New("role with the same name already exists")
+	ErrRoleInUse            = errors.New("role is assigned to users")
+	ErrPermissionNotGranted = errors.New("only the permissions granted to you can be granted")
+)
+
+func (s *service) GetPermissions(ctx context.Context, userID, orgID int64) ([]string, error) {
+	permissions, err := s.permissionCache.GetPermissions(ctx, userID, orgID)
+	if err == nil {
+		return permissions, nil
+	}
+
+	if !errors.Is(err, ErrCacheMiss) {
+		return nil, err
+	}
+
+	p, err := s.repo.GetUserPermissions(ctx, userID, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, base.NewNotFoundError("user not found for the given id")
+		}
+
+		return nil, err
+	}
+
+	permissions = splitPermissions(p)
+	if err := s.permissionCache.SetPermissions(ctx, userID, orgID, permissions); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (s *service) ListRoles(ctx context.Context, orgID int64) ([]Role, error) {
+	return s.repo.ListRoles(ctx, orgID)
+}
+
+func (s *service) CreateRole(ctx context.Context, actorID, orgID int64, req Request) (Role, error) {
+	if err := s.validateRequest(ctx, actorID, orgID, 0, req); err != nil {
+		return Role{}, err
+	}
+
+	return s.repo.CreateRole(ctx, orgID, req.Name, strings.Join(req.Permissions, permissionsSeparator))
+}
+
+func (s *service) UpdateRole(ctx context.Context, actorID, orgID, roleID int64, req Request) (Role, error) {
+	if err := s.checkCustomRole(ctx, orgID, roleID); err != nil {
+		return Role{}, err
+	}
+
+	if err := s.validateRequest(ctx, actorID, orgID, roleID, req); err != nil {
+		return Role{}, err
+	}
+
+	r, err := s.repo.UpdateRole(ctx, orgID, roleID, req.Name, strings.Join(req.Permissions, permissionsSeparator))
+	if err != nil {
+		return Role{}, err
+	}
+
+	// the role may be assigned to any user of the organization
+	if err := s.permissionCache.DeleteOrgPermissions(ctx, orgID); err != nil {
+		return Role{}, err
+	}
+
+	return r, nil
+}
+
+func (s *service) DeleteRole(ctx context.Context, orgID, roleID int64) error {
+	if err := s.checkCustomRole(ctx, orgID, roleID); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountRoleUsers(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := s.repo.DeleteRole(ctx, orgID, roleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return base.NewNotFoundError("role not found for the given id")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) AssignRole(ctx context.Context, actorID, orgID, userID, roleID int64) error {
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// the user must belong to the organization of the actor
+	if u.OrganizationID != orgID {
+		return base.NewNotFoundError("user not found for the given id")
+	}
+
+	r, err := s.getRole(ctx, orgID, roleID)
+	if err != nil {
+		return err
+	}
+
+	if u.IsOwner {
+		return ErrOwnerRole
+	}
+
+	if err := s.checkAssignable(ctx, actorID, orgID, r); err != nil {
+		return err
+	}
+
+	if err := s.userService.SetRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+
+	return s.permissionCache.DeleteUserPermissions(ctx, userID, orgID)
+}
+
+func (s *service) CheckAssignable(ctx context.Context, actorID, orgID, roleID int64) error {
+	r, err := s.getRole(ctx, orgID, roleID)
+	if err != nil {
+		return err
+	}
+
+	return s.checkAssignable(ctx, actorID, orgID, r)
+}
+
+// checkAssignable returns ErrOwnerRole for the owner role and ErrPermissionNotGranted
+// when any of the permissions of the role is not granted to the actor.
+func (s *service) checkAssignable(ctx context.Context, actorID, orgID int64, r Role) error {
+	if r.IsSystem() && r.Name == SystemRoleOwner {
+		return ErrOwnerRole
+	}
+
+	return s.checkGranted(ctx, actorID, orgID, r.PermissionList())
+}
+
+// getRole returns a system role or a custom role of the organization.
+func (s *service) getRole(ctx context.Context, orgID, roleID int64) (Role, error) {
+	r, err := s.repo.GetRoleByID(ctx, orgID, roleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Role{}, base.NewNotFoundError("role not found for the given id")
+		}
+
+		return Role{}, err
+	}
+
+	return r, nil
+}
+
+// checkCustomRole returns ErrSystemRole when the role is a system role.
+func (s *service) checkCustomRole(ctx context.Context, orgID, roleID int64) error {
+	r, err := s.getRole(ctx, orgID, roleID)
+	if err != nil {
+		return err
+	}
+
+	if r.IsSystem() {
+		return ErrSystemRole
+	}
+
+	return nil
+}
+
+// validateRequest validates the permissions and the name of the role.
+// The name must be unique among the roles other than the given role.
+func (s *service) validateRequest(ctx context.Context, actorID, orgID, roleID int64, req Request) error {
+	for _, permission := range req.Permissions {
+		if !IsValidPermission(permission) {
+			return base.NewInputValidationError(fmt.Sprintf("invalid permission: %s", permission))
+		}
+	}
+
+	if err := s.checkGranted(ctx, actorID, orgID, req.Permissions); err != nil {
+		return err
+	}
+
+	roles, err := s.repo.ListRoles(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range roles {
+		if r.ID != roleID && strings.EqualFold(r.Name, req.Name) {
+			return ErrDuplicateName
+		}
+	}
+
+	return nil
+}
+
+// checkGranted returns ErrPermissionNotGranted when any of the permissions is not granted to the actor.
+// This prevents the actor from escalating the privileges using the roles.
+func (s *service) checkGranted(ctx context.Context, actorID, orgID int64, permissions []string) error {
+	granted, err := s.GetPermissions(ctx, actorID, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(granted, permission) {
+			return fmt.Errorf("%w: %s", ErrPermissionNotGranted, permission)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,421 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package role
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// AssignRole provides a mock function with given fields: ctx, actorID, orgID, userID, roleID
+func (_m *MockService) AssignRole(ctx context.Context, actorID int64, orgID int64, userID int64, roleID int64) error {
+	ret := _m.Called(ctx, actorID, orgID, userID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, int64) error); ok {
+		r0 = rf(ctx, actorID, orgID, userID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_AssignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignRole'
+type MockService_AssignRole_Call struct {
+	*mock.Call
+}
+
+// AssignRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - userID int64
+//   - roleID int64
+func (_e *MockService_Expecter) AssignRole(ctx interface{}, actorID interface{}, orgID interface{}, userID interface{}, roleID interface{}) *MockService_AssignRole_Call {
+	return &MockService_AssignRole_Call{Call: _e.mock.On("AssignRole", ctx, actorID, orgID, userID, roleID)}
+}
+
+func (_c *MockService_AssignRole_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, userID int64, roleID int64)) *MockService_AssignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_AssignRole_Call) Return(_a0 error) *MockService_AssignRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_AssignRole_Call) RunAndReturn(run func(context.Context, int64, int64, int64, int64) error) *MockService_AssignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckAssignable provides a mock function with given fields: ctx, actorID, orgID, roleID
+func (_m *MockService) CheckAssignable(ctx context.Context, actorID int64, orgID int64, roleID int64) error {
+	ret := _m.Called(ctx, actorID, orgID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAssignable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, actorID, orgID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_CheckAssignable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAssignable'
+type MockService_CheckAssignable_Call struct {
+	*mock.Call
+}
+
+// CheckAssignable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - roleID int64
+func (_e *MockService_Expecter) CheckAssignable(ctx interface{}, actorID interface{}, orgID interface{}, roleID interface{}) *MockService_CheckAssignable_Call {
+	return &MockService_CheckAssignable_Call{Call: _e.mock.On("CheckAssignable", ctx, actorID, orgID, roleID)}
+}
+
+func (_c *MockService_CheckAssignable_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, roleID int64)) *MockService_CheckAssignable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_CheckAssignable_Call) Return(_a0 error) *MockService_CheckAssignable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_CheckAssignable_Call) RunAndReturn(run func(context.Context, int64, int64, int64) error) *MockService_CheckAssignable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRole provides a mock function with given fields: ctx, actorID, orgID, req
+func (_m *MockService) CreateRole(ctx context.Context, actorID int64, orgID int64, req Request) (Role, error) {
+	ret := _m.Called(ctx, actorID, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, Request) (Role, error)); ok {
+		return rf(ctx, actorID, orgID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, Request) Role); ok {
+		r0 = rf(ctx, actorID, orgID, req)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, Request) error); ok {
+		r1 = rf(ctx, actorID, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRole'
+type MockService_CreateRole_Call struct {
+	*mock.Call
+}
+
+// CreateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - req Request
+func (_e *MockService_Expecter) CreateRole(ctx interface{}, actorID interface{}, orgID interface{}, req interface{}) *MockService_CreateRole_Call {
+	return &MockService_CreateRole_Call{Call: _e.mock.On("CreateRole", ctx, actorID, orgID, req)}
+}
+
+func (_c *MockService_CreateRole_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, req Request)) *MockService_CreateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(Request))
+	})
+	return _c
+}
+
+func (_c *MockService_CreateRole_Call) Return(_a0 Role, _a1 error) *MockService_CreateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CreateRole_Call) RunAndReturn(run func(context.Context, int64, int64, Request) (Role, error)) *MockService_CreateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRole provides a mock function with given fields: ctx, orgID, roleID
+func (_m *MockService) DeleteRole(ctx context.Context, orgID int64, roleID int64) error {
+	ret := _m.Called(ctx, orgID, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, orgID, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_DeleteRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRole'
+type MockService_DeleteRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - roleID int64
+func (_e *MockService_Expecter) DeleteRole(ctx interface{}, orgID interface{}, roleID interface{}) *MockService_DeleteRole_Call {
+	return &MockService_DeleteRole_Call{Call: _e.mock.On("DeleteRole", ctx, orgID, roleID)}
+}
+
+func (_c *MockService_DeleteRole_Call) Run(run func(ctx context.Context, orgID int64, roleID int64)) *MockService_DeleteRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteRole_Call) Return(_a0 error) *MockService_DeleteRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_DeleteRole_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_DeleteRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPermissions provides a mock function with given fields: ctx, userID, orgID
+func (_m *MockService) GetPermissions(ctx context.Context, userID int64, orgID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPermissions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]string, error)); ok {
+		return rf(ctx, userID, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []string); ok {
+		r0 = rf(ctx, userID, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPermissions'
+type MockService_GetPermissions_Call struct {
+	*mock.Call
+}
+
+// GetPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+func (_e *MockService_Expecter) GetPermissions(ctx interface{}, userID interface{}, orgID interface{}) *MockService_GetPermissions_Call {
+	return &MockService_GetPermissions_Call{Call: _e.mock.On("GetPermissions", ctx, userID, orgID)}
+}
+
+func (_c *MockService_GetPermissions_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockService_GetPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetPermissions_Call) Return(_a0 []string, _a1 error) *MockService_GetPermissions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetPermissions_Call) RunAndReturn(run func(context.Context, int64, int64) ([]string, error)) *MockService_GetPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoles provides a mock function with given fields: ctx, orgID
+func (_m *MockService) ListRoles(ctx context.Context, orgID int64) ([]Role, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 []Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]Role, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []Role); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_ListRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoles'
+type MockService_ListRoles_Call struct {
+	*mock.Call
+}
+
+// ListRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) ListRoles(ctx interface{}, orgID interface{}) *MockService_ListRoles_Call {
+	return &MockService_ListRoles_Call{Call: _e.mock.On("ListRoles", ctx, orgID)}
+}
+
+func (_c *MockService_ListRoles_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_ListRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_ListRoles_Call) Return(_a0 []Role, _a1 error) *MockService_ListRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_ListRoles_Call) RunAndReturn(run func(context.Context, int64) ([]Role, error)) *MockService_ListRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: ctx, actorID, orgID, roleID, req
+func (_m *MockService) UpdateRole(ctx context.Context, actorID int64, orgID int64, roleID int64, req Request) (Role, error) {
+	ret := _m.Called(ctx, actorID, orgID, roleID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, Request) (Role, error)); ok {
+		return rf(ctx, actorID, orgID, roleID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, Request) Role); ok {
+		r0 = rf(ctx, actorID, orgID, roleID, req)
+	} else {
+		r0 = ret.Get(0).(Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64, Request) error); ok {
+		r1 = rf(ctx, actorID, orgID, roleID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockService_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - orgID int64
+//   - roleID int64
+//   - req Request
+func (_e *MockService_Expecter) UpdateRole(ctx interface{}, actorID interface{}, orgID interface{}, roleID interface{}, req interface{}) *MockService_UpdateRole_Call {
+	return &MockService_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, actorID, orgID, roleID, req)}
+}
+
+func (_c *MockService_UpdateRole_Call) Run(run func(ctx context.Context, actorID int64, orgID int64, roleID int64, req Request)) *MockService_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64), args[4].(Request))
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateRole_Call) Return(_a0 Role, _a1 error) *MockService_UpdateRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_UpdateRole_Call) RunAndReturn(run func(context.Context, int64, int64, int64, Request) (Role, error)) *MockService_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
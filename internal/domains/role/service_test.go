@@ -0,0 +1,433 @@
+package role_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allPermissions = "org:update,org:delete,org:security,users:read,users:manage," +
+	"roles:manage,employees:read,employees:manage"
+
+func TestRole_IsSystem(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return whether the role is a system role", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+
+		assert.True(t, role.Role{}.IsSystem())
+		assert.False(t, role.Role{OrganizationID: &orgID}.IsSystem())
+	})
+}
+
+func TestService_GetPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the cached permissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(nil, cache, nil)
+
+		cache.On("GetPermissions", ctx, userID, orgID).Return([]string{role.PermissionUsersRead}, nil)
+
+		permissions, err := service.GetPermissions(ctx, userID, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{role.PermissionUsersRead}, permissions)
+	})
+
+	t.Run("should load and cache the permissions on cache miss", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+		expected := []string{role.PermissionUsersRead, role.PermissionEmployeesRead}
+
+		cache.On("GetPermissions", ctx, userID, orgID).Return(nil, role.ErrCacheMiss)
+		repo.On("GetUserPermissions", ctx, userID, orgID).Return("users:read,employees:read", nil)
+		cache.On("SetPermissions", ctx, userID, orgID, expected).Return(nil)
+
+		permissions, err := service.GetPermissions(ctx, userID, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, permissions)
+	})
+
+	t.Run("should return not found error when the user does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+
+		cache.On("GetPermissions", ctx, userID, orgID).Return(nil, role.ErrCacheMiss)
+		repo.On("GetUserPermissions", ctx, userID, orgID).Return("", sql.ErrNoRows)
+
+		_, err := service.GetPermissions(ctx, userID, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the cache fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(nil, cache, nil)
+
+		cache.On("GetPermissions", ctx, userID, orgID).Return(nil, assert.AnError)
+
+		_, err := service.GetPermissions(ctx, userID, orgID)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestService_CreateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the permission is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		service := role.NewService(nil, nil, nil)
+		req := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead, "users:delete"}}
+
+		_, err := service.CreateRole(context.Background(), gofakeit.Int64(), gofakeit.Int64(), req)
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+		assert.ErrorContains(t, err, "invalid permission: users:delete")
+	})
+
+	t.Run("should return error when the permission is not granted to the actor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(nil, cache, nil)
+		req := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead, role.PermissionOrgDelete}}
+
+		cache.On("GetPermissions", ctx, actorID, orgID).
+			Return([]string{role.PermissionUsersRead, role.PermissionRolesManage}, nil)
+
+		_, err := service.CreateRole(ctx, actorID, orgID, req)
+		require.ErrorIs(t, err, role.ErrPermissionNotGranted)
+		assert.ErrorContains(t, err, role.PermissionOrgDelete)
+	})
+
+	t.Run("should return error when a role with the same name exists", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+		req := role.Request{Name: "Admin", Permissions: []string{role.PermissionUsersRead}}
+
+		cache.On("GetPermissions", ctx, actorID, orgID).Return([]string{role.PermissionUsersRead}, nil)
+		repo.On("ListRoles", ctx, orgID).Return([]role.Role{{ID: 1, Name: role.SystemRoleAdmin}}, nil)
+
+		_, err := service.CreateRole(ctx, actorID, orgID, req)
+		require.ErrorIs(t, err, role.ErrDuplicateName)
+	})
+
+	t.Run("should create the role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+		req := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead, role.PermissionEmployeesRead}}
+		expected := role.Role{ID: gofakeit.Int64(), OrganizationID: &orgID, Name: req.Name}
+
+		cache.On("GetPermissions", ctx, actorID, orgID).Return(role.Role{Permissions: allPermissions}.PermissionList(), nil)
+		repo.On("ListRoles", ctx, orgID).Return([]role.Role{{ID: 1, Name: role.SystemRoleAdmin}}, nil)
+		repo.On("CreateRole", ctx, orgID, req.Name, "users:read,employees:read").Return(expected, nil)
+
+		result, err := service.CreateRole(ctx, actorID, orgID, req)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestService_UpdateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the role is a system role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, Name: role.SystemRoleAdmin}, nil)
+
+		_, err := service.UpdateRole(ctx, gofakeit.Int64(), orgID, roleID, role.Request{Name: "admins"})
+		require.ErrorIs(t, err, role.ErrSystemRole)
+	})
+
+	t.Run("should return not found error when the role does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{}, sql.ErrNoRows)
+
+		_, err := service.UpdateRole(ctx, gofakeit.Int64(), orgID, roleID, role.Request{Name: "auditor"})
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should update the role and delete the cached permissions of the organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+		req := role.Request{Name: "auditor", Permissions: []string{role.PermissionUsersRead}}
+		existing := role.Role{ID: roleID, OrganizationID: &orgID, Name: "auditor"}
+		expected := role.Role{ID: roleID, OrganizationID: &orgID, Name: "auditor", Permissions: "users:read"}
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(existing, nil)
+		cache.On("GetPermissions", ctx, actorID, orgID).Return([]string{role.PermissionUsersRead}, nil)
+		// the role itself is not a duplicate
+		repo.On("ListRoles", ctx, orgID).Return([]role.Role{existing}, nil)
+		repo.On("UpdateRole", ctx, orgID, roleID, req.Name, "users:read").Return(expected, nil)
+		cache.On("DeleteOrgPermissions", ctx, orgID).Return(nil)
+
+		result, err := service.UpdateRole(ctx, actorID, orgID, roleID, req)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestService_DeleteRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the role is assigned to users", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, OrganizationID: &orgID}, nil)
+		repo.On("CountRoleUsers", ctx, roleID).Return(int64(2), nil)
+
+		err := service.DeleteRole(ctx, orgID, roleID)
+		require.ErrorIs(t, err, role.ErrRoleInUse)
+	})
+
+	t.Run("should delete the role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, OrganizationID: &orgID}, nil)
+		repo.On("CountRoleUsers", ctx, roleID).Return(int64(0), nil)
+		repo.On("DeleteRole", ctx, orgID, roleID).Return(nil)
+
+		err := service.DeleteRole(ctx, orgID, roleID)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_AssignRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the user belongs to another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		userService := user.NewMockService(t)
+		service := role.NewService(nil, nil, userService)
+
+		userService.On("GetUserByID", ctx, userID).Return(user.User{ID: userID, OrganizationID: orgID + 1}, nil)
+
+		err := service.AssignRole(ctx, gofakeit.Int64(), orgID, userID, gofakeit.Int64())
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the role of the owner is changed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		userService := user.NewMockService(t)
+		service := role.NewService(repo, nil, userService)
+
+		userService.On("GetUserByID", ctx, userID).
+			Return(user.User{ID: userID, OrganizationID: orgID, IsOwner: true}, nil)
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, Name: role.SystemRoleAdmin}, nil)
+
+		err := service.AssignRole(ctx, gofakeit.Int64(), orgID, userID, roleID)
+		require.ErrorIs(t, err, role.ErrOwnerRole)
+	})
+
+	t.Run("should return error when the owner role is assigned", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		userService := user.NewMockService(t)
+		service := role.NewService(repo, nil, userService)
+
+		userService.On("GetUserByID", ctx, userID).Return(user.User{ID: userID, OrganizationID: orgID}, nil)
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, Name: role.SystemRoleOwner}, nil)
+
+		err := service.AssignRole(ctx, gofakeit.Int64(), orgID, userID, roleID)
+		require.ErrorIs(t, err, role.ErrOwnerRole)
+	})
+
+	t.Run("should return error when the permissions of the role are not granted to the actor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		userService := user.NewMockService(t)
+		service := role.NewService(repo, cache, userService)
+
+		userService.On("GetUserByID", ctx, userID).Return(user.User{ID: userID, OrganizationID: orgID}, nil)
+		repo.On("GetRoleByID", ctx, orgID, roleID).
+			Return(role.Role{ID: roleID, Name: role.SystemRoleAdmin, Permissions: "users:read,org:security"}, nil)
+		cache.On("GetPermissions", ctx, actorID, orgID).
+			Return([]string{role.PermissionUsersRead, role.PermissionRolesManage}, nil)
+
+		err := service.AssignRole(ctx, actorID, orgID, userID, roleID)
+		require.ErrorIs(t, err, role.ErrPermissionNotGranted)
+	})
+
+	t.Run("should assign the role and delete the cached permissions of the user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		userID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		userService := user.NewMockService(t)
+		service := role.NewService(repo, cache, userService)
+
+		userService.On("GetUserByID", ctx, userID).Return(user.User{ID: userID, OrganizationID: orgID}, nil)
+		repo.On("GetRoleByID", ctx, orgID, roleID).
+			Return(role.Role{ID: roleID, Name: role.SystemRoleManager, Permissions: "users:read,employees:read"}, nil)
+		cache.On("GetPermissions", ctx, actorID, orgID).
+			Return(role.Role{Permissions: allPermissions}.PermissionList(), nil)
+		userService.On("SetRole", ctx, userID, roleID).Return(nil)
+		cache.On("DeleteUserPermissions", ctx, userID, orgID).Return(nil)
+
+		err := service.AssignRole(ctx, actorID, orgID, userID, roleID)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_CheckAssignable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when the role does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{}, sql.ErrNoRows)
+
+		err := service.CheckAssignable(ctx, gofakeit.Int64(), orgID, roleID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return error when the role is the owner role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		service := role.NewService(repo, nil, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).Return(role.Role{ID: roleID, Name: role.SystemRoleOwner}, nil)
+
+		err := service.CheckAssignable(ctx, gofakeit.Int64(), orgID, roleID)
+		require.ErrorIs(t, err, role.ErrOwnerRole)
+	})
+
+	t.Run("should allow the role whose permissions are granted to the actor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		actorID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleID := gofakeit.Int64()
+		repo := role.NewMockRepository(t)
+		cache := role.NewMockPermissionCache(t)
+		service := role.NewService(repo, cache, nil)
+
+		repo.On("GetRoleByID", ctx, orgID, roleID).
+			Return(role.Role{ID: roleID, Name: role.SystemRoleManager, Permissions: "users:read"}, nil)
+		cache.On("GetPermissions", ctx, actorID, orgID).Return([]string{role.PermissionUsersRead}, nil)
+
+		err := service.CheckAssignable(ctx, actorID, orgID, roleID)
+		require.NoError(t, err)
+	})
+}
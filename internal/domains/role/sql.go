@@ -0,0 +1,24 @@
+package role
+
+import _ "embed"
+
+//go:embed sql/list_roles.sql
+var listRolesQuery string
+
+//go:embed sql/get_role_by_id.sql
+var getRoleByIDQuery string
+
+//go:embed sql/create_role.sql
+var createRoleQuery string
+
+//go:embed sql/update_role.sql
+var updateRoleQuery string
+
+//go:embed sql/delete_role.sql
+var deleteRoleQuery string
+
+//go:embed sql/count_role_users.sql
+var countRoleUsersQuery string
+
+//go:embed sql/get_user_permissions.sql
+var getUserPermissionsQuery string
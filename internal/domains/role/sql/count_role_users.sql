@@ -0,0 +1,9 @@
+-- countRoleUsersQuery
+-- $1: role_id
+-- the deleted users are counted as well since they still reference the role
+SELECT
+    COUNT(*)
+FROM
+    users
+WHERE
+    role_id = $1;
@@ -0,0 +1,14 @@
+-- createRoleQuery
+-- $1: organization_id
+-- $2: name
+-- $3: permissions
+INSERT INTO
+    roles(organization_id, name, permissions)
+VALUES
+    ($1, $2, $3) RETURNING
+    role_id,
+    organization_id,
+    name,
+    permissions,
+    created_at,
+    updated_at;
@@ -0,0 +1,8 @@
+-- deleteRoleQuery
+-- $1: organization_id
+-- $2: role_id
+DELETE FROM
+    roles
+WHERE
+    organization_id = $1
+    AND role_id = $2 RETURNING role_id;
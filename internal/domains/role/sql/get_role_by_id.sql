@@ -0,0 +1,18 @@
+-- getRoleByIDQuery
+-- $1: organization_id
+-- $2: role_id
+SELECT
+    role_id,
+    organization_id,
+    name,
+    permissions,
+    created_at,
+    updated_at
+FROM
+    roles
+WHERE
+    role_id = $2
+    AND (
+        organization_id IS NULL
+        OR organization_id = $1
+    );
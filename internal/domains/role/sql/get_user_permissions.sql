@@ -0,0 +1,12 @@
+-- getUserPermissionsQuery
+-- $1: user_id
+-- $2: organization_id
+SELECT
+    r.permissions
+FROM
+    users u
+    INNER JOIN roles r ON u.role_id = r.role_id
+WHERE
+    u.user_id = $1
+    AND u.organization_id = $2
+    AND u.deleted_at IS NULL;
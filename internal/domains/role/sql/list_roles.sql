@@ -0,0 +1,17 @@
+-- listRolesQuery
+-- $1: organization_id
+SELECT
+    role_id,
+    organization_id,
+    name,
+    permissions,
+    created_at,
+    updated_at
+FROM
+    roles
+WHERE
+    organization_id IS NULL
+    OR organization_id = $1
+ORDER BY
+    organization_id NULLS FIRST,
+    role_id;
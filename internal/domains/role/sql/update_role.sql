@@ -0,0 +1,20 @@
+-- updateRoleQuery
+-- $1: organization_id
+-- $2: role_id
+-- $3: name
+-- $4: permissions
+UPDATE
+    roles
+SET
+    name = $3,
+    permissions = $4,
+    updated_at = NOW()
+WHERE
+    organization_id = $1
+    AND role_id = $2 RETURNING
+    role_id,
+    organization_id,
+    name,
+    permissions,
+    created_at,
+    updated_at;
@@ -0,0 +1,106 @@
+package role
+
+import (
+	"strings"
+	"time"
+)
+
+// The permissions grant the users access to the endpoints.
+// The permissions of a user are the permissions of the role assigned to the user.
+const (
+	PermissionOrgUpdate       = "org:update"
+	PermissionOrgDelete       = "org:delete"
+	PermissionOrgSecurity     = "org:security"
+	PermissionUsersRead       = "users:read"
+	PermissionUsersManage     = "users:manage"
+	PermissionRolesManage     = "roles:manage"
+	PermissionEmployeesRead   = "employees:read"
+	PermissionEmployeesManage = "employees:manage"
+)
+
+// The names of the system roles. The system roles are shared by all the organizations and can not be changed.
+const (
+	SystemRoleOwner     = "owner"
+	SystemRoleAdmin     = "admin"
+	SystemRoleHRManager = "hr_manager"
+	SystemRoleManager   = "manager"
+	SystemRoleEmployee  = "employee"
+)
+
+const (
+	// CacheTTL is the duration for which the permissions of a user are cached.
+	CacheTTL = 10 * time.Minute
+
+	permissionsSeparator = ","
+)
+
+// Role represents a named set of permissions.
+type Role struct {
+	// ID is the unique identifier of the role.
+	ID int64 `db:"role_id"`
+
+	// OrganizationID is the reference to the organization which defined the role.
+	// It is nil for the system roles.
+	OrganizationID *int64 `db:"organization_id"`
+
+	// Name is the unique name of the role.
+	Name string `db:"name"`
+
+	// Permissions is the comma separated list of the permissions granted to the role.
+	Permissions string `db:"permissions"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// IsSystem returns true if the role is a system role.
+func (r Role) IsSystem() bool {
+	return r.OrganizationID == nil
+}
+
+// PermissionList returns the permissions granted to the role.
+func (r Role) PermissionList() []string {
+	return splitPermissions(r.Permissions)
+}
+
+// IsValidPermission returns true if the given permission is one of the known permissions.
+func IsValidPermission(permission string) bool {
+	switch permission {
+	case PermissionOrgUpdate, PermissionOrgDelete, PermissionOrgSecurity, PermissionUsersRead,
+		PermissionUsersManage, PermissionRolesManage, PermissionEmployeesRead, PermissionEmployeesManage:
+		return true
+	default:
+		return false
+	}
+}
+
+// Request represents the request payload to create or update a custom role.
+type Request struct {
+	Name string `json:"name" validate:"required,max=50"`
+
+	Permissions []string `json:"permissions" validate:"unique"`
+}
+
+// AssignRequest represents the request payload to assign a role to a user.
+type AssignRequest struct {
+	RoleID int64 `json:"role_id" validate:"required"`
+}
+
+// Response represents the response payload of a role.
+type Response struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Permissions []string  `json:"permissions"`
+	IsSystem    bool      `json:"is_system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// splitPermissions returns the permissions of the comma separated list. An empty list has no permissions.
+func splitPermissions(permissions string) []string {
+	if permissions == "" {
+		return []string{}
+	}
+
+	return strings.Split(permissions, permissionsSeparator)
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	sessionManager SessionManager
+}
+
+func NewHandler(sessionManager SessionManager) *handler {
+	return &handler{sessionManager}
+}
+
+// ListSessions lists the active sessions of the authenticated user across the devices.
+func (h *handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	sessions, err := h.sessionManager.ListSessions(r.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	// the session id is absent in the context when authenticated using api token
+	currentSessionID, _ := r.Context().Value(request.CtxSessionIDKey).(string)
+
+	result := make([]Response, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, toResponse(s, currentSessionID))
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// RevokeSession signs the authenticated user out of the given session.
+func (h *handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	sessionID := request.URLParam(r, "sessionID")
+
+	if err := h.sessionManager.RevokeSession(r.Context(), userID, orgID, sessionID); err != nil {
+		if errors.Is(err, ErrInvalidSession) {
+			response.ErrorResponse(w, base.NewNotFoundError("session not found"))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+func toResponse(s Session, currentSessionID string) Response {
+	return Response{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		Current:    s.ID == currentSessionID,
+	}
+}
@@ -0,0 +1,211 @@
+package session_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	listSessionsPath  = "/api/v1/subdomains/{subdomain}/me/sessions"
+	revokeSessionPath = "/api/v1/subdomains/{subdomain}/me/sessions/{sessionID}"
+)
+
+func TestHandler_ListSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when user-id is not found in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, listSessionsPath, nil)
+		require.NoError(t, err)
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// call the handler
+		handler.ListSessions(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"user id not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return error when session manager call fails", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, listSessionsPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithSessionID(req, gofakeit.UUID())
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// mock the session manager calls
+		sessionManager.On("ListSessions", fake.MockContext, userID, orgID).Return(nil, assert.AnError)
+
+		// call the handler
+		handler.ListSessions(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should list the sessions and mark the current one", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		currentSessionID := gofakeit.UUID()
+		otherSessionID := gofakeit.UUID()
+		now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+		req, err := http.NewRequest(http.MethodGet, listSessionsPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithSessionID(req, currentSessionID)
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// mock the session manager calls
+		sessionManager.On("ListSessions", fake.MockContext, userID, orgID).Return([]session.Session{
+			{
+				ID:         currentSessionID,
+				UserID:     userID,
+				OrgID:      orgID,
+				UserAgent:  "laptop",
+				IP:         "10.0.0.1",
+				CreatedAt:  now,
+				LastSeenAt: now,
+			},
+			{
+				ID:         otherSessionID,
+				UserID:     userID,
+				OrgID:      orgID,
+				UserAgent:  "phone",
+				IP:         "10.0.0.2",
+				CreatedAt:  now.Add(-time.Hour),
+				LastSeenAt: now.Add(-time.Minute),
+			},
+		}, nil)
+
+		// call the handler
+		handler.ListSessions(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, fmt.Sprintf(`[
+			{
+				"id": "%s",
+				"user_agent": "laptop",
+				"ip": "10.0.0.1",
+				"created_at": "2024-06-01T10:00:00Z",
+				"last_seen_at": "2024-06-01T10:00:00Z",
+				"current": true
+			},
+			{
+				"id": "%s",
+				"user_agent": "phone",
+				"ip": "10.0.0.2",
+				"created_at": "2024-06-01T09:00:00Z",
+				"last_seen_at": "2024-06-01T09:59:00Z",
+				"current": false
+			}
+		]`, currentSessionID, otherSessionID), rr.Body.String())
+	})
+}
+
+func TestHandler_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when org-id is not found in the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodDelete, revokeSessionPath, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxUserIDKey, gofakeit.Int64()))
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// call the handler
+		handler.RevokeSession(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error":"org id not found in the request context: invalid context"}`, rr.Body.String())
+	})
+
+	t.Run("should return not found when session does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodDelete, revokeSessionPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithSessionID(req, gofakeit.UUID())
+		req = tests.WithURLParam(req, "sessionID", sessionID)
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// mock the session manager calls
+		sessionManager.On("RevokeSession", fake.MockContext, userID, orgID, sessionID).
+			Return(session.ErrInvalidSession)
+
+		// call the handler
+		handler.RevokeSession(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error":"session not found"}`, rr.Body.String())
+	})
+
+	t.Run("should revoke the session", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodDelete, revokeSessionPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithSessionID(req, gofakeit.UUID())
+		req = tests.WithURLParam(req, "sessionID", sessionID)
+
+		sessionManager := session.NewMockSessionManager(t)
+		rr := httptest.NewRecorder()
+		handler := session.NewHandler(sessionManager)
+
+		// mock the session manager calls
+		sessionManager.On("RevokeSession", fake.MockContext, userID, orgID, sessionID).Return(nil)
+
+		// call the handler
+		handler.RevokeSession(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+}
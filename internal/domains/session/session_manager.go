@@ -4,47 +4,110 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	sessionHKeyFormat      = "session:org:%v:user:%v"
-	apiTokenHKeyFormat     = "apiToken:%s"
-	apiTokenHKeyDefaultTTL = time.Hour * 24
-
-	orgKey      = "org"
-	userKey     = "user"
-	jwtKey      = "jwt"
-	apiTokenKey = "apiToken"
+	sessionHKeyFormat         = "session:org:%v:user:%v:sid:%v"
+	apiTokenSessionHKeyFormat = "session:org:%v:user:%v:apiToken:%v"
+	userSessionsKeyPattern    = "session:org:%v:user:%v:*"
+	orgSessionsKeyPattern     = "session:org:%v:user:*"
+	apiTokenHKeyFormat        = "apiToken:%s"
+	refreshTokenHKeyFormat    = "refreshToken:%s"
+
+	orgKey        = "org"
+	userKey       = "user"
+	jwtKey        = "jwt"
+	apiTokenKey   = "apiToken"
+	userAgentKey  = "userAgent"
+	ipKey         = "ip"
+	createdAtKey  = "createdAt"
+	lastSeenAtKey = "lastSeenAt"
+	ttlKey        = "ttl"
+	sessionIDKey  = "sid"
+	usedAtKey     = "usedAt"
+	tokenIDKey    = "tokenID"
+	scopesKey     = "scopes"
+	expiresAtKey  = "expiresAt"
+
+	scopesSeparator = ","
 )
 
 var (
-	ErrInvalidSession = errors.New("invalid session")
-	ErrMissingUserID  = errors.New("missing user id")
-	ErrMissingOrgID   = errors.New("missing org id")
-	ErrMissingToken   = errors.New("missing token")
+	ErrInvalidSession   = errors.New("invalid session")
+	ErrMissingUserID    = errors.New("missing user id")
+	ErrMissingOrgID     = errors.New("missing org id")
+	ErrMissingSessionID = errors.New("missing session id")
+	ErrMissingToken     = errors.New("missing token")
+
+	ErrRefreshTokenReused = errors.New("refresh token reused")
 )
 
 // SessionManager is an interface for managing user sessions.
-// It provides methods to create, validate and delete sessions.
+// It provides methods to create, validate, list and delete sessions.
 // The session can be validated using either JWT or API token.
+// A user can have multiple concurrent JWT sessions, one per device.
+// Each JWT session is renewed using a refresh token that is rotated on every use.
 type SessionManager interface {
-	// CreateSession creates a new session for the user of the given organization
-	// The session can be created using either JWT or API token
-	CreateSession(ctx context.Context, userID, orgID int64, jwt, apiToken string, ttl time.Duration) error
-
-	// ValidateJWTSession validates the JWT session for the user of the given organization
-	ValidateJWTSession(ctx context.Context, userID, orgID int64, jwt string) error
-
-	// ValidateAPITokenSession validates the API token session and returns the associated userID and orgID
-	ValidateAPITokenSession(ctx context.Context, apiToken string) (int64, int64, error)
-
-	// DeleteSession deletes the session for the user of the given organization
+	// CreateSession creates a new JWT session for the user of the given organization.
+	// The existing sessions of the user on other devices are kept.
+	// The refresh token can be used once to renew the session within the ttl.
+	CreateSession(
+		ctx context.Context,
+		userID, orgID int64,
+		sessionID, jwt, refreshToken string,
+		device Device,
+		ttl time.Duration,
+	) error
+
+	// ConsumeRefreshToken marks the given refresh token of the organization as used and returns the associated session.
+	// If the token was already used, the session is revoked along with all its refresh tokens
+	// and ErrRefreshTokenReused is returned. The token of another organization is neither used nor revoked
+	// and ErrInvalidSession is returned.
+	ConsumeRefreshToken(ctx context.Context, orgID int64, refreshToken string) (Session, error)
+
+	// RenewSession replaces the JWT and the refresh token of the given session and extends it by the ttl
+	RenewSession(
+		ctx context.Context,
+		userID, orgID int64,
+		sessionID, jwt, refreshToken string,
+		ttl time.Duration,
+	) error
+
+	// CreateAPITokenSession caches the authenticated API token for the ttl.
+	// The session expires along with the token if the token expires earlier.
+	// Only the hash of the API token is stored.
+	CreateAPITokenSession(ctx context.Context, apiToken string, s APITokenSession, ttl time.Duration) error
+
+	// ValidateJWTSession validates the JWT session for the user of the given organization.
+	// It also updates the last seen time of the session.
+	ValidateJWTSession(ctx context.Context, userID, orgID int64, sessionID, jwt string) error
+
+	// ValidateAPITokenSession validates the cached API token and returns the associated session
+	ValidateAPITokenSession(ctx context.Context, apiToken string) (APITokenSession, error)
+
+	// DeleteAPITokenSession deletes the cached session of the given API token of the user
+	DeleteAPITokenSession(ctx context.Context, userID, orgID, tokenID int64) error
+
+	// ListSessions returns the active JWT sessions of the user of the given organization.
+	// The sessions are sorted by the last seen time in descending order.
+	ListSessions(ctx context.Context, userID, orgID int64) ([]Session, error)
+
+	// RevokeSession deletes the given JWT session of the user of the given organization
+	RevokeSession(ctx context.Context, userID, orgID int64, sessionID string) error
+
+	// DeleteSession deletes all the sessions for the user of the given organization
 	DeleteSession(ctx context.Context, userID, orgID int64) error
 
+	// DeleteOtherSessions deletes all the sessions for the user of the given organization except the given JWT session
+	DeleteOtherSessions(ctx context.Context, userID, orgID int64, sessionID string) error
+
 	// DeleteAllOrgSessions deletes all user sessions under the given organization
 	DeleteAllOrgSessions(ctx context.Context, orgID int64) error
 }
@@ -60,54 +123,197 @@ func NewRedisSessionManager(redisClient *redis.Client) SessionManager {
 func (m *sessionManager) CreateSession(
 	ctx context.Context,
 	userID, orgID int64,
-	jwt, apiToken string,
+	sessionID, jwt, refreshToken string,
+	device Device,
 	ttl time.Duration,
 ) error {
-	if err := m.validateParams(userID, orgID, jwt, apiToken); err != nil {
+	if err := m.validateParams(userID, orgID, jwt); err != nil {
 		return err
 	}
 
-	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID)
-	keyValues := []any{orgKey, orgID, userKey, userID}
+	if sessionID == "" {
+		return ErrMissingSessionID
+	}
 
-	if jwt != "" {
-		keyValues = append(keyValues, jwtKey, jwt)
+	if refreshToken == "" {
+		return ErrMissingToken
 	}
 
-	if apiToken != "" {
-		keyValues = append(keyValues, apiTokenKey, apiToken)
+	now := time.Now().UTC().Unix()
+	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
 
-		// store user data for apiToken
-		apiTokenHKey := fmt.Sprintf(apiTokenHKeyFormat, apiToken)
-		if err := m.redisClient.HSet(ctx, apiTokenHKey, orgKey, orgID, userKey, userID).Err(); err != nil {
-			return fmt.Errorf("failed to set api-token hash key for user:%d org:%d: %w", userID, orgID, err)
-		}
+	// store user session
+	if err := m.redisClient.HSet(ctx, sessionHKey, orgKey, orgID, userKey, userID, jwtKey, jwt,
+		userAgentKey, device.UserAgent, ipKey, device.IP, createdAtKey, now, lastSeenAtKey, now,
+		ttlKey, int64(ttl.Seconds())).Err(); err != nil {
+		return fmt.Errorf("failed to persist session for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	// set expiry for session hash key
+	if err := m.redisClient.Expire(ctx, sessionHKey, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set session hash key expiry for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	return m.storeRefreshToken(ctx, userID, orgID, sessionID, refreshToken, ttl)
+}
+
+func (m *sessionManager) ConsumeRefreshToken(ctx context.Context, orgID int64, refreshToken string) (Session, error) {
+	if refreshToken == "" {
+		return Session{}, ErrMissingToken
+	}
+
+	refreshTokenHKey := fmt.Sprintf(refreshTokenHKeyFormat, base.HashToken(refreshToken))
+
+	tokenData, err := m.redisClient.HGetAll(ctx, refreshTokenHKey).Result()
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to retrieve session data of refresh-token: %w", err)
+	}
+
+	if len(tokenData) == 0 {
+		return Session{}, fmt.Errorf("session data not found for refresh-token: %w", ErrInvalidSession)
+	}
+
+	userID, _ := strconv.ParseInt(tokenData[userKey], 10, 64)
+	tokenOrgID, _ := strconv.ParseInt(tokenData[orgKey], 10, 64)
+	sessionID := tokenData[sessionIDKey]
+
+	// the token presented to another organization is kept usable by its own organization
+	if tokenOrgID != orgID {
+		return Session{}, fmt.Errorf("refresh-token does not belong to org:%d: %w", orgID, ErrInvalidSession)
+	}
+
+	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
+
+	// only the first use of the refresh token can mark it as used
+	firstUse, err := m.redisClient.HSetNX(ctx, refreshTokenHKey, usedAtKey, time.Now().UTC().Unix()).Result()
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to mark refresh-token as used for user:%d org:%d: %w",
+			userID, orgID, err)
+	}
 
-		// set expiry for api-token hash key
-		if err := m.redisClient.Expire(ctx, apiTokenHKey, apiTokenHKeyDefaultTTL).Err(); err != nil {
-			return fmt.Errorf("failed to set api-token hash key expiry for user:%d org:%d: %w", userID, orgID, err)
+	if !firstUse {
+		// the token was already rotated and might have been stolen
+		// revoke the session so that no token of the family can be used anymore
+		if err := m.redisClient.Del(ctx, sessionHKey).Err(); err != nil {
+			return Session{}, fmt.Errorf("failed to revoke session for user:%d org:%d: %w",
+				userID, orgID, err)
 		}
+
+		return Session{}, fmt.Errorf("refresh-token reused for user:%d org:%d: %w",
+			userID, orgID, ErrRefreshTokenReused)
 	}
 
-	// store user session
-	if err := m.redisClient.HSet(ctx, sessionHKey, keyValues...).Err(); err != nil {
-		return fmt.Errorf("failed to persist session for user:%d org:%d: %w", userID, orgID, err)
+	sessionData, err := m.redisClient.HGetAll(ctx, sessionHKey).Result()
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to retrieve session data for user:%d org:%d: %w",
+			userID, orgID, err)
 	}
 
-	// set expiry for session hash key
+	if len(sessionData) == 0 {
+		return Session{}, fmt.Errorf("session not found for user:%d org:%d: %w",
+			userID, orgID, ErrInvalidSession)
+	}
+
+	return toSession(sessionID, userID, orgID, sessionData), nil
+}
+
+func (m *sessionManager) RenewSession(
+	ctx context.Context,
+	userID, orgID int64,
+	sessionID, jwt, refreshToken string,
+	ttl time.Duration,
+) error {
+	if err := m.validateParams(userID, orgID, jwt); err != nil {
+		return err
+	}
+
+	if sessionID == "" {
+		return ErrMissingSessionID
+	}
+
+	if refreshToken == "" {
+		return ErrMissingToken
+	}
+
+	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
+
+	// the session might have been revoked meanwhile
+	if m.redisClient.Exists(ctx, sessionHKey).Val() == 0 {
+		return fmt.Errorf("session not found for user:%d org:%d: %w",
+			userID, orgID, ErrInvalidSession)
+	}
+
+	if err := m.redisClient.HSet(ctx, sessionHKey, jwtKey, jwt,
+		lastSeenAtKey, time.Now().UTC().Unix()).Err(); err != nil {
+		return fmt.Errorf("failed to renew session for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	// extend the session expiry
 	if err := m.redisClient.Expire(ctx, sessionHKey, ttl).Err(); err != nil {
 		return fmt.Errorf("failed to set session hash key expiry for user:%d org:%d: %w", userID, orgID, err)
 	}
 
+	return m.storeRefreshToken(ctx, userID, orgID, sessionID, refreshToken, ttl)
+}
+
+func (m *sessionManager) CreateAPITokenSession(
+	ctx context.Context,
+	apiToken string,
+	s APITokenSession,
+	ttl time.Duration,
+) error {
+	if err := m.validateParams(s.UserID, s.OrgID, apiToken); err != nil {
+		return err
+	}
+
+	// do not keep the session beyond the expiry of the token
+	if untilExpiry := time.Until(s.ExpiresAt); untilExpiry < ttl {
+		ttl = untilExpiry
+	}
+
+	if ttl <= 0 {
+		return fmt.Errorf("api-token expired for user:%d org:%d: %w", s.UserID, s.OrgID, ErrInvalidSession)
+	}
+
+	// store session data for the hash of the apiToken
+	tokenHash := base.HashToken(apiToken)
+	apiTokenHKey := fmt.Sprintf(apiTokenHKeyFormat, tokenHash)
+
+	if err := m.redisClient.HSet(ctx, apiTokenHKey, orgKey, s.OrgID, userKey, s.UserID, tokenIDKey, s.TokenID,
+		scopesKey, strings.Join(s.Scopes, scopesSeparator), expiresAtKey, s.ExpiresAt.Unix()).Err(); err != nil {
+		return fmt.Errorf("failed to set api-token hash key for user:%d org:%d: %w", s.UserID, s.OrgID, err)
+	}
+
+	// set expiry for api-token hash key
+	if err := m.redisClient.Expire(ctx, apiTokenHKey, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set api-token hash key expiry for user:%d org:%d: %w", s.UserID, s.OrgID, err)
+	}
+
+	// store user session of the token. it is deleted along with the other sessions of the user
+	sessionHKey := fmt.Sprintf(apiTokenSessionHKeyFormat, s.OrgID, s.UserID, s.TokenID)
+	if err := m.redisClient.HSet(ctx, sessionHKey, orgKey, s.OrgID, userKey, s.UserID,
+		apiTokenKey, tokenHash).Err(); err != nil {
+		return fmt.Errorf("failed to persist session for user:%d org:%d: %w", s.UserID, s.OrgID, err)
+	}
+
+	// set expiry for session hash key
+	if err := m.redisClient.Expire(ctx, sessionHKey, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set session hash key expiry for user:%d org:%d: %w", s.UserID, s.OrgID, err)
+	}
+
 	return nil
 }
 
-func (m *sessionManager) ValidateJWTSession(ctx context.Context, userID, orgID int64, jwt string) error {
-	if err := m.validateParams(userID, orgID, jwt, ""); err != nil {
+func (m *sessionManager) ValidateJWTSession(ctx context.Context, userID, orgID int64, sessionID, jwt string) error {
+	if err := m.validateParams(userID, orgID, jwt); err != nil {
 		return err
 	}
 
-	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID)
+	if sessionID == "" {
+		return ErrMissingSessionID
+	}
+
+	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
 
 	if m.redisClient.Exists(ctx, sessionHKey).Val() == 0 {
 		return fmt.Errorf("session not found for user:%d org:%d: %w",
@@ -125,52 +331,133 @@ func (m *sessionManager) ValidateJWTSession(ctx context.Context, userID, orgID i
 			userID, orgID, ErrInvalidSession)
 	}
 
+	if err := m.redisClient.HSet(ctx, sessionHKey, lastSeenAtKey, time.Now().UTC().Unix()).Err(); err != nil {
+		return fmt.Errorf("failed to update session last seen time for user:%d org:%d: %w",
+			userID, orgID, err)
+	}
+
 	return nil
 }
 
-func (m *sessionManager) ValidateAPITokenSession(ctx context.Context, apiToken string) (int64, int64, error) {
+func (m *sessionManager) ValidateAPITokenSession(ctx context.Context, apiToken string) (APITokenSession, error) {
 	if apiToken == "" {
-		return 0, 0, ErrMissingToken
+		return APITokenSession{}, ErrMissingToken
 	}
 
-	apiTokenHKey := fmt.Sprintf(apiTokenHKeyFormat, apiToken)
+	tokenHash := base.HashToken(apiToken)
+	apiTokenHKey := fmt.Sprintf(apiTokenHKeyFormat, tokenHash)
 
-	userData, err := m.redisClient.HGetAll(ctx, apiTokenHKey).Result()
+	sessionData, err := m.redisClient.HGetAll(ctx, apiTokenHKey).Result()
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to retrieve user data of api-token for user:%s org:%s: %w",
-			userData[userKey], userData[orgKey], err)
+		return APITokenSession{}, fmt.Errorf("failed to retrieve session data of api-token: %w", err)
 	}
 
-	if len(userData) == 0 {
-		return 0, 0, fmt.Errorf("user data not found for api-token: %w", ErrInvalidSession)
+	if len(sessionData) == 0 {
+		return APITokenSession{}, fmt.Errorf("session data not found for api-token: %w", ErrInvalidSession)
 	}
 
-	sessionHKey := fmt.Sprintf(sessionHKeyFormat, userData[orgKey], userData[userKey])
+	sessionHKey := fmt.Sprintf(apiTokenSessionHKeyFormat, sessionData[orgKey], sessionData[userKey],
+		sessionData[tokenIDKey])
 	if m.redisClient.Exists(ctx, sessionHKey).Val() == 0 {
-		return 0, 0, fmt.Errorf("session not found for user:%s org:%s: %w",
-			userData[userKey], userData[orgKey], ErrInvalidSession)
+		return APITokenSession{}, fmt.Errorf("session not found for user:%s org:%s: %w",
+			sessionData[userKey], sessionData[orgKey], ErrInvalidSession)
 	}
 
-	sessionAPIToken, err := m.redisClient.HGet(ctx, sessionHKey, apiTokenKey).Result()
+	sessionTokenHash, err := m.redisClient.HGet(ctx, sessionHKey, apiTokenKey).Result()
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to retrieve session api-token for user:%s org:%s: %w",
-			userData[userKey], userData[orgKey], err)
+		return APITokenSession{}, fmt.Errorf("failed to retrieve session api-token for user:%s org:%s: %w",
+			sessionData[userKey], sessionData[orgKey], err)
 	}
 
-	if sessionAPIToken != apiToken {
-		return 0, 0, fmt.Errorf("session api-token mismatch for user:%s org:%s: %w",
-			userData[userKey], userData[orgKey], ErrInvalidSession)
+	if sessionTokenHash != tokenHash {
+		return APITokenSession{}, fmt.Errorf("session api-token mismatch for user:%s org:%s: %w",
+			sessionData[userKey], sessionData[orgKey], ErrInvalidSession)
 	}
 
-	userID, _ := strconv.ParseInt(userData[userKey], 10, 64)
-	orgID, _ := strconv.ParseInt(userData[orgKey], 10, 64)
+	s := toAPITokenSession(sessionData)
+	if !time.Now().Before(s.ExpiresAt) {
+		return APITokenSession{}, fmt.Errorf("api-token expired for user:%s org:%s: %w",
+			sessionData[userKey], sessionData[orgKey], ErrInvalidSession)
+	}
 
-	return userID, orgID, nil
+	return s, nil
 }
 
-func (m *sessionManager) DeleteSession(ctx context.Context, userID, orgID int64) error {
-	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID)
+func (m *sessionManager) DeleteAPITokenSession(ctx context.Context, userID, orgID, tokenID int64) error {
+	// deleting the user session invalidates the cached token
+	sessionHKey := fmt.Sprintf(apiTokenSessionHKeyFormat, orgID, userID, tokenID)
 	if err := m.redisClient.Del(ctx, sessionHKey).Err(); err != nil {
+		return fmt.Errorf("failed to delete api-token session for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	return nil
+}
+
+func (m *sessionManager) ListSessions(ctx context.Context, userID, orgID int64) ([]Session, error) {
+	sessionHKeys, err := m.redisClient.Keys(ctx, fmt.Sprintf(sessionHKeyFormat, orgID, userID, "*")).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session keys for user:%d org:%d: %w",
+			userID, orgID, err)
+	}
+
+	sessions := make([]Session, 0, len(sessionHKeys))
+
+	for _, sessionHKey := range sessionHKeys {
+		sessionData, err := m.redisClient.HGetAll(ctx, sessionHKey).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve session data for user:%d org:%d: %w",
+				userID, orgID, err)
+		}
+
+		// the session might have expired after listing the keys
+		if len(sessionData) == 0 {
+			continue
+		}
+
+		sessionID := strings.TrimPrefix(sessionHKey, fmt.Sprintf(sessionHKeyFormat, orgID, userID, ""))
+		sessions = append(sessions, toSession(sessionID, userID, orgID, sessionData))
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+func (m *sessionManager) RevokeSession(ctx context.Context, userID, orgID int64, sessionID string) error {
+	if sessionID == "" {
+		return ErrMissingSessionID
+	}
+
+	sessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
+
+	deleted, err := m.redisClient.Del(ctx, sessionHKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to revoke session for user: %d org: %d: %w",
+			userID, orgID, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("session not found for user:%d org:%d: %w",
+			userID, orgID, ErrInvalidSession)
+	}
+
+	return nil
+}
+
+func (m *sessionManager) DeleteSession(ctx context.Context, userID, orgID int64) error {
+	sessionHKeys, err := m.redisClient.Keys(ctx, fmt.Sprintf(userSessionsKeyPattern, orgID, userID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve session keys for user: %d org: %d: %w",
+			userID, orgID, err)
+	}
+
+	if len(sessionHKeys) == 0 {
+		return nil
+	}
+
+	if err := m.redisClient.Del(ctx, sessionHKeys...).Err(); err != nil {
 		return fmt.Errorf("failed to delete session for user: %d org: %d: %w",
 			userID, orgID, err)
 	}
@@ -178,8 +465,40 @@ func (m *sessionManager) DeleteSession(ctx context.Context, userID, orgID int64)
 	return nil
 }
 
+func (m *sessionManager) DeleteOtherSessions(ctx context.Context, userID, orgID int64, sessionID string) error {
+	if sessionID == "" {
+		return ErrMissingSessionID
+	}
+
+	sessionHKeys, err := m.redisClient.Keys(ctx, fmt.Sprintf(userSessionsKeyPattern, orgID, userID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve session keys for user: %d org: %d: %w",
+			userID, orgID, err)
+	}
+
+	currentSessionHKey := fmt.Sprintf(sessionHKeyFormat, orgID, userID, sessionID)
+	otherSessionHKeys := make([]string, 0, len(sessionHKeys))
+
+	for _, sessionHKey := range sessionHKeys {
+		if sessionHKey != currentSessionHKey {
+			otherSessionHKeys = append(otherSessionHKeys, sessionHKey)
+		}
+	}
+
+	if len(otherSessionHKeys) == 0 {
+		return nil
+	}
+
+	if err := m.redisClient.Del(ctx, otherSessionHKeys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete other sessions for user: %d org: %d: %w",
+			userID, orgID, err)
+	}
+
+	return nil
+}
+
 func (m *sessionManager) DeleteAllOrgSessions(ctx context.Context, orgID int64) error {
-	sessionHKeys, err := m.redisClient.Keys(ctx, fmt.Sprintf(sessionHKeyFormat, orgID, "*")).Result()
+	sessionHKeys, err := m.redisClient.Keys(ctx, fmt.Sprintf(orgSessionsKeyPattern, orgID)).Result()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve all session keys for org: %d: %w",
 			orgID, err)
@@ -197,7 +516,28 @@ func (m *sessionManager) DeleteAllOrgSessions(ctx context.Context, orgID int64)
 	return nil
 }
 
-func (m *sessionManager) validateParams(userID, orgID int64, jwt, apiToken string) error {
+// storeRefreshToken stores the hash of the refresh token along with the session it belongs to.
+func (m *sessionManager) storeRefreshToken(
+	ctx context.Context,
+	userID, orgID int64,
+	sessionID, refreshToken string,
+	ttl time.Duration,
+) error {
+	refreshTokenHKey := fmt.Sprintf(refreshTokenHKeyFormat, base.HashToken(refreshToken))
+
+	if err := m.redisClient.HSet(ctx, refreshTokenHKey, orgKey, orgID, userKey, userID,
+		sessionIDKey, sessionID).Err(); err != nil {
+		return fmt.Errorf("failed to set refresh-token hash key for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	if err := m.redisClient.Expire(ctx, refreshTokenHKey, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set refresh-token hash key expiry for user:%d org:%d: %w", userID, orgID, err)
+	}
+
+	return nil
+}
+
+func (m *sessionManager) validateParams(userID, orgID int64, token string) error {
 	if userID == 0 {
 		return ErrMissingUserID
 	}
@@ -206,10 +546,43 @@ func (m *sessionManager) validateParams(userID, orgID int64, jwt, apiToken strin
 		return ErrMissingOrgID
 	}
 
-	// either jwt or apiToken should be present
-	if jwt == "" && apiToken == "" {
+	if token == "" {
 		return ErrMissingToken
 	}
 
 	return nil
 }
+
+// toSession converts the session hash data to the session.
+func toSession(sessionID string, userID, orgID int64, sessionData map[string]string) Session {
+	createdAt, _ := strconv.ParseInt(sessionData[createdAtKey], 10, 64)
+	lastSeenAt, _ := strconv.ParseInt(sessionData[lastSeenAtKey], 10, 64)
+	ttl, _ := strconv.ParseInt(sessionData[ttlKey], 10, 64)
+
+	return Session{
+		ID:         sessionID,
+		UserID:     userID,
+		OrgID:      orgID,
+		UserAgent:  sessionData[userAgentKey],
+		IP:         sessionData[ipKey],
+		CreatedAt:  time.Unix(createdAt, 0).UTC(),
+		LastSeenAt: time.Unix(lastSeenAt, 0).UTC(),
+		TTL:        time.Duration(ttl) * time.Second,
+	}
+}
+
+// toAPITokenSession converts the api-token hash data to the api token session.
+func toAPITokenSession(sessionData map[string]string) APITokenSession {
+	tokenID, _ := strconv.ParseInt(sessionData[tokenIDKey], 10, 64)
+	userID, _ := strconv.ParseInt(sessionData[userKey], 10, 64)
+	orgID, _ := strconv.ParseInt(sessionData[orgKey], 10, 64)
+	expiresAt, _ := strconv.ParseInt(sessionData[expiresAtKey], 10, 64)
+
+	return APITokenSession{
+		TokenID:   tokenID,
+		UserID:    userID,
+		OrgID:     orgID,
+		Scopes:    strings.Split(sessionData[scopesKey], scopesSeparator),
+		ExpiresAt: time.Unix(expiresAt, 0).UTC(),
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 )
 
@@ -17,54 +18,91 @@ func (s *SessionTestSuite) TestSessionManagerIntegration_CreateSession() {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", orgID, userID)
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+		sessionKey := fmt.Sprintf("session:org:%v:user:%v:sid:%v", orgID, userID, sessionID)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, jwt, gofakeit.UUID(), device, time.Hour)
 		s.Require().NoError(err)
 
 		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Len(sessionData, 4)
+		s.Len(sessionData, 8)
 		s.Equal(jwt, sessionData["jwt"])
-		s.Equal(apiToken, sessionData["apiToken"])
 		s.Equal(strconv.FormatInt(userID, 10), sessionData["user"])
 		s.Equal(strconv.FormatInt(orgID, 10), sessionData["org"])
+		s.Equal(device.UserAgent, sessionData["userAgent"])
+		s.Equal(device.IP, sessionData["ip"])
+		s.NotEmpty(sessionData["createdAt"])
+		s.NotEmpty(sessionData["lastSeenAt"])
+		s.Equal("3600", sessionData["ttl"])
 
 		ttl := s.RedisClient.TTL(ctx, sessionKey).Val()
 		s.Equal(time.Hour, ttl)
 	})
 
-	s.Run("should overwrite the existing session for new toke", func() {
+	s.Run("should keep the existing sessions of other devices", func() {
 		s.T().Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
-		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", orgID, userID)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
 		s.Require().NoError(err)
 
-		newJWT := gofakeit.UUID()
-		newAPIToken := gofakeit.UUID()
-		err = sessionManager.CreateSession(ctx, userID, orgID, newJWT, newAPIToken, time.Hour)
+		err = sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+
+		keys, err := s.RedisClient.Keys(ctx, fmt.Sprintf("session:org:%v:user:%v:sid:*", orgID, userID)).Result()
+		s.Require().NoError(err)
+		s.Len(keys, 2)
+	})
+}
+
+func (s *SessionTestSuite) TestSessionManagerIntegration_CreateAPITokenSession() {
+	s.Run("should create the api token session for the user of the given organization", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		apiToken := gofakeit.UUID()
+		apiTokenSession := newAPITokenSession()
+		sessionKey := fmt.Sprintf("session:org:%v:user:%v:apiToken:%v",
+			apiTokenSession.OrgID, apiTokenSession.UserID, apiTokenSession.TokenID)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, apiTokenSession, time.Hour)
 		s.Require().NoError(err)
 
 		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Len(sessionData, 4)
-		s.Equal(newJWT, sessionData["jwt"])
-		s.Equal(newAPIToken, sessionData["apiToken"])
-		s.Equal(strconv.FormatInt(userID, 10), sessionData["user"])
-		s.Equal(strconv.FormatInt(orgID, 10), sessionData["org"])
+		s.Len(sessionData, 3)
+		s.Equal(base.HashToken(apiToken), sessionData["apiToken"])
+		s.Equal(strconv.FormatInt(apiTokenSession.UserID, 10), sessionData["user"])
+		s.Equal(strconv.FormatInt(apiTokenSession.OrgID, 10), sessionData["org"])
 
 		ttl := s.RedisClient.TTL(ctx, sessionKey).Val()
 		s.Equal(time.Hour, ttl)
 	})
+
+	s.Run("should not cache the api token session beyond the token expiry", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		apiToken := gofakeit.UUID()
+		apiTokenSession := newAPITokenSession()
+		apiTokenSession.ExpiresAt = time.Now().Add(time.Minute)
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, apiTokenSession, time.Hour)
+		s.Require().NoError(err)
+
+		ttl := s.RedisClient.TTL(ctx, "apiToken:"+base.HashToken(apiToken)).Val()
+		s.LessOrEqual(ttl, time.Minute)
+	})
 }
 
 func (s *SessionTestSuite) TestSessionManagerIntegration_ValidateJWTSession() {
@@ -74,19 +112,20 @@ func (s *SessionTestSuite) TestSessionManagerIntegration_ValidateJWTSession() {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", orgID, userID)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, jwt, gofakeit.UUID(),
+			session.Device{}, time.Hour)
 		s.Require().NoError(err)
-		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Len(sessionData, 4)
-		s.Require().Equal(jwt, sessionData["jwt"])
 
-		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
 		s.Require().NoError(err)
+
+		// the jwt of another session is rejected
+		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, gofakeit.UUID(), jwt)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
 	})
 }
 
@@ -94,48 +133,215 @@ func (s *SessionTestSuite) TestSessionManagerIntegration_ValidateAPITokenSession
 	s.Run("should validate the API token session", func() {
 		s.T().Parallel()
 
+		ctx := context.Background()
+		apiToken := gofakeit.UUID()
+		apiTokenSession := newAPITokenSession()
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, apiTokenSession, time.Hour)
+		s.Require().NoError(err)
+
+		result, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		s.Require().NoError(err)
+		s.Equal(apiTokenSession.TokenID, result.TokenID)
+		s.Equal(apiTokenSession.UserID, result.UserID)
+		s.Equal(apiTokenSession.OrgID, result.OrgID)
+		s.Equal(apiTokenSession.Scopes, result.Scopes)
+		s.WithinDuration(apiTokenSession.ExpiresAt, result.ExpiresAt, time.Second)
+	})
+}
+
+func (s *SessionTestSuite) TestSessionManagerIntegration_DeleteAPITokenSession() {
+	s.Run("should invalidate the cached api token session", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		apiToken := gofakeit.UUID()
+		apiTokenSession := newAPITokenSession()
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, apiTokenSession, time.Hour)
+		s.Require().NoError(err)
+
+		err = sessionManager.DeleteAPITokenSession(ctx, apiTokenSession.UserID, apiTokenSession.OrgID,
+			apiTokenSession.TokenID)
+		s.Require().NoError(err)
+
+		_, err = sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+	})
+}
+
+func (s *SessionTestSuite) TestSessionManagerIntegration_RefreshSession() {
+	s.Run("should rotate the refresh token and extend the session", func() {
+		s.T().Parallel()
+
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
-		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", orgID, userID)
+		sessionID := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%v:user:%v:sid:%v", orgID, userID, sessionID)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, gofakeit.UUID(), refreshToken,
+			session.Device{}, time.Hour)
 		s.Require().NoError(err)
-		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Len(sessionData, 4)
-		s.Require().Equal(apiToken, sessionData["apiToken"])
 
-		resultUserID, resultOrgID, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		sess, err := sessionManager.ConsumeRefreshToken(ctx, orgID, refreshToken)
 		s.Require().NoError(err)
-		s.Equal(userID, resultUserID)
-		s.Equal(orgID, resultOrgID)
+		s.Equal(sessionID, sess.ID)
+		s.Equal(userID, sess.UserID)
+		s.Equal(orgID, sess.OrgID)
+		s.Equal(time.Hour, sess.TTL)
+
+		// shorten the session to verify that it is extended upon renewal
+		s.RedisClient.Expire(ctx, sessionKey, time.Minute)
+
+		newJWT := gofakeit.UUID()
+		newRefreshToken := gofakeit.UUID()
+		err = sessionManager.RenewSession(ctx, userID, orgID, sessionID, newJWT, newRefreshToken, sess.TTL)
+		s.Require().NoError(err)
+
+		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, newJWT)
+		s.Require().NoError(err)
+		s.Equal(time.Hour, s.RedisClient.TTL(ctx, sessionKey).Val())
+
+		// the new refresh token can be used once
+		sess, err = sessionManager.ConsumeRefreshToken(ctx, orgID, newRefreshToken)
+		s.Require().NoError(err)
+		s.Equal(sessionID, sess.ID)
+	})
+
+	s.Run("should revoke the session when a rotated refresh token is reused", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		newRefreshToken := gofakeit.UUID()
+		newJWT := gofakeit.UUID()
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, gofakeit.UUID(), refreshToken,
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+
+		_, err = sessionManager.ConsumeRefreshToken(ctx, orgID, refreshToken)
+		s.Require().NoError(err)
+		err = sessionManager.RenewSession(ctx, userID, orgID, sessionID, newJWT, newRefreshToken, time.Hour)
+		s.Require().NoError(err)
+
+		// the token presented to another organization is kept usable
+		_, err = sessionManager.ConsumeRefreshToken(ctx, orgID+1, newRefreshToken)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, newJWT)
+		s.Require().NoError(err)
+
+		// reuse the rotated token
+		_, err = sessionManager.ConsumeRefreshToken(ctx, orgID, refreshToken)
+		s.Require().ErrorIs(err, session.ErrRefreshTokenReused)
+
+		// the whole token family is revoked
+		err = sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, newJWT)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+		_, err = sessionManager.ConsumeRefreshToken(ctx, orgID, newRefreshToken)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
+	})
+}
+
+func (s *SessionTestSuite) TestSessionManagerIntegration_ListSessions() {
+	s.Run("should list the active sessions of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, gofakeit.UUID(), gofakeit.UUID(),
+			device, time.Hour)
+		s.Require().NoError(err)
+		err = sessionManager.CreateAPITokenSession(ctx, gofakeit.UUID(),
+			session.APITokenSession{TokenID: gofakeit.Int64(), UserID: userID, OrgID: orgID,
+				ExpiresAt: time.Now().Add(24 * time.Hour)}, time.Hour)
+		s.Require().NoError(err)
+
+		sessions, err := sessionManager.ListSessions(ctx, userID, orgID)
+		s.Require().NoError(err)
+		s.Require().Len(sessions, 1)
+		s.Equal(sessionID, sessions[0].ID)
+		s.Equal(userID, sessions[0].UserID)
+		s.Equal(orgID, sessions[0].OrgID)
+		s.Equal(device.UserAgent, sessions[0].UserAgent)
+		s.Equal(device.IP, sessions[0].IP)
+		s.WithinDuration(time.Now(), sessions[0].CreatedAt, time.Minute)
+		s.WithinDuration(time.Now(), sessions[0].LastSeenAt, time.Minute)
+	})
+}
+
+func (s *SessionTestSuite) TestSessionManagerIntegration_RevokeSession() {
+	s.Run("should revoke only the given session of the user", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		otherSessionID := gofakeit.UUID()
+		sessionManager := session.NewRedisSessionManager(s.RedisClient)
+
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+		err = sessionManager.CreateSession(ctx, userID, orgID, otherSessionID, gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+
+		err = sessionManager.RevokeSession(ctx, userID, orgID, sessionID)
+		s.Require().NoError(err)
+
+		sessions, err := sessionManager.ListSessions(ctx, userID, orgID)
+		s.Require().NoError(err)
+		s.Require().Len(sessions, 1)
+		s.Equal(otherSessionID, sessions[0].ID)
+
+		// the session of another user can not be revoked
+		err = sessionManager.RevokeSession(ctx, gofakeit.Int64(), orgID, otherSessionID)
+		s.Require().ErrorIs(err, session.ErrInvalidSession)
 	})
 }
 
 func (s *SessionTestSuite) TestSessionManagerIntegration_DeleteSession() {
-	s.Run("should delete the session for the user of the given organization", func() {
+	s.Run("should delete all the sessions for the user of the given organization", func() {
 		s.T().Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
-		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		sessionKey := fmt.Sprintf("session:org:%v:user:%v", orgID, userID)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+		err = sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID(),
+			session.Device{}, time.Hour)
+		s.Require().NoError(err)
+		err = sessionManager.CreateAPITokenSession(ctx, gofakeit.UUID(),
+			session.APITokenSession{TokenID: gofakeit.Int64(), UserID: userID, OrgID: orgID,
+				ExpiresAt: time.Now().Add(24 * time.Hour)}, time.Hour)
 		s.Require().NoError(err)
-		sessionData := s.RedisClient.HGetAll(ctx, sessionKey).Val()
-		s.Len(sessionData, 4)
 
 		err = sessionManager.DeleteSession(ctx, userID, orgID)
 		s.Require().NoError(err)
-		exist := s.RedisClient.Exists(ctx, sessionKey).Val()
-		s.Zero(exist)
+
+		keys, err := s.RedisClient.Keys(ctx, fmt.Sprintf("session:org:%v:user:%v:*", orgID, userID)).Result()
+		s.Require().NoError(err)
+		s.Empty(keys)
 	})
 }
 
@@ -147,9 +353,12 @@ func (s *SessionTestSuite) TestSessionManagerIntegration_DeleteAllOrgSessions()
 		orgID := gofakeit.Int64()
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 
-		err := sessionManager.CreateSession(ctx, gofakeit.Int64(), orgID, gofakeit.UUID(), gofakeit.UUID(), time.Hour)
+		err := sessionManager.CreateSession(ctx, gofakeit.Int64(), orgID, gofakeit.UUID(), gofakeit.UUID(),
+			gofakeit.UUID(), session.Device{}, time.Hour)
 		s.Require().NoError(err)
-		err = sessionManager.CreateSession(ctx, gofakeit.Int64(), orgID, gofakeit.UUID(), gofakeit.UUID(), time.Hour)
+		err = sessionManager.CreateAPITokenSession(ctx, gofakeit.UUID(),
+			session.APITokenSession{TokenID: gofakeit.Int64(), UserID: gofakeit.Int64(), OrgID: orgID,
+				ExpiresAt: time.Now().Add(24 * time.Hour)}, time.Hour)
 		s.Require().NoError(err)
 		keys, err := s.RedisClient.Keys(ctx, fmt.Sprintf("session:org:%v:user:*", orgID)).Result()
 		s.Require().NoError(err)
@@ -22,17 +22,124 @@ func (_m *MockSessionManager) EXPECT() *MockSessionManager_Expecter {
 	return &MockSessionManager_Expecter{mock: &_m.Mock}
 }
 
-// CreateSession provides a mock function with given fields: ctx, userID, orgID, jwt, apiToken, ttl
-func (_m *MockSessionManager) CreateSession(ctx context.Context, userID int64, orgID int64, jwt string, apiToken string, ttl time.Duration) error {
-	ret := _m.Called(ctx, userID, orgID, jwt, apiToken, ttl)
+// ConsumeRefreshToken provides a mock function with given fields: ctx, orgID, refreshToken
+func (_m *MockSessionManager) ConsumeRefreshToken(ctx context.Context, orgID int64, refreshToken string) (Session, error) {
+	ret := _m.Called(ctx, orgID, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeRefreshToken")
+	}
+
+	var r0 Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (Session, error)); ok {
+		return rf(ctx, orgID, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) Session); ok {
+		r0 = rf(ctx, orgID, refreshToken)
+	} else {
+		r0 = ret.Get(0).(Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, orgID, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionManager_ConsumeRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeRefreshToken'
+type MockSessionManager_ConsumeRefreshToken_Call struct {
+	*mock.Call
+}
+
+// ConsumeRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - refreshToken string
+func (_e *MockSessionManager_Expecter) ConsumeRefreshToken(ctx interface{}, orgID interface{}, refreshToken interface{}) *MockSessionManager_ConsumeRefreshToken_Call {
+	return &MockSessionManager_ConsumeRefreshToken_Call{Call: _e.mock.On("ConsumeRefreshToken", ctx, orgID, refreshToken)}
+}
+
+func (_c *MockSessionManager_ConsumeRefreshToken_Call) Run(run func(ctx context.Context, orgID int64, refreshToken string)) *MockSessionManager_ConsumeRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_ConsumeRefreshToken_Call) Return(_a0 Session, _a1 error) *MockSessionManager_ConsumeRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionManager_ConsumeRefreshToken_Call) RunAndReturn(run func(context.Context, int64, string) (Session, error)) *MockSessionManager_ConsumeRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPITokenSession provides a mock function with given fields: ctx, apiToken, s, ttl
+func (_m *MockSessionManager) CreateAPITokenSession(ctx context.Context, apiToken string, s APITokenSession, ttl time.Duration) error {
+	ret := _m.Called(ctx, apiToken, s, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPITokenSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, APITokenSession, time.Duration) error); ok {
+		r0 = rf(ctx, apiToken, s, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionManager_CreateAPITokenSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPITokenSession'
+type MockSessionManager_CreateAPITokenSession_Call struct {
+	*mock.Call
+}
+
+// CreateAPITokenSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiToken string
+//   - s APITokenSession
+//   - ttl time.Duration
+func (_e *MockSessionManager_Expecter) CreateAPITokenSession(ctx interface{}, apiToken interface{}, s interface{}, ttl interface{}) *MockSessionManager_CreateAPITokenSession_Call {
+	return &MockSessionManager_CreateAPITokenSession_Call{Call: _e.mock.On("CreateAPITokenSession", ctx, apiToken, s, ttl)}
+}
+
+func (_c *MockSessionManager_CreateAPITokenSession_Call) Run(run func(ctx context.Context, apiToken string, s APITokenSession, ttl time.Duration)) *MockSessionManager_CreateAPITokenSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(APITokenSession), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_CreateAPITokenSession_Call) Return(_a0 error) *MockSessionManager_CreateAPITokenSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionManager_CreateAPITokenSession_Call) RunAndReturn(run func(context.Context, string, APITokenSession, time.Duration) error) *MockSessionManager_CreateAPITokenSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSession provides a mock function with given fields: ctx, userID, orgID, sessionID, jwt, refreshToken, device, ttl
+func (_m *MockSessionManager) CreateSession(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string, refreshToken string, device Device, ttl time.Duration) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID, jwt, refreshToken, device, ttl)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, time.Duration) error); ok {
-		r0 = rf(ctx, userID, orgID, jwt, apiToken, ttl)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, string, Device, time.Duration) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID, jwt, refreshToken, device, ttl)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,16 +156,18 @@ type MockSessionManager_CreateSession_Call struct {
 //   - ctx context.Context
 //   - userID int64
 //   - orgID int64
+//   - sessionID string
 //   - jwt string
-//   - apiToken string
+//   - refreshToken string
+//   - device Device
 //   - ttl time.Duration
-func (_e *MockSessionManager_Expecter) CreateSession(ctx interface{}, userID interface{}, orgID interface{}, jwt interface{}, apiToken interface{}, ttl interface{}) *MockSessionManager_CreateSession_Call {
-	return &MockSessionManager_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, userID, orgID, jwt, apiToken, ttl)}
+func (_e *MockSessionManager_Expecter) CreateSession(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}, jwt interface{}, refreshToken interface{}, device interface{}, ttl interface{}) *MockSessionManager_CreateSession_Call {
+	return &MockSessionManager_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, userID, orgID, sessionID, jwt, refreshToken, device, ttl)}
 }
 
-func (_c *MockSessionManager_CreateSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, jwt string, apiToken string, ttl time.Duration)) *MockSessionManager_CreateSession_Call {
+func (_c *MockSessionManager_CreateSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string, refreshToken string, device Device, ttl time.Duration)) *MockSessionManager_CreateSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(string), args[5].(time.Duration))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(string), args[5].(string), args[6].(Device), args[7].(time.Duration))
 	})
 	return _c
 }
@@ -68,7 +177,56 @@ func (_c *MockSessionManager_CreateSession_Call) Return(_a0 error) *MockSessionM
 	return _c
 }
 
-func (_c *MockSessionManager_CreateSession_Call) RunAndReturn(run func(context.Context, int64, int64, string, string, time.Duration) error) *MockSessionManager_CreateSession_Call {
+func (_c *MockSessionManager_CreateSession_Call) RunAndReturn(run func(context.Context, int64, int64, string, string, string, Device, time.Duration) error) *MockSessionManager_CreateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAPITokenSession provides a mock function with given fields: ctx, userID, orgID, tokenID
+func (_m *MockSessionManager) DeleteAPITokenSession(ctx context.Context, userID int64, orgID int64, tokenID int64) error {
+	ret := _m.Called(ctx, userID, orgID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPITokenSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) error); ok {
+		r0 = rf(ctx, userID, orgID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionManager_DeleteAPITokenSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAPITokenSession'
+type MockSessionManager_DeleteAPITokenSession_Call struct {
+	*mock.Call
+}
+
+// DeleteAPITokenSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - tokenID int64
+func (_e *MockSessionManager_Expecter) DeleteAPITokenSession(ctx interface{}, userID interface{}, orgID interface{}, tokenID interface{}) *MockSessionManager_DeleteAPITokenSession_Call {
+	return &MockSessionManager_DeleteAPITokenSession_Call{Call: _e.mock.On("DeleteAPITokenSession", ctx, userID, orgID, tokenID)}
+}
+
+func (_c *MockSessionManager_DeleteAPITokenSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, tokenID int64)) *MockSessionManager_DeleteAPITokenSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_DeleteAPITokenSession_Call) Return(_a0 error) *MockSessionManager_DeleteAPITokenSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionManager_DeleteAPITokenSession_Call) RunAndReturn(run func(context.Context, int64, int64, int64) error) *MockSessionManager_DeleteAPITokenSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -120,6 +278,55 @@ func (_c *MockSessionManager_DeleteAllOrgSessions_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// DeleteOtherSessions provides a mock function with given fields: ctx, userID, orgID, sessionID
+func (_m *MockSessionManager) DeleteOtherSessions(ctx context.Context, userID int64, orgID int64, sessionID string) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionManager_DeleteOtherSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOtherSessions'
+type MockSessionManager_DeleteOtherSessions_Call struct {
+	*mock.Call
+}
+
+// DeleteOtherSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - sessionID string
+func (_e *MockSessionManager_Expecter) DeleteOtherSessions(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}) *MockSessionManager_DeleteOtherSessions_Call {
+	return &MockSessionManager_DeleteOtherSessions_Call{Call: _e.mock.On("DeleteOtherSessions", ctx, userID, orgID, sessionID)}
+}
+
+func (_c *MockSessionManager_DeleteOtherSessions_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string)) *MockSessionManager_DeleteOtherSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_DeleteOtherSessions_Call) Return(_a0 error) *MockSessionManager_DeleteOtherSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionManager_DeleteOtherSessions_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockSessionManager_DeleteOtherSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteSession provides a mock function with given fields: ctx, userID, orgID
 func (_m *MockSessionManager) DeleteSession(ctx context.Context, userID int64, orgID int64) error {
 	ret := _m.Called(ctx, userID, orgID)
@@ -168,39 +375,193 @@ func (_c *MockSessionManager_DeleteSession_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// ListSessions provides a mock function with given fields: ctx, userID, orgID
+func (_m *MockSessionManager) ListSessions(ctx context.Context, userID int64, orgID int64) ([]Session, error) {
+	ret := _m.Called(ctx, userID, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]Session, error)); ok {
+		return rf(ctx, userID, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []Session); ok {
+		r0 = rf(ctx, userID, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionManager_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockSessionManager_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+func (_e *MockSessionManager_Expecter) ListSessions(ctx interface{}, userID interface{}, orgID interface{}) *MockSessionManager_ListSessions_Call {
+	return &MockSessionManager_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, userID, orgID)}
+}
+
+func (_c *MockSessionManager_ListSessions_Call) Run(run func(ctx context.Context, userID int64, orgID int64)) *MockSessionManager_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_ListSessions_Call) Return(_a0 []Session, _a1 error) *MockSessionManager_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionManager_ListSessions_Call) RunAndReturn(run func(context.Context, int64, int64) ([]Session, error)) *MockSessionManager_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewSession provides a mock function with given fields: ctx, userID, orgID, sessionID, jwt, refreshToken, ttl
+func (_m *MockSessionManager) RenewSession(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string, refreshToken string, ttl time.Duration) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID, jwt, refreshToken, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID, jwt, refreshToken, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionManager_RenewSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewSession'
+type MockSessionManager_RenewSession_Call struct {
+	*mock.Call
+}
+
+// RenewSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - sessionID string
+//   - jwt string
+//   - refreshToken string
+//   - ttl time.Duration
+func (_e *MockSessionManager_Expecter) RenewSession(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}, jwt interface{}, refreshToken interface{}, ttl interface{}) *MockSessionManager_RenewSession_Call {
+	return &MockSessionManager_RenewSession_Call{Call: _e.mock.On("RenewSession", ctx, userID, orgID, sessionID, jwt, refreshToken, ttl)}
+}
+
+func (_c *MockSessionManager_RenewSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string, refreshToken string, ttl time.Duration)) *MockSessionManager_RenewSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(string), args[5].(string), args[6].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_RenewSession_Call) Return(_a0 error) *MockSessionManager_RenewSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionManager_RenewSession_Call) RunAndReturn(run func(context.Context, int64, int64, string, string, string, time.Duration) error) *MockSessionManager_RenewSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, orgID, sessionID
+func (_m *MockSessionManager) RevokeSession(ctx context.Context, userID int64, orgID int64, sessionID string) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionManager_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockSessionManager_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - orgID int64
+//   - sessionID string
+func (_e *MockSessionManager_Expecter) RevokeSession(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}) *MockSessionManager_RevokeSession_Call {
+	return &MockSessionManager_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, orgID, sessionID)}
+}
+
+func (_c *MockSessionManager_RevokeSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string)) *MockSessionManager_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionManager_RevokeSession_Call) Return(_a0 error) *MockSessionManager_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionManager_RevokeSession_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockSessionManager_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateAPITokenSession provides a mock function with given fields: ctx, apiToken
-func (_m *MockSessionManager) ValidateAPITokenSession(ctx context.Context, apiToken string) (int64, int64, error) {
+func (_m *MockSessionManager) ValidateAPITokenSession(ctx context.Context, apiToken string) (APITokenSession, error) {
 	ret := _m.Called(ctx, apiToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateAPITokenSession")
 	}
 
-	var r0 int64
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, int64, error)); ok {
+	var r0 APITokenSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (APITokenSession, error)); ok {
 		return rf(ctx, apiToken)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) APITokenSession); ok {
 		r0 = rf(ctx, apiToken)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(APITokenSession)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(ctx, apiToken)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, apiToken)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
+	return r0, r1
 }
 
 // MockSessionManager_ValidateAPITokenSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateAPITokenSession'
@@ -222,27 +583,27 @@ func (_c *MockSessionManager_ValidateAPITokenSession_Call) Run(run func(ctx cont
 	return _c
 }
 
-func (_c *MockSessionManager_ValidateAPITokenSession_Call) Return(_a0 int64, _a1 int64, _a2 error) *MockSessionManager_ValidateAPITokenSession_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockSessionManager_ValidateAPITokenSession_Call) Return(_a0 APITokenSession, _a1 error) *MockSessionManager_ValidateAPITokenSession_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockSessionManager_ValidateAPITokenSession_Call) RunAndReturn(run func(context.Context, string) (int64, int64, error)) *MockSessionManager_ValidateAPITokenSession_Call {
+func (_c *MockSessionManager_ValidateAPITokenSession_Call) RunAndReturn(run func(context.Context, string) (APITokenSession, error)) *MockSessionManager_ValidateAPITokenSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateJWTSession provides a mock function with given fields: ctx, userID, orgID, jwt
-func (_m *MockSessionManager) ValidateJWTSession(ctx context.Context, userID int64, orgID int64, jwt string) error {
-	ret := _m.Called(ctx, userID, orgID, jwt)
+// ValidateJWTSession provides a mock function with given fields: ctx, userID, orgID, sessionID, jwt
+func (_m *MockSessionManager) ValidateJWTSession(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string) error {
+	ret := _m.Called(ctx, userID, orgID, sessionID, jwt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateJWTSession")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
-		r0 = rf(ctx, userID, orgID, jwt)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string, string) error); ok {
+		r0 = rf(ctx, userID, orgID, sessionID, jwt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -259,14 +620,15 @@ type MockSessionManager_ValidateJWTSession_Call struct {
 //   - ctx context.Context
 //   - userID int64
 //   - orgID int64
+//   - sessionID string
 //   - jwt string
-func (_e *MockSessionManager_Expecter) ValidateJWTSession(ctx interface{}, userID interface{}, orgID interface{}, jwt interface{}) *MockSessionManager_ValidateJWTSession_Call {
-	return &MockSessionManager_ValidateJWTSession_Call{Call: _e.mock.On("ValidateJWTSession", ctx, userID, orgID, jwt)}
+func (_e *MockSessionManager_Expecter) ValidateJWTSession(ctx interface{}, userID interface{}, orgID interface{}, sessionID interface{}, jwt interface{}) *MockSessionManager_ValidateJWTSession_Call {
+	return &MockSessionManager_ValidateJWTSession_Call{Call: _e.mock.On("ValidateJWTSession", ctx, userID, orgID, sessionID, jwt)}
 }
 
-func (_c *MockSessionManager_ValidateJWTSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, jwt string)) *MockSessionManager_ValidateJWTSession_Call {
+func (_c *MockSessionManager_ValidateJWTSession_Call) Run(run func(ctx context.Context, userID int64, orgID int64, sessionID string, jwt string)) *MockSessionManager_ValidateJWTSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -276,7 +638,7 @@ func (_c *MockSessionManager_ValidateJWTSession_Call) Return(_a0 error) *MockSes
 	return _c
 }
 
-func (_c *MockSessionManager_ValidateJWTSession_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *MockSessionManager_ValidateJWTSession_Call {
+func (_c *MockSessionManager_ValidateJWTSession_Call) RunAndReturn(run func(context.Context, int64, int64, string, string) error) *MockSessionManager_ValidateJWTSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
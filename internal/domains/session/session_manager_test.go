@@ -3,10 +3,13 @@ package session_test
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 	"github.com/go-redis/redismock/v9"
 	"github.com/stretchr/testify/assert"
@@ -23,11 +26,11 @@ func TestSessionManager_CreateSession(t *testing.T) {
 		userID := gofakeit.Int64()
 		orgID := int64(0)
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), jwt, gofakeit.UUID(), session.Device{},
+			time.Hour)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingOrgID)
 	})
@@ -39,47 +42,57 @@ func TestSessionManager_CreateSession(t *testing.T) {
 		userID := int64(0)
 		orgID := gofakeit.Int64()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), jwt, gofakeit.UUID(), session.Device{},
+			time.Hour)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingUserID)
 	})
 
-	t.Run("should return error when both jwt & api-token is missing", func(t *testing.T) {
+	t.Run("should return error when jwt is missing", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
 		jwt := ""
-		apiToken := ""
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, gofakeit.UUID(), jwt, gofakeit.UUID(), session.Device{},
+			time.Hour)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingToken)
 	})
 
-	t.Run("should return error when setting api token fails", func(t *testing.T) {
+	t.Run("should return error when session id is missing", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
-		redisClient, redisClientMock := redismock.NewClientMock()
+		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHSet("apiToken:"+apiToken, "org", orgID, "user", userID).SetErr(assert.AnError)
+		err := sessionManager.CreateSession(ctx, userID, orgID, "", jwt, gofakeit.UUID(), session.Device{}, time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingSessionID)
+	})
+
+	t.Run("should return error when refresh token is missing", func(t *testing.T) {
+		t.Parallel()
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		ctx := context.Background()
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.CreateSession(ctx, gofakeit.Int64(), gofakeit.Int64(), gofakeit.UUID(), gofakeit.UUID(), "",
+			session.Device{}, time.Hour)
 		require.Error(t, err)
-		require.ErrorIs(t, err, assert.AnError)
+		require.ErrorIs(t, err, session.ErrMissingToken)
 	})
 
 	t.Run("should return error when setting session fails", func(t *testing.T) {
@@ -88,18 +101,19 @@ func TestSessionManager_CreateSession(t *testing.T) {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHSet("apiToken:"+apiToken, "org", orgID, "user", userID).SetVal(1)
-		redisClientMock.ExpectExpire("apiToken:"+apiToken, time.Hour*24).SetVal(true)
-		redisClientMock.ExpectHSet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "org", orgID,
-			"user", userID, "jwt", jwt, "apiToken", apiToken).SetErr(assert.AnError)
+		redisClientMock.Regexp().ExpectHSet(fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID),
+			"org", orgID, "user", userID, "jwt", jwt, "userAgent", regexp.QuoteMeta(device.UserAgent),
+			"ip", regexp.QuoteMeta(device.IP), "createdAt", `^\d+$`, "lastSeenAt", `^\d+$`,
+			"ttl", int64(3600)).SetErr(assert.AnError)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, jwt, gofakeit.UUID(), device, time.Hour)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
@@ -110,60 +124,324 @@ func TestSessionManager_CreateSession(t *testing.T) {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
-		apiToken := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		device := session.Device{UserAgent: gofakeit.UserAgent(), IP: gofakeit.IPv4Address()}
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHSet("apiToken:"+apiToken, "org", orgID, "user", userID).SetVal(1)
-		redisClientMock.ExpectExpire("apiToken:"+apiToken, time.Hour*24).SetVal(true)
-		redisClientMock.ExpectHSet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "org", orgID,
-			"user", userID, "jwt", jwt, "apiToken", apiToken).SetVal(1)
-		redisClientMock.ExpectExpire(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), time.Hour).SetVal(true)
+		redisClientMock.Regexp().ExpectHSet(sessionKey,
+			"org", orgID, "user", userID, "jwt", jwt, "userAgent", regexp.QuoteMeta(device.UserAgent),
+			"ip", regexp.QuoteMeta(device.IP), "createdAt", `^\d+$`, "lastSeenAt", `^\d+$`,
+			"ttl", int64(3600)).SetVal(8)
+		redisClientMock.ExpectExpire(sessionKey, time.Hour).SetVal(true)
+		redisClientMock.ExpectHSet(refreshTokenKey, "org", orgID, "user", userID, "sid", sessionID).SetVal(3)
+		redisClientMock.ExpectExpire(refreshTokenKey, time.Hour).SetVal(true)
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, apiToken, time.Hour)
+		err := sessionManager.CreateSession(ctx, userID, orgID, sessionID, jwt, refreshToken, device, time.Hour)
 		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
 	})
+}
+
+func TestSessionManager_ConsumeRefreshToken(t *testing.T) {
+	t.Parallel()
 
-	t.Run("should create session without api token", func(t *testing.T) {
+	t.Run("should return error when refresh token is missing", func(t *testing.T) {
+		t.Parallel()
+
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		_, err := sessionManager.ConsumeRefreshToken(context.Background(), gofakeit.Int64(), "")
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingToken)
+	})
+
+	t.Run("should return error when refresh token does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		refreshToken := gofakeit.UUID()
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(refreshTokenKey).SetVal(map[string]string{})
+
+		_, err := sessionManager.ConsumeRefreshToken(context.Background(), gofakeit.Int64(), refreshToken)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
+
+	t.Run("should neither use nor revoke the refresh token of another organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		refreshToken := gofakeit.UUID()
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(refreshTokenKey).SetVal(map[string]string{
+			"org":  strconv.FormatInt(orgID, 10),
+			"user": strconv.FormatInt(gofakeit.Int64(), 10),
+			"sid":  gofakeit.UUID(),
+		})
+
+		_, err := sessionManager.ConsumeRefreshToken(context.Background(), orgID+1, refreshToken)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should revoke the session when refresh token is reused", func(t *testing.T) {
 		t.Parallel()
 
-		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
-		jwt := gofakeit.UUID()
+		sessionID := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(refreshTokenKey).SetVal(map[string]string{
+			"org":    strconv.FormatInt(orgID, 10),
+			"user":   strconv.FormatInt(userID, 10),
+			"sid":    sessionID,
+			"usedAt": strconv.FormatInt(time.Now().Unix(), 10),
+		})
+		redisClientMock.Regexp().ExpectHSetNX(refreshTokenKey, "usedAt", `^\d+$`).SetVal(false)
+		redisClientMock.ExpectDel(sessionKey).SetVal(1)
+
+		_, err := sessionManager.ConsumeRefreshToken(context.Background(), orgID, refreshToken)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
 
+	t.Run("should return error when session does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHSet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "org", orgID,
-			"user", userID, "jwt", jwt).SetVal(1)
-		redisClientMock.ExpectExpire(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), time.Hour).SetVal(true)
+		redisClientMock.ExpectHGetAll(refreshTokenKey).SetVal(map[string]string{
+			"org":  strconv.FormatInt(orgID, 10),
+			"user": strconv.FormatInt(userID, 10),
+			"sid":  sessionID,
+		})
+		redisClientMock.Regexp().ExpectHSetNX(refreshTokenKey, "usedAt", `^\d+$`).SetVal(true)
+		redisClientMock.ExpectHGetAll(sessionKey).SetVal(map[string]string{})
+
+		_, err := sessionManager.ConsumeRefreshToken(context.Background(), orgID, refreshToken)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, jwt, "", time.Hour)
+	t.Run("should consume the refresh token and return the session", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+		createdAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(refreshTokenKey).SetVal(map[string]string{
+			"org":  strconv.FormatInt(orgID, 10),
+			"user": strconv.FormatInt(userID, 10),
+			"sid":  sessionID,
+		})
+		redisClientMock.Regexp().ExpectHSetNX(refreshTokenKey, "usedAt", `^\d+$`).SetVal(true)
+		redisClientMock.ExpectHGetAll(sessionKey).SetVal(map[string]string{
+			"org":        strconv.FormatInt(orgID, 10),
+			"user":       strconv.FormatInt(userID, 10),
+			"jwt":        gofakeit.UUID(),
+			"userAgent":  "laptop",
+			"ip":         "10.0.0.1",
+			"createdAt":  strconv.FormatInt(createdAt.Unix(), 10),
+			"lastSeenAt": strconv.FormatInt(createdAt.Unix(), 10),
+			"ttl":        "86400",
+		})
+
+		sess, err := sessionManager.ConsumeRefreshToken(context.Background(), orgID, refreshToken)
 		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+		assert.Equal(t, sessionID, sess.ID)
+		assert.Equal(t, userID, sess.UserID)
+		assert.Equal(t, orgID, sess.OrgID)
+		assert.Equal(t, "laptop", sess.UserAgent)
+		assert.Equal(t, "10.0.0.1", sess.IP)
+		assert.Equal(t, createdAt, sess.CreatedAt)
+		assert.Equal(t, 24*time.Hour, sess.TTL)
 	})
+}
+
+func TestSessionManager_RenewSession(t *testing.T) {
+	t.Parallel()
 
-	t.Run("should create session without jwt", func(t *testing.T) {
+	t.Run("should return error when refresh token is missing", func(t *testing.T) {
+		t.Parallel()
+
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.RenewSession(context.Background(), gofakeit.Int64(), gofakeit.Int64(),
+			gofakeit.UUID(), gofakeit.UUID(), "", time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingToken)
+	})
+
+	t.Run("should return error when session does not exist", func(t *testing.T) {
 		t.Parallel()
 
-		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectExists(sessionKey).SetVal(0)
+
+		err := sessionManager.RenewSession(context.Background(), userID, orgID, sessionID, gofakeit.UUID(),
+			gofakeit.UUID(), time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
+
+	t.Run("should renew the session successfully", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		jwt := gofakeit.UUID()
+		refreshToken := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+		refreshTokenKey := fmt.Sprintf("refreshToken:%s", base.HashToken(refreshToken))
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectExists(sessionKey).SetVal(1)
+		redisClientMock.Regexp().ExpectHSet(sessionKey, "jwt", jwt, "lastSeenAt", `^\d+$`).SetVal(0)
+		redisClientMock.ExpectExpire(sessionKey, time.Hour).SetVal(true)
+		redisClientMock.ExpectHSet(refreshTokenKey, "org", orgID, "user", userID, "sid", sessionID).SetVal(3)
+		redisClientMock.ExpectExpire(refreshTokenKey, time.Hour).SetVal(true)
+
+		err := sessionManager.RenewSession(context.Background(), userID, orgID, sessionID, jwt, refreshToken,
+			time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestSessionManager_CreateAPITokenSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when api token is missing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, "", newAPITokenSession(), time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingToken)
+	})
+
+	t.Run("should return error when api token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := newAPITokenSession()
+		s.ExpiresAt = time.Now().Add(-time.Minute)
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.CreateAPITokenSession(ctx, gofakeit.UUID(), s, time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
+
+	t.Run("should return error when setting api token fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := newAPITokenSession()
+		apiToken := gofakeit.UUID()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHSet("apiToken:"+base.HashToken(apiToken), "org", s.OrgID, "user", s.UserID,
+			"tokenID", s.TokenID, "scopes", "users:read,users:write", "expiresAt", s.ExpiresAt.Unix()).
+			SetErr(assert.AnError)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, s, time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return error when setting session fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := newAPITokenSession()
 		apiToken := gofakeit.UUID()
+		tokenHash := base.HashToken(apiToken)
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHSet("apiToken:"+apiToken, "org", orgID, "user", userID).SetVal(1)
-		redisClientMock.ExpectExpire("apiToken:"+apiToken, time.Hour*24).SetVal(true)
-		redisClientMock.ExpectHSet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "org", orgID,
-			"user", userID, "apiToken", apiToken).SetVal(1)
-		redisClientMock.ExpectExpire(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), time.Hour).SetVal(true)
+		redisClientMock.ExpectHSet("apiToken:"+tokenHash, "org", s.OrgID, "user", s.UserID,
+			"tokenID", s.TokenID, "scopes", "users:read,users:write", "expiresAt", s.ExpiresAt.Unix()).SetVal(1)
+		redisClientMock.ExpectExpire("apiToken:"+tokenHash, time.Hour).SetVal(true)
+		redisClientMock.ExpectHSet(fmt.Sprintf("session:org:%d:user:%d:apiToken:%d", s.OrgID, s.UserID, s.TokenID),
+			"org", s.OrgID, "user", s.UserID, "apiToken", tokenHash).SetErr(assert.AnError)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, s, time.Hour)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
 
-		err := sessionManager.CreateSession(ctx, userID, orgID, "", apiToken, time.Hour)
+	t.Run("should create api token session successfully", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := newAPITokenSession()
+		apiToken := gofakeit.UUID()
+		tokenHash := base.HashToken(apiToken)
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:apiToken:%d", s.OrgID, s.UserID, s.TokenID)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHSet("apiToken:"+tokenHash, "org", s.OrgID, "user", s.UserID,
+			"tokenID", s.TokenID, "scopes", "users:read,users:write", "expiresAt", s.ExpiresAt.Unix()).SetVal(1)
+		redisClientMock.ExpectExpire("apiToken:"+tokenHash, time.Hour).SetVal(true)
+		redisClientMock.ExpectHSet(sessionKey, "org", s.OrgID, "user", s.UserID, "apiToken", tokenHash).SetVal(1)
+		redisClientMock.ExpectExpire(sessionKey, time.Hour).SetVal(true)
+
+		err := sessionManager.CreateAPITokenSession(ctx, apiToken, s, time.Hour)
 		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
 	})
 }
 
@@ -180,7 +458,7 @@ func TestSessionManager_ValidateJWTSession(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, gofakeit.UUID(), jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingOrgID)
 	})
@@ -195,7 +473,7 @@ func TestSessionManager_ValidateJWTSession(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, gofakeit.UUID(), jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingUserID)
 	})
@@ -210,25 +488,41 @@ func TestSessionManager_ValidateJWTSession(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, gofakeit.UUID(), jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingToken)
 	})
 
+	t.Run("should return error when session id is missing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		jwt := gofakeit.UUID()
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, "", jwt)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingSessionID)
+	})
+
 	t.Run("should return error when session not found", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectExists(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetVal(0)
+		redisClientMock.ExpectExists(fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)).SetVal(0)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrInvalidSession)
 	})
@@ -239,15 +533,17 @@ func TestSessionManager_ValidateJWTSession(t *testing.T) {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectExists(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetVal(1)
-		redisClientMock.ExpectHGet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "jwt").SetErr(assert.AnError)
+		redisClientMock.ExpectExists(sessionKey).SetVal(1)
+		redisClientMock.ExpectHGet(sessionKey, "jwt").SetErr(assert.AnError)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
@@ -258,35 +554,63 @@ func TestSessionManager_ValidateJWTSession(t *testing.T) {
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectExists(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetVal(1)
-		redisClientMock.ExpectHGet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "jwt").SetVal("invalid-jwt")
+		redisClientMock.ExpectExists(sessionKey).SetVal(1)
+		redisClientMock.ExpectHGet(sessionKey, "jwt").SetVal("invalid-jwt")
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrInvalidSession)
 	})
 
+	t.Run("should return error when updating last seen time fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		jwt := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectExists(sessionKey).SetVal(1)
+		redisClientMock.ExpectHGet(sessionKey, "jwt").SetVal(jwt)
+		redisClientMock.Regexp().ExpectHSet(sessionKey, "lastSeenAt", `^\d+$`).SetErr(assert.AnError)
+
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
 	t.Run("should validate jwt session successfully", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
 		jwt := gofakeit.UUID()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectExists(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetVal(1)
-		redisClientMock.ExpectHGet(fmt.Sprintf("session:org:%d:user:%d", orgID, userID), "jwt").SetVal(jwt)
+		redisClientMock.ExpectExists(sessionKey).SetVal(1)
+		redisClientMock.ExpectHGet(sessionKey, "jwt").SetVal(jwt)
+		redisClientMock.Regexp().ExpectHSet(sessionKey, "lastSeenAt", `^\d+$`).SetVal(0)
 
-		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, jwt)
+		err := sessionManager.ValidateJWTSession(ctx, userID, orgID, sessionID, jwt)
 		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
 	})
 }
 
@@ -301,12 +625,12 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, _ := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrMissingToken)
 	})
 
-	t.Run("should return error when user data retrieval for api token fails", func(t *testing.T) {
+	t.Run("should return error when session data retrieval for api token fails", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
@@ -315,14 +639,14 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetErr(assert.AnError)
+		redisClientMock.ExpectHGetAll("apiToken:" + base.HashToken(apiToken)).SetErr(assert.AnError)
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
 
-	t.Run("should return error when user data not found for api token", func(t *testing.T) {
+	t.Run("should return error when session data not found for api token", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
@@ -331,14 +655,14 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetVal(map[string]string{})
+		redisClientMock.ExpectHGetAll("apiToken:" + base.HashToken(apiToken)).SetVal(map[string]string{})
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrInvalidSession)
 	})
 
-	t.Run("should return error when session not found for user data of api-token", func(t *testing.T) {
+	t.Run("should return error when session not found for session data of api-token", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
@@ -347,13 +671,14 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetVal(map[string]string{
-			"org":  "1",
-			"user": "1",
+		redisClientMock.ExpectHGetAll("apiToken:" + base.HashToken(apiToken)).SetVal(map[string]string{
+			"org":     "1",
+			"user":    "1",
+			"tokenID": "1",
 		})
-		redisClientMock.ExpectExists("session:org:1:user:1").SetVal(0)
+		redisClientMock.ExpectExists("session:org:1:user:1:apiToken:1").SetVal(0)
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrInvalidSession)
 	})
@@ -367,14 +692,15 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetVal(map[string]string{
-			"org":  "1",
-			"user": "1",
+		redisClientMock.ExpectHGetAll("apiToken:" + base.HashToken(apiToken)).SetVal(map[string]string{
+			"org":     "1",
+			"user":    "1",
+			"tokenID": "1",
 		})
-		redisClientMock.ExpectExists("session:org:1:user:1").SetVal(1)
-		redisClientMock.ExpectHGet("session:org:1:user:1", "apiToken").SetErr(assert.AnError)
+		redisClientMock.ExpectExists("session:org:1:user:1:apiToken:1").SetVal(1)
+		redisClientMock.ExpectHGet("session:org:1:user:1:apiToken:1", "apiToken").SetErr(assert.AnError)
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
@@ -388,14 +714,40 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetVal(map[string]string{
-			"org":  "1",
-			"user": "1",
+		redisClientMock.ExpectHGetAll("apiToken:" + base.HashToken(apiToken)).SetVal(map[string]string{
+			"org":     "1",
+			"user":    "1",
+			"tokenID": "1",
 		})
-		redisClientMock.ExpectExists("session:org:1:user:1").SetVal(1)
-		redisClientMock.ExpectHGet("session:org:1:user:1", "apiToken").SetVal("invalid-api-token")
+		redisClientMock.ExpectExists("session:org:1:user:1:apiToken:1").SetVal(1)
+		redisClientMock.ExpectHGet("session:org:1:user:1:apiToken:1", "apiToken").SetVal("invalid-api-token")
 
-		_, _, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
+
+	t.Run("should return error when api-token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		apiToken := gofakeit.UUID()
+		tokenHash := base.HashToken(apiToken)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectHGetAll("apiToken:" + tokenHash).SetVal(map[string]string{
+			"org":       "1",
+			"user":      "1",
+			"tokenID":   "1",
+			"scopes":    "users:read",
+			"expiresAt": strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+		})
+		redisClientMock.ExpectExists("session:org:1:user:1:apiToken:1").SetVal(1)
+		redisClientMock.ExpectHGet("session:org:1:user:1:apiToken:1", "apiToken").SetVal(tokenHash)
+
+		_, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.Error(t, err)
 		require.ErrorIs(t, err, session.ErrInvalidSession)
 	})
@@ -405,61 +757,354 @@ func TestSessionManager_ValidateAPITokenSession(t *testing.T) {
 
 		ctx := context.Background()
 		apiToken := gofakeit.UUID()
+		tokenHash := base.HashToken(apiToken)
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectHGetAll("apiToken:" + apiToken).SetVal(map[string]string{
-			"org":  "123",
-			"user": "456",
+		redisClientMock.ExpectHGetAll("apiToken:" + tokenHash).SetVal(map[string]string{
+			"org":       "123",
+			"user":      "456",
+			"tokenID":   "789",
+			"scopes":    "users:read,users:write",
+			"expiresAt": strconv.FormatInt(expiresAt.Unix(), 10),
 		})
-		redisClientMock.ExpectExists("session:org:123:user:456").SetVal(1)
-		redisClientMock.ExpectHGet("session:org:123:user:456", "apiToken").SetVal(apiToken)
+		redisClientMock.ExpectExists("session:org:123:user:456:apiToken:789").SetVal(1)
+		redisClientMock.ExpectHGet("session:org:123:user:456:apiToken:789", "apiToken").SetVal(tokenHash)
 
-		userID, orgID, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
+		result, err := sessionManager.ValidateAPITokenSession(ctx, apiToken)
 		require.NoError(t, err)
-		require.Equal(t, int64(456), userID)
-		require.Equal(t, int64(123), orgID)
+		assert.Equal(t, session.APITokenSession{
+			TokenID:   789,
+			UserID:    456,
+			OrgID:     123,
+			Scopes:    []string{"users:read", "users:write"},
+			ExpiresAt: expiresAt,
+		}, result)
 	})
 }
 
-func TestSessionManager_DeleteSession(t *testing.T) {
+func TestSessionManager_DeleteAPITokenSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when deleting the session fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectDel("session:org:1:user:2:apiToken:3").SetErr(assert.AnError)
+
+		err := sessionManager.DeleteAPITokenSession(ctx, 2, 1, 3)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should delete the api-token session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectDel("session:org:1:user:2:apiToken:3").SetVal(1)
+
+		err := sessionManager.DeleteAPITokenSession(ctx, 2, 1, 3)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestSessionManager_ListSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when retrieving session keys fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:sid:*", orgID, userID)).SetErr(assert.AnError)
+
+		_, err := sessionManager.ListSessions(ctx, userID, orgID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return error when retrieving session data fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, gofakeit.UUID())
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:sid:*", orgID, userID)).
+			SetVal([]string{sessionKey})
+		redisClientMock.ExpectHGetAll(sessionKey).SetErr(assert.AnError)
+
+		_, err := sessionManager.ListSessions(ctx, userID, orgID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should list the sessions sorted by last seen time", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionKeyFormat := fmt.Sprintf("session:org:%d:user:%d:sid:", orgID, userID) + "%s"
+		now := time.Now().UTC().Truncate(time.Second)
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:sid:*", orgID, userID)).
+			SetVal([]string{
+				fmt.Sprintf(sessionKeyFormat, "laptop"),
+				fmt.Sprintf(sessionKeyFormat, "expired"),
+				fmt.Sprintf(sessionKeyFormat, "phone"),
+			})
+		redisClientMock.ExpectHGetAll(fmt.Sprintf(sessionKeyFormat, "laptop")).SetVal(map[string]string{
+			"userAgent":  "laptop-agent",
+			"ip":         "10.0.0.1",
+			"createdAt":  strconv.FormatInt(now.Add(-time.Hour).Unix(), 10),
+			"lastSeenAt": strconv.FormatInt(now.Add(-time.Minute).Unix(), 10),
+		})
+		redisClientMock.ExpectHGetAll(fmt.Sprintf(sessionKeyFormat, "expired")).SetVal(map[string]string{})
+		redisClientMock.ExpectHGetAll(fmt.Sprintf(sessionKeyFormat, "phone")).SetVal(map[string]string{
+			"userAgent":  "phone-agent",
+			"ip":         "10.0.0.2",
+			"createdAt":  strconv.FormatInt(now.Add(-time.Hour).Unix(), 10),
+			"lastSeenAt": strconv.FormatInt(now.Unix(), 10),
+		})
+
+		sessions, err := sessionManager.ListSessions(ctx, userID, orgID)
+		require.NoError(t, err)
+		require.Equal(t, []session.Session{
+			{
+				ID:         "phone",
+				UserID:     userID,
+				OrgID:      orgID,
+				UserAgent:  "phone-agent",
+				IP:         "10.0.0.2",
+				CreatedAt:  now.Add(-time.Hour),
+				LastSeenAt: now,
+			},
+			{
+				ID:         "laptop",
+				UserID:     userID,
+				OrgID:      orgID,
+				UserAgent:  "laptop-agent",
+				IP:         "10.0.0.1",
+				CreatedAt:  now.Add(-time.Hour),
+				LastSeenAt: now.Add(-time.Minute),
+			},
+		}, sessions)
+	})
+}
+
+func TestSessionManager_RevokeSession(t *testing.T) {
 	t.Parallel()
 
+	t.Run("should return error when session id is missing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.RevokeSession(ctx, gofakeit.Int64(), gofakeit.Int64(), "")
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrMissingSessionID)
+	})
+
+	t.Run("should return error when deleting session fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)).
+			SetErr(assert.AnError)
+
+		err := sessionManager.RevokeSession(ctx, userID, orgID, sessionID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
 	t.Run("should return error when session not found", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)).SetVal(0)
+
+		err := sessionManager.RevokeSession(ctx, userID, orgID, sessionID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, session.ErrInvalidSession)
+	})
+
+	t.Run("should revoke session successfully", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectDel(fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)).SetVal(1)
+
+		err := sessionManager.RevokeSession(ctx, userID, orgID, sessionID)
+		require.NoError(t, err)
+	})
+}
+
+func TestSessionManager_DeleteSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when retrieving session keys fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:*", orgID, userID)).SetErr(assert.AnError)
+
+		err := sessionManager.DeleteSession(ctx, userID, orgID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return error when deleting session keys fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionKey := fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, gofakeit.UUID())
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectDel(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetErr(assert.AnError)
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:*", orgID, userID)).
+			SetVal([]string{sessionKey})
+		redisClientMock.ExpectDel(sessionKey).SetErr(assert.AnError)
 
 		err := sessionManager.DeleteSession(ctx, userID, orgID)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
 
-	t.Run("should delete session successfully", func(t *testing.T) {
+	t.Run("should delete all sessions of the user successfully", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
+		sessionKeys := []string{
+			fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, gofakeit.UUID()),
+			fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, gofakeit.UUID()),
+			fmt.Sprintf("session:org:%d:user:%d:apiToken", orgID, userID),
+		}
 
 		redisClient, redisClientMock := redismock.NewClientMock()
 		sessionManager := session.NewRedisSessionManager(redisClient)
 
-		redisClientMock.ExpectDel(fmt.Sprintf("session:org:%d:user:%d", orgID, userID)).SetVal(1)
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:*", orgID, userID)).SetVal(sessionKeys)
+		redisClientMock.ExpectDel(sessionKeys...).SetVal(3)
 
 		err := sessionManager.DeleteSession(ctx, userID, orgID)
 		require.NoError(t, err)
 	})
 }
 
+func TestSessionManager_DeleteOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the session id is missing", func(t *testing.T) {
+		t.Parallel()
+
+		redisClient, _ := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		err := sessionManager.DeleteOtherSessions(context.Background(), gofakeit.Int64(), gofakeit.Int64(), "")
+		require.ErrorIs(t, err, session.ErrMissingSessionID)
+	})
+
+	t.Run("should not delete anything when there is no other session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:*", orgID, userID)).
+			SetVal([]string{fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)})
+
+		err := sessionManager.DeleteOtherSessions(ctx, userID, orgID, sessionID)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+
+	t.Run("should delete all sessions of the user except the given session", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		otherSessionKeys := []string{
+			fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, gofakeit.UUID()),
+			fmt.Sprintf("session:org:%d:user:%d:apiToken:%d", orgID, userID, gofakeit.Int64()),
+		}
+
+		redisClient, redisClientMock := redismock.NewClientMock()
+		sessionManager := session.NewRedisSessionManager(redisClient)
+
+		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:%d:*", orgID, userID)).
+			SetVal(append([]string{fmt.Sprintf("session:org:%d:user:%d:sid:%s", orgID, userID, sessionID)},
+				otherSessionKeys...))
+		redisClientMock.ExpectDel(otherSessionKeys...).SetVal(2)
+
+		err := sessionManager.DeleteOtherSessions(ctx, userID, orgID, sessionID)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
 func TestSessionManager_DeleteAllOrgSessions(t *testing.T) {
 	t.Parallel()
 
@@ -490,10 +1135,10 @@ func TestSessionManager_DeleteAllOrgSessions(t *testing.T) {
 
 		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:*", orgID)).
 			SetVal([]string{
-				"session:org:1:user:1",
+				"session:org:1:user:1:apiToken",
 				"session:org:1:user:2",
 			})
-		redisClientMock.ExpectDel("session:org:1:user:1", "session:org:1:user:2").SetErr(assert.AnError)
+		redisClientMock.ExpectDel("session:org:1:user:1:apiToken", "session:org:1:user:2").SetErr(assert.AnError)
 
 		err := sessionManager.DeleteAllOrgSessions(ctx, orgID)
 		require.Error(t, err)
@@ -526,12 +1171,22 @@ func TestSessionManager_DeleteAllOrgSessions(t *testing.T) {
 
 		redisClientMock.ExpectKeys(fmt.Sprintf("session:org:%d:user:*", orgID)).
 			SetVal([]string{
-				"session:org:1:user:1",
+				"session:org:1:user:1:apiToken",
 				"session:org:1:user:2",
 			})
-		redisClientMock.ExpectDel("session:org:1:user:1", "session:org:1:user:2").SetVal(1)
+		redisClientMock.ExpectDel("session:org:1:user:1:apiToken", "session:org:1:user:2").SetVal(1)
 
 		err := sessionManager.DeleteAllOrgSessions(ctx, orgID)
 		require.NoError(t, err)
 	})
 }
+
+func newAPITokenSession() session.APITokenSession {
+	return session.APITokenSession{
+		TokenID:   gofakeit.Int64(),
+		UserID:    gofakeit.Int64(),
+		OrgID:     gofakeit.Int64(),
+		Scopes:    []string{"users:read", "users:write"},
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+}
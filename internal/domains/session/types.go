@@ -0,0 +1,73 @@
+package session
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Device represents the client device metadata of a session.
+type Device struct {
+	// UserAgent is the user agent of the client that created the session.
+	UserAgent string
+
+	// IP is the ip address of the client that created the session.
+	IP string
+}
+
+// NewDevice returns the device metadata of the client from the given request.
+// The remote address is expected to be resolved by the real-ip middleware when behind a proxy.
+func NewDevice(r *http.Request) Device {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return Device{
+		UserAgent: r.UserAgent(),
+		IP:        ip,
+	}
+}
+
+// Session represents an active jwt session of a user on a device.
+type Session struct {
+	// ID is the unique identifier of the session. It is embedded in the jwt as the sid claim.
+	ID string
+
+	UserID     int64
+	OrgID      int64
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+
+	// TTL is the time duration by which the session is extended upon renewal.
+	TTL time.Duration
+}
+
+// Response represents the response payload of a session.
+type Response struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	// Current is true when the session is the one used to make the request.
+	Current bool `json:"current"`
+}
+
+// APITokenSession represents a cached authentication of an api token.
+type APITokenSession struct {
+	// TokenID is the unique identifier of the api token.
+	TokenID int64
+
+	UserID int64
+	OrgID  int64
+
+	// Scopes are the scopes granted to the api token.
+	Scopes []string
+
+	// ExpiresAt is the expiry time of the api token.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,101 @@
+package sso
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetConfig returns the sso configuration of the organization of the authenticated user.
+func (h *handler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	c, err := h.service.GetConfig(r.Context(), orgID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, h.toResponse(c, h.extractOrgSubdomain(r)))
+}
+
+// SetConfig creates or replaces the sso configuration of the organization.
+func (h *handler) SetConfig(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	var reqPayload ConfigRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	c, err := h.service.SetConfig(r.Context(), orgID, reqPayload)
+	if err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, h.toResponse(c, h.extractOrgSubdomain(r)))
+}
+
+// DeleteConfig removes the sso configuration of the organization.
+func (h *handler) DeleteConfig(w http.ResponseWriter, r *http.Request) {
+	_, orgID, err := request.UserIDOrgID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	if err := h.service.DeleteConfig(r.Context(), orgID); err != nil {
+		response.ErrorResponse(w, mapError(err))
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+func (h *handler) toResponse(c Config, subdomain string) ConfigResponse {
+	return ConfigResponse{
+		Issuer:              c.Issuer,
+		ClientID:            c.ClientID,
+		AllowedEmailDomains: c.EmailDomains(),
+		JITProvisioning:     c.JITProvisioning,
+		RedirectURI:         h.service.RedirectURI(subdomain),
+	}
+}
+
+// extractOrgSubdomain returns the current subdomain of the organization the request is made to.
+// The redirect uri is always built from the subdomain, even if the request is made to a custom domain.
+func (h *handler) extractOrgSubdomain(r *http.Request) string {
+	subdomain, _ := r.Context().Value(request.CtxOrgSubdomainKey).(string)
+	return subdomain
+}
+
+// mapError sets the http status of the known sso errors.
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidIssuer):
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest))
+	default:
+		return err
+	}
+}
@@ -0,0 +1,226 @@
+package sso_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const configPath = "/api/v1/subdomains/{subdomain}/organizations/sso"
+
+const orgSubdomain = "acme"
+
+func TestHandler_GetConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found when sso is not configured", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, configPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("GetConfig", fake.MockContext, orgID).
+			Return(sso.Config{}, base.NewNotFoundError("sso configuration not found for the given organization"))
+
+		// call the handler
+		handler.GetConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return the configuration without the client secret", func(t *testing.T) {
+		t.Parallel()
+
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodGet, configPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("GetConfig", fake.MockContext, orgID).Return(sso.Config{
+			OrganizationID:      orgID,
+			Issuer:              "https://idp.example.org",
+			ClientID:            "client",
+			ClientSecret:        "secret",
+			AllowedEmailDomains: "camelhr.com,example.org",
+			JITProvisioning:     true,
+		}, nil)
+		mockService.On("RedirectURI", orgSubdomain).Return("https://camelhr.com/sso/callback")
+
+		// call the handler
+		handler.GetConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"issuer":"https://idp.example.org","client_id":"client",`+
+			`"allowed_email_domains":["camelhr.com","example.org"],"jit_provisioning":true,`+
+			`"redirect_uri":"https://camelhr.com/sso/callback"}`, rr.Body.String())
+	})
+}
+
+func TestHandler_SetConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the allowed email domains are missing", func(t *testing.T) {
+		t.Parallel()
+
+		payload := `{"issuer":"https://idp.example.org","client_id":"client","client_secret":"secret"}`
+		req, err := http.NewRequest(http.MethodPut, configPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// call the handler
+		handler.SetConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return bad request when the issuer is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		payload := `{"issuer":"https://idp.example.org","client_id":"client","client_secret":"secret",` +
+			`"allowed_email_domains":["camelhr.com"]}`
+		req, err := http.NewRequest(http.MethodPut, configPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetConfig", fake.MockContext, orgID, mock.AnythingOfType("sso.ConfigRequest")).
+			Return(sso.Config{}, sso.ErrInvalidIssuer)
+
+		// call the handler
+		handler.SetConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should store the configuration", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		payload := `{"issuer":"https://idp.example.org","client_id":"client","client_secret":"secret",` +
+			`"allowed_email_domains":["camelhr.com"],"jit_provisioning":true}`
+		req, err := http.NewRequest(http.MethodPut, configPath, strings.NewReader(payload))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("SetConfig", fake.MockContext, orgID, sso.ConfigRequest{
+			Issuer:              "https://idp.example.org",
+			ClientID:            "client",
+			ClientSecret:        "secret",
+			AllowedEmailDomains: []string{"camelhr.com"},
+			JITProvisioning:     true,
+		}).Return(sso.Config{
+			Issuer:              "https://idp.example.org",
+			ClientID:            "client",
+			ClientSecret:        "secret",
+			AllowedEmailDomains: "camelhr.com",
+			JITProvisioning:     true,
+		}, nil)
+		mockService.On("RedirectURI", orgSubdomain).Return("https://camelhr.com/sso/callback")
+
+		// call the handler
+		handler.SetConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "secret")
+	})
+}
+
+func TestHandler_DeleteConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the deletion fails", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, configPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteConfig", fake.MockContext, orgID).Return(assert.AnError)
+
+		// call the handler
+		handler.DeleteConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should delete the configuration", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		req, err := http.NewRequest(http.MethodDelete, configPath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, orgID)
+		req = tests.WithOrgSubdomain(req, orgSubdomain)
+
+		mockService := sso.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := sso.NewHandler(mockService)
+
+		// mock the service calls
+		mockService.On("DeleteConfig", fake.MockContext, orgID).Return(nil)
+
+		// call the handler
+		handler.DeleteConfig(rr, req)
+
+		// check the result
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
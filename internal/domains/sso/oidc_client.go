@@ -0,0 +1,397 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// maxResponseBytes limits the size of the responses read from the identity provider.
+	maxResponseBytes = 1 << 20
+
+	maxRedirects = 10
+)
+
+var (
+	ErrIdentityProvider = errors.New("identity provider request failed")
+	ErrInvalidIDToken   = errors.New("id token is invalid")
+	ErrUnsupportedKey   = errors.New("unsupported identity provider key")
+)
+
+// IDTokenClaims represents the claims of the id token issued by the identity provider.
+type IDTokenClaims struct {
+	Email           string `json:"email"`
+	EmailVerified   *bool  `json:"email_verified"`
+	Nonce           string `json:"nonce"`
+	AuthorizedParty string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// OIDCClient is an interface for talking to the openid connect identity provider of an organization.
+type OIDCClient interface {
+	// Discover returns the provider metadata published by the given issuer.
+	Discover(ctx context.Context, issuer string) (ProviderMetadata, error)
+
+	// ExchangeCode exchanges the authorization code and the pkce code verifier for the raw id token.
+	ExchangeCode(
+		ctx context.Context, provider ProviderMetadata, c Config, code, codeVerifier, redirectURI string,
+	) (string, error)
+
+	// VerifyIDToken verifies the signature, the issuer, the audience, the expiry and the nonce of the id token.
+	VerifyIDToken(ctx context.Context, provider ProviderMetadata, c Config, rawIDToken, nonce string) (
+		IDTokenClaims, error,
+	)
+}
+
+type oidcClient struct {
+	httpClient    *http.Client
+	allowInsecure bool
+}
+
+// nonPublicPrefixes are the special purpose address ranges not covered by the checks of netip.Addr.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// NewOIDCClient creates a new openid connect client.
+// The identity provider is configured by the organization admins, so the client talks only to the https urls
+// of public hosts. The address is checked once the host is resolved to make sure a dns change can not point
+// the client to an internal service. The checks are skipped when the insecure issuers are allowed.
+func NewOIDCClient(conf config.Config) OIDCClient {
+	const defaultTimeout = 10 * time.Second
+
+	if conf.SSOAllowInsecureIssuers {
+		return &oidcClient{httpClient: &http.Client{Timeout: defaultTimeout}, allowInsecure: true}
+	}
+
+	dialer := &net.Dialer{Timeout: defaultTimeout, Control: rejectNonPublicAddress}
+
+	return &oidcClient{httpClient: &http.Client{
+		Timeout: defaultTimeout,
+		// the proxy is not used since the address it connects to can not be checked
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: defaultTimeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to %s is not allowed", req.URL.Redacted())
+			}
+
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
+	}}
+}
+
+// rejectNonPublicAddress fails the connection to an address that is not publicly routable.
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("address %s is not public", addrPort.Addr())
+	}
+
+	return nil
+}
+
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkURL makes sure the url of the identity provider is an absolute https url.
+func (c *oidcClient) checkURL(rawURL string) error {
+	if c.allowInsecure {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("%q is not an https url: %w", rawURL, ErrIdentityProvider)
+	}
+
+	return nil
+}
+
+func (c *oidcClient) Discover(ctx context.Context, issuer string) (ProviderMetadata, error) {
+	var provider ProviderMetadata
+
+	if err := c.checkURL(issuer); err != nil {
+		return ProviderMetadata{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return ProviderMetadata{}, err
+	}
+
+	if err := c.doJSON(req, &provider); err != nil {
+		return ProviderMetadata{}, fmt.Errorf("failed to discover the issuer %s: %w", issuer, err)
+	}
+
+	// the discovery document must be published by the issuer itself
+	if provider.Issuer != issuer {
+		return ProviderMetadata{}, fmt.Errorf("issuer mismatch in the discovery document of %s: %w",
+			issuer, ErrIdentityProvider)
+	}
+
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return ProviderMetadata{}, fmt.Errorf("incomplete discovery document of %s: %w", issuer, ErrIdentityProvider)
+	}
+
+	for _, endpoint := range []string{provider.AuthorizationEndpoint, provider.TokenEndpoint, provider.JWKSURI} {
+		if err := c.checkURL(endpoint); err != nil {
+			return ProviderMetadata{}, err
+		}
+	}
+
+	return provider, nil
+}
+
+func (c *oidcClient) ExchangeCode(
+	ctx context.Context, provider ProviderMetadata, conf Config, code, codeVerifier, redirectURI string,
+) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+		"client_id":     {conf.ClientID},
+	}
+
+	if err := c.checkURL(provider.TokenEndpoint); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	// client_secret_basic requires the credentials to be form encoded
+	req.SetBasicAuth(url.QueryEscape(conf.ClientID), url.QueryEscape(conf.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+
+	if err := c.doJSON(req, &tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+
+	if tokenResponse.IDToken == "" {
+		return "", fmt.Errorf("id token not found in the token response: %w", ErrIdentityProvider)
+	}
+
+	return tokenResponse.IDToken, nil
+}
+
+func (c *oidcClient) VerifyIDToken(
+	ctx context.Context, provider ProviderMetadata, conf Config, rawIDToken, nonce string,
+) (IDTokenClaims, error) {
+	keys, err := c.fetchKeys(ctx, provider.JWKSURI)
+	if err != nil {
+		return IDTokenClaims{}, err
+	}
+
+	var claims IDTokenClaims
+
+	_, err = jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		for _, k := range keys {
+			if kid == "" || k.KeyID == kid {
+				return k.publicKey()
+			}
+		}
+
+		return nil, fmt.Errorf("key id %q not found in the provider keys: %w", kid, ErrUnsupportedKey)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(conf.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return IDTokenClaims{}, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	// the nonce binds the id token to the authorization request
+	if claims.Nonce != nonce {
+		return IDTokenClaims{}, fmt.Errorf("nonce mismatch: %w", ErrInvalidIDToken)
+	}
+
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != conf.ClientID {
+		return IDTokenClaims{}, fmt.Errorf("authorized party mismatch: %w", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// providerKey represents a public key of the identity provider in the json web key format.
+type providerKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey converts the json web key to the public key to verify the id token with.
+func (k providerKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("curve %s: %w", k.Curve, ErrUnsupportedKey)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if k.Curve != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("curve %s: %w", k.Curve, ErrUnsupportedKey)
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("key type %s: %w", k.KeyType, ErrUnsupportedKey)
+	}
+}
+
+// fetchKeys returns the public keys published by the identity provider.
+func (c *oidcClient) fetchKeys(ctx context.Context, jwksURI string) ([]providerKey, error) {
+	if err := c.checkURL(jwksURI); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []providerKey `json:"keys"`
+	}
+
+	if err := c.doJSON(req, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch the provider keys: %w", err)
+	}
+
+	return jwks.Keys, nil
+}
+
+// doJSON sends the request and decodes the json response into dest.
+// A non 2xx response is returned as ErrIdentityProvider along with the oauth error of the response.
+func (c *oidcClient) doJSON(req *http.Request, dest any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIdentityProvider, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIdentityProvider, err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+
+		_ = json.Unmarshal(body, &oauthErr)
+
+		return fmt.Errorf("%w: status %d %s %s", ErrIdentityProvider, resp.StatusCode, oauthErr.Error,
+			oauthErr.ErrorDescription)
+	}
+
+	if err := json.Unmarshal(body, dest); err != nil {
+		return fmt.Errorf("%w: %w", ErrIdentityProvider, err)
+	}
+
+	return nil
+}
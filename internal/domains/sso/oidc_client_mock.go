@@ -0,0 +1,214 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package sso
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOIDCClient is an autogenerated mock type for the OIDCClient type
+type MockOIDCClient struct {
+	mock.Mock
+}
+
+type MockOIDCClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOIDCClient) EXPECT() *MockOIDCClient_Expecter {
+	return &MockOIDCClient_Expecter{mock: &_m.Mock}
+}
+
+// Discover provides a mock function with given fields: ctx, issuer
+func (_m *MockOIDCClient) Discover(ctx context.Context, issuer string) (ProviderMetadata, error) {
+	ret := _m.Called(ctx, issuer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Discover")
+	}
+
+	var r0 ProviderMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ProviderMetadata, error)); ok {
+		return rf(ctx, issuer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) ProviderMetadata); ok {
+		r0 = rf(ctx, issuer)
+	} else {
+		r0 = ret.Get(0).(ProviderMetadata)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, issuer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOIDCClient_Discover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Discover'
+type MockOIDCClient_Discover_Call struct {
+	*mock.Call
+}
+
+// Discover is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+func (_e *MockOIDCClient_Expecter) Discover(ctx interface{}, issuer interface{}) *MockOIDCClient_Discover_Call {
+	return &MockOIDCClient_Discover_Call{Call: _e.mock.On("Discover", ctx, issuer)}
+}
+
+func (_c *MockOIDCClient_Discover_Call) Run(run func(ctx context.Context, issuer string)) *MockOIDCClient_Discover_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockOIDCClient_Discover_Call) Return(_a0 ProviderMetadata, _a1 error) *MockOIDCClient_Discover_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOIDCClient_Discover_Call) RunAndReturn(run func(context.Context, string) (ProviderMetadata, error)) *MockOIDCClient_Discover_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExchangeCode provides a mock function with given fields: ctx, provider, c, code, codeVerifier, redirectURI
+func (_m *MockOIDCClient) ExchangeCode(ctx context.Context, provider ProviderMetadata, c Config, code string, codeVerifier string, redirectURI string) (string, error) {
+	ret := _m.Called(ctx, provider, c, code, codeVerifier, redirectURI)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExchangeCode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProviderMetadata, Config, string, string, string) (string, error)); ok {
+		return rf(ctx, provider, c, code, codeVerifier, redirectURI)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ProviderMetadata, Config, string, string, string) string); ok {
+		r0 = rf(ctx, provider, c, code, codeVerifier, redirectURI)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ProviderMetadata, Config, string, string, string) error); ok {
+		r1 = rf(ctx, provider, c, code, codeVerifier, redirectURI)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOIDCClient_ExchangeCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExchangeCode'
+type MockOIDCClient_ExchangeCode_Call struct {
+	*mock.Call
+}
+
+// ExchangeCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider ProviderMetadata
+//   - c Config
+//   - code string
+//   - codeVerifier string
+//   - redirectURI string
+func (_e *MockOIDCClient_Expecter) ExchangeCode(ctx interface{}, provider interface{}, c interface{}, code interface{}, codeVerifier interface{}, redirectURI interface{}) *MockOIDCClient_ExchangeCode_Call {
+	return &MockOIDCClient_ExchangeCode_Call{Call: _e.mock.On("ExchangeCode", ctx, provider, c, code, codeVerifier, redirectURI)}
+}
+
+func (_c *MockOIDCClient_ExchangeCode_Call) Run(run func(ctx context.Context, provider ProviderMetadata, c Config, code string, codeVerifier string, redirectURI string)) *MockOIDCClient_ExchangeCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProviderMetadata), args[2].(Config), args[3].(string), args[4].(string), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *MockOIDCClient_ExchangeCode_Call) Return(_a0 string, _a1 error) *MockOIDCClient_ExchangeCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOIDCClient_ExchangeCode_Call) RunAndReturn(run func(context.Context, ProviderMetadata, Config, string, string, string) (string, error)) *MockOIDCClient_ExchangeCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyIDToken provides a mock function with given fields: ctx, provider, c, rawIDToken, nonce
+func (_m *MockOIDCClient) VerifyIDToken(ctx context.Context, provider ProviderMetadata, c Config, rawIDToken string, nonce string) (IDTokenClaims, error) {
+	ret := _m.Called(ctx, provider, c, rawIDToken, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyIDToken")
+	}
+
+	var r0 IDTokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ProviderMetadata, Config, string, string) (IDTokenClaims, error)); ok {
+		return rf(ctx, provider, c, rawIDToken, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ProviderMetadata, Config, string, string) IDTokenClaims); ok {
+		r0 = rf(ctx, provider, c, rawIDToken, nonce)
+	} else {
+		r0 = ret.Get(0).(IDTokenClaims)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ProviderMetadata, Config, string, string) error); ok {
+		r1 = rf(ctx, provider, c, rawIDToken, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOIDCClient_VerifyIDToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyIDToken'
+type MockOIDCClient_VerifyIDToken_Call struct {
+	*mock.Call
+}
+
+// VerifyIDToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider ProviderMetadata
+//   - c Config
+//   - rawIDToken string
+//   - nonce string
+func (_e *MockOIDCClient_Expecter) VerifyIDToken(ctx interface{}, provider interface{}, c interface{}, rawIDToken interface{}, nonce interface{}) *MockOIDCClient_VerifyIDToken_Call {
+	return &MockOIDCClient_VerifyIDToken_Call{Call: _e.mock.On("VerifyIDToken", ctx, provider, c, rawIDToken, nonce)}
+}
+
+func (_c *MockOIDCClient_VerifyIDToken_Call) Run(run func(ctx context.Context, provider ProviderMetadata, c Config, rawIDToken string, nonce string)) *MockOIDCClient_VerifyIDToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ProviderMetadata), args[2].(Config), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockOIDCClient_VerifyIDToken_Call) Return(_a0 IDTokenClaims, _a1 error) *MockOIDCClient_VerifyIDToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOIDCClient_VerifyIDToken_Call) RunAndReturn(run func(context.Context, ProviderMetadata, Config, string, string) (IDTokenClaims, error)) *MockOIDCClient_VerifyIDToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOIDCClient creates a new instance of MockOIDCClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOIDCClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOIDCClient {
+	mock := &MockOIDCClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
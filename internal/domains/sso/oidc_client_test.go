@@ -0,0 +1,240 @@
+package sso_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insecureConfig allows the local identity providers of the tests that are served over http on the loopback address.
+var insecureConfig = config.Config{SSOAllowInsecureIssuers: true}
+
+func TestOIDCClient_Discover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the provider metadata", func(t *testing.T) {
+		t.Parallel()
+
+		provider := newOIDCProvider(t)
+		client := sso.NewOIDCClient(insecureConfig)
+
+		result, err := client.Discover(context.Background(), provider.Issuer)
+		require.NoError(t, err)
+		assert.Equal(t, sso.ProviderMetadata{
+			Issuer:                provider.Issuer,
+			AuthorizationEndpoint: provider.Issuer + "/authorize",
+			TokenEndpoint:         provider.Issuer + "/token",
+			JWKSURI:               provider.Issuer + "/jwks",
+		}, result)
+	})
+
+	t.Run("should return error when the issuer does not match", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"issuer":"https://evil.com","authorization_endpoint":"https://evil.com/a",` +
+				`"token_endpoint":"https://evil.com/t","jwks_uri":"https://evil.com/k"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := sso.NewOIDCClient(insecureConfig).Discover(context.Background(), server.URL)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+	})
+
+	t.Run("should return error when the discovery document is not found", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(server.Close)
+
+		_, err := sso.NewOIDCClient(insecureConfig).Discover(context.Background(), server.URL)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+	})
+
+	t.Run("should return error when the issuer is not an https url", func(t *testing.T) {
+		t.Parallel()
+
+		var requested atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			requested.Store(true)
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := sso.NewOIDCClient(config.Config{}).Discover(context.Background(), server.URL)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+		assert.False(t, requested.Load())
+	})
+
+	t.Run("should return error when the issuer resolves to an internal address", func(t *testing.T) {
+		t.Parallel()
+
+		var requested atomic.Bool
+		server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			requested.Store(true)
+		}))
+		t.Cleanup(server.Close)
+
+		for _, issuer := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+			_, err := sso.NewOIDCClient(config.Config{}).Discover(context.Background(), issuer)
+			require.ErrorIs(t, err, sso.ErrIdentityProvider)
+			assert.ErrorContains(t, err, "is not public")
+		}
+
+		assert.False(t, requested.Load())
+	})
+}
+
+func TestOIDCClient_ExchangeCodeAndVerifyIDToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should exchange the code and verify the id token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		provider := newOIDCProvider(t)
+		client := sso.NewOIDCClient(insecureConfig)
+		c := sso.Config{Issuer: provider.Issuer, ClientID: provider.ClientID, ClientSecret: provider.ClientSecret}
+		email := gofakeit.Email()
+		nonce, codeVerifier, redirectURI, code := authorize(t, provider, email)
+
+		metadata, err := client.Discover(ctx, provider.Issuer)
+		require.NoError(t, err)
+
+		idToken, err := client.ExchangeCode(ctx, metadata, c, code, codeVerifier, redirectURI)
+		require.NoError(t, err)
+
+		claims, err := client.VerifyIDToken(ctx, metadata, c, idToken, nonce)
+		require.NoError(t, err)
+		assert.Equal(t, email, claims.Email)
+		assert.True(t, *claims.EmailVerified)
+		assert.NotEmpty(t, claims.Subject)
+	})
+
+	t.Run("should return error when the client secret is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		provider := newOIDCProvider(t)
+		client := sso.NewOIDCClient(insecureConfig)
+		c := sso.Config{Issuer: provider.Issuer, ClientID: provider.ClientID, ClientSecret: gofakeit.UUID()}
+		_, codeVerifier, redirectURI, code := authorize(t, provider, gofakeit.Email())
+
+		metadata, err := client.Discover(ctx, provider.Issuer)
+		require.NoError(t, err)
+
+		_, err = client.ExchangeCode(ctx, metadata, c, code, codeVerifier, redirectURI)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+		assert.ErrorContains(t, err, "invalid_client")
+	})
+
+	t.Run("should return error when the code verifier does not match", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		provider := newOIDCProvider(t)
+		client := sso.NewOIDCClient(insecureConfig)
+		c := sso.Config{Issuer: provider.Issuer, ClientID: provider.ClientID, ClientSecret: provider.ClientSecret}
+		_, _, redirectURI, code := authorize(t, provider, gofakeit.Email())
+
+		metadata, err := client.Discover(ctx, provider.Issuer)
+		require.NoError(t, err)
+
+		_, err = client.ExchangeCode(ctx, metadata, c, code, gofakeit.UUID(), redirectURI)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("should return error when the nonce does not match", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		provider := newOIDCProvider(t)
+		client := sso.NewOIDCClient(insecureConfig)
+		c := sso.Config{Issuer: provider.Issuer, ClientID: provider.ClientID, ClientSecret: provider.ClientSecret}
+		_, codeVerifier, redirectURI, code := authorize(t, provider, gofakeit.Email())
+
+		metadata, err := client.Discover(ctx, provider.Issuer)
+		require.NoError(t, err)
+
+		idToken, err := client.ExchangeCode(ctx, metadata, c, code, codeVerifier, redirectURI)
+		require.NoError(t, err)
+
+		_, err = client.VerifyIDToken(ctx, metadata, c, idToken, gofakeit.UUID())
+		require.ErrorIs(t, err, sso.ErrInvalidIDToken)
+	})
+
+	for _, tc := range []struct {
+		name  string
+		claim string
+		value any
+	}{
+		{"should return error when the audience does not match", "aud", gofakeit.UUID()},
+		{"should return error when the issuer does not match", "iss", "https://evil.com"},
+		{"should return error when the id token is expired", "exp", time.Now().Add(-time.Minute).Unix()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			provider := newOIDCProvider(t)
+			provider.IDTokenClaims[tc.claim] = tc.value
+			client := sso.NewOIDCClient(insecureConfig)
+			c := sso.Config{Issuer: provider.Issuer, ClientID: provider.ClientID, ClientSecret: provider.ClientSecret}
+			nonce, codeVerifier, redirectURI, code := authorize(t, provider, gofakeit.Email())
+
+			metadata, err := client.Discover(ctx, provider.Issuer)
+			require.NoError(t, err)
+
+			idToken, err := client.ExchangeCode(ctx, metadata, c, code, codeVerifier, redirectURI)
+			require.NoError(t, err)
+
+			_, err = client.VerifyIDToken(ctx, metadata, c, idToken, nonce)
+			require.ErrorIs(t, err, sso.ErrInvalidIDToken)
+		})
+	}
+}
+
+// newOIDCProvider starts a local identity provider that is closed when the test is done.
+func newOIDCProvider(t *testing.T) *tests.OIDCProvider {
+	t.Helper()
+
+	provider, err := tests.NewOIDCProvider()
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	return provider
+}
+
+// authorize simulates the login of the user at the identity provider.
+// It returns the nonce, the code verifier, the redirect uri and the authorization code.
+func authorize(t *testing.T, provider *tests.OIDCProvider, email string) (string, string, string, string) {
+	t.Helper()
+
+	nonce := gofakeit.UUID()
+	codeVerifier := gofakeit.UUID() + gofakeit.UUID()
+	redirectURI := appURL + "/sso/callback"
+	codeChallenge := sha256.Sum256([]byte(codeVerifier))
+
+	code, err := provider.Authorize(provider.Issuer+"/authorize?"+url.Values{
+		"nonce":          {nonce},
+		"redirect_uri":   {redirectURI},
+		"code_challenge": {base64.RawURLEncoding.EncodeToString(codeChallenge[:])},
+	}.Encode(), email)
+	require.NoError(t, err)
+
+	return nonce, codeVerifier, redirectURI, code
+}
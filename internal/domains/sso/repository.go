@@ -0,0 +1,45 @@
+package sso
+
+import (
+	"context"
+
+	"github.com/camelhr/camelhr-api/internal/database"
+)
+
+type Repository interface {
+	// GetConfig returns the sso configuration of the organization.
+	GetConfig(ctx context.Context, orgID int64) (Config, error)
+
+	// UpsertConfig creates or replaces the sso configuration of the organization.
+	UpsertConfig(ctx context.Context, c Config) (Config, error)
+
+	// DeleteConfig deletes the sso configuration of the organization.
+	DeleteConfig(ctx context.Context, orgID int64) error
+}
+
+type repository struct {
+	db database.Database
+}
+
+func NewRepository(db database.Database) Repository {
+	return &repository{db}
+}
+
+func (r *repository) GetConfig(ctx context.Context, orgID int64) (Config, error) {
+	var c Config
+	err := r.db.Get(ctx, &c, getSSOConfigQuery, orgID)
+
+	return c, err
+}
+
+func (r *repository) UpsertConfig(ctx context.Context, c Config) (Config, error) {
+	var result Config
+	err := r.db.Exec(ctx, &result, upsertSSOConfigQuery, c.OrganizationID, c.Issuer, c.ClientID, c.ClientSecret,
+		c.AllowedEmailDomains, c.JITProvisioning)
+
+	return result, err
+}
+
+func (r *repository) DeleteConfig(ctx context.Context, orgID int64) error {
+	return r.db.Exec(ctx, nil, deleteSSOConfigQuery, orgID)
+}
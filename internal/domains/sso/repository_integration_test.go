@@ -0,0 +1,56 @@
+package sso_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *SSOTestSuite) TestRepositoryIntegration_Config() {
+	s.Run("should create, replace and delete the sso configuration", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		repo := sso.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+
+		_, err := repo.GetConfig(ctx, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+
+		c := sso.Config{
+			OrganizationID:      o.ID,
+			Issuer:              gofakeit.URL(),
+			ClientID:            gofakeit.UUID(),
+			ClientSecret:        gofakeit.UUID(),
+			AllowedEmailDomains: "camelhr.com",
+		}
+
+		created, err := repo.UpsertConfig(ctx, c)
+		s.Require().NoError(err)
+		s.Equal(c.Issuer, created.Issuer)
+		s.False(created.JITProvisioning)
+		s.NotZero(created.CreatedAt)
+
+		// the configuration is replaced for the same organization
+		c.ClientSecret = gofakeit.UUID()
+		c.JITProvisioning = true
+
+		_, err = repo.UpsertConfig(ctx, c)
+		s.Require().NoError(err)
+
+		result, err := repo.GetConfig(ctx, o.ID)
+		s.Require().NoError(err)
+		s.Equal(c.ClientSecret, result.ClientSecret)
+		s.True(result.JITProvisioning)
+		s.Equal([]string{"camelhr.com"}, result.EmailDomains())
+
+		err = repo.DeleteConfig(ctx, o.ID)
+		s.Require().NoError(err)
+
+		_, err = repo.GetConfig(ctx, o.ID)
+		s.Require().ErrorIs(err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,197 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package sso
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteConfig provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) DeleteConfig(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_DeleteConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConfig'
+type MockRepository_DeleteConfig_Call struct {
+	*mock.Call
+}
+
+// DeleteConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) DeleteConfig(ctx interface{}, orgID interface{}) *MockRepository_DeleteConfig_Call {
+	return &MockRepository_DeleteConfig_Call{Call: _e.mock.On("DeleteConfig", ctx, orgID)}
+}
+
+func (_c *MockRepository_DeleteConfig_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_DeleteConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteConfig_Call) Return(_a0 error) *MockRepository_DeleteConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_DeleteConfig_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_DeleteConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfig provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetConfig(ctx context.Context, orgID int64) (Config, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Config, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Config); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Config)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
+type MockRepository_GetConfig_Call struct {
+	*mock.Call
+}
+
+// GetConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetConfig(ctx interface{}, orgID interface{}) *MockRepository_GetConfig_Call {
+	return &MockRepository_GetConfig_Call{Call: _e.mock.On("GetConfig", ctx, orgID)}
+}
+
+func (_c *MockRepository_GetConfig_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetConfig_Call) Return(_a0 Config, _a1 error) *MockRepository_GetConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetConfig_Call) RunAndReturn(run func(context.Context, int64) (Config, error)) *MockRepository_GetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertConfig provides a mock function with given fields: ctx, c
+func (_m *MockRepository) UpsertConfig(ctx context.Context, c Config) (Config, error) {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertConfig")
+	}
+
+	var r0 Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Config) (Config, error)); ok {
+		return rf(ctx, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Config) Config); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(Config)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Config) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_UpsertConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertConfig'
+type MockRepository_UpsertConfig_Call struct {
+	*mock.Call
+}
+
+// UpsertConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c Config
+func (_e *MockRepository_Expecter) UpsertConfig(ctx interface{}, c interface{}) *MockRepository_UpsertConfig_Call {
+	return &MockRepository_UpsertConfig_Call{Call: _e.mock.On("UpsertConfig", ctx, c)}
+}
+
+func (_c *MockRepository_UpsertConfig_Call) Run(run func(ctx context.Context, c Config)) *MockRepository_UpsertConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Config))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertConfig_Call) Return(_a0 Config, _a1 error) *MockRepository_UpsertConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_UpsertConfig_Call) RunAndReturn(run func(context.Context, Config) (Config, error)) *MockRepository_UpsertConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,248 @@
+package sso
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/log"
+)
+
+type Service interface {
+	// GetConfig returns the sso configuration of the organization.
+	GetConfig(ctx context.Context, orgID int64) (Config, error)
+
+	// SetConfig creates or replaces the sso configuration of the organization.
+	// The issuer must publish a valid discovery document using the https urls of a public host.
+	SetConfig(ctx context.Context, orgID int64, req ConfigRequest) (Config, error)
+
+	// DeleteConfig removes the sso configuration of the organization.
+	DeleteConfig(ctx context.Context, orgID int64) error
+
+	// RedirectURI returns the uri the identity provider redirects the user to after the login.
+	RedirectURI(subdomain string) string
+
+	// AuthorizationURL starts the login at the identity provider of the organization
+	// and returns the url to redirect the user to along with the browser binding.
+	// The authorization code flow with pkce is used. The state expires after the StateTTL.
+	// The browser binding must be kept by the browser starting the login to complete it.
+	AuthorizationURL(ctx context.Context, org organization.Organization, rememberMe bool) (string, string, error)
+
+	// Authenticate completes the login at the identity provider by exchanging the authorization code
+	// and returns the identity of the verified id token.
+	// The state must be presented along with the browser binding of the browser that started the login.
+	// The email of the identity must be verified and belong to one of the allowed email domains.
+	Authenticate(ctx context.Context, org organization.Organization, code, state, browserBinding string) (
+		Identity, error,
+	)
+}
+
+type service struct {
+	appURL       string
+	repo         Repository
+	oidcClient   OIDCClient
+	stateManager StateManager
+}
+
+func NewService(conf config.Config, repo Repository, oidcClient OIDCClient, stateManager StateManager) Service {
+	return &service{
+		appURL:       conf.AppURL,
+		repo:         repo,
+		oidcClient:   oidcClient,
+		stateManager: stateManager,
+	}
+}
+
+var (
+	ErrInvalidIssuer    = errors.New("issuer is not a valid openid connect provider")
+	ErrEmailNotVerified = errors.New("email is not verified by the identity provider")
+	ErrEmailNotAllowed  = errors.New("email domain is not allowed to login using sso")
+)
+
+func (s *service) GetConfig(ctx context.Context, orgID int64) (Config, error) {
+	c, err := s.repo.GetConfig(ctx, orgID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Config{}, base.NewNotFoundError("sso configuration not found for the given organization")
+	}
+
+	return c, err
+}
+
+func (s *service) SetConfig(ctx context.Context, orgID int64, req ConfigRequest) (Config, error) {
+	// reject a misconfigured issuer upfront instead of failing every login
+	// the detail is only logged since the response of an arbitrary host must not be sent back to the client
+	if _, err := s.oidcClient.Discover(ctx, req.Issuer); err != nil {
+		log.Warn("failed to discover the sso issuer of org:%d: %v", orgID, err)
+		return Config{}, ErrInvalidIssuer
+	}
+
+	domains := make([]string, 0, len(req.AllowedEmailDomains))
+	for _, d := range req.AllowedEmailDomains {
+		domains = append(domains, strings.ToLower(d))
+	}
+
+	return s.repo.UpsertConfig(ctx, Config{
+		OrganizationID:      orgID,
+		Issuer:              req.Issuer,
+		ClientID:            req.ClientID,
+		ClientSecret:        req.ClientSecret,
+		AllowedEmailDomains: strings.Join(domains, emailDomainsSeparator),
+		JITProvisioning:     req.JITProvisioning,
+	})
+}
+
+func (s *service) DeleteConfig(ctx context.Context, orgID int64) error {
+	return s.repo.DeleteConfig(ctx, orgID)
+}
+
+func (s *service) RedirectURI(subdomain string) string {
+	return fmt.Sprintf("%s/sso/callback?subdomain=%s", s.appURL, url.QueryEscape(subdomain))
+}
+
+func (s *service) AuthorizationURL(ctx context.Context, org organization.Organization, rememberMe bool) (
+	string, string, error,
+) {
+	c, err := s.GetConfig(ctx, org.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	provider, err := s.oidcClient.Discover(ctx, c.Issuer)
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid authorization endpoint: %w", ErrIdentityProvider)
+	}
+
+	state, nonce, codeVerifier, err := generateAuthSecrets()
+	if err != nil {
+		return "", "", err
+	}
+
+	// the state alone is not enough to complete the login. it is sent to the identity provider
+	// and could be used to log the browser of another user into the account of the attacker
+	browserBinding, err := base.GenerateRandomToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.stateManager.SaveState(ctx, state, AuthState{
+		OrgID:              org.ID,
+		Nonce:              nonce,
+		CodeVerifier:       codeVerifier,
+		RememberMe:         rememberMe,
+		BrowserBindingHash: base.HashToken(browserBinding),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	codeChallenge := sha256.Sum256([]byte(codeVerifier))
+
+	// keep the query parameters of the endpoint if any
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", s.RedirectURI(org.Subdomain))
+	query.Set("scope", "openid email")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(codeChallenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), browserBinding, nil
+}
+
+func (s *service) Authenticate(
+	ctx context.Context,
+	org organization.Organization,
+	code, state, browserBinding string,
+) (Identity, error) {
+	authState, err := s.stateManager.ConsumeState(ctx, state)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// the state must be issued for the same organization
+	if authState.OrgID != org.ID {
+		return Identity{}, ErrInvalidState
+	}
+
+	// the login must be completed by the browser that started it
+	if browserBinding == "" || subtle.ConstantTimeCompare([]byte(base.HashToken(browserBinding)),
+		[]byte(authState.BrowserBindingHash)) != 1 {
+		return Identity{}, ErrInvalidState
+	}
+
+	c, err := s.GetConfig(ctx, org.ID)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	provider, err := s.oidcClient.Discover(ctx, c.Issuer)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	rawIDToken, err := s.oidcClient.ExchangeCode(ctx, provider, c, code, authState.CodeVerifier,
+		s.RedirectURI(org.Subdomain))
+	if err != nil {
+		return Identity{}, err
+	}
+
+	claims, err := s.oidcClient.VerifyIDToken(ctx, provider, c, rawIDToken, authState.Nonce)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// some providers omit the email_verified claim. only an explicitly unverified email is rejected
+	// since the email domain must be one of the allowed domains anyway
+	if claims.Email == "" || (claims.EmailVerified != nil && !*claims.EmailVerified) {
+		return Identity{}, ErrEmailNotVerified
+	}
+
+	if !c.IsEmailAllowed(claims.Email) {
+		return Identity{}, ErrEmailNotAllowed
+	}
+
+	return Identity{
+		Subject:         claims.Subject,
+		Email:           claims.Email,
+		RememberMe:      authState.RememberMe,
+		JITProvisioning: c.JITProvisioning,
+	}, nil
+}
+
+// generateAuthSecrets returns the random state, nonce and pkce code verifier of an authorization request.
+func generateAuthSecrets() (string, string, string, error) {
+	state, err := base.GenerateRandomToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	nonce, err := base.GenerateRandomToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// the code verifier must be 43 to 128 characters long which the random token satisfies
+	codeVerifier, err := base.GenerateRandomToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return state, nonce, codeVerifier, nil
+}
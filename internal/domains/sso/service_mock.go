@@ -0,0 +1,370 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package sso
+
+import (
+	context "context"
+
+	organization "github.com/camelhr/camelhr-api/internal/domains/organization"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+type MockService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockService) EXPECT() *MockService_Expecter {
+	return &MockService_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, org, code, state, browserBinding
+func (_m *MockService) Authenticate(ctx context.Context, org organization.Organization, code string, state string, browserBinding string) (Identity, error) {
+	ret := _m.Called(ctx, org, code, state, browserBinding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, organization.Organization, string, string, string) (Identity, error)); ok {
+		return rf(ctx, org, code, state, browserBinding)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, organization.Organization, string, string, string) Identity); ok {
+		r0 = rf(ctx, org, code, state, browserBinding)
+	} else {
+		r0 = ret.Get(0).(Identity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, organization.Organization, string, string, string) error); ok {
+		r1 = rf(ctx, org, code, state, browserBinding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org organization.Organization
+//   - code string
+//   - state string
+//   - browserBinding string
+func (_e *MockService_Expecter) Authenticate(ctx interface{}, org interface{}, code interface{}, state interface{}, browserBinding interface{}) *MockService_Authenticate_Call {
+	return &MockService_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, org, code, state, browserBinding)}
+}
+
+func (_c *MockService_Authenticate_Call) Run(run func(ctx context.Context, org organization.Organization, code string, state string, browserBinding string)) *MockService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(organization.Organization), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_Authenticate_Call) Return(_a0 Identity, _a1 error) *MockService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_Authenticate_Call) RunAndReturn(run func(context.Context, organization.Organization, string, string, string) (Identity, error)) *MockService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthorizationURL provides a mock function with given fields: ctx, org, rememberMe
+func (_m *MockService) AuthorizationURL(ctx context.Context, org organization.Organization, rememberMe bool) (string, string, error) {
+	ret := _m.Called(ctx, org, rememberMe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizationURL")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, organization.Organization, bool) (string, string, error)); ok {
+		return rf(ctx, org, rememberMe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, organization.Organization, bool) string); ok {
+		r0 = rf(ctx, org, rememberMe)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, organization.Organization, bool) string); ok {
+		r1 = rf(ctx, org, rememberMe)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, organization.Organization, bool) error); ok {
+		r2 = rf(ctx, org, rememberMe)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockService_AuthorizationURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthorizationURL'
+type MockService_AuthorizationURL_Call struct {
+	*mock.Call
+}
+
+// AuthorizationURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org organization.Organization
+//   - rememberMe bool
+func (_e *MockService_Expecter) AuthorizationURL(ctx interface{}, org interface{}, rememberMe interface{}) *MockService_AuthorizationURL_Call {
+	return &MockService_AuthorizationURL_Call{Call: _e.mock.On("AuthorizationURL", ctx, org, rememberMe)}
+}
+
+func (_c *MockService_AuthorizationURL_Call) Run(run func(ctx context.Context, org organization.Organization, rememberMe bool)) *MockService_AuthorizationURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(organization.Organization), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockService_AuthorizationURL_Call) Return(_a0 string, _a1 string, _a2 error) *MockService_AuthorizationURL_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockService_AuthorizationURL_Call) RunAndReturn(run func(context.Context, organization.Organization, bool) (string, string, error)) *MockService_AuthorizationURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConfig provides a mock function with given fields: ctx, orgID
+func (_m *MockService) DeleteConfig(ctx context.Context, orgID int64) error {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_DeleteConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConfig'
+type MockService_DeleteConfig_Call struct {
+	*mock.Call
+}
+
+// DeleteConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) DeleteConfig(ctx interface{}, orgID interface{}) *MockService_DeleteConfig_Call {
+	return &MockService_DeleteConfig_Call{Call: _e.mock.On("DeleteConfig", ctx, orgID)}
+}
+
+func (_c *MockService_DeleteConfig_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_DeleteConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_DeleteConfig_Call) Return(_a0 error) *MockService_DeleteConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_DeleteConfig_Call) RunAndReturn(run func(context.Context, int64) error) *MockService_DeleteConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfig provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetConfig(ctx context.Context, orgID int64) (Config, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (Config, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Config); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		r0 = ret.Get(0).(Config)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
+type MockService_GetConfig_Call struct {
+	*mock.Call
+}
+
+// GetConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+func (_e *MockService_Expecter) GetConfig(ctx interface{}, orgID interface{}) *MockService_GetConfig_Call {
+	return &MockService_GetConfig_Call{Call: _e.mock.On("GetConfig", ctx, orgID)}
+}
+
+func (_c *MockService_GetConfig_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_GetConfig_Call) Return(_a0 Config, _a1 error) *MockService_GetConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_GetConfig_Call) RunAndReturn(run func(context.Context, int64) (Config, error)) *MockService_GetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedirectURI provides a mock function with given fields: subdomain
+func (_m *MockService) RedirectURI(subdomain string) string {
+	ret := _m.Called(subdomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedirectURI")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(subdomain)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockService_RedirectURI_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedirectURI'
+type MockService_RedirectURI_Call struct {
+	*mock.Call
+}
+
+// RedirectURI is a helper method to define mock.On call
+//   - subdomain string
+func (_e *MockService_Expecter) RedirectURI(subdomain interface{}) *MockService_RedirectURI_Call {
+	return &MockService_RedirectURI_Call{Call: _e.mock.On("RedirectURI", subdomain)}
+}
+
+func (_c *MockService_RedirectURI_Call) Run(run func(subdomain string)) *MockService_RedirectURI_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_RedirectURI_Call) Return(_a0 string) *MockService_RedirectURI_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_RedirectURI_Call) RunAndReturn(run func(string) string) *MockService_RedirectURI_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetConfig provides a mock function with given fields: ctx, orgID, req
+func (_m *MockService) SetConfig(ctx context.Context, orgID int64, req ConfigRequest) (Config, error) {
+	ret := _m.Called(ctx, orgID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetConfig")
+	}
+
+	var r0 Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ConfigRequest) (Config, error)); ok {
+		return rf(ctx, orgID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ConfigRequest) Config); ok {
+		r0 = rf(ctx, orgID, req)
+	} else {
+		r0 = ret.Get(0).(Config)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, ConfigRequest) error); ok {
+		r1 = rf(ctx, orgID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_SetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetConfig'
+type MockService_SetConfig_Call struct {
+	*mock.Call
+}
+
+// SetConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - req ConfigRequest
+func (_e *MockService_Expecter) SetConfig(ctx interface{}, orgID interface{}, req interface{}) *MockService_SetConfig_Call {
+	return &MockService_SetConfig_Call{Call: _e.mock.On("SetConfig", ctx, orgID, req)}
+}
+
+func (_c *MockService_SetConfig_Call) Run(run func(ctx context.Context, orgID int64, req ConfigRequest)) *MockService_SetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(ConfigRequest))
+	})
+	return _c
+}
+
+func (_c *MockService_SetConfig_Call) Return(_a0 Config, _a1 error) *MockService_SetConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_SetConfig_Call) RunAndReturn(run func(context.Context, int64, ConfigRequest) (Config, error)) *MockService_SetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
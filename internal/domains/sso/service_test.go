@@ -0,0 +1,408 @@
+package sso_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const appURL = "https://camelhr.com"
+
+func TestConfig_IsEmailAllowed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow only the emails of the allowed domains", func(t *testing.T) {
+		t.Parallel()
+
+		c := sso.Config{AllowedEmailDomains: "camelhr.com,example.org"}
+
+		assert.True(t, c.IsEmailAllowed("john@camelhr.com"))
+		assert.True(t, c.IsEmailAllowed("John@Example.ORG"))
+		assert.False(t, c.IsEmailAllowed("john@sub.camelhr.com"))
+		assert.False(t, c.IsEmailAllowed("john@camelhr.com.evil.com"))
+		assert.False(t, c.IsEmailAllowed("invalid"))
+		assert.False(t, sso.Config{}.IsEmailAllowed("john@camelhr.com"))
+	})
+}
+
+func TestService_GetConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when sso is not configured", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := sso.NewMockRepository(t)
+		service := sso.NewService(config.Config{}, repo, nil, nil)
+
+		repo.On("GetConfig", ctx, orgID).Return(sso.Config{}, sql.ErrNoRows)
+
+		_, err := service.GetConfig(ctx, orgID)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return the sso configuration", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c := sso.Config{OrganizationID: gofakeit.Int64(), Issuer: gofakeit.URL()}
+		repo := sso.NewMockRepository(t)
+		service := sso.NewService(config.Config{}, repo, nil, nil)
+
+		repo.On("GetConfig", ctx, c.OrganizationID).Return(c, nil)
+
+		result, err := service.GetConfig(ctx, c.OrganizationID)
+		require.NoError(t, err)
+		assert.Equal(t, c, result)
+	})
+}
+
+func TestService_SetConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the issuer can not be discovered", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		req := sso.ConfigRequest{Issuer: gofakeit.URL()}
+		oidcClient := sso.NewMockOIDCClient(t)
+		service := sso.NewService(config.Config{}, nil, oidcClient, nil)
+
+		oidcClient.On("Discover", ctx, req.Issuer).
+			Return(sso.ProviderMetadata{}, fmt.Errorf("%w: status 500 internal details", sso.ErrIdentityProvider))
+
+		_, err := service.SetConfig(ctx, orgID, req)
+		require.Equal(t, sso.ErrInvalidIssuer, err)
+	})
+
+	t.Run("should store the sso configuration with lowercase email domains", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		req := sso.ConfigRequest{
+			Issuer:              gofakeit.URL(),
+			ClientID:            gofakeit.UUID(),
+			ClientSecret:        gofakeit.UUID(),
+			AllowedEmailDomains: []string{"CamelHR.com", "example.org"},
+			JITProvisioning:     true,
+		}
+		c := sso.Config{
+			OrganizationID:      orgID,
+			Issuer:              req.Issuer,
+			ClientID:            req.ClientID,
+			ClientSecret:        req.ClientSecret,
+			AllowedEmailDomains: "camelhr.com,example.org",
+			JITProvisioning:     true,
+		}
+		oidcClient := sso.NewMockOIDCClient(t)
+		repo := sso.NewMockRepository(t)
+		service := sso.NewService(config.Config{}, repo, oidcClient, nil)
+
+		oidcClient.On("Discover", ctx, req.Issuer).Return(sso.ProviderMetadata{Issuer: req.Issuer}, nil)
+		repo.On("UpsertConfig", ctx, c).Return(c, nil)
+
+		result, err := service.SetConfig(ctx, orgID, req)
+		require.NoError(t, err)
+		assert.Equal(t, c, result)
+	})
+}
+
+func TestService_DeleteConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete the sso configuration", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orgID := gofakeit.Int64()
+		repo := sso.NewMockRepository(t)
+		service := sso.NewService(config.Config{}, repo, nil, nil)
+
+		repo.On("DeleteConfig", ctx, orgID).Return(nil)
+
+		err := service.DeleteConfig(ctx, orgID)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_AuthorizationURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return not found error when sso is not configured", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(10)}
+		repo := sso.NewMockRepository(t)
+		service := sso.NewService(config.Config{}, repo, nil, nil)
+
+		repo.On("GetConfig", ctx, org.ID).Return(sso.Config{}, sql.ErrNoRows)
+
+		_, _, err := service.AuthorizationURL(ctx, org, false)
+		require.Error(t, err)
+		assert.True(t, base.IsNotFoundError(err))
+	})
+
+	t.Run("should return the authorization url with pkce and store the state bound to the browser", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(10)}
+		c := sso.Config{OrganizationID: org.ID, Issuer: "https://idp.example.org", ClientID: gofakeit.UUID()}
+		provider := sso.ProviderMetadata{
+			Issuer:                c.Issuer,
+			AuthorizationEndpoint: c.Issuer + "/authorize?tenant=camelhr",
+		}
+		repo := sso.NewMockRepository(t)
+		oidcClient := sso.NewMockOIDCClient(t)
+		stateManager := sso.NewMockStateManager(t)
+		service := sso.NewService(config.Config{AppURL: appURL}, repo, oidcClient, stateManager)
+
+		var savedState string
+
+		var authState sso.AuthState
+
+		repo.On("GetConfig", ctx, org.ID).Return(c, nil)
+		oidcClient.On("Discover", ctx, c.Issuer).Return(provider, nil)
+		stateManager.On("SaveState", ctx, fake.MockString, mock.AnythingOfType("sso.AuthState")).
+			Run(func(args mock.Arguments) {
+				savedState = args.String(1)
+				authState = args.Get(2).(sso.AuthState) //nolint:forcetypeassert // type is asserted by the matcher
+			}).
+			Return(nil)
+
+		result, browserBinding, err := service.AuthorizationURL(ctx, org, true)
+		require.NoError(t, err)
+
+		u, err := url.Parse(result)
+		require.NoError(t, err)
+
+		codeChallenge := sha256.Sum256([]byte(authState.CodeVerifier))
+		query := u.Query()
+		assert.Equal(t, "idp.example.org", u.Host)
+		assert.Equal(t, "/authorize", u.Path)
+		assert.Equal(t, "camelhr", query.Get("tenant"))
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.Equal(t, c.ClientID, query.Get("client_id"))
+		assert.Equal(t, appURL+"/sso/callback?subdomain="+org.Subdomain, query.Get("redirect_uri"))
+		assert.Equal(t, "openid email", query.Get("scope"))
+		assert.Equal(t, savedState, query.Get("state"))
+		assert.Equal(t, authState.Nonce, query.Get("nonce"))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(codeChallenge[:]), query.Get("code_challenge"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.Equal(t, org.ID, authState.OrgID)
+		assert.True(t, authState.RememberMe)
+		assert.NotEmpty(t, authState.CodeVerifier)
+		assert.NotEmpty(t, browserBinding)
+		assert.Equal(t, base.HashToken(browserBinding), authState.BrowserBindingHash)
+		assert.NotEqual(t, savedState, browserBinding)
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the state is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		browserBinding := gofakeit.UUID()
+		org := organization.Organization{ID: gofakeit.Int64()}
+		stateManager := sso.NewMockStateManager(t)
+		service := sso.NewService(config.Config{}, nil, nil, stateManager)
+
+		stateManager.On("ConsumeState", ctx, "state").Return(sso.AuthState{}, sso.ErrInvalidState)
+
+		_, err := service.Authenticate(ctx, org, "code", "state", browserBinding)
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	t.Run("should return error when the state is issued for another organization", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		browserBinding := gofakeit.UUID()
+		org := organization.Organization{ID: gofakeit.Int64()}
+		stateManager := sso.NewMockStateManager(t)
+		service := sso.NewService(config.Config{}, nil, nil, stateManager)
+
+		stateManager.On("ConsumeState", ctx, "state").Return(sso.AuthState{OrgID: org.ID + 1}, nil)
+
+		_, err := service.Authenticate(ctx, org, "code", "state", browserBinding)
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	for _, tc := range []struct {
+		name           string
+		browserBinding string
+	}{
+		{
+			name:           "should return error when the browser binding is missing",
+			browserBinding: "",
+		},
+		{
+			name:           "should return error when the state is presented by another browser",
+			browserBinding: gofakeit.UUID(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			org := organization.Organization{ID: gofakeit.Int64()}
+			stateManager := sso.NewMockStateManager(t)
+			service := sso.NewService(config.Config{}, nil, nil, stateManager)
+
+			// the code is not exchanged. the mocks of the identity provider would panic otherwise
+			stateManager.On("ConsumeState", ctx, "state").
+				Return(sso.AuthState{OrgID: org.ID, BrowserBindingHash: base.HashToken(gofakeit.UUID())}, nil)
+
+			_, err := service.Authenticate(ctx, org, "code", "state", tc.browserBinding)
+			require.ErrorIs(t, err, sso.ErrInvalidState)
+		})
+	}
+
+	t.Run("should return error when the code exchange fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		browserBinding := gofakeit.UUID()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(10)}
+		c := sso.Config{OrganizationID: org.ID, Issuer: gofakeit.URL()}
+		provider := sso.ProviderMetadata{Issuer: c.Issuer}
+		authState := sso.AuthState{
+			OrgID:              org.ID,
+			Nonce:              gofakeit.UUID(),
+			CodeVerifier:       gofakeit.UUID(),
+			BrowserBindingHash: base.HashToken(browserBinding),
+		}
+		repo := sso.NewMockRepository(t)
+		oidcClient := sso.NewMockOIDCClient(t)
+		stateManager := sso.NewMockStateManager(t)
+		service := sso.NewService(config.Config{AppURL: appURL}, repo, oidcClient, stateManager)
+
+		stateManager.On("ConsumeState", ctx, "state").Return(authState, nil)
+		repo.On("GetConfig", ctx, org.ID).Return(c, nil)
+		oidcClient.On("Discover", ctx, c.Issuer).Return(provider, nil)
+		oidcClient.On("ExchangeCode", ctx, provider, c, "code", authState.CodeVerifier,
+			appURL+"/sso/callback?subdomain="+org.Subdomain).Return("", sso.ErrIdentityProvider)
+
+		_, err := service.Authenticate(ctx, org, "code", "state", browserBinding)
+		require.ErrorIs(t, err, sso.ErrIdentityProvider)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		claims sso.IDTokenClaims
+		err    error
+	}{
+		{
+			name:   "should return error when the email is missing",
+			claims: sso.IDTokenClaims{},
+			err:    sso.ErrEmailNotVerified,
+		},
+		{
+			name:   "should return error when the email is not verified",
+			claims: sso.IDTokenClaims{Email: "john@camelhr.com", EmailVerified: new(bool)},
+			err:    sso.ErrEmailNotVerified,
+		},
+		{
+			name:   "should return error when the email domain is not allowed",
+			claims: sso.IDTokenClaims{Email: "john@example.org"},
+			err:    sso.ErrEmailNotAllowed,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			browserBinding := gofakeit.UUID()
+			org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(10)}
+			c := sso.Config{OrganizationID: org.ID, Issuer: gofakeit.URL(), AllowedEmailDomains: "camelhr.com"}
+			provider := sso.ProviderMetadata{Issuer: c.Issuer}
+			authState := sso.AuthState{
+				OrgID:              org.ID,
+				Nonce:              gofakeit.UUID(),
+				CodeVerifier:       gofakeit.UUID(),
+				BrowserBindingHash: base.HashToken(browserBinding),
+			}
+			repo := sso.NewMockRepository(t)
+			oidcClient := sso.NewMockOIDCClient(t)
+			stateManager := sso.NewMockStateManager(t)
+			service := sso.NewService(config.Config{AppURL: appURL}, repo, oidcClient, stateManager)
+
+			stateManager.On("ConsumeState", ctx, "state").Return(authState, nil)
+			repo.On("GetConfig", ctx, org.ID).Return(c, nil)
+			oidcClient.On("Discover", ctx, c.Issuer).Return(provider, nil)
+			oidcClient.On("ExchangeCode", ctx, provider, c, "code", authState.CodeVerifier, fake.MockString).
+				Return("id_token", nil)
+			oidcClient.On("VerifyIDToken", ctx, provider, c, "id_token", authState.Nonce).Return(tc.claims, nil)
+
+			_, err := service.Authenticate(ctx, org, "code", "state", browserBinding)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	t.Run("should return the identity of the verified id token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		browserBinding := gofakeit.UUID()
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(10)}
+		c := sso.Config{
+			OrganizationID:      org.ID,
+			Issuer:              gofakeit.URL(),
+			AllowedEmailDomains: "camelhr.com",
+			JITProvisioning:     true,
+		}
+		provider := sso.ProviderMetadata{Issuer: c.Issuer}
+		authState := sso.AuthState{
+			OrgID:              org.ID,
+			Nonce:              gofakeit.UUID(),
+			CodeVerifier:       gofakeit.UUID(),
+			RememberMe:         true,
+			BrowserBindingHash: base.HashToken(browserBinding),
+		}
+		emailVerified := true
+		claims := sso.IDTokenClaims{Email: "john@camelhr.com", EmailVerified: &emailVerified}
+		claims.Subject = gofakeit.UUID()
+		repo := sso.NewMockRepository(t)
+		oidcClient := sso.NewMockOIDCClient(t)
+		stateManager := sso.NewMockStateManager(t)
+		service := sso.NewService(config.Config{AppURL: appURL}, repo, oidcClient, stateManager)
+
+		stateManager.On("ConsumeState", ctx, "state").Return(authState, nil)
+		repo.On("GetConfig", ctx, org.ID).Return(c, nil)
+		oidcClient.On("Discover", ctx, c.Issuer).Return(provider, nil)
+		oidcClient.On("ExchangeCode", ctx, provider, c, "code", authState.CodeVerifier, fake.MockString).
+			Return("id_token", nil)
+		oidcClient.On("VerifyIDToken", ctx, provider, c, "id_token", authState.Nonce).Return(claims, nil)
+
+		identity, err := service.Authenticate(ctx, org, "code", "state", browserBinding)
+		require.NoError(t, err)
+		assert.Equal(t, sso.Identity{
+			Subject:         claims.Subject,
+			Email:           claims.Email,
+			RememberMe:      true,
+			JITProvisioning: true,
+		}, identity)
+	})
+}
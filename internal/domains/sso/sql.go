@@ -0,0 +1,12 @@
+package sso
+
+import _ "embed"
+
+//go:embed sql/get_sso_config.sql
+var getSSOConfigQuery string
+
+//go:embed sql/upsert_sso_config.sql
+var upsertSSOConfigQuery string
+
+//go:embed sql/delete_sso_config.sql
+var deleteSSOConfigQuery string
@@ -0,0 +1,6 @@
+-- deleteSSOConfigQuery
+-- $1: organization_id
+DELETE FROM
+    sso_configs
+WHERE
+    organization_id = $1;
@@ -0,0 +1,15 @@
+-- getSSOConfigQuery
+-- $1: organization_id
+SELECT
+    organization_id,
+    issuer,
+    client_id,
+    client_secret,
+    allowed_email_domains,
+    jit_provisioning,
+    created_at,
+    updated_at
+FROM
+    sso_configs
+WHERE
+    organization_id = $1;
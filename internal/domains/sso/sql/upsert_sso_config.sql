@@ -0,0 +1,35 @@
+-- upsertSSOConfigQuery
+-- $1: organization_id
+-- $2: issuer
+-- $3: client_id
+-- $4: client_secret
+-- $5: allowed_email_domains
+-- $6: jit_provisioning
+INSERT INTO
+    sso_configs(
+        organization_id,
+        issuer,
+        client_id,
+        client_secret,
+        allowed_email_domains,
+        jit_provisioning
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6) ON CONFLICT (organization_id) DO
+UPDATE
+SET
+    issuer = EXCLUDED.issuer,
+    client_id = EXCLUDED.client_id,
+    client_secret = EXCLUDED.client_secret,
+    allowed_email_domains = EXCLUDED.allowed_email_domains,
+    jit_provisioning = EXCLUDED.jit_provisioning,
+    updated_at = NOW()
+RETURNING
+    organization_id,
+    issuer,
+    client_id,
+    client_secret,
+    allowed_email_domains,
+    jit_provisioning,
+    created_at,
+    updated_at;
@@ -0,0 +1,96 @@
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	stateHKeyFormat = "ssoState:%s"
+
+	orgKey            = "org"
+	nonceKey          = "nonce"
+	codeVerifierKey   = "codeVerifier"
+	rememberMeKey     = "rememberMe"
+	browserBindingKey = "browserBinding"
+)
+
+var ErrInvalidState = errors.New("sso state is invalid or expired")
+
+// StateManager is an interface for keeping track of the pending logins at the identity provider.
+// The state is bound to the nonce and the pkce code verifier of the authorization request.
+type StateManager interface {
+	// SaveState stores the pending login against the given state for the StateTTL.
+	SaveState(ctx context.Context, state string, authState AuthState) error
+
+	// ConsumeState deletes and returns the pending login of the given state.
+	// A state can be consumed only once. ErrInvalidState is returned when it is not found.
+	ConsumeState(ctx context.Context, state string) (AuthState, error)
+}
+
+type stateManager struct {
+	redisClient *redis.Client
+}
+
+func NewRedisStateManager(redisClient *redis.Client) StateManager {
+	return &stateManager{redisClient}
+}
+
+func (m *stateManager) SaveState(ctx context.Context, state string, authState AuthState) error {
+	stateHKey := fmt.Sprintf(stateHKeyFormat, base.HashToken(state))
+
+	if err := m.redisClient.HSet(ctx, stateHKey, orgKey, authState.OrgID, nonceKey, authState.Nonce,
+		codeVerifierKey, authState.CodeVerifier, rememberMeKey, authState.RememberMe,
+		browserBindingKey, authState.BrowserBindingHash).Err(); err != nil {
+		return fmt.Errorf("failed to persist sso state for org:%d: %w", authState.OrgID, err)
+	}
+
+	if err := m.redisClient.Expire(ctx, stateHKey, StateTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set sso state expiry for org:%d: %w", authState.OrgID, err)
+	}
+
+	return nil
+}
+
+func (m *stateManager) ConsumeState(ctx context.Context, state string) (AuthState, error) {
+	if state == "" {
+		return AuthState{}, ErrInvalidState
+	}
+
+	stateHKey := fmt.Sprintf(stateHKeyFormat, base.HashToken(state))
+
+	stateData, err := m.redisClient.HGetAll(ctx, stateHKey).Result()
+	if err != nil {
+		return AuthState{}, fmt.Errorf("failed to retrieve sso state: %w", err)
+	}
+
+	if len(stateData) == 0 {
+		return AuthState{}, ErrInvalidState
+	}
+
+	// only the request that deletes the state can use it
+	deleted, err := m.redisClient.Del(ctx, stateHKey).Result()
+	if err != nil {
+		return AuthState{}, fmt.Errorf("failed to delete sso state: %w", err)
+	}
+
+	if deleted == 0 {
+		return AuthState{}, ErrInvalidState
+	}
+
+	orgID, _ := strconv.ParseInt(stateData[orgKey], 10, 64)
+	rememberMe, _ := strconv.ParseBool(stateData[rememberMeKey])
+
+	return AuthState{
+		OrgID:              orgID,
+		Nonce:              stateData[nonceKey],
+		CodeVerifier:       stateData[codeVerifierKey],
+		RememberMe:         rememberMe,
+		BrowserBindingHash: stateData[browserBindingKey],
+	}, nil
+}
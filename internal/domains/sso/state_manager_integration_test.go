@@ -0,0 +1,41 @@
+package sso_test
+
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+)
+
+func (s *SSOTestSuite) TestStateManagerIntegration_ConsumeState() {
+	s.Run("should consume the state only once", func() {
+		s.T().Parallel()
+
+		ctx := context.Background()
+		state := gofakeit.UUID()
+		authState := sso.AuthState{
+			OrgID:              gofakeit.Int64(),
+			Nonce:              gofakeit.UUID(),
+			CodeVerifier:       gofakeit.UUID(),
+			RememberMe:         true,
+			BrowserBindingHash: base.HashToken(gofakeit.UUID()),
+		}
+		stateManager := sso.NewRedisStateManager(s.RedisClient)
+
+		err := stateManager.SaveState(ctx, state, authState)
+		s.Require().NoError(err)
+
+		ttl, err := s.RedisClient.TTL(ctx, "ssoState:"+base.HashToken(state)).Result()
+		s.Require().NoError(err)
+		s.Positive(ttl)
+		s.LessOrEqual(ttl, sso.StateTTL)
+
+		result, err := stateManager.ConsumeState(ctx, state)
+		s.Require().NoError(err)
+		s.Equal(authState, result)
+
+		_, err = stateManager.ConsumeState(ctx, state)
+		s.Require().ErrorIs(err, sso.ErrInvalidState)
+	})
+}
@@ -0,0 +1,141 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package sso
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockStateManager is an autogenerated mock type for the StateManager type
+type MockStateManager struct {
+	mock.Mock
+}
+
+type MockStateManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStateManager) EXPECT() *MockStateManager_Expecter {
+	return &MockStateManager_Expecter{mock: &_m.Mock}
+}
+
+// ConsumeState provides a mock function with given fields: ctx, state
+func (_m *MockStateManager) ConsumeState(ctx context.Context, state string) (AuthState, error) {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeState")
+	}
+
+	var r0 AuthState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (AuthState, error)); ok {
+		return rf(ctx, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) AuthState); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Get(0).(AuthState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStateManager_ConsumeState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeState'
+type MockStateManager_ConsumeState_Call struct {
+	*mock.Call
+}
+
+// ConsumeState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state string
+func (_e *MockStateManager_Expecter) ConsumeState(ctx interface{}, state interface{}) *MockStateManager_ConsumeState_Call {
+	return &MockStateManager_ConsumeState_Call{Call: _e.mock.On("ConsumeState", ctx, state)}
+}
+
+func (_c *MockStateManager_ConsumeState_Call) Run(run func(ctx context.Context, state string)) *MockStateManager_ConsumeState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStateManager_ConsumeState_Call) Return(_a0 AuthState, _a1 error) *MockStateManager_ConsumeState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStateManager_ConsumeState_Call) RunAndReturn(run func(context.Context, string) (AuthState, error)) *MockStateManager_ConsumeState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveState provides a mock function with given fields: ctx, state, authState
+func (_m *MockStateManager) SaveState(ctx context.Context, state string, authState AuthState) error {
+	ret := _m.Called(ctx, state, authState)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, AuthState) error); ok {
+		r0 = rf(ctx, state, authState)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStateManager_SaveState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveState'
+type MockStateManager_SaveState_Call struct {
+	*mock.Call
+}
+
+// SaveState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state string
+//   - authState AuthState
+func (_e *MockStateManager_Expecter) SaveState(ctx interface{}, state interface{}, authState interface{}) *MockStateManager_SaveState_Call {
+	return &MockStateManager_SaveState_Call{Call: _e.mock.On("SaveState", ctx, state, authState)}
+}
+
+func (_c *MockStateManager_SaveState_Call) Run(run func(ctx context.Context, state string, authState AuthState)) *MockStateManager_SaveState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(AuthState))
+	})
+	return _c
+}
+
+func (_c *MockStateManager_SaveState_Call) Return(_a0 error) *MockStateManager_SaveState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStateManager_SaveState_Call) RunAndReturn(run func(context.Context, string, AuthState) error) *MockStateManager_SaveState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStateManager creates a new instance of MockStateManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStateManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStateManager {
+	mock := &MockStateManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,127 @@
+package sso_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateManager_SaveState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when redis call fails", func(t *testing.T) {
+		t.Parallel()
+
+		state := gofakeit.UUID()
+		authState := sso.AuthState{OrgID: gofakeit.Int64(), Nonce: gofakeit.UUID(), CodeVerifier: gofakeit.UUID()}
+		redisClient, redisClientMock := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		redisClientMock.ExpectHSet("ssoState:"+base.HashToken(state), "org", authState.OrgID, "nonce",
+			authState.Nonce, "codeVerifier", authState.CodeVerifier, "rememberMe", false, "browserBinding", "").
+			SetErr(assert.AnError)
+
+		err := stateManager.SaveState(context.Background(), state, authState)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should store the hashed state with expiry", func(t *testing.T) {
+		t.Parallel()
+
+		state := gofakeit.UUID()
+		authState := sso.AuthState{
+			OrgID:              gofakeit.Int64(),
+			Nonce:              gofakeit.UUID(),
+			CodeVerifier:       gofakeit.UUID(),
+			RememberMe:         true,
+			BrowserBindingHash: base.HashToken(gofakeit.UUID()),
+		}
+		stateHKey := "ssoState:" + base.HashToken(state)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		redisClientMock.ExpectHSet(stateHKey, "org", authState.OrgID, "nonce", authState.Nonce, "codeVerifier",
+			authState.CodeVerifier, "rememberMe", true, "browserBinding", authState.BrowserBindingHash).SetVal(5)
+		redisClientMock.ExpectExpire(stateHKey, sso.StateTTL).SetVal(true)
+
+		err := stateManager.SaveState(context.Background(), state, authState)
+		require.NoError(t, err)
+		require.NoError(t, redisClientMock.ExpectationsWereMet())
+	})
+}
+
+func TestStateManager_ConsumeState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when state is empty", func(t *testing.T) {
+		t.Parallel()
+
+		redisClient, _ := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		_, err := stateManager.ConsumeState(context.Background(), "")
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	t.Run("should return error when state is not found", func(t *testing.T) {
+		t.Parallel()
+
+		state := gofakeit.UUID()
+		redisClient, redisClientMock := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		redisClientMock.ExpectHGetAll("ssoState:" + base.HashToken(state)).SetVal(map[string]string{})
+
+		_, err := stateManager.ConsumeState(context.Background(), state)
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	t.Run("should return error when state is consumed concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		state := gofakeit.UUID()
+		stateHKey := "ssoState:" + base.HashToken(state)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(stateHKey).SetVal(map[string]string{"org": "1"})
+		redisClientMock.ExpectDel(stateHKey).SetVal(0)
+
+		_, err := stateManager.ConsumeState(context.Background(), state)
+		require.ErrorIs(t, err, sso.ErrInvalidState)
+	})
+
+	t.Run("should delete and return the state", func(t *testing.T) {
+		t.Parallel()
+
+		state := gofakeit.UUID()
+		stateHKey := "ssoState:" + base.HashToken(state)
+		redisClient, redisClientMock := redismock.NewClientMock()
+		stateManager := sso.NewRedisStateManager(redisClient)
+
+		redisClientMock.ExpectHGetAll(stateHKey).SetVal(map[string]string{
+			"org":            "42",
+			"nonce":          "nonce",
+			"codeVerifier":   "verifier",
+			"rememberMe":     "1",
+			"browserBinding": "binding",
+		})
+		redisClientMock.ExpectDel(stateHKey).SetVal(1)
+
+		result, err := stateManager.ConsumeState(context.Background(), state)
+		require.NoError(t, err)
+		assert.Equal(t, sso.AuthState{
+			OrgID:              42,
+			Nonce:              "nonce",
+			CodeVerifier:       "verifier",
+			RememberMe:         true,
+			BrowserBindingHash: "binding",
+		}, result)
+	})
+}
@@ -0,0 +1,127 @@
+package sso
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// StateTTL is the time duration within which the login at the identity provider must be completed.
+	StateTTL = 10 * time.Minute
+
+	// emailDomainsSeparator separates the allowed email domains stored in a single column.
+	emailDomainsSeparator = ","
+)
+
+// Config represents the openid connect identity provider of an organization.
+type Config struct {
+	// OrganizationID is the reference to the organization the configuration belongs to.
+	OrganizationID int64 `db:"organization_id"`
+
+	// Issuer is the issuer url of the identity provider. It is used to discover the provider endpoints.
+	Issuer string `db:"issuer"`
+
+	// ClientID is the client id of camelhr registered at the identity provider.
+	ClientID string `db:"client_id"`
+
+	// ClientSecret is the client secret of camelhr registered at the identity provider.
+	ClientSecret string `db:"client_secret"`
+
+	// AllowedEmailDomains is the comma separated list of the email domains allowed to login.
+	AllowedEmailDomains string `db:"allowed_email_domains"`
+
+	// JITProvisioning represents whether a user is created on the first login
+	// when the email is not found in the organization.
+	JITProvisioning bool `db:"jit_provisioning"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// EmailDomains returns the list of the email domains allowed to login.
+func (c Config) EmailDomains() []string {
+	if c.AllowedEmailDomains == "" {
+		return []string{}
+	}
+
+	return strings.Split(c.AllowedEmailDomains, emailDomainsSeparator)
+}
+
+// IsEmailAllowed returns whether the domain of the given email is one of the allowed email domains.
+func (c Config) IsEmailAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range c.EmailDomains() {
+		if d == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthState represents a pending login at the identity provider.
+// It is stored against the state parameter of the authorization request.
+type AuthState struct {
+	// OrgID is the organization the login was started for.
+	OrgID int64
+
+	// Nonce is the value the id token must be bound to.
+	Nonce string
+
+	// CodeVerifier is the pkce secret the authorization code is exchanged with.
+	CodeVerifier string
+
+	// RememberMe represents whether the remember me was requested when the login started.
+	RememberMe bool
+
+	// BrowserBindingHash is the hash of the browser binding held by the browser that started the login.
+	BrowserBindingHash string
+}
+
+// Identity represents the user authenticated by the identity provider.
+type Identity struct {
+	// Subject is the unique identifier of the user at the identity provider.
+	Subject string
+
+	// Email is the verified email address of the user.
+	Email string
+
+	// RememberMe represents whether the remember me was requested when the login started.
+	RememberMe bool
+
+	// JITProvisioning represents whether the user should be created when not found in the organization.
+	JITProvisioning bool
+}
+
+// ProviderMetadata represents the openid connect discovery document of the identity provider.
+type ProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// ConfigRequest represents the request payload to configure the sso of the organization.
+type ConfigRequest struct {
+	Issuer              string   `json:"issuer" validate:"required,url,max=255"`
+	ClientID            string   `json:"client_id" validate:"required,max=255"`
+	ClientSecret        string   `json:"client_secret" validate:"required,max=255"`
+	AllowedEmailDomains []string `json:"allowed_email_domains" validate:"required,min=1,dive,fqdn"`
+	JITProvisioning     bool     `json:"jit_provisioning"`
+}
+
+// ConfigResponse represents the response payload of the sso configuration. The client secret is never returned.
+type ConfigResponse struct {
+	Issuer              string   `json:"issuer"`
+	ClientID            string   `json:"client_id"`
+	AllowedEmailDomains []string `json:"allowed_email_domains"`
+	JITProvisioning     bool     `json:"jit_provisioning"`
+
+	// RedirectURI is the uri to be registered at the identity provider.
+	RedirectURI string `json:"redirect_uri"`
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *handler {
+	return &handler{service}
+}
+
+// GetProfile returns the profile of the authenticated user.
+func (h *handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := request.UserID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	u, err := h.service.GetUserByID(r.Context(), userID)
+	if err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toProfileResponse(u))
+}
+
+// ChangePassword changes the password of the authenticated user.
+// The user is signed out of the other sessions.
+func (h *handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, err := request.UserID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+		return
+	}
+
+	sessionID, err := request.SessionID(r)
+	if err != nil {
+		response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+
+		return
+	}
+
+	var reqPayload ChangePasswordRequest
+	if err := request.DecodeAndValidateJSON(r.Body, &reqPayload); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	err = h.service.ChangePassword(r.Context(), userID, sessionID, reqPayload.CurrentPassword,
+		reqPayload.NewPassword)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCurrentPassword) {
+			response.ErrorResponse(w, base.WrapError(err, base.ErrorHTTPStatus(http.StatusBadRequest)))
+			return
+		}
+
+		response.ErrorResponse(w, err)
+
+		return
+	}
+
+	response.Empty(w, http.StatusOK)
+}
+
+func toProfileResponse(u User) ProfileResponse {
+	return ProfileResponse{
+		ID:              u.ID,
+		OrganizationID:  u.OrganizationID,
+		Email:           u.Email,
+		IsOwner:         u.IsOwner,
+		RoleID:          u.RoleID,
+		IsEmailVerified: u.IsEmailVerified,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+}
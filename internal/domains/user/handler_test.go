@@ -0,0 +1,137 @@
+package user_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mePath = "/api/v1/subdomains/{subdomain}/me"
+
+func TestHandler_GetProfile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the user is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodGet, mePath, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler := user.NewHandler(user.NewMockService(t))
+
+		handler.GetProfile(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return the profile of the authenticated user", func(t *testing.T) {
+		t.Parallel()
+
+		u := user.User{
+			ID:              gofakeit.Int64(),
+			OrganizationID:  gofakeit.Int64(),
+			Email:           gofakeit.Email(),
+			PasswordHash:    gofakeit.UUID(),
+			RoleID:          gofakeit.Int64(),
+			IsEmailVerified: true,
+		}
+		req, err := http.NewRequest(http.MethodGet, mePath, nil)
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, u.ID, u.OrganizationID)
+		req = tests.WithSessionID(req, gofakeit.UUID())
+
+		mockService := user.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := user.NewHandler(mockService)
+
+		mockService.On("GetUserByID", fake.MockContext, u.ID).Return(u, nil)
+
+		handler.GetProfile(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), u.PasswordHash)
+
+		var result user.ProfileResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, u.ID, result.ID)
+		assert.Equal(t, u.Email, result.Email)
+		assert.Equal(t, u.RoleID, result.RoleID)
+		assert.True(t, result.IsEmailVerified)
+	})
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return bad request when the current password is missing", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequest(http.MethodPut, mePath+"/password", strings.NewReader(`{"new_password":"x"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, gofakeit.Int64(), gofakeit.Int64())
+		req = tests.WithSessionID(req, gofakeit.UUID())
+
+		rr := httptest.NewRecorder()
+		handler := user.NewHandler(user.NewMockService(t))
+
+		handler.ChangePassword(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return bad request when the current password is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPut, mePath+"/password",
+			strings.NewReader(`{"current_password":"Wrong@123","new_password":"Password@123"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+		req = tests.WithSessionID(req, sessionID)
+
+		mockService := user.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := user.NewHandler(mockService)
+
+		mockService.On("ChangePassword", fake.MockContext, userID, sessionID, "Wrong@123", "Password@123").
+			Return(user.ErrInvalidCurrentPassword)
+
+		handler.ChangePassword(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should change the password of the authenticated user", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		sessionID := gofakeit.UUID()
+		req, err := http.NewRequest(http.MethodPut, mePath+"/password",
+			strings.NewReader(`{"current_password":"Current@123","new_password":"Password@123"}`))
+		require.NoError(t, err)
+		req = tests.WithAuthContext(req, userID, gofakeit.Int64())
+		req = tests.WithSessionID(req, sessionID)
+
+		mockService := user.NewMockService(t)
+		rr := httptest.NewRecorder()
+		handler := user.NewHandler(mockService)
+
+		mockService.On("ChangePassword", fake.MockContext, userID, sessionID, "Current@123", "Password@123").
+			Return(nil)
+
+		handler.ChangePassword(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,154 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+
+	argon2idPrefix    = "$argon2id$"
+	argon2idHashParts = 6
+	bcryptPrefix      = "$2"
+)
+
+var ErrUnsupportedPasswordHash = errors.New("unsupported password hash")
+
+// PasswordHasher is an interface for hashing and verifying the passwords of the users.
+// The passwords are hashed using argon2id encoded in the PHC string format.
+// The legacy bcrypt hashes are still verified so that they can be upgraded on the next successful login.
+type PasswordHasher interface {
+	// Hash returns the encoded hash of the password using argon2id with the configured parameters.
+	Hash(password string) (string, error)
+
+	// Verify reports whether the password matches the encoded hash.
+	// An empty hash never matches any password. ErrUnsupportedPasswordHash is returned for unknown formats.
+	Verify(encodedHash, password string) (bool, error)
+
+	// NeedsRehash reports whether the encoded hash was generated using another algorithm
+	// or other parameters than the configured ones.
+	NeedsRehash(encodedHash string) bool
+}
+
+// argon2idParams are the tunable parameters of argon2id.
+type argon2idParams struct {
+	memory      uint32 // in KiB
+	iterations  uint32
+	parallelism uint8
+}
+
+type passwordHasher struct {
+	params argon2idParams
+}
+
+// NewArgon2idPasswordHasher creates a new argon2id password hasher using the parameters of the config.
+// The parameters are validated when the config is loaded.
+func NewArgon2idPasswordHasher(conf config.Config) PasswordHasher {
+	return &passwordHasher{argon2idParams{
+		memory:      uint32(conf.PasswordArgon2Memory),
+		iterations:  uint32(conf.PasswordArgon2Iterations),
+		parallelism: uint8(conf.PasswordArgon2Parallelism),
+	}}
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	// a new random salt is generated for each password
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate password salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.iterations, h.params.memory, h.params.parallelism,
+		argon2idKeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.params.memory, h.params.iterations, h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *passwordHasher) Verify(encodedHash, password string) (bool, error) {
+	switch {
+	case encodedHash == "":
+		// users authenticated by an external identity provider have no password
+		return false, nil
+	case strings.HasPrefix(encodedHash, bcryptPrefix):
+		err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("failed to verify bcrypt password hash: %w", err)
+		}
+
+		return true, nil
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return false, err
+		}
+
+		otherKey := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism,
+			uint32(len(key)))
+
+		// compare in constant time to prevent timing attacks
+		return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+	default:
+		return false, ErrUnsupportedPasswordHash
+	}
+}
+
+func (h *passwordHasher) NeedsRehash(encodedHash string) bool {
+	if encodedHash == "" {
+		return false
+	}
+
+	params, _, _, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params != h.params
+}
+
+// decodeArgon2idHash decodes the parameters, the salt and the key of an argon2id hash in the PHC string format.
+// e.g. $argon2id$v=19$m=19456,t=2,p=1$<base64 salt>$<base64 key>.
+func decodeArgon2idHash(encodedHash string) (argon2idParams, []byte, []byte, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != argon2idHashParts || !strings.HasPrefix(encodedHash, argon2idPrefix) {
+		return argon2idParams{}, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2idParams{}, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	var params argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations,
+		&params.parallelism); err != nil {
+		return argon2idParams{}, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2idParams{}, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery v2.43.1. DO NOT EDIT.
+
+package user
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockPasswordHasher is an autogenerated mock type for the PasswordHasher type
+type MockPasswordHasher struct {
+	mock.Mock
+}
+
+type MockPasswordHasher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasswordHasher) EXPECT() *MockPasswordHasher_Expecter {
+	return &MockPasswordHasher_Expecter{mock: &_m.Mock}
+}
+
+// Hash provides a mock function with given fields: password
+func (_m *MockPasswordHasher) Hash(password string) (string, error) {
+	ret := _m.Called(password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Hash")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(password)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPasswordHasher_Hash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hash'
+type MockPasswordHasher_Hash_Call struct {
+	*mock.Call
+}
+
+// Hash is a helper method to define mock.On call
+//   - password string
+func (_e *MockPasswordHasher_Expecter) Hash(password interface{}) *MockPasswordHasher_Hash_Call {
+	return &MockPasswordHasher_Hash_Call{Call: _e.mock.On("Hash", password)}
+}
+
+func (_c *MockPasswordHasher_Hash_Call) Run(run func(password string)) *MockPasswordHasher_Hash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordHasher_Hash_Call) Return(_a0 string, _a1 error) *MockPasswordHasher_Hash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPasswordHasher_Hash_Call) RunAndReturn(run func(string) (string, error)) *MockPasswordHasher_Hash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NeedsRehash provides a mock function with given fields: encodedHash
+func (_m *MockPasswordHasher) NeedsRehash(encodedHash string) bool {
+	ret := _m.Called(encodedHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(encodedHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockPasswordHasher_NeedsRehash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsRehash'
+type MockPasswordHasher_NeedsRehash_Call struct {
+	*mock.Call
+}
+
+// NeedsRehash is a helper method to define mock.On call
+//   - encodedHash string
+func (_e *MockPasswordHasher_Expecter) NeedsRehash(encodedHash interface{}) *MockPasswordHasher_NeedsRehash_Call {
+	return &MockPasswordHasher_NeedsRehash_Call{Call: _e.mock.On("NeedsRehash", encodedHash)}
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) Run(run func(encodedHash string)) *MockPasswordHasher_NeedsRehash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) Return(_a0 bool) *MockPasswordHasher_NeedsRehash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) RunAndReturn(run func(string) bool) *MockPasswordHasher_NeedsRehash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: encodedHash, password
+func (_m *MockPasswordHasher) Verify(encodedHash string, password string) (bool, error) {
+	ret := _m.Called(encodedHash, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(encodedHash, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(encodedHash, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(encodedHash, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPasswordHasher_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockPasswordHasher_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - encodedHash string
+//   - password string
+func (_e *MockPasswordHasher_Expecter) Verify(encodedHash interface{}, password interface{}) *MockPasswordHasher_Verify_Call {
+	return &MockPasswordHasher_Verify_Call{Call: _e.mock.On("Verify", encodedHash, password)}
+}
+
+func (_c *MockPasswordHasher_Verify_Call) Run(run func(encodedHash string, password string)) *MockPasswordHasher_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordHasher_Verify_Call) Return(_a0 bool, _a1 error) *MockPasswordHasher_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPasswordHasher_Verify_Call) RunAndReturn(run func(string, string) (bool, error)) *MockPasswordHasher_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPasswordHasher creates a new instance of MockPasswordHasher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordHasher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordHasher {
+	mock := &MockPasswordHasher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,152 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher_Hash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should encode the argon2id hash in the phc string format", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := user.NewArgon2idPasswordHasher(config.Config{
+			PasswordArgon2Memory:      128,
+			PasswordArgon2Iterations:  2,
+			PasswordArgon2Parallelism: 1,
+		})
+
+		passwordHash, err := hasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(passwordHash, "$argon2id$v=19$m=128,t=2,p=1$"))
+		assert.Len(t, strings.Split(passwordHash, "$"), 6)
+	})
+
+	t.Run("should generate a new salt for each hash", func(t *testing.T) {
+		t.Parallel()
+
+		hash1, err := passwordHasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+
+		hash2, err := passwordHasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, hash1, hash2)
+	})
+}
+
+func TestPasswordHasher_Verify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should verify the argon2id hash", func(t *testing.T) {
+		t.Parallel()
+
+		passwordHash, err := passwordHasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+
+		matched, err := passwordHasher.Verify(passwordHash, "@paSSw0rd")
+		require.NoError(t, err)
+		assert.True(t, matched)
+
+		matched, err = passwordHasher.Verify(passwordHash, "@paSSw0rD")
+		require.NoError(t, err)
+		assert.False(t, matched)
+	})
+
+	t.Run("should verify the argon2id hash generated with other parameters", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := user.NewArgon2idPasswordHasher(config.Config{
+			PasswordArgon2Memory:      128,
+			PasswordArgon2Iterations:  2,
+			PasswordArgon2Parallelism: 2,
+		})
+
+		passwordHash, err := hasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+
+		matched, err := passwordHasher.Verify(passwordHash, "@paSSw0rd")
+		require.NoError(t, err)
+		assert.True(t, matched)
+	})
+
+	t.Run("should verify the legacy bcrypt hash", func(t *testing.T) {
+		t.Parallel()
+
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte("@paSSw0rd"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		matched, err := passwordHasher.Verify(string(passwordHash), "@paSSw0rd")
+		require.NoError(t, err)
+		assert.True(t, matched)
+
+		matched, err = passwordHasher.Verify(string(passwordHash), "@paSSw0rD")
+		require.NoError(t, err)
+		assert.False(t, matched)
+	})
+
+	t.Run("should not match any password when the hash is empty", func(t *testing.T) {
+		t.Parallel()
+
+		matched, err := passwordHasher.Verify("", "")
+		require.NoError(t, err)
+		assert.False(t, matched)
+	})
+
+	t.Run("should return error when the hash format is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		for _, passwordHash := range []string{
+			"plaintext",
+			"$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		} {
+			_, err := passwordHasher.Verify(passwordHash, "@paSSw0rd")
+			require.ErrorIs(t, err, user.ErrUnsupportedPasswordHash, passwordHash)
+		}
+	})
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return true for the legacy bcrypt hash", func(t *testing.T) {
+		t.Parallel()
+
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte("@paSSw0rd"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		assert.True(t, passwordHasher.NeedsRehash(string(passwordHash)))
+	})
+
+	t.Run("should return true when the argon2id parameters have changed", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := user.NewArgon2idPasswordHasher(config.Config{
+			PasswordArgon2Memory:      128,
+			PasswordArgon2Iterations:  1,
+			PasswordArgon2Parallelism: 1,
+		})
+
+		passwordHash, err := hasher.Hash("@paSSw0rd")
+		require.NoError(t, err)
+
+		assert.True(t, passwordHasher.NeedsRehash(passwordHash))
+		assert.False(t, hasher.NeedsRehash(passwordHash))
+	})
+
+	t.Run("should return false when the hash is empty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, passwordHasher.NeedsRehash(""))
+	})
+}
@@ -11,24 +11,28 @@ type Repository interface {
 	// GetUserByID returns a user by its ID.
 	GetUserByID(ctx context.Context, id int64) (User, error)
 
-	// GetUserByAPIToken returns a user by its API token.
-	GetUserByAPIToken(ctx context.Context, apiToken string) (User, error)
-
-	// GetUserByOrgSubdomainAPIToken returns a user of organization by its org subdomain and api token.
-	GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain, apiToken string) (User, error)
-
 	// GetUserByOrgIDEmail returns a user of organization by its org id and email.
 	GetUserByOrgIDEmail(ctx context.Context, orgID int64, email string) (User, error)
 
 	// GetUserByOrgSubdomainEmail returns a user of organization by its org subdomain and email.
 	GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain, email string) (User, error)
 
+	// GetDeletedOwnerByOrgID returns the soft deleted owner of an organization by its org id.
+	GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error)
+
 	// CreateUser creates a new user.
 	CreateUser(ctx context.Context, orgID int64, email, passwordHash string, isOwner bool) (User, error)
 
 	// ResetPassword resets the password of a user.
+	// The previous password hash is added to the password history of the user.
 	ResetPassword(ctx context.Context, id int64, passwordHash string) error
 
+	// UpdatePasswordHash replaces the password hash of a user with another hash of the same password.
+	UpdatePasswordHash(ctx context.Context, id int64, passwordHash string) error
+
+	// ListPasswordHistory returns the given number of the most recent previous password hashes of a user.
+	ListPasswordHistory(ctx context.Context, id int64, limit int) ([]string, error)
+
 	// DeleteUser deletes a user by its ID.
 	DeleteUser(ctx context.Context, id int64, comment string) error
 
@@ -38,14 +42,18 @@ type Repository interface {
 	// EnableUser enables a user by its ID.
 	EnableUser(ctx context.Context, id int64, comment string) error
 
-	// GenerateAPIToken generates a new API token for a user.
-	GenerateAPIToken(ctx context.Context, id int64) error
-
-	// ResetAPIToken resets the API token of a user.
-	ResetAPIToken(ctx context.Context, id int64) error
-
 	// SetEmailVerified sets the email_verified flag of a user.
 	SetEmailVerified(ctx context.Context, id int64) error
+
+	// SetRole sets the role of a user.
+	SetRole(ctx context.Context, id, roleID int64) error
+
+	// SetOwner sets the is_owner flag of a user along with the owner role.
+	// The admin role is assigned to the user when the flag is cleared.
+	SetOwner(ctx context.Context, id int64, isOwner bool) error
+
+	// ChangeEmail changes the email of a user and marks it as verified.
+	ChangeEmail(ctx context.Context, id int64, email string) error
 }
 
 type repository struct {
@@ -63,30 +71,23 @@ func (r *repository) GetUserByID(ctx context.Context, id int64) (User, error) {
 	return user, err
 }
 
-func (r *repository) GetUserByAPIToken(ctx context.Context, apiToken string) (User, error) {
-	var user User
-	err := r.db.Get(ctx, &user, getUserByAPITokenQuery, apiToken)
-
-	return user, err
-}
-
-func (r *repository) GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain, apiToken string) (User, error) {
+func (r *repository) GetUserByOrgIDEmail(ctx context.Context, orgID int64, email string) (User, error) {
 	var user User
-	err := r.db.Get(ctx, &user, getUserByOrgSubdomainAPITokenQuery, orgSubdomain, apiToken)
+	err := r.db.Get(ctx, &user, getUserByOrgIDEmailQuery, orgID, email)
 
 	return user, err
 }
 
-func (r *repository) GetUserByOrgIDEmail(ctx context.Context, orgID int64, email string) (User, error) {
+func (r *repository) GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain, email string) (User, error) {
 	var user User
-	err := r.db.Get(ctx, &user, getUserByOrgIDEmailQuery, orgID, email)
+	err := r.db.Get(ctx, &user, getUserByOrgSubdomainEmailQuery, orgSubdomain, email)
 
 	return user, err
 }
 
-func (r *repository) GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain, email string) (User, error) {
+func (r *repository) GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error) {
 	var user User
-	err := r.db.Get(ctx, &user, getUserByOrgSubdomainEmailQuery, orgSubdomain, email)
+	err := r.db.Get(ctx, &user, getDeletedOwnerByOrgIDQuery, orgID)
 
 	return user, err
 }
@@ -102,6 +103,17 @@ func (r *repository) ResetPassword(ctx context.Context, id int64, passwordHash s
 	return r.db.Exec(ctx, nil, resetPasswordQuery, id, passwordHash)
 }
 
+func (r *repository) UpdatePasswordHash(ctx context.Context, id int64, passwordHash string) error {
+	return r.db.Exec(ctx, nil, updatePasswordHashQuery, id, passwordHash)
+}
+
+func (r *repository) ListPasswordHistory(ctx context.Context, id int64, limit int) ([]string, error) {
+	var passwordHashes []string
+	err := r.db.List(ctx, &passwordHashes, listPasswordHistoryQuery, id, limit)
+
+	return passwordHashes, err
+}
+
 func (r *repository) DeleteUser(ctx context.Context, id int64, comment string) error {
 	return r.db.Exec(ctx, nil, deleteUserQuery, id, comment)
 }
@@ -114,14 +126,18 @@ func (r *repository) EnableUser(ctx context.Context, id int64, comment string) e
 	return r.db.Exec(ctx, nil, enableUserQuery, id, comment)
 }
 
-func (r *repository) GenerateAPIToken(ctx context.Context, id int64) error {
-	return r.db.Exec(ctx, nil, generateAPITokenQuery, id)
+func (r *repository) SetEmailVerified(ctx context.Context, id int64) error {
+	return r.db.Exec(ctx, nil, setEmailVerifiedQuery, id)
+}
+
+func (r *repository) SetRole(ctx context.Context, id, roleID int64) error {
+	return r.db.Exec(ctx, nil, setUserRoleQuery, id, roleID)
 }
 
-func (r *repository) ResetAPIToken(ctx context.Context, id int64) error {
-	return r.db.Exec(ctx, nil, resetAPITokenQuery, id)
+func (r *repository) SetOwner(ctx context.Context, id int64, isOwner bool) error {
+	return r.db.Exec(ctx, nil, setUserOwnerQuery, id, isOwner)
 }
 
-func (r *repository) SetEmailVerified(ctx context.Context, id int64) error {
-	return r.db.Exec(ctx, nil, setEmailVerifiedQuery, id)
+func (r *repository) ChangeEmail(ctx context.Context, id int64, email string) error {
+	return r.db.Exec(ctx, nil, changeEmailQuery, id, email)
 }
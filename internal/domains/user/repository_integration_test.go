@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 )
@@ -85,24 +86,23 @@ func (s *UserTestSuite) TestRepositoryIntegration_GetUserByID() {
 	})
 }
 
-func (s *UserTestSuite) TestRepositoryIntegration_GetUserByAPIToken() {
-	s.Run("should return user by api token", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgIDEmail() {
+	s.Run("should return user by org id and email", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
 
-		result, err := repo.GetUserByAPIToken(context.Background(), *u.APIToken)
+		result, err := repo.GetUserByOrgIDEmail(context.Background(), o.ID, u.Email)
 		s.Require().NoError(err)
 		s.Equal(u.User, result)
 	})
 
-	s.Run("should return error when user does not exist for api token", func() {
+	s.Run("should return error when user does not exist", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 
-		_, err := repo.GetUserByAPIToken(context.Background(), gofakeit.UUID())
+		_, err := repo.GetUserByOrgIDEmail(context.Background(), 0, gofakeit.Email())
 		s.Require().Error(err)
 		s.ErrorIs(err, sql.ErrNoRows)
 	})
@@ -111,71 +111,21 @@ func (s *UserTestSuite) TestRepositoryIntegration_GetUserByAPIToken() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		u := fake.NewUser(s.DB, fake.NewOrganization(s.DB).ID, fake.UserDeleted())
-		s.Require().NotNil(u.APIToken)
-
-		_, err := repo.GetUserByAPIToken(context.Background(), *u.APIToken)
-		s.Require().Error(err)
-		s.ErrorIs(err, sql.ErrNoRows)
-	})
-}
-
-func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgSubdomainAPIToken() {
-	s.Run("should return user by org subdomain and api token", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
-
-		result, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), o.Subdomain, *u.APIToken)
-		s.Require().NoError(err)
-		s.Equal(u.User, result)
-	})
-
-	s.Run("should return error when user does not exist for api token", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		o := fake.NewOrganization(s.DB)
-
-		_, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), o.Subdomain, gofakeit.UUID())
-		s.Require().Error(err)
-		s.ErrorIs(err, sql.ErrNoRows)
-	})
-
-	s.Run("should return error when user does not exist for subdomain", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		o1 := fake.NewOrganization(s.DB)
-		o2 := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o1.ID)
-		s.Require().NotNil(u.APIToken)
-
-		_, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), o2.Subdomain, *u.APIToken)
-		s.Require().Error(err)
-		s.ErrorIs(err, sql.ErrNoRows)
-	})
-
-	s.Run("should return error when user is deleted", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted())
-		s.Require().NotNil(u.APIToken)
 
-		_, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), o.Subdomain, *u.APIToken)
+		_, err := repo.GetUserByOrgIDEmail(context.Background(), u.OrganizationID, u.Email)
 		s.Require().Error(err)
 		s.ErrorIs(err, sql.ErrNoRows)
 	})
 }
 
-func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgIDEmail() {
-	s.Run("should return user by org id and email", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgSubdomainEmail() {
+	s.Run("should return user by org subdomain and email", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 
-		result, err := repo.GetUserByOrgIDEmail(context.Background(), o.ID, u.Email)
+		result, err := repo.GetUserByOrgSubdomainEmail(context.Background(), o.Subdomain, u.Email)
 		s.Require().NoError(err)
 		s.Equal(u.User, result)
 	})
@@ -184,7 +134,7 @@ func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgIDEmail() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 
-		_, err := repo.GetUserByOrgIDEmail(context.Background(), 0, gofakeit.Email())
+		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "invalid-subdomain", gofakeit.Email())
 		s.Require().Error(err)
 		s.ErrorIs(err, sql.ErrNoRows)
 	})
@@ -194,39 +144,34 @@ func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgIDEmail() {
 		repo := user.NewRepository(s.DB)
 		u := fake.NewUser(s.DB, fake.NewOrganization(s.DB).ID, fake.UserDeleted())
 
-		_, err := repo.GetUserByOrgIDEmail(context.Background(), u.OrganizationID, u.Email)
+		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "invalid-subdomain", u.Email)
 		s.Require().Error(err)
 		s.ErrorIs(err, sql.ErrNoRows)
 	})
 }
 
-func (s *UserTestSuite) TestRepositoryIntegration_GetUserByOrgSubdomainEmail() {
-	s.Run("should return user by org subdomain and email", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_GetDeletedOwnerByOrgID() {
+	s.Run("should return the deleted owner of the organization", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
+		fake.NewUser(s.DB, o.ID, fake.UserDeleted())
+		u := fake.NewUser(s.DB, o.ID, fake.UserIsOwner(), fake.UserDeleted())
 
-		result, err := repo.GetUserByOrgSubdomainEmail(context.Background(), o.Subdomain, u.Email)
+		result, err := repo.GetDeletedOwnerByOrgID(context.Background(), o.ID)
 		s.Require().NoError(err)
-		s.Equal(u.User, result)
-	})
-
-	s.Run("should return error when user does not exist", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-
-		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "invalid-subdomain", gofakeit.Email())
-		s.Require().Error(err)
-		s.ErrorIs(err, sql.ErrNoRows)
+		s.Equal(u.ID, result.ID)
+		s.True(result.IsOwner)
+		s.NotNil(result.DeletedAt)
 	})
 
-	s.Run("should return error when user is deleted", func() {
+	s.Run("should return error when the owner is not deleted", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		u := fake.NewUser(s.DB, fake.NewOrganization(s.DB).ID, fake.UserDeleted())
+		o := fake.NewOrganization(s.DB)
+		fake.NewUser(s.DB, o.ID, fake.UserIsOwner())
 
-		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "invalid-subdomain", u.Email)
+		_, err := repo.GetDeletedOwnerByOrgID(context.Background(), o.ID)
 		s.Require().Error(err)
 		s.ErrorIs(err, sql.ErrNoRows)
 	})
@@ -250,7 +195,6 @@ func (s *UserTestSuite) TestRepositoryIntegration_CreateUser() {
 		s.Equal(u.PasswordHash, result.PasswordHash)
 		s.False(result.IsOwner)
 		s.False(result.IsEmailVerified) // email is not verified by default
-		s.Nil(result.APIToken)
 		s.Nil(result.DisabledAt)
 		s.Nil(result.Comment)
 		s.NotZero(result.CreatedAt)
@@ -337,6 +281,56 @@ func (s *UserTestSuite) TestRepositoryIntegration_ResetPassword() {
 		s.Equal(time.UTC, result.UpdatedAt.Location())
 		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
 		s.GreaterOrEqual(result.UpdatedAt.Unix(), result.CreatedAt.Unix())
+		s.WithinDuration(time.Now().UTC(), result.PasswordChangedAt, 1*time.Minute)
+
+		// the previous password hash is kept in the history
+		history, err := repo.ListPasswordHistory(context.Background(), u.ID, 5)
+		s.Require().NoError(err)
+		s.Equal([]string{u.PasswordHash}, history)
+	})
+}
+
+func (s *UserTestSuite) TestRepositoryIntegration_UpdatePasswordHash() {
+	s.Run("should replace the hash without updating the history", func() {
+		s.T().Parallel()
+		repo := user.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		newPasswordHash := gofakeit.UUID()
+
+		before, err := repo.GetUserByID(context.Background(), u.ID)
+		s.Require().NoError(err)
+
+		err = repo.UpdatePasswordHash(context.Background(), u.ID, newPasswordHash)
+		s.Require().NoError(err)
+
+		result, err := repo.GetUserByID(context.Background(), u.ID)
+		s.Require().NoError(err)
+		s.Equal(newPasswordHash, result.PasswordHash)
+		s.Equal(before.PasswordChangedAt, result.PasswordChangedAt)
+
+		history, err := repo.ListPasswordHistory(context.Background(), u.ID, 5)
+		s.Require().NoError(err)
+		s.Empty(history)
+	})
+}
+
+func (s *UserTestSuite) TestRepositoryIntegration_ListPasswordHistory() {
+	s.Run("should return the most recent password hashes up to the limit", func() {
+		s.T().Parallel()
+		repo := user.NewRepository(s.DB)
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		hashes := []string{gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID()}
+
+		for _, h := range hashes {
+			err := repo.ResetPassword(context.Background(), u.ID, h)
+			s.Require().NoError(err)
+		}
+
+		history, err := repo.ListPasswordHistory(context.Background(), u.ID, 2)
+		s.Require().NoError(err)
+		s.Equal([]string{hashes[1], hashes[0]}, history)
 	})
 }
 
@@ -518,150 +512,143 @@ func (s *UserTestSuite) TestRepositoryIntegration_EnableUser() {
 	})
 }
 
-func (s *UserTestSuite) TestRepositoryIntegration_GenerateAPIToken() {
-	s.Run("should generate api token", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_SetEmailVerified() {
+	s.Run("should set email verified flag", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
+		u := fake.NewUser(s.DB, o.ID, fake.UserEmailNotVerified())
 
-		err := repo.GenerateAPIToken(context.Background(), u.ID)
+		err := repo.SetEmailVerified(context.Background(), u.ID)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.NotNil(result.APIToken)
+		s.True(result.IsEmailVerified)
 		s.Equal(time.UTC, result.UpdatedAt.Location())
 		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
 		s.GreaterOrEqual(result.UpdatedAt.Unix(), result.CreatedAt.Unix())
 	})
 
-	s.Run("should not generate api token if already exists", func() {
+	s.Run("should not set email verified flag for deleted user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
+		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted(), fake.UserEmailNotVerified())
 
-		err := repo.GenerateAPIToken(context.Background(), u.ID)
+		err := repo.SetEmailVerified(context.Background(), u.ID)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.NotNil(result.APIToken)
-		s.Equal(u.APIToken, result.APIToken)
+		s.Require().NotNil(result.DeletedAt)
+		s.False(result.IsEmailVerified)
 	})
+}
 
-	s.Run("should not generate api token for deleted user", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_SetRole() {
+	s.Run("should set the role of the user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted(), fake.UserWithoutToken())
+		r := fake.NewRole(s.DB, o.ID)
+		u := fake.NewUser(s.DB, o.ID)
 
-		err := repo.GenerateAPIToken(context.Background(), u.ID)
+		err := repo.SetRole(context.Background(), u.ID, r.ID)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.Nil(result.APIToken)
+		s.Equal(r.ID, result.RoleID)
+		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
 	})
-}
 
-func (s *UserTestSuite) TestRepositoryIntegration_ResetAPIToken() {
-	s.Run("should reset api token", func() {
+	s.Run("should not set the role of a deleted user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
+		r := fake.NewRole(s.DB, o.ID)
+		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted())
 
-		err := repo.ResetAPIToken(context.Background(), u.ID)
+		err := repo.SetRole(context.Background(), u.ID, r.ID)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.NotNil(result.APIToken)
-		s.NotEqual(u.APIToken, result.APIToken)
-		s.Equal(time.UTC, result.UpdatedAt.Location())
-		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
-		s.GreaterOrEqual(result.UpdatedAt.Unix(), result.CreatedAt.Unix())
+		s.NotEqual(r.ID, result.RoleID)
 	})
+}
 
-	s.Run("should not reset api token if not exists", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_SetOwner() {
+	s.Run("should clear the owner flag and assign the admin role", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserWithoutToken())
+		u := fake.NewUser(s.DB, o.ID, fake.UserIsOwner())
 
-		err := repo.ResetAPIToken(context.Background(), u.ID)
+		err := repo.SetOwner(context.Background(), u.ID, false)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.Nil(result.APIToken)
+		s.False(result.IsOwner)
+		s.Equal(fake.SystemRoleID(s.DB, role.SystemRoleAdmin), result.RoleID)
+		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
 	})
 
-	s.Run("should not reset api token for deleted user", func() {
+	s.Run("should set the owner flag and assign the owner role", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted())
+		u := fake.NewUser(s.DB, o.ID)
 
-		err := repo.ResetAPIToken(context.Background(), u.ID)
+		err := repo.SetOwner(context.Background(), u.ID, true)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
-		s.Require().NotNil(result.DeletedAt)
-		s.Equal(u.APIToken, result.APIToken)
+		s.True(result.IsOwner)
+		s.Equal(fake.SystemRoleID(s.DB, role.SystemRoleOwner), result.RoleID)
 	})
 
-	s.Run("should not reset api token for disabled user", func() {
+	s.Run("should not allow two owners in the same organization", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserDisabled())
-
-		err := repo.ResetAPIToken(context.Background(), u.ID)
-		s.Require().NoError(err)
+		fake.NewUser(s.DB, o.ID, fake.UserIsOwner())
+		u := fake.NewUser(s.DB, o.ID)
 
-		result := u.FetchLatest(s.DB)
-		s.Require().NotNil(result)
-		s.Require().NotNil(result.DisabledAt)
-		s.Equal(u.APIToken, result.APIToken)
+		err := repo.SetOwner(context.Background(), u.ID, true)
+		s.Require().Error(err)
 	})
 }
 
-func (s *UserTestSuite) TestRepositoryIntegration_SetEmailVerified() {
-	s.Run("should set email verified flag", func() {
+func (s *UserTestSuite) TestRepositoryIntegration_ChangeEmail() {
+	s.Run("should change the email and mark it as verified", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserEmailNotVerified())
+		email := gofakeit.Email()
 
-		err := repo.SetEmailVerified(context.Background(), u.ID)
+		err := repo.ChangeEmail(context.Background(), u.ID, email)
 		s.Require().NoError(err)
 
 		result := u.FetchLatest(s.DB)
 		s.Require().NotNil(result)
+		s.Equal(email, result.Email)
 		s.True(result.IsEmailVerified)
-		s.Equal(time.UTC, result.UpdatedAt.Location())
 		s.WithinDuration(time.Now().UTC(), result.UpdatedAt, 1*time.Minute)
-		s.GreaterOrEqual(result.UpdatedAt.Unix(), result.CreatedAt.Unix())
 	})
 
-	s.Run("should not set email verified flag for deleted user", func() {
+	s.Run("should not change the email to the email of another user of the organization", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
 		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserDeleted(), fake.UserEmailNotVerified())
-
-		err := repo.SetEmailVerified(context.Background(), u.ID)
-		s.Require().NoError(err)
+		other := fake.NewUser(s.DB, o.ID)
+		u := fake.NewUser(s.DB, o.ID)
 
-		result := u.FetchLatest(s.DB)
-		s.Require().NotNil(result)
-		s.Require().NotNil(result.DeletedAt)
-		s.False(result.IsEmailVerified)
+		err := repo.ChangeEmail(context.Background(), u.ID, other.Email)
+		s.Require().Error(err)
 	})
 }
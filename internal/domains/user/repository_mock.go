@@ -21,6 +21,54 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// ChangeEmail provides a mock function with given fields: ctx, id, email
+func (_m *MockRepository) ChangeEmail(ctx context.Context, id int64, email string) error {
+	ret := _m.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_ChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeEmail'
+type MockRepository_ChangeEmail_Call struct {
+	*mock.Call
+}
+
+// ChangeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - email string
+func (_e *MockRepository_Expecter) ChangeEmail(ctx interface{}, id interface{}, email interface{}) *MockRepository_ChangeEmail_Call {
+	return &MockRepository_ChangeEmail_Call{Call: _e.mock.On("ChangeEmail", ctx, id, email)}
+}
+
+func (_c *MockRepository_ChangeEmail_Call) Run(run func(ctx context.Context, id int64, email string)) *MockRepository_ChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ChangeEmail_Call) Return(_a0 error) *MockRepository_ChangeEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_ChangeEmail_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_ChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, orgID, email, passwordHash, isOwner
 func (_m *MockRepository) CreateUser(ctx context.Context, orgID int64, email string, passwordHash string, isOwner bool) (User, error) {
 	ret := _m.Called(ctx, orgID, email, passwordHash, isOwner)
@@ -225,74 +273,27 @@ func (_c *MockRepository_EnableUser_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GenerateAPIToken provides a mock function with given fields: ctx, id
-func (_m *MockRepository) GenerateAPIToken(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GenerateAPIToken")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// MockRepository_GenerateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateAPIToken'
-type MockRepository_GenerateAPIToken_Call struct {
-	*mock.Call
-}
-
-// GenerateAPIToken is a helper method to define mock.On call
-//   - ctx context.Context
-//   - id int64
-func (_e *MockRepository_Expecter) GenerateAPIToken(ctx interface{}, id interface{}) *MockRepository_GenerateAPIToken_Call {
-	return &MockRepository_GenerateAPIToken_Call{Call: _e.mock.On("GenerateAPIToken", ctx, id)}
-}
-
-func (_c *MockRepository_GenerateAPIToken_Call) Run(run func(ctx context.Context, id int64)) *MockRepository_GenerateAPIToken_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
-	})
-	return _c
-}
-
-func (_c *MockRepository_GenerateAPIToken_Call) Return(_a0 error) *MockRepository_GenerateAPIToken_Call {
-	_c.Call.Return(_a0)
-	return _c
-}
-
-func (_c *MockRepository_GenerateAPIToken_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_GenerateAPIToken_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetUserByAPIToken provides a mock function with given fields: ctx, apiToken
-func (_m *MockRepository) GetUserByAPIToken(ctx context.Context, apiToken string) (User, error) {
-	ret := _m.Called(ctx, apiToken)
+// GetDeletedOwnerByOrgID provides a mock function with given fields: ctx, orgID
+func (_m *MockRepository) GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error) {
+	ret := _m.Called(ctx, orgID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByAPIToken")
+		panic("no return value specified for GetDeletedOwnerByOrgID")
 	}
 
 	var r0 User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
-		return rf(ctx, apiToken)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (User, error)); ok {
+		return rf(ctx, orgID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) User); ok {
-		r0 = rf(ctx, apiToken)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) User); ok {
+		r0 = rf(ctx, orgID)
 	} else {
 		r0 = ret.Get(0).(User)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, apiToken)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -300,31 +301,31 @@ func (_m *MockRepository) GetUserByAPIToken(ctx context.Context, apiToken string
 	return r0, r1
 }
 
-// MockRepository_GetUserByAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByAPIToken'
-type MockRepository_GetUserByAPIToken_Call struct {
+// MockRepository_GetDeletedOwnerByOrgID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOwnerByOrgID'
+type MockRepository_GetDeletedOwnerByOrgID_Call struct {
 	*mock.Call
 }
 
-// GetUserByAPIToken is a helper method to define mock.On call
+// GetDeletedOwnerByOrgID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - apiToken string
-func (_e *MockRepository_Expecter) GetUserByAPIToken(ctx interface{}, apiToken interface{}) *MockRepository_GetUserByAPIToken_Call {
-	return &MockRepository_GetUserByAPIToken_Call{Call: _e.mock.On("GetUserByAPIToken", ctx, apiToken)}
+//   - orgID int64
+func (_e *MockRepository_Expecter) GetDeletedOwnerByOrgID(ctx interface{}, orgID interface{}) *MockRepository_GetDeletedOwnerByOrgID_Call {
+	return &MockRepository_GetDeletedOwnerByOrgID_Call{Call: _e.mock.On("GetDeletedOwnerByOrgID", ctx, orgID)}
 }
 
-func (_c *MockRepository_GetUserByAPIToken_Call) Run(run func(ctx context.Context, apiToken string)) *MockRepository_GetUserByAPIToken_Call {
+func (_c *MockRepository_GetDeletedOwnerByOrgID_Call) Run(run func(ctx context.Context, orgID int64)) *MockRepository_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetUserByAPIToken_Call) Return(_a0 User, _a1 error) *MockRepository_GetUserByAPIToken_Call {
+func (_c *MockRepository_GetDeletedOwnerByOrgID_Call) Return(_a0 User, _a1 error) *MockRepository_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetUserByAPIToken_Call) RunAndReturn(run func(context.Context, string) (User, error)) *MockRepository_GetUserByAPIToken_Call {
+func (_c *MockRepository_GetDeletedOwnerByOrgID_Call) RunAndReturn(run func(context.Context, int64) (User, error)) *MockRepository_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -444,64 +445,6 @@ func (_c *MockRepository_GetUserByOrgIDEmail_Call) RunAndReturn(run func(context
 	return _c
 }
 
-// GetUserByOrgSubdomainAPIToken provides a mock function with given fields: ctx, orgSubdomain, apiToken
-func (_m *MockRepository) GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain string, apiToken string) (User, error) {
-	ret := _m.Called(ctx, orgSubdomain, apiToken)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetUserByOrgSubdomainAPIToken")
-	}
-
-	var r0 User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (User, error)); ok {
-		return rf(ctx, orgSubdomain, apiToken)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) User); ok {
-		r0 = rf(ctx, orgSubdomain, apiToken)
-	} else {
-		r0 = ret.Get(0).(User)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, orgSubdomain, apiToken)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockRepository_GetUserByOrgSubdomainAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByOrgSubdomainAPIToken'
-type MockRepository_GetUserByOrgSubdomainAPIToken_Call struct {
-	*mock.Call
-}
-
-// GetUserByOrgSubdomainAPIToken is a helper method to define mock.On call
-//   - ctx context.Context
-//   - orgSubdomain string
-//   - apiToken string
-func (_e *MockRepository_Expecter) GetUserByOrgSubdomainAPIToken(ctx interface{}, orgSubdomain interface{}, apiToken interface{}) *MockRepository_GetUserByOrgSubdomainAPIToken_Call {
-	return &MockRepository_GetUserByOrgSubdomainAPIToken_Call{Call: _e.mock.On("GetUserByOrgSubdomainAPIToken", ctx, orgSubdomain, apiToken)}
-}
-
-func (_c *MockRepository_GetUserByOrgSubdomainAPIToken_Call) Run(run func(ctx context.Context, orgSubdomain string, apiToken string)) *MockRepository_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
-	})
-	return _c
-}
-
-func (_c *MockRepository_GetUserByOrgSubdomainAPIToken_Call) Return(_a0 User, _a1 error) *MockRepository_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *MockRepository_GetUserByOrgSubdomainAPIToken_Call) RunAndReturn(run func(context.Context, string, string) (User, error)) *MockRepository_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // GetUserByOrgSubdomainEmail provides a mock function with given fields: ctx, orgSubdomain, email
 func (_m *MockRepository) GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain string, email string) (User, error) {
 	ret := _m.Called(ctx, orgSubdomain, email)
@@ -560,49 +503,62 @@ func (_c *MockRepository_GetUserByOrgSubdomainEmail_Call) RunAndReturn(run func(
 	return _c
 }
 
-// ResetAPIToken provides a mock function with given fields: ctx, id
-func (_m *MockRepository) ResetAPIToken(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// ListPasswordHistory provides a mock function with given fields: ctx, id, limit
+func (_m *MockRepository) ListPasswordHistory(ctx context.Context, id int64, limit int) ([]string, error) {
+	ret := _m.Called(ctx, id, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetAPIToken")
+		panic("no return value specified for ListPasswordHistory")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]string, error)); ok {
+		return rf(ctx, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []string); ok {
+		r0 = rf(ctx, id, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockRepository_ResetAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetAPIToken'
-type MockRepository_ResetAPIToken_Call struct {
+// MockRepository_ListPasswordHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPasswordHistory'
+type MockRepository_ListPasswordHistory_Call struct {
 	*mock.Call
 }
 
-// ResetAPIToken is a helper method to define mock.On call
+// ListPasswordHistory is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int64
-func (_e *MockRepository_Expecter) ResetAPIToken(ctx interface{}, id interface{}) *MockRepository_ResetAPIToken_Call {
-	return &MockRepository_ResetAPIToken_Call{Call: _e.mock.On("ResetAPIToken", ctx, id)}
+//   - limit int
+func (_e *MockRepository_Expecter) ListPasswordHistory(ctx interface{}, id interface{}, limit interface{}) *MockRepository_ListPasswordHistory_Call {
+	return &MockRepository_ListPasswordHistory_Call{Call: _e.mock.On("ListPasswordHistory", ctx, id, limit)}
 }
 
-func (_c *MockRepository_ResetAPIToken_Call) Run(run func(ctx context.Context, id int64)) *MockRepository_ResetAPIToken_Call {
+func (_c *MockRepository_ListPasswordHistory_Call) Run(run func(ctx context.Context, id int64, limit int)) *MockRepository_ListPasswordHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockRepository_ResetAPIToken_Call) Return(_a0 error) *MockRepository_ResetAPIToken_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepository_ListPasswordHistory_Call) Return(_a0 []string, _a1 error) *MockRepository_ListPasswordHistory_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_ResetAPIToken_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepository_ResetAPIToken_Call {
+func (_c *MockRepository_ListPasswordHistory_Call) RunAndReturn(run func(context.Context, int64, int) ([]string, error)) *MockRepository_ListPasswordHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -702,6 +658,150 @@ func (_c *MockRepository_SetEmailVerified_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// SetOwner provides a mock function with given fields: ctx, id, isOwner
+func (_m *MockRepository) SetOwner(ctx context.Context, id int64, isOwner bool) error {
+	ret := _m.Called(ctx, id, isOwner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOwner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) error); ok {
+		r0 = rf(ctx, id, isOwner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOwner'
+type MockRepository_SetOwner_Call struct {
+	*mock.Call
+}
+
+// SetOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - isOwner bool
+func (_e *MockRepository_Expecter) SetOwner(ctx interface{}, id interface{}, isOwner interface{}) *MockRepository_SetOwner_Call {
+	return &MockRepository_SetOwner_Call{Call: _e.mock.On("SetOwner", ctx, id, isOwner)}
+}
+
+func (_c *MockRepository_SetOwner_Call) Run(run func(ctx context.Context, id int64, isOwner bool)) *MockRepository_SetOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetOwner_Call) Return(_a0 error) *MockRepository_SetOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetOwner_Call) RunAndReturn(run func(context.Context, int64, bool) error) *MockRepository_SetOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRole provides a mock function with given fields: ctx, id, roleID
+func (_m *MockRepository) SetRole(ctx context.Context, id int64, roleID int64) error {
+	ret := _m.Called(ctx, id, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type MockRepository_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - roleID int64
+func (_e *MockRepository_Expecter) SetRole(ctx interface{}, id interface{}, roleID interface{}) *MockRepository_SetRole_Call {
+	return &MockRepository_SetRole_Call{Call: _e.mock.On("SetRole", ctx, id, roleID)}
+}
+
+func (_c *MockRepository_SetRole_Call) Run(run func(ctx context.Context, id int64, roleID int64)) *MockRepository_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetRole_Call) Return(_a0 error) *MockRepository_SetRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetRole_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockRepository_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePasswordHash provides a mock function with given fields: ctx, id, passwordHash
+func (_m *MockRepository) UpdatePasswordHash(ctx context.Context, id int64, passwordHash string) error {
+	ret := _m.Called(ctx, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdatePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePasswordHash'
+type MockRepository_UpdatePasswordHash_Call struct {
+	*mock.Call
+}
+
+// UpdatePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - passwordHash string
+func (_e *MockRepository_Expecter) UpdatePasswordHash(ctx interface{}, id interface{}, passwordHash interface{}) *MockRepository_UpdatePasswordHash_Call {
+	return &MockRepository_UpdatePasswordHash_Call{Call: _e.mock.On("UpdatePasswordHash", ctx, id, passwordHash)}
+}
+
+func (_c *MockRepository_UpdatePasswordHash_Call) Run(run func(ctx context.Context, id int64, passwordHash string)) *MockRepository_UpdatePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdatePasswordHash_Call) Return(_a0 error) *MockRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdatePasswordHash_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
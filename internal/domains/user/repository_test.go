@@ -54,7 +54,7 @@ func TestRepository_GetUserByID(t *testing.T) {
 	})
 }
 
-func TestRepository_GetUserByAPIToken(t *testing.T) {
+func TestRepository_GetUserByOrgIDEmail(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return an error when the database call fails", func(t *testing.T) {
@@ -63,10 +63,11 @@ func TestRepository_GetUserByAPIToken(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserByAPITokenQuery"), "token").
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getUserByOrgIDEmailQuery"), int64(1), "email").
 			Return(assert.AnError)
 
-		_, err := repo.GetUserByAPIToken(context.Background(), "token")
+		_, err := repo.GetUserByOrgIDEmail(context.Background(), 1, "email")
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
@@ -81,7 +82,7 @@ func TestRepository_GetUserByAPIToken(t *testing.T) {
 
 		u := user.User{ID: 1}
 
-		mockDB.On("Get", context.Background(), &emptyUser, tests.QueryMatcher("getUserByAPITokenQuery"), "token").
+		mockDB.On("Get", context.Background(), &emptyUser, tests.QueryMatcher("getUserByOrgIDEmailQuery"), int64(1), "email").
 			Run(func(args mock.Arguments) {
 				// populate the passed argument with the user
 				arg, ok := args.Get(1).(*user.User)
@@ -89,54 +90,13 @@ func TestRepository_GetUserByAPIToken(t *testing.T) {
 				*arg = u
 			}).Return(nil)
 
-		result, err := repo.GetUserByAPIToken(context.Background(), "token")
-		require.NoError(t, err)
-		assert.Equal(t, u, result)
-	})
-}
-
-func TestRepository_GetUserByOrgSubdomainAPIToken(t *testing.T) {
-	t.Parallel()
-
-	t.Run("should return an error when the database call fails", func(t *testing.T) {
-		t.Parallel()
-
-		mockDB := database.NewMockDatabase(t)
-		repo := user.NewRepository(mockDB)
-
-		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserByOrgSubdomainAPITokenQuery"),
-			"subdomain", "token").Return(assert.AnError)
-
-		_, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), "subdomain", "token")
-		require.Error(t, err)
-		assert.ErrorIs(t, assert.AnError, err)
-	})
-
-	t.Run("should return a user", func(t *testing.T) {
-		t.Parallel()
-
-		var emptyUser user.User
-
-		mockDB := database.NewMockDatabase(t)
-		repo := user.NewRepository(mockDB)
-
-		u := user.User{ID: 1}
-
-		mockDB.On("Get", context.Background(), &emptyUser, tests.QueryMatcher("getUserByOrgSubdomainAPITokenQuery"),
-			"subdomain", "token").Run(func(args mock.Arguments) {
-			// populate the passed argument with the user
-			arg, ok := args.Get(1).(*user.User)
-			require.True(t, ok)
-			*arg = u
-		}).Return(nil)
-
-		result, err := repo.GetUserByOrgSubdomainAPIToken(context.Background(), "subdomain", "token")
+		result, err := repo.GetUserByOrgIDEmail(context.Background(), 1, "email")
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestRepository_GetUserByOrgIDEmail(t *testing.T) {
+func TestRepository_GetUserByOrgSubdomainEmail(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return an error when the database call fails", func(t *testing.T) {
@@ -145,11 +105,10 @@ func TestRepository_GetUserByOrgIDEmail(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Get", context.Background(), mock.Anything,
-			tests.QueryMatcher("getUserByOrgIDEmailQuery"), int64(1), "email").
-			Return(assert.AnError)
+		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserByOrgSubdomainEmailQuery"),
+			"subdomain", "email").Return(assert.AnError)
 
-		_, err := repo.GetUserByOrgIDEmail(context.Background(), 1, "email")
+		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", "email")
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
@@ -164,7 +123,8 @@ func TestRepository_GetUserByOrgIDEmail(t *testing.T) {
 
 		u := user.User{ID: 1}
 
-		mockDB.On("Get", context.Background(), &emptyUser, tests.QueryMatcher("getUserByOrgIDEmailQuery"), int64(1), "email").
+		mockDB.On("Get", context.Background(), &emptyUser,
+			tests.QueryMatcher("getUserByOrgSubdomainEmailQuery"), "subdomain", "email").
 			Run(func(args mock.Arguments) {
 				// populate the passed argument with the user
 				arg, ok := args.Get(1).(*user.User)
@@ -172,13 +132,13 @@ func TestRepository_GetUserByOrgIDEmail(t *testing.T) {
 				*arg = u
 			}).Return(nil)
 
-		result, err := repo.GetUserByOrgIDEmail(context.Background(), 1, "email")
+		result, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", "email")
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestRepository_GetUserByOrgSubdomainEmail(t *testing.T) {
+func TestRepository_GetDeletedOwnerByOrgID(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return an error when the database call fails", func(t *testing.T) {
@@ -187,15 +147,16 @@ func TestRepository_GetUserByOrgSubdomainEmail(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Get", context.Background(), mock.Anything, tests.QueryMatcher("getUserByOrgSubdomainEmailQuery"),
-			"subdomain", "email").Return(assert.AnError)
+		mockDB.On("Get", context.Background(), mock.Anything,
+			tests.QueryMatcher("getDeletedOwnerByOrgIDQuery"), int64(1)).
+			Return(assert.AnError)
 
-		_, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", "email")
+		_, err := repo.GetDeletedOwnerByOrgID(context.Background(), 1)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return a user", func(t *testing.T) {
+	t.Run("should return the deleted owner", func(t *testing.T) {
 		t.Parallel()
 
 		var emptyUser user.User
@@ -203,10 +164,9 @@ func TestRepository_GetUserByOrgSubdomainEmail(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		u := user.User{ID: 1}
+		u := user.User{ID: 1, IsOwner: true}
 
-		mockDB.On("Get", context.Background(), &emptyUser,
-			tests.QueryMatcher("getUserByOrgSubdomainEmailQuery"), "subdomain", "email").
+		mockDB.On("Get", context.Background(), &emptyUser, tests.QueryMatcher("getDeletedOwnerByOrgIDQuery"), int64(1)).
 			Run(func(args mock.Arguments) {
 				// populate the passed argument with the user
 				arg, ok := args.Get(1).(*user.User)
@@ -214,7 +174,7 @@ func TestRepository_GetUserByOrgSubdomainEmail(t *testing.T) {
 				*arg = u
 			}).Return(nil)
 
-		result, err := repo.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", "email")
+		result, err := repo.GetDeletedOwnerByOrgID(context.Background(), 1)
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
@@ -394,38 +354,7 @@ func TestRepository_EnableUser(t *testing.T) {
 	})
 }
 
-func TestRepository_GenerateAPIToken(t *testing.T) {
-	t.Parallel()
-
-	t.Run("should return an error when the database call fails", func(t *testing.T) {
-		t.Parallel()
-
-		mockDB := database.NewMockDatabase(t)
-		repo := user.NewRepository(mockDB)
-
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("generateAPITokenQuery"), int64(1)).
-			Return(assert.AnError)
-
-		err := repo.GenerateAPIToken(context.Background(), 1)
-		require.Error(t, err)
-		assert.ErrorIs(t, assert.AnError, err)
-	})
-
-	t.Run("should return nil when api token is generated", func(t *testing.T) {
-		t.Parallel()
-
-		mockDB := database.NewMockDatabase(t)
-		repo := user.NewRepository(mockDB)
-
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("generateAPITokenQuery"), int64(1)).
-			Return(nil)
-
-		err := repo.GenerateAPIToken(context.Background(), 1)
-		require.NoError(t, err)
-	})
-}
-
-func TestRepository_ResetAPIToken(t *testing.T) {
+func TestRepository_SetEmailVerified(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return an error when the database call fails", func(t *testing.T) {
@@ -434,29 +363,29 @@ func TestRepository_ResetAPIToken(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("resetAPITokenQuery"), int64(1)).
+		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setEmailVerifiedQuery"), int64(1)).
 			Return(assert.AnError)
 
-		err := repo.ResetAPIToken(context.Background(), 1)
+		err := repo.SetEmailVerified(context.Background(), 1)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return nil when api token is reset", func(t *testing.T) {
+	t.Run("should return nil when email is verified", func(t *testing.T) {
 		t.Parallel()
 
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("resetAPITokenQuery"), int64(1)).
+		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setEmailVerifiedQuery"), int64(1)).
 			Return(nil)
 
-		err := repo.ResetAPIToken(context.Background(), 1)
+		err := repo.SetEmailVerified(context.Background(), 1)
 		require.NoError(t, err)
 	})
 }
 
-func TestRepository_SetEmailVerified(t *testing.T) {
+func TestRepository_SetRole(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return an error when the database call fails", func(t *testing.T) {
@@ -465,24 +394,24 @@ func TestRepository_SetEmailVerified(t *testing.T) {
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setEmailVerifiedQuery"), int64(1)).
+		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setUserRoleQuery"), int64(1), int64(2)).
 			Return(assert.AnError)
 
-		err := repo.SetEmailVerified(context.Background(), 1)
+		err := repo.SetRole(context.Background(), 1, 2)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return nil when email is verified", func(t *testing.T) {
+	t.Run("should return nil when role is set", func(t *testing.T) {
 		t.Parallel()
 
 		mockDB := database.NewMockDatabase(t)
 		repo := user.NewRepository(mockDB)
 
-		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setEmailVerifiedQuery"), int64(1)).
+		mockDB.On("Exec", context.Background(), nil, tests.QueryMatcher("setUserRoleQuery"), int64(1), int64(2)).
 			Return(nil)
 
-		err := repo.SetEmailVerified(context.Background(), 1)
+		err := repo.SetRole(context.Background(), 1, 2)
 		require.NoError(t, err)
 	})
 }
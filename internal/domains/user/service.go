@@ -7,8 +7,9 @@ import (
 
 	"github.com/camelhr/camelhr-api/internal/base"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/camelhr/log"
 )
 
 // Service is a service for managing users.
@@ -16,27 +17,33 @@ type Service interface {
 	// GetUserByID returns a user by its ID.
 	GetUserByID(ctx context.Context, id int64) (User, error)
 
-	// GetUserByAPIToken returns a user by its API token.
-	GetUserByAPIToken(ctx context.Context, apiToken string) (User, error)
-
-	// GetUserByOrgSubdomainAPIToken returns a user of organization by its org subdomain and api token.
-	GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain, apiToken string) (User, error)
-
 	// GetUserByOrgIDEmail returns a user of organization by its org id and email.
 	GetUserByOrgIDEmail(ctx context.Context, orgID int64, email string) (User, error)
 
 	// GetUserByOrgSubdomainEmail returns a user of organization by its org subdomain and email.
 	GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain, email string) (User, error)
 
+	// GetDeletedOwnerByOrgID returns the soft deleted owner of an organization by its org id.
+	GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error)
+
 	// CreateUser creates a new user.
 	CreateUser(ctx context.Context, orgID int64, email, password string) (User, error)
 
+	// CreateExternalUser creates a new user authenticated by an external identity provider.
+	// The user has no password and can not login using password until it is reset.
+	CreateExternalUser(ctx context.Context, orgID int64, email string) (User, error)
+
 	// CreateOwner creates a new owner user.
 	CreateOwner(ctx context.Context, orgID int64, email, password string) (User, error)
 
 	// ResetPassword resets the password of a user.
+	// The new password must satisfy the password policy of the organization of the user.
 	ResetPassword(ctx context.Context, id int64, newPassword string) error
 
+	// ChangePassword changes the password of a user after verifying the current password.
+	// All the sessions of the user other than the given session are deleted.
+	ChangePassword(ctx context.Context, id int64, sessionID, currentPassword, newPassword string) error
+
 	// DeleteUser deletes a user by its ID.
 	// This also deletes the user session.
 	DeleteUser(ctx context.Context, id int64, comment string) error
@@ -48,26 +55,52 @@ type Service interface {
 	// EnableUser enables a user by its ID.
 	EnableUser(ctx context.Context, id int64, comment string) error
 
-	// GenerateAPIToken generates a new API token for a user.
-	GenerateAPIToken(ctx context.Context, id int64) error
-
-	// ResetAPIToken resets the API token of a user.
-	ResetAPIToken(ctx context.Context, id int64) error
-
 	// SetEmailVerified sets the email_verified flag of a user.
 	SetEmailVerified(ctx context.Context, id int64) error
+
+	// SetRole sets the role of a user.
+	SetRole(ctx context.Context, id, roleID int64) error
+
+	// TransferOwnership makes the given user the owner of the organization in place of the current owner.
+	// The previous owner is assigned the admin role. It must be called within a transaction so that
+	// the organization is never left without an owner.
+	TransferOwnership(ctx context.Context, fromUserID, toUserID int64) error
+
+	// ChangeEmail changes the email of a user. The new email must be verified by the caller
+	// since it is marked as verified.
+	ChangeEmail(ctx context.Context, id int64, email string) error
+
+	// VerifyPassword reports whether the password matches the password hash of the user.
+	// When the hash was generated using an outdated algorithm or parameters, e.g. bcrypt,
+	// the password is re-hashed using the current ones once it is verified.
+	VerifyPassword(ctx context.Context, u User, password string) (bool, error)
+
+	// IsPasswordExpired reports whether the password of the user has exceeded the maximum age
+	// of the password policy of the organization and must be changed.
+	IsPasswordExpired(ctx context.Context, u User) (bool, error)
 }
 
-var ErrUserIsOwner = errors.New("operation not allowed. user is owner")
+var (
+	ErrUserIsOwner            = errors.New("operation not allowed. user is owner")
+	ErrInvalidCurrentPassword = errors.New("current password is invalid")
+	ErrPasswordReused         = base.NewInputValidationError("password must not be one of the recently used passwords")
+)
 
 type service struct {
 	repo           Repository
 	sessionManager session.SessionManager
+	passwordHasher PasswordHasher
+	policyService  passwordpolicy.Service
 }
 
 // NewService creates a new user service.
-func NewService(repo Repository, sessionManager session.SessionManager) *service {
-	return &service{repo, sessionManager}
+func NewService(
+	repo Repository,
+	sessionManager session.SessionManager,
+	passwordHasher PasswordHasher,
+	policyService passwordpolicy.Service,
+) *service {
+	return &service{repo, sessionManager, passwordHasher, policyService}
 }
 
 func (s *service) GetUserByID(ctx context.Context, id int64) (User, error) {
@@ -83,36 +116,6 @@ func (s *service) GetUserByID(ctx context.Context, id int64) (User, error) {
 	return u, nil
 }
 
-func (s *service) GetUserByAPIToken(ctx context.Context, apiToken string) (User, error) {
-	u, err := s.repo.GetUserByAPIToken(ctx, apiToken)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return User{}, base.NewNotFoundError("user not found for the given api-token")
-		}
-
-		return User{}, err
-	}
-
-	return u, nil
-}
-
-func (s *service) GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain, apiToken string) (User, error) {
-	if err := organization.ValidateSubdomain(orgSubdomain); err != nil {
-		return User{}, err
-	}
-
-	u, err := s.repo.GetUserByOrgSubdomainAPIToken(ctx, orgSubdomain, apiToken)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return User{}, base.NewNotFoundError("user not found for the given org-subdomain and api-token")
-		}
-
-		return User{}, err
-	}
-
-	return u, nil
-}
-
 func (s *service) GetUserByOrgIDEmail(ctx context.Context, orgID int64, email string) (User, error) {
 	if err := ValidateEmail(email); err != nil {
 		return User{}, err
@@ -151,16 +154,29 @@ func (s *service) GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain,
 	return u, nil
 }
 
+func (s *service) GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error) {
+	u, err := s.repo.GetDeletedOwnerByOrgID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, base.NewNotFoundError("deleted owner not found for the given org-id")
+		}
+
+		return User{}, err
+	}
+
+	return u, nil
+}
+
 func (s *service) CreateUser(ctx context.Context, orgID int64, email, password string) (User, error) {
 	if err := ValidateEmail(email); err != nil {
 		return User{}, err
 	}
 
-	if err := ValidatePassword(password); err != nil {
+	if err := s.validatePassword(ctx, orgID, password); err != nil {
 		return User{}, err
 	}
 
-	passwordHash, err := s.bcryptPassword(password)
+	passwordHash, err := s.passwordHasher.Hash(password)
 	if err != nil {
 		return User{}, err
 	}
@@ -168,16 +184,25 @@ func (s *service) CreateUser(ctx context.Context, orgID int64, email, password s
 	return s.repo.CreateUser(ctx, orgID, email, passwordHash, false)
 }
 
+func (s *service) CreateExternalUser(ctx context.Context, orgID int64, email string) (User, error) {
+	if err := ValidateEmail(email); err != nil {
+		return User{}, err
+	}
+
+	// an empty hash never matches any password
+	return s.repo.CreateUser(ctx, orgID, email, "", false)
+}
+
 func (s *service) CreateOwner(ctx context.Context, orgID int64, email, password string) (User, error) {
 	if err := ValidateEmail(email); err != nil {
 		return User{}, err
 	}
 
-	if err := ValidatePassword(password); err != nil {
+	if err := s.validatePassword(ctx, orgID, password); err != nil {
 		return User{}, err
 	}
 
-	passwordHash, err := s.bcryptPassword(password)
+	passwordHash, err := s.passwordHasher.Hash(password)
 	if err != nil {
 		return User{}, err
 	}
@@ -186,16 +211,37 @@ func (s *service) CreateOwner(ctx context.Context, orgID int64, email, password
 }
 
 func (s *service) ResetPassword(ctx context.Context, id int64, newPassword string) error {
-	if err := ValidatePassword(newPassword); err != nil {
+	u, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.setPassword(ctx, u, newPassword)
+}
+
+func (s *service) ChangePassword(
+	ctx context.Context, id int64, sessionID, currentPassword, newPassword string,
+) error {
+	u, err := s.GetUserByID(ctx, id)
+	if err != nil {
 		return err
 	}
 
-	passwordHash, err := s.bcryptPassword(newPassword)
+	matched, err := s.passwordHasher.Verify(u.PasswordHash, currentPassword)
 	if err != nil {
 		return err
 	}
 
-	return s.repo.ResetPassword(ctx, id, passwordHash)
+	if !matched {
+		return ErrInvalidCurrentPassword
+	}
+
+	if err := s.setPassword(ctx, u, newPassword); err != nil {
+		return err
+	}
+
+	// the other devices must login again using the new password
+	return s.sessionManager.DeleteOtherSessions(ctx, u.ID, u.OrganizationID, sessionID)
 }
 
 func (s *service) DeleteUser(ctx context.Context, id int64, comment string) error {
@@ -256,35 +302,124 @@ func (s *service) EnableUser(ctx context.Context, id int64, comment string) erro
 	return s.repo.EnableUser(ctx, id, comment)
 }
 
-func (s *service) GenerateAPIToken(ctx context.Context, id int64) error {
-	return s.repo.GenerateAPIToken(ctx, id)
+func (s *service) SetEmailVerified(ctx context.Context, id int64) error {
+	return s.repo.SetEmailVerified(ctx, id)
 }
 
-func (s *service) ResetAPIToken(ctx context.Context, id int64) error {
-	return s.repo.ResetAPIToken(ctx, id)
+func (s *service) SetRole(ctx context.Context, id, roleID int64) error {
+	return s.repo.SetRole(ctx, id, roleID)
 }
 
-func (s *service) SetEmailVerified(ctx context.Context, id int64) error {
-	return s.repo.SetEmailVerified(ctx, id)
+func (s *service) TransferOwnership(ctx context.Context, fromUserID, toUserID int64) error {
+	// only one owner is allowed per organization. so the flag of the current owner is cleared first
+	if err := s.repo.SetOwner(ctx, fromUserID, false); err != nil {
+		return err
+	}
+
+	return s.repo.SetOwner(ctx, toUserID, true)
+}
+
+func (s *service) ChangeEmail(ctx context.Context, id int64, email string) error {
+	if err := ValidateEmail(email); err != nil {
+		return err
+	}
+
+	return s.repo.ChangeEmail(ctx, id, email)
+}
+
+func (s *service) VerifyPassword(ctx context.Context, u User, password string) (bool, error) {
+	matched, err := s.passwordHasher.Verify(u.PasswordHash, password)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	// the plaintext password is known only at this point. so the outdated hashes are upgraded here
+	if s.passwordHasher.NeedsRehash(u.PasswordHash) {
+		if err := s.rehashPassword(ctx, u.ID, password); err != nil {
+			// the user is still allowed to login. the upgrade is retried on the next login
+			log.Error("failed to upgrade password hash of user:%d org:%d: %v", u.ID, u.OrganizationID, err)
+		}
+	}
+
+	return true, nil
+}
+
+func (s *service) IsPasswordExpired(ctx context.Context, u User) (bool, error) {
+	// the users authenticated by an external identity provider have no password to expire
+	if u.PasswordHash == "" {
+		return false, nil
+	}
+
+	policy, err := s.policyService.GetPolicy(ctx, u.OrganizationID)
+	if err != nil {
+		return false, err
+	}
+
+	return policy.IsExpired(u.PasswordChangedAt), nil
 }
 
-// bcryptPassword hashes a password using bcrypt.
-func (s *service) bcryptPassword(password string) (string, error) {
-	passwordBytes := []byte(password)
+// validatePassword validates the new password against the password policy of the organization.
+func (s *service) validatePassword(ctx context.Context, orgID int64, password string) error {
+	policy, err := s.policyService.GetPolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
 
-	// generate a bcrypt hash of the password. this automatically generates a new salt for each password
-	passwordHashBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return policy.Validate(password)
+}
+
+// setPassword sets the new password of the user once it satisfies the password policy of the organization.
+// The current password and the previous passwords kept by the policy history can not be reused.
+func (s *service) setPassword(ctx context.Context, u User, newPassword string) error {
+	policy, err := s.policyService.GetPolicy(ctx, u.OrganizationID)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// verify that the password and the hashed password match
-	// this is not typically necessary immediately after hashing the password
-	err = bcrypt.CompareHashAndPassword(passwordHashBytes, passwordBytes)
+	if err := policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	if policy.HistoryCount > 0 {
+		// the current password counts as one of the recent passwords
+		passwordHashes := []string{u.PasswordHash}
+
+		if policy.HistoryCount > 1 {
+			previousHashes, err := s.repo.ListPasswordHistory(ctx, u.ID, policy.HistoryCount-1)
+			if err != nil {
+				return err
+			}
+
+			passwordHashes = append(passwordHashes, previousHashes...)
+		}
+
+		for _, passwordHash := range passwordHashes {
+			matched, err := s.passwordHasher.Verify(passwordHash, newPassword)
+			if err != nil {
+				return err
+			}
+
+			if matched {
+				return ErrPasswordReused
+			}
+		}
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(newPassword)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	return s.repo.ResetPassword(ctx, u.ID, passwordHash)
+}
+
+// rehashPassword replaces the password hash of the user with a hash of the current algorithm and parameters.
+// The password is not validated since it is already in use.
+func (s *service) rehashPassword(ctx context.Context, id int64, password string) error {
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return err
 	}
 
-	// return the hashed password as a string
-	return string(passwordHashBytes), nil
+	return s.repo.UpdatePasswordHash(ctx, id, passwordHash)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
@@ -14,7 +15,8 @@ func (s *UserTestSuite) TestServiceIntegration_GetUserByID() {
 	s.Run("should return user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 
@@ -30,53 +32,12 @@ func (s *UserTestSuite) TestServiceIntegration_GetUserByID() {
 	})
 }
 
-func (s *UserTestSuite) TestServiceIntegration_GetUserByAPIToken() {
-	s.Run("should return user", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
-
-		result, err := svc.GetUserByAPIToken(context.Background(), *u.APIToken)
-		s.Require().NoError(err)
-		s.Equal(u.ID, result.ID)
-		s.Equal(u.Email, result.Email)
-		s.Nil(result.DisabledAt)
-		s.NotZero(result.CreatedAt)
-		s.NotZero(result.UpdatedAt)
-		s.Equal(result.CreatedAt, result.UpdatedAt)
-		s.Nil(result.Comment)
-	})
-}
-
-func (s *UserTestSuite) TestServiceIntegration_GetUserByOrgSubdomainAPIToken() {
-	s.Run("should return user", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-		s.Require().NotNil(u.APIToken)
-
-		result, err := svc.GetUserByOrgSubdomainAPIToken(context.Background(), o.Subdomain, *u.APIToken)
-		s.Require().NoError(err)
-		s.Equal(u.ID, result.ID)
-		s.Equal(u.Email, result.Email)
-		s.Nil(result.DisabledAt)
-		s.NotZero(result.CreatedAt)
-		s.NotZero(result.UpdatedAt)
-		s.Equal(result.CreatedAt, result.UpdatedAt)
-		s.Nil(result.Comment)
-	})
-}
-
 func (s *UserTestSuite) TestServiceIntegration_GetUserByOrgIDEmail() {
 	s.Run("should return user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 
@@ -96,7 +57,8 @@ func (s *UserTestSuite) TestServiceIntegration_GetUserByOrgSubdomainEmail() {
 	s.Run("should return user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 
@@ -116,7 +78,8 @@ func (s *UserTestSuite) TestServiceIntegration_CreateUser() {
 	s.Run("should create user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		email := gofakeit.Email()
 		password := generatePassword()
@@ -126,7 +89,6 @@ func (s *UserTestSuite) TestServiceIntegration_CreateUser() {
 		s.Equal(email, result.Email)
 		s.Nil(result.DisabledAt)
 		s.Nil(result.Comment)
-		s.Nil(result.APIToken)
 		s.False(result.IsOwner)
 		s.NotZero(result.CreatedAt)
 		s.NotZero(result.UpdatedAt)
@@ -135,11 +97,30 @@ func (s *UserTestSuite) TestServiceIntegration_CreateUser() {
 	})
 }
 
+func (s *UserTestSuite) TestServiceIntegration_CreateExternalUser() {
+	s.Run("should create user without password", func() {
+		s.T().Parallel()
+		repo := user.NewRepository(s.DB)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
+		o := fake.NewOrganization(s.DB)
+		email := gofakeit.Email()
+
+		result, err := svc.CreateExternalUser(context.Background(), o.ID, email)
+		s.Require().NoError(err)
+		s.Equal(email, result.Email)
+		s.Empty(result.PasswordHash)
+		s.False(result.IsOwner)
+		s.False(result.IsEmailVerified)
+	})
+}
+
 func (s *UserTestSuite) TestServiceIntegration_CreateOwner() {
 	s.Run("should create owner", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		email := gofakeit.Email()
 		password := generatePassword()
@@ -149,7 +130,6 @@ func (s *UserTestSuite) TestServiceIntegration_CreateOwner() {
 		s.Equal(email, result.Email)
 		s.Nil(result.DisabledAt)
 		s.Nil(result.Comment)
-		s.Nil(result.APIToken)
 		s.True(result.IsOwner)
 		s.NotZero(result.CreatedAt)
 		s.NotZero(result.UpdatedAt)
@@ -162,7 +142,8 @@ func (s *UserTestSuite) TestServiceIntegration_ResetPassword() {
 	s.Run("should reset password", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 		newPassword := generatePassword()
@@ -182,7 +163,8 @@ func (s *UserTestSuite) TestServiceIntegration_DeleteUser() {
 
 		repo := user.NewRepository(s.DB)
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
-		svc := user.NewService(repo, sessionManager)
+		svc := user.NewService(repo, sessionManager, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 		comment := gofakeit.Sentence(5)
@@ -210,7 +192,8 @@ func (s *UserTestSuite) TestServiceIntegration_DisableUser() {
 
 		sessionManager := session.NewRedisSessionManager(s.RedisClient)
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, sessionManager)
+		svc := user.NewService(repo, sessionManager, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID)
 
@@ -238,7 +221,8 @@ func (s *UserTestSuite) TestServiceIntegration_EnableUser() {
 	s.Run("should enable user", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserDisabled())
 
@@ -253,45 +237,12 @@ func (s *UserTestSuite) TestServiceIntegration_EnableUser() {
 	})
 }
 
-func (s *UserTestSuite) TestServiceIntegration_GenerateAPIToken() {
-	s.Run("should generate API token", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-
-		err := svc.GenerateAPIToken(context.Background(), u.ID)
-		s.Require().NoError(err)
-
-		result, err := svc.GetUserByID(context.Background(), u.ID)
-		s.Require().NoError(err)
-		s.NotNil(result.APIToken)
-	})
-}
-
-func (s *UserTestSuite) TestServiceIntegration_ResetAPIToken() {
-	s.Run("should reset API token", func() {
-		s.T().Parallel()
-		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID)
-
-		err := svc.ResetAPIToken(context.Background(), u.ID)
-		s.Require().NoError(err)
-
-		result, err := svc.GetUserByID(context.Background(), u.ID)
-		s.Require().NoError(err)
-		s.NotNil(result.APIToken)
-	})
-}
-
 func (s *UserTestSuite) TestServiceIntegration_SetEmailVerified() {
 	s.Run("should set email verified", func() {
 		s.T().Parallel()
 		repo := user.NewRepository(s.DB)
-		svc := user.NewService(repo, nil)
+		svc := user.NewService(repo, nil, passwordHasher,
+			passwordpolicy.NewService(passwordpolicy.NewRepository(s.DB)))
 		o := fake.NewOrganization(s.DB)
 		u := fake.NewUser(s.DB, o.ID, fake.UserEmailNotVerified())
 
@@ -21,6 +21,162 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
+// ChangeEmail provides a mock function with given fields: ctx, id, email
+func (_m *MockService) ChangeEmail(ctx context.Context, id int64, email string) error {
+	ret := _m.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeEmail'
+type MockService_ChangeEmail_Call struct {
+	*mock.Call
+}
+
+// ChangeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - email string
+func (_e *MockService_Expecter) ChangeEmail(ctx interface{}, id interface{}, email interface{}) *MockService_ChangeEmail_Call {
+	return &MockService_ChangeEmail_Call{Call: _e.mock.On("ChangeEmail", ctx, id, email)}
+}
+
+func (_c *MockService_ChangeEmail_Call) Run(run func(ctx context.Context, id int64, email string)) *MockService_ChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ChangeEmail_Call) Return(_a0 error) *MockService_ChangeEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ChangeEmail_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockService_ChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function with given fields: ctx, id, sessionID, currentPassword, newPassword
+func (_m *MockService) ChangePassword(ctx context.Context, id int64, sessionID string, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, id, sessionID, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, string) error); ok {
+		r0 = rf(ctx, id, sessionID, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - sessionID string
+//   - currentPassword string
+//   - newPassword string
+func (_e *MockService_Expecter) ChangePassword(ctx interface{}, id interface{}, sessionID interface{}, currentPassword interface{}, newPassword interface{}) *MockService_ChangePassword_Call {
+	return &MockService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, sessionID, currentPassword, newPassword)}
+}
+
+func (_c *MockService_ChangePassword_Call) Run(run func(ctx context.Context, id int64, sessionID string, currentPassword string, newPassword string)) *MockService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_ChangePassword_Call) Return(_a0 error) *MockService_ChangePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_ChangePassword_Call) RunAndReturn(run func(context.Context, int64, string, string, string) error) *MockService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateExternalUser provides a mock function with given fields: ctx, orgID, email
+func (_m *MockService) CreateExternalUser(ctx context.Context, orgID int64, email string) (User, error) {
+	ret := _m.Called(ctx, orgID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExternalUser")
+	}
+
+	var r0 User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (User, error)); ok {
+		return rf(ctx, orgID, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) User); ok {
+		r0 = rf(ctx, orgID, email)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, orgID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_CreateExternalUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateExternalUser'
+type MockService_CreateExternalUser_Call struct {
+	*mock.Call
+}
+
+// CreateExternalUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orgID int64
+//   - email string
+func (_e *MockService_Expecter) CreateExternalUser(ctx interface{}, orgID interface{}, email interface{}) *MockService_CreateExternalUser_Call {
+	return &MockService_CreateExternalUser_Call{Call: _e.mock.On("CreateExternalUser", ctx, orgID, email)}
+}
+
+func (_c *MockService_CreateExternalUser_Call) Run(run func(ctx context.Context, orgID int64, email string)) *MockService_CreateExternalUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_CreateExternalUser_Call) Return(_a0 User, _a1 error) *MockService_CreateExternalUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_CreateExternalUser_Call) RunAndReturn(run func(context.Context, int64, string) (User, error)) *MockService_CreateExternalUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOwner provides a mock function with given fields: ctx, orgID, email, password
 func (_m *MockService) CreateOwner(ctx context.Context, orgID int64, email string, password string) (User, error) {
 	ret := _m.Called(ctx, orgID, email, password)
@@ -283,74 +439,27 @@ func (_c *MockService_EnableUser_Call) RunAndReturn(run func(context.Context, in
 	return _c
 }
 
-// GenerateAPIToken provides a mock function with given fields: ctx, id
-func (_m *MockService) GenerateAPIToken(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GenerateAPIToken")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// MockService_GenerateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateAPIToken'
-type MockService_GenerateAPIToken_Call struct {
-	*mock.Call
-}
-
-// GenerateAPIToken is a helper method to define mock.On call
-//   - ctx context.Context
-//   - id int64
-func (_e *MockService_Expecter) GenerateAPIToken(ctx interface{}, id interface{}) *MockService_GenerateAPIToken_Call {
-	return &MockService_GenerateAPIToken_Call{Call: _e.mock.On("GenerateAPIToken", ctx, id)}
-}
-
-func (_c *MockService_GenerateAPIToken_Call) Run(run func(ctx context.Context, id int64)) *MockService_GenerateAPIToken_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
-	})
-	return _c
-}
-
-func (_c *MockService_GenerateAPIToken_Call) Return(_a0 error) *MockService_GenerateAPIToken_Call {
-	_c.Call.Return(_a0)
-	return _c
-}
-
-func (_c *MockService_GenerateAPIToken_Call) RunAndReturn(run func(context.Context, int64) error) *MockService_GenerateAPIToken_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetUserByAPIToken provides a mock function with given fields: ctx, apiToken
-func (_m *MockService) GetUserByAPIToken(ctx context.Context, apiToken string) (User, error) {
-	ret := _m.Called(ctx, apiToken)
+// GetDeletedOwnerByOrgID provides a mock function with given fields: ctx, orgID
+func (_m *MockService) GetDeletedOwnerByOrgID(ctx context.Context, orgID int64) (User, error) {
+	ret := _m.Called(ctx, orgID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByAPIToken")
+		panic("no return value specified for GetDeletedOwnerByOrgID")
 	}
 
 	var r0 User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
-		return rf(ctx, apiToken)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (User, error)); ok {
+		return rf(ctx, orgID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) User); ok {
-		r0 = rf(ctx, apiToken)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) User); ok {
+		r0 = rf(ctx, orgID)
 	} else {
 		r0 = ret.Get(0).(User)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, apiToken)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orgID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -358,31 +467,31 @@ func (_m *MockService) GetUserByAPIToken(ctx context.Context, apiToken string) (
 	return r0, r1
 }
 
-// MockService_GetUserByAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByAPIToken'
-type MockService_GetUserByAPIToken_Call struct {
+// MockService_GetDeletedOwnerByOrgID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedOwnerByOrgID'
+type MockService_GetDeletedOwnerByOrgID_Call struct {
 	*mock.Call
 }
 
-// GetUserByAPIToken is a helper method to define mock.On call
+// GetDeletedOwnerByOrgID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - apiToken string
-func (_e *MockService_Expecter) GetUserByAPIToken(ctx interface{}, apiToken interface{}) *MockService_GetUserByAPIToken_Call {
-	return &MockService_GetUserByAPIToken_Call{Call: _e.mock.On("GetUserByAPIToken", ctx, apiToken)}
+//   - orgID int64
+func (_e *MockService_Expecter) GetDeletedOwnerByOrgID(ctx interface{}, orgID interface{}) *MockService_GetDeletedOwnerByOrgID_Call {
+	return &MockService_GetDeletedOwnerByOrgID_Call{Call: _e.mock.On("GetDeletedOwnerByOrgID", ctx, orgID)}
 }
 
-func (_c *MockService_GetUserByAPIToken_Call) Run(run func(ctx context.Context, apiToken string)) *MockService_GetUserByAPIToken_Call {
+func (_c *MockService_GetDeletedOwnerByOrgID_Call) Run(run func(ctx context.Context, orgID int64)) *MockService_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockService_GetUserByAPIToken_Call) Return(_a0 User, _a1 error) *MockService_GetUserByAPIToken_Call {
+func (_c *MockService_GetDeletedOwnerByOrgID_Call) Return(_a0 User, _a1 error) *MockService_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_GetUserByAPIToken_Call) RunAndReturn(run func(context.Context, string) (User, error)) *MockService_GetUserByAPIToken_Call {
+func (_c *MockService_GetDeletedOwnerByOrgID_Call) RunAndReturn(run func(context.Context, int64) (User, error)) *MockService_GetDeletedOwnerByOrgID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -502,64 +611,6 @@ func (_c *MockService_GetUserByOrgIDEmail_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// GetUserByOrgSubdomainAPIToken provides a mock function with given fields: ctx, orgSubdomain, apiToken
-func (_m *MockService) GetUserByOrgSubdomainAPIToken(ctx context.Context, orgSubdomain string, apiToken string) (User, error) {
-	ret := _m.Called(ctx, orgSubdomain, apiToken)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetUserByOrgSubdomainAPIToken")
-	}
-
-	var r0 User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (User, error)); ok {
-		return rf(ctx, orgSubdomain, apiToken)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) User); ok {
-		r0 = rf(ctx, orgSubdomain, apiToken)
-	} else {
-		r0 = ret.Get(0).(User)
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, orgSubdomain, apiToken)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// MockService_GetUserByOrgSubdomainAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByOrgSubdomainAPIToken'
-type MockService_GetUserByOrgSubdomainAPIToken_Call struct {
-	*mock.Call
-}
-
-// GetUserByOrgSubdomainAPIToken is a helper method to define mock.On call
-//   - ctx context.Context
-//   - orgSubdomain string
-//   - apiToken string
-func (_e *MockService_Expecter) GetUserByOrgSubdomainAPIToken(ctx interface{}, orgSubdomain interface{}, apiToken interface{}) *MockService_GetUserByOrgSubdomainAPIToken_Call {
-	return &MockService_GetUserByOrgSubdomainAPIToken_Call{Call: _e.mock.On("GetUserByOrgSubdomainAPIToken", ctx, orgSubdomain, apiToken)}
-}
-
-func (_c *MockService_GetUserByOrgSubdomainAPIToken_Call) Run(run func(ctx context.Context, orgSubdomain string, apiToken string)) *MockService_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
-	})
-	return _c
-}
-
-func (_c *MockService_GetUserByOrgSubdomainAPIToken_Call) Return(_a0 User, _a1 error) *MockService_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *MockService_GetUserByOrgSubdomainAPIToken_Call) RunAndReturn(run func(context.Context, string, string) (User, error)) *MockService_GetUserByOrgSubdomainAPIToken_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // GetUserByOrgSubdomainEmail provides a mock function with given fields: ctx, orgSubdomain, email
 func (_m *MockService) GetUserByOrgSubdomainEmail(ctx context.Context, orgSubdomain string, email string) (User, error) {
 	ret := _m.Called(ctx, orgSubdomain, email)
@@ -618,49 +669,59 @@ func (_c *MockService_GetUserByOrgSubdomainEmail_Call) RunAndReturn(run func(con
 	return _c
 }
 
-// ResetAPIToken provides a mock function with given fields: ctx, id
-func (_m *MockService) ResetAPIToken(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// IsPasswordExpired provides a mock function with given fields: ctx, u
+func (_m *MockService) IsPasswordExpired(ctx context.Context, u User) (bool, error) {
+	ret := _m.Called(ctx, u)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetAPIToken")
+		panic("no return value specified for IsPasswordExpired")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, User) (bool, error)); ok {
+		return rf(ctx, u)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, User) bool); ok {
+		r0 = rf(ctx, u)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, User) error); ok {
+		r1 = rf(ctx, u)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockService_ResetAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetAPIToken'
-type MockService_ResetAPIToken_Call struct {
+// MockService_IsPasswordExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsPasswordExpired'
+type MockService_IsPasswordExpired_Call struct {
 	*mock.Call
 }
 
-// ResetAPIToken is a helper method to define mock.On call
+// IsPasswordExpired is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockService_Expecter) ResetAPIToken(ctx interface{}, id interface{}) *MockService_ResetAPIToken_Call {
-	return &MockService_ResetAPIToken_Call{Call: _e.mock.On("ResetAPIToken", ctx, id)}
+//   - u User
+func (_e *MockService_Expecter) IsPasswordExpired(ctx interface{}, u interface{}) *MockService_IsPasswordExpired_Call {
+	return &MockService_IsPasswordExpired_Call{Call: _e.mock.On("IsPasswordExpired", ctx, u)}
 }
 
-func (_c *MockService_ResetAPIToken_Call) Run(run func(ctx context.Context, id int64)) *MockService_ResetAPIToken_Call {
+func (_c *MockService_IsPasswordExpired_Call) Run(run func(ctx context.Context, u User)) *MockService_IsPasswordExpired_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(User))
 	})
 	return _c
 }
 
-func (_c *MockService_ResetAPIToken_Call) Return(_a0 error) *MockService_ResetAPIToken_Call {
-	_c.Call.Return(_a0)
+func (_c *MockService_IsPasswordExpired_Call) Return(_a0 bool, _a1 error) *MockService_IsPasswordExpired_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockService_ResetAPIToken_Call) RunAndReturn(run func(context.Context, int64) error) *MockService_ResetAPIToken_Call {
+func (_c *MockService_IsPasswordExpired_Call) RunAndReturn(run func(context.Context, User) (bool, error)) *MockService_IsPasswordExpired_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -760,6 +821,160 @@ func (_c *MockService_SetEmailVerified_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// SetRole provides a mock function with given fields: ctx, id, roleID
+func (_m *MockService) SetRole(ctx context.Context, id int64, roleID int64) error {
+	ret := _m.Called(ctx, id, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type MockService_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - roleID int64
+func (_e *MockService_Expecter) SetRole(ctx interface{}, id interface{}, roleID interface{}) *MockService_SetRole_Call {
+	return &MockService_SetRole_Call{Call: _e.mock.On("SetRole", ctx, id, roleID)}
+}
+
+func (_c *MockService_SetRole_Call) Run(run func(ctx context.Context, id int64, roleID int64)) *MockService_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_SetRole_Call) Return(_a0 error) *MockService_SetRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_SetRole_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferOwnership provides a mock function with given fields: ctx, fromUserID, toUserID
+func (_m *MockService) TransferOwnership(ctx context.Context, fromUserID int64, toUserID int64) error {
+	ret := _m.Called(ctx, fromUserID, toUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferOwnership")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, fromUserID, toUserID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockService_TransferOwnership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferOwnership'
+type MockService_TransferOwnership_Call struct {
+	*mock.Call
+}
+
+// TransferOwnership is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromUserID int64
+//   - toUserID int64
+func (_e *MockService_Expecter) TransferOwnership(ctx interface{}, fromUserID interface{}, toUserID interface{}) *MockService_TransferOwnership_Call {
+	return &MockService_TransferOwnership_Call{Call: _e.mock.On("TransferOwnership", ctx, fromUserID, toUserID)}
+}
+
+func (_c *MockService_TransferOwnership_Call) Run(run func(ctx context.Context, fromUserID int64, toUserID int64)) *MockService_TransferOwnership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockService_TransferOwnership_Call) Return(_a0 error) *MockService_TransferOwnership_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockService_TransferOwnership_Call) RunAndReturn(run func(context.Context, int64, int64) error) *MockService_TransferOwnership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyPassword provides a mock function with given fields: ctx, u, password
+func (_m *MockService) VerifyPassword(ctx context.Context, u User, password string) (bool, error) {
+	ret := _m.Called(ctx, u, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPassword")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, User, string) (bool, error)); ok {
+		return rf(ctx, u, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, User, string) bool); ok {
+		r0 = rf(ctx, u, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, User, string) error); ok {
+		r1 = rf(ctx, u, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockService_VerifyPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyPassword'
+type MockService_VerifyPassword_Call struct {
+	*mock.Call
+}
+
+// VerifyPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - u User
+//   - password string
+func (_e *MockService_Expecter) VerifyPassword(ctx interface{}, u interface{}, password interface{}) *MockService_VerifyPassword_Call {
+	return &MockService_VerifyPassword_Call{Call: _e.mock.On("VerifyPassword", ctx, u, password)}
+}
+
+func (_c *MockService_VerifyPassword_Call) Run(run func(ctx context.Context, u User, password string)) *MockService_VerifyPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(User), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockService_VerifyPassword_Call) Return(_a0 bool, _a1 error) *MockService_VerifyPassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockService_VerifyPassword_Call) RunAndReturn(run func(context.Context, User, string) (bool, error)) *MockService_VerifyPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockService(t interface {
@@ -4,18 +4,40 @@ import (
 	"context"
 	"database/sql"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// passwordHasher is an argon2id hasher with the minimum cost to keep the tests fast.
+var passwordHasher = user.NewArgon2idPasswordHasher(config.Config{
+	PasswordArgon2Memory:      64,
+	PasswordArgon2Iterations:  1,
+	PasswordArgon2Parallelism: 1,
+})
+
+// newPolicyService returns a password policy service mock which returns the given policy for the organization.
+func newPolicyService(t *testing.T, p passwordpolicy.Policy) *passwordpolicy.MockService {
+	t.Helper()
+
+	policyService := passwordpolicy.NewMockService(t)
+	policyService.On("GetPolicy", context.Background(), p.OrganizationID).Return(p, nil)
+
+	return policyService
+}
+
 func TestService_GetUserByID(t *testing.T) {
 	t.Parallel()
 
@@ -23,7 +45,7 @@ func TestService_GetUserByID(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
 			Return(user.User{}, assert.AnError)
@@ -37,7 +59,7 @@ func TestService_GetUserByID(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
 			Return(user.User{}, sql.ErrNoRows)
@@ -52,7 +74,7 @@ func TestService_GetUserByID(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		u := user.User{
 			ID:             gofakeit.Int64(),
@@ -69,43 +91,57 @@ func TestService_GetUserByID(t *testing.T) {
 	})
 }
 
-func TestService_GetUserByAPIToken(t *testing.T) {
+func TestService_GetUserByOrgIDEmail(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
 
-		mockRepo.On("GetUserByAPIToken", context.Background(), "token").
+		mockRepo.On("GetUserByOrgIDEmail", context.Background(), int64(1), email).
 			Return(user.User{}, assert.AnError)
 
-		_, err := service.GetUserByAPIToken(context.Background(), "token")
+		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return error when user not found for the given api token", func(t *testing.T) {
+	t.Run("should return error when user not found for the given organization id and email", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
 
-		mockRepo.On("GetUserByAPIToken", context.Background(), "token").
+		mockRepo.On("GetUserByOrgIDEmail", context.Background(), int64(1), email).
 			Return(user.User{}, sql.ErrNoRows)
 
-		_, err := service.GetUserByAPIToken(context.Background(), "token")
+		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
 		require.Error(t, err)
 		require.IsType(t, &base.NotFoundError{}, err)
-		assert.ErrorContains(t, err, "user not found for the given api-token")
+		assert.ErrorContains(t, err, "user not found for the given org-id and email")
+	})
+
+	t.Run("should return error when email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := "invalid@invalid"
+
+		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "email must be a valid email address")
 	})
 
-	t.Run("should return user by api token", func(t *testing.T) {
+	t.Run("should return user by organization id and email", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		u := user.User{
 			ID:             gofakeit.Int64(),
@@ -113,63 +149,78 @@ func TestService_GetUserByAPIToken(t *testing.T) {
 			Email:          gofakeit.Email(),
 		}
 
-		mockRepo.On("GetUserByAPIToken", context.Background(), "token").
+		mockRepo.On("GetUserByOrgIDEmail", context.Background(), u.ID, u.Email).
 			Return(u, nil)
 
-		result, err := service.GetUserByAPIToken(context.Background(), "token")
+		result, err := service.GetUserByOrgIDEmail(context.Background(), u.ID, u.Email)
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestService_GetUserByOrgSubdomainAPIToken(t *testing.T) {
+func TestService_GetUserByOrgSubdomainEmail(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
 
-		mockRepo.On("GetUserByOrgSubdomainAPIToken", context.Background(), "subdomain", "token").
+		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
 			Return(user.User{}, assert.AnError)
 
-		_, err := service.GetUserByOrgSubdomainAPIToken(context.Background(), "subdomain", "token")
+		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return error when user not found for the given subdomain and api token", func(t *testing.T) {
+	t.Run("should return error when user not found for the given subdomain and email", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
 
-		mockRepo.On("GetUserByOrgSubdomainAPIToken", context.Background(), "subdomain", "token").
+		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
 			Return(user.User{}, sql.ErrNoRows)
 
-		_, err := service.GetUserByOrgSubdomainAPIToken(context.Background(), "subdomain", "token")
+		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
 		require.Error(t, err)
 		require.IsType(t, &base.NotFoundError{}, err)
-		assert.ErrorContains(t, err, "user not found for the given org-subdomain and api-token")
+		assert.ErrorContains(t, err, "user not found for the given org-subdomain and email")
 	})
 
 	t.Run("should return error when subdomain is invalid", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		_, err := service.GetUserByOrgSubdomainAPIToken(context.Background(), "invalid_sub", "token")
+		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "@#invalid", gofakeit.Email())
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "subdomain can only contain alphanumeric characters")
 	})
 
-	t.Run("should return user by organization subdomain and api token", func(t *testing.T) {
+	t.Run("should return error when email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := ""
+
+		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "email is required")
+	})
+
+	t.Run("should return user by organization subdomain and email", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
 
 		u := user.User{
 			ID:             gofakeit.Int64(),
@@ -177,225 +228,185 @@ func TestService_GetUserByOrgSubdomainAPIToken(t *testing.T) {
 			Email:          gofakeit.Email(),
 		}
 
-		mockRepo.On("GetUserByOrgSubdomainAPIToken", context.Background(), "subdomain", "token").
+		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
 			Return(u, nil)
 
-		result, err := service.GetUserByOrgSubdomainAPIToken(context.Background(), "subdomain", "token")
+		result, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestService_GetUserByOrgIDEmail(t *testing.T) {
+func TestService_GetDeletedOwnerByOrgID(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := gofakeit.Email()
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("GetUserByOrgIDEmail", context.Background(), int64(1), email).
+		mockRepo.On("GetDeletedOwnerByOrgID", context.Background(), int64(1)).
 			Return(user.User{}, assert.AnError)
 
-		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
+		_, err := service.GetDeletedOwnerByOrgID(context.Background(), int64(1))
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return error when user not found for the given organization id and email", func(t *testing.T) {
+	t.Run("should return base.NotFoundError when deleted owner not found", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := gofakeit.Email()
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("GetUserByOrgIDEmail", context.Background(), int64(1), email).
+		mockRepo.On("GetDeletedOwnerByOrgID", context.Background(), int64(1)).
 			Return(user.User{}, sql.ErrNoRows)
 
-		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
+		_, err := service.GetDeletedOwnerByOrgID(context.Background(), int64(1))
 		require.Error(t, err)
 		require.IsType(t, &base.NotFoundError{}, err)
-		assert.ErrorContains(t, err, "user not found for the given org-id and email")
-	})
-
-	t.Run("should return error when email is invalid", func(t *testing.T) {
-		t.Parallel()
-
-		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := "invalid@invalid"
-
-		_, err := service.GetUserByOrgIDEmail(context.Background(), int64(1), email)
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "email must be a valid email address")
+		assert.ErrorContains(t, err, "deleted owner not found for the given org-id")
 	})
 
-	t.Run("should return user by organization id and email", func(t *testing.T) {
+	t.Run("should return the deleted owner by organization id", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		u := user.User{
 			ID:             gofakeit.Int64(),
-			OrganizationID: gofakeit.Int64(),
+			OrganizationID: 1,
 			Email:          gofakeit.Email(),
+			IsOwner:        true,
 		}
 
-		mockRepo.On("GetUserByOrgIDEmail", context.Background(), u.ID, u.Email).
+		mockRepo.On("GetDeletedOwnerByOrgID", context.Background(), int64(1)).
 			Return(u, nil)
 
-		result, err := service.GetUserByOrgIDEmail(context.Background(), u.ID, u.Email)
+		result, err := service.GetDeletedOwnerByOrgID(context.Background(), int64(1))
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestService_GetUserByOrgSubdomainEmail(t *testing.T) {
+func TestService_CreateUser(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := gofakeit.Email()
+		password := generatePassword()
 
-		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
+		u := user.User{
+			OrganizationID: gofakeit.Int64(),
+			Email:          gofakeit.Email(),
+			PasswordHash:   gofakeit.UUID(),
+		}
+
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
+		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, false).
 			Return(user.User{}, assert.AnError)
 
-		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
+		_, err := service.CreateUser(context.Background(), u.OrganizationID, u.Email, password)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should return error when user not found for the given subdomain and email", func(t *testing.T) {
+	t.Run("should return error when email is invalid", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := gofakeit.Email()
-
-		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
-			Return(user.User{}, sql.ErrNoRows)
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
+		password := generatePassword()
 
-		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
+		_, err := service.CreateUser(context.Background(), int64(1), "invalid", password)
 		require.Error(t, err)
-		require.IsType(t, &base.NotFoundError{}, err)
-		assert.ErrorContains(t, err, "user not found for the given org-subdomain and email")
+		assert.ErrorContains(t, err, "email must be a valid email address")
 	})
 
-	t.Run("should return error when subdomain is invalid", func(t *testing.T) {
+	t.Run("should return error when password is invalid", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(1))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
 
-		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "@#invalid", gofakeit.Email())
+		_, err := service.CreateUser(context.Background(), int64(1), gofakeit.Email(), "invalid")
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "subdomain can only contain alphanumeric characters")
+		assert.ErrorContains(t, err, "password must be at least 8 characters in length")
 	})
 
-	t.Run("should return error when email is invalid", func(t *testing.T) {
+	t.Run("should return error when password does not satisfy the organization policy", func(t *testing.T) {
 		t.Parallel()
 
+		policy := passwordpolicy.DefaultPolicy(gofakeit.Int64())
+		policy.MinLength = 16
+
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := ""
+		service := user.NewService(mockRepo, nil, passwordHasher, newPolicyService(t, policy))
 
-		_, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
+		_, err := service.CreateUser(context.Background(), policy.OrganizationID, gofakeit.Email(), generatePassword())
 		require.Error(t, err)
-		assert.ErrorContains(t, err, "email is required")
+		assert.ErrorContains(t, err, "password must be at least 16 characters in length")
 	})
 
-	t.Run("should return user by organization subdomain and email", func(t *testing.T) {
+	t.Run("should create user", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		email := gofakeit.Email()
+		password := generatePassword()
 
 		u := user.User{
-			ID:             gofakeit.Int64(),
 			OrganizationID: gofakeit.Int64(),
 			Email:          gofakeit.Email(),
+			PasswordHash:   gofakeit.UUID(),
 		}
 
-		mockRepo.On("GetUserByOrgSubdomainEmail", context.Background(), "subdomain", email).
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
+		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, false).
 			Return(u, nil)
 
-		result, err := service.GetUserByOrgSubdomainEmail(context.Background(), "subdomain", email)
+		result, err := service.CreateUser(context.Background(), u.OrganizationID, u.Email, password)
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
 }
 
-func TestService_CreateUser(t *testing.T) {
+func TestService_CreateExternalUser(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should return error when repository return error", func(t *testing.T) {
-		t.Parallel()
-
-		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		password := generatePassword()
-
-		u := user.User{
-			OrganizationID: gofakeit.Int64(),
-			Email:          gofakeit.Email(),
-			PasswordHash:   gofakeit.UUID(),
-		}
-
-		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, false).
-			Return(user.User{}, assert.AnError)
-
-		_, err := service.CreateUser(context.Background(), u.OrganizationID, u.Email, password)
-		require.Error(t, err)
-		assert.ErrorIs(t, assert.AnError, err)
-	})
-
 	t.Run("should return error when email is invalid", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		password := generatePassword()
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
 
-		_, err := service.CreateUser(context.Background(), int64(1), "invalid", password)
+		_, err := service.CreateExternalUser(context.Background(), int64(1), "invalid")
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "email must be a valid email address")
 	})
 
-	t.Run("should return error when password is invalid", func(t *testing.T) {
-		t.Parallel()
-
-		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-
-		_, err := service.CreateUser(context.Background(), int64(1), gofakeit.Email(), "invalid")
-		require.Error(t, err)
-		assert.ErrorContains(t, err, "password must be at least 8 characters in length")
-	})
-
-	t.Run("should create user", func(t *testing.T) {
+	t.Run("should create user without password", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
-		password := generatePassword()
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
 
 		u := user.User{
 			OrganizationID: gofakeit.Int64(),
 			Email:          gofakeit.Email(),
-			PasswordHash:   gofakeit.UUID(),
 		}
 
-		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, false).
-			Return(u, nil)
+		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, "", false).Return(u, nil)
 
-		result, err := service.CreateUser(context.Background(), u.OrganizationID, u.Email, password)
+		result, err := service.CreateExternalUser(context.Background(), u.OrganizationID, u.Email)
 		require.NoError(t, err)
 		assert.Equal(t, u, result)
 	})
@@ -408,7 +419,6 @@ func TestService_CreateOwner(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
 		password := generatePassword()
 
 		u := user.User{
@@ -417,6 +427,9 @@ func TestService_CreateOwner(t *testing.T) {
 			PasswordHash:   gofakeit.UUID(),
 		}
 
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
 		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, true).
 			Return(user.User{}, assert.AnError)
 
@@ -429,7 +442,7 @@ func TestService_CreateOwner(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
 		password := generatePassword()
 
 		_, err := service.CreateOwner(context.Background(), int64(1), "invalid", password)
@@ -441,7 +454,8 @@ func TestService_CreateOwner(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(1))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
 
 		_, err := service.CreateOwner(context.Background(), int64(1), gofakeit.Email(), "invalid123")
 		require.Error(t, err)
@@ -452,7 +466,6 @@ func TestService_CreateOwner(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
 		password := generatePassword()
 
 		u := user.User{
@@ -461,6 +474,9 @@ func TestService_CreateOwner(t *testing.T) {
 			PasswordHash:   gofakeit.UUID(),
 		}
 
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
 		mockRepo.On("CreateUser", context.Background(), u.OrganizationID, u.Email, fake.MockString, true).
 			Return(u, nil)
 
@@ -476,14 +492,18 @@ func TestService_ResetPassword(t *testing.T) {
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
 		password := generatePassword()
 
-		mockRepo.On("ResetPassword", context.Background(), int64(1), fake.MockString).
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		mockRepo.On("ResetPassword", ctx, u.ID, fake.MockString).
 			Return(assert.AnError)
 
-		err := service.ResetPassword(context.Background(), int64(1), password)
+		err := service.ResetPassword(ctx, u.ID, password)
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
@@ -491,25 +511,133 @@ func TestService_ResetPassword(t *testing.T) {
 	t.Run("should return error when password is invalid", func(t *testing.T) {
 		t.Parallel()
 
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
 
-		err := service.ResetPassword(context.Background(), int64(1), "Invalid123")
+		err := service.ResetPassword(ctx, u.ID, "Invalid123")
 		require.Error(t, err)
 		assert.ErrorContains(t, err, "password must contain at least one special character")
 	})
 
+	t.Run("should return error when password is one of the recent passwords", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		password := generatePassword()
+		previousPasswordHash, err := passwordHasher.Hash(password)
+		require.NoError(t, err)
+
+		currentPasswordHash, err := passwordHasher.Hash(generatePassword())
+		require.NoError(t, err)
+
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), PasswordHash: currentPasswordHash}
+		policy := passwordpolicy.DefaultPolicy(u.OrganizationID)
+		policy.HistoryCount = 3
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, passwordHasher, newPolicyService(t, policy))
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		mockRepo.On("ListPasswordHistory", ctx, u.ID, 2).Return([]string{previousPasswordHash}, nil)
+
+		err = service.ResetPassword(ctx, u.ID, password)
+		require.ErrorIs(t, err, user.ErrPasswordReused)
+	})
+
 	t.Run("should reset password", func(t *testing.T) {
 		t.Parallel()
 
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
 		password := generatePassword()
 
-		mockRepo.On("ResetPassword", context.Background(), int64(1), fake.MockString).
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		mockRepo.On("ResetPassword", ctx, u.ID, fake.MockString).
 			Return(nil)
 
-		err := service.ResetPassword(context.Background(), int64(1), password)
+		err := service.ResetPassword(ctx, u.ID, password)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	currentPassword := generatePassword()
+	currentPasswordHash, err := bcrypt.GenerateFromPassword([]byte(currentPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	t.Run("should return error when the current password is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: string(currentPasswordHash)}
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		err := service.ChangePassword(ctx, u.ID, gofakeit.UUID(), "Wrong@123", generatePassword())
+		require.ErrorIs(t, err, user.ErrInvalidCurrentPassword)
+	})
+
+	t.Run("should return error when the new password is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), PasswordHash: string(currentPasswordHash)}
+		mockRepo := user.NewMockRepository(t)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, nil, passwordHasher, policyService)
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		err := service.ChangePassword(ctx, u.ID, gofakeit.UUID(), currentPassword, "invalid")
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should return error when the new password is the current password", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), PasswordHash: string(currentPasswordHash)}
+		policy := passwordpolicy.DefaultPolicy(u.OrganizationID)
+		policy.HistoryCount = 1
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, passwordHasher, newPolicyService(t, policy))
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+
+		err := service.ChangePassword(ctx, u.ID, gofakeit.UUID(), currentPassword, currentPassword)
+		require.ErrorIs(t, err, user.ErrPasswordReused)
+	})
+
+	t.Run("should change the password and delete the other sessions", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		sessionID := gofakeit.UUID()
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), PasswordHash: string(currentPasswordHash)}
+		mockRepo := user.NewMockRepository(t)
+		sessionManager := session.NewMockSessionManager(t)
+		policyService := newPolicyService(t, passwordpolicy.DefaultPolicy(u.OrganizationID))
+		service := user.NewService(mockRepo, sessionManager, passwordHasher, policyService)
+
+		mockRepo.On("GetUserByID", ctx, u.ID).Return(u, nil)
+		mockRepo.On("ResetPassword", ctx, u.ID, fake.MockString).Return(nil)
+		sessionManager.On("DeleteOtherSessions", ctx, u.ID, u.OrganizationID, sessionID).Return(nil)
+
+		err := service.ChangePassword(ctx, u.ID, sessionID, currentPassword, generatePassword())
 		require.NoError(t, err)
 	})
 }
@@ -521,7 +649,7 @@ func TestService_DeleteUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		err := service.DeleteUser(context.Background(), int64(1), "")
 		require.Error(t, err)
@@ -533,7 +661,7 @@ func TestService_DeleteUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -548,7 +676,7 @@ func TestService_DeleteUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -565,7 +693,7 @@ func TestService_DeleteUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -581,7 +709,7 @@ func TestService_DeleteUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		u := user.User{
@@ -604,7 +732,7 @@ func TestService_DeleteUser(t *testing.T) {
 
 		mockRepo := user.NewMockRepository(t)
 		sessionManager := session.NewMockSessionManager(t)
-		service := user.NewService(mockRepo, sessionManager)
+		service := user.NewService(mockRepo, sessionManager, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		u := user.User{
@@ -630,7 +758,7 @@ func TestService_DeleteUser(t *testing.T) {
 
 		mockRepo := user.NewMockRepository(t)
 		sessionManager := session.NewMockSessionManager(t)
-		service := user.NewService(mockRepo, sessionManager)
+		service := user.NewService(mockRepo, sessionManager, nil, nil)
 		comment := gofakeit.Sentence(5)
 
 		u := user.User{
@@ -658,7 +786,7 @@ func TestService_DisableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		err := service.DisableUser(context.Background(), int64(1), "")
 		require.Error(t, err)
@@ -670,7 +798,7 @@ func TestService_DisableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -685,7 +813,7 @@ func TestService_DisableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -702,7 +830,7 @@ func TestService_DisableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		mockRepo.On("GetUserByID", context.Background(), int64(1)).
@@ -718,7 +846,7 @@ func TestService_DisableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		u := user.User{
@@ -741,7 +869,7 @@ func TestService_DisableUser(t *testing.T) {
 
 		mockRepo := user.NewMockRepository(t)
 		sessionManager := session.NewMockSessionManager(t)
-		service := user.NewService(mockRepo, sessionManager)
+		service := user.NewService(mockRepo, sessionManager, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		u := user.User{
@@ -767,7 +895,7 @@ func TestService_DisableUser(t *testing.T) {
 
 		mockRepo := user.NewMockRepository(t)
 		sessionManager := session.NewMockSessionManager(t)
-		service := user.NewService(mockRepo, sessionManager)
+		service := user.NewService(mockRepo, sessionManager, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		u := user.User{
@@ -795,7 +923,7 @@ func TestService_EnableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
 		err := service.DisableUser(context.Background(), int64(1), "")
 		require.Error(t, err)
@@ -807,7 +935,7 @@ func TestService_EnableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		mockRepo.On("EnableUser", context.Background(), int64(1), comment).
@@ -822,7 +950,7 @@ func TestService_EnableUser(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 		comment := gofakeit.SentenceSimple()
 
 		mockRepo.On("EnableUser", context.Background(), int64(1), comment).
@@ -833,95 +961,141 @@ func TestService_EnableUser(t *testing.T) {
 	})
 }
 
-func TestService_GenerateAPIToken(t *testing.T) {
+func TestService_SetEmailVerified(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("GenerateAPIToken", context.Background(), int64(1)).
+		mockRepo.On("SetEmailVerified", context.Background(), int64(1)).
 			Return(assert.AnError)
 
-		err := service.GenerateAPIToken(context.Background(), int64(1))
+		err := service.SetEmailVerified(context.Background(), int64(1))
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should generate api token", func(t *testing.T) {
+	t.Run("should set email verified", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("GenerateAPIToken", context.Background(), int64(1)).
+		mockRepo.On("SetEmailVerified", context.Background(), int64(1)).
 			Return(nil)
 
-		err := service.GenerateAPIToken(context.Background(), int64(1))
+		err := service.SetEmailVerified(context.Background(), int64(1))
 		require.NoError(t, err)
 	})
 }
 
-func TestService_ResetAPIToken(t *testing.T) {
+func TestService_SetRole(t *testing.T) {
 	t.Parallel()
 
 	t.Run("should return error when repository return error", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("ResetAPIToken", context.Background(), int64(1)).
+		mockRepo.On("SetRole", context.Background(), int64(1), int64(2)).
 			Return(assert.AnError)
 
-		err := service.ResetAPIToken(context.Background(), int64(1))
+		err := service.SetRole(context.Background(), int64(1), int64(2))
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should reset api token", func(t *testing.T) {
+	t.Run("should set role", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("ResetAPIToken", context.Background(), int64(1)).
+		mockRepo.On("SetRole", context.Background(), int64(1), int64(2)).
 			Return(nil)
 
-		err := service.ResetAPIToken(context.Background(), int64(1))
+		err := service.SetRole(context.Background(), int64(1), int64(2))
 		require.NoError(t, err)
 	})
 }
 
-func TestService_SetEmailVerified(t *testing.T) {
+func TestService_TransferOwnership(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should return error when repository return error", func(t *testing.T) {
+	t.Run("should return error when clearing the current owner fails", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("SetEmailVerified", context.Background(), int64(1)).
+		mockRepo.On("SetOwner", context.Background(), int64(1), false).
 			Return(assert.AnError)
 
-		err := service.SetEmailVerified(context.Background(), int64(1))
+		err := service.TransferOwnership(context.Background(), int64(1), int64(2))
 		require.Error(t, err)
 		assert.ErrorIs(t, assert.AnError, err)
 	})
 
-	t.Run("should set email verified", func(t *testing.T) {
+	t.Run("should return error when setting the new owner fails", func(t *testing.T) {
 		t.Parallel()
 
 		mockRepo := user.NewMockRepository(t)
-		service := user.NewService(mockRepo, nil)
+		service := user.NewService(mockRepo, nil, nil, nil)
 
-		mockRepo.On("SetEmailVerified", context.Background(), int64(1)).
+		mockRepo.On("SetOwner", context.Background(), int64(1), false).
 			Return(nil)
+		mockRepo.On("SetOwner", context.Background(), int64(2), true).
+			Return(assert.AnError)
 
-		err := service.SetEmailVerified(context.Background(), int64(1))
+		err := service.TransferOwnership(context.Background(), int64(1), int64(2))
+		require.Error(t, err)
+		assert.ErrorIs(t, assert.AnError, err)
+	})
+
+	t.Run("should clear the current owner before setting the new owner", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, nil, nil)
+
+		clearCall := mockRepo.On("SetOwner", context.Background(), int64(1), false).
+			Return(nil)
+		mockRepo.On("SetOwner", context.Background(), int64(2), true).
+			Return(nil).NotBefore(clearCall)
+
+		err := service.TransferOwnership(context.Background(), int64(1), int64(2))
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ChangeEmail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return error when the email is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		service := user.NewService(user.NewMockRepository(t), nil, nil, nil)
+
+		err := service.ChangeEmail(context.Background(), int64(1), "invalid")
+		require.Error(t, err)
+		assert.True(t, base.IsInputValidationError(err))
+	})
+
+	t.Run("should change the email", func(t *testing.T) {
+		t.Parallel()
+
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, nil, nil)
+		email := gofakeit.Email()
+
+		mockRepo.On("ChangeEmail", context.Background(), int64(1), email).
+			Return(nil)
+
+		err := service.ChangeEmail(context.Background(), int64(1), email)
 		require.NoError(t, err)
 	})
 }
@@ -960,3 +1134,138 @@ func generatePassword() string {
 
 	return string(password)
 }
+
+func TestService_VerifyPassword(t *testing.T) {
+	t.Parallel()
+
+	password := generatePassword()
+
+	t.Run("should return false when the password does not match", func(t *testing.T) {
+		t.Parallel()
+
+		passwordHash, err := passwordHasher.Hash(password)
+		require.NoError(t, err)
+
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: passwordHash}
+		service := user.NewService(user.NewMockRepository(t), nil, passwordHasher, nil)
+
+		matched, err := service.VerifyPassword(context.Background(), u, "Wrong@123")
+		require.NoError(t, err)
+		assert.False(t, matched)
+	})
+
+	t.Run("should not re-hash the password when the hash is up to date", func(t *testing.T) {
+		t.Parallel()
+
+		passwordHash, err := passwordHasher.Hash(password)
+		require.NoError(t, err)
+
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: passwordHash}
+		service := user.NewService(user.NewMockRepository(t), nil, passwordHasher, nil)
+
+		matched, err := service.VerifyPassword(context.Background(), u, password)
+		require.NoError(t, err)
+		assert.True(t, matched)
+	})
+
+	t.Run("should re-hash the password using argon2id when the hash is bcrypt", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		var newPasswordHash string
+
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: string(passwordHash)}
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
+
+		mockRepo.On("UpdatePasswordHash", ctx, u.ID, fake.MockString).
+			Run(func(args mock.Arguments) {
+				newPasswordHash = args.String(2)
+			}).
+			Return(nil)
+
+		matched, err := service.VerifyPassword(ctx, u, password)
+		require.NoError(t, err)
+		assert.True(t, matched)
+		assert.True(t, strings.HasPrefix(newPasswordHash, "$argon2id$"))
+
+		matched, err = passwordHasher.Verify(newPasswordHash, password)
+		require.NoError(t, err)
+		assert.True(t, matched)
+	})
+
+	t.Run("should return true when the upgrade of the hash fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		u := user.User{ID: gofakeit.Int64(), PasswordHash: string(passwordHash)}
+		mockRepo := user.NewMockRepository(t)
+		service := user.NewService(mockRepo, nil, passwordHasher, nil)
+
+		mockRepo.On("UpdatePasswordHash", ctx, u.ID, fake.MockString).Return(assert.AnError)
+
+		matched, err := service.VerifyPassword(ctx, u, password)
+		require.NoError(t, err)
+		assert.True(t, matched)
+	})
+}
+
+func TestService_IsPasswordExpired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return false when the user has no password", func(t *testing.T) {
+		t.Parallel()
+
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64()}
+		service := user.NewService(user.NewMockRepository(t), nil, passwordHasher, nil)
+
+		expired, err := service.IsPasswordExpired(context.Background(), u)
+		require.NoError(t, err)
+		assert.False(t, expired)
+	})
+
+	t.Run("should return error when the policy can not be fetched", func(t *testing.T) {
+		t.Parallel()
+
+		u := user.User{ID: gofakeit.Int64(), OrganizationID: gofakeit.Int64(), PasswordHash: gofakeit.UUID()}
+		policyService := passwordpolicy.NewMockService(t)
+		service := user.NewService(user.NewMockRepository(t), nil, passwordHasher, policyService)
+
+		policyService.On("GetPolicy", context.Background(), u.OrganizationID).
+			Return(passwordpolicy.Policy{}, assert.AnError)
+
+		_, err := service.IsPasswordExpired(context.Background(), u)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("should return whether the password exceeded the maximum age", func(t *testing.T) {
+		t.Parallel()
+
+		u := user.User{
+			ID:                gofakeit.Int64(),
+			OrganizationID:    gofakeit.Int64(),
+			PasswordHash:      gofakeit.UUID(),
+			PasswordChangedAt: time.Now().UTC().AddDate(0, 0, -31),
+		}
+		policy := passwordpolicy.DefaultPolicy(u.OrganizationID)
+		policy.MaxAgeDays = 30
+
+		service := user.NewService(user.NewMockRepository(t), nil, passwordHasher, newPolicyService(t, policy))
+
+		expired, err := service.IsPasswordExpired(context.Background(), u)
+		require.NoError(t, err)
+		assert.True(t, expired)
+
+		u.PasswordChangedAt = time.Now().UTC().AddDate(0, 0, -29)
+
+		expired, err = service.IsPasswordExpired(context.Background(), u)
+		require.NoError(t, err)
+		assert.False(t, expired)
+	})
+}
@@ -5,18 +5,15 @@ import _ "embed"
 //go:embed sql/get_user_by_id.sql
 var getUserByIDQuery string
 
-//go:embed sql/get_user_by_api_token.sql
-var getUserByAPITokenQuery string
-
-//go:embed sql/get_user_by_org_subdomain_api_token.sql
-var getUserByOrgSubdomainAPITokenQuery string
-
 //go:embed sql/get_user_by_org_id_email.sql
 var getUserByOrgIDEmailQuery string
 
 //go:embed sql/get_user_by_org_subdomain_email.sql
 var getUserByOrgSubdomainEmailQuery string
 
+//go:embed sql/get_deleted_owner_by_org_id.sql
+var getDeletedOwnerByOrgIDQuery string
+
 //go:embed sql/create_user.sql
 var createUserQuery string
 
@@ -32,11 +29,20 @@ var disableUserQuery string
 //go:embed sql/enable_user.sql
 var enableUserQuery string
 
-//go:embed sql/genereate_api_token.sql
-var generateAPITokenQuery string
-
-//go:embed sql/reset_api_token.sql
-var resetAPITokenQuery string
-
 //go:embed sql/set_email_verified.sql
 var setEmailVerifiedQuery string
+
+//go:embed sql/set_user_role.sql
+var setUserRoleQuery string
+
+//go:embed sql/set_user_owner.sql
+var setUserOwnerQuery string
+
+//go:embed sql/change_email.sql
+var changeEmailQuery string
+
+//go:embed sql/update_password_hash.sql
+var updatePasswordHashQuery string
+
+//go:embed sql/list_password_history.sql
+var listPasswordHistoryQuery string
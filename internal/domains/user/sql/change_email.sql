@@ -0,0 +1,12 @@
+-- changeEmailQuery
+-- $1 - user_id
+-- $2 - email
+UPDATE
+    users
+SET
+    email = $2,
+    is_email_verified = true,
+    updated_at = now()
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL;
@@ -4,15 +4,38 @@
 -- $3 - password_hash
 -- $4 - is_owner
 INSERT INTO
-    users(organization_id, email, password_hash, is_owner, is_email_verified)
+    users(
+        organization_id,
+        email,
+        password_hash,
+        is_owner,
+        is_email_verified,
+        role_id
+    )
 VALUES
-    ($1, $2, $3, $4, false) RETURNING
+    (
+        $1,
+        $2,
+        $3,
+        $4,
+        false,
+        (
+            SELECT
+                role_id
+            FROM
+                roles
+            WHERE
+                organization_id IS NULL
+                AND name = CASE WHEN $4 THEN 'owner' ELSE 'employee' END
+        )
+    ) RETURNING
     user_id,
     organization_id,
     email,
     password_hash,
-    api_token,
+    password_changed_at,
     is_owner,
+    role_id,
     is_email_verified,
     disabled_at,
     comment,
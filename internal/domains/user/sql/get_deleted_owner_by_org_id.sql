@@ -1,12 +1,13 @@
--- getUserByAPITokenQuery
--- $1: api_token
+-- getDeletedOwnerByOrgIDQuery
+-- $1: organization_id
 SELECT
     user_id,
     organization_id,
     email,
     password_hash,
-    api_token,
+    password_changed_at,
     is_owner,
+    role_id,
     is_email_verified,
     disabled_at,
     comment,
@@ -16,5 +17,6 @@ SELECT
 FROM
     users
 WHERE
-    api_token = $1
-    AND deleted_at IS NULL;
+    organization_id = $1
+    AND is_owner = TRUE
+    AND deleted_at IS NOT NULL;
@@ -5,8 +5,9 @@ SELECT
     organization_id,
     email,
     password_hash,
-    api_token,
+    password_changed_at,
     is_owner,
+    role_id,
     is_email_verified,
     disabled_at,
     comment,
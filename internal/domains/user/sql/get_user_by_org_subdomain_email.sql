@@ -6,8 +6,9 @@ SELECT
     u.organization_id,
     u.email,
     u.password_hash,
-    u.api_token,
+    u.password_changed_at,
     u.is_owner,
+    u.role_id,
     is_email_verified,
     u.disabled_at,
     u.comment,
@@ -0,0 +1,14 @@
+-- listPasswordHistoryQuery
+-- $1 - user_id
+-- $2 - limit
+SELECT
+    password_hash
+FROM
+    password_histories
+WHERE
+    user_id = $1
+ORDER BY
+    created_at DESC,
+    password_history_id DESC
+LIMIT
+    $2;
@@ -1,9 +1,26 @@
 -- resetPasswordQuery
 -- $1 - user_id
 -- $2 - password_hash
+-- the previous password hash is kept in the history to prevent its reuse.
+-- both statements see the row before the update
+WITH previous AS (
+    INSERT INTO
+        password_histories(user_id, password_hash)
+    SELECT
+        user_id,
+        password_hash
+    FROM
+        users
+    WHERE
+        user_id = $1
+        AND password_hash <> ''
+        AND deleted_at IS NULL
+        AND disabled_at IS NULL
+)
 UPDATE users
 SET
     password_hash = $2,
+    password_changed_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
     updated_at = now()
 WHERE
     user_id = $1
@@ -0,0 +1,20 @@
+-- setUserOwnerQuery
+-- $1 - user_id
+-- $2 - is_owner
+UPDATE
+    users
+SET
+    is_owner = $2,
+    role_id = (
+        SELECT
+            role_id
+        FROM
+            roles
+        WHERE
+            organization_id IS NULL
+            AND name = CASE WHEN $2 THEN 'owner' ELSE 'admin' END
+    ),
+    updated_at = now()
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL;
@@ -0,0 +1,11 @@
+-- setUserRoleQuery
+-- $1 - user_id
+-- $2 - role_id
+UPDATE
+    users
+SET
+    role_id = $2,
+    updated_at = now()
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL;
@@ -20,12 +20,15 @@ type User struct {
 	// PasswordHash is the hashed password of the user.
 	PasswordHash string `db:"password_hash"`
 
-	// APIToken is the token used to authenticate the user for API requests.
-	APIToken *string `db:"api_token"`
+	// PasswordChangedAt is the timestamp when the password was last set.
+	PasswordChangedAt time.Time `db:"password_changed_at"`
 
 	// IsOwner represents whether the user is the owner of the organization.
 	IsOwner bool `db:"is_owner"`
 
+	// RoleID is the reference to the role which grants the permissions to the user.
+	RoleID int64 `db:"role_id"`
+
 	// IsEmailVerified represents whether the user's email is verified.
 	IsEmailVerified bool `db:"is_email_verified"`
 
@@ -37,3 +40,23 @@ type User struct {
 
 	base.Timestamps
 }
+
+type (
+	// ChangePasswordRequest represents the request payload to change the password of the authenticated user.
+	ChangePasswordRequest struct {
+		CurrentPassword string `json:"current_password" validate:"required"`
+		NewPassword     string `json:"new_password" validate:"required"`
+	}
+
+	// ProfileResponse represents the response payload of the profile of the authenticated user.
+	ProfileResponse struct {
+		ID              int64     `json:"id"`
+		OrganizationID  int64     `json:"organization_id"`
+		Email           string    `json:"email"`
+		IsOwner         bool      `json:"is_owner"`
+		RoleID          int64     `json:"role_id"`
+		IsEmailVerified bool      `json:"is_email_verified"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+)
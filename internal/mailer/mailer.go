@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/camelhr/log"
+)
+
+// Message represents an email message.
+type Message struct {
+	// To is the email address of the recipient.
+	To string
+
+	// Subject is the subject line of the email.
+	Subject string
+
+	// Body is the plain text body of the email.
+	Body string
+}
+
+// Mailer is an interface for sending emails.
+type Mailer interface {
+	// Send sends the given message to its recipient.
+	Send(ctx context.Context, msg Message) error
+}
+
+type logMailer struct{}
+
+// NewLogMailer creates a mailer that writes the messages to the log instead of sending them.
+// It is meant for local development only since the log would contain the email content.
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(_ context.Context, msg Message) error {
+	log.Info("sending email to: %s subject: %s body: %s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+type smtpMailer struct {
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer that sends the messages through the given smtp server.
+// The address must be in host:port format. Auth is skipped when the username is empty.
+func NewSMTPMailer(address, username, password, from string) Mailer {
+	var auth smtp.Auth
+
+	if username != "" {
+		host, _, _ := net.SplitHostPort(address)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{address, from, auth}
+}
+
+func (m *smtpMailer) Send(_ context.Context, msg Message) error {
+	var sb strings.Builder
+
+	sb.WriteString("From: " + m.from + "\r\n")
+	sb.WriteString("To: " + msg.To + "\r\n")
+	sb.WriteString("Subject: " + msg.Subject + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(msg.Body)
+
+	if err := smtp.SendMail(m.address, m.auth, m.from, []string{msg.To}, []byte(sb.String())); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", msg.To, err)
+	}
+
+	return nil
+}
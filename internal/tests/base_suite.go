@@ -1,10 +1,15 @@
 package tests
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 
 	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/auth"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
 )
@@ -12,6 +17,7 @@ import (
 type IntegrationBaseSuite struct {
 	suite.Suite
 	Config         config.Config
+	JWTKeys        *auth.KeySet
 	DB             database.Database
 	RedisClient    *redis.Client
 	RedisContainer *RedisContainer
@@ -31,9 +37,21 @@ func (s *IntegrationBaseSuite) SetupSuite() {
 	}()
 
 	s.Config = config.Config{
-		AppSecret: "test_secret",
+		AppSecret:     "test_secret",
+		BaseDomain:    "camelhr.com",
+		JWTSigningKey: generateJWTSigningKey(s.T()),
+		// the minimum argon2id cost keeps the password hashing fast in the tests
+		PasswordArgon2Memory:      64,
+		PasswordArgon2Iterations:  1,
+		PasswordArgon2Parallelism: 1,
+		// the oidc provider of the tests is served over http on the loopback address
+		SSOAllowInsecureIssuers: true,
 	}
 
+	jwtKeys, err := auth.NewKeySet(s.Config)
+	s.Require().NoError(err)
+	s.JWTKeys = jwtKeys
+
 	pgContainer, err := NewPostgresContainer()
 	s.Require().NoError(err)
 	s.PGContainer = pgContainer
@@ -65,3 +83,20 @@ func (s *IntegrationBaseSuite) TearDownSuite() {
 		s.T().Logf("error purging redis container: %v", err)
 	}
 }
+
+// generateJWTSigningKey returns a pem encoded ed25519 private key to sign the access tokens.
+func generateJWTSigningKey(t *testing.T) string {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate jwt signing key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal jwt signing key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
@@ -0,0 +1,118 @@
+package fake
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
+)
+
+// FakeAPIToken is a fake api token for testing.
+// It embeds the apitoken.APIToken struct to inherit its fields.
+type FakeAPIToken struct {
+	apitoken.APIToken
+
+	// Token is the plaintext token. Use it to authenticate the requests.
+	Token string
+}
+
+// APITokenOption is a function that modifies an api token's default values.
+type APITokenOption func(*FakeAPIToken) (*FakeAPIToken, error)
+
+// APITokenScopes sets/overrides the default scopes of an api token.
+func APITokenScopes(scopes ...string) APITokenOption {
+	return func(t *FakeAPIToken) (*FakeAPIToken, error) {
+		t.Scopes = strings.Join(scopes, ",")
+		return t, nil
+	}
+}
+
+// APITokenExpired sets expires_at to a timestamp in the past.
+func APITokenExpired() APITokenOption {
+	return func(t *FakeAPIToken) (*FakeAPIToken, error) {
+		t.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+		return t, nil
+	}
+}
+
+// NewAPIToken creates a fake api token of the user for testing.
+func NewAPIToken(db database.Database, userID int64, options ...APITokenOption) *FakeAPIToken {
+	t := &FakeAPIToken{}
+	t.UserID = userID
+	t.setDefaults()
+
+	var err error
+	for _, fn := range options {
+		t, err = fn(t)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if err := t.persist(db); err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// setDefaults sets the default values of a fake api token.
+// The token is granted all the scopes by default.
+func (t *FakeAPIToken) setDefaults() {
+	token, err := base.GenerateRandomToken()
+	if err != nil {
+		panic(err)
+	}
+
+	t.Token = token
+	t.Name = gofakeit.UUID()
+	t.TokenHash = base.HashToken(token)
+	t.Scopes = strings.Join([]string{
+		apitoken.ScopeOrganizationRead, apitoken.ScopeOrganizationWrite,
+		apitoken.ScopeUsersRead, apitoken.ScopeUsersWrite,
+		apitoken.ScopeEmployeesRead, apitoken.ScopeEmployeesWrite,
+	}, ",")
+	t.ExpiresAt = time.Now().UTC().Add(24 * time.Hour)
+}
+
+// persist saves the fake api token to the database.
+func (t *FakeAPIToken) persist(db database.Database) error {
+	insertQuery := `INSERT INTO api_tokens
+	(user_id, name, token_hash, scopes, expires_at) VALUES
+	($1, $2, $3, $4, $5)
+	RETURNING api_token_id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at,
+		(SELECT organization_id FROM users WHERE user_id = $1) AS organization_id`
+
+	return db.Exec(context.Background(), t, insertQuery, t.UserID, t.Name, t.TokenHash, t.Scopes, t.ExpiresAt)
+}
+
+// FetchLatest fetches and returns the latest version of api token by querying the database.
+func (t *FakeAPIToken) FetchLatest(db database.Database) *FakeAPIToken {
+	fakeAPIToken := &FakeAPIToken{Token: t.Token}
+
+	query := `
+			SELECT
+				t.api_token_id,
+				t.user_id,
+				u.organization_id,
+				t.name,
+				t.token_hash,
+				t.scopes,
+				t.expires_at,
+				t.last_used_at,
+				t.created_at
+			FROM api_tokens t
+			INNER JOIN users u ON t.user_id = u.user_id
+			WHERE t.api_token_id = $1
+			`
+
+	if err := db.Get(context.Background(), fakeAPIToken, query, t.ID); err != nil {
+		panic(err)
+	}
+
+	return fakeAPIToken
+}
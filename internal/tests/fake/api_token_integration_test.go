@@ -0,0 +1,54 @@
+package fake_test
+
+import (
+	"time"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *FakeTestSuite) TestFakeAPIToken() {
+	s.Run("should create an api token with default values", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := fake.NewAPIToken(s.DB, u.ID)
+
+		// assert that the api token is created with the default values
+		s.Require().NotNil(t)
+		s.NotEmpty(t.ID)
+		s.Equal(u.ID, t.UserID)
+		s.Equal(o.ID, t.OrganizationID)
+		s.NotEmpty(t.Name)
+		s.NotEmpty(t.Token)
+		s.Equal(base.HashToken(t.Token), t.TokenHash)
+		s.Contains(t.ScopeList(), apitoken.ScopeEmployeesRead)
+		s.False(t.IsExpired())
+		s.Nil(t.LastUsedAt)
+		s.WithinDuration(time.Now().UTC(), t.CreatedAt, 1*time.Minute)
+	})
+
+	s.Run("should create an api token with custom scopes", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := fake.NewAPIToken(s.DB, u.ID, fake.APITokenScopes(apitoken.ScopeUsersRead))
+
+		s.Require().NotNil(t)
+		s.Equal([]string{apitoken.ScopeUsersRead}, t.ScopeList())
+	})
+
+	s.Run("should create an expired api token", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		u := fake.NewUser(s.DB, o.ID)
+		t := fake.NewAPIToken(s.DB, u.ID, fake.APITokenExpired())
+
+		s.Require().NotNil(t)
+		s.True(t.FetchLatest(s.DB).IsExpired())
+	})
+}
@@ -3,6 +3,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -80,7 +81,7 @@ func NewOrganization(db database.Database, options ...OrganizationOption) *FakeO
 //
 //nolint:mnd // generates random values
 func (o *FakeOrganization) setDefaults() {
-	o.Subdomain = gofakeit.LetterN(uint(gofakeit.Number(1, 30)))
+	o.Subdomain = strings.ToLower(gofakeit.LetterN(uint(gofakeit.Number(1, 30))))
 	o.Name = fmt.Sprint(gofakeit.LetterN(8), " ", gofakeit.Company())
 	o.CreatedAt = time.Now().UTC()
 	o.UpdatedAt = o.CreatedAt
@@ -106,6 +107,15 @@ func (o *FakeOrganization) Delete(db database.Database) {
 	}
 }
 
+// DeleteAt deletes the organization from the database as if it was deleted at the given time.
+// Use it when you want the restore window of the organization to have ended.
+func (o *FakeOrganization) DeleteAt(db database.Database, deletedAt time.Time) {
+	query := "UPDATE organizations SET deleted_at = $2 WHERE organization_id = $1"
+	if err := db.Exec(context.Background(), nil, query, o.ID, deletedAt); err != nil {
+		panic(err)
+	}
+}
+
 // IsDeleted returns deleted status of the organization by querying the database.
 func (o *FakeOrganization) IsDeleted(db database.Database) bool {
 	var isDeleted bool
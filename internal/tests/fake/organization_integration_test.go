@@ -107,6 +107,19 @@ func (s *FakeTestSuite) TestFakeOrganization() {
 		s.True(isDeleted)
 	})
 
+	s.Run("should delete the organization at the given time", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		deletedAt := time.Now().UTC().Add(-48 * time.Hour)
+		o.DeleteAt(s.DB, deletedAt)
+
+		// assert that the organization is deleted at the given time
+		result := o.FetchLatest(s.DB)
+		s.Require().NotNil(result.DeletedAt)
+		s.WithinDuration(deletedAt, *result.DeletedAt, time.Second)
+	})
+
 	s.Run("should add user to organization", func() {
 		s.T().Parallel()
 
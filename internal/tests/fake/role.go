@@ -0,0 +1,89 @@
+package fake
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+)
+
+// FakeRole is a fake custom role for testing.
+// It embeds the role.Role struct to inherit its fields.
+type FakeRole struct {
+	role.Role
+}
+
+// RoleOption is a function that modifies a role's default values.
+type RoleOption func(*FakeRole) (*FakeRole, error)
+
+// RoleName sets/overrides the default name of a role.
+func RoleName(name string) RoleOption {
+	return func(r *FakeRole) (*FakeRole, error) {
+		r.Name = name
+		return r, nil
+	}
+}
+
+// RolePermissions sets/overrides the default permissions of a role.
+func RolePermissions(permissions ...string) RoleOption {
+	return func(r *FakeRole) (*FakeRole, error) {
+		r.Permissions = strings.Join(permissions, ",")
+		return r, nil
+	}
+}
+
+// NewRole creates a fake custom role of the organization for testing.
+func NewRole(db database.Database, orgID int64, options ...RoleOption) *FakeRole {
+	r := &FakeRole{}
+	r.OrganizationID = &orgID
+	r.setDefaults()
+
+	var err error
+	for _, fn := range options {
+		r, err = fn(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if err := r.persist(db); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// SystemRoleID returns the id of the system role with the given name by querying the database.
+func SystemRoleID(db database.Database, name string) int64 {
+	var roleID int64
+
+	query := `SELECT role_id FROM roles WHERE organization_id IS NULL AND name = $1`
+	if err := db.Get(context.Background(), &roleID, query, name); err != nil {
+		panic(err)
+	}
+
+	return roleID
+}
+
+// setDefaults sets the default values of a fake role.
+// The role is granted the read permissions by default.
+func (r *FakeRole) setDefaults() {
+	r.Name = gofakeit.UUID()
+	r.Permissions = strings.Join([]string{role.PermissionUsersRead, role.PermissionEmployeesRead}, ",")
+	r.CreatedAt = time.Now().UTC()
+	r.UpdatedAt = r.CreatedAt
+}
+
+// persist saves the fake role to the database.
+func (r *FakeRole) persist(db database.Database) error {
+	insertQuery := `INSERT INTO roles
+	(organization_id, name, permissions, created_at, updated_at) VALUES
+	($1, $2, $3, $4, $5)
+	RETURNING *`
+
+	return db.Exec(context.Background(), r, insertQuery,
+		r.OrganizationID, r.Name, r.Permissions, r.CreatedAt, r.UpdatedAt)
+}
@@ -0,0 +1,42 @@
+package fake_test
+
+import (
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+)
+
+func (s *FakeTestSuite) TestFakeRole() {
+	s.Run("should create a role with default values", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+
+		// assert that the role is created with the default values
+		s.Require().NotNil(r)
+		s.NotEmpty(r.ID)
+		s.Require().NotNil(r.OrganizationID)
+		s.Equal(o.ID, *r.OrganizationID)
+		s.NotEmpty(r.Name)
+		s.False(r.IsSystem())
+		s.Equal([]string{role.PermissionUsersRead, role.PermissionEmployeesRead}, r.PermissionList())
+	})
+
+	s.Run("should create a role with custom name and permissions", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID, fake.RoleName("auditor"), fake.RolePermissions(role.PermissionUsersRead))
+
+		s.Require().NotNil(r)
+		s.Equal("auditor", r.Name)
+		s.Equal([]string{role.PermissionUsersRead}, r.PermissionList())
+	})
+
+	s.Run("should return the id of a system role", func() {
+		s.T().Parallel()
+
+		s.NotZero(fake.SystemRoleID(s.DB, role.SystemRoleOwner))
+		s.NotEqual(fake.SystemRoleID(s.DB, role.SystemRoleOwner), fake.SystemRoleID(s.DB, role.SystemRoleEmployee))
+	})
+}
@@ -15,7 +15,6 @@ import (
 // This is useful when you want to add custom fields or methods to the fake user.
 type FakeUser struct {
 	user.User
-	setAPIToken bool
 }
 
 // UserOption is a function that modifies a user's default values.
@@ -51,18 +50,19 @@ func UserIsOwner() UserOption {
 	}
 }
 
-// UserEmailNotVerified sets is_email_verified to false.
-func UserEmailNotVerified() UserOption {
+// UserRole sets/overrides the default role of a user.
+// By default, the owner system role is assigned to an owner and the employee system role to everyone else.
+func UserRole(roleID int64) UserOption {
 	return func(u *FakeUser) (*FakeUser, error) {
-		u.IsEmailVerified = false
+		u.RoleID = roleID
 		return u, nil
 	}
 }
 
-// UserWithoutToken sets the API token to null.
-func UserWithoutToken() UserOption {
+// UserEmailNotVerified sets is_email_verified to false.
+func UserEmailNotVerified() UserOption {
 	return func(u *FakeUser) (*FakeUser, error) {
-		u.setAPIToken = false
+		u.IsEmailVerified = false
 		return u, nil
 	}
 }
@@ -138,20 +138,22 @@ func (u *FakeUser) setDefaults() {
 	u.IsEmailVerified = true
 	u.CreatedAt = time.Now().UTC()
 	u.UpdatedAt = u.CreatedAt
-	u.setAPIToken = true
 }
 
 // persist saves the fake user to the database.
 func (u *FakeUser) persist(db database.Database) error {
 	insertQuery := `INSERT INTO users
-	(organization_id, email, password_hash, api_token, is_owner, is_email_verified,
-		disabled_at, comment, created_at, updated_at, deleted_at) VALUES
-	($1, $2, $3, CASE WHEN $4 THEN random_token() ELSE null END, $5, $6, $7, $8, $9, $10, $11)
+	(organization_id, email, password_hash, is_owner, is_email_verified,
+		disabled_at, comment, created_at, updated_at, deleted_at, role_id) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(NULLIF($11, 0), (
+		SELECT role_id FROM roles
+		WHERE organization_id IS NULL AND name = CASE WHEN $4 THEN 'owner' ELSE 'employee' END
+	)))
 	RETURNING *`
 
 	return db.Exec(context.Background(), u, insertQuery,
-		u.OrganizationID, u.Email, u.PasswordHash, u.setAPIToken, u.IsOwner, u.IsEmailVerified,
-		u.DisabledAt, u.Comment, u.CreatedAt, u.UpdatedAt, u.DeletedAt)
+		u.OrganizationID, u.Email, u.PasswordHash, u.IsOwner, u.IsEmailVerified,
+		u.DisabledAt, u.Comment, u.CreatedAt, u.UpdatedAt, u.DeletedAt, u.RoleID)
 }
 
 // FetchLatest fetches and returns the latest version of user by querying the database.
@@ -164,8 +166,8 @@ func (u *FakeUser) FetchLatest(db database.Database) *FakeUser {
 				organization_id,
 				email,
 				password_hash,
-				api_token,
 				is_owner,
+				role_id,
 				is_email_verified,
 				disabled_at,
 				comment,
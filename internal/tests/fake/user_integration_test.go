@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,8 +23,8 @@ func (s *FakeTestSuite) TestFakeUser() {
 		s.Equal(o.ID, u.OrganizationID)
 		s.NotEmpty(u.Email)
 		s.NotEmpty(u.PasswordHash)
-		s.NotNil(u.APIToken)
 		s.False(u.IsOwner)
+		s.Equal(fake.SystemRoleID(s.DB, role.SystemRoleEmployee), u.RoleID)
 		s.Nil(u.DisabledAt)
 		s.Nil(u.Comment)
 		s.NotZero(u.CreatedAt)
@@ -36,17 +37,6 @@ func (s *FakeTestSuite) TestFakeUser() {
 		s.Nil(u.DeletedAt)
 	})
 
-	s.Run("should create a user without an API token", func() {
-		s.T().Parallel()
-
-		o := fake.NewOrganization(s.DB)
-		u := fake.NewUser(s.DB, o.ID, fake.UserWithoutToken())
-
-		// assert that the user is created without an API token
-		s.Require().NotNil(u)
-		s.Nil(u.APIToken)
-	})
-
 	s.Run("should create a user with custom email", func() {
 		s.T().Parallel()
 
@@ -68,6 +58,19 @@ func (s *FakeTestSuite) TestFakeUser() {
 		// assert that the user is created as the owner of the organization
 		s.Require().NotNil(u)
 		s.True(u.IsOwner)
+		s.Equal(fake.SystemRoleID(s.DB, role.SystemRoleOwner), u.RoleID)
+	})
+
+	s.Run("should create a user with custom role", func() {
+		s.T().Parallel()
+
+		o := fake.NewOrganization(s.DB)
+		r := fake.NewRole(s.DB, o.ID)
+		u := fake.NewUser(s.DB, o.ID, fake.UserRole(r.ID))
+
+		// assert that the user is created with the specified role
+		s.Require().NotNil(u)
+		s.Equal(r.ID, u.RoleID)
 	})
 
 	s.Run("should create a user with email not verified", func() {
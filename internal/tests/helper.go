@@ -1,8 +1,12 @@
 package tests
 
 import (
+	"context"
+	"net/http"
 	"strings"
 
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,3 +19,41 @@ func QueryMatcher(queryLabel string) any {
 		return false
 	})
 }
+
+// RunTx executes the transaction function with the given context.
+// Use it as the return value of the mocked transactor.
+func RunTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// WithAuthContext sets the user-id and org-id in the request context as done by the auth middleware.
+func WithAuthContext(req *http.Request, userID, orgID int64) *http.Request {
+	ctx := context.WithValue(req.Context(), request.CtxUserIDKey, userID)
+	ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
+
+	return req.WithContext(ctx)
+}
+
+// WithSessionID sets the session-id in the request context as done by the auth middleware for a session.
+func WithSessionID(req *http.Request, sessionID string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), request.CtxSessionIDKey, sessionID))
+}
+
+// WithOrgSubdomain sets the org-subdomain in the request context as done by the auth middleware.
+func WithOrgSubdomain(req *http.Request, subdomain string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), request.CtxOrgSubdomainKey, subdomain))
+}
+
+// WithURLParam simulates chi's URL parameters.
+// The parameter is added to the route context of the request when it is set already.
+func WithURLParam(req *http.Request, key, value string) *http.Request {
+	if routeContext := chi.RouteContext(req.Context()); routeContext != nil {
+		routeContext.URLParams.Add(key, value)
+		return req
+	}
+
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add(key, value)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+}
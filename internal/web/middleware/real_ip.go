@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+)
+
+type realIPMiddleware struct {
+	trustedProxies []netip.Prefix
+}
+
+// NewRealIPMiddleware creates a new real ip middleware using the trusted proxies of the config.
+// The malformed entries of the trusted proxies are ignored.
+func NewRealIPMiddleware(conf config.Config) *realIPMiddleware {
+	var trustedProxies []netip.Prefix
+
+	for _, entry := range strings.Split(conf.TrustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// a single ip is trusted as a prefix of its full length
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			trustedProxies = append(trustedProxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			trustedProxies = append(trustedProxies, prefix.Masked())
+		}
+	}
+
+	return &realIPMiddleware{trustedProxies}
+}
+
+// RealIP is a middleware that sets the remote address of the request to the ip of the client
+// when the request is forwarded by a trusted proxy.
+// The X-Forwarded-For and the X-Real-IP headers can be set by any client. So they are read only when
+// the request is made by a trusted proxy. Otherwise, the remote address of the connection is kept.
+// The X-Forwarded-For header is read from right to left and the first ip that is not a trusted proxy is used
+// since the leftmost entries are the ones set by the client.
+func (m *realIPMiddleware) RealIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ip := m.clientIP(r); ip != "" {
+			r.RemoteAddr = ip
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the ip of the client forwarded by a trusted proxy.
+// It returns an empty string when the request is not made by a trusted proxy.
+func (m *realIPMiddleware) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !m.isTrusted(host) {
+		return ""
+	}
+
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		ips := strings.Split(strings.Join(forwardedFor, ","), ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if _, err := netip.ParseAddr(ip); err != nil {
+				// the entries left of a malformed one can not be trusted either
+				return ""
+			}
+
+			if !m.isTrusted(ip) {
+				return ip
+			}
+		}
+
+		return ""
+	}
+
+	ip := strings.TrimSpace(r.Header.Get("X-Real-IP"))
+	if _, err := netip.ParseAddr(ip); err != nil {
+		return ""
+	}
+
+	return ip
+}
+
+func (m *realIPMiddleware) isTrusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range m.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
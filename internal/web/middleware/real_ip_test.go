@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/web/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealIPMiddleware_RealIP(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{TrustedProxies: "10.0.0.0/8, malformed ,192.168.1.10"}
+
+	for _, tc := range []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{
+			name:       "should keep the remote address without the forwarding headers",
+			remoteAddr: "203.0.113.7:4321",
+			expected:   "203.0.113.7:4321",
+		},
+		{
+			name:       "should ignore the forwarding headers set by an untrusted client",
+			remoteAddr: "203.0.113.7:4321",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"},
+			expected:   "203.0.113.7:4321",
+		},
+		{
+			name:       "should use the ip forwarded by a trusted proxy",
+			remoteAddr: "10.1.2.3:4321",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "should skip the trusted proxies and the ips set by the client in the forwarded chain",
+			remoteAddr: "192.168.1.10:4321",
+			headers:    map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.1, 10.9.9.9"},
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "should use the real ip header set by a trusted proxy",
+			remoteAddr: "10.1.2.3:4321",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.2"},
+			expected:   "198.51.100.2",
+		},
+		{
+			name:       "should keep the remote address for a malformed forwarded ip",
+			remoteAddr: "10.1.2.3:4321",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1, not-an-ip"},
+			expected:   "10.1.2.3:4321",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/subdomains/acme/auth/login", nil)
+			req.RemoteAddr = tc.remoteAddr
+
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			var remoteAddr string
+
+			middleware.NewRealIPMiddleware(conf).RealIP(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					remoteAddr = r.RemoteAddr
+				})).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.expected, remoteAddr)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type permissionMiddleware struct {
+	roleService role.Service
+}
+
+// NewPermissionMiddleware creates a new permission middleware.
+func NewPermissionMiddleware(roleService role.Service) *permissionMiddleware {
+	return &permissionMiddleware{roleService}
+}
+
+// RequirePermission is a middleware that allows the request only if the role of the user grants the given permission.
+// The permissions of the user are loaded from the cache and from the database on a cache miss.
+// It must be used after the ValidateAuth middleware.
+func (m *permissionMiddleware) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, userOK := r.Context().Value(request.CtxUserIDKey).(int64)
+			orgID, orgOK := r.Context().Value(request.CtxOrgIDKey).(int64)
+
+			if !userOK || !orgOK {
+				response.Empty(w, http.StatusUnauthorized)
+				return
+			}
+
+			permissions, err := m.roleService.GetPermissions(r.Context(), userID, orgID)
+			if err != nil && !base.IsNotFoundError(err) {
+				response.ErrorResponse(w, err)
+				return
+			}
+
+			if !slices.Contains(permissions, permission) {
+				response.ErrorResponse(w, base.NewAPIError(
+					fmt.Sprintf("user does not have the required permission: %s", permission),
+					base.ErrorHTTPStatus(http.StatusForbidden)))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
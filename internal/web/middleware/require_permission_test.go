@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionMiddleware_RequirePermission(t *testing.T) {
+	t.Parallel()
+
+	// newRequest creates a request with the user-id and org-id in the context as done by the auth middleware
+	newRequest := func(userID, orgID int64) *http.Request {
+		return tests.WithAuthContext(httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil), userID, orgID)
+	}
+
+	t.Run("should allow the request of a user granted the permission", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleService := role.NewMockService(t)
+		m := middleware.NewPermissionMiddleware(roleService)
+		rr := httptest.NewRecorder()
+
+		roleService.On("GetPermissions", fake.MockContext, userID, orgID).
+			Return([]string{role.PermissionUsersRead, role.PermissionOrgUpdate}, nil)
+
+		m.RequirePermission(role.PermissionOrgUpdate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, newRequest(userID, orgID))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return forbidden response for a user without the permission", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleService := role.NewMockService(t)
+		m := middleware.NewPermissionMiddleware(roleService)
+		rr := httptest.NewRecorder()
+
+		roleService.On("GetPermissions", fake.MockContext, userID, orgID).
+			Return([]string{role.PermissionUsersRead}, nil)
+
+		m.RequirePermission(role.PermissionOrgUpdate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, newRequest(userID, orgID))
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.JSONEq(t, `{"error":"user does not have the required permission: org:update"}`, rr.Body.String())
+	})
+
+	t.Run("should return forbidden response when the user is not found", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleService := role.NewMockService(t)
+		m := middleware.NewPermissionMiddleware(roleService)
+		rr := httptest.NewRecorder()
+
+		roleService.On("GetPermissions", fake.MockContext, userID, orgID).
+			Return(nil, base.NewNotFoundError("user not found for the given id"))
+
+		m.RequirePermission(role.PermissionOrgUpdate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, newRequest(userID, orgID))
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return error response when the permissions can not be loaded", func(t *testing.T) {
+		t.Parallel()
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		roleService := role.NewMockService(t)
+		m := middleware.NewPermissionMiddleware(roleService)
+		rr := httptest.NewRecorder()
+
+		roleService.On("GetPermissions", fake.MockContext, userID, orgID).Return(nil, assert.AnError)
+
+		m.RequirePermission(role.PermissionOrgUpdate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, newRequest(userID, orgID))
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should return unauthorized response when the user is not in the context", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewPermissionMiddleware(role.NewMockService(t))
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		rr := httptest.NewRecorder()
+
+		m.RequirePermission(role.PermissionOrgUpdate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
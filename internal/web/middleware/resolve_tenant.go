@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+const subdomainsPathPrefix = "/subdomains/"
+
+type tenantMiddleware struct {
+	baseDomain          string
+	orgService          organization.Service
+	customDomainService customdomain.Service
+}
+
+// NewTenantMiddleware creates a new tenant middleware.
+func NewTenantMiddleware(
+	conf config.Config,
+	orgService organization.Service,
+	customDomainService customdomain.Service,
+) *tenantMiddleware {
+	return &tenantMiddleware{conf.BaseDomain, orgService, customDomainService}
+}
+
+// ResolveTenant is a middleware that resolves the organization the request is made to
+// and sets it in the request context.
+// The organization is identified by the subdomain path parameter when the endpoint is mounted
+// under /subdomains/{subdomain}. Otherwise, it is identified by the host of the request
+// which is either a subdomain of the base domain or a verified custom domain of the organization.
+// The requests made to a former subdomain of the organization under /subdomains/{subdomain} are redirected
+// to the same path under its current subdomain. A temporary redirect is used so that the method and the body
+// of the request are preserved and the redirect is not cached beyond the expiry of the alias.
+func (m *tenantMiddleware) ResolveTenant(next http.Handler) http.Handler {
+	return m.resolveTenant(next, false)
+}
+
+// ResolveDeletedTenant is a middleware that resolves the deleted organization the request is made to
+// and sets it in the request context. It is used by the endpoints restoring the deleted organizations.
+// The organization is identified the same way as the ResolveTenant middleware except for the former subdomains.
+func (m *tenantMiddleware) ResolveDeletedTenant(next http.Handler) http.Handler {
+	return m.resolveTenant(next, true)
+}
+
+func (m *tenantMiddleware) resolveTenant(next http.Handler, deleted bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			org organization.Organization
+			err error
+		)
+
+		if subdomain := request.URLParam(r, "subdomain"); subdomain != "" {
+			org, err = m.getOrganizationBySubdomain(r.Context(), subdomain, deleted)
+			if base.IsNotFoundError(err) && !deleted {
+				aliasOrg, aliasErr := m.orgService.GetOrganizationBySubdomainAlias(r.Context(), subdomain)
+				if aliasErr == nil {
+					redirectSubdomainAlias(w, r, subdomain, aliasOrg.Subdomain)
+					return
+				}
+
+				// the subdomain is not an alias either
+				if !base.IsNotFoundError(aliasErr) {
+					err = aliasErr
+				}
+			}
+		} else {
+			org, err = m.getOrganizationByHost(r.Context(), r.Host, deleted)
+		}
+
+		if err != nil {
+			response.ErrorResponse(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(organization.NewContext(r.Context(), org)))
+	})
+}
+
+// getOrganizationByHost returns the organization of the subdomain of the base domain
+// or of the verified custom domain the request is made to.
+func (m *tenantMiddleware) getOrganizationByHost(
+	ctx context.Context, host string, deleted bool,
+) (organization.Organization, error) {
+	// the port is not a part of the domain
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	host = strings.ToLower(host)
+
+	subdomain, found := strings.CutSuffix(host, "."+m.baseDomain)
+	if found && !strings.Contains(subdomain, ".") {
+		org, err := m.getOrganizationBySubdomain(ctx, subdomain, deleted)
+		if base.IsNotFoundError(err) && !deleted {
+			// the former subdomains of the organization are served as is
+			// since the request can not be redirected to another host without knowing its scheme
+			return m.orgService.GetOrganizationBySubdomainAlias(ctx, subdomain)
+		}
+
+		return org, err
+	}
+
+	d, err := m.customDomainService.GetVerifiedDomain(ctx, host)
+	if err != nil {
+		return organization.Organization{}, err
+	}
+
+	if deleted {
+		return m.orgService.GetDeletedOrganizationByID(ctx, d.OrganizationID)
+	}
+
+	return m.orgService.GetOrganizationByID(ctx, d.OrganizationID)
+}
+
+func (m *tenantMiddleware) getOrganizationBySubdomain(
+	ctx context.Context, subdomain string, deleted bool,
+) (organization.Organization, error) {
+	if deleted {
+		return m.orgService.GetDeletedOrganizationBySubdomain(ctx, subdomain)
+	}
+
+	return m.orgService.GetOrganizationBySubdomain(ctx, subdomain)
+}
+
+// redirectSubdomainAlias redirects the request made to the alias to the same path under the given subdomain.
+func redirectSubdomainAlias(w http.ResponseWriter, r *http.Request, alias, subdomain string) {
+	// the subdomain endpoints are mounted under /subdomains/{subdomain}
+	target := *r.URL
+	target.Path = strings.Replace(r.URL.Path, subdomainsPathPrefix+alias, subdomainsPathPrefix+subdomain, 1)
+	target.RawPath = ""
+
+	http.Redirect(w, r, target.RequestURI(), http.StatusTemporaryRedirect)
+}
@@ -0,0 +1,281 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/tests"
+	"github.com/camelhr/camelhr-api/internal/tests/fake"
+	"github.com/camelhr/camelhr-api/internal/web/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantMiddleware_ResolveTenant(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{BaseDomain: "camelhr.com"}
+
+	// expectOrganization returns a handler asserting the organization resolved in the request context
+	expectOrganization := func(t *testing.T, org organization.Organization) http.Handler {
+		t.Helper()
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved, err := organization.FromRequest(r)
+			require.NoError(t, err)
+			assert.Equal(t, org, resolved)
+		})
+	}
+
+	t.Run("should resolve the organization of the subdomain path parameter", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, org.Subdomain).Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/subdomains/"+org.Subdomain+"/organizations", nil)
+		req = tests.WithURLParam(req, "subdomain", org.Subdomain)
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(expectOrganization(t, org)).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should redirect the request of an alias to the current subdomain", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		alias := gofakeit.LetterN(30)
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, alias).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+		orgService.On("GetOrganizationBySubdomainAlias", fake.MockContext, alias).Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/subdomains/"+alias+"/auth/login?next=/me", nil)
+		req = tests.WithURLParam(req, "subdomain", alias)
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		assert.Equal(t, "/api/v1/subdomains/"+org.Subdomain+"/auth/login?next=/me", rr.Header().Get("Location"))
+	})
+
+	t.Run("should return not found when the subdomain is neither an organization nor an alias", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		subdomain := gofakeit.LetterN(30)
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("organization not found for the given subdomain"))
+		orgService.On("GetOrganizationBySubdomainAlias", fake.MockContext, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/subdomains/"+subdomain+"/organizations", nil)
+		req = tests.WithURLParam(req, "subdomain", subdomain)
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error":"organization not found for the given subdomain"}`, rr.Body.String())
+	})
+
+	t.Run("should return the error when the alias lookup fails", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		subdomain := gofakeit.LetterN(30)
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, subdomain).
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+		orgService.On("GetOrganizationBySubdomainAlias", fake.MockContext, subdomain).
+			Return(organization.Organization{}, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/subdomains/"+subdomain+"/organizations", nil)
+		req = tests.WithURLParam(req, "subdomain", subdomain)
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("should resolve the organization of the subdomain of the host", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "acme"}
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, "acme").Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = "ACME.camelhr.com:443"
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(expectOrganization(t, org)).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should serve the former subdomain of the host without a redirect", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "acme"}
+
+		orgService.On("GetOrganizationBySubdomain", fake.MockContext, "acmeold").
+			Return(organization.Organization{}, base.NewNotFoundError("not found"))
+		orgService.On("GetOrganizationBySubdomainAlias", fake.MockContext, "acmeold").Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = "acmeold.camelhr.com"
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(expectOrganization(t, org)).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should resolve the organization of the verified custom domain of the host", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		customDomainService := customdomain.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customDomainService)
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "acme"}
+
+		customDomainService.On("GetVerifiedDomain", fake.MockContext, "hr.acme.com").
+			Return(customdomain.CustomDomain{OrganizationID: org.ID, Domain: "hr.acme.com"}, nil)
+		orgService.On("GetOrganizationByID", fake.MockContext, org.ID).Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = "HR.acme.com"
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(expectOrganization(t, org)).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should look up the nested subdomains of the base domain as a custom domain", func(t *testing.T) {
+		t.Parallel()
+
+		customDomainService := customdomain.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, organization.NewMockService(t), customDomainService)
+
+		customDomainService.On("GetVerifiedDomain", fake.MockContext, "a.b.camelhr.com").
+			Return(customdomain.CustomDomain{},
+				base.NewNotFoundError("verified custom domain not found for the given domain"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/organizations", nil)
+		req.Host = "a.b.camelhr.com"
+		rr := httptest.NewRecorder()
+
+		m.ResolveTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		assert.JSONEq(t, `{"error":"verified custom domain not found for the given domain"}`, rr.Body.String())
+	})
+}
+
+func TestTenantMiddleware_ResolveDeletedTenant(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{BaseDomain: "camelhr.com"}
+
+	t.Run("should resolve the deleted organization of the subdomain path parameter", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+
+		orgService.On("GetDeletedOrganizationBySubdomain", fake.MockContext, org.Subdomain).Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodPost,
+			"/api/v1/subdomains/"+org.Subdomain+"/auth/restore-organization", nil)
+
+		req = tests.WithURLParam(req, "subdomain", org.Subdomain)
+
+		rr := httptest.NewRecorder()
+
+		m.ResolveDeletedTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved, err := organization.FromRequest(r)
+			require.NoError(t, err)
+			assert.Equal(t, org, resolved)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should resolve the deleted organization of the verified custom domain of the host", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		customDomainService := customdomain.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customDomainService)
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "acme"}
+
+		customDomainService.On("GetVerifiedDomain", fake.MockContext, "hr.acme.com").
+			Return(customdomain.CustomDomain{OrganizationID: org.ID, Domain: "hr.acme.com"}, nil)
+		orgService.On("GetDeletedOrganizationByID", fake.MockContext, org.ID).Return(org, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/restore-organization", nil)
+		req.Host = "hr.acme.com"
+		rr := httptest.NewRecorder()
+
+		m.ResolveDeletedTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved, err := organization.FromRequest(r)
+			require.NoError(t, err)
+			assert.Equal(t, org, resolved)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should not look up the aliases for a deleted organization", func(t *testing.T) {
+		t.Parallel()
+
+		orgService := organization.NewMockService(t)
+		m := middleware.NewTenantMiddleware(conf, orgService, customdomain.NewMockService(t))
+
+		orgService.On("GetDeletedOrganizationBySubdomain", fake.MockContext, "acme").
+			Return(organization.Organization{}, base.NewNotFoundError("deleted organization not found"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/restore-organization", nil)
+		req.Host = "acme.camelhr.com"
+		rr := httptest.NewRecorder()
+
+		m.ResolveDeletedTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
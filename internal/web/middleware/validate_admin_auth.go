@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/camelhr/camelhr-api/internal/web/response"
+)
+
+type adminMiddleware struct {
+	// keyHashes maps the sha256 hex digest of an admin api key to the name of its operator
+	keyHashes map[string]string
+}
+
+// NewAdminMiddleware creates a new admin middleware using the admin api keys of the config.
+// The malformed entries of the admin api keys are ignored.
+func NewAdminMiddleware(conf config.Config) *adminMiddleware {
+	keyHashes := make(map[string]string)
+
+	for _, entry := range strings.Split(conf.AdminAPIKeys, ",") {
+		operator, keyHash, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || operator == "" || keyHash == "" {
+			continue
+		}
+
+		keyHashes[strings.ToLower(keyHash)] = operator
+	}
+
+	return &adminMiddleware{keyHashes}
+}
+
+// ValidateAdminAuth is a middleware that authenticates the platform operators using an admin api key.
+// The admin api key is read from the bearer authorization header.
+// The name of the operator is set in the request context.
+func (m *adminMiddleware) ValidateAdminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || key == "" {
+			response.Empty(w, http.StatusUnauthorized)
+			return
+		}
+
+		operator, ok := m.lookupOperator(base.HashToken(key))
+		if !ok {
+			response.Empty(w, http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), request.CtxOperatorKey, operator)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// lookupOperator returns the operator of the key hash. The hashes are compared in constant time.
+func (m *adminMiddleware) lookupOperator(keyHash string) (string, bool) {
+	for h, operator := range m.keyHashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(keyHash)) == 1 {
+			return operator, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/web/middleware"
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminMiddleware_ValidateAdminAuth(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{
+		AdminAPIKeys: "john:" + base.HashToken("john-key") + ", malformed ,jane:" + base.HashToken("jane-key"),
+	}
+
+	for _, tc := range []struct {
+		name       string
+		authHeader string
+	}{
+		{name: "should return unauthorized response when the authorization header is missing"},
+		{name: "should return unauthorized response for a non bearer token", authHeader: "Basic john-key"},
+		{name: "should return unauthorized response for an unknown key", authHeader: "Bearer unknown-key"},
+		{name: "should return unauthorized response for a malformed entry", authHeader: "Bearer malformed"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/organizations", nil)
+			req.Header.Set("Authorization", tc.authHeader)
+			rr := httptest.NewRecorder()
+
+			middleware.NewAdminMiddleware(conf).ValidateAdminAuth(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})).ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusUnauthorized, rr.Code)
+		})
+	}
+
+	t.Run("should return unauthorized response when no admin api key is configured", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/organizations", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		rr := httptest.NewRecorder()
+
+		middleware.NewAdminMiddleware(config.Config{}).ValidateAdminAuth(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should set the operator of the admin api key in the context", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/organizations", nil)
+		req.Header.Set("Authorization", "Bearer jane-key")
+		rr := httptest.NewRecorder()
+
+		var operator string
+
+		middleware.NewAdminMiddleware(conf).ValidateAdminAuth(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				operator, _ = r.Context().Value(request.CtxOperatorKey).(string)
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "jane", operator)
+	})
+}
@@ -3,41 +3,52 @@ package middleware
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
-	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/web/request"
 	"github.com/camelhr/camelhr-api/internal/web/response"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type authMiddleware struct {
-	appSecret      string
-	userService    user.Service
-	sessionManager session.SessionManager
+	jwtKeys         *auth.KeySet
+	apiTokenService apitoken.Service
+	sessionManager  session.SessionManager
+	orgService      organization.Service
+	adminService    admin.Service
 }
 
 // NewAuthMiddleware creates a new auth middleware.
 func NewAuthMiddleware(
-	appSecret string,
-	userService user.Service,
+	jwtKeys *auth.KeySet,
+	apiTokenService apitoken.Service,
 	sessionManager session.SessionManager,
+	orgService organization.Service,
+	adminService admin.Service,
 ) *authMiddleware {
-	return &authMiddleware{appSecret, userService, sessionManager}
+	return &authMiddleware{jwtKeys, apiTokenService, sessionManager, orgService, adminService}
 }
 
 // ValidateAuth is a middleware that authenticates the request.
-// Before using this middleware, make sure that associated endpoint contains the subdomain path parameter.
+// Before using this middleware, make sure that the organization of the request is resolved
+// by the ResolveTenant middleware.
+// The requests of a suspended organization are rejected.
 func (m *authMiddleware) ValidateAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// subdomain is required for user authentication
-		if request.URLParam(r, "subdomain") == "" {
-			response.ErrorResponse(w, base.NewAPIError("subdomain is required for user authentication",
-				base.ErrorHTTPStatus(http.StatusUnauthorized)))
+		// organization is required for user authentication
+		if _, err := organization.FromRequest(r); err != nil {
+			response.ErrorResponse(w, base.NewAPIError("organization is required for user authentication",
+				base.ErrorCause(err), base.ErrorHTTPStatus(http.StatusUnauthorized)))
 
 			return
 		}
@@ -67,11 +78,85 @@ func (m *authMiddleware) ValidateAuth(next http.Handler) http.Handler {
 	})
 }
 
+// RequireScope is a middleware that restricts the requests authenticated using an api token
+// to the tokens granted the given scope. The requests authenticated using a session are not restricted.
+// It must be used after the ValidateAuth middleware.
+func (m *authMiddleware) RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, isAPIToken := r.Context().Value(request.CtxAPITokenScopesKey).([]string)
+			if isAPIToken && !slices.Contains(scopes, scope) {
+				response.ErrorResponse(w, base.NewAPIError(
+					fmt.Sprintf("api token does not have the required scope: %s", scope),
+					base.ErrorHTTPStatus(http.StatusForbidden)))
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession is a middleware that rejects the requests authenticated using an api token
+// or made by a platform operator impersonating the user.
+// Use it for the endpoints which manage the credentials of the user.
+// It must be used after the ValidateAuth middleware.
+func (m *authMiddleware) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, isAPIToken := r.Context().Value(request.CtxAPITokenScopesKey).([]string); isAPIToken {
+			response.ErrorResponse(w, base.NewAPIError("api token is not allowed for this endpoint",
+				base.ErrorHTTPStatus(http.StatusForbidden)))
+
+			return
+		}
+
+		if _, isImpersonated := r.Context().Value(request.CtxImpersonatorKey).(string); isImpersonated {
+			response.ErrorResponse(w, base.NewAPIError("impersonation is not allowed for this endpoint",
+				base.ErrorHTTPStatus(http.StatusForbidden)))
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RejectImpersonation is a middleware that rejects the requests made by a platform operator impersonating
+// the user unless they only read the data. The operators can look into the issues of an organization
+// but can not act on behalf of its users.
+// It must be used after the ValidateAuth middleware.
+func (m *authMiddleware) RejectImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+
+		if _, isImpersonated := r.Context().Value(request.CtxImpersonatorKey).(string); isImpersonated && !readOnly {
+			response.ErrorResponse(w, base.NewAPIError("impersonation is not allowed to modify the data",
+				base.ErrorHTTPStatus(http.StatusForbidden)))
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // processJWT parses and validates the jwt token.
 // It then ensures that the token is present in the session.
-// If the token is valid, it sets the user-id, org-id and org-subdomain in the request context.
+// If the token is valid, it sets the user-id, org-id, org-subdomain and session-id in the request context.
+// The requests of an impersonation session are recorded before being served and the operator is set
+// in the request context as well as in the response header.
 func (m *authMiddleware) processJWT(next http.Handler, w http.ResponseWriter, r *http.Request, jwtString string) {
-	token, claims, err := auth.ParseAndValidateJWT(jwtString, m.appSecret)
+	token, claims, err := auth.ParseAndValidateJWT(jwtString, m.jwtKeys)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		// let the client know that the session can be renewed using the refresh token
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="token expired"`)
+		response.ErrorResponse(w, base.NewAPIError("token expired", base.ErrorCause(err),
+			base.ErrorHTTPStatus(http.StatusUnauthorized)))
+
+		return
+	}
+
 	if err != nil {
 		response.ErrorResponse(w, base.NewAPIError("invalid token", base.ErrorCause(err),
 			base.ErrorHTTPStatus(http.StatusUnauthorized)))
@@ -84,35 +169,89 @@ func (m *authMiddleware) processJWT(next http.Handler, w http.ResponseWriter, r
 		return
 	}
 
-	// only one most recent jwt is stored in the session upon login
-	// validate the session to ensure that the same jwt is present in the session
-	if err := m.sessionManager.ValidateJWTSession(r.Context(), claims.UserID, claims.OrgID, jwtString); err != nil {
+	// each login creates a separate session identified by the sid claim
+	// validate the session to ensure that it is not revoked and the same jwt is present in the session
+	err = m.sessionManager.ValidateJWTSession(r.Context(), claims.UserID, claims.OrgID, claims.SessionID, jwtString)
+	if err != nil {
 		response.ErrorResponse(w, base.NewAPIError("invalid token", base.ErrorCause(err),
 			base.ErrorHTTPStatus(http.StatusUnauthorized)))
 
 		return
 	}
 
+	// validate the organization of the request against the organization in the jwt claims
+	org, _ := organization.FromRequest(r)
+	if err := orgMismatchError(w, claims, org); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
 	// set claims values to request context
 	ctx := context.WithValue(r.Context(), request.CtxUserIDKey, claims.UserID)
 	ctx = context.WithValue(ctx, request.CtxOrgIDKey, claims.OrgID)
-	ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, claims.OrgSubdomain)
-
-	// validate the subdomain from the request path against the subdomain in the jwt claims
-	if request.URLParam(r, "subdomain") != claims.OrgSubdomain {
-		response.ErrorResponse(w, base.NewAPIError("user doesn't belong to the organization",
-			base.ErrorHTTPStatus(http.StatusUnauthorized)))
+	ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, org.Subdomain)
+	ctx = context.WithValue(ctx, request.CtxSessionIDKey, claims.SessionID)
 
+	if err := m.checkOrgStatus(r.Context(), claims.OrgID); err != nil {
+		response.ErrorResponse(w, err)
 		return
 	}
 
+	if claims.IsImpersonated() {
+		if err := m.logImpersonatedRequest(r, claims); err != nil {
+			response.ErrorResponse(w, err)
+			return
+		}
+
+		ctx = context.WithValue(ctx, request.CtxImpersonatorKey, claims.Actor.Operator)
+		w.Header().Set(auth.ImpersonatedByHeader, claims.Actor.Operator)
+	}
+
 	next.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// orgMismatchError returns the error for a jwt issued for an organization other than the requested one.
+// When the subdomain of the organization was changed after the jwt was issued, the client is asked
+// to renew the session using the refresh token so that the jwt is reissued with the new subdomain.
+func orgMismatchError(w http.ResponseWriter, claims *auth.AppClaims, org organization.Organization) error {
+	if claims.OrgID != org.ID {
+		return base.NewAPIError("user doesn't belong to the organization",
+			base.ErrorHTTPStatus(http.StatusUnauthorized))
+	}
+
+	if claims.OrgSubdomain != org.Subdomain {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token", error_description="subdomain changed"`)
+
+		return base.NewAPIError("organization subdomain changed", base.ErrorHTTPStatus(http.StatusUnauthorized))
+	}
+
+	return nil
+}
+
+// logImpersonatedRequest records the request made with an impersonation session.
+// The request must not be served when it can not be recorded.
+func (m *authMiddleware) logImpersonatedRequest(r *http.Request, claims *auth.AppClaims) error {
+	err := m.adminService.LogImpersonatedRequest(r.Context(), admin.ImpersonationLog{
+		OrganizationID: claims.OrgID,
+		UserID:         claims.UserID,
+		Operator:       claims.Actor.Operator,
+		SessionID:      claims.SessionID,
+		Method:         r.Method,
+		Path:           r.URL.Path,
+	})
+	if errors.Is(err, admin.ErrImpersonationNotAllowed) {
+		return base.WrapError(err, base.ErrorHTTPStatus(http.StatusForbidden))
+	}
+
+	return err
+}
+
 // processAPIToken validates the api token from the basic auth header.
 // It first checks the api-token in the session.
-// If the token is not present in the session, it queries the database to get the user.
-// It sets the user-id, org-id and org-subdomain in the request context.
+// If the token is not present in the session, it queries the database to authenticate the token.
+// Expired tokens are rejected.
+// It sets the user-id, org-id, org-subdomain and the scopes of the token in the request context.
+// The scopes are enforced by the RequireScope middleware of the endpoint.
 func (m *authMiddleware) processAPIToken(
 	next http.Handler,
 	w http.ResponseWriter,
@@ -137,49 +276,92 @@ func (m *authMiddleware) processAPIToken(
 		return
 	}
 
-	subdomain := request.URLParam(r, "subdomain")
+	org, _ := organization.FromRequest(r)
 
-	userID, orgID, err := m.getUserForAPIToken(r.Context(), apiToken, subdomain)
+	s, err := m.getAPITokenSession(r.Context(), apiToken, org.Subdomain)
 	if err != nil {
 		response.ErrorResponse(w, err)
 		return
 	}
 
-	// set user-id, org-id and org-subdomain in the request context
-	ctx := context.WithValue(r.Context(), request.CtxUserIDKey, userID)
-	ctx = context.WithValue(ctx, request.CtxOrgIDKey, orgID)
-	ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, subdomain)
+	// the cached session of the token is not bound to the subdomain it was authenticated with
+	if s.OrgID != org.ID {
+		response.ErrorResponse(w, base.NewAPIError("invalid api token",
+			base.ErrorHTTPStatus(http.StatusUnauthorized)))
+
+		return
+	}
+
+	if err := m.checkOrgStatus(r.Context(), s.OrgID); err != nil {
+		response.ErrorResponse(w, err)
+		return
+	}
+
+	// set user-id, org-id, org-subdomain and scopes in the request context
+	ctx := context.WithValue(r.Context(), request.CtxUserIDKey, s.UserID)
+	ctx = context.WithValue(ctx, request.CtxOrgIDKey, s.OrgID)
+	ctx = context.WithValue(ctx, request.CtxOrgSubdomainKey, org.Subdomain)
+	ctx = context.WithValue(ctx, request.CtxAPITokenScopesKey, s.Scopes)
 
 	next.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (m *authMiddleware) getUserForAPIToken(ctx context.Context, apiToken, subdomain string) (int64, int64, error) {
-	// check if the api token is present in the session and get associated ids
-	if userID, orgID, err := m.sessionManager.ValidateAPITokenSession(ctx, apiToken); err == nil {
-		return userID, orgID, nil
+// checkOrgStatus returns an error when the organization is suspended or no longer exists.
+// The status is cached by the organization service so that it doesn't query the database on every request.
+func (m *authMiddleware) checkOrgStatus(ctx context.Context, orgID int64) error {
+	suspended, err := m.orgService.IsSuspended(ctx, orgID)
+	if err != nil {
+		if base.IsNotFoundError(err) {
+			return base.NewAPIError("organization not found", base.ErrorCause(err),
+				base.ErrorHTTPStatus(http.StatusUnauthorized))
+		}
+
+		return err
+	}
+
+	if suspended {
+		return base.WrapError(auth.ErrOrgSuspended, base.ErrorHTTPStatus(http.StatusForbidden))
+	}
+
+	return nil
+}
+
+func (m *authMiddleware) getAPITokenSession(
+	ctx context.Context,
+	apiToken, subdomain string,
+) (session.APITokenSession, error) {
+	// check if the api token is present in the session and get associated data
+	if s, err := m.sessionManager.ValidateAPITokenSession(ctx, apiToken); err == nil {
+		return s, nil
 	}
 
-	// if not, get user for the given subdomain and api token from the database
-	u, err := m.userService.GetUserByOrgSubdomainAPIToken(ctx, subdomain, apiToken)
+	// if not, authenticate the token of the given subdomain using the database
+	t, err := m.apiTokenService.Authenticate(ctx, subdomain, apiToken)
 	if err != nil {
 		if base.IsNotFoundError(err) {
-			return 0, 0, base.NewAPIError("invalid api token", base.ErrorCause(err),
+			return session.APITokenSession{}, base.NewAPIError("invalid api token", base.ErrorCause(err),
 				base.ErrorHTTPStatus(http.StatusUnauthorized))
 		}
 
-		return 0, 0, err
+		if errors.Is(err, apitoken.ErrTokenExpired) {
+			return session.APITokenSession{}, base.WrapError(err, base.ErrorHTTPStatus(http.StatusUnauthorized))
+		}
+
+		return session.APITokenSession{}, err
 	}
 
-	// ensure that the user is not disabled
-	if u.DisabledAt != nil {
-		return 0, 0, base.WrapError(auth.ErrUserDisabled, base.ErrorHTTPStatus(http.StatusUnauthorized))
+	s := session.APITokenSession{
+		TokenID:   t.ID,
+		UserID:    t.UserID,
+		OrgID:     t.OrganizationID,
+		Scopes:    t.ScopeList(),
+		ExpiresAt: t.ExpiresAt,
 	}
 
-	// set the api token in the session
-	if err := m.sessionManager.CreateSession(ctx, u.ID, u.OrganizationID, "",
-		apiToken, auth.DefaultSessionTTL); err != nil {
-		return 0, 0, err
+	// cache the token in the session. the last used time is updated again once the cache expires
+	if err := m.sessionManager.CreateAPITokenSession(ctx, apiToken, s, apitoken.CacheTTL); err != nil {
+		return session.APITokenSession{}, err
 	}
 
-	return u.ID, u.OrganizationID, nil
+	return s, nil
 }
@@ -9,13 +9,14 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/camelhr/camelhr-api/internal/base"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/organization"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
-	"github.com/camelhr/camelhr-api/internal/domains/user"
 	"github.com/camelhr/camelhr-api/internal/tests/fake"
 	"github.com/camelhr/camelhr-api/internal/web/middleware"
 	"github.com/camelhr/camelhr-api/internal/web/request"
-	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -27,33 +28,35 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 	t.Run("should validate the request with a jwt bearer token", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// generate a new jwt token
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
 		subdomain := gofakeit.LetterN(30)
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, subdomain)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, subdomain, sessionID)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
 		// mock expectations
-		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, token).Return(nil).Once()
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(false, nil).Once()
 
 		// create a new request with jwt bearer token
 		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -78,6 +81,12 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			s, ok := orgSubdomainCtx.(string)
 			assert.True(t, ok)
 			assert.Equal(t, subdomain, s)
+
+			sessionIDCtx := ctx.Value(request.CtxSessionIDKey)
+			require.NotNil(t, sessionIDCtx)
+			sid, ok := sessionIDCtx.(string)
+			assert.True(t, ok)
+			assert.Equal(t, sessionID, sid)
 		})).ServeHTTP(rr, req)
 
 		// assert that the response status code is 200
@@ -87,24 +96,27 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 	t.Run("should validate the request with a jwt cookie", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// generate a new jwt token
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
 		subdomain := gofakeit.LetterN(30)
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, subdomain)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, subdomain, sessionID)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
 		// mock expectations
-		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, token).Return(nil).Once()
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(false, nil).Once()
 
 		// create a new request with jwt bearer token
 		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
@@ -113,10 +125,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			Value: token,
 		})
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -141,6 +152,12 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			s, ok := orgSubdomainCtx.(string)
 			assert.True(t, ok)
 			assert.Equal(t, subdomain, s)
+
+			sessionIDCtx := ctx.Value(request.CtxSessionIDKey)
+			require.NotNil(t, sessionIDCtx)
+			sid, ok := sessionIDCtx.(string)
+			assert.True(t, ok)
+			assert.Equal(t, sessionID, sid)
 		})).ServeHTTP(rr, req)
 
 		// assert that the response status code is 200
@@ -151,26 +168,36 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		t.Parallel()
 
 		sessionManager := session.NewMockSessionManager(t)
-		userService := user.NewMockService(t)
+		orgService := organization.NewMockService(t)
+		apiTokenService := apitoken.NewMockService(t)
 		subdomain := gofakeit.LetterN(30)
 		apiToken := gofakeit.UUID()
-		u := user.User{
+		token := apitoken.APIToken{
 			ID:             gofakeit.Int64(),
+			UserID:         gofakeit.Int64(),
 			OrganizationID: gofakeit.Int64(),
+			Scopes:         apitoken.ScopeUsersRead,
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+		apiTokenSession := session.APITokenSession{
+			TokenID:   token.ID,
+			UserID:    token.UserID,
+			OrgID:     token.OrganizationID,
+			Scopes:    []string{apitoken.ScopeUsersRead},
+			ExpiresAt: token.ExpiresAt,
 		}
 
 		// mock expectations
 		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
-			Return(int64(0), int64(0), assert.AnError).Once()
+			Return(session.APITokenSession{}, assert.AnError).Once()
 
-		userService.On("GetUserByOrgSubdomainAPIToken", mock.Anything, subdomain, apiToken).
-			Return(u, nil).Once()
-
-		sessionManager.On("CreateSession", fake.MockContext, u.ID, u.OrganizationID, "", apiToken, auth.DefaultSessionTTL).
+		apiTokenService.On("Authenticate", fake.MockContext, subdomain, apiToken).Return(token, nil).Once()
+		sessionManager.On("CreateAPITokenSession", fake.MockContext, apiToken, apiTokenSession, apitoken.CacheTTL).
 			Return(nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, token.OrganizationID).Return(false, nil).Once()
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware("", userService, sessionManager)
+		m := middleware.NewAuthMiddleware(nil, apiTokenService, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -180,10 +207,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			auth.APITokenBasicAuthPassword,
 		)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: token.OrganizationID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -195,13 +221,17 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			require.NotNil(t, userIDCtx)
 			uid, ok := userIDCtx.(int64)
 			assert.True(t, ok)
-			assert.Equal(t, u.ID, uid)
+			assert.Equal(t, token.UserID, uid)
 
 			orgIDCtx := ctx.Value(request.CtxOrgIDKey)
 			require.NotNil(t, orgIDCtx)
 			oid, ok := orgIDCtx.(int64)
 			assert.True(t, ok)
-			assert.Equal(t, u.OrganizationID, oid)
+			assert.Equal(t, token.OrganizationID, oid)
+
+			scopes, ok := ctx.Value(request.CtxAPITokenScopesKey).([]string)
+			assert.True(t, ok)
+			assert.Equal(t, []string{apitoken.ScopeUsersRead}, scopes)
 
 			orgSubdomainCtx := ctx.Value(request.CtxOrgSubdomainKey)
 			require.NotNil(t, orgSubdomainCtx)
@@ -218,26 +248,35 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		t.Parallel()
 
 		sessionManager := session.NewMockSessionManager(t)
-		userService := user.NewMockService(t)
+		orgService := organization.NewMockService(t)
+		apiTokenService := apitoken.NewMockService(t)
 		subdomain := gofakeit.LetterN(30)
 		apiToken := gofakeit.UUID()
-		u := user.User{
+		token := apitoken.APIToken{
 			ID:             gofakeit.Int64(),
+			UserID:         gofakeit.Int64(),
 			OrganizationID: gofakeit.Int64(),
+			Scopes:         apitoken.ScopeUsersRead,
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+		apiTokenSession := session.APITokenSession{
+			TokenID:   token.ID,
+			UserID:    token.UserID,
+			OrgID:     token.OrganizationID,
+			Scopes:    []string{apitoken.ScopeUsersRead},
+			ExpiresAt: token.ExpiresAt,
 		}
 
 		// mock expectations
 		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
-			Return(int64(0), int64(0), assert.AnError).Once()
+			Return(session.APITokenSession{}, assert.AnError).Once()
 
-		userService.On("GetUserByOrgSubdomainAPIToken", mock.Anything, subdomain, apiToken).
-			Return(u, nil).Once()
-
-		sessionManager.On("CreateSession", fake.MockContext, u.ID, u.OrganizationID, "", apiToken, auth.DefaultSessionTTL).
+		apiTokenService.On("Authenticate", fake.MockContext, subdomain, apiToken).Return(token, nil).Once()
+		sessionManager.On("CreateAPITokenSession", fake.MockContext, apiToken, apiTokenSession, apitoken.CacheTTL).
 			Return(assert.AnError).Once()
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware("", userService, sessionManager)
+		m := middleware.NewAuthMiddleware(nil, apiTokenService, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -247,10 +286,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			auth.APITokenBasicAuthPassword,
 		)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: token.OrganizationID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -268,7 +306,8 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		t.Parallel()
 
 		sessionManager := session.NewMockSessionManager(t)
-		userService := user.NewMockService(t)
+		orgService := organization.NewMockService(t)
+		apiTokenService := apitoken.NewMockService(t)
 		subdomain := gofakeit.LetterN(30)
 		apiToken := gofakeit.UUID()
 		userID := gofakeit.Int64()
@@ -276,10 +315,12 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 
 		// mock expectations
 		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
-			Return(userID, orgID, nil).Once()
+			Return(session.APITokenSession{UserID: userID, OrgID: orgID, Scopes: []string{apitoken.ScopeUsersRead}}, nil).
+			Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(false, nil).Once()
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware("", userService, sessionManager)
+		m := middleware.NewAuthMiddleware(nil, apiTokenService, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -289,10 +330,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			auth.APITokenBasicAuthPassword,
 		)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -323,15 +363,48 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		require.Equal(t, http.StatusOK, rr.Code)
 	})
 
-	t.Run("should return unauthorized response if subdomain path param is missing", func(t *testing.T) {
+	t.Run("should return unauthorized response for an api-token session of another organization", func(t *testing.T) {
+		t.Parallel()
+
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+		apiToken := gofakeit.UUID()
+
+		// mock expectations
+		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
+			Return(session.APITokenSession{UserID: gofakeit.Int64(), OrgID: gofakeit.Int64()}, nil).Once()
+
+		// create a new auth middleware
+		m := middleware.NewAuthMiddleware(nil, nil, sessionManager, orgService, nil)
+		require.NotNil(t, m)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.SetBasicAuth(apiToken, auth.APITokenBasicAuthPassword)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		require.JSONEq(t, `{"error":"invalid api token"}`, rr.Body.String())
+	})
+
+	t.Run("should return unauthorized response if organization is not resolved", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -346,18 +419,19 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 
 		// assert that the response status code is 401
 		require.Equal(t, http.StatusUnauthorized, rr.Code)
-		require.JSONEq(t, `{"error":"subdomain is required for user authentication"}`, rr.Body.String())
+		require.JSONEq(t, `{"error":"organization is required for user authentication"}`, rr.Body.String())
 	})
 
 	t.Run("should return unauthorized response for an invalid jwt token", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create random user id, org id and org subdomain
@@ -366,7 +440,8 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		orgSubdomain := gofakeit.Username()
 
 		// generate jwt token
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, orgSubdomain)
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, orgSubdomain,
+			gofakeit.UUID())
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
@@ -377,10 +452,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", "test")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: "test"}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -394,37 +468,78 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		require.JSONEq(t, `{"error":"invalid token"}`, rr.Body.String())
 	})
 
+	t.Run("should return token expired response for an expired jwt token", func(t *testing.T) {
+		t.Parallel()
+
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+
+		// create a new auth middleware
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
+		require.NotNil(t, m)
+
+		// generate an already expired jwt token
+		token, err := auth.GenerateJWT(-time.Minute, jwtKeys, gofakeit.Int64(), gofakeit.Int64(),
+			gofakeit.Username(), gofakeit.UUID())
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		// create a new request with jwt bearer token
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "test"}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		// create a new response recorder
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		// assert that the response
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		require.JSONEq(t, `{"error":"token expired"}`, rr.Body.String())
+		require.Equal(t, `Bearer error="invalid_token", error_description="token expired"`,
+			rr.Header().Get("WWW-Authenticate"))
+	})
+
 	t.Run("should return unauthorized response if jwt is not found in session", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// generate a new jwt token
 		userID := gofakeit.Int64()
 		orgID := gofakeit.Int64()
 		subdomain := gofakeit.LetterN(30)
-		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, appSecret, userID, orgID, subdomain)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, subdomain, sessionID)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 
 		// mock expectations
-		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, token).
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).
 			Return(assert.AnError).Once()
 
 		// create a new request with jwt bearer token
 		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
 		req.Header.Set("Authorization", "Bearer "+token)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -438,21 +553,114 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		require.JSONEq(t, `{"error":"invalid token"}`, rr.Body.String())
 	})
 
+	t.Run("should ask to renew the session when the subdomain of the organization was changed", func(t *testing.T) {
+		t.Parallel()
+
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+
+		// create a new auth middleware
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
+		require.NotNil(t, m)
+
+		// generate a jwt token issued for the former subdomain
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		formerSubdomain := gofakeit.LetterN(30)
+		subdomain := gofakeit.LetterN(30)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, formerSubdomain, sessionID)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		// mock expectations
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+
+		// create a new request with jwt bearer token
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		// create a new response recorder
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		// assert that the response
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		require.JSONEq(t, `{"error":"organization subdomain changed"}`, rr.Body.String())
+		require.Equal(t, `Bearer error="invalid_token", error_description="subdomain changed"`,
+			rr.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("should return unauthorized response if user doesn't belong to the organization", func(t *testing.T) {
+		t.Parallel()
+
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+
+		// create a new auth middleware
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
+		require.NotNil(t, m)
+
+		// generate a new jwt token
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		subdomain := gofakeit.LetterN(30)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, subdomain, sessionID)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+
+		// mock expectations
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+
+		// create a new request with jwt bearer token
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		// set another organization resolved by the tenant middleware
+		otherOrg := organization.Organization{ID: gofakeit.Int64(), Subdomain: gofakeit.LetterN(30)}
+		req = req.WithContext(organization.NewContext(req.Context(), otherOrg))
+
+		// create a new response recorder
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		// assert that the response
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		require.JSONEq(t, `{"error":"user doesn't belong to the organization"}`, rr.Body.String())
+		require.Empty(t, rr.Header().Get("WWW-Authenticate"))
+	})
+
 	t.Run("should return unauthorized response if user not found for api-token", func(t *testing.T) {
 		t.Parallel()
 
 		sessionManager := session.NewMockSessionManager(t)
-		userService := user.NewMockService(t)
+		orgService := organization.NewMockService(t)
+		apiTokenService := apitoken.NewMockService(t)
 		subdomain := gofakeit.LetterN(30)
 		apiToken := gofakeit.UUID()
 
 		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
-			Return(int64(0), int64(0), assert.AnError).Once()
-		userService.On("GetUserByOrgSubdomainAPIToken", mock.Anything, subdomain, apiToken).
-			Return(user.User{}, base.NewNotFoundError("not found")).Once()
+			Return(session.APITokenSession{}, assert.AnError).Once()
+		apiTokenService.On("Authenticate", fake.MockContext, subdomain, apiToken).
+			Return(apitoken.APIToken{}, base.NewNotFoundError("not found")).Once()
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware("", userService, sessionManager)
+		m := middleware.NewAuthMiddleware(nil, apiTokenService, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -462,10 +670,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			auth.APITokenBasicAuthPassword,
 		)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -479,26 +686,22 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		require.JSONEq(t, `{"error":"invalid api token"}`, rr.Body.String())
 	})
 
-	t.Run("should return unauthorized response for an api-token of disabled user", func(t *testing.T) {
+	t.Run("should return unauthorized response for an expired api-token", func(t *testing.T) {
 		t.Parallel()
 
 		sessionManager := session.NewMockSessionManager(t)
-		userService := user.NewMockService(t)
+		orgService := organization.NewMockService(t)
+		apiTokenService := apitoken.NewMockService(t)
 		subdomain := gofakeit.LetterN(30)
 		apiToken := gofakeit.UUID()
-		now := time.Now()
-		u := user.User{
-			ID:         gofakeit.Int64(),
-			DisabledAt: &now,
-		}
 
 		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
-			Return(int64(0), int64(0), assert.AnError).Once()
-		userService.On("GetUserByOrgSubdomainAPIToken", mock.Anything, subdomain, apiToken).
-			Return(u, nil).Once()
+			Return(session.APITokenSession{}, assert.AnError).Once()
+		apiTokenService.On("Authenticate", fake.MockContext, subdomain, apiToken).
+			Return(apitoken.APIToken{}, apitoken.ErrTokenExpired).Once()
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware("", userService, sessionManager)
+		m := middleware.NewAuthMiddleware(nil, apiTokenService, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
@@ -508,10 +711,9 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 			auth.APITokenBasicAuthPassword,
 		)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", subdomain)
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -522,27 +724,27 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 
 		// assert that the response
 		require.Equal(t, http.StatusUnauthorized, rr.Code)
-		require.JSONEq(t, `{"error":"user is disabled"}`, rr.Body.String())
+		require.JSONEq(t, `{"error":"api token expired"}`, rr.Body.String())
 	})
 
 	t.Run("should return unauthorized response for a request without auth details", func(t *testing.T) {
 		t.Parallel()
 
-		// create a random secret key
-		appSecret := gofakeit.UUID()
+		// create a key set with a random secret
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
 		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
 
 		// create a new auth middleware
-		m := middleware.NewAuthMiddleware(appSecret, nil, sessionManager)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
 		require.NotNil(t, m)
 
 		// create a new request with jwt bearer token
 		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
 
-		// simulate chi's URL parameters
-		routeContext := chi.NewRouteContext()
-		routeContext.URLParams.Add("subdomain", "test")
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeContext))
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: gofakeit.Int64(), Subdomain: "test"}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
 
 		// create a new response recorder
 		rr := httptest.NewRecorder()
@@ -555,4 +757,314 @@ func TestAuthMiddleware_ValidateAuth(t *testing.T) {
 		require.Equal(t, http.StatusUnauthorized, rr.Code)
 		require.Empty(t, rr.Body.String())
 	})
+
+	t.Run("should return forbidden response for a jwt of a suspended organization", func(t *testing.T) {
+		t.Parallel()
+
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, nil)
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		subdomain := gofakeit.LetterN(30)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateJWT(auth.DefaultSessionTTL, jwtKeys, userID, orgID, subdomain, sessionID)
+		require.NoError(t, err)
+
+		// mock expectations
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(true, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.JSONEq(t, `{"error":"organization is suspended"}`, rr.Body.String())
+	})
+
+	t.Run("should return forbidden response for an api-token of a suspended organization", func(t *testing.T) {
+		t.Parallel()
+
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+		m := middleware.NewAuthMiddleware(nil, nil, sessionManager, orgService, nil)
+		subdomain := gofakeit.LetterN(30)
+		apiToken := gofakeit.UUID()
+		orgID := gofakeit.Int64()
+
+		// mock expectations
+		sessionManager.On("ValidateAPITokenSession", fake.MockContext, apiToken).
+			Return(session.APITokenSession{UserID: gofakeit.Int64(), OrgID: orgID}, nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(true, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.SetBasicAuth(apiToken, auth.APITokenBasicAuthPassword)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should record the request of an impersonation session and mark the response", func(t *testing.T) {
+		t.Parallel()
+
+		jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+		sessionManager := session.NewMockSessionManager(t)
+		orgService := organization.NewMockService(t)
+		adminService := admin.NewMockService(t)
+		m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, adminService)
+
+		userID := gofakeit.Int64()
+		orgID := gofakeit.Int64()
+		subdomain := gofakeit.LetterN(30)
+		sessionID := gofakeit.UUID()
+		token, err := auth.GenerateImpersonationJWT(auth.ImpersonationSessionTTL, jwtKeys, userID, orgID, subdomain,
+			sessionID, "john")
+		require.NoError(t, err)
+
+		// mock expectations
+		sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).Return(nil).Once()
+		orgService.On("IsSuspended", fake.MockContext, orgID).Return(false, nil).Once()
+		adminService.On("LogImpersonatedRequest", fake.MockContext, admin.ImpersonationLog{
+			OrganizationID: orgID,
+			UserID:         userID,
+			Operator:       "john",
+			SessionID:      sessionID,
+			Method:         http.MethodGet,
+			Path:           "/api/some-endpoint",
+		}).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		// set the organization resolved by the tenant middleware
+		org := organization.Organization{ID: orgID, Subdomain: subdomain}
+		req = req.WithContext(organization.NewContext(req.Context(), org))
+
+		rr := httptest.NewRecorder()
+
+		m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			operator, ok := r.Context().Value(request.CtxImpersonatorKey).(string)
+			assert.True(t, ok)
+			assert.Equal(t, "john", operator)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "john", rr.Header().Get(auth.ImpersonatedByHeader))
+	})
+
+	t.Run("should return forbidden response when the organization no longer allows the impersonation",
+		func(t *testing.T) {
+			t.Parallel()
+
+			jwtKeys := auth.NewHMACKeySet(gofakeit.UUID())
+			sessionManager := session.NewMockSessionManager(t)
+			orgService := organization.NewMockService(t)
+			adminService := admin.NewMockService(t)
+			m := middleware.NewAuthMiddleware(jwtKeys, nil, sessionManager, orgService, adminService)
+
+			userID := gofakeit.Int64()
+			orgID := gofakeit.Int64()
+			subdomain := gofakeit.LetterN(30)
+			sessionID := gofakeit.UUID()
+			token, err := auth.GenerateImpersonationJWT(auth.ImpersonationSessionTTL, jwtKeys, userID, orgID,
+				subdomain, sessionID, "john")
+			require.NoError(t, err)
+
+			// mock expectations
+			sessionManager.On("ValidateJWTSession", fake.MockContext, userID, orgID, sessionID, token).
+				Return(nil).Once()
+			orgService.On("IsSuspended", fake.MockContext, orgID).Return(false, nil).Once()
+			adminService.On("LogImpersonatedRequest", fake.MockContext, mock.Anything).
+				Return(admin.ErrImpersonationNotAllowed).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			// set the organization resolved by the tenant middleware
+			org := organization.Organization{ID: orgID, Subdomain: subdomain}
+			req = req.WithContext(organization.NewContext(req.Context(), org))
+
+			rr := httptest.NewRecorder()
+
+			m.ValidateAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Fail(t, "should not be called")
+			})).ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+			assert.JSONEq(t, `{"error":"impersonation is not allowed by the organization"}`, rr.Body.String())
+		})
+}
+
+func TestAuthMiddleware_RequireScope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow the request authenticated using a session", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		rr := httptest.NewRecorder()
+
+		m.RequireScope(apitoken.ScopeUsersWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should allow the request of an api-token granted the scope", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxAPITokenScopesKey,
+			[]string{apitoken.ScopeUsersRead, apitoken.ScopeUsersWrite}))
+		rr := httptest.NewRecorder()
+
+		m.RequireScope(apitoken.ScopeUsersWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return forbidden response for an api-token without the scope", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxAPITokenScopesKey,
+			[]string{apitoken.ScopeUsersRead}))
+		rr := httptest.NewRecorder()
+
+		m.RequireScope(apitoken.ScopeUsersWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.JSONEq(t, `{"error":"api token does not have the required scope: users:write"}`, rr.Body.String())
+	})
+}
+
+func TestAuthMiddleware_RequireSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow the request authenticated using a session", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		rr := httptest.NewRecorder()
+
+		m.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return forbidden response for the request authenticated using an api-token", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxAPITokenScopesKey,
+			[]string{apitoken.ScopeUsersRead}))
+		rr := httptest.NewRecorder()
+
+		m.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.JSONEq(t, `{"error":"api token is not allowed for this endpoint"}`, rr.Body.String())
+	})
+	t.Run("should return forbidden response for the request of an impersonation session", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxImpersonatorKey, "john"))
+		rr := httptest.NewRecorder()
+
+		m.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "should not be called")
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+		require.JSONEq(t, `{"error":"impersonation is not allowed for this endpoint"}`, rr.Body.String())
+	})
+}
+
+func TestAuthMiddleware_RejectImpersonation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should allow the request that modifies the data using a session", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodDelete, "/api/some-endpoint", nil)
+		rr := httptest.NewRecorder()
+
+		m.RejectImpersonation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should allow the read request of an impersonation session", func(t *testing.T) {
+		t.Parallel()
+
+		m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/some-endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxImpersonatorKey, "john"))
+		rr := httptest.NewRecorder()
+
+		m.RejectImpersonation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run("should return forbidden response for the "+method+" request of an impersonation session",
+			func(t *testing.T) {
+				t.Parallel()
+
+				m := middleware.NewAuthMiddleware(nil, nil, nil, nil, nil)
+				req := httptest.NewRequest(method, "/api/some-endpoint", nil)
+				req = req.WithContext(context.WithValue(req.Context(), request.CtxImpersonatorKey, "john"))
+				rr := httptest.NewRecorder()
+
+				m.RejectImpersonation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Fail(t, "should not be called")
+				})).ServeHTTP(rr, req)
+
+				require.Equal(t, http.StatusForbidden, rr.Code)
+				require.JSONEq(t, `{"error":"impersonation is not allowed to modify the data"}`, rr.Body.String())
+			})
+	}
 }
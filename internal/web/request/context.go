@@ -0,0 +1,65 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrInvalidContext = errors.New("invalid context")
+
+// UserID returns the id of the authenticated user set in the request context by the auth middleware.
+func UserID(r *http.Request) (int64, error) {
+	userID, ok := r.Context().Value(CtxUserIDKey).(int64)
+	if !ok {
+		return 0, fmt.Errorf("user id not found in the request context: %w", ErrInvalidContext)
+	}
+
+	return userID, nil
+}
+
+// OrgID returns the organization id of the authenticated user set in the request context by the auth middleware.
+func OrgID(r *http.Request) (int64, error) {
+	orgID, ok := r.Context().Value(CtxOrgIDKey).(int64)
+	if !ok {
+		return 0, fmt.Errorf("org id not found in the request context: %w", ErrInvalidContext)
+	}
+
+	return orgID, nil
+}
+
+// UserIDOrgID returns the user id and the organization id of the authenticated user from the request context.
+func UserIDOrgID(r *http.Request) (int64, int64, error) {
+	userID, err := UserID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	orgID, err := OrgID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, orgID, nil
+}
+
+// SessionID returns the id of the session the request is authenticated with.
+// It is set in the request context by the auth middleware unless the request is made using an api token.
+func SessionID(r *http.Request) (string, error) {
+	sessionID, ok := r.Context().Value(CtxSessionIDKey).(string)
+	if !ok {
+		return "", fmt.Errorf("session id not found in the request context: %w", ErrInvalidContext)
+	}
+
+	return sessionID, nil
+}
+
+// Operator returns the name of the platform operator set in the request context by the admin middleware.
+func Operator(r *http.Request) (string, error) {
+	operator, ok := r.Context().Value(CtxOperatorKey).(string)
+	if !ok {
+		return "", fmt.Errorf("operator not found in the request context: %w", ErrInvalidContext)
+	}
+
+	return operator, nil
+}
@@ -0,0 +1,95 @@
+package request_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/web/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserIDOrgID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the user id and the org id of the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx := context.WithValue(req.Context(), request.CtxUserIDKey, int64(1))
+		ctx = context.WithValue(ctx, request.CtxOrgIDKey, int64(2))
+
+		userID, orgID, err := request.UserIDOrgID(req.WithContext(ctx))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), userID)
+		assert.Equal(t, int64(2), orgID)
+	})
+
+	t.Run("should return error when the user id is not set", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxOrgIDKey, int64(2)))
+
+		_, _, err := request.UserIDOrgID(req)
+		require.ErrorIs(t, err, request.ErrInvalidContext)
+		assert.EqualError(t, err, "user id not found in the request context: invalid context")
+	})
+
+	t.Run("should return error when the org id is not set", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxUserIDKey, int64(1)))
+
+		_, _, err := request.UserIDOrgID(req)
+		require.ErrorIs(t, err, request.ErrInvalidContext)
+		assert.EqualError(t, err, "org id not found in the request context: invalid context")
+	})
+}
+
+func TestSessionID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the session id of the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxSessionIDKey, "session"))
+
+		sessionID, err := request.SessionID(req)
+		require.NoError(t, err)
+		assert.Equal(t, "session", sessionID)
+	})
+
+	t.Run("should return error when the session id is not set", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := request.SessionID(httptest.NewRequest(http.MethodGet, "/", nil))
+		require.ErrorIs(t, err, request.ErrInvalidContext)
+	})
+}
+
+func TestOperator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the operator of the request context", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), request.CtxOperatorKey, "john"))
+
+		operator, err := request.Operator(req)
+		require.NoError(t, err)
+		assert.Equal(t, "john", operator)
+	})
+
+	t.Run("should return error when the operator is not set", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := request.Operator(httptest.NewRequest(http.MethodGet, "/", nil))
+		require.ErrorIs(t, err, request.ErrInvalidContext)
+	})
+}
@@ -20,6 +20,11 @@ const (
 	CtxUserIDKey requestContextKey = iota
 	CtxOrgIDKey
 	CtxOrgSubdomainKey
+	CtxSessionIDKey
+	CtxAPITokenScopesKey
+	CtxOperatorKey
+	CtxImpersonatorKey
+	CtxOrganizationKey
 )
 
 var ErrInvalidPathParam = errors.New("invalid path parameter")
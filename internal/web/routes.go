@@ -6,10 +6,22 @@ import (
 
 	"github.com/camelhr/camelhr-api/internal/config"
 	"github.com/camelhr/camelhr-api/internal/database"
+	"github.com/camelhr/camelhr-api/internal/domains/admin"
+	"github.com/camelhr/camelhr-api/internal/domains/apitoken"
 	"github.com/camelhr/camelhr-api/internal/domains/auth"
+	"github.com/camelhr/camelhr-api/internal/domains/customdomain"
+	"github.com/camelhr/camelhr-api/internal/domains/invitation"
+	"github.com/camelhr/camelhr-api/internal/domains/lockout"
+	"github.com/camelhr/camelhr-api/internal/domains/mfa"
 	"github.com/camelhr/camelhr-api/internal/domains/organization"
+	"github.com/camelhr/camelhr-api/internal/domains/orgsettings"
+	"github.com/camelhr/camelhr-api/internal/domains/ownership"
+	"github.com/camelhr/camelhr-api/internal/domains/passwordpolicy"
+	"github.com/camelhr/camelhr-api/internal/domains/role"
 	"github.com/camelhr/camelhr-api/internal/domains/session"
+	"github.com/camelhr/camelhr-api/internal/domains/sso"
 	"github.com/camelhr/camelhr-api/internal/domains/user"
+	"github.com/camelhr/camelhr-api/internal/mailer"
 	"github.com/camelhr/camelhr-api/internal/web/middleware"
 	"github.com/camelhr/camelhr-api/internal/web/response"
 	"github.com/go-chi/chi/v5"
@@ -21,27 +33,79 @@ import (
 // SetupRoutes initializes the routes for the web server.
 //
 //nolint:funlen // ignore function length since this is a setup function
-func SetupRoutes(db database.Database, redisClient *redis.Client, conf config.Config) http.Handler {
+func SetupRoutes(
+	db database.Database, redisClient *redis.Client, conf config.Config, jwtKeys *auth.KeySet,
+) http.Handler {
 	// initialize dependencies
 	sessionManager := session.NewRedisSessionManager(redisClient)
+	sessionHandler := session.NewHandler(sessionManager)
+	lockoutManager := lockout.NewRedisLockoutManager(redisClient, conf)
 	orgRepo := organization.NewRepository(db)
-	orgService := organization.NewService(orgRepo, sessionManager)
+	orgService := organization.NewService(conf, orgRepo, sessionManager,
+		organization.NewRedisStatusCache(redisClient))
 	orgHandler := organization.NewHandler(orgService)
+	passwordPolicyRepo := passwordpolicy.NewRepository(db)
+	passwordPolicyService := passwordpolicy.NewService(passwordPolicyRepo)
+	passwordPolicyHandler := passwordpolicy.NewHandler(passwordPolicyService)
+	orgSettingsRepo := orgsettings.NewRepository(db)
+	orgSettingsService := orgsettings.NewService(orgSettingsRepo, orgsettings.NewRedisCache(redisClient))
+	orgSettingsHandler := orgsettings.NewHandler(orgSettingsService)
 	userRepo := user.NewRepository(db)
-	userService := user.NewService(userRepo, sessionManager)
-	authService := auth.NewService(conf.AppSecret, db, orgService, userService, sessionManager)
+	userService := user.NewService(userRepo, sessionManager, user.NewArgon2idPasswordHasher(conf),
+		passwordPolicyService)
+	userHandler := user.NewHandler(userService)
+	mfaRepo := mfa.NewRepository(db)
+	mfaService := mfa.NewService(mfaRepo, db, orgService, userService)
+	mfaHandler := mfa.NewHandler(mfaService)
+	ssoRepo := sso.NewRepository(db)
+	ssoService := sso.NewService(conf, ssoRepo, sso.NewOIDCClient(conf), sso.NewRedisStateManager(redisClient))
+	ssoHandler := sso.NewHandler(ssoService)
+	customDomainRepo := customdomain.NewRepository(db)
+	customDomainService := customdomain.NewService(conf, customDomainRepo, customdomain.NewNetResolver(nil))
+	customDomainHandler := customdomain.NewHandler(customDomainService)
+	appMailer := newMailer(conf)
+	authRepo := auth.NewRepository(db)
+	authService := auth.NewService(conf, jwtKeys, authRepo, db, orgService, userService, mfaService, ssoService,
+		sessionManager, lockoutManager, appMailer)
 	authHandler := auth.NewHandler(authService)
-	authMiddleware := middleware.NewAuthMiddleware(conf.AppSecret, userService, sessionManager)
+	apiTokenRepo := apitoken.NewRepository(db)
+	apiTokenService := apitoken.NewService(apiTokenRepo, sessionManager)
+	apiTokenHandler := apitoken.NewHandler(apiTokenService)
+	roleRepo := role.NewRepository(db)
+	permissionCache := role.NewRedisPermissionCache(redisClient)
+	roleService := role.NewService(roleRepo, permissionCache, userService)
+	roleHandler := role.NewHandler(roleService)
+	invitationRepo := invitation.NewRepository(db)
+	invitationService := invitation.NewService(conf, invitationRepo, db, orgService, userService, roleService,
+		appMailer)
+	invitationHandler := invitation.NewHandler(invitationService)
+	ownershipRepo := ownership.NewRepository(db)
+	ownershipService := ownership.NewService(conf, ownershipRepo, db, orgService, userService, permissionCache,
+		appMailer)
+	ownershipHandler := ownership.NewHandler(ownershipService)
+	adminRepo := admin.NewRepository(db)
+	adminService := admin.NewService(conf, adminRepo, db, orgService, authService)
+	adminHandler := admin.NewHandler(adminService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtKeys, apiTokenService, sessionManager, orgService,
+		adminService)
+	permissionMiddleware := middleware.NewPermissionMiddleware(roleService)
+	adminMiddleware := middleware.NewAdminMiddleware(conf)
+	tenantMiddleware := middleware.NewTenantMiddleware(conf, orgService, customDomainService)
+	realIPMiddleware := middleware.NewRealIPMiddleware(conf)
 
 	// create a default router
 	r := chi.NewRouter()
 
 	// add middlewares
-	r.Use(cors.Handler(corsOptions()))
+	r.Use(cors.Handler(corsOptions(conf, customDomainService)))
 	r.Use(chimiddleware.RequestID)
+	r.Use(realIPMiddleware.RealIP)
 	r.Use(middleware.ChiRequestLoggerMiddleware()) // <--<< logger should come before recoverer
 	r.Use(chimiddleware.Recoverer)
 
+	// public keys to verify the access tokens
+	r.Get("/.well-known/jwks.json", authHandler.JWKS)
+
 	// create a sub-router for v1 api endpoints
 	v1 := chi.NewRouter()
 	r.Mount("/api/v1", v1)
@@ -53,57 +117,251 @@ func SetupRoutes(db database.Database, redisClient *redis.Client, conf config.Co
 		})
 
 		r.Post("/auth/register", authHandler.Register)
+		r.Post("/auth/verify-email", authHandler.VerifyEmail)
+
+		// the subdomain is checked before the registration. it is not resolved to an organization
+		r.Get("/subdomains/{subdomain}/availability", orgHandler.CheckSubdomainAvailability)
+	})
+
+	// platform admin routes. admin api key required
+	v1.Route("/admin", func(r chi.Router) {
+		r.Use(adminMiddleware.ValidateAdminAuth)
+
+		r.Get("/organizations", adminHandler.ListOrganizations)
+		r.Get("/organizations/{orgID}", adminHandler.GetOrganization)
+		r.Post("/organizations/{orgID}/suspend", adminHandler.SuspendOrganization)
+		r.Post("/organizations/{orgID}/unsuspend", adminHandler.UnsuspendOrganization)
+		r.Post("/organizations/{orgID}/restore", adminHandler.RestoreOrganization)
+		r.Get("/organizations/{orgID}/audit-logs", adminHandler.ListAuditLogs)
+		r.Post("/organizations/{orgID}/users/{userID}/impersonate", adminHandler.Impersonate)
+		r.Get("/organizations/{orgID}/impersonation-logs", adminHandler.ListImpersonationLogs)
+	})
+
+	// create a sub-router for v1 tenant endpoints.
+	// the endpoints are served under /subdomains/{subdomain} as well as under the host of the organization
+	// i.e. a subdomain of the base domain or a verified custom domain
+	v1Tenant := chi.NewRouter()
+	v1.Mount("/subdomains/{subdomain}", v1Tenant)
+	v1.Mount("/", v1Tenant)
+
+	// the deleted organization is resolved to be restored. no auth required
+	v1Tenant.Group(func(r chi.Router) {
+		r.Use(tenantMiddleware.ResolveDeletedTenant)
+
+		r.Post("/auth/restore-organization", authHandler.RequestOrganizationRestore)
+		r.Post("/auth/restore-organization/confirm", authHandler.ConfirmOrganizationRestore)
 	})
 
-	// create a sub-router for v1 subdomain endpoints
-	v1Subdomain := chi.NewRouter()
-	v1.Mount("/subdomains/{subdomain}", v1Subdomain)
+	// the organization of the request is resolved for the rest of the endpoints
+	v1Org := v1Tenant.With(tenantMiddleware.ResolveTenant)
 
-	v1Subdomain.Route("/auth", func(r chi.Router) {
+	v1Org.Route("/auth", func(r chi.Router) {
 		// open routes. no auth required
 		r.Post("/login", authHandler.Login)
+		r.Post("/expired-password", authHandler.ChangeExpiredPassword)
+		r.Post("/refresh", authHandler.Refresh)
+		r.Post("/forgot-password", authHandler.ForgotPassword)
+		r.Post("/reset-password", authHandler.ResetPassword)
+		r.Post("/confirm-email-change", authHandler.ConfirmEmailChange)
+		r.Post("/magic-link", authHandler.RequestMagicLink)
+		r.Post("/magic-link/callback", authHandler.MagicLinkCallback)
+		r.Post("/mfa/setup", authHandler.SetupMFA)
+		r.Post("/mfa/verify", authHandler.VerifyMFA)
+		r.Get("/sso/authorize", authHandler.SSOAuthorize)
+		r.Post("/sso/callback", authHandler.SSOCallback)
 
 		// protected routes. auth required
 		r.Group(func(r chi.Router) {
 			r.Use(authMiddleware.ValidateAuth)
 
 			r.Post("/logout", authHandler.Logout)
+
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireSession)
+
+				r.Post("/mfa/enroll", mfaHandler.Enroll)
+				r.Post("/mfa/activate", mfaHandler.Activate)
+				r.Post("/mfa/disable", mfaHandler.Disable)
+			})
 		})
 	})
 
-	v1Subdomain.Route("/organizations", func(r chi.Router) {
+	// the tenant endpoints below can only be read by a platform operator impersonating a user.
+	// the /auth endpoints are left out so that the operator can log out of the impersonation session
+	v1Org.Route("/organizations", func(r chi.Router) {
 		// open routes. no auth required
 		r.Get("/", orgHandler.GetOrganizationBySubdomain)
 
 		// protected routes. auth required
 		r.Group(func(r chi.Router) {
 			r.Use(authMiddleware.ValidateAuth)
+			r.Use(authMiddleware.RejectImpersonation)
+
+			r.With(
+				authMiddleware.RequireScope(apitoken.ScopeOrganizationWrite),
+				permissionMiddleware.RequirePermission(role.PermissionOrgUpdate),
+			).Put("/", orgHandler.UpdateOrganization)
+			r.With(
+				authMiddleware.RequireScope(apitoken.ScopeOrganizationWrite),
+				permissionMiddleware.RequirePermission(role.PermissionOrgDelete),
+			).Delete("/", orgHandler.DeleteOrganization)
+			r.With(authMiddleware.RequireScope(apitoken.ScopeOrganizationRead)).
+				Get("/settings", orgSettingsHandler.GetSettings)
+			r.With(
+				authMiddleware.RequireScope(apitoken.ScopeOrganizationWrite),
+				permissionMiddleware.RequirePermission(role.PermissionOrgUpdate),
+			).Patch("/settings", orgSettingsHandler.UpdateSettings)
+
+			// the security settings of the organization are managed using a session only
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireSession)
+				r.Use(permissionMiddleware.RequirePermission(role.PermissionOrgSecurity))
+
+				r.Put("/subdomain", orgHandler.ChangeSubdomain)
+				r.Put("/mfa-requirement", mfaHandler.SetOrganizationRequirement)
+				r.Put("/magic-link", orgHandler.SetMagicLinkLogin)
+				r.Put("/impersonation", orgHandler.SetImpersonation)
+				r.Get("/password-policy", passwordPolicyHandler.GetPolicy)
+				r.Put("/password-policy", passwordPolicyHandler.SetPolicy)
+				r.Get("/sso", ssoHandler.GetConfig)
+				r.Put("/sso", ssoHandler.SetConfig)
+				r.Delete("/sso", ssoHandler.DeleteConfig)
+				r.Get("/custom-domain", customDomainHandler.GetDomain)
+				r.Put("/custom-domain", customDomainHandler.SetDomain)
+				r.Post("/custom-domain/verify", customDomainHandler.VerifyDomain)
+				r.Delete("/custom-domain", customDomainHandler.DeleteDomain)
+			})
 
-			r.Put("/", orgHandler.UpdateOrganization)
-			r.Delete("/", orgHandler.DeleteOrganization)
+			// the ownership is transferred using a session only. the service checks the owner and the nominee
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireSession)
+
+				r.Get("/ownership-transfer", ownershipHandler.GetPendingTransfer)
+				r.Post("/ownership-transfer", ownershipHandler.NominateOwner)
+				r.Post("/ownership-transfer/accept", ownershipHandler.AcceptOwnership)
+				r.Delete("/ownership-transfer", ownershipHandler.CancelTransfer)
+			})
+		})
+	})
+
+	v1Org.Route("/users", func(r chi.Router) {
+		// protected routes. auth required
+		r.Use(authMiddleware.ValidateAuth)
+		r.Use(authMiddleware.RejectImpersonation)
+
+		r.With(
+			authMiddleware.RequireScope(apitoken.ScopeUsersWrite),
+			permissionMiddleware.RequirePermission(role.PermissionUsersManage),
+		).Post("/{userID}/unlock", authHandler.UnlockUser)
+
+		// the roles grant the permissions and are managed using a session only
+		r.With(
+			authMiddleware.RequireSession,
+			permissionMiddleware.RequirePermission(role.PermissionRolesManage),
+		).Put("/{userID}/role", roleHandler.AssignRole)
+	})
+
+	v1Org.Route("/invitations", func(r chi.Router) {
+		// open routes. no auth required
+		r.Post("/accept", invitationHandler.AcceptInvitation)
+
+		// protected routes. auth required
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.ValidateAuth)
+			r.Use(authMiddleware.RejectImpersonation)
+			r.Use(permissionMiddleware.RequirePermission(role.PermissionUsersManage))
+
+			r.With(authMiddleware.RequireScope(apitoken.ScopeUsersRead)).Get("/", invitationHandler.ListInvitations)
+
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.RequireScope(apitoken.ScopeUsersWrite))
+
+				r.Post("/", invitationHandler.CreateInvitation)
+				r.Post("/{invitationID}/resend", invitationHandler.ResendInvitation)
+				r.Delete("/{invitationID}", invitationHandler.RevokeInvitation)
+			})
+		})
+	})
+
+	v1Org.Route("/roles", func(r chi.Router) {
+		// protected routes. auth required
+		r.Use(authMiddleware.ValidateAuth)
+		r.Use(authMiddleware.RejectImpersonation)
+		r.Use(authMiddleware.RequireSession)
+
+		r.Get("/", roleHandler.ListRoles)
+
+		r.Group(func(r chi.Router) {
+			r.Use(permissionMiddleware.RequirePermission(role.PermissionRolesManage))
+
+			r.Post("/", roleHandler.CreateRole)
+			r.Put("/{roleID}", roleHandler.UpdateRole)
+			r.Delete("/{roleID}", roleHandler.DeleteRole)
+		})
+	})
+
+	v1Org.Route("/me", func(r chi.Router) {
+		// protected routes. auth required
+		r.Use(authMiddleware.ValidateAuth)
+		r.Use(authMiddleware.RejectImpersonation)
+
+		r.Get("/", userHandler.GetProfile)
+
+		// an api token can not be used to manage the sessions or change the credentials of the user
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireSession)
+
+			r.Get("/sessions", sessionHandler.ListSessions)
+			r.Delete("/sessions/{sessionID}", sessionHandler.RevokeSession)
+			r.Put("/password", userHandler.ChangePassword)
+			r.Put("/email", authHandler.RequestEmailChange)
+			r.Get("/api-tokens", apiTokenHandler.ListAPITokens)
+			r.Post("/api-tokens", apiTokenHandler.CreateAPIToken)
+			r.Post("/api-tokens/{apiTokenID}/regenerate", apiTokenHandler.RegenerateAPIToken)
+			r.Delete("/api-tokens/{apiTokenID}", apiTokenHandler.DeleteAPIToken)
 		})
 	})
 
 	return r
 }
 
-func corsOptions() cors.Options {
+// newMailer returns the smtp mailer when the smtp server is configured. Otherwise, it returns the log mailer.
+func newMailer(conf config.Config) mailer.Mailer {
+	if conf.SMTPAddress == "" {
+		return mailer.NewLogMailer()
+	}
+
+	return mailer.NewSMTPMailer(conf.SMTPAddress, conf.SMTPUsername, conf.SMTPPassword, conf.MailFrom)
+}
+
+// corsOptions allows the requests from the base domain, its subdomains and the verified custom domains.
+func corsOptions(conf config.Config, customDomainService customdomain.Service) cors.Options {
 	const corsMaxAgeInSeconds = 300 // 5 minutes
 
 	return cors.Options{
 		AllowOriginFunc: func(r *http.Request, origin string) bool {
 			// allow exact match
-			if origin == "https://camelhr.com" {
+			if origin == "https://"+conf.BaseDomain {
 				return true
 			}
-			// allow any subdomain of camelhr.com
-			if strings.HasSuffix(origin, ".camelhr.com") {
+			// allow any subdomain of the base domain
+			if strings.HasSuffix(origin, "."+conf.BaseDomain) {
 				return true
 			}
 
-			return false
+			// allow the verified custom domains
+			host, found := strings.CutPrefix(origin, "https://")
+			if !found {
+				return false
+			}
+
+			_, err := customDomainService.GetVerifiedDomain(r.Context(), host)
+
+			return err == nil
+		},
+		AllowedMethods: []string{
+			http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
 		},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
@@ -0,0 +1,40 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/camelhr/camelhr-api/internal/config"
+	"github.com/camelhr/camelhr-api/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupRoutes_CORSPreflight(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{BaseDomain: "camelhr.com"}
+
+	// the preflight requests are answered by the cors middleware before reaching the database
+	h := web.SetupRoutes(nil, nil, conf, nil)
+
+	for _, method := range []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	} {
+		t.Run("should allow the "+method+" requests from a subdomain", func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodOptions, "/api/v1/organizations/settings", nil)
+			req.Header.Set("Origin", "https://acme.camelhr.com")
+			req.Header.Set("Access-Control-Request-Method", method)
+			rr := httptest.NewRecorder()
+
+			h.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "https://acme.camelhr.com", rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, method, rr.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
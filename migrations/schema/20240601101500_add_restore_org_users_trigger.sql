@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- create trigger to restore users of the org when the org is restored
+-- only the users that were soft deleted along with the org are restored
+CREATE OR REPLACE FUNCTION restore_org_users()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- check if the organization is being restored (i.e., deleted_at is being unset)
+    IF NEW.deleted_at IS NULL AND OLD.deleted_at IS NOT NULL THEN
+        UPDATE users
+        SET deleted_at = NULL,
+            comment = NULL
+        WHERE organization_id = OLD.organization_id
+            AND deleted_at >= OLD.deleted_at
+            AND comment = 'deletion_reason: associated_organization_deleted';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER restore_users_on_org_restore
+BEFORE UPDATE ON organizations
+FOR EACH ROW
+EXECUTE FUNCTION restore_org_users();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS restore_users_on_org_restore ON organizations;
+DROP FUNCTION IF EXISTS restore_org_users();
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the openid connect identity provider of the organization
+CREATE TABLE sso_configs (
+    organization_id INTEGER PRIMARY KEY,
+    issuer TEXT NOT NULL CHECK (issuer <> ''),
+    client_id TEXT NOT NULL CHECK (client_id <> ''),
+    client_secret TEXT NOT NULL CHECK (client_secret <> ''),
+    allowed_email_domains TEXT NOT NULL DEFAULT '',
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sso_configs;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the named api tokens of the users. only the sha256 hash of the token is stored
+CREATE TABLE api_tokens (
+    api_token_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL CHECK (name <> ''),
+    token_hash TEXT NOT NULL UNIQUE CHECK (token_hash <> ''),
+    scopes TEXT NOT NULL CHECK (scopes <> ''),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE UNIQUE INDEX idx_api_tokens_user_id_name ON api_tokens(user_id, name);
+
+-- move the existing plaintext tokens so that the current integrations keep working until the token expires
+INSERT INTO
+    api_tokens(user_id, name, token_hash, scopes, expires_at)
+SELECT
+    user_id,
+    'default',
+    encode(sha256(api_token::bytea), 'hex'),
+    'organization:read,organization:write,users:read,users:write,employees:read,employees:write',
+    (CURRENT_TIMESTAMP AT TIME ZONE 'UTC') + INTERVAL '90 days'
+FROM
+    users
+WHERE
+    api_token IS NOT NULL;
+
+ALTER TABLE users DROP COLUMN api_token;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- the plaintext tokens can not be restored from the hashes
+ALTER TABLE users ADD COLUMN api_token TEXT UNIQUE;
+DROP TABLE IF EXISTS api_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the roles of the users. the system roles are shared by all the organizations and have no organization_id.
+-- permissions is the comma separated list of the permissions granted to the role
+CREATE TABLE roles (
+    role_id SERIAL PRIMARY KEY,
+    organization_id INTEGER,
+    name VARCHAR(50) NOT NULL CHECK (name <> ''),
+    permissions TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id)
+);
+
+-- create unique index on the role name. the system role names can not be used by the organizations
+CREATE UNIQUE INDEX idx_roles_organization_id_name ON roles(COALESCE(organization_id, 0), name);
+CREATE INDEX idx_roles_organization_id ON roles(organization_id);
+
+-- create the system roles
+INSERT INTO
+    roles(name, permissions)
+VALUES
+    (
+        'owner',
+        'org:update,org:delete,org:security,users:read,users:manage,roles:manage,employees:read,employees:manage'
+    ),
+    (
+        'admin',
+        'org:update,org:security,users:read,users:manage,roles:manage,employees:read,employees:manage'
+    ),
+    ('hr_manager', 'users:read,employees:read,employees:manage'),
+    ('manager', 'users:read,employees:read'),
+    ('employee', '');
+
+-- assign the owner role to the owners and the employee role to the other users
+ALTER TABLE users ADD COLUMN role_id INTEGER REFERENCES roles(role_id);
+
+UPDATE
+    users
+SET
+    role_id = (
+        SELECT
+            role_id
+        FROM
+            roles
+        WHERE
+            organization_id IS NULL
+            AND name = CASE WHEN users.is_owner THEN 'owner' ELSE 'employee' END
+    );
+
+ALTER TABLE users ALTER COLUMN role_id SET NOT NULL;
+CREATE INDEX idx_users_role_id ON users(role_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN role_id;
+DROP TABLE IF EXISTS roles;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the actions taken by the platform operators on the organizations
+CREATE TABLE admin_audit_logs (
+    admin_audit_log_id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    operator VARCHAR(100) NOT NULL CHECK (operator <> ''),
+    action VARCHAR(50) NOT NULL CHECK (action <> ''),
+    comment VARCHAR(255) NOT NULL CHECK (comment <> ''),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id)
+);
+
+-- create indexes
+CREATE INDEX idx_admin_audit_logs_organization_id ON admin_audit_logs(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS admin_audit_logs;
+-- +goose StatementEnd
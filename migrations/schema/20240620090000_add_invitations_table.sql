@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the invitations of the users to join an organization. only the sha256 hash of the token is stored.
+-- role_id is the role assigned to the user once the invitation is accepted. the default role is used when it is null
+CREATE TABLE invitations (
+    invitation_id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    email VARCHAR(255) NOT NULL CHECK (email <> ''),
+    role_id INTEGER,
+    invited_by INTEGER NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE CHECK (token_hash <> ''),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITHOUT TIME ZONE,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id),
+    FOREIGN KEY (role_id) REFERENCES roles(role_id) ON DELETE SET NULL,
+    FOREIGN KEY (invited_by) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_invitations_organization_id ON invitations(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invitations;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the nominations of the users to take over the ownership of an organization from the current owner.
+-- only one transfer is pending at a time since nominating another user cancels the previous transfer
+CREATE TABLE ownership_transfers (
+    ownership_transfer_id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    from_user_id INTEGER NOT NULL,
+    to_user_id INTEGER NOT NULL CHECK (to_user_id <> from_user_id),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITHOUT TIME ZONE,
+    cancelled_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id),
+    FOREIGN KEY (from_user_id) REFERENCES users(user_id),
+    FOREIGN KEY (to_user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_ownership_transfers_organization_id ON ownership_transfers(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ownership_transfers;
+-- +goose StatementEnd
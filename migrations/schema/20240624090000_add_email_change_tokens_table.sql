@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the pending changes of the email addresses of the users. the email of the user is changed only once
+-- the token mailed to the new email is used. only the sha256 hash of the token is stored
+CREATE TABLE email_change_tokens (
+    email_change_token_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    new_email VARCHAR(255) NOT NULL CHECK (new_email <> ''),
+    token_hash TEXT NOT NULL UNIQUE CHECK (token_hash <> ''),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_email_change_tokens_user_id ON email_change_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_change_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the passwordless login using a link mailed to the user is disabled by default
+ALTER TABLE organizations ADD COLUMN magic_link_enabled BOOLEAN NOT NULL DEFAULT false;
+
+-- the one-time login links mailed to the users. only the sha256 hashes of the token and of the browser binding
+-- are stored. the browser binding is kept in a cookie of the browser that requested the link
+CREATE TABLE magic_link_tokens (
+    magic_link_token_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE CHECK (token_hash <> ''),
+    browser_hash TEXT NOT NULL CHECK (browser_hash <> ''),
+    remember_me BOOLEAN NOT NULL DEFAULT false,
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_magic_link_tokens_user_id ON magic_link_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS magic_link_tokens;
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS magic_link_enabled;
+-- +goose StatementEnd
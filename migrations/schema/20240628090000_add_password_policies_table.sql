@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the password policy of the organization. the default policy applies to the organizations without a policy
+CREATE TABLE password_policies (
+    organization_id INTEGER PRIMARY KEY,
+    min_length INTEGER NOT NULL CHECK (min_length > 0),
+    max_length INTEGER NOT NULL CHECK (max_length >= min_length),
+    require_uppercase BOOLEAN NOT NULL DEFAULT TRUE,
+    require_lowercase BOOLEAN NOT NULL DEFAULT TRUE,
+    require_number BOOLEAN NOT NULL DEFAULT TRUE,
+    require_special BOOLEAN NOT NULL DEFAULT TRUE,
+    reject_common BOOLEAN NOT NULL DEFAULT FALSE,
+    history_count INTEGER NOT NULL DEFAULT 0 CHECK (history_count >= 0),
+    max_age_days INTEGER NOT NULL DEFAULT 0 CHECK (max_age_days >= 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id)
+);
+
+-- the age of the password is counted from the time it was set. the existing passwords are counted from now
+-- so that the users are not forced to change them right after a maximum age is configured
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+    DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC');
+
+-- the previous password hashes of the users. they are kept to prevent the reuse of the recent passwords
+CREATE TABLE password_histories (
+    password_history_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    password_hash TEXT NOT NULL CHECK (password_hash <> ''),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_password_histories_user_id ON password_histories(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS password_histories;
+
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
+
+DROP TABLE IF EXISTS password_policies;
+-- +goose StatementEnd
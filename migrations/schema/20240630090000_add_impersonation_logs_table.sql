@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the impersonation of the users by the platform operators is denied by default
+ALTER TABLE organizations ADD COLUMN impersonation_allowed BOOLEAN NOT NULL DEFAULT false;
+
+-- the requests made by the platform operators while impersonating the users of the organizations
+CREATE TABLE impersonation_logs (
+    impersonation_log_id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    operator VARCHAR(100) NOT NULL CHECK (operator <> ''),
+    session_id TEXT NOT NULL CHECK (session_id <> ''),
+    method VARCHAR(10) NOT NULL CHECK (method <> ''),
+    path TEXT NOT NULL CHECK (path <> ''),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id),
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- create indexes
+CREATE INDEX idx_impersonation_logs_organization_id ON impersonation_logs(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS impersonation_logs;
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS impersonation_allowed;
+-- +goose StatementEnd
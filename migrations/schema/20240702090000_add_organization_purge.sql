@@ -0,0 +1,128 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the organizations purged once their restore window has ended.
+-- the subdomain of a purged organization is released and can be registered again.
+CREATE TABLE organization_tombstones (
+    organization_id INTEGER PRIMARY KEY,
+    subdomain VARCHAR(30) NOT NULL CHECK (subdomain <> ''),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    purged_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC')
+);
+
+-- create indexes
+CREATE INDEX idx_organization_tombstones_subdomain ON organization_tombstones(subdomain);
+
+-- the actions taken by the platform operators are kept after the organization is purged
+ALTER TABLE admin_audit_logs DROP CONSTRAINT admin_audit_logs_organization_id_fkey;
+
+-- create a function that prevents the hard delete of a row unless it belongs to the organization being purged.
+-- the organization being purged is set by purge_organization for the duration of its transaction only.
+CREATE OR REPLACE FUNCTION hard_delete_not_allowed()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.organization_id::TEXT = current_setting('camelhr.purging_organization_id', true) THEN
+        RETURN OLD;
+    END IF;
+
+    RAISE EXCEPTION '% operation on table % is not allowed: %', TG_OP, TG_TABLE_NAME, TG_NAME::TEXT;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER prevent_hard_delete_on_organizations ON organizations;
+CREATE TRIGGER prevent_hard_delete_on_organizations
+BEFORE DELETE ON organizations
+FOR EACH ROW
+EXECUTE FUNCTION hard_delete_not_allowed();
+
+DROP TRIGGER prevent_hard_delete_on_users ON users;
+CREATE TRIGGER prevent_hard_delete_on_users
+BEFORE DELETE ON users
+FOR EACH ROW
+EXECUTE FUNCTION hard_delete_not_allowed();
+
+-- create a function that hard deletes a soft deleted organization along with its tenant data
+-- and leaves a tombstone of the organization in place
+-- the tables listed below are deleted explicitly. a new table referencing the organizations or the users
+-- must use ON DELETE CASCADE for its foreign key. otherwise, the purge fails on the foreign key violation.
+CREATE OR REPLACE FUNCTION purge_organization(purged_organization_id INTEGER)
+RETURNS VOID AS $$
+BEGIN
+    -- lock the organization so that it can not be restored while being purged
+    PERFORM 1 FROM organizations
+    WHERE organization_id = purged_organization_id AND deleted_at IS NOT NULL
+    FOR UPDATE;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'organization % is not deleted', purged_organization_id;
+    END IF;
+
+    -- allow the hard delete of the rows of this organization until the end of the transaction
+    PERFORM set_config('camelhr.purging_organization_id', purged_organization_id::TEXT, true);
+
+    -- the data of the users
+    DELETE FROM password_reset_tokens
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM email_change_tokens
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM magic_link_tokens
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM mfa_recovery_codes
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM user_mfa
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM api_tokens
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    DELETE FROM password_histories
+    WHERE user_id IN (SELECT user_id FROM users WHERE organization_id = purged_organization_id);
+
+    -- the data of the organization
+    DELETE FROM impersonation_logs WHERE organization_id = purged_organization_id;
+    DELETE FROM ownership_transfers WHERE organization_id = purged_organization_id;
+    DELETE FROM invitations WHERE organization_id = purged_organization_id;
+    DELETE FROM password_policies WHERE organization_id = purged_organization_id;
+    DELETE FROM sso_configs WHERE organization_id = purged_organization_id;
+    DELETE FROM users WHERE organization_id = purged_organization_id;
+    DELETE FROM roles WHERE organization_id = purged_organization_id;
+
+    INSERT INTO organization_tombstones (organization_id, subdomain, deleted_at)
+    SELECT organization_id, subdomain, deleted_at
+    FROM organizations
+    WHERE organization_id = purged_organization_id;
+
+    DELETE FROM organizations WHERE organization_id = purged_organization_id;
+
+    PERFORM set_config('camelhr.purging_organization_id', '', true);
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS purge_organization(INTEGER);
+
+DROP TRIGGER IF EXISTS prevent_hard_delete_on_users ON users;
+CREATE TRIGGER prevent_hard_delete_on_users
+BEFORE DELETE ON users
+FOR EACH ROW
+EXECUTE FUNCTION operation_not_allowed();
+
+DROP TRIGGER IF EXISTS prevent_hard_delete_on_organizations ON organizations;
+CREATE TRIGGER prevent_hard_delete_on_organizations
+BEFORE DELETE ON organizations
+FOR EACH ROW
+EXECUTE FUNCTION operation_not_allowed();
+
+DROP FUNCTION IF EXISTS hard_delete_not_allowed();
+
+-- the audit logs of the purged organizations are left unchecked
+ALTER TABLE admin_audit_logs ADD CONSTRAINT admin_audit_logs_organization_id_fkey
+FOREIGN KEY (organization_id) REFERENCES organizations(organization_id) NOT VALID;
+
+DROP TABLE IF EXISTS organization_tombstones;
+-- +goose StatementEnd
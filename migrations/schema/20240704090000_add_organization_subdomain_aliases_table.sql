@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the former subdomains of the organizations.
+-- the requests to a former subdomain are redirected to the current subdomain of the organization
+-- and the former subdomain can not be used by another organization until the alias expires.
+CREATE TABLE organization_subdomain_aliases (
+    subdomain_alias_id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    subdomain VARCHAR(30) NOT NULL UNIQUE CHECK (subdomain <> ''),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    -- the aliases are released along with the subdomain when the organization is purged
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id) ON DELETE CASCADE
+);
+
+-- create indexes
+CREATE INDEX idx_organization_subdomain_aliases_organization_id ON organization_subdomain_aliases(organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS organization_subdomain_aliases;
+-- +goose StatementEnd
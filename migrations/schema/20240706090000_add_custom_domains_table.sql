@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the custom domain of the organization. the requests to a custom domain are served for the organization
+-- once the ownership of the domain is verified using the dns txt record of the verification token
+CREATE TABLE custom_domains (
+    organization_id INTEGER PRIMARY KEY,
+    domain VARCHAR(253) NOT NULL UNIQUE CHECK (domain <> ''),
+    verification_token TEXT NOT NULL CHECK (verification_token <> ''),
+    verified_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    -- the custom domain is released along with the subdomain when the organization is purged
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS custom_domains;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the settings of the organization used by the hr features.
+-- the default settings apply to the organizations without a row
+CREATE TABLE organization_settings (
+    organization_id INTEGER PRIMARY KEY,
+    time_zone VARCHAR(64) NOT NULL CHECK (time_zone <> ''),
+    locale VARCHAR(35) NOT NULL CHECK (locale <> ''),
+    currency CHAR(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    date_format VARCHAR(10) NOT NULL CHECK (date_format <> ''),
+    fiscal_year_start_month SMALLINT NOT NULL DEFAULT 1 CHECK (fiscal_year_start_month BETWEEN 1 AND 12),
+    -- the comma separated lowercase names of the days of the week
+    working_days TEXT NOT NULL CHECK (working_days <> ''),
+    -- the working hours are kept in the 24-hour HH:MM format so that they are ordered as strings
+    work_day_start CHAR(5) NOT NULL CHECK (work_day_start ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    work_day_end CHAR(5) NOT NULL CHECK (work_day_end ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC'),
+    CHECK (work_day_end > work_day_start),
+    -- the settings are removed along with the organization when it is purged
+    FOREIGN KEY (organization_id) REFERENCES organizations(organization_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS organization_settings;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the subdomains are case insensitive and kept in lowercase so that e.g. Acme and acme can not both exist.
+-- the migration fails on the subdomains differing in case only. one of them must be changed beforehand
+UPDATE organizations SET subdomain = LOWER(subdomain) WHERE subdomain <> LOWER(subdomain);
+UPDATE organization_subdomain_aliases SET subdomain = LOWER(subdomain) WHERE subdomain <> LOWER(subdomain);
+UPDATE organization_tombstones SET subdomain = LOWER(subdomain) WHERE subdomain <> LOWER(subdomain);
+
+ALTER TABLE organizations ADD CONSTRAINT organizations_subdomain_lowercase
+CHECK (subdomain = LOWER(subdomain));
+
+ALTER TABLE organization_subdomain_aliases ADD CONSTRAINT organization_subdomain_aliases_subdomain_lowercase
+CHECK (subdomain = LOWER(subdomain));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- the lowercased subdomains are kept
+ALTER TABLE organization_subdomain_aliases DROP CONSTRAINT IF EXISTS organization_subdomain_aliases_subdomain_lowercase;
+
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_subdomain_lowercase;
+-- +goose StatementEnd